@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the cache shared by the GitHub responses, the embeddings and the generated artifacts",
+	Long: `Manage the cache directory of a server or rag build started with --cache-dir.
+The content is stored once by its SHA-256 hash and referenced by the entries of the namespaces github, embeddings and artifacts.`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:     "stats",
+	Short:   "Report the entries and the size of the cache by namespace",
+	Example: `  opentelemetry-mcp-server cache stats --cache-dir ~/.cache/otel-mcp`,
+	RunE:    runCacheStats,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the entries of a namespace or of the whole cache",
+	Long: `Remove the entries of a namespace or of the whole cache and the content no other entry references.
+A running server drops its entries of the removed content on their next lookup.`,
+	Example: `  opentelemetry-mcp-server cache clear --cache-dir ~/.cache/otel-mcp --namespace github`,
+	RunE:    runCacheClear,
+}
+
+func init() {
+	cacheStatsCmd.Flags().Bool("json", false, "Print the statistics as JSON")
+	cacheClearCmd.Flags().String("namespace", "", "Namespace to clear: github, embeddings or artifacts, the whole cache is cleared if empty")
+	cacheCmd.AddCommand(cacheStatsCmd, cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// openCacheDir opens the cache of the --cache-dir directory, the cache commands have no in-memory cache to manage
+func openCacheDir(cmd *cobra.Command) (*cache.Cache, error) {
+	if dir, _ := cmd.Flags().GetString("cache-dir"); dir == "" {
+		return nil, errors.New("--cache-dir is required")
+	}
+	return newCache(cmd)
+}
+
+func runCacheStats(cmd *cobra.Command, _ []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	contentCache, err := openCacheDir(cmd)
+	if err != nil {
+		return err
+	}
+	stats := contentCache.Stats()
+	out := cmd.OutOrStdout()
+	if asJSON {
+		statsJSON, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal cache statistics: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(statsJSON))
+		return err
+	}
+	fmt.Fprintf(out, "%s: %d entries, %d blobs, %d of %d bytes\n", stats.Dir, stats.Entries, stats.Blobs, stats.Size, stats.MaxSize)
+	for _, namespace := range stats.Namespaces {
+		fmt.Fprintf(out, "  %s: %d entries, %d bytes\n", namespace.Namespace, namespace.Entries, namespace.Size)
+	}
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, _ []string) error {
+	namespace, _ := cmd.Flags().GetString("namespace")
+	switch namespace {
+	case "", cache.NamespaceGitHub, cache.NamespaceEmbeddings, cache.NamespaceArtifacts:
+	default:
+		return fmt.Errorf("unknown namespace %q, it can be %s, %s or %s", namespace, cache.NamespaceGitHub, cache.NamespaceEmbeddings, cache.NamespaceArtifacts)
+	}
+	contentCache, err := openCacheDir(cmd)
+	if err != nil {
+		return err
+	}
+	removed, err := contentCache.Clear(namespace)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "removed %d entries from %s\n", removed, contentCache.Dir())
+	return err
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var exportBundleCmd = &cobra.Command{
+	Use:   "export-bundle",
+	Short: "Export all schemas, READMEs and defaults of a version as a single JSON document",
+	Long: `Export the schema bundle of a version for offline tooling: the full configuration JSON Schema and every component
+with its manifest entry, JSON Schema, README and field defaults. The layout is described in the README.`,
+	RunE: runExportBundle,
+}
+
+func init() {
+	exportBundleCmd.Flags().String("version", "", "OpenTelemetry Collector version e.g. 0.138.0, defaults to the latest version")
+	exportBundleCmd.Flags().StringP("output", "o", "", "Output file, defaults to stdout")
+	rootCmd.AddCommand(exportBundleCmd)
+}
+
+func runExportBundle(cmd *cobra.Command, _ []string) error {
+	version, _ := cmd.Flags().GetString("version")
+	output, _ := cmd.Flags().GetString("output")
+
+	schemaManager, err := newSchemaManager(cmd)
+	if err != nil {
+		return err
+	}
+	if version == "" {
+		latestVersion, err := schemaManager.GetLatestVersion()
+		if err != nil {
+			return err
+		}
+		version = latestVersion
+	}
+
+	bundle, err := schemaManager.GetSchemaBundle(version)
+	if err != nil {
+		return err
+	}
+	bundleJSON, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema bundle: %w", err)
+	}
+	return writeExport(cmd, output, append(bundleJSON, '\n'))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+var exportSchemaCmd = &cobra.Command{
+	Use:   "export-schema",
+	Short: "Export a JSON Schema for YAML language servers to enable editor autocomplete",
+	Long: `Export a component or a full collector configuration JSON Schema in the layout expected by YAML language servers.
+Reference the schema in the collector configuration with:
+  # yaml-language-server: $schema=./otelcol.schema.json
+
+With --format cue a component schema is exported as a CUE definition for validating configurations with:
+  cue vet -d '#OtlpReceiver' otlp.cue otlp.yaml`,
+	RunE: runExportSchema,
+}
+
+func init() {
+	exportSchemaCmd.Flags().String("version", "", "OpenTelemetry Collector version e.g. 0.138.0, defaults to the latest version")
+	exportSchemaCmd.Flags().String("kind", "", "Component kind e.g. receiver, exports the full configuration schema if not set")
+	exportSchemaCmd.Flags().String("name", "", "Component name e.g. otlp")
+	exportSchemaCmd.Flags().StringP("output", "o", "", "Output file, defaults to stdout")
+	exportSchemaCmd.Flags().String("format", collectorschema.SchemaFormatJSON, "Format of the schema: json or cue, the cue format requires kind and name")
+	rootCmd.AddCommand(exportSchemaCmd)
+}
+
+func runExportSchema(cmd *cobra.Command, _ []string) error {
+	version, _ := cmd.Flags().GetString("version")
+	kind, _ := cmd.Flags().GetString("kind")
+	name, _ := cmd.Flags().GetString("name")
+	output, _ := cmd.Flags().GetString("output")
+	format, _ := cmd.Flags().GetString("format")
+	if (kind == "") != (name == "") {
+		return fmt.Errorf("kind and name have to be provided together")
+	}
+	if format != collectorschema.SchemaFormatJSON && format != collectorschema.SchemaFormatCUE {
+		return fmt.Errorf("unsupported format %q, must be json or cue", format)
+	}
+	if format == collectorschema.SchemaFormatCUE && kind == "" {
+		return fmt.Errorf("the cue format requires kind and name")
+	}
+
+	schemaManager, err := newSchemaManager(cmd)
+	if err != nil {
+		return err
+	}
+	if version == "" {
+		latestVersion, err := schemaManager.GetLatestVersion()
+		if err != nil {
+			return err
+		}
+		version = latestVersion
+	}
+
+	if format == collectorschema.SchemaFormatCUE {
+		componentType, err := collectorschema.ParseComponentType(kind)
+		if err != nil {
+			return err
+		}
+		cue, err := schemaManager.GetComponentCUE(componentType, name, version)
+		if err != nil {
+			return err
+		}
+		return writeExport(cmd, output, []byte(cue))
+	}
+
+	var schema map[string]interface{}
+	if kind == "" {
+		schema, err = schemaManager.GetEditorConfigSchema(version)
+	} else {
+		componentType, parseErr := collectorschema.ParseComponentType(kind)
+		if parseErr != nil {
+			return parseErr
+		}
+		schema, err = schemaManager.GetEditorSchema(componentType, name, version)
+	}
+	if err != nil {
+		return err
+	}
+
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	return writeExport(cmd, output, append(schemaJSON, '\n'))
+}
+
+// writeExport writes an exported schema to the output file or to stdout if it is empty
+func writeExport(cmd *cobra.Command, output string, data []byte) error {
+	if output == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	return os.WriteFile(output, data, 0o644)
+}
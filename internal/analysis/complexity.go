@@ -0,0 +1,218 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const (
+	// maxProcessorsPerPipeline is the processor chain length above which a refactor is suggested
+	maxProcessorsPerPipeline = 8
+	// maxConfigDepth is the component config nesting depth above which a refactor is suggested
+	maxConfigDepth = 6
+	// minDuplicatedBlockKeys is the minimum size of a map to be reported as a duplicated block
+	minDuplicatedBlockKeys = 2
+)
+
+// ComplexityReport contains size and complexity metrics of a collector configuration
+type ComplexityReport struct {
+	Pipelines             int               `json:"pipelines"`
+	PipelinesPerSignal    map[string]int    `json:"pipelinesPerSignal"`
+	Components            map[string]int    `json:"components"`
+	ProcessorsPerPipeline map[string]int    `json:"processorsPerPipeline"`
+	MaxProcessors         int               `json:"maxProcessors"`
+	MaxConfigDepth        int               `json:"maxConfigDepth"`
+	DeepestComponent      string            `json:"deepestComponent,omitempty"`
+	DuplicatedBlocks      []DuplicatedBlock `json:"duplicatedBlocks,omitempty"`
+	SharedProcessorChains []SharedChain     `json:"sharedProcessorChains,omitempty"`
+	Suggestions           []string          `json:"suggestions,omitempty"`
+}
+
+// DuplicatedBlock is a configuration block repeated in several places
+type DuplicatedBlock struct {
+	Paths []string `json:"paths"`
+	Keys  []string `json:"keys"`
+}
+
+// SharedChain is a processor chain repeated in several pipelines of the same signal
+type SharedChain struct {
+	Signal     string   `json:"signal"`
+	Processors []string `json:"processors"`
+	Pipelines  []string `json:"pipelines"`
+}
+
+// Complexity computes complexity metrics of the configuration and suggests refactors
+func Complexity(config *collectorconfig.Config) *ComplexityReport {
+	report := &ComplexityReport{
+		Pipelines:          len(config.Service.Pipelines),
+		PipelinesPerSignal: make(map[string]int),
+		Components: map[string]int{
+			"receivers":  len(config.Receivers),
+			"processors": len(config.Processors),
+			"exporters":  len(config.Exporters),
+			"connectors": len(config.Connectors),
+			"extensions": len(config.Extensions),
+		},
+		ProcessorsPerPipeline: make(map[string]int),
+	}
+
+	chains := make(map[string]*SharedChain)
+	for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+		pipeline := config.Service.Pipelines[pipelineID]
+		signal := collectorconfig.Signal(pipelineID)
+		report.PipelinesPerSignal[signal]++
+		report.ProcessorsPerPipeline[pipelineID] = len(pipeline.Processors)
+		if len(pipeline.Processors) > report.MaxProcessors {
+			report.MaxProcessors = len(pipeline.Processors)
+		}
+		if len(pipeline.Processors) < 2 {
+			continue
+		}
+		key := signal + ":" + strings.Join(pipeline.Processors, ",")
+		if chains[key] == nil {
+			chains[key] = &SharedChain{Signal: signal, Processors: pipeline.Processors}
+		}
+		chains[key].Pipelines = append(chains[key].Pipelines, pipelineID)
+	}
+	for _, key := range slices.Sorted(maps.Keys(chains)) {
+		if chain := chains[key]; len(chain.Pipelines) > 1 {
+			report.SharedProcessorChains = append(report.SharedProcessorChains, *chain)
+		}
+	}
+
+	blocks := make(map[string]*DuplicatedBlock)
+	for _, section := range componentSections(config) {
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			path := section.name + "::" + id
+			depth := collectBlocks(path, section.components[id], blocks, true)
+			if depth > report.MaxConfigDepth {
+				report.MaxConfigDepth = depth
+				report.DeepestComponent = path
+			}
+		}
+	}
+	for _, fingerprint := range slices.Sorted(maps.Keys(blocks)) {
+		if block := blocks[fingerprint]; len(block.Paths) > 1 {
+			report.DuplicatedBlocks = append(report.DuplicatedBlocks, *block)
+		}
+	}
+	report.DuplicatedBlocks = outermostBlocks(report.DuplicatedBlocks)
+
+	report.Suggestions = suggestions(report)
+	return report
+}
+
+func suggestions(report *ComplexityReport) []string {
+	var suggestions []string
+	for _, block := range report.DuplicatedBlocks {
+		suggestions = append(suggestions, fmt.Sprintf("%s have identical configuration, define it once with a YAML anchor (&name) and reference it with an alias (*name)", strings.Join(block.Paths, ", ")))
+	}
+	for _, chain := range report.SharedProcessorChains {
+		suggestions = append(suggestions, fmt.Sprintf("pipelines %s repeat the processor chain [%s], move it to a single %s pipeline fed by a forward connector or merge the pipelines receivers", strings.Join(chain.Pipelines, ", "), strings.Join(chain.Processors, ", "), chain.Signal))
+	}
+	for _, pipelineID := range slices.Sorted(maps.Keys(report.ProcessorsPerPipeline)) {
+		if count := report.ProcessorsPerPipeline[pipelineID]; count > maxProcessorsPerPipeline {
+			suggestions = append(suggestions, fmt.Sprintf("pipeline %s has %d processors, consider combining transform and filter statements into fewer processors", pipelineID, count))
+		}
+	}
+	if report.MaxConfigDepth > maxConfigDepth {
+		suggestions = append(suggestions, fmt.Sprintf("%s is nested %d levels deep, consider splitting it into separate configuration files merged with --config", report.DeepestComponent, report.MaxConfigDepth))
+	}
+	return suggestions
+}
+
+type componentSection struct {
+	name       string
+	components map[string]interface{}
+}
+
+func componentSections(config *collectorconfig.Config) []componentSection {
+	return []componentSection{
+		{name: "extensions", components: config.Extensions},
+		{name: "receivers", components: config.Receivers},
+		{name: "processors", components: config.Processors},
+		{name: "exporters", components: config.Exporters},
+		{name: "connectors", components: config.Connectors},
+	}
+}
+
+// collectBlocks records the fingerprints of the maps in the value and returns its nesting depth
+func collectBlocks(path string, value interface{}, blocks map[string]*DuplicatedBlock, component bool) int {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		maxDepth := 0
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if depth := collectBlocks(path+"::"+key, v[key], blocks, false); depth > maxDepth {
+				maxDepth = depth
+			}
+		}
+		// Whole component configs are compared by type so e.g. otlp/a and otlp/b are reported together
+		if len(v) >= minDuplicatedBlockKeys || (component && len(v) > 0) {
+			fingerprint, err := json.Marshal(v)
+			if err == nil {
+				key := string(fingerprint)
+				if component {
+					key = "component:" + collectorconfig.ComponentType(lastSegment(path)) + ":" + key
+				}
+				if blocks[key] == nil {
+					blocks[key] = &DuplicatedBlock{Keys: slices.Sorted(maps.Keys(v))}
+				}
+				blocks[key].Paths = append(blocks[key].Paths, path)
+			}
+		}
+		return maxDepth + 1
+	case []interface{}:
+		maxDepth := 0
+		for i, item := range v {
+			if depth := collectBlocks(fmt.Sprintf("%s[%d]", path, i), item, blocks, false); depth > maxDepth {
+				maxDepth = depth
+			}
+		}
+		return maxDepth + 1
+	}
+	return 0
+}
+
+// outermostBlocks drops duplicated blocks nested in another reported duplicated block
+func outermostBlocks(blocks []DuplicatedBlock) []DuplicatedBlock {
+	var result []DuplicatedBlock
+	for _, block := range blocks {
+		nested := false
+		for _, other := range blocks {
+			if isNestedIn(block.Paths, other.Paths) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			result = append(result, block)
+		}
+	}
+	return result
+}
+
+// isNestedIn returns true if every path is below one of the parent paths
+func isNestedIn(paths, parents []string) bool {
+	for _, path := range paths {
+		found := false
+		for _, parent := range parents {
+			if strings.HasPrefix(path, parent+"::") || strings.HasPrefix(path, parent+"[") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func lastSegment(path string) string {
+	return path[strings.LastIndex(path, "::")+2:]
+}
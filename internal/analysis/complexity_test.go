@@ -0,0 +1,69 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestComplexity(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+  jaeger:
+    protocols:
+      grpc:
+processors:
+  batch:
+  memory_limiter:
+    check_interval: 1s
+    limit_mib: 512
+exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls:
+      insecure: true
+      ca_file: /ca.pem
+  otlp/b:
+    endpoint: b:4317
+    tls:
+      insecure: true
+      ca_file: /ca.pem
+  debug:
+service:
+  pipelines:
+    traces/a:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [otlp/a]
+    traces/b:
+      receivers: [jaeger]
+      processors: [memory_limiter, batch]
+      exporters: [otlp/b]
+    metrics:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	report := Complexity(config)
+	assert.Equal(t, 3, report.Pipelines)
+	assert.Equal(t, map[string]int{"traces": 2, "metrics": 1}, report.PipelinesPerSignal)
+	assert.Equal(t, 3, report.Components["exporters"])
+	assert.Equal(t, 2, report.MaxProcessors)
+	assert.Equal(t, 3, report.MaxConfigDepth)
+	assert.Equal(t, "receivers::otlp", report.DeepestComponent)
+
+	require.Len(t, report.DuplicatedBlocks, 1)
+	assert.Equal(t, []string{"exporters::otlp/a::tls", "exporters::otlp/b::tls"}, report.DuplicatedBlocks[0].Paths)
+	require.Len(t, report.SharedProcessorChains, 1)
+	assert.Equal(t, []string{"traces/a", "traces/b"}, report.SharedProcessorChains[0].Pipelines)
+	assert.Len(t, report.Suggestions, 2)
+}
@@ -0,0 +1,271 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// Finding types
+const (
+	FindingDuplicateComponent  = "duplicate-component"
+	FindingConflictingListener = "conflicting-listener"
+	FindingMemoryLimiter       = "conflicting-memory-limiter"
+)
+
+// Finding is a duplicate or conflicting part of the configuration
+type Finding struct {
+	Type     string                   `json:"type"`
+	Severity collectorconfig.Severity `json:"severity"`
+	Paths    []string                 `json:"paths"`
+	Message  string                   `json:"message"`
+	// Suggestion is the corrected value of the first path, empty if the finding has none
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// defaultListeners are the endpoints components listen on when the endpoint is not configured
+var defaultListeners = map[string]map[string]string{
+	"receivers": {
+		"protocols::grpc": "0.0.0.0:4317",
+		"protocols::http": "0.0.0.0:4318",
+	},
+	"extensions": {
+		"health_check": "0.0.0.0:13133",
+		"pprof":        "localhost:1777",
+		"zpages":       "localhost:55679",
+	},
+}
+
+// listenerKeys are the configuration keys holding a listen address
+var listenerKeys = []string{"endpoint", "listen_address"}
+
+// scraperReceivers are receivers whose endpoint is a scrape target and not a listen address
+var scraperReceivers = []string{
+	"apache", "elasticsearch", "haproxy", "httpcheck", "jmx", "kafkametrics", "memcached", "mongodb", "mysql",
+	"nginx", "postgresql", "prometheus", "rabbitmq", "redis", "riak", "sqlserver", "zookeeper", "kafka", "k8s_cluster",
+	"kubeletstats", "docker_stats", "sqlquery", "snmp", "vcenter", "couchdb", "aerospike", "bigip", "oracledb",
+}
+
+type listener struct {
+	path    string
+	address string
+}
+
+// Conflicts returns duplicate component definitions, listeners conflicting on the same port and
+// memory_limiter processors with different budgets
+func Conflicts(config *collectorconfig.Config) []Finding {
+	var findings []Finding
+	findings = append(findings, duplicateComponents(config)...)
+	findings = append(findings, conflictingListeners(config)...)
+	findings = append(findings, conflictingMemoryLimiters(config)...)
+	return findings
+}
+
+func duplicateComponents(config *collectorconfig.Config) []Finding {
+	var findings []Finding
+	for _, section := range componentSections(config) {
+		groups := make(map[string][]string)
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			fingerprint, err := json.Marshal(section.components[id])
+			if err != nil {
+				continue
+			}
+			key := collectorconfig.ComponentType(id) + ":" + string(fingerprint)
+			groups[key] = append(groups[key], section.name+"::"+id)
+		}
+		for _, key := range slices.Sorted(maps.Keys(groups)) {
+			if paths := groups[key]; len(paths) > 1 {
+				findings = append(findings, Finding{
+					Type:     FindingDuplicateComponent,
+					Severity: collectorconfig.SeverityWarning,
+					Paths:    paths,
+					Message:  fmt.Sprintf("%s are identical except the name, use a single component", strings.Join(paths, ", ")),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func conflictingListeners(config *collectorconfig.Config) []Finding {
+	var listeners []listener
+	for _, id := range startedReceivers(config) {
+		if slices.Contains(scraperReceivers, collectorconfig.ComponentType(id)) {
+			continue
+		}
+		listeners = append(listeners, componentListeners("receivers", id, config.Receivers[id])...)
+	}
+	for _, id := range config.Service.Extensions {
+		if componentConfig, exists := config.Extensions[id]; exists {
+			listeners = append(listeners, componentListeners("extensions", id, componentConfig)...)
+		}
+	}
+
+	byPort := make(map[string][]listener)
+	for _, l := range listeners {
+		_, port, err := net.SplitHostPort(l.address)
+		if err != nil || port == "" || port == "0" {
+			continue
+		}
+		byPort[port] = append(byPort[port], l)
+	}
+
+	var findings []Finding
+	for _, port := range slices.Sorted(maps.Keys(byPort)) {
+		candidates := byPort[port]
+		for i := 0; i < len(candidates); i++ {
+			conflicting := []listener{candidates[i]}
+			for j := i + 1; j < len(candidates); j++ {
+				if hostsOverlap(candidates[i].address, candidates[j].address) {
+					conflicting = append(conflicting, candidates[j])
+					candidates = append(candidates[:j], candidates[j+1:]...)
+					j--
+				}
+			}
+			if len(conflicting) < 2 {
+				continue
+			}
+			var paths, addresses []string
+			for _, l := range conflicting {
+				paths = append(paths, l.path)
+				addresses = append(addresses, l.address)
+			}
+			findings = append(findings, Finding{
+				Type:     FindingConflictingListener,
+				Severity: collectorconfig.SeverityError,
+				Paths:    paths,
+				Message:  fmt.Sprintf("%s listen on the same port %s (%s), the collector fails to start with address already in use", strings.Join(paths, ", "), port, strings.Join(addresses, ", ")),
+			})
+		}
+	}
+	return findings
+}
+
+// componentListeners returns the configured or default listen addresses of a component
+func componentListeners(section, id string, componentConfig interface{}) []listener {
+	var listeners []listener
+	configured := make(map[string]bool)
+	var walk func(path string, value interface{})
+	walk = func(path string, value interface{}) {
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			if address, ok := values[key].(string); ok && slices.Contains(listenerKeys, key) {
+				listeners = append(listeners, listener{path: path + "::" + key, address: address})
+				configured[path] = true
+				continue
+			}
+			walk(path+"::"+key, values[key])
+		}
+	}
+	root := section + "::" + id
+	walk(root, componentConfig)
+
+	componentType := collectorconfig.ComponentType(id)
+	for _, suffix := range slices.Sorted(maps.Keys(defaultListeners[section])) {
+		address := defaultListeners[section][suffix]
+		switch {
+		case section == "extensions" && suffix == componentType && !configured[root]:
+			listeners = append(listeners, listener{path: root + " (default endpoint)", address: address})
+		case section == "receivers" && componentType == "otlp" && !configured[root+"::"+suffix] && hasPath(componentConfig, suffix):
+			listeners = append(listeners, listener{path: root + "::" + suffix + " (default endpoint)", address: address})
+		}
+	}
+	return listeners
+}
+
+func conflictingMemoryLimiters(config *collectorconfig.Config) []Finding {
+	var paths []string
+	var budgets []map[string]interface{}
+	for _, id := range slices.Sorted(maps.Keys(config.Processors)) {
+		if collectorconfig.ComponentType(id) != "memory_limiter" || !isProcessorUsed(config, id) {
+			continue
+		}
+		budget := make(map[string]interface{})
+		if values, ok := config.Processors[id].(map[string]interface{}); ok {
+			for _, key := range []string{"limit_mib", "spike_limit_mib", "limit_percentage", "spike_limit_percentage"} {
+				if value, exists := values[key]; exists {
+					budget[key] = value
+				}
+			}
+		}
+		paths = append(paths, "processors::"+id)
+		budgets = append(budgets, budget)
+	}
+
+	for i := 1; i < len(budgets); i++ {
+		if !reflect.DeepEqual(budgets[0], budgets[i]) {
+			return []Finding{{
+				Type:     FindingMemoryLimiter,
+				Severity: collectorconfig.SeverityWarning,
+				Paths:    paths,
+				Message:  fmt.Sprintf("%s have different memory budgets, all of them measure the memory of the whole collector process so the lowest limit refuses data for every pipeline", strings.Join(paths, ", ")),
+			}}
+		}
+	}
+	return nil
+}
+
+// startedReceivers returns the receivers used in at least one pipeline
+func startedReceivers(config *collectorconfig.Config) []string {
+	var receivers []string
+	for _, id := range slices.Sorted(maps.Keys(config.Receivers)) {
+		for _, pipeline := range config.Service.Pipelines {
+			if slices.Contains(pipeline.Receivers, id) {
+				receivers = append(receivers, id)
+				break
+			}
+		}
+	}
+	return receivers
+}
+
+func isProcessorUsed(config *collectorconfig.Config, id string) bool {
+	for _, pipeline := range config.Service.Pipelines {
+		if slices.Contains(pipeline.Processors, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostsOverlap returns true if both addresses bind the same host or one of them binds all interfaces
+func hostsOverlap(a, b string) bool {
+	hostA, _, _ := net.SplitHostPort(a)
+	hostB, _, _ := net.SplitHostPort(b)
+	isWildcard := func(host string) bool {
+		return host == "" || host == "0.0.0.0" || host == "::"
+	}
+	if isWildcard(hostA) || isWildcard(hostB) {
+		return true
+	}
+	normalize := func(host string) string {
+		if host == "127.0.0.1" || host == "::1" {
+			return "localhost"
+		}
+		return host
+	}
+	return normalize(hostA) == normalize(hostB)
+}
+
+// hasPath returns true if the nested "a::b" path exists in the value
+func hasPath(value interface{}, path string) bool {
+	for _, key := range strings.Split(path, "::") {
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if value, ok = values[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
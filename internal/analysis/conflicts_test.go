@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestConflicts(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+extensions:
+  health_check:
+    endpoint: 0.0.0.0:4318
+receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+  otlp/internal:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+  redis:
+    endpoint: localhost:4317
+processors:
+  batch:
+  batch/2:
+  memory_limiter:
+    limit_mib: 512
+  memory_limiter/logs:
+    limit_mib: 1024
+exporters:
+  debug:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp, otlp/internal, redis]
+      processors: [memory_limiter, batch]
+      exporters: [debug]
+    logs:
+      receivers: [otlp]
+      processors: [memory_limiter/logs, batch/2]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	findings := Conflicts(config)
+	require.Len(t, findings, 4)
+
+	assert.Equal(t, FindingDuplicateComponent, findings[0].Type)
+	assert.Equal(t, []string{"processors::batch", "processors::batch/2"}, findings[0].Paths)
+
+	assert.Equal(t, FindingConflictingListener, findings[1].Type)
+	assert.Equal(t, []string{"receivers::otlp::protocols::grpc (default endpoint)", "receivers::otlp/internal::protocols::grpc::endpoint"}, findings[1].Paths)
+	assert.Equal(t, FindingConflictingListener, findings[2].Type)
+	assert.Equal(t, []string{"receivers::otlp::protocols::http (default endpoint)", "extensions::health_check::endpoint"}, findings[2].Paths)
+
+	assert.Equal(t, FindingMemoryLimiter, findings[3].Type)
+	assert.Equal(t, []string{"processors::memory_limiter", "processors::memory_limiter/logs"}, findings[3].Paths)
+}
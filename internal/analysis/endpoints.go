@@ -0,0 +1,231 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// FindingEndpointSyntax is the finding type of an endpoint not matching the format its component expects
+const FindingEndpointSyntax = "endpoint-syntax"
+
+// The OTLP ports
+const (
+	otlpGRPCPort = "4317"
+	otlpHTTPPort = "4318"
+)
+
+// listenerReceivers are the receivers whose endpoints are listen addresses, other receivers e.g. the scrapers connect
+// to their endpoint
+var listenerReceivers = []string{
+	"awsfirehose", "awsxray", "carbon", "cloudflare", "collectd", "datadog", "faro", "fluentforward", "github",
+	"influxdb", "jaeger", "libhoney", "loki", "opencensus", "otlp", "prometheusremotewrite", "sapm", "signalfx",
+	"skywalking", "splunk_hec", "statsd", "syslog", "tcplog", "udplog", "webhookevent", "zipkin",
+}
+
+// listenerExporters are the exporters serving their endpoint e.g. the prometheus scrape endpoint
+var listenerExporters = []string{"prometheus"}
+
+// grpcExporters are the exporters sending to a gRPC endpoint host:port
+var grpcExporters = []string{"otlp", "otlp_grpc"}
+
+// httpExporters are the exporters sending to an http URL, the keys are the settings holding URLs
+var httpExporters = map[string][]string{
+	"otlphttp":              {"endpoint", "traces_endpoint", "metrics_endpoint", "logs_endpoint", "profiles_endpoint"},
+	"otlp_http":             {"endpoint", "traces_endpoint", "metrics_endpoint", "logs_endpoint", "profiles_endpoint"},
+	"prometheusremotewrite": {"endpoint"},
+	"zipkin":                {"endpoint"},
+}
+
+// otlpHTTPPaths are the signal paths the otlphttp exporter appends to its endpoint
+var otlpHTTPPaths = []string{"/v1/traces", "/v1/metrics", "/v1/logs", "/v1development/profiles"}
+
+// Endpoints returns the endpoints that do not match the format their component expects with a corrected suggestion:
+// gRPC exporters send to host:port without a path, http exporters to a URL with a scheme and the listeners of the
+// receivers, extensions and exporters bind host:port without a scheme. Endpoints with environment variable references
+// are skipped.
+func Endpoints(config *collectorconfig.Config) []Finding {
+	var findings []Finding
+	for _, id := range slices.Sorted(maps.Keys(config.Receivers)) {
+		if !slices.Contains(listenerReceivers, collectorconfig.ComponentType(id)) {
+			continue
+		}
+		for _, l := range componentListeners("receivers", id, config.Receivers[id]) {
+			findings = append(findings, listenAddress(l, collectorconfig.ComponentType(id))...)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(config.Extensions)) {
+		if _, isListener := defaultListeners["extensions"][collectorconfig.ComponentType(id)]; !isListener {
+			continue
+		}
+		for _, l := range componentListeners("extensions", id, config.Extensions[id]) {
+			findings = append(findings, listenAddress(l, collectorconfig.ComponentType(id))...)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(config.Exporters)) {
+		exporterType := collectorconfig.ComponentType(id)
+		settings, _ := config.Exporters[id].(map[string]interface{})
+		path := "exporters::" + id
+		switch {
+		case slices.Contains(listenerExporters, exporterType):
+			for _, l := range componentListeners("exporters", id, settings) {
+				findings = append(findings, listenAddress(l, exporterType)...)
+			}
+		case slices.Contains(grpcExporters, exporterType):
+			if endpoint, ok := settings["endpoint"].(string); ok {
+				findings = append(findings, grpcEndpoint(path+"::endpoint", endpoint)...)
+			}
+		default:
+			for _, key := range httpExporters[exporterType] {
+				if endpoint, ok := settings[key].(string); ok {
+					findings = append(findings, httpEndpoint(path+"::"+key, endpoint, exporterType, key == "endpoint")...)
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// grpcEndpoint checks the endpoint of a gRPC exporter: host:port, an http or https scheme selects the transport security
+func grpcEndpoint(path, endpoint string) []Finding {
+	// dns:///host:port and unix:///path are gRPC name resolver targets
+	if skipEndpoint(endpoint) || strings.HasPrefix(endpoint, "dns:") || strings.HasPrefix(endpoint, "unix:") || strings.HasPrefix(endpoint, "passthrough:") {
+		return nil
+	}
+	scheme, hostPort, rest := splitEndpoint(endpoint)
+	suggestionScheme := ""
+	if scheme == "https" {
+		suggestionScheme = "https://"
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host, port = hostPort, ""
+	}
+	suggestedPort := port
+	if port == "" || port == otlpHTTPPort {
+		suggestedPort = otlpGRPCPort
+	}
+	suggestion := suggestionScheme + net.JoinHostPort(host, suggestedPort)
+
+	switch {
+	case scheme != "" && scheme != "http" && scheme != "https":
+		return endpointFinding(path, collectorconfig.SeverityError, fmt.Sprintf("gRPC endpoint %s has the scheme %s, gRPC endpoints are host:port, https://host:port enables TLS", endpoint, scheme), suggestion)
+	case rest != "":
+		return endpointFinding(path, collectorconfig.SeverityError, fmt.Sprintf("gRPC endpoint %s has the path %s, gRPC endpoints are host:port without a path, the otlphttp exporter sends to URL paths", endpoint, rest), suggestion)
+	case port == "":
+		return endpointFinding(path, collectorconfig.SeverityWarning, fmt.Sprintf("gRPC endpoint %s has no port, gRPC connects to port 443, the OTLP/gRPC port is %s", endpoint, otlpGRPCPort), suggestion)
+	case port == otlpHTTPPort:
+		return endpointFinding(path, collectorconfig.SeverityWarning, fmt.Sprintf("gRPC endpoint %s uses the OTLP/HTTP port %s, the OTLP/gRPC port is %s", endpoint, otlpHTTPPort, otlpGRPCPort), suggestion)
+	}
+	return nil
+}
+
+// httpEndpoint checks the URL of an http exporter, the otlphttp exporter appends the signal path to its endpoint
+func httpEndpoint(path, endpoint, exporterType string, appendsPath bool) []Finding {
+	if skipEndpoint(endpoint) {
+		return nil
+	}
+	scheme, hostPort, rest := splitEndpoint(endpoint)
+	isOTLP := exporterType == "otlphttp" || exporterType == "otlp_http"
+	switch {
+	case scheme != "http" && scheme != "https":
+		suggestedScheme := "http"
+		if strings.HasSuffix(hostPort, ":443") {
+			suggestedScheme = "https"
+		}
+		return endpointFinding(path, collectorconfig.SeverityError, fmt.Sprintf("http endpoint %s has no http or https scheme, the requests fail with unsupported protocol scheme", endpoint), suggestedScheme+"://"+hostPort+rest)
+	case isOTLP && appendsPath:
+		for _, signalPath := range otlpHTTPPaths {
+			if trimmed, ok := strings.CutSuffix(strings.TrimSuffix(rest, "/"), signalPath); ok {
+				return endpointFinding(path, collectorconfig.SeverityError, fmt.Sprintf("endpoint %s ends with %s, the exporter appends the signal path and sends to %s%s, set the URL of a single signal as the %s_endpoint", endpoint, signalPath, strings.TrimSuffix(endpoint, "/"), signalPath, signalName(signalPath)), scheme+"://"+hostPort+trimmed)
+			}
+		}
+	}
+	if host, port, err := net.SplitHostPort(hostPort); err == nil && isOTLP && port == otlpGRPCPort {
+		return endpointFinding(path, collectorconfig.SeverityWarning, fmt.Sprintf("http endpoint %s uses the OTLP/gRPC port %s, the OTLP/HTTP port is %s", endpoint, otlpGRPCPort, otlpHTTPPort), scheme+"://"+net.JoinHostPort(host, otlpHTTPPort)+rest)
+	}
+	return nil
+}
+
+// listenAddress checks a listen address: host:port without a scheme or a path
+func listenAddress(l listener, componentType string) []Finding {
+	if strings.HasSuffix(l.path, "(default endpoint)") || skipEndpoint(l.address) {
+		return nil
+	}
+	scheme, hostPort, rest := splitEndpoint(l.address)
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host, port = hostPort, defaultListenPort(l.path, componentType)
+	}
+	suggestion := ""
+	if port != "" {
+		suggestion = net.JoinHostPort(host, port)
+	}
+	switch {
+	case scheme != "":
+		return endpointFinding(l.path, collectorconfig.SeverityError, fmt.Sprintf("listen address %s has the scheme %s, the collector fails to start, listen addresses are host:port", l.address, scheme), suggestion)
+	case rest != "":
+		return endpointFinding(l.path, collectorconfig.SeverityError, fmt.Sprintf("listen address %s has the path %s, the collector fails to start, listen addresses are host:port", l.address, rest), suggestion)
+	case err != nil:
+		return endpointFinding(l.path, collectorconfig.SeverityError, fmt.Sprintf("listen address %s has no port, the collector fails to start, listen addresses are host:port", l.address), suggestion)
+	}
+	if number, err := strconv.Atoi(port); err != nil || number < 0 || number > 65535 {
+		return endpointFinding(l.path, collectorconfig.SeverityError, fmt.Sprintf("listen address %s has the invalid port %s, the collector fails to start", l.address, port), "")
+	}
+	return nil
+}
+
+// defaultListenPort returns the port a listener without a port is meant to use, empty if it is unknown
+func defaultListenPort(path, componentType string) string {
+	if componentType != "otlp" {
+		return ""
+	}
+	switch {
+	case strings.Contains(path, "::protocols::grpc::"):
+		return otlpGRPCPort
+	case strings.Contains(path, "::protocols::http::"):
+		return otlpHTTPPort
+	}
+	return ""
+}
+
+// splitEndpoint splits an endpoint into its scheme, host:port and the rest e.g. the path, the scheme is empty for
+// host:port
+func splitEndpoint(endpoint string) (string, string, string) {
+	scheme, rest, hasScheme := strings.Cut(endpoint, "://")
+	if !hasScheme {
+		scheme, rest = "", endpoint
+	}
+	if parsed, err := url.Parse("//" + rest); err == nil && parsed.Host != "" {
+		remainder := strings.TrimPrefix(rest, parsed.Host)
+		return scheme, parsed.Host, remainder
+	}
+	hostPort, remainder, hasPath := strings.Cut(rest, "/")
+	if hasPath {
+		remainder = "/" + remainder
+	}
+	return scheme, hostPort, remainder
+}
+
+// skipEndpoint returns true for endpoints that cannot be checked: empty or with environment variable references
+func skipEndpoint(endpoint string) bool {
+	return endpoint == "" || strings.Contains(endpoint, "${")
+}
+
+// signalName returns the signal of an OTLP/HTTP path e.g. traces for /v1/traces
+func signalName(signalPath string) string {
+	return signalPath[strings.LastIndex(signalPath, "/")+1:]
+}
+
+func endpointFinding(path string, severity collectorconfig.Severity, message, suggestion string) []Finding {
+	if suggestion != "" {
+		message += ", use " + suggestion
+	}
+	return []Finding{{Type: FindingEndpointSyntax, Severity: severity, Paths: []string{path}, Message: message, Suggestion: suggestion}}
+}
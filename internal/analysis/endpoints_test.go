@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const endpointsConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: http://0.0.0.0:4317
+      http:
+        endpoint: localhost
+  zipkin:
+    endpoint: 0.0.0.0:9411/api/v2/spans
+  redis:
+    endpoint: redis://cache:6379
+extensions:
+  health_check:
+    endpoint: 0.0.0.0:13133
+exporters:
+  otlp/path:
+    endpoint: https://backend:4317/v1/traces
+  otlp/port:
+    endpoint: backend:4318
+  otlp/noport:
+    endpoint: backend
+  otlp/ok:
+    endpoint: https://backend:4317
+  otlp/dns:
+    endpoint: dns:///backend:4317
+  otlp/env:
+    endpoint: ${env:OTLP_ENDPOINT}
+  otlphttp/scheme:
+    endpoint: backend:4318
+  otlphttp/signal:
+    endpoint: https://backend/v1/traces
+  otlphttp/grpcport:
+    endpoint: http://backend:4317
+  otlphttp/ok:
+    endpoint: https://backend/otlp
+    traces_endpoint: https://backend/v1/traces
+  prometheus:
+    endpoint: http://0.0.0.0:8889
+`
+
+func TestEndpoints(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(endpointsConfig))
+	require.NoError(t, err)
+
+	type finding struct {
+		Path       string
+		Severity   collectorconfig.Severity
+		Suggestion string
+	}
+	var findings []finding
+	for _, f := range Endpoints(config) {
+		assert.Equal(t, FindingEndpointSyntax, f.Type)
+		findings = append(findings, finding{Path: f.Paths[0], Severity: f.Severity, Suggestion: f.Suggestion})
+	}
+	assert.Equal(t, []finding{
+		{"receivers::otlp::protocols::grpc::endpoint", collectorconfig.SeverityError, "0.0.0.0:4317"},
+		{"receivers::otlp::protocols::http::endpoint", collectorconfig.SeverityError, "localhost:4318"},
+		{"receivers::zipkin::endpoint", collectorconfig.SeverityError, "0.0.0.0:9411"},
+		{"exporters::otlp/noport::endpoint", collectorconfig.SeverityWarning, "backend:4317"},
+		{"exporters::otlp/path::endpoint", collectorconfig.SeverityError, "https://backend:4317"},
+		{"exporters::otlp/port::endpoint", collectorconfig.SeverityWarning, "backend:4317"},
+		{"exporters::otlphttp/grpcport::endpoint", collectorconfig.SeverityWarning, "http://backend:4318"},
+		{"exporters::otlphttp/scheme::endpoint", collectorconfig.SeverityError, "http://backend:4318"},
+		{"exporters::otlphttp/signal::endpoint", collectorconfig.SeverityError, "https://backend"},
+		{"exporters::prometheus::endpoint", collectorconfig.SeverityError, "0.0.0.0:8889"},
+	}, findings)
+}
@@ -0,0 +1,228 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// destinationKeys are the configuration keys of exporters and scraping receivers holding the address of an external
+// endpoint the collector connects to
+var destinationKeys = []string{"endpoint", "endpoints", "traces_endpoint", "metrics_endpoint", "logs_endpoint", "url", "brokers", "targets"}
+
+// listeningExporters are exporters whose endpoint is a listen address scraped by the backend
+var listeningExporters = []string{"prometheus"}
+
+// Explanation is a structured explanation of a collector configuration
+type Explanation struct {
+	Pipelines  []PipelineExplanation  `json:"pipelines"`
+	Components []ComponentExplanation `json:"components"`
+	// Listeners are the addresses the collector listens on
+	Listeners []Endpoint `json:"listeners"`
+	// Destinations are the external endpoints the collector connects to: export destinations and scrape targets
+	Destinations []Endpoint `json:"destinations"`
+	// UnusedComponents are the defined components that are not part of a pipeline or the service extensions
+	UnusedComponents []string `json:"unusedComponents,omitempty"`
+}
+
+// PipelineExplanation describes the flow of a pipeline
+type PipelineExplanation struct {
+	ID         string   `json:"id"`
+	Signal     string   `json:"signal"`
+	Receivers  []string `json:"receivers"`
+	Processors []string `json:"processors"`
+	Exporters  []string `json:"exporters"`
+	// Flowing is true if the pipeline receives telemetry and delivers it to an exporter, possibly through connectors
+	Flowing   bool   `json:"flowing"`
+	Narrative string `json:"narrative"`
+}
+
+// ComponentExplanation describes a component of the configuration
+type ComponentExplanation struct {
+	// ID is the component as section::id e.g. processors::batch
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Pipelines   []string `json:"pipelines,omitempty"`
+}
+
+// Endpoint is an address in the configuration of a component
+type Endpoint struct {
+	Path    string `json:"path"`
+	Address string `json:"address"`
+}
+
+// Explain describes the configuration: the narrative of the flow of each pipeline, the components with their
+// description returned by describe and the endpoints the collector listens on and connects to
+func Explain(config *collectorconfig.Config, describe func(section, id string) string) *Explanation {
+	explanation := &Explanation{
+		Pipelines:    []PipelineExplanation{},
+		Components:   []ComponentExplanation{},
+		Listeners:    []Endpoint{},
+		Destinations: []Endpoint{},
+	}
+
+	pipelinesOf := make(map[string][]string)
+	flowing := flowingPipelines(config)
+	for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+		pipeline := config.Service.Pipelines[pipelineID]
+		for _, id := range pipeline.Receivers {
+			section := "receivers"
+			if _, isConnector := config.Connectors[id]; isConnector {
+				section = "connectors"
+			}
+			pipelinesOf[section+"::"+id] = appendUnique(pipelinesOf[section+"::"+id], pipelineID)
+		}
+		for _, id := range pipeline.Processors {
+			pipelinesOf["processors::"+id] = appendUnique(pipelinesOf["processors::"+id], pipelineID)
+		}
+		for _, id := range pipeline.Exporters {
+			section := "exporters"
+			if _, isConnector := config.Connectors[id]; isConnector {
+				section = "connectors"
+			}
+			pipelinesOf[section+"::"+id] = appendUnique(pipelinesOf[section+"::"+id], pipelineID)
+		}
+		explanation.Pipelines = append(explanation.Pipelines, PipelineExplanation{
+			ID:         pipelineID,
+			Signal:     collectorconfig.Signal(pipelineID),
+			Receivers:  nonNil(pipeline.Receivers),
+			Processors: nonNil(pipeline.Processors),
+			Exporters:  nonNil(pipeline.Exporters),
+			Flowing:    flowing[pipelineID],
+			Narrative:  pipelineNarrative(config, pipelineID, pipeline, flowing[pipelineID]),
+		})
+	}
+
+	for _, section := range componentSections(config) {
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			component := section.name + "::" + id
+			explanation.Components = append(explanation.Components, ComponentExplanation{
+				ID:          component,
+				Description: describe(section.name, id),
+				Pipelines:   pipelinesOf[component],
+			})
+			used := len(pipelinesOf[component]) > 0 || (section.name == "extensions" && slices.Contains(config.Service.Extensions, id))
+			if !used {
+				explanation.UnusedComponents = append(explanation.UnusedComponents, component)
+				continue
+			}
+
+			componentType := collectorconfig.ComponentType(id)
+			switch {
+			case section.name == "exporters" && !slices.Contains(listeningExporters, componentType),
+				section.name == "receivers" && slices.Contains(scraperReceivers, componentType):
+				explanation.Destinations = append(explanation.Destinations, destinations(component, section.components[id])...)
+			case section.name == "receivers", section.name == "extensions", section.name == "exporters":
+				for _, l := range componentListeners(section.name, id, section.components[id]) {
+					explanation.Listeners = append(explanation.Listeners, Endpoint{Path: l.path, Address: l.address})
+				}
+			}
+		}
+	}
+	return explanation
+}
+
+// pipelineNarrative describes in a sentence what data flows where in the pipeline
+func pipelineNarrative(config *collectorconfig.Config, pipelineID string, pipeline *collectorconfig.Pipeline, flowing bool) string {
+	signal := collectorconfig.Signal(pipelineID)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "The %s pipeline %s receives %s from %s", signal, pipelineID, signal, describeIDs(config, pipeline.Receivers, "receiver"))
+	if len(pipeline.Processors) > 0 {
+		fmt.Fprintf(&sb, ", processes them with %s in this order", strings.Join(pipeline.Processors, ", then "))
+	} else {
+		sb.WriteString(" without processing them")
+	}
+	fmt.Fprintf(&sb, " and exports them to %s.", describeIDs(config, pipeline.Exporters, "exporter"))
+	if !flowing {
+		sb.WriteString(" No telemetry flows through it: it has no receiver fed with telemetry or no path to an exporter.")
+	}
+	return sb.String()
+}
+
+// describeIDs lists the components of a pipeline, connectors are named with the pipelines they link
+func describeIDs(config *collectorconfig.Config, ids []string, kind string) string {
+	if len(ids) == 0 {
+		return "no " + kind
+	}
+	described := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, isConnector := config.Connectors[id]; !isConnector {
+			described = append(described, id)
+			continue
+		}
+		// The linked pipelines use the connector on the opposite side
+		var linked []string
+		for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+			pipeline := config.Service.Pipelines[pipelineID]
+			if (kind == "receiver" && slices.Contains(pipeline.Exporters, id)) || (kind == "exporter" && slices.Contains(pipeline.Receivers, id)) {
+				linked = append(linked, pipelineID)
+			}
+		}
+		direction := "to"
+		if kind == "receiver" {
+			direction = "from"
+		}
+		described = append(described, fmt.Sprintf("the %s connector (%s %s)", id, direction, strings.Join(linked, ", ")))
+	}
+	return strings.Join(described, ", ")
+}
+
+// destinations returns the external endpoints in the configuration of an exporter or a scraping receiver
+func destinations(component string, componentConfig interface{}) []Endpoint {
+	var endpoints []Endpoint
+	var walk func(path string, value interface{})
+	walk = func(path string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				if slices.Contains(destinationKeys, key) {
+					for _, address := range addresses(v[key]) {
+						endpoints = append(endpoints, Endpoint{Path: path + "::" + key, Address: address})
+					}
+					continue
+				}
+				walk(path+"::"+key, v[key])
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(path, item)
+			}
+		}
+	}
+	walk(component, componentConfig)
+	return endpoints
+}
+
+// addresses returns the string addresses of a single address or a list of addresses
+func addresses(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			if address, ok := item.(string); ok {
+				result = append(result, address)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestExplain(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+extensions:
+  health_check:
+  pprof:
+receivers:
+  otlp:
+    protocols:
+      grpc:
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: app
+          static_configs:
+            - targets: [app:8080, app:8081]
+processors:
+  batch:
+exporters:
+  otlp/tempo:
+    endpoint: tempo:4317
+  prometheus:
+    endpoint: 0.0.0.0:8889
+  kafka:
+    brokers: [kafka-1:9092]
+connectors:
+  spanmetrics:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [otlp/tempo, spanmetrics]
+    metrics:
+      receivers: [spanmetrics, prometheus]
+      exporters: [prometheus]
+`))
+	require.NoError(t, err)
+
+	explanation := Explain(config, func(section, id string) string {
+		if id == "batch" {
+			return "Batches telemetry"
+		}
+		return ""
+	})
+
+	require.Len(t, explanation.Pipelines, 2)
+	assert.Equal(t, "The metrics pipeline metrics receives metrics from the spanmetrics connector (from traces), prometheus without processing them and exports them to prometheus.", explanation.Pipelines[0].Narrative)
+	assert.Equal(t, "The traces pipeline traces receives traces from otlp, processes them with batch in this order and exports them to otlp/tempo, the spanmetrics connector (to metrics).", explanation.Pipelines[1].Narrative)
+	assert.True(t, explanation.Pipelines[1].Flowing)
+
+	assert.Contains(t, explanation.Components, ComponentExplanation{ID: "processors::batch", Description: "Batches telemetry", Pipelines: []string{"traces"}})
+	assert.Equal(t, []string{"extensions::pprof", "exporters::kafka"}, explanation.UnusedComponents)
+	assert.Equal(t, []Endpoint{
+		{Path: "receivers::prometheus::config::scrape_configs::static_configs::targets", Address: "app:8080"},
+		{Path: "receivers::prometheus::config::scrape_configs::static_configs::targets", Address: "app:8081"},
+		{Path: "exporters::otlp/tempo::endpoint", Address: "tempo:4317"},
+	}, explanation.Destinations)
+	assert.Equal(t, []Endpoint{
+		{Path: "extensions::health_check (default endpoint)", Address: "0.0.0.0:13133"},
+		{Path: "receivers::otlp::protocols::grpc (default endpoint)", Address: "0.0.0.0:4317"},
+		{Path: "exporters::prometheus::endpoint", Address: "0.0.0.0:8889"},
+	}, explanation.Listeners)
+}
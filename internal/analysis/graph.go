@@ -0,0 +1,190 @@
+package analysis
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// Edge kinds of the pipeline graph
+const (
+	// EdgePipeline is the data flow between consecutive components of a pipeline
+	EdgePipeline = "pipeline"
+	// EdgeConnector is a connector linking a pipeline it exports from to a pipeline it receives to
+	EdgeConnector = "connector"
+)
+
+// Graph is the pipeline model of a collector configuration: the components are the nodes, the pipeline membership
+// and the connector links are the edges
+type Graph struct {
+	Nodes     []GraphNode     `json:"nodes"`
+	Edges     []GraphEdge     `json:"edges"`
+	Pipelines []GraphPipeline `json:"pipelines"`
+}
+
+// GraphNode is a component of the configuration
+type GraphNode struct {
+	// ID is the component as section::id e.g. processors::batch
+	ID      string `json:"id"`
+	Section string `json:"section"`
+	// Name is the component ID of the configuration e.g. otlp/backend, Type its component type e.g. otlp
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Pipelines []string `json:"pipelines"`
+	Signals   []string `json:"signals"`
+	// Used is false for components defined but not part of a pipeline or the service extensions
+	Used bool `json:"used"`
+	// Undefined is true for components of a pipeline missing in their section, the collector fails to start
+	Undefined bool          `json:"undefined,omitempty"`
+	Metadata  *NodeMetadata `json:"metadata,omitempty"`
+}
+
+// NodeMetadata is the schema and stability metadata of the component type of a node in a collector version
+type NodeMetadata struct {
+	// Schema is false if the schemas of the version have no component of the type
+	Schema      bool   `json:"schema"`
+	Description string `json:"description,omitempty"`
+	FieldCount  int    `json:"fieldCount,omitempty"`
+	// Stability are the signals by stability level e.g. beta: [traces, metrics]
+	Stability    map[string][]string `json:"stability,omitempty"`
+	Deprecated   bool                `json:"deprecated,omitempty"`
+	Unmaintained bool                `json:"unmaintained,omitempty"`
+}
+
+// GraphEdge is a data flow between two nodes. The edges of a connector link start and end at the connector, the
+// pipelines tell the direction.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+	// Pipeline is the pipeline of the flow, or the pipeline a connector exports from
+	Pipeline string `json:"pipeline"`
+	Signal   string `json:"signal"`
+	// ToPipeline and ToSignal are the pipeline a connector receives to and its signal
+	ToPipeline string `json:"toPipeline,omitempty"`
+	ToSignal   string `json:"toSignal,omitempty"`
+}
+
+// GraphPipeline is a pipeline with its nodes in the order of the data flow
+type GraphPipeline struct {
+	ID     string   `json:"id"`
+	Signal string   `json:"signal"`
+	Nodes  []string `json:"nodes"`
+	// Flowing is true if the pipeline receives telemetry and delivers it to an exporter, possibly through connectors
+	Flowing bool `json:"flowing"`
+}
+
+// BuildGraph returns the pipeline graph of the configuration, the metadata of the nodes is returned by metadata, which
+// may return nil
+func BuildGraph(config *collectorconfig.Config, metadata func(section, id string) *NodeMetadata) *Graph {
+	graph := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}, Pipelines: []GraphPipeline{}}
+	nodeID := func(kind, id string) string {
+		if _, isConnector := config.Connectors[id]; isConnector {
+			return "connectors::" + id
+		}
+		return kind + "::" + id
+	}
+
+	pipelinesOf := make(map[string][]string)
+	signalsOf := make(map[string][]string)
+	flowing := flowingPipelines(config)
+	for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+		pipeline := config.Service.Pipelines[pipelineID]
+		signal := collectorconfig.Signal(pipelineID)
+		stages := [][]string{}
+		for _, stage := range []struct {
+			kind string
+			ids  []string
+		}{{"receivers", pipeline.Receivers}, {"processors", pipeline.Processors}, {"exporters", pipeline.Exporters}} {
+			if len(stage.ids) == 0 {
+				continue
+			}
+			nodes := make([]string, 0, len(stage.ids))
+			for _, id := range stage.ids {
+				nodes = append(nodes, nodeID(stage.kind, id))
+			}
+			if stage.kind == "processors" {
+				// The processors are chained in their order
+				for _, node := range nodes {
+					stages = append(stages, []string{node})
+				}
+				continue
+			}
+			stages = append(stages, nodes)
+		}
+
+		graphPipeline := GraphPipeline{ID: pipelineID, Signal: signal, Nodes: []string{}, Flowing: flowing[pipelineID]}
+		for i, stage := range stages {
+			for _, node := range stage {
+				graphPipeline.Nodes = appendUnique(graphPipeline.Nodes, node)
+				pipelinesOf[node] = appendUnique(pipelinesOf[node], pipelineID)
+				signalsOf[node] = appendUnique(signalsOf[node], signal)
+			}
+			if i == 0 {
+				continue
+			}
+			for _, from := range stages[i-1] {
+				for _, to := range stage {
+					graph.Edges = append(graph.Edges, GraphEdge{From: from, To: to, Kind: EdgePipeline, Pipeline: pipelineID, Signal: signal})
+				}
+			}
+		}
+		graph.Pipelines = append(graph.Pipelines, graphPipeline)
+	}
+
+	// A connector links every pipeline exporting to it with every pipeline receiving from it
+	for _, id := range slices.Sorted(maps.Keys(config.Connectors)) {
+		for _, fromID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+			if !slices.Contains(config.Service.Pipelines[fromID].Exporters, id) {
+				continue
+			}
+			for _, toID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+				if !slices.Contains(config.Service.Pipelines[toID].Receivers, id) {
+					continue
+				}
+				graph.Edges = append(graph.Edges, GraphEdge{
+					From:       "connectors::" + id,
+					To:         "connectors::" + id,
+					Kind:       EdgeConnector,
+					Pipeline:   fromID,
+					Signal:     collectorconfig.Signal(fromID),
+					ToPipeline: toID,
+					ToSignal:   collectorconfig.Signal(toID),
+				})
+			}
+		}
+	}
+
+	defined := make(map[string]bool)
+	newNode := func(section, id string) GraphNode {
+		node := section + "::" + id
+		return GraphNode{
+			ID:        node,
+			Section:   section,
+			Name:      id,
+			Type:      collectorconfig.ComponentType(id),
+			Pipelines: nonNil(pipelinesOf[node]),
+			Signals:   nonNil(signalsOf[node]),
+			Used:      len(pipelinesOf[node]) > 0 || (section == "extensions" && slices.Contains(config.Service.Extensions, id)),
+			Metadata:  metadata(section, id),
+		}
+	}
+	for _, section := range componentSections(config) {
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			graph.Nodes = append(graph.Nodes, newNode(section.name, id))
+			defined[section.name+"::"+id] = true
+		}
+	}
+	for _, node := range slices.Sorted(maps.Keys(pipelinesOf)) {
+		if defined[node] {
+			continue
+		}
+		section, id, _ := strings.Cut(node, "::")
+		undefined := newNode(section, id)
+		undefined.Undefined = true
+		graph.Nodes = append(graph.Nodes, undefined)
+	}
+	return graph
+}
@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestBuildGraph(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+extensions:
+  health_check:
+receivers:
+  otlp:
+processors:
+  memory_limiter:
+  batch:
+exporters:
+  otlp/tempo:
+  debug:
+connectors:
+  spanmetrics:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [otlp/tempo, spanmetrics]
+    metrics:
+      receivers: [spanmetrics]
+      exporters: [prometheus]
+`))
+	require.NoError(t, err)
+
+	graph := BuildGraph(config, func(section, id string) *NodeMetadata {
+		if id == "batch" {
+			return &NodeMetadata{Schema: true, Stability: map[string][]string{"beta": {"traces"}}}
+		}
+		return nil
+	})
+
+	assert.Equal(t, []GraphEdge{
+		{From: "connectors::spanmetrics", To: "exporters::prometheus", Kind: EdgePipeline, Pipeline: "metrics", Signal: "metrics"},
+		{From: "receivers::otlp", To: "processors::memory_limiter", Kind: EdgePipeline, Pipeline: "traces", Signal: "traces"},
+		{From: "processors::memory_limiter", To: "processors::batch", Kind: EdgePipeline, Pipeline: "traces", Signal: "traces"},
+		{From: "processors::batch", To: "exporters::otlp/tempo", Kind: EdgePipeline, Pipeline: "traces", Signal: "traces"},
+		{From: "processors::batch", To: "connectors::spanmetrics", Kind: EdgePipeline, Pipeline: "traces", Signal: "traces"},
+		{From: "connectors::spanmetrics", To: "connectors::spanmetrics", Kind: EdgeConnector, Pipeline: "traces", Signal: "traces", ToPipeline: "metrics", ToSignal: "metrics"},
+	}, graph.Edges)
+
+	nodes := make(map[string]GraphNode)
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node
+	}
+	assert.Len(t, graph.Nodes, 8)
+	assert.True(t, nodes["extensions::health_check"].Used)
+	assert.False(t, nodes["exporters::debug"].Used)
+	assert.Equal(t, []string{"metrics", "traces"}, nodes["connectors::spanmetrics"].Pipelines)
+	assert.Equal(t, "otlp", nodes["exporters::otlp/tempo"].Type)
+	assert.True(t, nodes["exporters::prometheus"].Undefined)
+	assert.Equal(t, []string{"traces"}, nodes["processors::batch"].Metadata.Stability["beta"])
+
+	require.Len(t, graph.Pipelines, 2)
+	assert.Equal(t, []string{"connectors::spanmetrics", "exporters::prometheus"}, graph.Pipelines[0].Nodes)
+	assert.False(t, graph.Pipelines[0].Flowing)
+	assert.Equal(t, []string{"receivers::otlp", "processors::memory_limiter", "processors::batch", "exporters::otlp/tempo", "connectors::spanmetrics"}, graph.Pipelines[1].Nodes)
+}
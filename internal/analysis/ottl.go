@@ -0,0 +1,200 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/ottl"
+)
+
+// transformStatementContexts are the OTTL contexts usable in the transform processor statement lists
+var transformStatementContexts = map[string][]string{
+	"trace_statements":  {"resource", "scope", "instrumentation_scope", "span", "spanevent"},
+	"metric_statements": {"resource", "scope", "instrumentation_scope", "metric", "datapoint"},
+	"log_statements":    {"resource", "scope", "instrumentation_scope", "log"},
+}
+
+// filterConditionContexts are the filter processor condition lists and their OTTL context
+var filterConditionContexts = map[string]map[string]string{
+	"traces":  {"span": "span", "spanevent": "spanevent"},
+	"metrics": {"metric": "metric", "datapoint": "datapoint"},
+	"logs":    {"log_record": "log"},
+}
+
+// countConditionContexts are the count and sum connector sections and their OTTL context
+var countConditionContexts = map[string]string{
+	"spans":      "span",
+	"spanevents": "spanevent",
+	"metrics":    "metric",
+	"datapoints": "datapoint",
+	"logs":       "log",
+}
+
+// ValidateOTTL parses the OTTL statements and conditions of the transform and filter processors, tail_sampling
+// ottl_condition policies and count and sum connectors and checks their paths against the context they run in
+func ValidateOTTL(config *collectorconfig.Config, version string) []collectorconfig.Issue {
+	var issues []collectorconfig.Issue
+	for _, id := range slices.Sorted(maps.Keys(config.Processors)) {
+		settings, _ := config.Processors[id].(map[string]interface{})
+		path := "processors::" + id
+		switch collectorconfig.ComponentType(id) {
+		case "transform":
+			issues = append(issues, validateTransformStatements(path, settings, version)...)
+		case "filter":
+			issues = append(issues, validateFilterConditions(path, settings)...)
+		case "tail_sampling":
+			issues = append(issues, validateOTTLConditionPolicies(path, settings)...)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(config.Connectors)) {
+		if componentType := collectorconfig.ComponentType(id); componentType != "count" && componentType != "sum" {
+			continue
+		}
+		settings, _ := config.Connectors[id].(map[string]interface{})
+		for _, section := range slices.Sorted(maps.Keys(countConditionContexts)) {
+			metrics, _ := settings[section].(map[string]interface{})
+			for _, name := range slices.Sorted(maps.Keys(metrics)) {
+				metric, _ := metrics[name].(map[string]interface{})
+				path := fmt.Sprintf("connectors::%s::%s::%s::conditions", id, section, name)
+				issues = append(issues, validateConditions(path, countConditionContexts[section], stringList(metric["conditions"]))...)
+			}
+		}
+	}
+	return issues
+}
+
+func validateTransformStatements(path string, settings map[string]interface{}, version string) []collectorconfig.Issue {
+	var issues []collectorconfig.Issue
+	for _, key := range slices.Sorted(maps.Keys(transformStatementContexts)) {
+		allowed := transformStatementContexts[key]
+		groups, _ := settings[key].([]interface{})
+		for i, group := range groups {
+			groupPath := fmt.Sprintf("%s::%s::%d", path, key, i)
+			var context string
+			var statements, conditions []string
+			switch g := group.(type) {
+			case string:
+				statements = []string{g}
+			case map[string]interface{}:
+				context, _ = g["context"].(string)
+				statements = stringList(g["statements"])
+				conditions = stringList(g["conditions"])
+			default:
+				issues = append(issues, ottlIssue(groupPath, "must be a statement or a group with context and statements"))
+				continue
+			}
+
+			var parsed []ottl.Node
+			for _, raw := range statements {
+				statement, err := ottl.ParseStatement(raw)
+				if err != nil {
+					issues = append(issues, ottlIssue(groupPath, fmt.Sprintf("invalid statement %q: %v", raw, err)))
+					continue
+				}
+				parsed = append(parsed, statement)
+			}
+			for _, raw := range conditions {
+				condition, err := ottl.ParseCondition(raw)
+				if err != nil {
+					issues = append(issues, ottlIssue(groupPath, fmt.Sprintf("invalid condition %q: %v", raw, err)))
+					continue
+				}
+				parsed = append(parsed, condition)
+			}
+
+			if context == "" {
+				if version != "" && collectorschema.CompareVersions(version, ottl.InferredContextSince) < 0 {
+					issues = append(issues, ottlIssue(groupPath, fmt.Sprintf("statements without a context require collector %s or newer, set the context", ottl.InferredContextSince)))
+					continue
+				}
+				if context = ottl.InferContext(parsed...); context == "" {
+					if len(parsed) > 0 {
+						issues = append(issues, ottlIssue(groupPath, "the context cannot be inferred, prefix the paths with the context e.g. span.attributes or set the context"))
+					}
+					continue
+				}
+			}
+			if !slices.Contains(allowed, context) {
+				issues = append(issues, ottlIssue(groupPath, fmt.Sprintf("context %s cannot be used in %s, use one of %s", context, key, strings.Join(allowed, ", "))))
+				continue
+			}
+			for _, node := range parsed {
+				for _, problem := range ottl.ValidatePaths(node, context) {
+					issues = append(issues, ottlIssue(groupPath, fmt.Sprintf("%q: %s", node, problem)))
+				}
+			}
+		}
+	}
+	return issues
+}
+
+func validateFilterConditions(path string, settings map[string]interface{}) []collectorconfig.Issue {
+	var issues []collectorconfig.Issue
+	for _, signal := range slices.Sorted(maps.Keys(filterConditionContexts)) {
+		lists, _ := settings[signal].(map[string]interface{})
+		for _, key := range slices.Sorted(maps.Keys(filterConditionContexts[signal])) {
+			conditionsPath := fmt.Sprintf("%s::%s::%s", path, signal, key)
+			issues = append(issues, validateConditions(conditionsPath, filterConditionContexts[signal][key], stringList(lists[key]))...)
+		}
+	}
+	return issues
+}
+
+// validateOTTLConditionPolicies validates ottl_condition policies, also nested in and and composite policies
+func validateOTTLConditionPolicies(path string, value interface{}) []collectorconfig.Issue {
+	var issues []collectorconfig.Issue
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if key != "ottl_condition" {
+				issues = append(issues, validateOTTLConditionPolicies(path+"::"+key, v[key])...)
+				continue
+			}
+			policy, _ := v[key].(map[string]interface{})
+			for _, context := range []string{"span", "spanevent"} {
+				issues = append(issues, validateConditions(path+"::ottl_condition::"+context, context, stringList(policy[context]))...)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			issues = append(issues, validateOTTLConditionPolicies(fmt.Sprintf("%s::%d", path, i), item)...)
+		}
+	}
+	return issues
+}
+
+func validateConditions(path, context string, conditions []string) []collectorconfig.Issue {
+	var issues []collectorconfig.Issue
+	for _, raw := range conditions {
+		condition, err := ottl.ParseCondition(raw)
+		if err != nil {
+			issues = append(issues, ottlIssue(path, fmt.Sprintf("invalid condition %q: %v", raw, err)))
+			continue
+		}
+		for _, problem := range ottl.ValidatePaths(condition, context) {
+			issues = append(issues, ottlIssue(path, fmt.Sprintf("%q: %s", raw, problem)))
+		}
+	}
+	return issues
+}
+
+func ottlIssue(path, message string) collectorconfig.Issue {
+	return collectorconfig.Issue{Severity: collectorconfig.SeverityError, Path: path, Message: message}
+}
+
+// stringList returns the strings of a YAML list
+func stringList(value interface{}) []string {
+	var result []string
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const ottlConfig = `
+processors:
+  transform:
+    metric_statements:
+      - context: metric
+        statements:
+          - set(attributes["env"], "prod")
+      - context: datapoint
+        statements:
+          - set(attributes["env"], resource.attributes["deployment.environment"])
+      - set(datapoint.attributes["region"], "eu")
+    trace_statements:
+      - context: log
+        statements:
+          - set(attributes["a"], "b")
+  filter:
+    logs:
+      log_record:
+        - severity_number < SEVERITY_NUMBER_WARN
+        - span.name == "a"
+  tail_sampling:
+    policies:
+      - name: errors
+        type: ottl_condition
+        ottl_condition:
+          span:
+            - status.code == STATUS_CODE_ERROR
+          spanevent:
+            - metric.name == "a"
+connectors:
+  count:
+    datapoints:
+      requests:
+        conditions:
+          - attributes["http.route"] != nil
+    spans:
+      server:
+        conditions:
+          - datapoint.attributes["a"] == "b"
+`
+
+func TestValidateOTTL(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(ottlConfig))
+	require.NoError(t, err)
+
+	issues := ValidateOTTL(config, "0.130.0")
+	paths := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		paths = append(paths, issue.Path)
+	}
+	assert.Equal(t, []string{
+		"processors::filter::logs::log_record",
+		"processors::tail_sampling::policies::0::ottl_condition::spanevent",
+		"processors::transform::metric_statements::0",
+		"processors::transform::trace_statements::0",
+		"connectors::count::spans::server::conditions",
+	}, paths)
+	assert.Contains(t, issues[2].Message, "use the datapoint context")
+	assert.Contains(t, issues[3].Message, "context log cannot be used in trace_statements")
+}
+
+func TestValidateOTTL_InferredContextVersion(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+processors:
+  transform:
+    log_statements:
+      - set(log.attributes["a"], "b")
+      - set(attributes["a"], "b")
+`))
+	require.NoError(t, err)
+
+	issues := ValidateOTTL(config, "0.119.0")
+	require.Len(t, issues, 2)
+	assert.Contains(t, issues[0].Message, "require collector 0.120.0")
+
+	issues = ValidateOTTL(config, "0.130.0")
+	require.Len(t, issues, 1)
+	assert.Equal(t, "processors::transform::log_statements::1", issues[0].Path)
+	assert.Contains(t, issues[0].Message, "cannot be inferred")
+}
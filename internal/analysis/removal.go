@@ -0,0 +1,288 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// removalImplications are the performance and behavior implications of removing a component type
+var removalImplications = map[string]string{
+	"batch":                 "telemetry is exported without batching, many small export requests increase CPU, network overhead and backend load",
+	"memory_limiter":        "the collector no longer refuses data close to its memory limit, load spikes can crash it with out of memory",
+	"tail_sampling":         "all traces are exported, export volume and backend costs increase",
+	"probabilistic_sampler": "all telemetry is exported, export volume and backend costs increase",
+	"filter":                "telemetry dropped by the filter is exported, export volume and backend costs increase",
+	"redaction":             "sensitive attributes are no longer redacted before they are exported",
+	"k8sattributes":         "telemetry is no longer enriched with Kubernetes metadata, queries and dashboards grouping by k8s attributes break",
+	"resourcedetection":     "telemetry is no longer enriched with host and cloud resource attributes",
+	"resource":              "resource attributes set by the processor are missing, queries relying on them break",
+	"attributes":            "attributes inserted, updated or deleted by the processor are exported unchanged",
+	"transform":             "telemetry is no longer modified by the OTTL statements",
+	"cumulativetodelta":     "metrics are exported with cumulative temporality, delta-only backends reject them",
+	"deltatocumulative":     "metrics are exported with delta temporality, cumulative-only backends e.g. Prometheus reject them",
+	"health_check":          "the health check endpoint is removed, Kubernetes liveness and readiness probes using it fail",
+	"file_storage":          "persistent queues and receiver offsets using the storage are lost on restart",
+	"pprof":                 "the profiling endpoint is removed",
+	"zpages":                "the zPages debugging endpoint is removed",
+}
+
+// RemovalReport describes what happens when a component is removed from the configuration
+type RemovalReport struct {
+	Component string `json:"component"`
+	// Pipelines are the pipelines the component is removed from
+	Pipelines []string `json:"pipelines"`
+	// StoppedPipelines are pipelines delivering telemetry to an exporter before the removal but not after
+	StoppedPipelines []string `json:"stoppedPipelines"`
+	// StoppedSignals are signals that no longer reach any exporter
+	StoppedSignals []string `json:"stoppedSignals"`
+	// OrphanedComponents are components that no longer process any telemetry
+	OrphanedComponents []string                `json:"orphanedComponents"`
+	Issues             []collectorconfig.Issue `json:"issues"`
+	Implications       []string                `json:"implications"`
+	Config             *collectorconfig.Config `json:"config"`
+}
+
+// WhatIfRemove removes the component from the section (receivers, processors, exporters, connectors or extensions)
+// and the pipelines referencing it and reports the telemetry that stops flowing
+func WhatIfRemove(config *collectorconfig.Config, section, id string) (*RemovalReport, error) {
+	var components map[string]interface{}
+	for _, s := range componentSections(config) {
+		if s.name == section {
+			components = s.components
+		}
+	}
+	if components == nil {
+		return nil, fmt.Errorf("unknown section %q, must be receivers, processors, exporters, connectors or extensions", section)
+	}
+	if _, exists := components[id]; !exists {
+		return nil, fmt.Errorf("%s %q is not defined", section, id)
+	}
+
+	after, err := config.Clone()
+	if err != nil {
+		return nil, err
+	}
+	// The lists are empty and not null when the removal stops nothing
+	report := &RemovalReport{
+		Component:          section + "::" + id,
+		Pipelines:          []string{},
+		StoppedPipelines:   []string{},
+		StoppedSignals:     []string{},
+		OrphanedComponents: []string{},
+		Issues:             []collectorconfig.Issue{},
+		Implications:       []string{},
+		Config:             after,
+	}
+	for _, s := range componentSections(after) {
+		if s.name == section {
+			delete(s.components, id)
+		}
+	}
+	if section == "extensions" {
+		after.Service.Extensions = slices.DeleteFunc(after.Service.Extensions, func(v string) bool { return v == id })
+	} else {
+		for _, pipelineID := range slices.Sorted(maps.Keys(after.Service.Pipelines)) {
+			pipeline := after.Service.Pipelines[pipelineID]
+			// Receivers and exporters can share an ID e.g. otlp, connectors are referenced from both lists
+			referenced := false
+			if section == "receivers" || section == "connectors" {
+				referenced = referenced || slices.Contains(pipeline.Receivers, id)
+				pipeline.Receivers = slices.DeleteFunc(pipeline.Receivers, func(v string) bool { return v == id })
+			}
+			if section == "exporters" || section == "connectors" {
+				referenced = referenced || slices.Contains(pipeline.Exporters, id)
+				pipeline.Exporters = slices.DeleteFunc(pipeline.Exporters, func(v string) bool { return v == id })
+			}
+			if section == "processors" {
+				referenced = slices.Contains(pipeline.Processors, id)
+				pipeline.Processors = slices.DeleteFunc(pipeline.Processors, func(v string) bool { return v == id })
+			}
+			if referenced {
+				report.Pipelines = append(report.Pipelines, pipelineID)
+			}
+		}
+	}
+
+	flowingBefore := flowingPipelines(config)
+	flowingAfter := flowingPipelines(after)
+	signalsAfter := make(map[string]bool)
+	for pipelineID := range flowingAfter {
+		signalsAfter[collectorconfig.Signal(pipelineID)] = true
+	}
+	for _, pipelineID := range slices.Sorted(maps.Keys(flowingBefore)) {
+		if !flowingAfter[pipelineID] {
+			report.StoppedPipelines = append(report.StoppedPipelines, pipelineID)
+		}
+		if signal := collectorconfig.Signal(pipelineID); !signalsAfter[signal] && !slices.Contains(report.StoppedSignals, signal) {
+			report.StoppedSignals = append(report.StoppedSignals, signal)
+		}
+	}
+
+	usedAfter := componentsInPipelines(after, flowingAfter)
+	for _, component := range slices.Sorted(maps.Keys(componentsInPipelines(config, flowingBefore))) {
+		if component != report.Component && !usedAfter[component] {
+			report.OrphanedComponents = append(report.OrphanedComponents, component)
+		}
+	}
+
+	// Report only the issues introduced by the removal
+	existing := make(map[string]bool)
+	for _, issue := range config.ValidateTopology() {
+		existing[issue.String()] = true
+	}
+	for _, issue := range after.ValidateTopology() {
+		if !existing[issue.String()] {
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+	if section == "extensions" {
+		for _, path := range referencingPaths(after, id) {
+			report.Issues = append(report.Issues, collectorconfig.Issue{
+				Severity: collectorconfig.SeverityError,
+				Path:     path,
+				Message:  fmt.Sprintf("references extension %q which is removed", id),
+			})
+		}
+	}
+
+	if collectorconfig.HasErrors(report.Issues) {
+		report.Implications = append(report.Implications, "the collector fails to start with the resulting configuration, fix the issues or remove the affected pipelines")
+	}
+	if implication, ok := removalImplications[collectorconfig.ComponentType(id)]; ok {
+		report.Implications = append(report.Implications, implication)
+	}
+	switch section {
+	case "receivers":
+		report.Implications = append(report.Implications, fmt.Sprintf("telemetry sent to or scraped by %s is no longer collected", id))
+	case "exporters":
+		report.Implications = append(report.Implications, fmt.Sprintf("telemetry is no longer sent to the %s destination", id))
+	case "connectors":
+		report.Implications = append(report.Implications, fmt.Sprintf("pipelines receiving from %s no longer get telemetry from the pipelines exporting to it", id))
+	}
+	if len(report.StoppedSignals) > 0 {
+		report.Implications = append(report.Implications, fmt.Sprintf("%s no longer reach any exporter", strings.Join(report.StoppedSignals, ", ")))
+	}
+	return report, nil
+}
+
+// flowingPipelines returns the pipelines that receive telemetry from a receiver and deliver it to an exporter,
+// possibly through other pipelines connected via connectors
+func flowingPipelines(config *collectorconfig.Config) map[string]bool {
+	fed := make(map[string]bool)
+	delivers := make(map[string]bool)
+	// Propagate along connectors until nothing changes, both relations only grow
+	for changed := true; changed; {
+		changed = false
+		for pipelineID, pipeline := range config.Service.Pipelines {
+			if !fed[pipelineID] {
+				for _, id := range pipeline.Receivers {
+					if _, isReceiver := config.Receivers[id]; isReceiver || connectorFed(config, id, fed) {
+						fed[pipelineID] = true
+						changed = true
+						break
+					}
+				}
+			}
+			if !delivers[pipelineID] {
+				for _, id := range pipeline.Exporters {
+					if _, isExporter := config.Exporters[id]; isExporter || connectorDelivers(config, id, delivers) {
+						delivers[pipelineID] = true
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+
+	flowing := make(map[string]bool)
+	for pipelineID := range config.Service.Pipelines {
+		if fed[pipelineID] && delivers[pipelineID] {
+			flowing[pipelineID] = true
+		}
+	}
+	return flowing
+}
+
+// connectorFed returns true if the connector receives telemetry from a fed pipeline
+func connectorFed(config *collectorconfig.Config, id string, fed map[string]bool) bool {
+	if _, isConnector := config.Connectors[id]; !isConnector {
+		return false
+	}
+	for pipelineID, pipeline := range config.Service.Pipelines {
+		if fed[pipelineID] && slices.Contains(pipeline.Exporters, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// connectorDelivers returns true if the connector forwards telemetry to a delivering pipeline
+func connectorDelivers(config *collectorconfig.Config, id string, delivers map[string]bool) bool {
+	if _, isConnector := config.Connectors[id]; !isConnector {
+		return false
+	}
+	for pipelineID, pipeline := range config.Service.Pipelines {
+		if delivers[pipelineID] && slices.Contains(pipeline.Receivers, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// componentsInPipelines returns the components referenced by the pipelines as section::id
+func componentsInPipelines(config *collectorconfig.Config, pipelines map[string]bool) map[string]bool {
+	components := make(map[string]bool)
+	for pipelineID := range pipelines {
+		pipeline := config.Service.Pipelines[pipelineID]
+		// A connector processes telemetry only if the pipeline receiving from it flows
+		for _, id := range pipeline.Receivers {
+			if _, isConnector := config.Connectors[id]; isConnector {
+				components["connectors::"+id] = true
+			} else if _, isReceiver := config.Receivers[id]; isReceiver {
+				components["receivers::"+id] = true
+			}
+		}
+		for _, id := range pipeline.Exporters {
+			if _, isExporter := config.Exporters[id]; isExporter {
+				components["exporters::"+id] = true
+			}
+		}
+		for _, id := range pipeline.Processors {
+			components["processors::"+id] = true
+		}
+	}
+	return components
+}
+
+// referencingPaths returns the paths of component settings referencing the extension e.g. storage or authenticator
+func referencingPaths(config *collectorconfig.Config, extension string) []string {
+	var paths []string
+	var walk func(path string, value interface{})
+	walk = func(path string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				walk(path+"::"+key, v[key])
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(path, item)
+			}
+		case string:
+			if v == extension {
+				paths = append(paths, path)
+			}
+		}
+	}
+	for _, section := range componentSections(config) {
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			walk(section.name+"::"+id, section.components[id])
+		}
+	}
+	return paths
+}
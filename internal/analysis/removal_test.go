@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const removalConfig = `
+extensions:
+  file_storage:
+receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+  k8sattributes:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    sending_queue:
+      storage: file_storage
+  prometheus:
+    endpoint: 0.0.0.0:8889
+connectors:
+  spanmetrics:
+service:
+  extensions: [file_storage]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [k8sattributes, batch]
+      exporters: [otlp, spanmetrics]
+    metrics:
+      receivers: [spanmetrics]
+      processors: [batch]
+      exporters: [prometheus]
+`
+
+func TestWhatIfRemove_Processor(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(removalConfig))
+	require.NoError(t, err)
+
+	report, err := WhatIfRemove(config, "processors", "batch")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metrics", "traces"}, report.Pipelines)
+	// The lists are serialized as empty arrays as required by the output schema
+	assert.Equal(t, []string{}, report.StoppedPipelines)
+	assert.Equal(t, []string{}, report.StoppedSignals)
+	assert.Equal(t, []string{}, report.OrphanedComponents)
+	assert.Equal(t, []collectorconfig.Issue{}, report.Issues)
+	assert.Contains(t, report.Implications[0], "without batching")
+	assert.Equal(t, []string{"k8sattributes"}, report.Config.Service.Pipelines["traces"].Processors)
+	// The input configuration is not modified
+	assert.Equal(t, []string{"k8sattributes", "batch"}, config.Service.Pipelines["traces"].Processors)
+}
+
+func TestWhatIfRemove_Exporter(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(removalConfig))
+	require.NoError(t, err)
+
+	report, err := WhatIfRemove(config, "exporters", "prometheus")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metrics"}, report.Pipelines)
+	assert.Equal(t, []string{"metrics"}, report.StoppedPipelines)
+	assert.Equal(t, []string{"metrics"}, report.StoppedSignals)
+	assert.Equal(t, []string{"connectors::spanmetrics"}, report.OrphanedComponents)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "service::pipelines::metrics", report.Issues[0].Path)
+	assert.Contains(t, report.Implications[0], "fails to start")
+}
+
+func TestWhatIfRemove_ReceiverSharingExporterID(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(removalConfig))
+	require.NoError(t, err)
+
+	report, err := WhatIfRemove(config, "receivers", "otlp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metrics", "traces"}, report.StoppedPipelines)
+	assert.Equal(t, []string{"metrics", "traces"}, report.StoppedSignals)
+	assert.Equal(t, []string{"otlp", "spanmetrics"}, report.Config.Service.Pipelines["traces"].Exporters)
+	assert.Contains(t, report.OrphanedComponents, "exporters::otlp")
+}
+
+func TestWhatIfRemove_Extension(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(removalConfig))
+	require.NoError(t, err)
+
+	report, err := WhatIfRemove(config, "extensions", "file_storage")
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "exporters::otlp::sending_queue::storage", report.Issues[0].Path)
+	assert.Empty(t, report.Config.Service.Extensions)
+
+	_, err = WhatIfRemove(config, "extensions", "pprof")
+	assert.Error(t, err)
+	_, err = WhatIfRemove(config, "pipelines", "traces")
+	assert.Error(t, err)
+}
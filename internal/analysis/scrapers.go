@@ -0,0 +1,147 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// Finding types of the scraper analysis
+const (
+	FindingScraperInterval     = "scraper-interval"
+	FindingScraperTimeout      = "scraper-timeout"
+	FindingScraperInitialDelay = "scraper-initial-delay"
+)
+
+// minCollectionInterval is the collection interval below which a scraper loads the scraped system and multiplies the
+// data points without a practical gain
+const minCollectionInterval = 10 * time.Second
+
+// scraperHelperReceivers are the receivers sharing the collection_interval, initial_delay and timeout settings of the
+// scraperhelper controller. Receivers of other types setting collection_interval e.g. of a custom distribution are
+// checked as well.
+var scraperHelperReceivers = []string{
+	"activedirectoryds", "aerospike", "apache", "bigip", "chrony", "couchdb", "docker_stats", "elasticsearch", "expvar",
+	"filestats", "flinkmetrics", "haproxy", "hostmetrics", "httpcheck", "iis", "kafkametrics", "kubeletstats",
+	"memcached", "mongodb", "mysql", "nginx", "nsxt", "ntp", "oracledb", "podman_stats", "postgresql", "rabbitmq",
+	"redis", "riak", "saphana", "snmp", "splunkenterprise", "sqlquery", "sqlserver", "sshcheck", "systemd",
+	"tlscheck", "vcenter", "windowsperfcounters", "zookeeper",
+}
+
+// Scrapers returns the misconfigured scraper settings of the receivers in pipelines and of the receiver_creator
+// templates: collection intervals that are not positive or unreasonably low, timeouts not lower than the interval and
+// initial delays that are negative or longer than the interval
+func Scrapers(config *collectorconfig.Config) []Finding {
+	var findings []Finding
+	for _, id := range startedReceivers(config) {
+		settings, _ := config.Receivers[id].(map[string]interface{})
+		path := "receivers::" + id
+		findings = append(findings, scraperSettings(path, collectorconfig.ComponentType(id), settings)...)
+
+		if collectorconfig.ComponentType(id) != "receiver_creator" {
+			continue
+		}
+		templates, _ := settings["receivers"].(map[string]interface{})
+		for _, templateID := range slices.Sorted(maps.Keys(templates)) {
+			template, _ := templates[templateID].(map[string]interface{})
+			templateSettings, _ := template["config"].(map[string]interface{})
+			findings = append(findings, scraperSettings(path+"::receivers::"+templateID+"::config", collectorconfig.ComponentType(templateID), templateSettings)...)
+		}
+	}
+	return findings
+}
+
+// scraperSettings checks the scraperhelper settings of a scraping receiver
+func scraperSettings(path, receiverType string, settings map[string]interface{}) []Finding {
+	if _, hasInterval := settings["collection_interval"]; !hasInterval && !slices.Contains(scraperHelperReceivers, receiverType) {
+		return nil
+	}
+	var findings []Finding
+	interval, hasInterval := scraperDuration(settings, "collection_interval")
+	timeout, hasTimeout := scraperDuration(settings, "timeout")
+	delay, hasDelay := scraperDuration(settings, "initial_delay")
+
+	switch {
+	case hasInterval && interval.value <= 0:
+		findings = append(findings, Finding{
+			Type:     FindingScraperInterval,
+			Severity: collectorconfig.SeverityError,
+			Paths:    []string{path + "::collection_interval"},
+			Message:  fmt.Sprintf("collection_interval %s must be a positive duration, the collector fails to start", interval),
+		})
+	case hasInterval && interval.value < minCollectionInterval:
+		findings = append(findings, Finding{
+			Type:     FindingScraperInterval,
+			Severity: collectorconfig.SeverityWarning,
+			Paths:    []string{path + "::collection_interval"},
+			Message:  fmt.Sprintf("collection_interval %s scrapes more often than every %s, it loads the scraped system and multiplies the data points, use %s or more", interval, minCollectionInterval, minCollectionInterval),
+		})
+	}
+
+	switch {
+	case hasTimeout && timeout.value < 0:
+		findings = append(findings, Finding{
+			Type:     FindingScraperTimeout,
+			Severity: collectorconfig.SeverityError,
+			Paths:    []string{path + "::timeout"},
+			Message:  fmt.Sprintf("timeout %s must not be negative, the collector fails to start", timeout),
+		})
+	case hasTimeout && hasInterval && timeout.value > 0 && interval.value > 0 && timeout.value >= interval.value:
+		findings = append(findings, Finding{
+			Type:     FindingScraperTimeout,
+			Severity: collectorconfig.SeverityWarning,
+			Paths:    []string{path + "::timeout"},
+			Message:  fmt.Sprintf("timeout %s is not lower than collection_interval %s, a slow scrape delays the next one and the data points have gaps, set the timeout below the interval", timeout, interval),
+		})
+	}
+
+	switch {
+	case hasDelay && delay.value < 0:
+		findings = append(findings, Finding{
+			Type:     FindingScraperInitialDelay,
+			Severity: collectorconfig.SeverityWarning,
+			Paths:    []string{path + "::initial_delay"},
+			Message:  fmt.Sprintf("initial_delay %s is negative, the first scrape starts immediately, remove it or set a positive delay", delay),
+		})
+	case hasDelay && hasInterval && interval.value > 0 && delay.value > interval.value:
+		findings = append(findings, Finding{
+			Type:     FindingScraperInitialDelay,
+			Severity: collectorconfig.SeverityWarning,
+			Paths:    []string{path + "::initial_delay"},
+			Message:  fmt.Sprintf("initial_delay %s is longer than collection_interval %s, the first data points arrive after more than an interval, the delay is meant to let the scraped system start", delay, interval),
+		})
+	}
+	return findings
+}
+
+// duration is a duration setting with the value as configured
+type duration struct {
+	value      time.Duration
+	configured string
+}
+
+func (d duration) String() string {
+	return d.configured
+}
+
+// scraperDuration returns a duration setting. A number without a unit is decoded as nanoseconds by the collector, the
+// configured value tells it. Environment variable references are unknown.
+func scraperDuration(settings map[string]interface{}, key string) (duration, bool) {
+	switch value := settings[key].(type) {
+	case string:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return duration{}, false
+		}
+		return duration{value: parsed, configured: value}, true
+	default:
+		nanoseconds, ok := collectorconfig.Number(value)
+		if !ok {
+			return duration{}, false
+		}
+		return duration{value: time.Duration(nanoseconds), configured: fmt.Sprintf("%s (%s without a unit)", collectorconfig.FormatNumber(nanoseconds), time.Duration(nanoseconds))}, true
+	}
+}
@@ -0,0 +1,81 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const scrapersConfig = `
+receivers:
+  hostmetrics:
+    collection_interval: 1s
+    scrapers:
+      cpu:
+  postgresql:
+    collection_interval: 30s
+    timeout: 30s
+    initial_delay: 1m
+  redis:
+    collection_interval: 0s
+    timeout: -1s
+  kubeletstats:
+    collection_interval: 10
+  custom:
+    collection_interval: 1m
+    initial_delay: -1s
+  mysql:
+    collection_interval: ${env:INTERVAL}
+    timeout: 10s
+  nginx:
+    collection_interval: 1s
+  receiver_creator:
+    receivers:
+      redis/on_pods:
+        rule: type == "port" && port == 6379
+        config:
+          collection_interval: 60s
+          timeout: 2m
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [hostmetrics, postgresql, redis, kubeletstats, custom, mysql, receiver_creator, otlp]
+      exporters: [debug]
+`
+
+func TestScrapers(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(scrapersConfig))
+	require.NoError(t, err)
+
+	type finding struct {
+		Type     string
+		Severity collectorconfig.Severity
+		Path     string
+	}
+	var findings []finding
+	for _, f := range Scrapers(config) {
+		findings = append(findings, finding{Type: f.Type, Severity: f.Severity, Path: f.Paths[0]})
+	}
+	// The nginx receiver is not in a pipeline, the interval of the mysql receiver is unknown
+	assert.Equal(t, []finding{
+		{FindingScraperInitialDelay, collectorconfig.SeverityWarning, "receivers::custom::initial_delay"},
+		{FindingScraperInterval, collectorconfig.SeverityWarning, "receivers::hostmetrics::collection_interval"},
+		{FindingScraperInterval, collectorconfig.SeverityWarning, "receivers::kubeletstats::collection_interval"},
+		{FindingScraperTimeout, collectorconfig.SeverityWarning, "receivers::postgresql::timeout"},
+		{FindingScraperInitialDelay, collectorconfig.SeverityWarning, "receivers::postgresql::initial_delay"},
+		{FindingScraperTimeout, collectorconfig.SeverityWarning, "receivers::receiver_creator::receivers::redis/on_pods::config::timeout"},
+		{FindingScraperInterval, collectorconfig.SeverityError, "receivers::redis::collection_interval"},
+		{FindingScraperTimeout, collectorconfig.SeverityError, "receivers::redis::timeout"},
+	}, findings)
+
+	messages := Scrapers(config)
+	assert.Equal(t, "collection_interval 10 (10ns without a unit) scrapes more often than every 10s, it loads the scraped system and multiplies the data points, use 10s or more", messages[2].Message)
+}
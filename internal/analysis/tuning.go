@@ -0,0 +1,275 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// Finding types of the tuning analysis
+const (
+	FindingGRPCMessageSize = "grpc-message-size"
+	FindingMemoryLimit     = "memory-limit"
+	FindingQueueMemory     = "queue-memory"
+	FindingQueueConsumers  = "queue-consumers"
+)
+
+// DefaultGRPCMaxRecvMsgSizeMiB is the maximum message size of a gRPC server without max_recv_msg_size_mib
+const DefaultGRPCMaxRecvMsgSizeMiB = 4
+
+// estimatedItemBytes is the estimated size of a span, data point or log record of a batch counted in items
+const estimatedItemBytes = 1024
+
+// Environment describes where the collector runs, a zero value is unknown and skips the checks depending on it
+type Environment struct {
+	// MemoryMiB is the memory limit of the collector container
+	MemoryMiB float64 `json:"memoryMiB,omitempty"`
+	// CPUs is the CPU limit of the collector container e.g. 0.5
+	CPUs float64 `json:"cpus,omitempty"`
+	// MaxRecvMsgSizeMiB is the maximum message size of the gRPC servers the collector exports to, defaults to 4
+	MaxRecvMsgSizeMiB float64 `json:"maxRecvMsgSizeMiB,omitempty"`
+}
+
+// Tuning returns the settings of a configuration exceeding known hard limits: batches larger than the gRPC message
+// size of the backend, memory_limiter budgets and queues beyond the container memory and more queue consumers than the
+// container CPUs can run
+func Tuning(config *collectorconfig.Config, environment Environment) []Finding {
+	if environment.MaxRecvMsgSizeMiB <= 0 {
+		environment.MaxRecvMsgSizeMiB = DefaultGRPCMaxRecvMsgSizeMiB
+	}
+	var findings []Finding
+	findings = append(findings, grpcMessageSizes(config, environment)...)
+	findings = append(findings, memoryLimits(config, environment)...)
+	findings = append(findings, queueLimits(config, environment)...)
+	return findings
+}
+
+// grpcMessageSizes checks the batches sent by the otlp exporters against the maximum message size of the backend,
+// larger requests are rejected with ResourceExhausted and dropped
+func grpcMessageSizes(config *collectorconfig.Config, environment Environment) []Finding {
+	maxBytes := environment.MaxRecvMsgSizeMiB * 1024 * 1024
+	var findings []Finding
+	for _, exporterID := range slices.Sorted(maps.Keys(config.Exporters)) {
+		if collectorconfig.ComponentType(exporterID) != "otlp" || !isExporterUsed(config, exporterID) {
+			continue
+		}
+		exporter, _ := config.Exporters[exporterID].(map[string]interface{})
+		batch, _ := nestedMap(exporter, "sending_queue", "batch")
+		if batch != nil {
+			if size, ok := batchBytes(batch, "max_size", nestedString(exporter, "sending_queue", "sizer")); ok && size > maxBytes {
+				findings = append(findings, grpcMessageSizeFinding("exporters::"+exporterID+"::sending_queue::batch::max_size", size, environment))
+			}
+		}
+
+		// The batch processors of the pipelines of the exporter
+		for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+			pipeline := config.Service.Pipelines[pipelineID]
+			if !slices.Contains(pipeline.Exporters, exporterID) {
+				continue
+			}
+			for _, processorID := range pipeline.Processors {
+				if collectorconfig.ComponentType(processorID) != "batch" {
+					continue
+				}
+				processor, _ := config.Processors[processorID].(map[string]interface{})
+				path := "processors::" + processorID
+				maxSize, _ := collectorconfig.Number(processor["send_batch_max_size"])
+				if maxSize <= 0 {
+					sendSize, ok := collectorconfig.Number(processor["send_batch_size"])
+					if !ok {
+						sendSize = 8192
+					}
+					if sendSize*estimatedItemBytes > maxBytes {
+						findings = append(findings, Finding{
+							Type:     FindingGRPCMessageSize,
+							Severity: collectorconfig.SeverityWarning,
+							Paths:    []string{path + "::send_batch_size", "exporters::" + exporterID},
+							Message: fmt.Sprintf("%s has no send_batch_max_size, its batches of %s items or more are estimated at %s at %d bytes per item which exceeds the %s gRPC message limit of the backend %s sends to, set send_batch_max_size to split them",
+								processorID, collectorconfig.FormatNumber(sendSize), formatMiB(sendSize*estimatedItemBytes), estimatedItemBytes, formatMiB(maxBytes), exporterID),
+						})
+					}
+					continue
+				}
+				if maxSize*estimatedItemBytes > maxBytes {
+					findings = append(findings, grpcMessageSizeFinding(path+"::send_batch_max_size", maxSize*estimatedItemBytes, environment))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func grpcMessageSizeFinding(path string, size float64, environment Environment) Finding {
+	return Finding{
+		Type:     FindingGRPCMessageSize,
+		Severity: collectorconfig.SeverityWarning,
+		Paths:    []string{path},
+		Message: fmt.Sprintf("batches of up to %s exceed the %s gRPC message limit of the backend (max_recv_msg_size_mib of its otlp receiver), the requests are rejected with ResourceExhausted and dropped, lower the batch size or raise the limit of the backend",
+			formatMiB(size), formatMiB(environment.MaxRecvMsgSizeMiB*1024*1024)),
+	}
+}
+
+// memoryLimits checks the memory_limiter budgets against the container memory, a budget above the container memory
+// never refuses data before the container is OOM killed
+func memoryLimits(config *collectorconfig.Config, environment Environment) []Finding {
+	var findings []Finding
+	for _, id := range slices.Sorted(maps.Keys(config.Processors)) {
+		if collectorconfig.ComponentType(id) != "memory_limiter" || !isProcessorUsed(config, id) {
+			continue
+		}
+		processor, _ := config.Processors[id].(map[string]interface{})
+		path := "processors::" + id
+		limit, hasLimit := collectorconfig.Number(processor["limit_mib"])
+		spike, hasSpike := collectorconfig.Number(processor["spike_limit_mib"])
+		limitPercentage, hasLimitPercentage := collectorconfig.Number(processor["limit_percentage"])
+		spikePercentage, hasSpikePercentage := collectorconfig.Number(processor["spike_limit_percentage"])
+
+		if hasLimit && hasSpike && spike >= limit {
+			findings = append(findings, Finding{
+				Type:     FindingMemoryLimit,
+				Severity: collectorconfig.SeverityError,
+				Paths:    []string{path + "::spike_limit_mib"},
+				Message:  fmt.Sprintf("spike_limit_mib %s must be lower than limit_mib %s, the collector fails to start", collectorconfig.FormatNumber(spike), collectorconfig.FormatNumber(limit)),
+			})
+		}
+		if hasLimitPercentage && hasSpikePercentage && spikePercentage >= limitPercentage {
+			findings = append(findings, Finding{
+				Type:     FindingMemoryLimit,
+				Severity: collectorconfig.SeverityError,
+				Paths:    []string{path + "::spike_limit_percentage"},
+				Message:  fmt.Sprintf("spike_limit_percentage %s must be lower than limit_percentage %s, the collector fails to start", collectorconfig.FormatNumber(spikePercentage), collectorconfig.FormatNumber(limitPercentage)),
+			})
+		}
+		if hasLimitPercentage && limitPercentage > 90 {
+			findings = append(findings, Finding{
+				Type:     FindingMemoryLimit,
+				Severity: collectorconfig.SeverityWarning,
+				Paths:    []string{path + "::limit_percentage"},
+				Message:  fmt.Sprintf("limit_percentage %s%% leaves no headroom for the memory outside of the Go heap, the container is OOM killed before the limiter refuses data, use 75 to 80 percent", collectorconfig.FormatNumber(limitPercentage)),
+			})
+		}
+
+		if environment.MemoryMiB <= 0 || !hasLimit {
+			continue
+		}
+		switch {
+		case limit > environment.MemoryMiB:
+			findings = append(findings, Finding{
+				Type:     FindingMemoryLimit,
+				Severity: collectorconfig.SeverityError,
+				Paths:    []string{path + "::limit_mib"},
+				Message:  fmt.Sprintf("limit_mib %s exceeds the container memory of %s MiB, the container is OOM killed before the limiter refuses data, set limit_mib to about %s or use limit_percentage: 80", collectorconfig.FormatNumber(limit), collectorconfig.FormatNumber(environment.MemoryMiB), collectorconfig.FormatNumber(math.Floor(environment.MemoryMiB*0.8))),
+			})
+		case limit > environment.MemoryMiB*0.9:
+			findings = append(findings, Finding{
+				Type:     FindingMemoryLimit,
+				Severity: collectorconfig.SeverityWarning,
+				Paths:    []string{path + "::limit_mib"},
+				Message:  fmt.Sprintf("limit_mib %s is above 90%% of the container memory of %s MiB, the memory outside of the Go heap can get the container OOM killed before the limiter refuses data, set limit_mib to about %s", collectorconfig.FormatNumber(limit), collectorconfig.FormatNumber(environment.MemoryMiB), collectorconfig.FormatNumber(math.Floor(environment.MemoryMiB*0.8))),
+			})
+		}
+	}
+	return findings
+}
+
+// queueLimits checks the sending queues measured in bytes against the container memory and the queue consumers
+// against the container CPUs
+func queueLimits(config *collectorconfig.Config, environment Environment) []Finding {
+	var findings []Finding
+	consumers := 0.0
+	var consumerPaths []string
+	for _, id := range slices.Sorted(maps.Keys(config.Exporters)) {
+		if !isExporterUsed(config, id) {
+			continue
+		}
+		exporter, _ := config.Exporters[id].(map[string]interface{})
+		queue, _ := nestedMap(exporter, "sending_queue")
+		if queue == nil {
+			continue
+		}
+		if enabled, ok := queue["enabled"].(bool); ok && !enabled {
+			continue
+		}
+		path := "exporters::" + id + "::sending_queue"
+		if size, ok := batchBytes(queue, "queue_size", nestedString(exporter, "sending_queue", "sizer")); ok && environment.MemoryMiB > 0 && queue["storage"] == nil && size > environment.MemoryMiB*1024*1024/2 {
+			findings = append(findings, Finding{
+				Type:     FindingQueueMemory,
+				Severity: collectorconfig.SeverityWarning,
+				Paths:    []string{path + "::queue_size"},
+				Message:  fmt.Sprintf("the in-memory queue of %s holds up to %s, more than half of the container memory of %s MiB, a backend outage fills it and the container is OOM killed, lower queue_size or use a persistent queue with storage", id, formatMiB(size), collectorconfig.FormatNumber(environment.MemoryMiB)),
+			})
+		}
+		numConsumers, ok := collectorconfig.Number(queue["num_consumers"])
+		if !ok {
+			numConsumers = 10
+		}
+		consumers += numConsumers
+		consumerPaths = append(consumerPaths, path+"::num_consumers")
+	}
+	if environment.CPUs > 0 && consumers > environment.CPUs*16 {
+		findings = append(findings, Finding{
+			Type:     FindingQueueConsumers,
+			Severity: collectorconfig.SeverityWarning,
+			Paths:    consumerPaths,
+			Message:  fmt.Sprintf("the sending queues run %s consumers on %s CPUs, the consumers serialize and compress the requests concurrently and the CPU limit throttles them, lower num_consumers or raise the CPU limit", collectorconfig.FormatNumber(consumers), collectorconfig.FormatNumber(environment.CPUs)),
+		})
+	}
+	return findings
+}
+
+// batchBytes returns the size in bytes of a size setting of a queue or a batch measured with the sizer, sizes in
+// items are estimated and sizes in requests are unknown
+func batchBytes(settings map[string]interface{}, key string, sizer string) (float64, bool) {
+	if batchSizer, ok := settings["sizer"].(string); ok && key == "max_size" {
+		sizer = batchSizer
+	}
+	size, ok := collectorconfig.Number(settings[key])
+	if !ok || size <= 0 {
+		return 0, false
+	}
+	switch sizer {
+	case "bytes":
+		return size, true
+	case "items":
+		return size * estimatedItemBytes, true
+	}
+	return 0, false
+}
+
+func isExporterUsed(config *collectorconfig.Config, id string) bool {
+	for _, pipeline := range config.Service.Pipelines {
+		if slices.Contains(pipeline.Exporters, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedMap returns the map at the path of keys
+func nestedMap(value map[string]interface{}, keys ...string) (map[string]interface{}, bool) {
+	for _, key := range keys {
+		nested, ok := value[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value = nested
+	}
+	return value, true
+}
+
+// nestedString returns the string at the path of keys
+func nestedString(value map[string]interface{}, keys ...string) string {
+	parent, ok := nestedMap(value, keys[:len(keys)-1]...)
+	if !ok {
+		return ""
+	}
+	s, _ := parent[keys[len(keys)-1]].(string)
+	return s
+}
+
+func formatMiB(bytes float64) string {
+	return fmt.Sprintf("%.1f MiB", bytes/1024/1024)
+}
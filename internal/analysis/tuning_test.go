@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const tuningConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+    send_batch_size: 10000
+  batch/logs:
+    send_batch_size: 1000
+    send_batch_max_size: 2000
+  memory_limiter:
+    check_interval: 1s
+    limit_mib: 1000
+    spike_limit_mib: 1000
+exporters:
+  otlp:
+    endpoint: backend:4317
+    sending_queue:
+      sizer: bytes
+      queue_size: 800000000
+      num_consumers: 20
+      batch:
+        max_size: 8000000
+  otlp/logs:
+    endpoint: backend:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [otlp]
+    logs:
+      receivers: [otlp]
+      processors: [memory_limiter, batch/logs]
+      exporters: [otlp/logs]
+`
+
+func TestTuning(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(tuningConfig))
+	require.NoError(t, err)
+
+	findings := Tuning(config, Environment{MemoryMiB: 1024, CPUs: 1})
+	var paths [][]string
+	for _, finding := range findings {
+		paths = append(paths, finding.Paths)
+	}
+	assert.Equal(t, [][]string{
+		{"exporters::otlp::sending_queue::batch::max_size"},
+		{"processors::batch::send_batch_size", "exporters::otlp"},
+		{"processors::memory_limiter::spike_limit_mib"},
+		{"processors::memory_limiter::limit_mib"},
+		{"exporters::otlp::sending_queue::queue_size"},
+		{"exporters::otlp::sending_queue::num_consumers"},
+	}, paths)
+	assert.Equal(t, FindingGRPCMessageSize, findings[0].Type)
+	assert.Contains(t, findings[0].Message, "batches of up to 7.6 MiB exceed the 4.0 MiB gRPC message limit")
+	assert.Equal(t, collectorconfig.SeverityError, findings[2].Severity)
+	assert.Equal(t, FindingMemoryLimit, findings[3].Type)
+	assert.Equal(t, collectorconfig.SeverityWarning, findings[3].Severity)
+	assert.Contains(t, findings[3].Message, "set limit_mib to about 819")
+	assert.Equal(t, FindingQueueMemory, findings[4].Type)
+	assert.Equal(t, FindingQueueConsumers, findings[5].Type)
+	assert.Contains(t, findings[5].Message, "20 consumers on 1 CPUs")
+}
+
+func TestTuning_Environment(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(tuningConfig))
+	require.NoError(t, err)
+
+	// Without the container limits only the gRPC message size and the memory_limiter budget are checked
+	findings := Tuning(config, Environment{MaxRecvMsgSizeMiB: 16})
+	require.Len(t, findings, 1)
+	assert.Equal(t, []string{"processors::memory_limiter::spike_limit_mib"}, findings[0].Paths)
+
+	findings = Tuning(config, Environment{MemoryMiB: 4096})
+	for _, finding := range findings {
+		assert.NotEqual(t, []string{"processors::memory_limiter::limit_mib"}, finding.Paths)
+	}
+}
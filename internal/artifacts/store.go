@@ -0,0 +1,164 @@
+package artifacts
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+// URIScheme is the scheme of the artifact resource URIs
+const URIScheme = "artifact"
+
+// Artifact is a generated tool result that can be fetched by clients as an MCP resource
+type Artifact struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	MIMEType string `json:"mimeType"`
+	// Content is kept in the shared cache, it is not stored with the artifact
+	Content string `json:"-"`
+	// Hash is the SHA-256 of the content in the shared cache
+	Hash      string    `json:"hash"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// URI returns the resource URI of the artifact e.g. artifact://3f2a.../collector.yaml
+func (a *Artifact) URI() string {
+	return fmt.Sprintf("%s://%s/%s", URIScheme, a.ID, a.Name)
+}
+
+// Store keeps the artifacts in the storage of the server and their content in the shared cache, artifacts expire after
+// the TTL or when the cache evicts their content
+type Store struct {
+	ttl     time.Duration
+	cache   *cache.Cache
+	storage storage.Store
+	now     func() time.Time
+}
+
+// NewStore creates an artifact store with the given TTL keeping the artifacts in the storage and the content in the
+// cache
+func NewStore(ttl time.Duration, contentCache *cache.Cache, store storage.Store) *Store {
+	return &Store{
+		ttl:     ttl,
+		cache:   contentCache,
+		storage: store,
+		now:     time.Now,
+	}
+}
+
+// TTL returns how long the artifacts are kept
+func (s *Store) TTL() time.Duration {
+	return s.ttl
+}
+
+// Put stores the content and returns the created artifact
+func (s *Store) Put(name, mimeType, content string) (*Artifact, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate artifact ID: %w", err)
+	}
+
+	hash, err := s.cache.Put(cache.NamespaceArtifacts, id, []byte(content), s.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	if err := s.evictExpired(); err != nil {
+		return nil, err
+	}
+	artifact := &Artifact{
+		ID:        id,
+		Name:      name,
+		MIMEType:  mimeType,
+		Hash:      hash,
+		ExpiresAt: s.now().Add(s.ttl),
+	}
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal artifact: %w", err)
+	}
+	if err := s.storage.Put(storage.NamespaceArtifacts, id, data); err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+	withContent := *artifact
+	withContent.Content = content
+	return &withContent, nil
+}
+
+// Get returns the artifact for the resource URI, expired artifacts are not returned
+func (s *Store) Get(uri string) (*Artifact, error) {
+	rest, ok := strings.CutPrefix(uri, URIScheme+"://")
+	if !ok {
+		return nil, fmt.Errorf("invalid artifact URI %q", uri)
+	}
+	id, _, _ := strings.Cut(rest, "/")
+
+	artifact, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if artifact == nil || s.now().After(artifact.ExpiresAt) {
+		return nil, fmt.Errorf("artifact %q not found or expired", uri)
+	}
+	content, found := s.cache.Get(cache.NamespaceArtifacts, id)
+	if !found {
+		if err := s.storage.Delete(storage.NamespaceArtifacts, id); err != nil {
+			return nil, fmt.Errorf("failed to delete artifact: %w", err)
+		}
+		return nil, fmt.Errorf("artifact %q was evicted from the cache, call the tool again", uri)
+	}
+	withContent := *artifact
+	withContent.Content = string(content)
+	return &withContent, nil
+}
+
+// get returns the stored artifact of the ID without its content, nil if it is not stored
+func (s *Store) get(id string) (*Artifact, error) {
+	data, found, err := s.storage.Get(storage.NamespaceArtifacts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	var artifact Artifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to parse artifact %s: %w", id, err)
+	}
+	return &artifact, nil
+}
+
+// evictExpired removes the expired artifacts
+func (s *Store) evictExpired() error {
+	ids, err := s.storage.Keys(storage.NamespaceArtifacts, "")
+	if err != nil {
+		return fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	now := s.now()
+	for _, id := range ids {
+		artifact, err := s.get(id)
+		if err != nil {
+			return err
+		}
+		if artifact != nil && now.After(artifact.ExpiresAt) {
+			if err := s.storage.Delete(storage.NamespaceArtifacts, id); err != nil {
+				return fmt.Errorf("failed to delete artifact: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,80 @@
+package artifacts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	contentCache, err := cache.New("", 0)
+	require.NoError(t, err)
+	store := NewStore(time.Minute, contentCache, storage.NewMemory())
+	store.now = func() time.Time { return now }
+
+	artifact, err := store.Put("collector.yaml", "application/yaml", "receivers:")
+	require.NoError(t, err)
+	assert.Equal(t, "artifact://"+artifact.ID+"/collector.yaml", artifact.URI())
+
+	got, err := store.Get(artifact.URI())
+	require.NoError(t, err)
+	assert.Equal(t, "receivers:", got.Content)
+
+	now = now.Add(2 * time.Minute)
+	_, err = store.Get(artifact.URI())
+	require.Error(t, err)
+
+	_, err = store.Get("file:///etc/passwd")
+	require.Error(t, err)
+}
+
+func TestStore_Evicted(t *testing.T) {
+	contentCache, err := cache.New("", 16)
+	require.NoError(t, err)
+	store := NewStore(time.Minute, contentCache, storage.NewMemory())
+
+	first, err := store.Put("first.yaml", "application/yaml", "receivers: {}")
+	require.NoError(t, err)
+	second, err := store.Put("second.yaml", "application/yaml", "exporters: {}")
+	require.NoError(t, err)
+	// Identical content is stored once
+	third, err := store.Put("third.yaml", "application/yaml", "exporters: {}")
+	require.NoError(t, err)
+	assert.Equal(t, second.Hash, third.Hash)
+	assert.Equal(t, 1, contentCache.Stats().Blobs)
+
+	_, err = store.Get(first.URI())
+	require.ErrorContains(t, err, "was evicted from the cache")
+	got, err := store.Get(second.URI())
+	require.NoError(t, err)
+	assert.Equal(t, "exporters: {}", got.Content)
+}
+
+func TestStore_Restart(t *testing.T) {
+	cacheDir, storageDir := t.TempDir(), t.TempDir()
+	newStore := func() (*Store, *cache.Cache) {
+		contentCache, err := cache.New(cacheDir, 0)
+		require.NoError(t, err)
+		disk, err := storage.NewDisk(storageDir)
+		require.NoError(t, err)
+		return NewStore(time.Minute, contentCache, disk), contentCache
+	}
+	store, contentCache := newStore()
+	artifact, err := store.Put("collector.yaml", "application/yaml", "receivers:")
+	require.NoError(t, err)
+	// The server closes the cache on shutdown
+	require.NoError(t, contentCache.Close())
+
+	store, _ = newStore()
+	got, err := store.Get(artifact.URI())
+	require.NoError(t, err)
+	assert.Equal(t, "receivers:", got.Content)
+	assert.Equal(t, "collector.yaml", got.Name)
+	assert.Equal(t, artifact.ExpiresAt.UTC(), got.ExpiresAt.UTC())
+}
@@ -0,0 +1,428 @@
+// Package cache is the content-addressable cache shared by the features of the server: the GitHub responses, the
+// embeddings of an embeddings API and the generated artifacts. Entries are looked up by namespace and key and point to
+// a blob addressed by the SHA-256 of its content, so identical content is stored once.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultMaxSize is the default maximum size in bytes of the cached content
+const DefaultMaxSize = 256 * 1024 * 1024
+
+// The namespaces of the features using the cache
+const (
+	NamespaceGitHub     = "github"
+	NamespaceEmbeddings = "embeddings"
+	NamespaceArtifacts  = "artifacts"
+)
+
+const (
+	// indexFile is the file of the entries in the cache directory
+	indexFile = "index.json"
+	// indexFlushDelay is the time the changes of the entries are collected before the index is written, a build of
+	// the documentation index puts thousands of embeddings and rewriting the index for each of them is quadratic
+	indexFlushDelay = time.Second
+	// blobsDir is the directory of the blobs in the cache directory, a blob is stored in blobs/<hash[:2]>/<hash>
+	blobsDir = "blobs"
+)
+
+// Observer is notified of the cache lookups e.g. by the server metrics, the namespace is reported as the cache
+type Observer interface {
+	CacheAccess(cache string, hit bool)
+}
+
+// entry maps a key of a namespace to the hash of its content
+type entry struct {
+	Namespace string    `json:"namespace"`
+	Key       string    `json:"key"`
+	Hash      string    `json:"hash"`
+	Size      int64     `json:"size"`
+	ExpiresAt time.Time `json:"expiresAt,omitzero"`
+	// AccessedAt orders the entries for the eviction, it is persisted with the next change of the index
+	AccessedAt time.Time `json:"accessedAt"`
+}
+
+type entryKey struct {
+	namespace string
+	key       string
+}
+
+// blob is a stored content, it is removed when no entry references it
+type blob struct {
+	size int64
+	refs int
+	// content is kept in memory if the cache has no directory
+	content []byte
+}
+
+// Cache is a content-addressable cache evicting the least recently used entries above the maximum size. The blobs and
+// the index are persisted in a directory, or kept in memory if the directory is empty. The blobs are written by Put and
+// the index shortly after, Close writes the pending changes of the index.
+type Cache struct {
+	dir      string
+	maxSize  int64
+	observer Observer
+
+	mutex   sync.Mutex
+	entries map[entryKey]*entry
+	blobs   map[string]*blob
+	size    int64
+	now     func() time.Time
+	// dirty is true if the entries changed since the index was written, the flush timer writes it after flushDelay
+	dirty      bool
+	flushDelay time.Duration
+	flushTimer *time.Timer
+}
+
+// New creates a cache persisted in the directory, the entries of a previous run are loaded. The cache is kept in
+// memory if dir is empty and the size is not limited if maxSize is 0.
+func New(dir string, maxSize int64) (*Cache, error) {
+	c := &Cache{
+		dir:      dir,
+		maxSize:  maxSize,
+		observer: noopObserver{},
+		entries:  make(map[entryKey]*entry),
+		blobs:    make(map[string]*blob),
+		now:      time.Now,
+
+		flushDelay: indexFlushDelay,
+	}
+	if dir == "" {
+		return c, nil
+	}
+	if err := os.MkdirAll(filepath.Join(dir, blobsDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, indexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache index: %w", err)
+	}
+	var entries []*entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse cache index %s: %w", filepath.Join(dir, indexFile), err)
+	}
+	for _, e := range entries {
+		// Entries whose blob was removed e.g. by the cache clear command are dropped
+		if _, err := os.Stat(c.blobPath(e.Hash)); err != nil {
+			continue
+		}
+		c.add(e)
+	}
+	return c, nil
+}
+
+// SetObserver configures the observer of the cache lookups
+func (c *Cache) SetObserver(observer Observer) {
+	c.observer = observer
+}
+
+// Dir returns the directory of the cache, empty if the cache is kept in memory
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get returns the content of the key, expired and evicted keys are not found
+func (c *Cache) Get(namespace, key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, exists := c.entries[entryKey{namespace, key}]
+	if exists && !e.ExpiresAt.IsZero() && c.now().After(e.ExpiresAt) {
+		c.remove(e)
+		exists = false
+	}
+	if !exists {
+		c.observer.CacheAccess(namespace, false)
+		return nil, false
+	}
+	content, err := c.readBlob(e.Hash)
+	if err != nil {
+		c.remove(e)
+		c.observer.CacheAccess(namespace, false)
+		return nil, false
+	}
+	e.AccessedAt = c.now()
+	c.observer.CacheAccess(namespace, true)
+	return content, true
+}
+
+// Put stores the content of the key and returns the hash of the content, the key expires after the TTL unless it is 0.
+// The least recently used entries are evicted if the cache exceeds its maximum size.
+func (c *Cache) Put(namespace, key string, content []byte, ttl time.Duration) (string, error) {
+	size := int64(len(content))
+	if c.maxSize > 0 && size > c.maxSize {
+		return "", fmt.Errorf("content of %d bytes exceeds the cache size of %d bytes", size, c.maxSize)
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if previous, exists := c.entries[entryKey{namespace, key}]; exists {
+		c.remove(previous)
+	}
+	if _, exists := c.blobs[hash]; !exists {
+		if err := c.writeBlob(hash, content); err != nil {
+			return "", err
+		}
+	}
+	e := &entry{Namespace: namespace, Key: key, Hash: hash, Size: size, AccessedAt: c.now()}
+	if ttl > 0 {
+		e.ExpiresAt = e.AccessedAt.Add(ttl)
+	}
+	c.add(e)
+	if c.dir == "" {
+		c.blobs[hash].content = bytes.Clone(content)
+	}
+	c.evict(e)
+	c.scheduleFlush()
+	return hash, nil
+}
+
+// Flush writes the pending changes of the index
+func (c *Cache) Flush() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.flush()
+}
+
+// Close writes the pending changes of the index and stops the flush timer, the cache can still be used and is
+// flushed again by the next Close
+func (c *Cache) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.flushTimer != nil {
+		c.flushTimer.Stop()
+		c.flushTimer = nil
+	}
+	return c.flush()
+}
+
+// NamespaceStats are the entries of a namespace, the size counts shared blobs once per entry
+type NamespaceStats struct {
+	Namespace string `json:"namespace"`
+	Entries   int    `json:"entries"`
+	Size      int64  `json:"size"`
+}
+
+// Stats describe the content of the cache
+type Stats struct {
+	Dir        string           `json:"dir,omitempty"`
+	MaxSize    int64            `json:"maxSize"`
+	Size       int64            `json:"size"`
+	Entries    int              `json:"entries"`
+	Blobs      int              `json:"blobs"`
+	Namespaces []NamespaceStats `json:"namespaces"`
+}
+
+// Stats returns the entries by namespace and the size of the stored blobs
+func (c *Cache) Stats() Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := Stats{Dir: c.dir, MaxSize: c.maxSize, Size: c.size, Entries: len(c.entries), Blobs: len(c.blobs), Namespaces: []NamespaceStats{}}
+	namespaces := make(map[string]*NamespaceStats)
+	for _, e := range c.entries {
+		ns, exists := namespaces[e.Namespace]
+		if !exists {
+			ns = &NamespaceStats{Namespace: e.Namespace}
+			namespaces[e.Namespace] = ns
+		}
+		ns.Entries++
+		ns.Size += e.Size
+	}
+	for _, ns := range namespaces {
+		stats.Namespaces = append(stats.Namespaces, *ns)
+	}
+	sort.Slice(stats.Namespaces, func(i, j int) bool { return stats.Namespaces[i].Namespace < stats.Namespaces[j].Namespace })
+	return stats
+}
+
+// Clear removes the entries of the namespace, or all entries if the namespace is empty, and returns the number of
+// removed entries
+func (c *Cache) Clear(namespace string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	removed := 0
+	for _, e := range c.entries {
+		if namespace == "" || e.Namespace == namespace {
+			c.remove(e)
+			removed++
+		}
+	}
+	return removed, c.writeIndex()
+}
+
+// add indexes the entry and references its blob, the caller must hold the mutex
+func (c *Cache) add(e *entry) {
+	b, exists := c.blobs[e.Hash]
+	if !exists {
+		b = &blob{size: e.Size}
+		c.blobs[e.Hash] = b
+		c.size += e.Size
+	}
+	b.refs++
+	c.entries[entryKey{e.Namespace, e.Key}] = e
+}
+
+// remove removes the entry and the blob no other entry references, the caller must hold the mutex
+func (c *Cache) remove(e *entry) {
+	delete(c.entries, entryKey{e.Namespace, e.Key})
+	b := c.blobs[e.Hash]
+	if b == nil {
+		return
+	}
+	if b.refs--; b.refs > 0 {
+		return
+	}
+	delete(c.blobs, e.Hash)
+	c.size -= b.size
+	if c.dir != "" {
+		_ = os.Remove(c.blobPath(e.Hash))
+	}
+}
+
+// evict removes the expired entries and then the least recently used entries until the cache fits its maximum size,
+// the kept entry is not evicted. The caller must hold the mutex.
+func (c *Cache) evict(kept *entry) {
+	now := c.now()
+	var candidates []*entry
+	for _, e := range c.entries {
+		switch {
+		case e == kept:
+		case !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt):
+			c.remove(e)
+		default:
+			candidates = append(candidates, e)
+		}
+	}
+	if c.maxSize <= 0 || c.size <= c.maxSize {
+		return
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].AccessedAt.Before(candidates[j].AccessedAt) })
+	for _, e := range candidates {
+		if c.size <= c.maxSize {
+			return
+		}
+		c.remove(e)
+	}
+}
+
+func (c *Cache) blobPath(hash string) string {
+	return filepath.Join(c.dir, blobsDir, hash[:2], hash)
+}
+
+// readBlob returns the content of the blob, the caller must hold the mutex
+func (c *Cache) readBlob(hash string) ([]byte, error) {
+	if c.dir == "" {
+		return c.blobs[hash].content, nil
+	}
+	return os.ReadFile(c.blobPath(hash))
+}
+
+// writeBlob stores the content of a new blob on disk, the caller must hold the mutex
+func (c *Cache) writeBlob(hash string, content []byte) error {
+	if c.dir == "" {
+		return nil
+	}
+	path := c.blobPath(hash)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return writeFile(path, content)
+}
+
+// scheduleFlush marks the index as changed and starts the flush timer unless it is running, the caller must hold the
+// mutex
+func (c *Cache) scheduleFlush() {
+	if c.dir == "" {
+		return
+	}
+	c.dirty = true
+	if c.flushTimer != nil {
+		return
+	}
+	c.flushTimer = time.AfterFunc(c.flushDelay, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.flushTimer = nil
+		// A failed write keeps the changes pending, the next flush or Close writes them and returns the error
+		_ = c.flush()
+	})
+}
+
+// flush writes the index if the entries changed since it was written, the caller must hold the mutex
+func (c *Cache) flush() error {
+	if !c.dirty {
+		return nil
+	}
+	return c.writeIndex()
+}
+
+// writeIndex persists the entries, the caller must hold the mutex
+func (c *Cache) writeIndex() error {
+	if c.dir == "" {
+		return nil
+	}
+	entries := make([]*entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache index: %w", err)
+	}
+	if err := writeFile(filepath.Join(c.dir, indexFile), data); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// writeFile writes the file atomically, a concurrent reader sees the previous or the new content
+func writeFile(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
+
+type noopObserver struct{}
+
+func (noopObserver) CacheAccess(string, bool) {}
@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	hits, misses int
+}
+
+func (o *recordingObserver) CacheAccess(_ string, hit bool) {
+	if hit {
+		o.hits++
+	} else {
+		o.misses++
+	}
+}
+
+func TestCache(t *testing.T) {
+	for name, dir := range map[string]string{"memory": "", "directory": t.TempDir()} {
+		t.Run(name, func(t *testing.T) {
+			c, err := New(dir, 0)
+			require.NoError(t, err)
+			observer := &recordingObserver{}
+			c.SetObserver(observer)
+
+			_, found := c.Get(NamespaceGitHub, "https://raw.githubusercontent.com/README.md")
+			assert.False(t, found)
+
+			hash, err := c.Put(NamespaceGitHub, "https://raw.githubusercontent.com/README.md", []byte("# Redis Receiver"), 0)
+			require.NoError(t, err)
+			assert.Len(t, hash, 64)
+			content, found := c.Get(NamespaceGitHub, "https://raw.githubusercontent.com/README.md")
+			require.True(t, found)
+			assert.Equal(t, "# Redis Receiver", string(content))
+			assert.Equal(t, &recordingObserver{hits: 1, misses: 1}, observer)
+
+			// Identical content is stored once
+			sameHash, err := c.Put(NamespaceArtifacts, "3f2a", []byte("# Redis Receiver"), 0)
+			require.NoError(t, err)
+			assert.Equal(t, hash, sameHash)
+			assert.Equal(t, Stats{Dir: dir, Size: 16, Entries: 2, Blobs: 1, Namespaces: []NamespaceStats{
+				{Namespace: NamespaceArtifacts, Entries: 1, Size: 16},
+				{Namespace: NamespaceGitHub, Entries: 1, Size: 16},
+			}}, c.Stats())
+
+			removed, err := c.Clear(NamespaceGitHub)
+			require.NoError(t, err)
+			assert.Equal(t, 1, removed)
+			_, found = c.Get(NamespaceGitHub, "https://raw.githubusercontent.com/README.md")
+			assert.False(t, found)
+			content, found = c.Get(NamespaceArtifacts, "3f2a")
+			require.True(t, found)
+			assert.Equal(t, "# Redis Receiver", string(content))
+
+			removed, err = c.Clear("")
+			require.NoError(t, err)
+			assert.Equal(t, 1, removed)
+			assert.Equal(t, Stats{Dir: dir, Namespaces: []NamespaceStats{}}, c.Stats())
+			require.NoError(t, c.Close())
+		})
+	}
+}
+
+func TestCache_Expiration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c, err := New("", 0)
+	require.NoError(t, err)
+	c.now = func() time.Time { return now }
+
+	_, err = c.Put(NamespaceGitHub, "readme", []byte("README"), time.Hour)
+	require.NoError(t, err)
+	_, found := c.Get(NamespaceGitHub, "readme")
+	assert.True(t, found)
+
+	now = now.Add(2 * time.Hour)
+	_, found = c.Get(NamespaceGitHub, "readme")
+	assert.False(t, found)
+	assert.Zero(t, c.Stats().Size)
+}
+
+func TestCache_Eviction(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c, err := New("", 10)
+	require.NoError(t, err)
+	c.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	_, err = c.Put(NamespaceEmbeddings, "a", []byte("aaaa"), 0)
+	require.NoError(t, err)
+	_, err = c.Put(NamespaceEmbeddings, "b", []byte("bbbb"), 0)
+	require.NoError(t, err)
+	// a is used more recently than b, b is evicted
+	_, found := c.Get(NamespaceEmbeddings, "a")
+	require.True(t, found)
+	_, err = c.Put(NamespaceEmbeddings, "c", []byte("cccc"), 0)
+	require.NoError(t, err)
+
+	_, found = c.Get(NamespaceEmbeddings, "b")
+	assert.False(t, found)
+	_, found = c.Get(NamespaceEmbeddings, "a")
+	assert.True(t, found)
+	_, found = c.Get(NamespaceEmbeddings, "c")
+	assert.True(t, found)
+	assert.Equal(t, int64(8), c.Stats().Size)
+
+	_, err = c.Put(NamespaceEmbeddings, "d", []byte("01234567890"), 0)
+	require.EqualError(t, err, "content of 11 bytes exceeds the cache size of 10 bytes")
+}
+
+func TestCache_Persistence(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(dir, 0)
+	require.NoError(t, err)
+	_, err = c.Put(NamespaceGitHub, "readme", []byte("README"), 0)
+	require.NoError(t, err)
+	hash, err := c.Put(NamespaceGitHub, "issues", []byte("[]"), 0)
+	require.NoError(t, err)
+	// The index is written after the flush delay or by Close
+	assert.NoFileExists(t, filepath.Join(dir, indexFile))
+	require.NoError(t, c.Close())
+
+	reopened, err := New(dir, 0)
+	require.NoError(t, err)
+	content, found := reopened.Get(NamespaceGitHub, "readme")
+	require.True(t, found)
+	assert.Equal(t, "README", string(content))
+
+	// A removed blob drops its entry
+	require.NoError(t, os.Remove(filepath.Join(dir, blobsDir, hash[:2], hash)))
+	_, found = reopened.Get(NamespaceGitHub, "issues")
+	assert.False(t, found)
+	reopened, err = New(dir, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reopened.Stats().Entries)
+
+	// Replacing the content of a key removes the previous blob
+	_, err = reopened.Put(NamespaceGitHub, "readme", []byte("README v2"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reopened.Stats().Blobs)
+	content, found = reopened.Get(NamespaceGitHub, "readme")
+	require.True(t, found)
+	assert.Equal(t, "README v2", string(content))
+}
+
+func TestCache_FlushDelay(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(dir, 0)
+	require.NoError(t, err)
+	c.flushDelay = 10 * time.Millisecond
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, err = c.Put(NamespaceEmbeddings, key, []byte(key), 0)
+		require.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool {
+		reopened, err := New(dir, 0)
+		return err == nil && reopened.Stats().Entries == 3
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, c.Flush())
+	require.NoError(t, c.Close())
+}
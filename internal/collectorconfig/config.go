@@ -0,0 +1,116 @@
+package collectorconfig
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config represents an OpenTelemetry collector configuration, an empty service section is not marshaled so a fragment
+// e.g. a single processor stays a fragment
+type Config struct {
+	Extensions map[string]interface{} `yaml:"extensions,omitempty" json:"extensions,omitempty"`
+	Receivers  map[string]interface{} `yaml:"receivers,omitempty" json:"receivers,omitempty"`
+	Processors map[string]interface{} `yaml:"processors,omitempty" json:"processors,omitempty"`
+	Exporters  map[string]interface{} `yaml:"exporters,omitempty" json:"exporters,omitempty"`
+	Connectors map[string]interface{} `yaml:"connectors,omitempty" json:"connectors,omitempty"`
+	Service    Service                `yaml:"service,omitempty" json:"service"`
+}
+
+// Service represents the service section of the collector configuration
+type Service struct {
+	Extensions []string               `yaml:"extensions,omitempty" json:"extensions,omitempty"`
+	Pipelines  map[string]*Pipeline   `yaml:"pipelines,omitempty" json:"pipelines,omitempty"`
+	Telemetry  map[string]interface{} `yaml:"telemetry,omitempty" json:"telemetry,omitempty"`
+}
+
+// Pipeline represents a traces, metrics or logs pipeline
+type Pipeline struct {
+	Receivers  []string `yaml:"receivers,omitempty" json:"receivers,omitempty"`
+	Processors []string `yaml:"processors,omitempty" json:"processors,omitempty"`
+	Exporters  []string `yaml:"exporters,omitempty" json:"exporters,omitempty"`
+}
+
+// NewConfig creates an empty collector configuration
+func NewConfig() *Config {
+	return &Config{
+		Extensions: make(map[string]interface{}),
+		Receivers:  make(map[string]interface{}),
+		Processors: make(map[string]interface{}),
+		Exporters:  make(map[string]interface{}),
+		Connectors: make(map[string]interface{}),
+		Service: Service{
+			Pipelines: make(map[string]*Pipeline),
+		},
+	}
+}
+
+// Parse parses a collector configuration YAML
+func Parse(data []byte) (*Config, error) {
+	config := NewConfig()
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse collector config YAML: %w", err)
+	}
+	// Ensure all sections are usable even if they were not present
+	if config.Extensions == nil {
+		config.Extensions = make(map[string]interface{})
+	}
+	if config.Receivers == nil {
+		config.Receivers = make(map[string]interface{})
+	}
+	if config.Processors == nil {
+		config.Processors = make(map[string]interface{})
+	}
+	if config.Exporters == nil {
+		config.Exporters = make(map[string]interface{})
+	}
+	if config.Connectors == nil {
+		config.Connectors = make(map[string]interface{})
+	}
+	if config.Service.Pipelines == nil {
+		config.Service.Pipelines = make(map[string]*Pipeline)
+	}
+	for id, pipeline := range config.Service.Pipelines {
+		if pipeline == nil {
+			config.Service.Pipelines[id] = &Pipeline{}
+		}
+	}
+	return config, nil
+}
+
+// Marshal returns the collector configuration YAML
+func (c *Config) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return nil, fmt.Errorf("failed to marshal collector config YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal collector config YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Clone returns a deep copy of the configuration
+func (c *Config) Clone() (*Config, error) {
+	data, err := c.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// ComponentType returns the component type of a component ID e.g. otlp for otlp/backend
+func ComponentType(id string) string {
+	componentType, _, _ := strings.Cut(id, "/")
+	return componentType
+}
+
+// Signal returns the signal of a pipeline ID e.g. traces for traces/backend
+func Signal(pipelineID string) string {
+	signal, _, _ := strings.Cut(pipelineID, "/")
+	return signal
+}
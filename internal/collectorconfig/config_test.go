@@ -0,0 +1,170 @@
+package collectorconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	config, err := Parse([]byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces/backend:
+      receivers: [otlp]
+      exporters: [debug]
+    logs:
+`))
+	require.NoError(t, err)
+
+	assert.Contains(t, config.Receivers, "otlp")
+	assert.Contains(t, config.Exporters, "debug")
+	assert.NotNil(t, config.Processors)
+	assert.Equal(t, []string{"otlp"}, config.Service.Pipelines["traces/backend"].Receivers)
+	assert.NotNil(t, config.Service.Pipelines["logs"])
+
+	data, err := config.Marshal()
+	require.NoError(t, err)
+	roundTrip, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, config, roundTrip)
+
+	_, err = Parse([]byte("receivers: ["))
+	require.Error(t, err)
+}
+
+func TestConfig_Clone(t *testing.T) {
+	config, err := Parse([]byte("receivers:\n  otlp:\n    protocols:\n      grpc:\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n"))
+	require.NoError(t, err)
+
+	clone, err := config.Clone()
+	require.NoError(t, err)
+	assert.Equal(t, config, clone)
+
+	clone.Service.Pipelines["traces"].Receivers[0] = "jaeger"
+	clone.Receivers["otlp"].(map[string]interface{})["protocols"] = nil
+	assert.Equal(t, []string{"otlp"}, config.Service.Pipelines["traces"].Receivers)
+	assert.NotNil(t, config.Receivers["otlp"].(map[string]interface{})["protocols"])
+}
+
+func TestNumber(t *testing.T) {
+	for _, value := range []interface{}{512, int64(512), uint64(512), 512.0} {
+		number, ok := Number(value)
+		assert.True(t, ok)
+		assert.Equal(t, "512", FormatNumber(number))
+	}
+	_, ok := Number("${env:LIMIT_MIB}")
+	assert.False(t, ok)
+	assert.Equal(t, "0.8", FormatNumber(0.8))
+}
+
+func TestComponentTypeAndSignal(t *testing.T) {
+	assert.Equal(t, "otlp", ComponentType("otlp/backend"))
+	assert.Equal(t, "debug", ComponentType("debug"))
+	assert.Equal(t, "traces", Signal("traces/backend"))
+}
+
+func TestValidateTopology(t *testing.T) {
+	config, err := Parse([]byte(`
+receivers:
+  otlp:
+  unused:
+exporters:
+  debug:
+connectors:
+  forward:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces/in:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [forward]
+    traces/out:
+      receivers: [forward]
+      exporters: [debug, missing]
+    spans:
+      receivers: [otlp]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	issues := config.ValidateTopology()
+	assert.True(t, HasErrors(issues))
+	var messages []string
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	assert.Contains(t, messages, `error: service::pipelines::traces/in::processors: references processor "batch" which is not defined`)
+	assert.Contains(t, messages, `error: service::pipelines::traces/out::exporters: references exporter "missing" which is not defined`)
+	assert.Contains(t, messages, `error: service::extensions: references extension "health_check" which is not defined`)
+	assert.Contains(t, messages, `warning: receivers::unused: receiver is defined but not used in any pipeline`)
+	assert.Contains(t, messages, `error: service::pipelines::spans: unknown signal "spans", pipeline ID has to start with one of traces, metrics, logs, profiles`)
+}
+
+func TestValidateTopology_Cycle(t *testing.T) {
+	config, err := Parse([]byte(`
+receivers:
+  otlp:
+exporters:
+  debug:
+connectors:
+  forward/a:
+  forward/b:
+service:
+  pipelines:
+    traces/in:
+      receivers: [otlp]
+      exporters: [forward/a]
+    traces/a:
+      receivers: [forward/a, forward/b]
+      exporters: [forward/b, debug]
+`))
+	require.NoError(t, err)
+
+	issues := config.ValidateTopology()
+	require.Len(t, issues, 1)
+	assert.Equal(t, "pipelines form a cycle through connectors: traces/a -> traces/a", issues[0].Message)
+}
+
+func TestValidateTopology_ConnectorConversions(t *testing.T) {
+	config, err := Parse([]byte(`
+receivers:
+  otlp:
+exporters:
+  debug:
+connectors:
+  spanmetrics:
+  forward:
+  custom:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics, forward, custom]
+    metrics:
+      receivers: [spanmetrics, custom]
+      exporters: [debug]
+    logs:
+      receivers: [spanmetrics, forward]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	var messages []string
+	for _, issue := range config.ValidateTopology() {
+		messages = append(messages, issue.String())
+	}
+	assert.Equal(t, []string{
+		"error: connectors::forward: connector is used as an exporter in traces pipeline but not as a receiver in any pipeline of a signal it converts traces to, supported conversions: traces_to_traces, metrics_to_metrics, logs_to_logs",
+		"error: connectors::forward: connector is used as a receiver in logs pipeline but not as an exporter in any pipeline of a signal it converts to logs, supported conversions: traces_to_traces, metrics_to_metrics, logs_to_logs",
+		"error: connectors::spanmetrics: connector is used as a receiver in logs pipeline but not as an exporter in any pipeline of a signal it converts to logs, supported conversions: traces_to_metrics",
+	}, messages)
+}
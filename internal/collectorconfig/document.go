@@ -0,0 +1,291 @@
+package collectorconfig
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ComponentSections are the configuration sections defining components
+var ComponentSections = []string{"receivers", "processors", "exporters", "connectors", "extensions"}
+
+// maxCommentWidth is the line width comments are wrapped at
+const maxCommentWidth = 100
+
+// Document is a collector configuration YAML document that keeps the comments, the key order and the anchors
+// of the original YAML when it is marshaled
+type Document struct {
+	root yaml.Node
+}
+
+// ParseDocument parses a collector configuration YAML keeping its comments
+func ParseDocument(data []byte) (*Document, error) {
+	document := &Document{}
+	if err := yaml.Unmarshal(data, &document.root); err != nil {
+		return nil, fmt.Errorf("failed to parse collector config YAML: %w", err)
+	}
+	if document.root.Kind != yaml.DocumentNode || len(document.root.Content) == 0 || document.root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse collector config YAML: the document must be a mapping")
+	}
+	return document, nil
+}
+
+// Marshal returns the configuration YAML including the comments
+func (d *Document) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&d.root); err != nil {
+		return nil, fmt.Errorf("failed to marshal collector config YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal collector config YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Annotate adds a comment above the component fields without a comment. describe returns the comment of a field of
+// a component of a section e.g. receivers, otlp and protocols.grpc.endpoint, or an empty string. Annotate returns the
+// number of annotated fields.
+func (d *Document) Annotate(describe func(section, id, path string) string) int {
+	annotated := 0
+	for _, section := range ComponentSections {
+		components := mappingValue(d.root.Content[0], section)
+		if components == nil || components.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(components.Content); i += 2 {
+			id := components.Content[i].Value
+			annotated += annotateFields(components.Content[i+1], "", func(path string) string {
+				return describe(section, id, path)
+			})
+		}
+	}
+	return annotated
+}
+
+// RemoveFields removes the component fields for which remove returns true and returns their paths e.g.
+// processors::batch::timeout. Mappings left empty are kept as null values, their presence can enable a feature
+// e.g. protocols.grpc of the otlp receiver.
+func (d *Document) RemoveFields(remove func(section, id, path string, value interface{}) bool) []string {
+	var removed []string
+	for _, section := range ComponentSections {
+		components := mappingValue(d.root.Content[0], section)
+		if components == nil || components.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(components.Content); i += 2 {
+			id := components.Content[i].Value
+			for _, path := range removeFields(components.Content[i+1], "", func(path string, value interface{}) bool {
+				return remove(section, id, path, value)
+			}) {
+				removed = append(removed, section+"::"+id+"::"+path)
+			}
+			nullIfEmpty(components.Content[i+1])
+		}
+	}
+	return removed
+}
+
+// AddFields adds the fields returned by fields for a component of a section that are not set, with the comment
+// as line comment, and returns their paths e.g. processors::batch::timeout. Fields are only added to mappings
+// present in the configuration, a new mapping can enable a feature e.g. protocols.http of the otlp receiver.
+func (d *Document) AddFields(fields func(section, id string) map[string]interface{}, comment string) ([]string, error) {
+	var added []string
+	for _, section := range ComponentSections {
+		components := mappingValue(d.root.Content[0], section)
+		if components == nil || components.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(components.Content); i += 2 {
+			id := components.Content[i].Value
+			values := fields(section, id)
+			paths := make([]string, 0, len(values))
+			for path := range values {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			for _, path := range paths {
+				ok, err := addField(components.Content[i+1], strings.Split(path, "."), values[path], comment)
+				if err != nil {
+					return nil, fmt.Errorf("failed to add %s::%s::%s: %w", section, id, path, err)
+				}
+				if ok {
+					added = append(added, section+"::"+id+"::"+path)
+				}
+			}
+		}
+	}
+	return added, nil
+}
+
+// addField adds a field to a mapping or a null value if its parent mappings exist and it is not set
+func addField(node *yaml.Node, keys []string, value interface{}, comment string) (bool, error) {
+	parent := node
+	for _, key := range keys[:len(keys)-1] {
+		if parent.Kind != yaml.MappingNode {
+			return false, nil
+		}
+		if parent = mappingValue(parent, key); parent == nil {
+			return false, nil
+		}
+	}
+	if parent.Kind != yaml.MappingNode && !isNull(parent) {
+		return false, nil
+	}
+	if mappingValue(parent, keys[len(keys)-1]) != nil {
+		return false, nil
+	}
+
+	valueNode := &yaml.Node{}
+	if err := valueNode.Encode(value); err != nil {
+		return false, err
+	}
+	if isNull(parent) {
+		*parent = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: parent.HeadComment, LineComment: parent.LineComment}
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[len(keys)-1]}
+	if valueNode.Kind == yaml.ScalarNode {
+		valueNode.LineComment = comment
+	} else {
+		keyNode.LineComment = comment
+	}
+	parent.Content = append(parent.Content, keyNode, valueNode)
+	return true, nil
+}
+
+// isNull returns true for a null value e.g. grpc: without a value
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+// removeFields removes the fields of a mapping and its nested mappings for which remove returns true
+func removeFields(node *yaml.Node, path string, remove func(path string, value interface{}) bool) []string {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var removed []string
+	var content []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		fieldPath := key.Value
+		if path != "" {
+			fieldPath = path + "." + key.Value
+		}
+		if value.Kind == yaml.MappingNode {
+			removed = append(removed, removeFields(value, fieldPath, remove)...)
+			nullIfEmpty(value)
+			content = append(content, key, value)
+			continue
+		}
+		var decoded interface{}
+		if err := value.Decode(&decoded); err == nil && decoded != nil && remove(fieldPath, decoded) {
+			removed = append(removed, fieldPath)
+			continue
+		}
+		content = append(content, key, value)
+	}
+	node.Content = content
+	return removed
+}
+
+// nullIfEmpty replaces an empty mapping with a null value e.g. grpc: instead of grpc: {}
+func nullIfEmpty(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode && len(node.Content) == 0 {
+		*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", LineComment: node.LineComment}
+	}
+}
+
+// annotateFields annotates the fields of a mapping and its nested mappings and sequences
+func annotateFields(node *yaml.Node, path string, describe func(path string) string) int {
+	annotated := 0
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			annotated += annotateFields(item, path, describe)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			fieldPath := key.Value
+			if path != "" {
+				fieldPath = path + "." + key.Value
+			}
+			if key.HeadComment == "" {
+				if description := describe(fieldPath); description != "" {
+					key.HeadComment = wrapComment(description, maxCommentWidth)
+					annotated++
+				}
+			}
+			annotated += annotateFields(node.Content[i+1], fieldPath, describe)
+		}
+	}
+	return annotated
+}
+
+// Position returns the line and column of the key of a path e.g. receivers, otlp and protocols, or of the deepest key
+// of the path found in the document. Items of lists are addressed by their index. It returns 0, 0 if the first key is
+// not found.
+func (d *Document) Position(path ...string) (int, int) {
+	node := d.root.Content[0]
+	line, column := 0, 0
+	for _, key := range path {
+		switch node.Kind {
+		case yaml.MappingNode:
+			found := false
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					line, column, node, found = node.Content[i].Line, node.Content[i].Column, node.Content[i+1], true
+					break
+				}
+			}
+			if !found {
+				return line, column
+			}
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return line, column
+			}
+			node = node.Content[index]
+			line, column = node.Line, node.Column
+		default:
+			return line, column
+		}
+	}
+	return line, column
+}
+
+// mappingValue returns the value of a key of a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// wrapComment wraps the text into lines not longer than the width unless a single word is longer
+func wrapComment(text string, width int) string {
+	var lines []string
+	var line strings.Builder
+	for _, word := range strings.Fields(text) {
+		if line.Len() > 0 && line.Len()+1+len(word) > width {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteString(" ")
+		}
+		line.WriteString(word)
+	}
+	if line.Len() > 0 {
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
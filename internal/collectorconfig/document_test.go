@@ -0,0 +1,107 @@
+package collectorconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Annotate(t *testing.T) {
+	document, err := ParseDocument([]byte(`# gateway collector
+receivers:
+  otlp/in:
+    protocols:
+      grpc:
+        # keep the custom port
+        endpoint: 0.0.0.0:14317
+      http:
+        endpoint: 0.0.0.0:4318 # default
+processors:
+  filter:
+    logs:
+      log_record:
+        - severity_number < 9
+service:
+  pipelines:
+    logs:
+      receivers: [otlp/in]
+`))
+	require.NoError(t, err)
+
+	var calls []string
+	annotated := document.Annotate(func(section, id, path string) string {
+		calls = append(calls, section+"::"+id+"::"+path)
+		if path == "protocols.grpc.endpoint" || path == "protocols.http.endpoint" {
+			return "Endpoint configures the address for this network connection."
+		}
+		if path == "logs.log_record" {
+			return "Conditions dropping log records. A log record is dropped when any of the conditions matches, the conditions use the log context."
+		}
+		return ""
+	})
+	assert.Equal(t, 2, annotated)
+	assert.Contains(t, calls, "receivers::otlp/in::protocols.http.endpoint")
+	assert.NotContains(t, calls, "receivers::otlp/in::protocols.grpc.endpoint")
+	assert.NotContains(t, calls, "service::pipelines::logs")
+
+	data, err := document.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, `# gateway collector
+receivers:
+  otlp/in:
+    protocols:
+      grpc:
+        # keep the custom port
+        endpoint: 0.0.0.0:14317
+      http:
+        # Endpoint configures the address for this network connection.
+        endpoint: 0.0.0.0:4318 # default
+processors:
+  filter:
+    logs:
+      # Conditions dropping log records. A log record is dropped when any of the conditions matches, the
+      # conditions use the log context.
+      log_record:
+        - severity_number < 9
+service:
+  pipelines:
+    logs:
+      receivers: [otlp/in]
+`, string(data))
+}
+
+func TestParseDocument_Invalid(t *testing.T) {
+	for _, input := range []string{"receivers: [", "- a", ""} {
+		_, err := ParseDocument([]byte(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestDocument_Position(t *testing.T) {
+	document, err := ParseDocument([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters:
+        - debug
+`))
+	require.NoError(t, err)
+
+	position := func(path ...string) []int {
+		line, column := document.Position(path...)
+		return []int{line, column}
+	}
+	assert.Equal(t, []int{3, 5}, position("receivers", "otlp", "protocols"))
+	assert.Equal(t, []int{12, 11}, position("service", "pipelines", "traces", "exporters", "0"))
+	// The deepest key found
+	assert.Equal(t, []int{2, 3}, position("receivers", "otlp", "endpoint"))
+	assert.Equal(t, []int{11, 7}, position("service", "pipelines", "traces", "exporters", "1"))
+	assert.Equal(t, []int{0, 0}, position("processors", "batch"))
+}
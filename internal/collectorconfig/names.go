@@ -0,0 +1,105 @@
+package collectorconfig
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// typeRegexp are the component types accepted by the collector, an ASCII letter followed by ASCII letters, digits
+	// and underscores
+	typeRegexp = regexp.MustCompile(`^[a-zA-Z][0-9a-zA-Z_]{0,62}$`)
+	// nameRegexp are the component and pipeline names accepted by the collector, Unicode characters except whitespace,
+	// control characters and symbols
+	nameRegexp = regexp.MustCompile(`^[^\pZ\pC\pS]+$`)
+)
+
+// maxNameLength is the maximum number of characters of a component or pipeline name
+const maxNameLength = 1024
+
+// ValidateComponentID returns an error if the collector rejects the component ID e.g. "otlp receiver/2" at startup,
+// the type is matched before the first / and the name after it the same way the collector parses the ID
+func ValidateComponentID(id string) error {
+	componentType, name, hasName := strings.Cut(id, "/")
+	componentType = strings.TrimSpace(componentType)
+	if componentType == "" {
+		if hasName {
+			return fmt.Errorf("in %q id: the part before / should not be empty", id)
+		}
+		return errors.New("id must not be empty")
+	}
+	if hasName {
+		if err := validateName(id, name); err != nil {
+			return err
+		}
+	}
+	if !typeRegexp.MatchString(componentType) {
+		return fmt.Errorf("in %q id: invalid character(s) in type %q, it must start with an ASCII letter and contain only ASCII letters, digits and _ (at most 63 characters)", id, componentType)
+	}
+	return nil
+}
+
+// ValidatePipelineID returns an error if the collector rejects the name of the pipeline ID e.g. "traces/my backend",
+// the signal is validated by ValidateTopology
+func ValidatePipelineID(id string) error {
+	signal, name, hasName := strings.Cut(id, "/")
+	if strings.TrimSpace(signal) == "" {
+		if hasName {
+			return fmt.Errorf("in %q id: the part before / should not be empty", id)
+		}
+		return errors.New("id must not be empty")
+	}
+	if hasName {
+		return validateName(id, name)
+	}
+	return nil
+}
+
+// validateName validates the name part of a component or pipeline ID
+func validateName(id, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("in %q id: the part after / should not be empty", id)
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("in %q id: name is longer than %d characters", id, maxNameLength)
+	}
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("in %q id: invalid character(s) in name %q, whitespace, control characters and symbols are not allowed", id, name)
+	}
+	return nil
+}
+
+// ValidateNames validates the IDs of the defined components and pipelines against the collector naming rules, the
+// JSON schemas do not cover them and the collector fails to start
+func (c *Config) ValidateNames() []Issue {
+	var issues []Issue
+	sections := []struct {
+		name       string
+		components map[string]interface{}
+	}{
+		{"receivers", c.Receivers},
+		{"processors", c.Processors},
+		{"exporters", c.Exporters},
+		{"connectors", c.Connectors},
+		{"extensions", c.Extensions},
+	}
+	for _, section := range sections {
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			if err := ValidateComponentID(id); err != nil {
+				issues = append(issues, Issue{Severity: SeverityError, Path: section.name + "::" + id, Message: err.Error()})
+			}
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(c.Service.Pipelines)) {
+		if err := ValidatePipelineID(id); err != nil {
+			issues = append(issues, Issue{Severity: SeverityError, Path: "service::pipelines::" + id, Message: err.Error()})
+		}
+	}
+	return issues
+}
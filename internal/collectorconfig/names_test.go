@@ -0,0 +1,68 @@
+package collectorconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateComponentID(t *testing.T) {
+	for _, id := range []string{"otlp", "otlp/2", "otlp/backend-eu.1", "otlp_http/ünïcode", "k8s_cluster/名前", "otlp/a/b"} {
+		assert.NoError(t, ValidateComponentID(id), id)
+	}
+
+	for id, message := range map[string]string{
+		"":                  "id must not be empty",
+		"/backend":          `in "/backend" id: the part before / should not be empty`,
+		"otlp/":             `in "otlp/" id: the part after / should not be empty`,
+		"otlp receiver/2":   `in "otlp receiver/2" id: invalid character(s) in type "otlp receiver"`,
+		"2otlp":             `in "2otlp" id: invalid character(s) in type "2otlp"`,
+		"otlp-http":         `in "otlp-http" id: invalid character(s) in type "otlp-http"`,
+		"ötlp":              `in "ötlp" id: invalid character(s) in type "ötlp"`,
+		"otlp/my backend":   `in "otlp/my backend" id: invalid character(s) in name "my backend"`,
+		"otlp/🚀":            `in "otlp/🚀" id: invalid character(s) in name "🚀"`,
+		"otlp/a\u200bb":     `invalid character(s) in name`,
+		"otlp/back\u00a0up": `invalid character(s) in name`,
+	} {
+		err := ValidateComponentID(id)
+		require.Error(t, err, id)
+		assert.Contains(t, err.Error(), message)
+	}
+
+	assert.EqualError(t, ValidateComponentID("otlp/"+strings.Repeat("a", 1025)), `in "otlp/`+strings.Repeat("a", 1025)+`" id: name is longer than 1024 characters`)
+	assert.Error(t, ValidateComponentID(strings.Repeat("a", 64)))
+}
+
+func TestValidatePipelineID(t *testing.T) {
+	assert.NoError(t, ValidatePipelineID("traces"))
+	assert.NoError(t, ValidatePipelineID("traces/backend"))
+	assert.EqualError(t, ValidatePipelineID("traces/"), `in "traces/" id: the part after / should not be empty`)
+	assert.EqualError(t, ValidatePipelineID("metrics/my pipeline"), `in "metrics/my pipeline" id: invalid character(s) in name "my pipeline", whitespace, control characters and symbols are not allowed`)
+}
+
+func TestValidateTopology_Names(t *testing.T) {
+	config, err := Parse([]byte(`
+receivers:
+  otlp receiver/2:
+  otlp:
+exporters:
+  debug/🚀:
+service:
+  pipelines:
+    traces/my pipeline:
+      receivers: [otlp receiver/2, otlp]
+      exporters: [debug/🚀]
+`))
+	require.NoError(t, err)
+
+	var messages []string
+	for _, issue := range config.ValidateTopology() {
+		messages = append(messages, issue.String())
+	}
+	assert.Contains(t, messages, `error: receivers::otlp receiver/2: in "otlp receiver/2" id: invalid character(s) in type "otlp receiver", it must start with an ASCII letter and contain only ASCII letters, digits and _ (at most 63 characters)`)
+	assert.Contains(t, messages, `error: exporters::debug/🚀: in "debug/🚀" id: invalid character(s) in name "🚀", whitespace, control characters and symbols are not allowed`)
+	assert.Contains(t, messages, `error: service::pipelines::traces/my pipeline: in "traces/my pipeline" id: invalid character(s) in name "my pipeline", whitespace, control characters and symbols are not allowed`)
+	assert.NotContains(t, strings.Join(messages, "\n"), "receivers::otlp:")
+}
@@ -0,0 +1,23 @@
+package collectorconfig
+
+import "fmt"
+
+// Number returns the value of a YAML or JSON number of the configuration, environment variable references are unknown
+func Number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// FormatNumber formats a number of the configuration in its shortest form e.g. 512 or 0.8
+func FormatNumber(value float64) string {
+	return fmt.Sprintf("%g", value)
+}
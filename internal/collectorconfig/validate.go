@@ -0,0 +1,72 @@
+package collectorconfig
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// Severity of a configuration issue
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found in the collector configuration
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Path, i.Message)
+}
+
+// Signals are the pipeline signal types supported by the collector
+var Signals = collectorschema.PipelineSignals
+
+// ValidateTopology validates that component and pipeline IDs follow the collector naming rules, pipelines reference
+// defined components, connectors are wired on both ends with signals they convert, pipelines do not form cycles and
+// reports defined but unused components
+func (c *Config) ValidateTopology() []Issue {
+	issues := c.ValidateNames()
+	for _, issue := range collectorschema.ValidateTopology(c.Topology()) {
+		issues = append(issues, Issue{Severity: Severity(issue.Severity), Path: issue.Path, Message: issue.Message})
+	}
+	return issues
+}
+
+// Topology returns the component IDs of the sections and the service of the configuration
+func (c *Config) Topology() collectorschema.Topology {
+	topology := collectorschema.Topology{
+		Receivers:         slices.Sorted(maps.Keys(c.Receivers)),
+		Processors:        slices.Sorted(maps.Keys(c.Processors)),
+		Exporters:         slices.Sorted(maps.Keys(c.Exporters)),
+		Connectors:        slices.Sorted(maps.Keys(c.Connectors)),
+		Extensions:        slices.Sorted(maps.Keys(c.Extensions)),
+		ServiceExtensions: c.Service.Extensions,
+		Pipelines:         make(map[string]collectorschema.TopologyPipeline, len(c.Service.Pipelines)),
+	}
+	for id, pipeline := range c.Service.Pipelines {
+		if pipeline == nil {
+			pipeline = &Pipeline{}
+		}
+		topology.Pipelines[id] = collectorschema.TopologyPipeline{Receivers: pipeline.Receivers, Processors: pipeline.Processors, Exporters: pipeline.Exporters}
+	}
+	return topology
+}
+
+// HasErrors returns true if any of the issues is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
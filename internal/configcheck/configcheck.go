@@ -0,0 +1,180 @@
+// Package configcheck runs the validate and lint checks of a collector configuration shared by the command line and
+// the tools, the findings are located at the line and column of their key in the configuration YAML
+package configcheck
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/analysis"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/policy"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+// componentSections are the sections of the configuration defining components
+var componentSections = []string{"receivers", "processors", "exporters", "connectors", "extensions"}
+
+// Rules of the validate checks, the lint rules are the analysis finding types e.g. conflicting-listener
+const (
+	RuleSchema   = "schema"
+	RuleTopology = "topology"
+	RuleOTTL     = "ottl"
+)
+
+// Validate validates the configuration against the configuration schema of the version and the pipeline topology with
+// collectorschema.ValidateConfig, the component naming rules and the OTTL statements. Placeholders are not reported if the profile accepts them.
+func Validate(schemaManager *collectorschema.SchemaManager, configYAML []byte, version string, profile validation.Profile) ([]report.Finding, error) {
+	return validate(schemaManager, configYAML, version, profile, false)
+}
+
+// Verify validates a configuration generated by a tool like Validate. A fragment without a service section e.g. a
+// single processor to merge into a configuration is validated without the service and the pipeline topology, components
+// without a schema in the version are warnings.
+func Verify(schemaManager *collectorschema.SchemaManager, configYAML []byte, version string, profile validation.Profile) ([]report.Finding, error) {
+	return validate(schemaManager, configYAML, version, profile, true)
+}
+
+func validate(schemaManager *collectorschema.SchemaManager, configYAML []byte, version string, profile validation.Profile, verify bool) ([]report.Finding, error) {
+	document, config, err := parse(configYAML)
+	if err != nil {
+		return nil, err
+	}
+	fragment := verify && isFragment(document)
+	configValidation, err := schemaManager.ValidateConfig(version, configYAML)
+	if err != nil {
+		return nil, err
+	}
+	var findings []report.Finding
+	for _, schemaError := range configValidation.Schema.Errors() {
+		if profile.Placeholders && validation.IsPlaceholder(schemaError.Value()) {
+			continue
+		}
+		if fragment && schemaError.Type() == "required" && schemaError.Details()["property"] == "service" {
+			continue
+		}
+		var keys []string
+		if field := schemaError.Field(); field != "(root)" {
+			keys = strings.Split(field, ".")
+		}
+		// A generated component without a schema in the version e.g. of a custom distribution cannot be verified
+		if property, ok := schemaError.Details()["property"].(string); verify && ok && schemaError.Type() == "additional_property_not_allowed" && len(keys) == 1 && slices.Contains(componentSections, keys[0]) {
+			findings = append(findings, configFinding(document, report.SeverityWarning, RuleSchema, append(keys, property), fmt.Sprintf("%s has no schema in version %s, it is not verified", property, version)))
+			continue
+		}
+		// The schemas describe text unmarshaled structs e.g. the component IDs of watch_observers as objects
+		if verify && schemaError.Type() == "invalid_type" && schemaError.Details()["given"] == "string" && strings.Contains(fmt.Sprint(schemaError.Details()["expected"]), "object") {
+			findings = append(findings, configFinding(document, report.SeverityWarning, RuleSchema, keys, schemaError.Description()))
+			continue
+		}
+		findings = append(findings, configFinding(document, report.SeverityError, RuleSchema, keys, schemaError.Description()))
+	}
+	if !fragment {
+		for _, issue := range config.ValidateNames() {
+			findings = append(findings, issueFinding(document, RuleTopology, issue))
+		}
+		for _, issue := range configValidation.Issues {
+			findings = append(findings, issueFinding(document, RuleTopology, collectorconfig.Issue{Severity: collectorconfig.Severity(issue.Severity), Path: issue.Path, Message: issue.Message}))
+		}
+	}
+	for _, issue := range analysis.ValidateOTTL(config, version) {
+		findings = append(findings, issueFinding(document, RuleOTTL, issue))
+	}
+	return findings, nil
+}
+
+// IsFragment returns true if the configuration has no service section e.g. a single processor to merge into a
+// configuration, a fragment has no pipeline topology
+func IsFragment(configYAML []byte) bool {
+	document, err := collectorconfig.ParseDocument(configYAML)
+	return err == nil && isFragment(document)
+}
+
+func isFragment(document *collectorconfig.Document) bool {
+	line, _ := document.Position("service")
+	return line == 0
+}
+
+// Lint reports duplicate and conflicting components, misconfigured scraper intervals, malformed endpoints and settings
+// exceeding the known hard limits of the environment
+func Lint(configYAML []byte, environment analysis.Environment) ([]report.Finding, error) {
+	document, config, err := parse(configYAML)
+	if err != nil {
+		return nil, err
+	}
+	var findings []report.Finding
+	analysisFindings := analysis.Conflicts(config)
+	analysisFindings = append(analysisFindings, analysis.Scrapers(config)...)
+	analysisFindings = append(analysisFindings, analysis.Endpoints(config)...)
+	analysisFindings = append(analysisFindings, analysis.Tuning(config, environment)...)
+	for _, finding := range analysisFindings {
+		// A finding of several components is located at the first one, the message names the others
+		var keys []string
+		if len(finding.Paths) > 0 {
+			keys = strings.Split(finding.Paths[0], "::")
+		}
+		findings = append(findings, configFinding(document, report.Severity(finding.Severity), finding.Type, keys, finding.Message))
+	}
+	return findings, nil
+}
+
+// Policies reports the violations of the rules of the policy packs, the rules are namespaced by their pack e.g.
+// acme/exporters-tls and the findings carry the documentation of their rule
+func Policies(schemaManager *collectorschema.SchemaManager, configYAML []byte, version string, packs []policy.Pack) ([]report.Finding, error) {
+	if len(packs) == 0 {
+		return nil, nil
+	}
+	document, err := collectorconfig.ParseDocument(configYAML)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(configYAML, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse collector config YAML: %w", err)
+	}
+	var findings []report.Finding
+	for _, violation := range policy.Check(packs, config, schemaManager, version) {
+		finding := configFinding(document, report.Severity(violation.Severity), violation.Rule, violation.Keys, violation.Message)
+		finding.Documentation = violation.Documentation
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// parse parses the configuration as a document locating the findings and as a configuration
+func parse(configYAML []byte) (*collectorconfig.Document, *collectorconfig.Config, error) {
+	document, err := collectorconfig.ParseDocument(configYAML)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := collectorconfig.Parse(configYAML)
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, config, nil
+}
+
+// issueFinding returns the finding of a configuration issue
+func issueFinding(document *collectorconfig.Document, rule string, issue collectorconfig.Issue) report.Finding {
+	var keys []string
+	if issue.Path != "" {
+		keys = strings.Split(issue.Path, "::")
+	}
+	return configFinding(document, report.Severity(issue.Severity), rule, keys, issue.Message)
+}
+
+// configFinding returns the finding of the configuration keys e.g. receivers, otlp, grouped by the configuration
+// section and located at the deepest key found in the document
+func configFinding(document *collectorconfig.Document, severity report.Severity, rule string, keys []string, message string) report.Finding {
+	finding := report.Finding{Severity: severity, Rule: rule, Group: "config", Path: strings.Join(keys, "::"), Message: message}
+	if len(keys) > 0 {
+		finding.Group = keys[0]
+		finding.Line, finding.Column = document.Position(keys...)
+	}
+	return finding
+}
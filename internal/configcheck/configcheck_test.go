@@ -0,0 +1,130 @@
+package configcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/analysis"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/policy"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+func TestLint(t *testing.T) {
+	findings, err := Lint([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+  otlp/internal:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, otlp/internal]
+      exporters: [debug]
+`), analysis.Environment{})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, report.Finding{
+		Severity: report.SeverityError,
+		Rule:     analysis.FindingConflictingListener,
+		Group:    "receivers",
+		Path:     "receivers::otlp::protocols::grpc::endpoint",
+		Message:  findings[0].Message,
+		Line:     5,
+		Column:   9,
+	}, findings[0])
+}
+
+func TestLint_Scrapers(t *testing.T) {
+	findings, err := Lint([]byte(`receivers:
+  redis:
+    collection_interval: 10s
+    timeout: 15s
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [redis]
+      exporters: [debug]
+`), analysis.Environment{})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, analysis.FindingScraperTimeout, findings[0].Rule)
+	assert.Equal(t, "receivers::redis::timeout", findings[0].Path)
+	assert.Equal(t, []int{4, 5}, []int{findings[0].Line, findings[0].Column})
+}
+
+func TestLint_InvalidYAML(t *testing.T) {
+	_, err := Lint([]byte("receivers: [otlp"), analysis.Environment{})
+	assert.Error(t, err)
+}
+
+func TestPolicies(t *testing.T) {
+	packs := []policy.Pack{{Name: "acme", Rules: []policy.Rule{
+		{ID: "no-debug", Description: "the debug exporter is forbidden", Documentation: "use the otlp exporter", Components: "exporters/debug", Forbidden: true},
+	}}}
+	findings, err := Policies(collectorschema.NewSchemaManager(), []byte("exporters:\n  otlp:\n    endpoint: tempo:4317\n  debug:\n"), "0.139.0", packs)
+	require.NoError(t, err)
+	assert.Equal(t, []report.Finding{{
+		Severity:      report.SeverityError,
+		Rule:          "acme/no-debug",
+		Group:         "exporters",
+		Path:          "exporters::debug",
+		Message:       "the debug exporter is forbidden: exporter debug is configured",
+		Line:          4,
+		Column:        3,
+		Documentation: "use the otlp exporter",
+	}}, findings)
+
+	findings, err = Policies(collectorschema.NewSchemaManager(), []byte("receivers: [otlp"), "0.139.0", nil)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestVerify(t *testing.T) {
+	schemaManager := collectorschema.NewSchemaManager()
+	profile, err := validation.Get(validation.ProfileAgent)
+	require.NoError(t, err)
+
+	// A fragment is validated without the service and the topology
+	findings, err := Verify(schemaManager, []byte("processors:\n  batch:\n    timeout: 1s\n"), "0.139.0", profile)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+	findings, err = Verify(schemaManager, []byte("receivers:\n  otlp:\n    protocols: 5\n"), "0.139.0", profile)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "receivers::otlp::protocols", findings[0].Path)
+
+	// A configuration with a service section is validated like by Validate
+	findings, err = Verify(schemaManager, []byte("receivers:\n  otlp:\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n"), "0.139.0", profile)
+	require.NoError(t, err)
+	validateFindings, err := Validate(schemaManager, []byte("receivers:\n  otlp:\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n"), "0.139.0", profile)
+	require.NoError(t, err)
+	assert.NotEmpty(t, findings)
+	assert.Equal(t, validateFindings, findings)
+	// A service section without pipelines is not a fragment
+	findings, err = Verify(schemaManager, []byte("processors:\n  batch:\nservice: {}\n"), "0.139.0", profile)
+	require.NoError(t, err)
+	require.NotEmpty(t, findings)
+	assert.Equal(t, RuleTopology, findings[0].Rule)
+	assert.True(t, IsFragment([]byte("processors:\n  batch:\n")))
+	assert.False(t, IsFragment([]byte("processors:\n  batch:\nservice: {}\n")))
+
+	// Components without a schema are not verified
+	findings, err = Verify(schemaManager, []byte("exporters:\n  unknown:\n"), "0.139.0", profile)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, report.SeverityWarning, findings[0].Severity)
+	assert.Equal(t, "exporters::unknown", findings[0].Path)
+}
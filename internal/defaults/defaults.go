@@ -0,0 +1,115 @@
+// Package defaults compares component configuration values with the schema defaults to remove or add them
+package defaults
+
+import (
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// Lookup returns the defaults of a component of a configuration section by field path, or an error if the
+// component has no schema
+type Lookup func(section, id string) (map[string]interface{}, error)
+
+// DefaultComment is the line comment of the fields added by Expand
+const DefaultComment = "default"
+
+// Minimize removes the component fields set to their default value and returns the removed field paths and
+// the components without a schema
+func Minimize(document *collectorconfig.Document, lookup Lookup) ([]string, []string) {
+	components := newComponentDefaults(lookup)
+	removed := document.RemoveFields(func(section, id, path string, value interface{}) bool {
+		defaultValue, ok := components.get(section, id)[path]
+		return ok && Equal(value, defaultValue)
+	})
+	return removed, components.missing
+}
+
+// Expand adds the component fields that are not set with their default value, marked with a comment, and returns
+// the added field paths and the components without a schema. Nested settings are only expanded in the mappings
+// present in the configuration because adding a mapping can enable a feature.
+func Expand(document *collectorconfig.Document, lookup Lookup) ([]string, []string, error) {
+	components := newComponentDefaults(lookup)
+	added, err := document.AddFields(components.get, DefaultComment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return added, components.missing, nil
+}
+
+// componentDefaults caches the defaults of the component types
+type componentDefaults struct {
+	lookup  Lookup
+	cache   map[string]map[string]interface{}
+	missing []string
+}
+
+func newComponentDefaults(lookup Lookup) *componentDefaults {
+	return &componentDefaults{lookup: lookup, cache: make(map[string]map[string]interface{})}
+}
+
+// get returns the defaults of a component and records the component types without a schema
+func (c *componentDefaults) get(section, id string) map[string]interface{} {
+	key := section + "::" + collectorconfig.ComponentType(id)
+	defaults, loaded := c.cache[key]
+	if !loaded {
+		var err error
+		if defaults, err = c.lookup(section, collectorconfig.ComponentType(id)); err != nil {
+			c.missing = append(c.missing, key)
+		}
+		c.cache[key] = defaults
+	}
+	return defaults
+}
+
+// Equal returns true if a configured value equals the default value. Durations are compared by length e.g. 1m
+// equals 60s, numbers by value regardless of their YAML or JSON type and maps and lists element by element.
+func Equal(value, defaultValue interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		d, ok := defaultValue.(string)
+		if !ok {
+			return false
+		}
+		if v == d {
+			return true
+		}
+		valueDuration, err := time.ParseDuration(v)
+		if err != nil {
+			return false
+		}
+		defaultDuration, err := time.ParseDuration(d)
+		return err == nil && valueDuration == defaultDuration
+	case bool:
+		d, ok := defaultValue.(bool)
+		return ok && v == d
+	case []interface{}:
+		d, ok := defaultValue.([]interface{})
+		if !ok || len(v) != len(d) {
+			return false
+		}
+		for i := range v {
+			if !Equal(v[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		d, ok := defaultValue.(map[string]interface{})
+		if !ok || len(v) != len(d) {
+			return false
+		}
+		for key, item := range v {
+			if defaultItem, ok := d[key]; !ok || !Equal(item, defaultItem) {
+				return false
+			}
+		}
+		return true
+	}
+	valueNumber, ok := collectorconfig.Number(value)
+	if !ok {
+		return false
+	}
+	defaultNumber, ok := collectorconfig.Number(defaultValue)
+	return ok && valueNumber == defaultNumber
+}
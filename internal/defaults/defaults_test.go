@@ -0,0 +1,154 @@
+package defaults
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		value        interface{}
+		defaultValue interface{}
+		equal        bool
+	}{
+		{"200ms", "200ms", true},
+		{"0.2s", "200ms", true},
+		{"1m", "60s", true},
+		{"1m", "30s", false},
+		{"localhost:4317", "localhost:4317", true},
+		{"0.0.0.0:4317", "localhost:4317", false},
+		{8192, 8192, true},
+		{8192, float64(8192), true},
+		{1000, int64(1000), true},
+		{"8192", 8192, false},
+		{false, false, true},
+		{true, false, false},
+		{[]interface{}{"a", "b"}, []interface{}{"a", "b"}, true},
+		{[]interface{}{"b", "a"}, []interface{}{"a", "b"}, false},
+		{map[string]interface{}{"a": "1s"}, map[string]interface{}{"a": "1000ms"}, true},
+		{map[string]interface{}{"a": 1}, map[string]interface{}{"b": 1}, false},
+		{nil, nil, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.equal, Equal(test.value, test.defaultValue), "%v %v", test.value, test.defaultValue)
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	document, err := collectorconfig.ParseDocument([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+processors:
+  # batches telemetry
+  batch:
+    timeout: 0.2s
+    send_batch_size: 1024
+  batch/defaults:
+    timeout: 200ms
+exporters:
+  debug:
+    verbosity: basic
+`))
+	require.NoError(t, err)
+
+	removed, missing := Minimize(document, func(section, id string) (map[string]interface{}, error) {
+		switch section + "::" + id {
+		case "receivers::otlp":
+			return map[string]interface{}{"protocols.grpc.endpoint": "localhost:4317"}, nil
+		case "processors::batch":
+			return map[string]interface{}{"timeout": "200ms", "send_batch_size": 8192}, nil
+		}
+		return nil, fmt.Errorf("no schema")
+	})
+	assert.Equal(t, []string{
+		"receivers::otlp::protocols.grpc.endpoint",
+		"processors::batch::timeout",
+		"processors::batch/defaults::timeout",
+	}, removed)
+	assert.Equal(t, []string{"exporters::debug"}, missing)
+
+	data, err := document.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  # batches telemetry
+  batch:
+    send_batch_size: 1024
+  batch/defaults:
+exporters:
+  debug:
+    verbosity: basic
+`, string(data))
+}
+
+func TestExpand(t *testing.T) {
+	document, err := collectorconfig.ParseDocument([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  # batches telemetry
+  batch:
+    timeout: 1s
+  batch/empty:
+exporters:
+  debug:
+`))
+	require.NoError(t, err)
+
+	added, missing, err := Expand(document, func(section, id string) (map[string]interface{}, error) {
+		switch section + "::" + id {
+		case "receivers::otlp":
+			return map[string]interface{}{
+				"protocols.grpc.endpoint": "localhost:4317",
+				"protocols.http.endpoint": "localhost:4318",
+			}, nil
+		case "processors::batch":
+			return map[string]interface{}{"timeout": "200ms", "send_batch_size": 8192, "metadata_keys": []interface{}{"a"}}, nil
+		}
+		return nil, fmt.Errorf("no schema")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"receivers::otlp::protocols.grpc.endpoint",
+		"processors::batch::metadata_keys",
+		"processors::batch::send_batch_size",
+		"processors::batch/empty::metadata_keys",
+		"processors::batch/empty::send_batch_size",
+		"processors::batch/empty::timeout",
+	}, added)
+	assert.Equal(t, []string{"exporters::debug"}, missing)
+
+	data, err := document.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317 # default
+processors:
+  # batches telemetry
+  batch:
+    timeout: 1s
+    metadata_keys: # default
+      - a
+    send_batch_size: 8192 # default
+  batch/empty:
+    metadata_keys: # default
+      - a
+    send_batch_size: 8192 # default
+    timeout: 200ms # default
+exporters:
+  debug:
+`, string(data))
+}
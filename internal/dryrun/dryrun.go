@@ -0,0 +1,123 @@
+// Package dryrun validates collector configurations with the validate command of collector binaries, which runs
+// the collector's own unmarshalling and Validate() rules
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// VersionPlaceholder is replaced with the collector version in the binary path e.g. /opt/otelcol-{version}/otelcol
+const VersionPlaceholder = "{version}"
+
+// binaryVersionPattern matches the version printed by otelcol --version e.g. otelcol-contrib version 0.139.0
+var binaryVersionPattern = regexp.MustCompile(`version v?(\d+\.\d+\.\d+)`)
+
+// Validator validates configurations with collector binaries
+type Validator struct {
+	binary  string
+	timeout time.Duration
+}
+
+// Result is the outcome of the collector validate command
+type Result struct {
+	Binary string `json:"binary"`
+	// BinaryVersion is the version reported by the binary
+	BinaryVersion string   `json:"binaryVersion,omitempty"`
+	Valid         bool     `json:"valid"`
+	Errors        []string `json:"errors"`
+	Warnings      []string `json:"warnings,omitempty"`
+}
+
+// NewValidator returns a validator running the binary, the path can contain VersionPlaceholder to use a binary
+// built for each collector version
+func NewValidator(binary string, timeout time.Duration) *Validator {
+	return &Validator{binary: binary, timeout: timeout}
+}
+
+// Binary returns the path of the binary validating configurations of the collector version
+func (v *Validator) Binary(version string) string {
+	return strings.ReplaceAll(v.binary, VersionPlaceholder, strings.TrimPrefix(version, "v"))
+}
+
+// Validate runs the validate command of the collector binary for the version with the configuration
+func (v *Validator) Validate(ctx context.Context, version string, config []byte) (*Result, error) {
+	binary := v.Binary(version)
+	if _, err := os.Stat(binary); err != nil {
+		return nil, fmt.Errorf("collector binary for version %s is not available: %w", version, err)
+	}
+
+	file, err := os.CreateTemp("", "otelcol-config-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(config); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	result := &Result{Binary: binary, Errors: []string{}}
+	if output, err := v.run(ctx, binary, "--version"); err == nil {
+		if match := binaryVersionPattern.FindStringSubmatch(output); match != nil {
+			result.BinaryVersion = match[1]
+			if match[1] != strings.TrimPrefix(version, "v") {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("the configuration was validated with collector %s instead of %s", match[1], version))
+			}
+		}
+	}
+
+	output, err := v.run(ctx, binary, "validate", "--config=file:"+file.Name())
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		result.Valid = true
+	case errors.As(err, &exitErr):
+		result.Errors = validationErrors(output)
+	default:
+		return nil, err
+	}
+	return result, nil
+}
+
+// run runs the binary with the timeout and returns the combined output
+func (v *Validator) run(ctx context.Context, binary string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// The validated configuration must not read the environment of the server e.g. credentials
+	cmd.Env = []string{}
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("collector %s timed out after %s", strings.Join(args, " "), v.timeout)
+	}
+	return output.String(), err
+}
+
+// validationErrors returns the error lines printed by the collector, or the whole output if none is marked
+func validationErrors(output string) []string {
+	var errs []string
+	for _, line := range strings.Split(output, "\n") {
+		if _, message, ok := strings.Cut(line, "Error: "); ok {
+			errs = append(errs, strings.TrimSpace(message))
+		}
+	}
+	if len(errs) == 0 && strings.TrimSpace(output) != "" {
+		errs = append(errs, strings.TrimSpace(output))
+	}
+	return errs
+}
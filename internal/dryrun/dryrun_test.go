@@ -0,0 +1,64 @@
+package dryrun
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCollector writes a script behaving like the collector validate command, configs containing invalid fail
+func fakeCollector(t *testing.T, dir string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake collector is a shell script")
+	}
+	script := `#!/bin/sh
+if [ "$1" = "--version" ]; then
+  echo "otelcol-contrib version 0.139.0"
+  exit 0
+fi
+if grep -q invalid "${2#--config=file:}"; then
+  echo "Error: invalid configuration: processors::batch: send_batch_max_size must be greater or equal to send_batch_size"
+  echo "2025/11/03 10:00:00 collector server run finished with error"
+  exit 1
+fi
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "otelcol"), []byte(script), 0o755))
+}
+
+func TestValidator_Validate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "0.139.0")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	fakeCollector(t, dir)
+	validator := NewValidator(filepath.Join(filepath.Dir(dir), VersionPlaceholder, "otelcol"), 10*time.Second)
+
+	result, err := validator.Validate(context.Background(), "0.139.0", []byte("receivers: {}"))
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "0.139.0", result.BinaryVersion)
+	assert.Empty(t, result.Warnings)
+
+	result, err = validator.Validate(context.Background(), "v0.139.0", []byte("# invalid"))
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, []string{"invalid configuration: processors::batch: send_batch_max_size must be greater or equal to send_batch_size"}, result.Errors)
+
+	_, err = validator.Validate(context.Background(), "0.138.0", []byte("receivers: {}"))
+	assert.ErrorContains(t, err, "collector binary for version 0.138.0 is not available")
+}
+
+func TestValidator_VersionMismatch(t *testing.T) {
+	dir := t.TempDir()
+	fakeCollector(t, dir)
+	validator := NewValidator(filepath.Join(dir, "otelcol"), 10*time.Second)
+
+	result, err := validator.Validate(context.Background(), "0.138.0", []byte("receivers: {}"))
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, []string{"the configuration was validated with collector 0.139.0 instead of 0.138.0"}, result.Warnings)
+}
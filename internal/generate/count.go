@@ -0,0 +1,197 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/ottl"
+)
+
+// countSignalContexts maps the telemetry counted by the count and sum connectors to the pipeline signal
+// they are connected to and the OTTL contexts usable in their conditions
+var countSignalContexts = map[string]struct {
+	pipeline string
+	contexts []string
+}{
+	"spans":      {"traces", []string{"span", "resource", "scope", "instrumentation_scope"}},
+	"spanevents": {"traces", []string{"spanevent", "span", "resource", "scope", "instrumentation_scope"}},
+	"metrics":    {"metrics", []string{"metric", "resource", "scope", "instrumentation_scope"}},
+	"datapoints": {"metrics", []string{"datapoint", "metric", "resource", "scope", "instrumentation_scope"}},
+	"logs":       {"logs", []string{"log", "resource", "scope", "instrumentation_scope"}},
+}
+
+// logSeverities are the log severities usable as a minimum severity
+var logSeverities = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// CountMetric is a metric counting or summing telemetry matching conditions
+type CountMetric struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Signal is the counted telemetry: spans, spanevents, metrics, datapoints or logs
+	Signal string `json:"signal"`
+	// Severity counts only logs with the severity or higher e.g. ERROR
+	Severity   string   `json:"severity,omitempty"`
+	Conditions []string `json:"conditions,omitempty"`
+	// Attributes are the metric attributes e.g. service.name, name=default sets a default value
+	Attributes []string `json:"attributes,omitempty"`
+	// SourceAttribute is the numeric attribute summed by the sum connector
+	SourceAttribute string `json:"source_attribute,omitempty"`
+}
+
+// CountRequest is the high-level count or sum connector intent
+type CountRequest struct {
+	// Connector is count (default) or sum
+	Connector        string        `json:"connector,omitempty"`
+	Metrics          []CountMetric `json:"metrics"`
+	MetricsExporters []string      `json:"metrics_exporters,omitempty"`
+}
+
+// CountResult is the generated count or sum connector configuration
+type CountResult struct {
+	Config   *collectorconfig.Config
+	Warnings []string
+	Issues   []collectorconfig.Issue
+}
+
+// Count generates the count or sum connector, the pipelines of the counted signals and the metrics pipeline
+func Count(request CountRequest) (*CountResult, error) {
+	connectorID := request.Connector
+	if connectorID == "" {
+		connectorID = "count"
+	}
+	if connectorID != "count" && connectorID != "sum" {
+		return nil, fmt.Errorf("unsupported connector %q, must be count or sum", connectorID)
+	}
+	if len(request.Metrics) == 0 {
+		return nil, fmt.Errorf("at least one metric must be set")
+	}
+
+	var warnings []string
+	connector := make(map[string]interface{})
+	var inputSignals []string
+	for _, metric := range request.Metrics {
+		signal, ok := countSignalContexts[metric.Signal]
+		if !ok {
+			return nil, fmt.Errorf("metric %s: unsupported signal %q, supported signals: %s", metric.Name, metric.Signal, strings.Join(slices.Sorted(maps.Keys(countSignalContexts)), ", "))
+		}
+		if metric.Name == "" {
+			return nil, fmt.Errorf("metric name must be set")
+		}
+		metrics, _ := connector[metric.Signal].(map[string]interface{})
+		if metrics == nil {
+			metrics = make(map[string]interface{})
+			connector[metric.Signal] = metrics
+		}
+		if _, exists := metrics[metric.Name]; exists {
+			return nil, fmt.Errorf("metric %s is defined more than once for %s", metric.Name, metric.Signal)
+		}
+
+		conditions := append([]string{}, metric.Conditions...)
+		if metric.Severity != "" {
+			severity := strings.ToUpper(metric.Severity)
+			if metric.Signal != "logs" {
+				return nil, fmt.Errorf("metric %s: severity can be set only for logs", metric.Name)
+			}
+			if !slices.Contains(logSeverities, severity) {
+				return nil, fmt.Errorf("metric %s: unsupported severity %q, must be one of %s", metric.Name, metric.Severity, strings.Join(logSeverities, ", "))
+			}
+			conditions = append(conditions, "severity_number >= SEVERITY_NUMBER_"+severity)
+		}
+		if len(conditions) > 1 {
+			// Connector conditions are ORed, the intent is all of them
+			conditions = []string{"(" + strings.Join(conditions, ") and (") + ")"}
+		}
+		for _, condition := range conditions {
+			if problems := validateCountCondition(signal.contexts, condition); len(problems) > 0 {
+				return nil, fmt.Errorf("metric %s: invalid condition %q: %s", metric.Name, condition, strings.Join(problems, ", "))
+			}
+		}
+
+		metricConfig := make(map[string]interface{})
+		if metric.Description != "" {
+			metricConfig["description"] = metric.Description
+		}
+		if len(conditions) > 0 {
+			metricConfig["conditions"] = conditions
+		}
+		var attributes []interface{}
+		for _, attribute := range metric.Attributes {
+			key, defaultValue, hasDefault := strings.Cut(strings.TrimSpace(attribute), "=")
+			entry := map[string]interface{}{"key": key}
+			if hasDefault {
+				entry["default_value"] = defaultValue
+			}
+			attributes = append(attributes, entry)
+		}
+		if len(attributes) > 0 {
+			metricConfig["attributes"] = attributes
+		}
+		switch {
+		case connectorID == "sum" && metric.SourceAttribute == "":
+			return nil, fmt.Errorf("metric %s: source_attribute must be set for the sum connector", metric.Name)
+		case connectorID == "sum":
+			metricConfig["source_attribute"] = metric.SourceAttribute
+		case metric.SourceAttribute != "":
+			return nil, fmt.Errorf("metric %s: source_attribute can be set only for the sum connector", metric.Name)
+		}
+		metrics[metric.Name] = metricConfig
+
+		if !slices.Contains(inputSignals, signal.pipeline) {
+			inputSignals = append(inputSignals, signal.pipeline)
+		}
+	}
+
+	config := collectorconfig.NewConfig()
+	config.Receivers["otlp"] = map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+			"http": map[string]interface{}{"endpoint": "0.0.0.0:4318"},
+		},
+	}
+	config.Connectors[connectorID] = connector
+	for _, signal := range inputSignals {
+		config.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+			Receivers: []string{"otlp"},
+			Exporters: []string{connectorID},
+		}
+	}
+
+	metricsExporters := request.MetricsExporters
+	if len(metricsExporters) == 0 {
+		metricsExporters = []string{"debug"}
+		config.Exporters["debug"] = nil
+		warnings = append(warnings, "no metrics_exporters are set, replace the debug exporter with the metrics backend exporter")
+	}
+	for _, id := range request.MetricsExporters {
+		// The backend configuration is not known, the endpoint has to be filled in
+		config.Exporters[id] = map[string]interface{}{"endpoint": "<" + id + " endpoint>"}
+	}
+	config.Service.Pipelines["metrics/"+connectorID] = &collectorconfig.Pipeline{
+		Receivers: []string{connectorID},
+		Exporters: metricsExporters,
+	}
+
+	warnings = append(warnings, fmt.Sprintf("the %s connector emits cumulative sums, compute per minute rates in the backend e.g. increase(metric[1m]) or add the cumulativetodelta processor for delta backends", connectorID))
+	if len(inputSignals) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the %s pipelines only feed the connector, add the existing exporters to keep sending the telemetry", strings.Join(inputSignals, ", ")))
+	}
+	return &CountResult{Config: config, Warnings: warnings, Issues: config.ValidateTopology()}, nil
+}
+
+// validateCountCondition parses the condition and checks the path contexts are available for the counted signal
+func validateCountCondition(contexts []string, condition string) []string {
+	node, err := ottl.ParseCondition(condition)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var problems []string
+	for _, path := range ottl.Paths(node) {
+		if pathContext := path.Context(); pathContext != "" && !slices.Contains(contexts, pathContext) {
+			problems = append(problems, fmt.Sprintf("path %s cannot be used, the available contexts are %s", path, strings.Join(contexts, ", ")))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,73 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	result, err := Count(CountRequest{
+		Metrics: []CountMetric{
+			{
+				Name:       "log.error.count",
+				Signal:     "logs",
+				Severity:   "error",
+				Attributes: []string{"service.name=unknown"},
+			},
+			{
+				Name:       "checkout.span.count",
+				Signal:     "spans",
+				Conditions: []string{`resource.attributes["service.name"] == "checkout"`, `kind == SPAN_KIND_SERVER`},
+			},
+		},
+		MetricsExporters: []string{"prometheus"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+
+	connector := result.Config.Connectors["count"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"log.error.count": map[string]interface{}{
+			"conditions": []string{"severity_number >= SEVERITY_NUMBER_ERROR"},
+			"attributes": []interface{}{map[string]interface{}{"key": "service.name", "default_value": "unknown"}},
+		},
+	}, connector["logs"])
+	spans := connector["spans"].(map[string]interface{})["checkout.span.count"].(map[string]interface{})
+	assert.Equal(t, []string{`(resource.attributes["service.name"] == "checkout") and (kind == SPAN_KIND_SERVER)`}, spans["conditions"])
+
+	assert.Equal(t, []string{"count"}, result.Config.Service.Pipelines["logs"].Exporters)
+	assert.Equal(t, []string{"count"}, result.Config.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"count"}, result.Config.Service.Pipelines["metrics/count"].Receivers)
+	assert.Equal(t, []string{"prometheus"}, result.Config.Service.Pipelines["metrics/count"].Exporters)
+}
+
+func TestCount_Sum(t *testing.T) {
+	result, err := Count(CountRequest{
+		Connector: "sum",
+		Metrics:   []CountMetric{{Name: "http.response.size", Signal: "spans", SourceAttribute: "http.response.body.size"}},
+	})
+	require.NoError(t, err)
+	metric := result.Config.Connectors["sum"].(map[string]interface{})["spans"].(map[string]interface{})["http.response.size"].(map[string]interface{})
+	assert.Equal(t, "http.response.body.size", metric["source_attribute"])
+	assert.Contains(t, result.Config.Exporters, "debug")
+}
+
+func TestCount_Invalid(t *testing.T) {
+	for _, request := range []CountRequest{
+		{},
+		{Connector: "histogram", Metrics: []CountMetric{{Name: "a", Signal: "logs"}}},
+		{Metrics: []CountMetric{{Name: "a", Signal: "profiles"}}},
+		{Metrics: []CountMetric{{Name: "a", Signal: "spans", Severity: "ERROR"}}},
+		{Metrics: []CountMetric{{Name: "a", Signal: "logs", Severity: "CRITICAL"}}},
+		{Metrics: []CountMetric{{Name: "a", Signal: "logs", Conditions: []string{`span.name == "x"`}}}},
+		{Metrics: []CountMetric{{Name: "a", Signal: "logs", Conditions: []string{`attributes["x"] ==`}}}},
+		{Metrics: []CountMetric{{Name: "a", Signal: "logs", SourceAttribute: "size"}}},
+		{Connector: "sum", Metrics: []CountMetric{{Name: "a", Signal: "logs"}}},
+		{Metrics: []CountMetric{{Name: "a", Signal: "logs"}, {Name: "a", Signal: "logs"}}},
+	} {
+		_, err := Count(request)
+		assert.Error(t, err, "%+v", request)
+	}
+}
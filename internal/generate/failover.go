@@ -0,0 +1,193 @@
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// Settings of the generated failover setup that are not set by the request
+const (
+	failoverRetryInterval  = "10m"
+	failoverMaxElapsedTime = "30s"
+	healthCheckEndpoint    = "0.0.0.0:13133"
+)
+
+// FailoverRequest is the high-level intent for a collector failing over from a primary exporter to fallback exporters
+type FailoverRequest struct {
+	Signal    string   `json:"signal,omitempty"`
+	Receivers []string `json:"receivers,omitempty"`
+	// Primary is the ID of the primary exporter e.g. otlp/primary
+	Primary string `json:"primary"`
+	// PrimaryConfig is the configuration of the primary exporter, the fallback exporters copy it with their endpoint
+	PrimaryConfig map[string]interface{} `json:"primary_config"`
+	// FallbackEndpoints are the endpoints of the fallback exporters in priority order
+	FallbackEndpoints []string `json:"fallback_endpoints"`
+	// RetryInterval is how often the failover connector retries the higher priority levels, defaults to 10m
+	RetryInterval string `json:"retry_interval,omitempty"`
+	// Connector is true if the collector version has the failover connector, the telemetry is sent to every exporter
+	// otherwise
+	Connector bool `json:"connector,omitempty"`
+}
+
+// FailoverResult is the generated failover configuration and the description of its behavior
+type FailoverResult struct {
+	Config *collectorconfig.Config
+	// Behavior describes how the configuration behaves when the primary exporter fails and recovers
+	Behavior []string
+	Warnings []string
+	Issues   []collectorconfig.Issue
+}
+
+// Failover generates the failover connector routing to a pipeline per exporter in priority order, or a pipeline
+// sending to every exporter for the versions without the failover connector, and the health_check extension
+func Failover(request FailoverRequest) (*FailoverResult, error) {
+	signal := request.Signal
+	if signal == "" {
+		signal = "traces"
+	}
+	if !slices.Contains([]string{"traces", "metrics", "logs"}, signal) {
+		return nil, fmt.Errorf("unsupported signal %q, must be traces, metrics or logs", signal)
+	}
+	if request.Primary == "" {
+		return nil, fmt.Errorf("the primary exporter ID must be set e.g. otlp/primary")
+	}
+	primaryEndpoint, ok := request.PrimaryConfig["endpoint"].(string)
+	if !ok || primaryEndpoint == "" {
+		return nil, fmt.Errorf("exporter %s must set an endpoint, the fallback exporters copy its configuration with their endpoint", request.Primary)
+	}
+	if len(request.FallbackEndpoints) == 0 {
+		return nil, fmt.Errorf("at least one fallback endpoint must be set e.g. tempo-dr:4317")
+	}
+	receivers := request.Receivers
+	if len(receivers) == 0 {
+		receivers = []string{"otlp"}
+	}
+	retryInterval := request.RetryInterval
+	if retryInterval == "" {
+		retryInterval = failoverRetryInterval
+	}
+
+	config := collectorconfig.NewConfig()
+	exporterType := collectorconfig.ComponentType(request.Primary)
+	exporters := []string{request.Primary}
+	config.Exporters[request.Primary] = copyConfig(request.PrimaryConfig)
+	endpoints := map[string]string{primaryEndpoint: request.Primary}
+	for i, endpoint := range request.FallbackEndpoints {
+		if id, exists := endpoints[endpoint]; exists {
+			return nil, fmt.Errorf("fallback endpoint %s is already the endpoint of %s", endpoint, id)
+		}
+		id := exporterType + "/fallback"
+		if i > 0 {
+			id = fmt.Sprintf("%s/fallback%d", exporterType, i+1)
+		}
+		if _, exists := config.Exporters[id]; exists {
+			return nil, fmt.Errorf("the primary exporter cannot be named %s, it is the ID of a fallback exporter", id)
+		}
+		exporterConfig := copyConfig(request.PrimaryConfig)
+		exporterConfig["endpoint"] = endpoint
+		config.Exporters[id] = exporterConfig
+		endpoints[endpoint] = id
+		exporters = append(exporters, id)
+	}
+
+	for _, receiver := range receivers {
+		config.Receivers[receiver] = nil
+		if receiver == "otlp" {
+			config.Receivers[receiver] = otlpReceiverConfig(4317)
+		}
+	}
+	config.Processors["batch"] = nil
+	config.Extensions["health_check"] = map[string]interface{}{"endpoint": healthCheckEndpoint}
+	config.Service.Extensions = []string{"health_check"}
+
+	var warnings []string
+	behavior := []string{
+		fmt.Sprintf("the health_check extension reports the collector status on %s, use it as the liveness and readiness probe; it does not report the health of the backends", healthCheckEndpoint),
+	}
+	if !request.Connector {
+		config.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+			Receivers:  receivers,
+			Processors: []string{"batch"},
+			Exporters:  exporters,
+		}
+		warnings = append(warnings, "the failover connector is not available in this collector version, every exporter receives all telemetry; use a version with the failover connector to only send to the fallback exporters when the primary fails")
+		behavior = append(behavior, fmt.Sprintf("%s and the fallback exporters receive every batch, the backends store duplicate telemetry and a failing backend does not affect the others", request.Primary))
+		return &FailoverResult{Config: config, Behavior: behavior, Warnings: warnings, Issues: config.ValidateTopology()}, nil
+	}
+
+	// The exporters have to return their failures to the failover connector: a sending queue acknowledges the
+	// batches before they are exported and the retries delay the failover
+	var priorityLevels []interface{}
+	for _, id := range exporters {
+		exporterConfig := config.Exporters[id].(map[string]interface{})
+		if queue, ok := exporterConfig["sending_queue"].(map[string]interface{}); id == request.Primary && ok && queue["enabled"] != false {
+			warnings = append(warnings, fmt.Sprintf("the sending_queue of %s is disabled, a queue acknowledges the batches before they are exported and the failover connector never sees the failures", id))
+		}
+		exporterConfig["sending_queue"] = map[string]interface{}{"enabled": false}
+		retry, _ := exporterConfig["retry_on_failure"].(map[string]interface{})
+		if retry == nil {
+			retry = map[string]interface{}{"enabled": true}
+		}
+		if _, ok := retry["max_elapsed_time"]; !ok {
+			retry["max_elapsed_time"] = failoverMaxElapsedTime
+		}
+		exporterConfig["retry_on_failure"] = retry
+
+		pipelineID := signal + "/" + failoverPipelineName(id)
+		config.Service.Pipelines[pipelineID] = &collectorconfig.Pipeline{
+			Receivers: []string{"failover"},
+			Exporters: []string{id},
+		}
+		priorityLevels = append(priorityLevels, []string{pipelineID})
+	}
+	config.Connectors["failover"] = map[string]interface{}{
+		"priority_levels": priorityLevels,
+		"retry_interval":  retryInterval,
+	}
+	config.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+		Receivers:  receivers,
+		Processors: []string{"batch"},
+		Exporters:  []string{"failover"},
+	}
+	behavior = append(behavior,
+		fmt.Sprintf("the failover connector sends the telemetry to the pipeline of %s while its exports succeed", request.Primary),
+		fmt.Sprintf("an export failing after the retries of the exporter (max_elapsed_time %s unless set) switches to the next priority level, the batch is sent to it", failoverMaxElapsedTime),
+		fmt.Sprintf("every %s the connector retries the higher priority levels and switches back to the first one exporting successfully", retryInterval),
+		"the sending queues are disabled so the failures reach the connector, a batch failing on every priority level is dropped and reported by the receiver to the client",
+	)
+	return &FailoverResult{Config: config, Behavior: behavior, Warnings: warnings, Issues: config.ValidateTopology()}, nil
+}
+
+// failoverPipelineName returns the pipeline name of an exporter e.g. primary for otlp/primary or otlp for otlp
+func failoverPipelineName(exporterID string) string {
+	if _, name, ok := strings.Cut(exporterID, "/"); ok && name != "" {
+		return name
+	}
+	return exporterID
+}
+
+// copyConfig copies a component configuration and its nested maps and lists
+func copyConfig(config map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		copied[key] = copyValue(value)
+	}
+	return copied
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyConfig(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,80 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailover(t *testing.T) {
+	result, err := Failover(FailoverRequest{
+		Primary: "otlp/primary",
+		PrimaryConfig: map[string]interface{}{
+			"endpoint":      "tempo:4317",
+			"tls":           map[string]interface{}{"ca_file": "/etc/ca.pem"},
+			"sending_queue": map[string]interface{}{"queue_size": 5000},
+		},
+		FallbackEndpoints: []string{"tempo-dr:4317", "tempo-backup:4317"},
+		RetryInterval:     "5m",
+		Connector:         true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+
+	config := result.Config
+	assert.Equal(t, map[string]interface{}{
+		"priority_levels": []interface{}{[]string{"traces/primary"}, []string{"traces/fallback"}, []string{"traces/fallback2"}},
+		"retry_interval":  "5m",
+	}, config.Connectors["failover"])
+	assert.Equal(t, []string{"failover"}, config.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"failover"}, config.Service.Pipelines["traces/fallback2"].Receivers)
+	assert.Equal(t, []string{"otlp/fallback2"}, config.Service.Pipelines["traces/fallback2"].Exporters)
+
+	fallback := config.Exporters["otlp/fallback"].(map[string]interface{})
+	assert.Equal(t, "tempo-dr:4317", fallback["endpoint"])
+	assert.Equal(t, map[string]interface{}{"ca_file": "/etc/ca.pem"}, fallback["tls"])
+	assert.Equal(t, map[string]interface{}{"enabled": false}, fallback["sending_queue"])
+	assert.Equal(t, map[string]interface{}{"enabled": true, "max_elapsed_time": "30s"}, fallback["retry_on_failure"])
+	assert.Equal(t, "tempo:4317", config.Exporters["otlp/primary"].(map[string]interface{})["endpoint"])
+
+	assert.Equal(t, []string{"health_check"}, config.Service.Extensions)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "the sending_queue of otlp/primary is disabled")
+	assert.Contains(t, result.Behavior[3], "every 5m the connector retries the higher priority levels")
+}
+
+func TestFailover_WithoutConnector(t *testing.T) {
+	result, err := Failover(FailoverRequest{
+		Signal:            "logs",
+		Primary:           "otlphttp",
+		PrimaryConfig:     map[string]interface{}{"endpoint": "https://loki:3100/otlp"},
+		FallbackEndpoints: []string{"https://loki-dr:3100/otlp"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+	assert.Empty(t, result.Config.Connectors)
+	assert.Equal(t, []string{"otlphttp", "otlphttp/fallback"}, result.Config.Service.Pipelines["logs"].Exporters)
+	assert.NotContains(t, result.Config.Exporters["otlphttp/fallback"], "sending_queue")
+	assert.Contains(t, result.Warnings[0], "the failover connector is not available")
+}
+
+func TestFailover_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		request FailoverRequest
+		err     string
+	}{
+		{"signal", FailoverRequest{Signal: "profiles"}, `unsupported signal "profiles"`},
+		{"endpoint", FailoverRequest{Primary: "otlp", PrimaryConfig: map[string]interface{}{}}, "exporter otlp must set an endpoint"},
+		{"fallbacks", FailoverRequest{Primary: "otlp", PrimaryConfig: map[string]interface{}{"endpoint": "tempo:4317"}}, "at least one fallback endpoint"},
+		{"duplicate", FailoverRequest{Primary: "otlp", PrimaryConfig: map[string]interface{}{"endpoint": "tempo:4317"}, FallbackEndpoints: []string{"tempo:4317"}}, "fallback endpoint tempo:4317 is already the endpoint of otlp"},
+		{"id", FailoverRequest{Primary: "otlp/fallback", PrimaryConfig: map[string]interface{}{"endpoint": "tempo:4317"}, FallbackEndpoints: []string{"tempo-dr:4317"}}, "cannot be named otlp/fallback"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Failover(test.request)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
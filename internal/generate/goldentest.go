@@ -0,0 +1,235 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const (
+	// goldenReceiver replaces the receivers of the tested configuration, telemetrygen sends the inputs to it
+	goldenReceiver = "otlp/golden"
+	// goldenEndpoint does not use the default OTLP port to not conflict with a locally running collector
+	goldenEndpoint = "127.0.0.1:14317"
+	// goldenWait is the time the collector gets to flush the outputs after the inputs are sent
+	goldenWait = 5 * time.Second
+)
+
+// environmentProcessors add data depending on where the collector runs, their outputs differ between machines
+var environmentProcessors = []string{"k8sattributes", "resourcedetection"}
+
+// randomProcessors make non-deterministic decisions, the golden outputs are not stable
+var randomProcessors = []string{"probabilistic_sampler", "tail_sampling"}
+
+// goldenNormalize flattens the file exporter batches into sorted records and removes IDs and timestamps
+const goldenNormalize = `# Flattens the file exporter batches into records and removes the fields that change between runs
+def records:
+  (.resourceSpans // .resourceMetrics // .resourceLogs // [])[] as $resource
+  | ($resource.scopeSpans // $resource.scopeMetrics // $resource.scopeLogs // [])[] as $scope
+  | ($scope.spans // $scope.metrics // $scope.logRecords // [])[]
+  | {resource: $resource.resource, scope: $scope.scope, record: .};
+
+[.[] | records]
+| walk(if type == "object" then del(.traceId, .spanId, .parentSpanId, .startTimeUnixNano, .endTimeUnixNano, .timeUnixNano, .observedTimeUnixNano) else . end)
+| sort
+`
+
+// telemetrygenCommands are the inputs sent for each signal, the attributes make the inputs deterministic
+var telemetrygenCommands = map[string]string{
+	"traces":  `telemetrygen traces --otlp-endpoint "$ENDPOINT" --otlp-insecure --traces %d --service golden-test --otlp-attributes 'deployment.environment="test"' --telemetry-attributes 'http.request.method="GET"'`,
+	"metrics": `telemetrygen metrics --otlp-endpoint "$ENDPOINT" --otlp-insecure --metrics %d --metric-type Sum --service golden-test --otlp-attributes 'deployment.environment="test"' --telemetry-attributes 'http.request.method="GET"'`,
+	"logs":    `telemetrygen logs --otlp-endpoint "$ENDPOINT" --otlp-insecure --logs %d --service golden-test --body 'golden test log' --otlp-attributes 'deployment.environment="test"' --telemetry-attributes 'http.request.method="GET"'`,
+}
+
+// GoldenTestRequest configures the generated golden test harness
+type GoldenTestRequest struct {
+	// Collector is the collector binary started by the script, defaults to otelcol-contrib
+	Collector string
+	// Count is the number of traces, metrics and logs sent per signal, defaults to 5
+	Count int
+}
+
+// GeneratedFile is a file of a generated harness
+type GeneratedFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// GoldenTestResult contains the files of the golden test harness
+type GoldenTestResult struct {
+	Files    []GeneratedFile
+	Warnings []string
+}
+
+// GoldenTest generates a golden test harness for the processors of the configuration.
+// The receivers are replaced by an OTLP receiver fed by telemetrygen and the exporters by file exporters,
+// the script compares the normalized outputs with the expected outputs in testdata/expected.
+func GoldenTest(config *collectorconfig.Config, request GoldenTestRequest) (*GoldenTestResult, error) {
+	if issues := config.ValidateTopology(); collectorconfig.HasErrors(issues) {
+		return nil, fmt.Errorf("the configuration is not valid: %v", issues)
+	}
+	collector := request.Collector
+	if collector == "" {
+		collector = "otelcol-contrib"
+	}
+	count := request.Count
+	if count == 0 {
+		count = 5
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+
+	var warnings []string
+	test := collectorconfig.NewConfig()
+	test.Processors = config.Processors
+	test.Connectors = config.Connectors
+	test.Receivers[goldenReceiver] = map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{"endpoint": goldenEndpoint},
+		},
+	}
+	// The collector own telemetry is disabled to not bind the default metrics port
+	test.Service.Telemetry = map[string]interface{}{
+		"metrics": map[string]interface{}{"level": "none"},
+	}
+	if len(config.Extensions) > 0 {
+		warnings = append(warnings, "extensions are removed from the test configuration, components referencing them e.g. storage or authenticators have to be adjusted")
+	}
+
+	var signals, outputs []string
+	for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+		pipeline := config.Service.Pipelines[pipelineID]
+		signal := collectorconfig.Signal(pipelineID)
+		if _, supported := telemetrygenCommands[signal]; !supported {
+			warnings = append(warnings, fmt.Sprintf("pipeline %s is skipped, telemetrygen does not generate %s", pipelineID, signal))
+			continue
+		}
+
+		testPipeline := &collectorconfig.Pipeline{Processors: pipeline.Processors}
+		for _, id := range pipeline.Receivers {
+			if _, isConnector := config.Connectors[id]; isConnector {
+				testPipeline.Receivers = append(testPipeline.Receivers, id)
+				continue
+			}
+			if !slices.Contains(testPipeline.Receivers, goldenReceiver) {
+				testPipeline.Receivers = append(testPipeline.Receivers, goldenReceiver)
+				if !slices.Contains(signals, signal) {
+					signals = append(signals, signal)
+				}
+			}
+			if collectorconfig.ComponentType(id) != "otlp" {
+				warnings = append(warnings, fmt.Sprintf("receiver %s in pipeline %s is replaced by OTLP inputs, the test covers the processors but not the receiver", id, pipelineID))
+			}
+		}
+
+		output := strings.ReplaceAll(pipelineID, "/", "_") + ".json"
+		exporterID := "file/golden_" + strings.TrimSuffix(output, ".json")
+		for _, id := range pipeline.Exporters {
+			if _, isConnector := config.Connectors[id]; isConnector {
+				testPipeline.Exporters = append(testPipeline.Exporters, id)
+			} else if !slices.Contains(testPipeline.Exporters, exporterID) {
+				testPipeline.Exporters = append(testPipeline.Exporters, exporterID)
+			}
+		}
+		if slices.Contains(testPipeline.Exporters, exporterID) {
+			test.Exporters[exporterID] = map[string]interface{}{"path": "./output/" + output}
+			outputs = append(outputs, output)
+		}
+		test.Service.Pipelines[pipelineID] = testPipeline
+	}
+	if len(signals) == 0 {
+		return nil, fmt.Errorf("no pipeline with a receiver for traces, metrics or logs found")
+	}
+
+	wait := goldenWait
+	for _, id := range slices.Sorted(maps.Keys(config.Processors)) {
+		processorType := collectorconfig.ComponentType(id)
+		if slices.Contains(environmentProcessors, processorType) {
+			warnings = append(warnings, fmt.Sprintf("processor %s adds attributes of the environment, the outputs differ between machines, exclude the attributes in normalize.jq", id))
+		}
+		if slices.Contains(randomProcessors, processorType) {
+			warnings = append(warnings, fmt.Sprintf("processor %s samples randomly, the outputs are not stable unless it keeps all test inputs", id))
+		}
+		// Buffering processors delay the outputs
+		for _, key := range []string{"decision_wait", "timeout", "wait_duration"} {
+			settings, _ := config.Processors[id].(map[string]interface{})
+			if value, ok := settings[key].(string); ok {
+				if duration, err := time.ParseDuration(value); err == nil && duration+goldenWait > wait {
+					wait = duration + goldenWait
+				}
+			}
+		}
+	}
+
+	configYAML, err := test.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	var inputs strings.Builder
+	inputs.WriteString("# Inputs sent to the collector, edit the attributes to match what the processors act on\n")
+	for _, signal := range signals {
+		inputs.WriteString(fmt.Sprintf(telemetrygenCommands[signal], count))
+		inputs.WriteString("\n")
+	}
+
+	return &GoldenTestResult{
+		Files: []GeneratedFile{
+			{Path: "collector.yaml", Content: string(configYAML)},
+			{Path: "testdata/inputs.sh", Content: inputs.String()},
+			{Path: "normalize.jq", Content: goldenNormalize},
+			{Path: "run.sh", Content: goldenScript(collector, outputs, wait)},
+		},
+		Warnings: warnings,
+	}, nil
+}
+
+// goldenScript returns the script running the collector, sending the inputs and comparing the outputs
+func goldenScript(collector string, outputs []string, wait time.Duration) string {
+	return fmt.Sprintf(`#!/usr/bin/env bash
+# Golden tests of the collector configuration processors.
+# Usage: ./run.sh [--update]
+#   --update records the current outputs as the expected outputs in testdata/expected, review and commit them.
+# Requires the collector, jq and telemetrygen:
+#   go install github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen@latest
+set -euo pipefail
+cd "$(dirname "$0")"
+
+COLLECTOR="${COLLECTOR:-%s}"
+ENDPOINT="%s"
+OUTPUTS=(%s)
+
+rm -rf output && mkdir -p output testdata/expected
+"$COLLECTOR" --config collector.yaml > output/collector.log 2>&1 &
+COLLECTOR_PID=$!
+trap 'kill "$COLLECTOR_PID" 2>/dev/null || true' EXIT
+sleep 3
+
+source testdata/inputs.sh
+
+# Wait for the processors and exporters to flush
+sleep %d
+kill "$COLLECTOR_PID"
+wait "$COLLECTOR_PID" || true
+
+failed=0
+for output in "${OUTPUTS[@]}"; do
+  touch "output/$output"
+  jq -S -s -f normalize.jq "output/$output" > "output/$output.normalized"
+  if [[ "${1:-}" == "--update" ]]; then
+    cp "output/$output.normalized" "testdata/expected/$output"
+    echo "updated testdata/expected/$output"
+  elif ! diff -u "testdata/expected/$output" "output/$output.normalized"; then
+    echo "FAIL: $output differs from testdata/expected/$output"
+    failed=1
+  else
+    echo "PASS: $output"
+  fi
+done
+exit "$failed"
+`, collector, goldenEndpoint, strings.Join(outputs, " "), int(wait.Seconds()))
+}
@@ -0,0 +1,84 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestGoldenTest(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+extensions:
+  health_check:
+receivers:
+  otlp:
+    protocols:
+      grpc:
+  hostmetrics:
+    scrapers:
+      cpu:
+processors:
+  tail_sampling:
+    decision_wait: 10s
+    policies: []
+  batch:
+exporters:
+  otlp/backend:
+    endpoint: backend:4317
+connectors:
+  spanmetrics:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [tail_sampling, batch]
+      exporters: [otlp/backend, spanmetrics]
+    metrics:
+      receivers: [hostmetrics, spanmetrics]
+      processors: [batch]
+      exporters: [otlp/backend]
+`))
+	require.NoError(t, err)
+
+	result, err := GoldenTest(config, GoldenTestRequest{Count: 2})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 4)
+
+	test, err := collectorconfig.Parse([]byte(result.Files[0].Content))
+	require.NoError(t, err)
+	assert.Empty(t, test.ValidateTopology())
+	assert.Equal(t, []string{"otlp/golden"}, test.Service.Pipelines["traces"].Receivers)
+	assert.Equal(t, []string{"file/golden_traces", "spanmetrics"}, test.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"otlp/golden", "spanmetrics"}, test.Service.Pipelines["metrics"].Receivers)
+	assert.Equal(t, []string{"batch"}, test.Service.Pipelines["metrics"].Processors)
+
+	assert.Contains(t, result.Files[1].Content, "telemetrygen traces")
+	assert.Contains(t, result.Files[1].Content, "--traces 2")
+	assert.Contains(t, result.Files[1].Content, "telemetrygen metrics")
+	assert.NotContains(t, result.Files[1].Content, "telemetrygen logs")
+	assert.Contains(t, result.Files[3].Content, `OUTPUTS=(metrics.json traces.json)`)
+	// tail_sampling decision_wait delays the outputs
+	assert.Contains(t, result.Files[3].Content, "sleep 15")
+
+	assert.Contains(t, result.Warnings[0], "extensions are removed")
+	assert.Contains(t, result.Warnings[1], "receiver hostmetrics in pipeline metrics is replaced")
+	assert.Contains(t, result.Warnings[2], "tail_sampling samples randomly")
+}
+
+func TestGoldenTest_Invalid(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+receivers:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`))
+	require.NoError(t, err)
+	_, err = GoldenTest(config, GoldenTestRequest{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// kafkaPasswordEnv is the environment variable of the SASL password, secrets are not written to the configuration
+const kafkaPasswordEnv = "${env:KAFKA_PASSWORD}"
+
+// kafkaDefaultTopics are the default topics of the kafka exporter and receiver by signal
+var kafkaDefaultTopics = map[string]string{
+	"traces":  "otlp_spans",
+	"metrics": "otlp_metrics",
+	"logs":    "otlp_logs",
+}
+
+// kafkaEncodings are the encodings supported by both the kafka exporter and receiver by signal
+var kafkaEncodings = map[string][]string{
+	"traces":  {"otlp_proto", "otlp_json", "jaeger_proto", "jaeger_json", "zipkin_proto", "zipkin_json"},
+	"metrics": {"otlp_proto", "otlp_json"},
+	"logs":    {"otlp_proto", "otlp_json", "raw"},
+}
+
+// kafkaPartitioning maps the partitioning strategies to the kafka exporter setting by signal
+var kafkaPartitioning = map[string]map[string]string{
+	"trace_id": {"traces": "partition_traces_by_id"},
+	"resource": {"metrics": "partition_metrics_by_resource_attributes", "logs": "partition_logs_by_resource_attributes"},
+}
+
+// kafkaSASLMechanisms maps the SASL auth methods to the SASL mechanism
+var kafkaSASLMechanisms = map[string]string{
+	"plain":         "PLAIN",
+	"scram-sha-256": "SCRAM-SHA-256",
+	"scram-sha-512": "SCRAM-SHA-512",
+	"aws_msk_iam":   "AWS_MSK_IAM_OAUTHBEARER",
+}
+
+// KafkaRequest is the high-level intent for a collector exporting to Kafka and a collector consuming from it
+type KafkaRequest struct {
+	Signal  string   `json:"signal,omitempty"`
+	Brokers []string `json:"brokers"`
+	// Topic defaults to the topic of the signal e.g. otlp_spans
+	Topic    string `json:"topic,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	// Partitioning is none (default), trace_id (traces) or resource (metrics, logs)
+	Partitioning string `json:"partitioning,omitempty"`
+	// Auth is none (default), plain, scram-sha-256, scram-sha-512, mtls or aws_msk_iam
+	Auth     string `json:"auth,omitempty"`
+	Username string `json:"username,omitempty"`
+	// Region is the AWS region of the MSK cluster for aws_msk_iam
+	Region string `json:"region,omitempty"`
+	// CAFile, CertFile and KeyFile are the TLS files, the client certificate is used for mtls
+	CAFile          string `json:"ca_file,omitempty"`
+	CertFile        string `json:"cert_file,omitempty"`
+	KeyFile         string `json:"key_file,omitempty"`
+	GroupID         string `json:"group_id,omitempty"`
+	ProtocolVersion string `json:"protocol_version,omitempty"`
+	BackendEndpoint string `json:"backend_endpoint,omitempty"`
+	// LegacyTopic sets the topic and encoding at the top level for the versions without the signal specific settings
+	LegacyTopic bool `json:"legacy_topic,omitempty"`
+}
+
+// KafkaResult contains the configurations of the collector producing to Kafka and of the collector consuming from it
+type KafkaResult struct {
+	Producer *collectorconfig.Config
+	Consumer *collectorconfig.Config
+	Warnings []string
+	Issues   []collectorconfig.Issue
+}
+
+// Kafka generates the kafka exporter and receiver with matching topic, encoding and authentication settings
+func Kafka(request KafkaRequest) (*KafkaResult, error) {
+	signal := request.Signal
+	if signal == "" {
+		signal = "traces"
+	}
+	if !slices.Contains([]string{"traces", "metrics", "logs"}, signal) {
+		return nil, fmt.Errorf("unsupported signal %q, must be traces, metrics or logs", signal)
+	}
+	if len(request.Brokers) == 0 {
+		return nil, fmt.Errorf("brokers must be set e.g. kafka-1:9092,kafka-2:9092")
+	}
+
+	var warnings []string
+	topic := request.Topic
+	if topic == "" {
+		topic = kafkaDefaultTopics[signal]
+	}
+	encoding := request.Encoding
+	if encoding == "" {
+		encoding = "otlp_proto"
+	}
+	if !slices.Contains(kafkaEncodings[signal], encoding) {
+		return nil, fmt.Errorf("unsupported %s encoding %q, must be %s", signal, encoding, strings.Join(kafkaEncodings[signal], ", "))
+	}
+	switch {
+	case strings.HasPrefix(encoding, "jaeger_") || strings.HasPrefix(encoding, "zipkin_"):
+		warnings = append(warnings, fmt.Sprintf("%s translates the spans, OTLP only data e.g. the instrumentation scope and span links may be lost, use otlp_proto between collectors", encoding))
+	case encoding == "raw":
+		warnings = append(warnings, "raw writes only the log body to Kafka, the attributes and the resource are dropped")
+	case encoding == "otlp_json":
+		warnings = append(warnings, "otlp_json messages are several times larger than otlp_proto, use it only for consumers other than collectors")
+	}
+
+	common, authWarnings, err := kafkaClientConfig(request)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, authWarnings...)
+
+	exporterConfig := kafkaComponentConfig(common, signal, topic, encoding, request.LegacyTopic)
+	switch request.Partitioning {
+	case "", "none":
+		if signal == "traces" {
+			warnings = append(warnings, "the spans of a trace are spread across the partitions, set partitioning trace_id if the consumers sample or aggregate per trace")
+		}
+	default:
+		settings, ok := kafkaPartitioning[request.Partitioning]
+		if !ok {
+			return nil, fmt.Errorf("unsupported partitioning %q, must be none, trace_id or resource", request.Partitioning)
+		}
+		setting, ok := settings[signal]
+		if !ok {
+			return nil, fmt.Errorf("partitioning %s cannot be used with %s, it supports %s", request.Partitioning, signal, strings.Join(slices.Sorted(maps.Keys(settings)), ", "))
+		}
+		exporterConfig[setting] = true
+	}
+
+	groupID := request.GroupID
+	if groupID == "" {
+		groupID = "otel-collector"
+	}
+	receiverConfig := kafkaComponentConfig(common, signal, topic, encoding, request.LegacyTopic)
+	receiverConfig["group_id"] = groupID
+	warnings = append(warnings, fmt.Sprintf("create the topic %s with at least as many partitions as consumer collectors in the group %s, the consumers above the partition count are idle", topic, groupID))
+
+	producer := collectorconfig.NewConfig()
+	producer.Receivers["otlp"] = otlpReceiverConfig(4317)
+	producer.Processors["batch"] = nil
+	producer.Exporters["kafka"] = exporterConfig
+	producer.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+		Receivers:  []string{"otlp"},
+		Processors: []string{"batch"},
+		Exporters:  []string{"kafka"},
+	}
+
+	backendEndpoint := request.BackendEndpoint
+	if backendEndpoint == "" {
+		backendEndpoint = "backend:4317"
+	}
+	consumer := collectorconfig.NewConfig()
+	consumer.Receivers["kafka"] = receiverConfig
+	consumer.Processors["batch"] = nil
+	consumer.Exporters["otlp/backend"] = map[string]interface{}{"endpoint": backendEndpoint}
+	consumer.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+		Receivers:  []string{"kafka"},
+		Processors: []string{"batch"},
+		Exporters:  []string{"otlp/backend"},
+	}
+
+	var issues []collectorconfig.Issue
+	issues = append(issues, producer.ValidateTopology()...)
+	issues = append(issues, consumer.ValidateTopology()...)
+	return &KafkaResult{Producer: producer, Consumer: consumer, Warnings: warnings, Issues: issues}, nil
+}
+
+// kafkaClientConfig returns the broker, protocol and authentication settings shared by the kafka exporter and receiver
+func kafkaClientConfig(request KafkaRequest) (map[string]interface{}, []string, error) {
+	var warnings []string
+	protocolVersion := request.ProtocolVersion
+	if protocolVersion == "" {
+		protocolVersion = "2.1.0"
+	}
+	config := map[string]interface{}{
+		"brokers":          request.Brokers,
+		"protocol_version": protocolVersion,
+	}
+
+	tls := map[string]interface{}{"insecure": false}
+	if request.CAFile != "" {
+		tls["ca_file"] = request.CAFile
+	}
+	auth := request.Auth
+	switch auth {
+	case "", "none":
+		warnings = append(warnings, "no authentication and TLS, the telemetry is sent in plain text, use it only on a trusted network")
+		return config, warnings, nil
+	case "plain", "scram-sha-256", "scram-sha-512":
+		if request.Username == "" {
+			return nil, nil, fmt.Errorf("username must be set for the %s auth", auth)
+		}
+		config["auth"] = map[string]interface{}{
+			"sasl": map[string]interface{}{
+				"username":  request.Username,
+				"password":  kafkaPasswordEnv,
+				"mechanism": kafkaSASLMechanisms[auth],
+			},
+		}
+		if auth == "plain" {
+			warnings = append(warnings, "SASL/PLAIN sends the password to the brokers, it is protected only by TLS, prefer SCRAM if the brokers support it")
+		}
+		warnings = append(warnings, fmt.Sprintf("set the KAFKA_PASSWORD environment variable of both collectors to the password of %s", request.Username))
+	case "mtls":
+		if request.CertFile == "" || request.KeyFile == "" {
+			return nil, nil, fmt.Errorf("cert_file and key_file must be set for the mtls auth")
+		}
+		tls["cert_file"] = request.CertFile
+		tls["key_file"] = request.KeyFile
+	case "aws_msk_iam":
+		if request.Region == "" {
+			return nil, nil, fmt.Errorf("region must be set for the aws_msk_iam auth e.g. us-east-1")
+		}
+		config["auth"] = map[string]interface{}{
+			"sasl": map[string]interface{}{
+				"mechanism": kafkaSASLMechanisms[auth],
+				"aws_msk":   map[string]interface{}{"region": request.Region},
+			},
+		}
+		for _, broker := range request.Brokers {
+			if !strings.HasSuffix(broker, ":9098") {
+				warnings = append(warnings, fmt.Sprintf("broker %s does not use port 9098, MSK serves IAM authentication on port 9098", broker))
+			}
+		}
+		warnings = append(warnings, "the collectors need AWS credentials e.g. an IAM role for the service account with kafka-cluster:Connect, kafka-cluster:WriteData (producer) and kafka-cluster:ReadData (consumer) permissions")
+	default:
+		return nil, nil, fmt.Errorf("unsupported auth %q, must be none, plain, scram-sha-256, scram-sha-512, mtls or aws_msk_iam", auth)
+	}
+	config["tls"] = tls
+	return config, warnings, nil
+}
+
+// kafkaComponentConfig returns the kafka exporter or receiver configuration with the topic and encoding of the signal
+func kafkaComponentConfig(common map[string]interface{}, signal, topic, encoding string, legacyTopic bool) map[string]interface{} {
+	config := make(map[string]interface{}, len(common)+2)
+	maps.Copy(config, common)
+	if legacyTopic {
+		config["topic"] = topic
+		config["encoding"] = encoding
+	} else {
+		config[signal] = map[string]interface{}{"topic": topic, "encoding": encoding}
+	}
+	return config
+}
@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafka(t *testing.T) {
+	result, err := Kafka(KafkaRequest{
+		Brokers:      []string{"kafka-1:9092", "kafka-2:9092"},
+		Partitioning: "trace_id",
+		Auth:         "scram-sha-512",
+		Username:     "otel",
+		CAFile:       "/etc/kafka/ca.pem",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+
+	exporter := result.Producer.Exporters["kafka"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"topic": "otlp_spans", "encoding": "otlp_proto"}, exporter["traces"])
+	assert.Equal(t, true, exporter["partition_traces_by_id"])
+	assert.Equal(t, map[string]interface{}{
+		"sasl": map[string]interface{}{"username": "otel", "password": "${env:KAFKA_PASSWORD}", "mechanism": "SCRAM-SHA-512"},
+	}, exporter["auth"])
+	assert.Equal(t, map[string]interface{}{"insecure": false, "ca_file": "/etc/kafka/ca.pem"}, exporter["tls"])
+	assert.NotContains(t, exporter, "topic")
+
+	receiver := result.Consumer.Receivers["kafka"].(map[string]interface{})
+	assert.Equal(t, exporter["traces"], receiver["traces"])
+	assert.Equal(t, exporter["auth"], receiver["auth"])
+	assert.Equal(t, "otel-collector", receiver["group_id"])
+	assert.NotContains(t, receiver, "partition_traces_by_id")
+	assert.Equal(t, []string{"kafka"}, result.Consumer.Service.Pipelines["traces"].Receivers)
+	assert.Equal(t, []string{"otlp/backend"}, result.Consumer.Service.Pipelines["traces"].Exporters)
+}
+
+func TestKafka_MSKIAM(t *testing.T) {
+	result, err := Kafka(KafkaRequest{
+		Signal:       "logs",
+		Brokers:      []string{"b-1.msk.amazonaws.com:9098", "b-2.msk.amazonaws.com:9092"},
+		Partitioning: "resource",
+		Auth:         "aws_msk_iam",
+		Region:       "us-east-1",
+		LegacyTopic:  true,
+	})
+	require.NoError(t, err)
+	exporter := result.Producer.Exporters["kafka"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"sasl": map[string]interface{}{"mechanism": "AWS_MSK_IAM_OAUTHBEARER", "aws_msk": map[string]interface{}{"region": "us-east-1"}},
+	}, exporter["auth"])
+	assert.Equal(t, true, exporter["partition_logs_by_resource_attributes"])
+	assert.Equal(t, "otlp_logs", exporter["topic"])
+	assert.Equal(t, "otlp_proto", exporter["encoding"])
+	assert.NotContains(t, exporter, "logs")
+	assert.Contains(t, result.Warnings[0], "b-2.msk.amazonaws.com:9092 does not use port 9098")
+}
+
+func TestKafka_Invalid(t *testing.T) {
+	for _, request := range []KafkaRequest{
+		{},
+		{Brokers: []string{"kafka:9092"}, Signal: "profiles"},
+		{Brokers: []string{"kafka:9092"}, Signal: "metrics", Encoding: "jaeger_proto"},
+		{Brokers: []string{"kafka:9092"}, Signal: "metrics", Partitioning: "trace_id"},
+		{Brokers: []string{"kafka:9092"}, Partitioning: "round_robin"},
+		{Brokers: []string{"kafka:9092"}, Auth: "plain"},
+		{Brokers: []string{"kafka:9092"}, Auth: "mtls", CertFile: "client.pem"},
+		{Brokers: []string{"kafka:9098"}, Auth: "aws_msk_iam"},
+		{Brokers: []string{"kafka:9092"}, Auth: "kerberos"},
+	} {
+		_, err := Kafka(request)
+		assert.Error(t, err, "%+v", request)
+	}
+}
@@ -0,0 +1,199 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// loadBalancingRoutingKeys maps loadbalancing exporter routing keys to the signals they support
+var loadBalancingRoutingKeys = map[string][]string{
+	"traceID":    {"traces", "logs"},
+	"service":    {"traces", "metrics"},
+	"attributes": {"traces"},
+	"resource":   {"metrics"},
+	"metric":     {"metrics"},
+	"streamID":   {"metrics"},
+}
+
+// LoadBalancingRequest is the high-level intent for a two tier load balancing setup
+type LoadBalancingRequest struct {
+	Signal            string   `json:"signal,omitempty"`
+	RoutingKey        string   `json:"routing_key,omitempty"`
+	RoutingAttributes []string `json:"routing_attributes,omitempty"`
+	// Resolver discovers the downstream collectors: static, dns or k8s
+	Resolver string `json:"resolver,omitempty"`
+	// Hostnames are the static downstream collectors, the DNS hostname or the k8s service (name.namespace)
+	Hostnames       []string `json:"hostnames"`
+	Port            int      `json:"port,omitempty"`
+	BackendEndpoint string   `json:"backend_endpoint,omitempty"`
+	TailSampling    bool     `json:"tail_sampling,omitempty"`
+}
+
+// LoadBalancingResult contains the load balancer tier and the downstream collector tier configurations
+type LoadBalancingResult struct {
+	LoadBalancer *collectorconfig.Config
+	Downstream   *collectorconfig.Config
+	Issues       []collectorconfig.Issue
+}
+
+// LoadBalancing generates the loadbalancing exporter tier and the matching downstream collector configuration
+func LoadBalancing(request LoadBalancingRequest) (*LoadBalancingResult, error) {
+	signal := request.Signal
+	if signal == "" {
+		signal = "traces"
+	}
+	if !slices.Contains([]string{"traces", "metrics", "logs"}, signal) {
+		return nil, fmt.Errorf("unsupported signal %q, must be traces, metrics or logs", signal)
+	}
+
+	routingKey := request.RoutingKey
+	if routingKey == "" {
+		routingKey = "traceID"
+		if signal == "metrics" {
+			routingKey = "service"
+		}
+	}
+	signals, ok := loadBalancingRoutingKeys[routingKey]
+	if !ok {
+		return nil, fmt.Errorf("unsupported routing_key %q, supported keys: %s", routingKey, strings.Join(slices.Sorted(maps.Keys(loadBalancingRoutingKeys)), ", "))
+	}
+	if !slices.Contains(signals, signal) {
+		return nil, fmt.Errorf("routing_key %s cannot be used with %s, it supports %s", routingKey, signal, strings.Join(signals, ", "))
+	}
+	if routingKey == "attributes" && len(request.RoutingAttributes) == 0 {
+		return nil, fmt.Errorf("routing_attributes must be set for routing_key attributes")
+	}
+	if request.TailSampling {
+		if signal != "traces" {
+			return nil, fmt.Errorf("tail sampling is only supported for traces")
+		}
+		// All spans of a trace have to reach the same downstream collector to make a sampling decision
+		if routingKey != "traceID" {
+			return nil, fmt.Errorf("tail sampling requires routing_key traceID, %s splits spans of a trace across downstream collectors", routingKey)
+		}
+	}
+
+	port := request.Port
+	if port == 0 {
+		port = 4317
+	}
+	resolver, err := loadBalancingResolver(request.Resolver, request.Hostnames, port)
+	if err != nil {
+		return nil, err
+	}
+
+	exporterConfig := map[string]interface{}{
+		"routing_key": routingKey,
+		"protocol": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"tls": map[string]interface{}{"insecure": true},
+			},
+		},
+		"resolver": resolver,
+	}
+	if routingKey == "attributes" {
+		exporterConfig["routing_attributes"] = request.RoutingAttributes
+	}
+
+	loadBalancer := collectorconfig.NewConfig()
+	loadBalancer.Receivers["otlp"] = otlpReceiverConfig(4317)
+	loadBalancer.Exporters["loadbalancing"] = exporterConfig
+	loadBalancer.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+		Receivers: []string{"otlp"},
+		Exporters: []string{"loadbalancing"},
+	}
+
+	backendEndpoint := request.BackendEndpoint
+	if backendEndpoint == "" {
+		backendEndpoint = "backend:4317"
+	}
+	downstream := collectorconfig.NewConfig()
+	downstream.Receivers["otlp"] = otlpReceiverConfig(port)
+	downstream.Exporters["otlp/backend"] = map[string]interface{}{"endpoint": backendEndpoint}
+	pipeline := &collectorconfig.Pipeline{
+		Receivers: []string{"otlp"},
+		Exporters: []string{"otlp/backend"},
+	}
+	if request.TailSampling {
+		downstream.Processors["tail_sampling"] = map[string]interface{}{
+			"decision_wait": "10s",
+			"policies": []interface{}{
+				map[string]interface{}{
+					"name":        "errors",
+					"type":        "status_code",
+					"status_code": map[string]interface{}{"status_codes": []string{"ERROR"}},
+				},
+				map[string]interface{}{
+					"name":          "probabilistic",
+					"type":          "probabilistic",
+					"probabilistic": map[string]interface{}{"sampling_percentage": 10},
+				},
+			},
+		}
+		pipeline.Processors = append(pipeline.Processors, "tail_sampling")
+	}
+	downstream.Processors["batch"] = nil
+	pipeline.Processors = append(pipeline.Processors, "batch")
+	downstream.Service.Pipelines[signal] = pipeline
+
+	var issues []collectorconfig.Issue
+	issues = append(issues, loadBalancer.ValidateTopology()...)
+	issues = append(issues, downstream.ValidateTopology()...)
+	return &LoadBalancingResult{LoadBalancer: loadBalancer, Downstream: downstream, Issues: issues}, nil
+}
+
+// loadBalancingResolver returns the loadbalancing exporter resolver configuration
+func loadBalancingResolver(resolver string, hostnames []string, port int) (map[string]interface{}, error) {
+	if resolver == "" {
+		resolver = "static"
+	}
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("hostnames must be set for the %s resolver", resolver)
+	}
+
+	switch resolver {
+	case "static":
+		var endpoints []string
+		for _, hostname := range hostnames {
+			if !strings.Contains(hostname, ":") {
+				hostname = fmt.Sprintf("%s:%d", hostname, port)
+			}
+			endpoints = append(endpoints, hostname)
+		}
+		return map[string]interface{}{
+			"static": map[string]interface{}{"hostnames": endpoints},
+		}, nil
+	case "dns":
+		if len(hostnames) != 1 {
+			return nil, fmt.Errorf("the dns resolver requires exactly one hostname e.g. a headless service")
+		}
+		return map[string]interface{}{
+			"dns": map[string]interface{}{"hostname": hostnames[0], "port": port},
+		}, nil
+	case "k8s":
+		if len(hostnames) != 1 {
+			return nil, fmt.Errorf("the k8s resolver requires exactly one service e.g. collector.observability")
+		}
+		if !strings.Contains(hostnames[0], ".") {
+			return nil, fmt.Errorf("the k8s resolver service %q must be in the name.namespace format", hostnames[0])
+		}
+		return map[string]interface{}{
+			"k8s": map[string]interface{}{"service": hostnames[0], "ports": []int{port}},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported resolver %q, must be static, dns or k8s", resolver)
+	}
+}
+
+// otlpReceiverConfig returns the OTLP receiver listening for gRPC on the port
+func otlpReceiverConfig(port int) map[string]interface{} {
+	return map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{"endpoint": fmt.Sprintf("0.0.0.0:%d", port)},
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBalancing(t *testing.T) {
+	result, err := LoadBalancing(LoadBalancingRequest{
+		Resolver:     "k8s",
+		Hostnames:    []string{"sampler.observability"},
+		TailSampling: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+
+	exporter := result.LoadBalancer.Exporters["loadbalancing"].(map[string]interface{})
+	assert.Equal(t, "traceID", exporter["routing_key"])
+	assert.Equal(t, map[string]interface{}{
+		"k8s": map[string]interface{}{"service": "sampler.observability", "ports": []int{4317}},
+	}, exporter["resolver"])
+	assert.Equal(t, []string{"tail_sampling", "batch"}, result.Downstream.Service.Pipelines["traces"].Processors)
+
+	result, err = LoadBalancing(LoadBalancingRequest{Signal: "metrics", Hostnames: []string{"collector-1", "collector-2:5317"}})
+	require.NoError(t, err)
+	exporter = result.LoadBalancer.Exporters["loadbalancing"].(map[string]interface{})
+	assert.Equal(t, "service", exporter["routing_key"])
+	assert.Equal(t, map[string]interface{}{
+		"static": map[string]interface{}{"hostnames": []string{"collector-1:4317", "collector-2:5317"}},
+	}, exporter["resolver"])
+}
+
+func TestLoadBalancing_Invalid(t *testing.T) {
+	_, err := LoadBalancing(LoadBalancingRequest{RoutingKey: "service", Hostnames: []string{"a"}, TailSampling: true})
+	require.Error(t, err)
+
+	_, err = LoadBalancing(LoadBalancingRequest{Signal: "logs", RoutingKey: "service", Hostnames: []string{"a"}})
+	require.Error(t, err)
+
+	_, err = LoadBalancing(LoadBalancingRequest{Resolver: "k8s", Hostnames: []string{"sampler"}})
+	require.Error(t, err)
+
+	_, err = LoadBalancing(LoadBalancingRequest{Resolver: "dns"})
+	require.Error(t, err)
+}
@@ -0,0 +1,367 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// observerEndpointTypes maps observer extensions to the endpoint types they discover
+var observerEndpointTypes = map[string][]string{
+	"k8s_observer":    {"port", "pod", "pod.container", "k8s.node", "k8s.service", "k8s.ingress"},
+	"docker_observer": {"container"},
+	"host_observer":   {"hostport"},
+	"ecs_observer":    {"container"},
+}
+
+// endpointVariables are the variables available in receiver_creator rules per endpoint type
+var endpointVariables = map[string][]string{
+	"port":          {"type", "endpoint", "name", "port", "protocol", "transport", "pod.name", "pod.namespace", "pod.uid", "pod.labels", "pod.annotations"},
+	"pod":           {"type", "endpoint", "name", "namespace", "uid", "labels", "annotations"},
+	"pod.container": {"type", "endpoint", "container_name", "container_id", "container_image", "pod.name", "pod.namespace", "pod.uid", "pod.labels", "pod.annotations"},
+	"hostport":      {"type", "endpoint", "process_name", "command", "is_ipv6", "port", "transport"},
+	"container":     {"type", "endpoint", "name", "image", "tag", "port", "alternate_port", "command", "container_id", "host", "transport", "labels"},
+	"k8s.node":      {"type", "endpoint", "name", "uid", "annotations", "labels", "hostname", "external_ip", "internal_ip", "kubelet_endpoint_port"},
+	"k8s.service":   {"type", "endpoint", "name", "namespace", "uid", "labels", "annotations", "service_type", "cluster_ip"},
+	"k8s.ingress":   {"type", "endpoint", "name", "namespace", "uid", "labels", "annotations", "scheme", "host", "path"},
+}
+
+// ReceiverCreatorMatch describes which discovered endpoints a receiver is created for
+type ReceiverCreatorMatch struct {
+	Port           int               `json:"port,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Namespace      string            `json:"namespace,omitempty"`
+	Image          string            `json:"image,omitempty"`
+	ProcessName    string            `json:"process_name,omitempty"`
+	PodLabels      map[string]string `json:"pod_labels,omitempty"`
+	PodAnnotations map[string]string `json:"pod_annotations,omitempty"`
+}
+
+// ReceiverCreatorRequest is the high-level intent for a receiver_creator configuration
+type ReceiverCreatorRequest struct {
+	Observer     string                 `json:"observer"`
+	EndpointType string                 `json:"endpoint_type,omitempty"`
+	Receiver     string                 `json:"receiver"`
+	Match        ReceiverCreatorMatch   `json:"match"`
+	Config       map[string]interface{} `json:"config,omitempty"`
+	Signal       string                 `json:"signal,omitempty"`
+}
+
+// ReceiverCreatorResult is the generated receiver_creator configuration
+type ReceiverCreatorResult struct {
+	Config         *collectorconfig.Config
+	Rule           string
+	ReceiverConfig map[string]interface{}
+}
+
+// ReceiverCreator generates the observer extension, the receiver_creator rule and pipeline wiring
+func ReceiverCreator(request ReceiverCreatorRequest) (*ReceiverCreatorResult, error) {
+	endpointTypes, ok := observerEndpointTypes[request.Observer]
+	if !ok {
+		return nil, fmt.Errorf("unsupported observer %q, supported observers: %s", request.Observer, strings.Join(slices.Sorted(maps.Keys(observerEndpointTypes)), ", "))
+	}
+	if request.Receiver == "" {
+		return nil, fmt.Errorf("receiver must be set")
+	}
+
+	endpointType := request.EndpointType
+	if endpointType == "" {
+		endpointType = endpointTypes[0]
+	}
+	if !slices.Contains(endpointTypes, endpointType) {
+		return nil, fmt.Errorf("observer %s does not discover endpoints of type %q, supported types: %s", request.Observer, endpointType, strings.Join(endpointTypes, ", "))
+	}
+
+	rule, err := receiverCreatorRule(endpointType, request.Match)
+	if err != nil {
+		return nil, err
+	}
+
+	receiverConfig := request.Config
+	if receiverConfig == nil {
+		// The discovered endpoint is available as a backtick expression
+		receiverConfig = map[string]interface{}{"endpoint": "`endpoint`"}
+	}
+
+	signal := request.Signal
+	if signal == "" {
+		signal = "metrics"
+	}
+
+	config := collectorconfig.NewConfig()
+	config.Extensions[request.Observer] = observerConfig(request.Observer, endpointType)
+	config.Receivers["receiver_creator"] = map[string]interface{}{
+		"watch_observers": []string{request.Observer},
+		"receivers": map[string]interface{}{
+			request.Receiver: map[string]interface{}{
+				"rule":   rule,
+				"config": receiverConfig,
+			},
+		},
+	}
+	config.Exporters["debug"] = nil
+	config.Service.Extensions = []string{request.Observer}
+	config.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+		Receivers: []string{"receiver_creator"},
+		Exporters: []string{"debug"},
+	}
+
+	return &ReceiverCreatorResult{Config: config, Rule: rule, ReceiverConfig: receiverConfig}, nil
+}
+
+// receiverCreatorRule builds the rule expression from the match criteria
+func receiverCreatorRule(endpointType string, match ReceiverCreatorMatch) (string, error) {
+	conditions := []string{fmt.Sprintf("type == %q", endpointType)}
+	variables := endpointVariables[endpointType]
+
+	add := func(variable, condition string) error {
+		root := variable
+		if idx := strings.Index(variable, "["); idx > 0 {
+			root = variable[:idx]
+		}
+		if !slices.Contains(variables, root) {
+			return fmt.Errorf("%s is not available for endpoint type %s", root, endpointType)
+		}
+		conditions = append(conditions, condition)
+		return nil
+	}
+
+	if match.Port != 0 {
+		if err := add("port", fmt.Sprintf("port == %d", match.Port)); err != nil {
+			return "", err
+		}
+	}
+	if match.Name != "" {
+		variable := "name"
+		if endpointType == "pod.container" {
+			variable = "container_name"
+		}
+		if err := add(variable, fmt.Sprintf("%s == %q", variable, match.Name)); err != nil {
+			return "", err
+		}
+	}
+	if match.Namespace != "" {
+		variable := "namespace"
+		if endpointType == "port" || endpointType == "pod.container" {
+			variable = "pod.namespace"
+		}
+		if err := add(variable, fmt.Sprintf("%s == %q", variable, match.Namespace)); err != nil {
+			return "", err
+		}
+	}
+	if match.Image != "" {
+		variable := "image"
+		if endpointType == "pod.container" {
+			variable = "container_image"
+		}
+		if err := add(variable, fmt.Sprintf("%s matches %q", variable, match.Image)); err != nil {
+			return "", err
+		}
+	}
+	if match.ProcessName != "" {
+		if err := add("process_name", fmt.Sprintf("process_name == %q", match.ProcessName)); err != nil {
+			return "", err
+		}
+	}
+
+	// Port and container endpoints expose the labels and annotations of their pod
+	podPrefix := ""
+	if endpointType == "port" || endpointType == "pod.container" {
+		podPrefix = "pod."
+	}
+	for _, key := range slices.Sorted(maps.Keys(match.PodLabels)) {
+		variable := podPrefix + "labels"
+		if err := add(variable, fmt.Sprintf("%s[%q] == %q", variable, key, match.PodLabels[key])); err != nil {
+			return "", err
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(match.PodAnnotations)) {
+		variable := podPrefix + "annotations"
+		if err := add(variable, fmt.Sprintf("%s[%q] == %q", variable, key, match.PodAnnotations[key])); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.Join(conditions, " && "), nil
+}
+
+// observerConfig returns the observer extension configuration for the endpoint type
+func observerConfig(observer, endpointType string) map[string]interface{} {
+	switch observer {
+	case "k8s_observer":
+		config := map[string]interface{}{
+			"auth_type": "serviceAccount",
+			"node":      "${env:K8S_NODE_NAME}",
+		}
+		switch endpointType {
+		case "k8s.node":
+			config["observe_nodes"] = true
+			config["observe_pods"] = false
+		case "k8s.service":
+			config["observe_services"] = true
+			config["observe_pods"] = false
+		case "k8s.ingress":
+			config["observe_ingresses"] = true
+			config["observe_pods"] = false
+		default:
+			config["observe_pods"] = true
+		}
+		return config
+	case "docker_observer":
+		return map[string]interface{}{"endpoint": "unix:///var/run/docker.sock"}
+	case "ecs_observer":
+		return map[string]interface{}{"cluster_name": "${env:ECS_CLUSTER_NAME}", "cluster_region": "${env:AWS_REGION}"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// ValidateReceiverCreatorRule validates the syntax of a receiver_creator rule and the variables it uses
+func ValidateReceiverCreatorRule(rule string) []string {
+	var problems []string
+
+	tokens, err := tokenizeRule(rule)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	// Rules have to start with the endpoint type check
+	endpointType := ""
+	if len(tokens) >= 3 && tokens[0] == "type" && tokens[1] == "==" && strings.HasPrefix(tokens[2], "\"") {
+		endpointType, _ = strconv.Unquote(tokens[2])
+	} else {
+		problems = append(problems, `rule must start with the endpoint type check e.g. type == "port"`)
+	}
+
+	variables, knownType := endpointVariables[endpointType]
+	if endpointType != "" && !knownType {
+		problems = append(problems, fmt.Sprintf("unknown endpoint type %q, supported types: %s", endpointType, strings.Join(slices.Sorted(maps.Keys(endpointVariables)), ", ")))
+	}
+
+	depth := 0
+	expectOperand := true
+	for i, tok := range tokens {
+		switch {
+		case tok == "(" || tok == "[":
+			depth++
+			expectOperand = true
+		case tok == ")" || tok == "]":
+			depth--
+			if depth < 0 {
+				problems = append(problems, "unbalanced closing bracket")
+				depth = 0
+			}
+			expectOperand = false
+		case tok == ",":
+			expectOperand = true
+		case isRuleOperator(tok):
+			if expectOperand && tok != "!" && tok != "not" {
+				problems = append(problems, fmt.Sprintf("operator %q is missing its left operand", tok))
+			}
+			expectOperand = true
+		case tok == "=":
+			problems = append(problems, `invalid operator "=", use "==" for comparisons`)
+		default:
+			if !expectOperand && tokens[i-1] != "]" {
+				problems = append(problems, fmt.Sprintf("missing operator before %q", tok))
+			}
+			expectOperand = false
+			if isRuleIdentifier(tok) && knownType && !(i > 0 && tokens[i-1] == "[") {
+				root := tok
+				if !slices.Contains(variables, root) {
+					problems = append(problems, fmt.Sprintf("variable %q is not available for endpoint type %s, available variables: %s", root, endpointType, strings.Join(variables, ", ")))
+				}
+			}
+		}
+	}
+	if depth != 0 {
+		problems = append(problems, "unbalanced brackets")
+	}
+	if expectOperand && len(tokens) > 0 {
+		problems = append(problems, "rule ends with an operator")
+	}
+
+	return problems
+}
+
+var ruleOperators = []string{"==", "!=", "<", "<=", ">", ">=", "&&", "||", "!", "and", "or", "not", "matches", "contains", "startsWith", "endsWith", "in"}
+
+func isRuleOperator(token string) bool {
+	return slices.Contains(ruleOperators, token)
+}
+
+func isRuleIdentifier(token string) bool {
+	if token == "" || token == "true" || token == "false" || token == "nil" {
+		return false
+	}
+	r := rune(token[0])
+	return unicode.IsLetter(r) || r == '_'
+}
+
+// tokenizeRule splits an expr-lang rule into identifiers (including dotted paths), literals, brackets and operators
+func tokenizeRule(rule string) ([]string, error) {
+	var tokens []string
+	runes := []rune(rule)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'' || r == '`':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start)
+			}
+			i++
+			literal := string(runes[start:i])
+			if r != '"' {
+				literal = strconv.Quote(literal[1 : len(literal)-1])
+			}
+			tokens = append(tokens, literal)
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		case strings.ContainsRune("()[],", r):
+			tokens = append(tokens, string(r))
+			i++
+		case strings.ContainsRune("=!<>&|", r):
+			start := i
+			i++
+			if i < len(runes) && strings.ContainsRune("=&|", runes[i]) {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "&" || op == "|" {
+				return nil, fmt.Errorf("invalid operator %q at position %d, use && or ||", op, start)
+			}
+			tokens = append(tokens, op)
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("rule is empty")
+	}
+	return tokens, nil
+}
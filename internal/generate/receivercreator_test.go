@@ -0,0 +1,63 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiverCreator(t *testing.T) {
+	result, err := ReceiverCreator(ReceiverCreatorRequest{
+		Observer: "k8s_observer",
+		Receiver: "redis",
+		Match: ReceiverCreatorMatch{
+			Port:           6379,
+			PodAnnotations: map[string]string{"redis.io/scrape": "true"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `type == "port" && port == 6379 && pod.annotations["redis.io/scrape"] == "true"`, result.Rule)
+	assert.Empty(t, ValidateReceiverCreatorRule(result.Rule))
+	assert.Equal(t, map[string]interface{}{"endpoint": "`endpoint`"}, result.ReceiverConfig)
+	assert.Contains(t, result.Config.Extensions, "k8s_observer")
+	assert.Equal(t, []string{"receiver_creator"}, result.Config.Service.Pipelines["metrics"].Receivers)
+
+	configYAML, err := result.Config.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(configYAML), "watch_observers:")
+}
+
+func TestReceiverCreator_Invalid(t *testing.T) {
+	_, err := ReceiverCreator(ReceiverCreatorRequest{Observer: "unknown_observer", Receiver: "redis"})
+	require.Error(t, err)
+
+	_, err = ReceiverCreator(ReceiverCreatorRequest{Observer: "docker_observer", EndpointType: "pod", Receiver: "redis"})
+	require.Error(t, err)
+
+	_, err = ReceiverCreator(ReceiverCreatorRequest{Observer: "host_observer", Receiver: "redis", Match: ReceiverCreatorMatch{Image: "redis"}})
+	require.Error(t, err)
+}
+
+func TestValidateReceiverCreatorRule(t *testing.T) {
+	tests := []struct {
+		rule  string
+		valid bool
+	}{
+		{`type == "port" && port == 6379`, true},
+		{`type == "pod.container" && container_image matches "redis" && pod.labels["app"] == "redis"`, true},
+		{`type == "hostport" and process_name in ["redis-server", "redis"]`, true},
+		{`port == 6379`, false},
+		{`type == "port" && container_image == "redis"`, false},
+		{`type = "port"`, false},
+		{`type == "port" && (port == 6379`, false},
+		{`type == "port" &&`, false},
+		{`type == "unknown"`, false},
+		{`type == "port" & port == 1`, false},
+	}
+	for _, tt := range tests {
+		problems := ValidateReceiverCreatorRule(tt.rule)
+		assert.Equal(t, tt.valid, len(problems) == 0, "%s: %v", tt.rule, problems)
+	}
+}
@@ -0,0 +1,433 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/ottl"
+)
+
+// routingContextSignals maps routing connector contexts to the signals they can be used with
+var routingContextSignals = map[string][]string{
+	"resource":  {"traces", "metrics", "logs"},
+	"request":   {"traces", "metrics", "logs"},
+	"span":      {"traces"},
+	"metric":    {"metrics"},
+	"datapoint": {"metrics"},
+	"log":       {"logs"},
+}
+
+// RoutingRoute routes telemetry with an attribute or request header value to exporters
+type RoutingRoute struct {
+	Name string `json:"name,omitempty"`
+	// Source is where the attribute is read from: resource, request (client metadata e.g. tenant header), span, metric, datapoint or log
+	Source    string   `json:"source,omitempty"`
+	Attribute string   `json:"attribute,omitempty"`
+	Values    []string `json:"values,omitempty"`
+	// Condition is a custom OTTL condition used instead of the attribute and values
+	Condition string   `json:"condition,omitempty"`
+	Exporters []string `json:"exporters"`
+}
+
+// RoutingRequest is the high-level routing intent
+type RoutingRequest struct {
+	Signal           string         `json:"signal"`
+	Receivers        []string       `json:"receivers,omitempty"`
+	Routes           []RoutingRoute `json:"routes"`
+	DefaultExporters []string       `json:"default_exporters,omitempty"`
+}
+
+// RoutingResult is the generated routing connector configuration
+type RoutingResult struct {
+	Config *collectorconfig.Config
+	Issues []collectorconfig.Issue
+}
+
+// Routing generates the routing connector table and the pipelines wiring it to the exporters
+func Routing(request RoutingRequest) (*RoutingResult, error) {
+	signal := request.Signal
+	if signal == "" {
+		signal = "traces"
+	}
+	if !slices.Contains([]string{"traces", "metrics", "logs"}, signal) {
+		return nil, fmt.Errorf("unsupported signal %q, must be traces, metrics or logs", signal)
+	}
+	if len(request.Routes) == 0 {
+		return nil, fmt.Errorf("at least one route must be set")
+	}
+	receivers := request.Receivers
+	if len(receivers) == 0 {
+		receivers = []string{"otlp"}
+	}
+
+	config := collectorconfig.NewConfig()
+	connectorID := "routing"
+	usesRequestContext := false
+
+	var table []interface{}
+	for i, route := range request.Routes {
+		if len(route.Exporters) == 0 {
+			return nil, fmt.Errorf("routes[%d]: at least one exporter must be set", i)
+		}
+		routeContext, condition, err := routingCondition(signal, route)
+		if err != nil {
+			return nil, fmt.Errorf("routes[%d]: %w", i, err)
+		}
+		if routeContext == "request" {
+			usesRequestContext = true
+		}
+
+		name := route.Name
+		if name == "" {
+			name = routePipelineName(route, i)
+		}
+		pipelineID := signal + "/" + name
+		if _, exists := config.Service.Pipelines[pipelineID]; exists {
+			return nil, fmt.Errorf("routes[%d]: pipeline %s is generated more than once, set a unique route name", i, pipelineID)
+		}
+		config.Service.Pipelines[pipelineID] = &collectorconfig.Pipeline{
+			Receivers: []string{connectorID},
+			Exporters: route.Exporters,
+		}
+
+		entry := map[string]interface{}{
+			"condition": condition,
+			"pipelines": []string{pipelineID},
+		}
+		// resource is the default context of the routing connector
+		if routeContext != "resource" {
+			entry["context"] = routeContext
+		}
+		table = append(table, entry)
+
+		for _, exporter := range route.Exporters {
+			config.Exporters[exporter] = nil
+		}
+	}
+
+	connectorConfig := map[string]interface{}{
+		"table": table,
+	}
+	if len(request.DefaultExporters) > 0 {
+		defaultPipeline := signal + "/default"
+		if _, exists := config.Service.Pipelines[defaultPipeline]; exists {
+			return nil, fmt.Errorf("route name default is reserved for the default pipeline")
+		}
+		config.Service.Pipelines[defaultPipeline] = &collectorconfig.Pipeline{
+			Receivers: []string{connectorID},
+			Exporters: request.DefaultExporters,
+		}
+		connectorConfig["default_pipelines"] = []string{defaultPipeline}
+		for _, exporter := range request.DefaultExporters {
+			config.Exporters[exporter] = nil
+		}
+	}
+	config.Connectors[connectorID] = connectorConfig
+
+	for _, receiver := range receivers {
+		config.Receivers[receiver] = nil
+		// Request headers are only available to the routing connector when the receiver keeps the client metadata
+		if usesRequestContext && collectorconfig.ComponentType(receiver) == "otlp" {
+			config.Receivers[receiver] = map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{"include_metadata": true},
+					"http": map[string]interface{}{"include_metadata": true},
+				},
+			}
+		}
+	}
+	config.Service.Pipelines[signal+"/in"] = &collectorconfig.Pipeline{
+		Receivers: receivers,
+		Exporters: []string{connectorID},
+	}
+
+	return &RoutingResult{Config: config, Issues: ValidateRouting(config)}, nil
+}
+
+// routingCondition returns the routing context and the OTTL condition of the route
+func routingCondition(signal string, route RoutingRoute) (string, string, error) {
+	routeContext := route.Source
+	if routeContext == "" {
+		routeContext = "resource"
+	}
+	signals, ok := routingContextSignals[routeContext]
+	if !ok {
+		return "", "", fmt.Errorf("unsupported source %q, supported sources: %s", routeContext, strings.Join(slices.Sorted(maps.Keys(routingContextSignals)), ", "))
+	}
+	if !slices.Contains(signals, signal) {
+		return "", "", fmt.Errorf("source %s cannot be used with %s, it supports %s", routeContext, signal, strings.Join(signals, ", "))
+	}
+
+	condition := route.Condition
+	if condition == "" {
+		if route.Attribute == "" || len(route.Values) == 0 {
+			return "", "", fmt.Errorf("either condition or attribute and values must be set")
+		}
+		path := fmt.Sprintf("attributes[%q]", route.Attribute)
+		if routeContext == "request" {
+			path = fmt.Sprintf("request[%q]", route.Attribute)
+		}
+		var comparisons []string
+		for _, value := range route.Values {
+			comparisons = append(comparisons, fmt.Sprintf("%s == %q", path, value))
+		}
+		condition = strings.Join(comparisons, " or ")
+	}
+
+	if problems := validateRoutingCondition(routeContext, condition); len(problems) > 0 {
+		return "", "", fmt.Errorf("invalid condition %q: %s", condition, strings.Join(problems, "; "))
+	}
+	return routeContext, condition, nil
+}
+
+// routePipelineName derives the pipeline name from the first route value
+func routePipelineName(route RoutingRoute, index int) string {
+	if len(route.Values) == 0 {
+		return fmt.Sprintf("route%d", index+1)
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(route.Values[0]) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// validateRoutingCondition parses the condition and checks the paths are valid in the routing context
+func validateRoutingCondition(routeContext, condition string) []string {
+	node, err := ottl.ParseCondition(condition)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var problems []string
+	for _, path := range ottl.Paths(node) {
+		isRequest := len(path.Segments) > 0 && path.Segments[0] == "request"
+		switch {
+		case routeContext == "request" && !isRequest:
+			problems = append(problems, fmt.Sprintf("path %s cannot be used in the request context, only request[\"<header>\"] is supported", path))
+		case routeContext == "request" && len(path.Keys) != 1:
+			problems = append(problems, fmt.Sprintf("path %s must select a single request header e.g. request[\"X-Tenant\"]", path))
+		case routeContext != "request" && isRequest:
+			problems = append(problems, fmt.Sprintf("path %s can only be used in the request context", path))
+		}
+	}
+	if routeContext != "request" && len(problems) == 0 {
+		problems = ottl.ValidatePaths(node, routeContext)
+	}
+	return problems
+}
+
+// ValidateRouting validates the pipeline topology and the routing connectors and processors of the configuration
+func ValidateRouting(config *collectorconfig.Config) []collectorconfig.Issue {
+	issues := config.ValidateTopology()
+	for _, id := range slices.Sorted(maps.Keys(config.Connectors)) {
+		if collectorconfig.ComponentType(id) == "routing" {
+			issues = append(issues, validateRoutingConnector(config, id)...)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(config.Processors)) {
+		if collectorconfig.ComponentType(id) == "routing" {
+			issues = append(issues, validateRoutingProcessor(config, id)...)
+		}
+	}
+	return issues
+}
+
+func validateRoutingConnector(config *collectorconfig.Config, id string) []collectorconfig.Issue {
+	var issues []collectorconfig.Issue
+	path := "connectors::" + id
+	errorf := func(path, format string, args ...interface{}) {
+		issues = append(issues, collectorconfig.Issue{Severity: collectorconfig.SeverityError, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	warnf := func(path, format string, args ...interface{}) {
+		issues = append(issues, collectorconfig.Issue{Severity: collectorconfig.SeverityWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// The routing connector can only route to pipelines of the signal it receives
+	var signals []string
+	for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+		signal := collectorconfig.Signal(pipelineID)
+		if slices.Contains(config.Service.Pipelines[pipelineID].Exporters, id) && !slices.Contains(signals, signal) {
+			signals = append(signals, signal)
+		}
+	}
+	checkPipelines := func(path string, pipelines []string) {
+		for _, pipelineID := range pipelines {
+			pipeline, exists := config.Service.Pipelines[pipelineID]
+			if !exists {
+				errorf(path, "routes to pipeline %q which is not defined", pipelineID)
+				continue
+			}
+			if !slices.Contains(pipeline.Receivers, id) {
+				errorf(path, "routes to pipeline %q which does not have %s as a receiver", pipelineID, id)
+			}
+			if len(signals) > 0 && !slices.Contains(signals, collectorconfig.Signal(pipelineID)) {
+				errorf(path, "routes to %s pipeline %q but receives %s", collectorconfig.Signal(pipelineID), pipelineID, strings.Join(signals, ", "))
+			}
+		}
+	}
+
+	connectorConfig, _ := config.Connectors[id].(map[string]interface{})
+	if errorMode, ok := connectorConfig["error_mode"].(string); ok && !slices.Contains([]string{"propagate", "ignore", "silent"}, errorMode) {
+		errorf(path+"::error_mode", "unsupported error_mode %q, must be propagate, ignore or silent", errorMode)
+	}
+	if _, ok := connectorConfig["match_once"]; ok {
+		warnf(path+"::match_once", "match_once is deprecated and removed in recent collector versions, the connector always routes to the first matching route")
+	}
+	if defaults, ok := connectorConfig["default_pipelines"]; ok {
+		checkPipelines(path+"::default_pipelines", stringList(defaults))
+	} else {
+		warnf(path, "default_pipelines is not set, telemetry not matching any route is dropped")
+	}
+
+	table, _ := connectorConfig["table"].([]interface{})
+	if len(table) == 0 {
+		errorf(path+"::table", "at least one route must be configured")
+	}
+	for i, item := range table {
+		routePath := fmt.Sprintf("%s::table[%d]", path, i)
+		route, ok := item.(map[string]interface{})
+		if !ok {
+			errorf(routePath, "route must be a map")
+			continue
+		}
+
+		routeContext := "resource"
+		if value, ok := route["context"].(string); ok {
+			routeContext = value
+		}
+		contextSignals, known := routingContextSignals[routeContext]
+		if !known {
+			errorf(routePath+"::context", "unsupported context %q, supported contexts: %s", routeContext, strings.Join(slices.Sorted(maps.Keys(routingContextSignals)), ", "))
+		} else {
+			for _, signal := range signals {
+				if !slices.Contains(contextSignals, signal) {
+					errorf(routePath+"::context", "context %s cannot be used with %s", routeContext, signal)
+				}
+			}
+		}
+
+		statement, hasStatement := route["statement"].(string)
+		condition, hasCondition := route["condition"].(string)
+		switch {
+		case hasStatement && hasCondition:
+			errorf(routePath, "only one of statement and condition can be set")
+		case hasStatement:
+			parsed, err := ottl.ParseStatement(statement)
+			if err != nil {
+				errorf(routePath+"::statement", "%v", err)
+				break
+			}
+			if parsed.Editor.Name != "route" {
+				errorf(routePath+"::statement", "statement must use the route() function, got %s()", parsed.Editor.Name)
+			}
+			if parsed.Condition != nil && known {
+				for _, problem := range validateRoutingCondition(routeContext, parsed.Condition.String()) {
+					errorf(routePath+"::statement", "%s", problem)
+				}
+			}
+		case hasCondition:
+			if known {
+				for _, problem := range validateRoutingCondition(routeContext, condition) {
+					errorf(routePath+"::condition", "%s", problem)
+				}
+			}
+		default:
+			errorf(routePath, "either statement or condition must be set")
+		}
+
+		pipelines := stringList(route["pipelines"])
+		if len(pipelines) == 0 {
+			errorf(routePath+"::pipelines", "at least one pipeline must be set")
+		}
+		checkPipelines(routePath+"::pipelines", pipelines)
+	}
+	return issues
+}
+
+func validateRoutingProcessor(config *collectorconfig.Config, id string) []collectorconfig.Issue {
+	var issues []collectorconfig.Issue
+	path := "processors::" + id
+	errorf := func(path, format string, args ...interface{}) {
+		issues = append(issues, collectorconfig.Issue{Severity: collectorconfig.SeverityError, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	issues = append(issues, collectorconfig.Issue{
+		Severity: collectorconfig.SeverityWarning,
+		Path:     path,
+		Message:  "the routing processor is deprecated and removed in recent collector versions, use the routing connector instead",
+	})
+
+	processorConfig, _ := config.Processors[id].(map[string]interface{})
+	if source, ok := processorConfig["attribute_source"].(string); ok && source != "context" && source != "resource" {
+		errorf(path+"::attribute_source", "unsupported attribute_source %q, must be context or resource", source)
+	}
+	checkExporters := func(path string, exporters []string) {
+		for _, exporter := range exporters {
+			if _, exists := config.Exporters[exporter]; !exists {
+				errorf(path, "routes to exporter %q which is not defined", exporter)
+			}
+		}
+	}
+	checkExporters(path+"::default_exporters", stringList(processorConfig["default_exporters"]))
+
+	table, _ := processorConfig["table"].([]interface{})
+	if len(table) == 0 {
+		errorf(path+"::table", "at least one route must be configured")
+	}
+	for i, item := range table {
+		routePath := fmt.Sprintf("%s::table[%d]", path, i)
+		route, ok := item.(map[string]interface{})
+		if !ok {
+			errorf(routePath, "route must be a map")
+			continue
+		}
+		_, hasValue := route["value"]
+		statement, hasStatement := route["statement"].(string)
+		switch {
+		case hasValue && hasStatement:
+			errorf(routePath, "only one of value and statement can be set")
+		case hasValue:
+			if _, ok := processorConfig["from_attribute"].(string); !ok {
+				errorf(routePath+"::value", "from_attribute must be set to route by value")
+			}
+		case hasStatement:
+			parsed, err := ottl.ParseStatement(statement)
+			if err != nil {
+				errorf(routePath+"::statement", "%v", err)
+			} else if parsed.Editor.Name != "route" {
+				errorf(routePath+"::statement", "statement must use the route() function, got %s()", parsed.Editor.Name)
+			}
+		default:
+			errorf(routePath, "either value or statement must be set")
+		}
+
+		exporters := stringList(route["exporters"])
+		if len(exporters) == 0 {
+			errorf(routePath+"::exporters", "at least one exporter must be set")
+		}
+		checkExporters(routePath+"::exporters", exporters)
+	}
+	return issues
+}
+
+// stringList converts a parsed YAML list to strings
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return nil
+}
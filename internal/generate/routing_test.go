@@ -0,0 +1,103 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestRouting(t *testing.T) {
+	result, err := Routing(RoutingRequest{
+		Signal: "logs",
+		Routes: []RoutingRoute{
+			{Attribute: "X-Tenant", Source: "request", Values: []string{"acme"}, Exporters: []string{"otlp/acme"}},
+			{Name: "prod", Attribute: "deployment.environment", Values: []string{"prod", "production"}, Exporters: []string{"otlp/prod"}},
+		},
+		DefaultExporters: []string{"debug"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+
+	connector := result.Config.Connectors["routing"].(map[string]interface{})
+	assert.Equal(t, []string{"logs/default"}, connector["default_pipelines"])
+	table := connector["table"].([]interface{})
+	require.Len(t, table, 2)
+	assert.Equal(t, map[string]interface{}{
+		"context":   "request",
+		"condition": `request["X-Tenant"] == "acme"`,
+		"pipelines": []string{"logs/acme"},
+	}, table[0])
+	assert.Equal(t, `attributes["deployment.environment"] == "prod" or attributes["deployment.environment"] == "production"`, table[1].(map[string]interface{})["condition"])
+
+	assert.Equal(t, []string{"routing"}, result.Config.Service.Pipelines["logs/in"].Exporters)
+	assert.Equal(t, []string{"otlp/prod"}, result.Config.Service.Pipelines["logs/prod"].Exporters)
+	configYAML, err := result.Config.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(configYAML), "include_metadata: true")
+}
+
+func TestRouting_Invalid(t *testing.T) {
+	_, err := Routing(RoutingRequest{Signal: "traces", Routes: []RoutingRoute{{Source: "log", Attribute: "a", Values: []string{"b"}, Exporters: []string{"debug"}}}})
+	require.Error(t, err)
+
+	_, err = Routing(RoutingRequest{Signal: "traces", Routes: []RoutingRoute{{Attribute: "a", Values: []string{"b"}}}})
+	require.Error(t, err)
+
+	_, err = Routing(RoutingRequest{Signal: "traces", Routes: []RoutingRoute{{Source: "request", Condition: `attributes["a"] == "b"`, Exporters: []string{"debug"}}}})
+	require.Error(t, err)
+}
+
+func TestValidateRouting(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+receivers:
+  otlp:
+exporters:
+  otlp/acme:
+  debug:
+connectors:
+  routing:
+    table:
+      - condition: attributes["tenant"] == "acme"
+        pipelines: [traces/acme]
+      - statement: set(attributes["a"], "b")
+        pipelines: [metrics/other]
+      - context: log
+        condition: attributes["tenant"] == "other" and
+        pipelines: [traces/missing]
+processors:
+  routing:
+    from_attribute: X-Tenant
+    table:
+      - value: acme
+        exporters: [otlp/unknown]
+service:
+  pipelines:
+    traces/in:
+      receivers: [otlp]
+      processors: [routing]
+      exporters: [routing]
+    traces/acme:
+      receivers: [routing]
+      exporters: [otlp/acme]
+    metrics/other:
+      receivers: [otlp]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	var messages []string
+	for _, issue := range ValidateRouting(config) {
+		messages = append(messages, issue.String())
+	}
+	assert.Contains(t, messages, "warning: connectors::routing: default_pipelines is not set, telemetry not matching any route is dropped")
+	assert.Contains(t, messages, "error: connectors::routing::table[1]::statement: statement must use the route() function, got set()")
+	assert.Contains(t, messages, `error: connectors::routing::table[1]::pipelines: routes to pipeline "metrics/other" which does not have routing as a receiver`)
+	assert.Contains(t, messages, `error: connectors::routing::table[1]::pipelines: routes to metrics pipeline "metrics/other" but receives traces`)
+	assert.Contains(t, messages, "error: connectors::routing::table[2]::context: context log cannot be used with traces")
+	assert.Contains(t, messages, `error: connectors::routing::table[2]::pipelines: routes to pipeline "traces/missing" which is not defined`)
+	assert.Contains(t, messages, `error: processors::routing::table[0]::exporters: routes to exporter "otlp/unknown" which is not defined`)
+	assert.Contains(t, messages, "warning: processors::routing: the routing processor is deprecated and removed in recent collector versions, use the routing connector instead")
+}
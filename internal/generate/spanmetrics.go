@@ -0,0 +1,204 @@
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const (
+	// maxSpanMetricsDimensions is the number of dimensions above which the series count is likely to explode
+	maxSpanMetricsDimensions = 8
+	// maxHistogramBuckets is the number of explicit buckets above which the series count per histogram is a concern
+	maxHistogramBuckets = 20
+)
+
+// spanMetricsDefaultDimensions are always added by the spanmetrics connector
+var spanMetricsDefaultDimensions = []string{"service.name", "span.name", "span.kind", "status.code"}
+
+// highCardinalityAttributes have a unique or unbounded value per request and must not be metric dimensions
+var highCardinalityAttributes = []string{
+	"http.url", "url.full", "http.target", "url.path", "url.query", "db.statement", "db.query.text",
+	"user.id", "enduser.id", "session.id", "request.id", "trace.id", "span.id", "messaging.message.id",
+	"client.address", "client.port", "net.peer.ip", "net.peer.port", "net.sock.peer.addr", "net.sock.peer.port",
+	"network.peer.address", "network.peer.port", "source.address", "source.port", "exception.message",
+	"exception.stacktrace", "k8s.pod.uid", "container.id", "process.pid",
+}
+
+// mediumCardinalityAttributes are bounded but grow with the deployment size
+var mediumCardinalityAttributes = []string{"k8s.pod.name", "host.name", "service.instance.id", "container.name"}
+
+// SpanMetricsRequest is the high-level spanmetrics connector intent
+type SpanMetricsRequest struct {
+	// Dimensions are span or resource attributes added as metric labels, name=default sets a default value
+	Dimensions []string `json:"dimensions,omitempty"`
+	// Histogram is explicit (default) or exponential
+	Histogram string `json:"histogram,omitempty"`
+	// Buckets are the explicit histogram bucket bounds e.g. 10ms, 100ms, 1s
+	Buckets []string `json:"buckets,omitempty"`
+	// ExponentialMaxSize is the max number of exponential histogram buckets
+	ExponentialMaxSize int    `json:"exponential_max_size,omitempty"`
+	Exemplars          bool   `json:"exemplars,omitempty"`
+	Namespace          string `json:"namespace,omitempty"`
+	// Export is prometheus (default), prometheusremotewrite or otlp
+	Export string `json:"export,omitempty"`
+	// Endpoint is the Prometheus scrape endpoint, the remote write URL or the OTLP backend
+	Endpoint string `json:"endpoint,omitempty"`
+	// TracesExporters receive the spans next to the connector e.g. otlp/tempo
+	TracesExporters []string `json:"traces_exporters,omitempty"`
+}
+
+// SpanMetricsResult is the generated spanmetrics configuration
+type SpanMetricsResult struct {
+	Config   *collectorconfig.Config
+	Warnings []string
+	Issues   []collectorconfig.Issue
+}
+
+// SpanMetrics generates the spanmetrics connector, the traces and metrics pipelines and the metrics exporter
+func SpanMetrics(request SpanMetricsRequest) (*SpanMetricsResult, error) {
+	var warnings []string
+	connector := make(map[string]interface{})
+
+	var dimensions []interface{}
+	for _, dimension := range request.Dimensions {
+		name, defaultValue, hasDefault := strings.Cut(strings.TrimSpace(dimension), "=")
+		if name == "" {
+			return nil, fmt.Errorf("dimension name must not be empty")
+		}
+		switch {
+		case slices.Contains(spanMetricsDefaultDimensions, name):
+			warnings = append(warnings, fmt.Sprintf("dimension %s is added by default, it is ignored", name))
+			continue
+		case slices.Contains(highCardinalityAttributes, name):
+			return nil, fmt.Errorf("dimension %s has a unique value per request, it creates a new series for every span, use a low cardinality attribute instead e.g. http.route instead of url.full", name)
+		case slices.Contains(mediumCardinalityAttributes, name):
+			warnings = append(warnings, fmt.Sprintf("dimension %s grows with the number of instances, the series count multiplies by the number of distinct values", name))
+		}
+		entry := map[string]interface{}{"name": name}
+		if hasDefault {
+			entry["default"] = defaultValue
+		}
+		dimensions = append(dimensions, entry)
+	}
+	if len(dimensions) > 0 {
+		connector["dimensions"] = dimensions
+	}
+	if len(dimensions)+len(spanMetricsDefaultDimensions) > maxSpanMetricsDimensions {
+		warnings = append(warnings, fmt.Sprintf("%d dimensions including the defaults, the series count is the product of the distinct values of all dimensions", len(dimensions)+len(spanMetricsDefaultDimensions)))
+	}
+
+	switch request.Histogram {
+	case "", "explicit":
+		if len(request.Buckets) > 0 {
+			var previous time.Duration
+			for _, bucket := range request.Buckets {
+				bound, err := time.ParseDuration(bucket)
+				if err != nil {
+					return nil, fmt.Errorf("invalid bucket %q: %w", bucket, err)
+				}
+				if bound <= previous {
+					return nil, fmt.Errorf("buckets must be positive and increasing, %s is not greater than %s", bound, previous)
+				}
+				previous = bound
+			}
+			connector["histogram"] = map[string]interface{}{
+				"explicit": map[string]interface{}{"buckets": request.Buckets},
+			}
+			if len(request.Buckets) > maxHistogramBuckets {
+				warnings = append(warnings, fmt.Sprintf("%d buckets create %d series per duration histogram, consider the exponential histogram", len(request.Buckets), len(request.Buckets)+1))
+			}
+		}
+	case "exponential":
+		if len(request.Buckets) > 0 {
+			return nil, fmt.Errorf("buckets can be set only for the explicit histogram")
+		}
+		exponential := make(map[string]interface{})
+		if request.ExponentialMaxSize > 0 {
+			exponential["max_size"] = request.ExponentialMaxSize
+		}
+		connector["histogram"] = map[string]interface{}{"exponential": exponential}
+	default:
+		return nil, fmt.Errorf("unsupported histogram %q, must be explicit or exponential", request.Histogram)
+	}
+
+	if request.Exemplars {
+		connector["exemplars"] = map[string]interface{}{"enabled": true}
+	}
+	if request.Namespace != "" {
+		connector["namespace"] = request.Namespace
+	}
+
+	exporterID, exporterConfig, exportWarnings, err := spanMetricsExporter(request)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, exportWarnings...)
+
+	config := collectorconfig.NewConfig()
+	config.Receivers["otlp"] = map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+			"http": map[string]interface{}{"endpoint": "0.0.0.0:4318"},
+		},
+	}
+	config.Connectors["spanmetrics"] = connector
+	config.Exporters[exporterID] = exporterConfig
+	tracesExporters := []string{"spanmetrics"}
+	for _, id := range request.TracesExporters {
+		// The backend configuration is not known, the endpoint has to be filled in
+		config.Exporters[id] = map[string]interface{}{"endpoint": "<" + id + " endpoint>"}
+		tracesExporters = append(tracesExporters, id)
+	}
+	if len(request.TracesExporters) == 0 {
+		warnings = append(warnings, "no traces_exporters are set, the spans are only used to compute the metrics")
+	}
+	config.Service.Pipelines["traces"] = &collectorconfig.Pipeline{
+		Receivers: []string{"otlp"},
+		Exporters: tracesExporters,
+	}
+	config.Service.Pipelines["metrics/spanmetrics"] = &collectorconfig.Pipeline{
+		Receivers: []string{"spanmetrics"},
+		Exporters: []string{exporterID},
+	}
+	warnings = append(warnings, "spanmetrics must receive the spans before sampling, connect it in a pipeline without tail_sampling or probabilistic_sampler otherwise the metrics undercount")
+
+	return &SpanMetricsResult{Config: config, Warnings: warnings, Issues: config.ValidateTopology()}, nil
+}
+
+// spanMetricsExporter returns the metrics exporter of the span metrics pipeline
+func spanMetricsExporter(request SpanMetricsRequest) (string, map[string]interface{}, []string, error) {
+	var warnings []string
+	switch request.Export {
+	case "", "prometheus":
+		endpoint := request.Endpoint
+		if endpoint == "" {
+			endpoint = "0.0.0.0:8889"
+		}
+		exporter := map[string]interface{}{"endpoint": endpoint}
+		if request.Exemplars {
+			// Prometheus exposes exemplars only in the OpenMetrics format
+			exporter["enable_open_metrics"] = true
+		}
+		return "prometheus", exporter, warnings, nil
+	case "prometheusremotewrite":
+		if request.Endpoint == "" {
+			return "", nil, nil, fmt.Errorf("endpoint must be set to the remote write URL e.g. http://prometheus:9090/api/v1/write")
+		}
+		if request.Exemplars {
+			warnings = append(warnings, "the Prometheus server has to be started with --enable-feature=exemplar-storage to store exemplars")
+		}
+		return "prometheusremotewrite", map[string]interface{}{"endpoint": request.Endpoint}, warnings, nil
+	case "otlp":
+		if request.Endpoint == "" {
+			return "", nil, nil, fmt.Errorf("endpoint must be set to the OTLP metrics backend")
+		}
+		warnings = append(warnings, "spanmetrics produces cumulative metrics, set aggregation_temporality to AGGREGATION_TEMPORALITY_DELTA for backends expecting delta temporality")
+		return "otlp/metrics", map[string]interface{}{"endpoint": request.Endpoint}, warnings, nil
+	default:
+		return "", nil, nil, fmt.Errorf("unsupported export %q, must be prometheus, prometheusremotewrite or otlp", request.Export)
+	}
+}
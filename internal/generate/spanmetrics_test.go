@@ -0,0 +1,64 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpanMetrics(t *testing.T) {
+	result, err := SpanMetrics(SpanMetricsRequest{
+		Dimensions:      []string{"http.route", "service.name", "k8s.pod.name", "deployment.environment=unknown"},
+		Buckets:         []string{"10ms", "100ms", "1s"},
+		Exemplars:       true,
+		TracesExporters: []string{"otlp/tempo"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+
+	connector := result.Config.Connectors["spanmetrics"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "http.route"},
+		map[string]interface{}{"name": "k8s.pod.name"},
+		map[string]interface{}{"name": "deployment.environment", "default": "unknown"},
+	}, connector["dimensions"])
+	assert.Equal(t, map[string]interface{}{"explicit": map[string]interface{}{"buckets": []string{"10ms", "100ms", "1s"}}}, connector["histogram"])
+	assert.Equal(t, map[string]interface{}{"enabled": true}, connector["exemplars"])
+	assert.Equal(t, true, result.Config.Exporters["prometheus"].(map[string]interface{})["enable_open_metrics"])
+
+	assert.Equal(t, []string{"spanmetrics", "otlp/tempo"}, result.Config.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"spanmetrics"}, result.Config.Service.Pipelines["metrics/spanmetrics"].Receivers)
+	assert.Equal(t, []string{"prometheus"}, result.Config.Service.Pipelines["metrics/spanmetrics"].Exporters)
+
+	assert.Contains(t, result.Warnings[0], "service.name is added by default")
+	assert.Contains(t, result.Warnings[1], "k8s.pod.name grows")
+}
+
+func TestSpanMetrics_Exponential(t *testing.T) {
+	result, err := SpanMetrics(SpanMetricsRequest{
+		Histogram:          "exponential",
+		ExponentialMaxSize: 160,
+		Export:             "otlp",
+		Endpoint:           "metrics:4317",
+	})
+	require.NoError(t, err)
+	connector := result.Config.Connectors["spanmetrics"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"exponential": map[string]interface{}{"max_size": 160}}, connector["histogram"])
+	assert.Contains(t, result.Config.Exporters, "otlp/metrics")
+}
+
+func TestSpanMetrics_Invalid(t *testing.T) {
+	for _, request := range []SpanMetricsRequest{
+		{Dimensions: []string{"url.full"}},
+		{Buckets: []string{"100ms", "10ms"}},
+		{Buckets: []string{"fast"}},
+		{Histogram: "exponential", Buckets: []string{"10ms"}},
+		{Histogram: "linear"},
+		{Export: "prometheusremotewrite"},
+		{Export: "influxdb"},
+	} {
+		_, err := SpanMetrics(request)
+		assert.Error(t, err, "%+v", request)
+	}
+}
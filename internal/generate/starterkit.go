@@ -0,0 +1,579 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// starterKitSignals are the signals in the order of the generated pipelines
+var starterKitSignals = []string{"traces", "metrics", "logs"}
+
+// signalWords maps the words of a description to the signal they mention
+var signalWords = map[string]string{
+	"trace": "traces", "traces": "traces", "tracing": "traces", "span": "traces", "spans": "traces",
+	"metric": "metrics", "metrics": "metrics",
+	"log": "logs", "logs": "logs", "logging": "logs",
+}
+
+// starterKitBackend is a backend recognized in a description with the exporter sending it the telemetry
+type starterKitBackend struct {
+	exporter string
+	// config returns the exporter configuration, a new map per configuration
+	config func() map[string]interface{}
+	// signals are the signals the backend stores when the description does not name them
+	signals []string
+	note    string
+}
+
+// starterKitBackends are the backends recognized in a description by name, the endpoints are the in-cluster service
+// names of their default installations
+var starterKitBackends = map[string]starterKitBackend{
+	"tempo": {
+		exporter: "otlp/tempo",
+		config: func() map[string]interface{} {
+			return map[string]interface{}{"endpoint": "tempo:4317", "tls": map[string]interface{}{"insecure": true}}
+		},
+		signals: []string{"traces"},
+	},
+	"jaeger": {
+		exporter: "otlp/jaeger",
+		config: func() map[string]interface{} {
+			return map[string]interface{}{"endpoint": "jaeger-collector:4317", "tls": map[string]interface{}{"insecure": true}}
+		},
+		signals: []string{"traces"},
+	},
+	"mimir": {
+		exporter: "prometheusremotewrite/mimir",
+		config: func() map[string]interface{} {
+			return map[string]interface{}{"endpoint": "http://mimir:9009/api/v1/push"}
+		},
+		signals: []string{"metrics"},
+	},
+	"prometheus": {
+		exporter: "prometheusremotewrite/prometheus",
+		config: func() map[string]interface{} {
+			return map[string]interface{}{"endpoint": "http://prometheus:9090/api/v1/write"}
+		},
+		signals: []string{"metrics"},
+		note:    "Prometheus accepts remote write with the --web.enable-remote-write-receiver flag",
+	},
+	"loki": {
+		exporter: "otlphttp/loki",
+		config: func() map[string]interface{} {
+			return map[string]interface{}{"endpoint": "http://loki:3100/otlp"}
+		},
+		signals: []string{"logs"},
+		note:    "Loki accepts OTLP from version 3.0, older versions need the loki exporter",
+	},
+	"elasticsearch": {
+		exporter: "elasticsearch",
+		config: func() map[string]interface{} {
+			return map[string]interface{}{"endpoints": []string{"http://elasticsearch:9200"}}
+		},
+		signals: []string{"traces", "metrics", "logs"},
+	},
+	"honeycomb": {
+		exporter: "otlp/honeycomb",
+		config: func() map[string]interface{} {
+			return map[string]interface{}{
+				"endpoint": "api.honeycomb.io:443",
+				"headers":  map[string]interface{}{"x-honeycomb-team": "${env:HONEYCOMB_API_KEY}"},
+			}
+		},
+		signals: []string{"traces", "metrics", "logs"},
+		note:    "set the HONEYCOMB_API_KEY environment variable of the collector",
+	},
+}
+
+// backendWords maps the words of a description to the backend they name
+var backendWords = map[string]string{
+	"tempo": "tempo", "jaeger": "jaeger", "mimir": "mimir", "prometheus": "prometheus", "loki": "loki",
+	"elasticsearch": "elasticsearch", "elastic": "elasticsearch", "honeycomb": "honeycomb",
+}
+
+// languageWords maps the words of a description to the SDK language they name, go is matched separately
+var languageWords = map[string]string{
+	"java": "java", "kotlin": "java", "spring": "java",
+	"python": "python", "django": "python", "flask": "python",
+	"golang": "go", "gin": "go",
+	"node": "js", "nodejs": "js", "node.js": "js", "javascript": "js", "typescript": "js",
+	".net": "dotnet", "dotnet": "dotnet", "c#": "dotnet", "csharp": "dotnet",
+	"ruby": "ruby", "rails": "ruby",
+	"php": "php",
+}
+
+// platformWords maps the words of a description to the platform and cloud they name
+var platformWords = map[string][2]string{
+	"eks": {"kubernetes", "aws"}, "gke": {"kubernetes", "gcp"}, "aks": {"kubernetes", "azure"},
+	"kubernetes": {"kubernetes", ""}, "k8s": {"kubernetes", ""}, "openshift": {"kubernetes", ""},
+	"docker": {"docker", ""}, "compose": {"docker", ""},
+	"ec2": {"host", "aws"}, "vm": {"host", ""}, "vms": {"host", ""}, "host": {"host", ""}, "hosts": {"host", ""}, "systemd": {"host", ""},
+}
+
+// resourceDetectors are the resourcedetection processor detectors by platform and cloud
+var resourceDetectors = map[string][]string{
+	"kubernetes/aws":   {"env", "eks", "ec2"},
+	"kubernetes/gcp":   {"env", "gcp"},
+	"kubernetes/azure": {"env", "aks", "azure"},
+	"kubernetes/":      {"env"},
+	"host/aws":         {"env", "ec2", "system"},
+	"host/":            {"env", "system"},
+	"docker/":          {"env", "docker"},
+}
+
+// StarterKitRequest is the free-text description of the environment a starter kit is generated for
+type StarterKitRequest struct {
+	// Description is the environment e.g. "EKS cluster, Java services, want traces to Tempo and metrics to Mimir"
+	Description string `json:"description"`
+	// Namespace is the Kubernetes namespace of the collector, defaults to observability
+	Namespace string `json:"namespace,omitempty"`
+	// Version is the collector version of the container image
+	Version string `json:"version"`
+	// SDKProtocols are the default OTLP protocols of the language SDKs e.g. java: grpc, http/protobuf if not set
+	SDKProtocols map[string]string `json:"sdk_protocols,omitempty"`
+}
+
+// Environment is the environment recognized in a description
+type Environment struct {
+	// Platform is kubernetes, docker or host
+	Platform  string         `json:"platform"`
+	Cloud     string         `json:"cloud,omitempty"`
+	Languages []string       `json:"languages,omitempty"`
+	Backends  []BackendRoute `json:"backends"`
+}
+
+// BackendRoute is a backend and the signals exported to it
+type BackendRoute struct {
+	Backend  string   `json:"backend"`
+	Exporter string   `json:"exporter"`
+	Signals  []string `json:"signals"`
+}
+
+// ComponentSuggestion is a component of the starter configuration and why it is used
+type ComponentSuggestion struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// SDKEnvVar is an environment variable of the instrumented services
+type SDKEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SDKSettings are the environment variables configuring the SDK of a language to export to the collector
+type SDKSettings struct {
+	Language string      `json:"language"`
+	Env      []SDKEnvVar `json:"env"`
+	Notes    []string    `json:"notes,omitempty"`
+}
+
+// StarterKitResult is the starter kit of an environment: the collector configuration, its deployment and the SDK
+// settings
+type StarterKitResult struct {
+	Environment Environment
+	Components  []ComponentSuggestion
+	Config      *collectorconfig.Config
+	// Files are the deployment files of the collector e.g. the Kubernetes manifests
+	Files    []GeneratedFile
+	SDKs     []SDKSettings
+	Warnings []string
+	Issues   []collectorconfig.Issue
+}
+
+// StarterKit recognizes the platform, languages and backends of a free-text description and generates the collector
+// configuration, its Kubernetes manifests and the SDK environment variables in one step
+func StarterKit(request StarterKitRequest) (*StarterKitResult, error) {
+	if strings.TrimSpace(request.Description) == "" {
+		return nil, fmt.Errorf("description must be set")
+	}
+	namespace := request.Namespace
+	if namespace == "" {
+		namespace = "observability"
+	}
+
+	environment, warnings := parseEnvironment(request.Description)
+	result := &StarterKitResult{Environment: environment, Warnings: warnings}
+	result.Config, result.Components = starterKitConfig(environment)
+	for _, route := range environment.Backends {
+		if note := starterKitBackends[route.Backend].note; note != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s", route.Backend, note))
+		}
+	}
+	result.Issues = result.Config.ValidateTopology()
+
+	host := "localhost"
+	switch environment.Platform {
+	case "kubernetes":
+		configYAML, err := result.Config.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		result.Files = []GeneratedFile{{Path: "otel-collector.yaml", Content: kubernetesManifests(string(configYAML), namespace, request.Version)}}
+		host = fmt.Sprintf("otel-collector.%s.svc.cluster.local", namespace)
+	case "docker":
+		host = "otel-collector"
+	}
+
+	languages := environment.Languages
+	if len(languages) == 0 {
+		languages = []string{"any"}
+	}
+	for _, language := range languages {
+		result.SDKs = append(result.SDKs, sdkSettings(language, request.SDKProtocols[language], host, environment))
+	}
+	return result, nil
+}
+
+// parseEnvironment recognizes the environment of a description, a backend gets the signals named before it, or
+// after it with "for" up to the end of the clause, or the signals it stores by default
+func parseEnvironment(description string) (Environment, []string) {
+	words := descriptionWords(description)
+	var environment Environment
+	var warnings []string
+	backendSignals := make(map[string][]string)
+	var backends, pending []string
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if platform, ok := platformWords[word]; ok {
+			if environment.Platform == "" || environment.Platform == "host" && platform[0] == "kubernetes" {
+				environment.Platform = platform[0]
+			}
+			if environment.Cloud == "" {
+				environment.Cloud = platform[1]
+			}
+		}
+		if language := descriptionLanguage(words, i); language != "" && !slices.Contains(environment.Languages, language) {
+			environment.Languages = append(environment.Languages, language)
+		}
+		if signal, ok := signalWords[word]; ok && !slices.Contains(pending, signal) {
+			pending = append(pending, signal)
+		}
+		backend, ok := backendWords[word]
+		if !ok {
+			continue
+		}
+		if !slices.Contains(backends, backend) {
+			backends = append(backends, backend)
+		}
+		signals := pending
+		pending = nil
+		if i+1 < len(words) && words[i+1] == "for" {
+			for i += 2; i < len(words); i++ {
+				if signal, ok := signalWords[words[i]]; ok {
+					signals = append(signals, signal)
+				} else if words[i] != "and" {
+					i--
+					break
+				}
+			}
+		}
+		if len(signals) == 0 {
+			signals = starterKitBackends[backend].signals
+		}
+		for _, signal := range signals {
+			if !slices.Contains(backendSignals[backend], signal) {
+				backendSignals[backend] = append(backendSignals[backend], signal)
+			}
+		}
+	}
+
+	if environment.Platform == "" {
+		environment.Platform = "host"
+		warnings = append(warnings, "no platform recognized e.g. EKS, Kubernetes or Docker, the collector runs on a host without deployment files")
+	}
+	if len(backends) == 0 {
+		if len(pending) == 0 {
+			pending = starterKitSignals
+		}
+		warnings = append(warnings, fmt.Sprintf("no backend recognized, %s are exported to the debug exporter, supported backends: %s", strings.Join(pending, ", "), strings.Join(slices.Sorted(maps.Keys(starterKitBackends)), ", ")))
+	} else if len(pending) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s named without a backend are exported to the debug exporter", strings.Join(pending, ", ")))
+	}
+	for _, backend := range backends {
+		environment.Backends = append(environment.Backends, BackendRoute{Backend: backend, Exporter: starterKitBackends[backend].exporter, Signals: backendSignals[backend]})
+	}
+	if len(pending) > 0 {
+		environment.Backends = append(environment.Backends, BackendRoute{Backend: "debug", Exporter: "debug", Signals: pending})
+	}
+	if len(environment.Languages) == 0 {
+		warnings = append(warnings, "no language recognized, the SDK settings apply to the SDKs of all languages")
+	}
+	return environment, warnings
+}
+
+// descriptionWords returns the lower case words of a description, dots and signs of names like .net or c# are kept and
+// the commas separating the clauses are words
+func descriptionWords(description string) []string {
+	description = strings.NewReplacer(",", " , ", ";", " , ").Replace(strings.ToLower(description))
+	words := strings.FieldsFunc(description, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '#' || r == '-' || r == ',')
+	})
+	for i, word := range words {
+		words[i] = strings.TrimRight(word, ".-")
+	}
+	return words
+}
+
+// descriptionLanguage returns the language named by the word at the index, go is only a language next to another
+// language or followed by e.g. services to not match the verb
+func descriptionLanguage(words []string, i int) string {
+	if language, ok := languageWords[words[i]]; ok {
+		return language
+	}
+	if words[i] != "go" {
+		return ""
+	}
+	isLanguage := func(j int) bool {
+		if j < 0 || j >= len(words) {
+			return false
+		}
+		_, ok := languageWords[words[j]]
+		return ok
+	}
+	if i+1 < len(words) && slices.Contains([]string{"service", "services", "app", "apps", "application", "applications", "microservices", "sdk"}, words[i+1]) {
+		return "go"
+	}
+	if isLanguage(i-1) || isLanguage(i+1) {
+		return "go"
+	}
+	if i > 0 && slices.Contains([]string{"and", "or"}, words[i-1]) && isLanguage(i-2) {
+		return "go"
+	}
+	if i+1 < len(words) && slices.Contains([]string{"and", "or"}, words[i+1]) && isLanguage(i+2) {
+		return "go"
+	}
+	return ""
+}
+
+// starterKitConfig returns the collector configuration of the environment and the components it uses
+func starterKitConfig(environment Environment) (*collectorconfig.Config, []ComponentSuggestion) {
+	config := collectorconfig.NewConfig()
+	components := []ComponentSuggestion{
+		{Kind: "receiver", Name: "otlp", Reason: "the SDKs export OTLP over gRPC (4317) or HTTP (4318)"},
+		{Kind: "processor", Name: "memory_limiter", Reason: "refuses data before the collector runs out of memory, it is the first processor"},
+	}
+	config.Receivers["otlp"] = map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+			"http": map[string]interface{}{"endpoint": "0.0.0.0:4318"},
+		},
+	}
+	config.Processors["memory_limiter"] = map[string]interface{}{"check_interval": "1s", "limit_percentage": 80, "spike_limit_percentage": 25}
+	processors := []string{"memory_limiter"}
+	if environment.Platform == "kubernetes" {
+		config.Processors["k8sattributes"] = map[string]interface{}{
+			"extract": map[string]interface{}{
+				"metadata": []string{"k8s.namespace.name", "k8s.deployment.name", "k8s.pod.name", "k8s.node.name"},
+			},
+		}
+		processors = append(processors, "k8sattributes")
+		components = append(components, ComponentSuggestion{Kind: "processor", Name: "k8sattributes", Reason: "adds the namespace, deployment and pod of the sending service"})
+	}
+	detectors := resourceDetectors[environment.Platform+"/"+environment.Cloud]
+	if detectors == nil {
+		detectors = resourceDetectors[environment.Platform+"/"]
+	}
+	config.Processors["resourcedetection"] = map[string]interface{}{"detectors": detectors, "timeout": "2s", "override": false}
+	config.Processors["batch"] = nil
+	processors = append(processors, "resourcedetection", "batch")
+	components = append(components,
+		ComponentSuggestion{Kind: "processor", Name: "resourcedetection", Reason: fmt.Sprintf("adds the %s attributes of the environment", strings.Join(detectors, ", "))},
+		ComponentSuggestion{Kind: "processor", Name: "batch", Reason: "batches the telemetry to reduce the export requests"},
+	)
+
+	for _, signal := range starterKitSignals {
+		var exporters []string
+		for _, route := range environment.Backends {
+			if slices.Contains(route.Signals, signal) {
+				exporters = append(exporters, route.Exporter)
+			}
+		}
+		if len(exporters) == 0 {
+			continue
+		}
+		config.Service.Pipelines[signal] = &collectorconfig.Pipeline{
+			Receivers:  []string{"otlp"},
+			Processors: processors,
+			Exporters:  exporters,
+		}
+	}
+	for _, route := range environment.Backends {
+		if route.Exporter == "debug" {
+			config.Exporters["debug"] = nil
+			components = append(components, ComponentSuggestion{Kind: "exporter", Name: "debug", Reason: fmt.Sprintf("logs the %s without a backend", strings.Join(route.Signals, ", "))})
+			continue
+		}
+		config.Exporters[route.Exporter] = starterKitBackends[route.Backend].config()
+		components = append(components, ComponentSuggestion{Kind: "exporter", Name: route.Exporter, Reason: fmt.Sprintf("sends the %s to %s", strings.Join(route.Signals, ", "), route.Backend)})
+	}
+
+	config.Extensions["health_check"] = map[string]interface{}{"endpoint": "0.0.0.0:13133"}
+	config.Service.Extensions = []string{"health_check"}
+	components = append(components, ComponentSuggestion{Kind: "extension", Name: "health_check", Reason: "serves the liveness and readiness probes on 13133"})
+	return config, components
+}
+
+// sdkSettings returns the environment variables exporting the signals of the environment from an SDK to the collector
+func sdkSettings(language, protocol, host string, environment Environment) SDKSettings {
+	var notes []string
+	if protocol == "" {
+		protocol = "http/protobuf"
+	}
+	port := 4318
+	if protocol == "grpc" {
+		port = 4317
+	}
+	settings := SDKSettings{
+		Language: language,
+		Env: []SDKEnvVar{
+			{Name: "OTEL_SERVICE_NAME", Value: "my-service"},
+			{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: fmt.Sprintf("http://%s:%d", host, port)},
+			{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: protocol},
+		},
+	}
+	for _, signal := range starterKitSignals {
+		exporter := "none"
+		for _, route := range environment.Backends {
+			if slices.Contains(route.Signals, signal) {
+				exporter = "otlp"
+			}
+		}
+		settings.Env = append(settings.Env, SDKEnvVar{Name: fmt.Sprintf("OTEL_%s_EXPORTER", strings.ToUpper(signal)), Value: exporter})
+	}
+
+	switch language {
+	case "java":
+		settings.Env = append(settings.Env, SDKEnvVar{Name: "JAVA_TOOL_OPTIONS", Value: "-javaagent:/otel/opentelemetry-javaagent.jar"})
+		notes = append(notes, "download opentelemetry-javaagent.jar from the opentelemetry-java-instrumentation releases into the image")
+	case "js":
+		settings.Env = append(settings.Env, SDKEnvVar{Name: "NODE_OPTIONS", Value: "--require @opentelemetry/auto-instrumentations-node/register"})
+		notes = append(notes, "install @opentelemetry/api and @opentelemetry/auto-instrumentations-node")
+	case "python":
+		notes = append(notes, "install opentelemetry-distro and opentelemetry-exporter-otlp, run opentelemetry-bootstrap -a install and start the service with opentelemetry-instrument")
+	case "go":
+		notes = append(notes, "the Go SDK reads the endpoint but the protocol is selected by the exporter package e.g. otlptracehttp, or by the autoexport package from the environment variables")
+	case "dotnet":
+		notes = append(notes, "install the OpenTelemetry.AutoInstrumentation package or add the OpenTelemetry.Exporter.OpenTelemetryProtocol exporter to the SDK setup")
+	}
+	if environment.Platform == "kubernetes" {
+		notes = append(notes, "the k8sattributes processor of the collector adds the namespace, deployment and pod, set OTEL_SERVICE_NAME per deployment")
+	}
+	settings.Notes = notes
+	return settings
+}
+
+// kubernetesManifests returns the manifests deploying the collector configuration as a Deployment with a Service and
+// the RBAC of the k8sattributes processor
+func kubernetesManifests(configYAML, namespace, version string) string {
+	// GOMEMLIMIT is 80% of the memory limit of the container
+	return fmt.Sprintf(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: otel-collector
+  namespace: %[1]s
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: otel-collector
+rules:
+  - apiGroups: [""]
+    resources: [pods, namespaces, nodes]
+    verbs: [get, list, watch]
+  - apiGroups: [apps]
+    resources: [replicasets]
+    verbs: [get, list, watch]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: otel-collector
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: otel-collector
+subjects:
+  - kind: ServiceAccount
+    name: otel-collector
+    namespace: %[1]s
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: otel-collector
+  namespace: %[1]s
+data:
+  config.yaml: |
+%[3]s
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: otel-collector
+  namespace: %[1]s
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: otel-collector
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: otel-collector
+    spec:
+      serviceAccountName: otel-collector
+      containers:
+        - name: otel-collector
+          image: otel/opentelemetry-collector-contrib:%[2]s
+          args: [--config=/conf/config.yaml]
+          env:
+            - name: GOMEMLIMIT
+              value: 819MiB
+          ports:
+            - {name: otlp-grpc, containerPort: 4317}
+            - {name: otlp-http, containerPort: 4318}
+            - {name: health, containerPort: 13133}
+          livenessProbe:
+            httpGet: {path: /, port: health}
+          readinessProbe:
+            httpGet: {path: /, port: health}
+          resources:
+            requests: {cpu: 200m, memory: 512Mi}
+            limits: {memory: 1Gi}
+          volumeMounts:
+            - {name: config, mountPath: /conf}
+      volumes:
+        - name: config
+          configMap:
+            name: otel-collector
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: otel-collector
+  namespace: %[1]s
+spec:
+  selector:
+    app.kubernetes.io/name: otel-collector
+  ports:
+    - {name: otlp-grpc, port: 4317, targetPort: otlp-grpc}
+    - {name: otlp-http, port: 4318, targetPort: otlp-http}
+`, namespace, version, indent(strings.TrimRight(configYAML, "\n"), "    "))
+}
+
+// indent prefixes the non-empty lines of a text
+func indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
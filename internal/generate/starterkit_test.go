@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStarterKit(t *testing.T) {
+	result, err := StarterKit(StarterKitRequest{
+		Description:  "EKS cluster, Java and Go services, want traces to Tempo and metrics to Mimir",
+		Version:      "0.139.0",
+		SDKProtocols: map[string]string{"java": "grpc"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+	assert.Empty(t, result.Warnings)
+	assert.Equal(t, Environment{
+		Platform:  "kubernetes",
+		Cloud:     "aws",
+		Languages: []string{"java", "go"},
+		Backends: []BackendRoute{
+			{Backend: "tempo", Exporter: "otlp/tempo", Signals: []string{"traces"}},
+			{Backend: "mimir", Exporter: "prometheusremotewrite/mimir", Signals: []string{"metrics"}},
+		},
+	}, result.Environment)
+
+	assert.Equal(t, []string{"metrics", "traces"}, slices.Sorted(maps.Keys(result.Config.Service.Pipelines)))
+	traces := result.Config.Service.Pipelines["traces"]
+	assert.Equal(t, []string{"memory_limiter", "k8sattributes", "resourcedetection", "batch"}, traces.Processors)
+	assert.Equal(t, []string{"otlp/tempo"}, traces.Exporters)
+	assert.Equal(t, []string{"env", "eks", "ec2"}, result.Config.Processors["resourcedetection"].(map[string]interface{})["detectors"])
+
+	require.Len(t, result.Files, 1)
+	assert.Contains(t, result.Files[0].Content, "image: otel/opentelemetry-collector-contrib:0.139.0")
+	assert.Contains(t, result.Files[0].Content, "  config.yaml: |\n    extensions:\n")
+	assert.Contains(t, result.Files[0].Content, "namespace: observability")
+
+	require.Len(t, result.SDKs, 2)
+	assert.Equal(t, "java", result.SDKs[0].Language)
+	assert.Equal(t, []SDKEnvVar{
+		{Name: "OTEL_SERVICE_NAME", Value: "my-service"},
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://otel-collector.observability.svc.cluster.local:4317"},
+		{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "grpc"},
+		{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+		{Name: "OTEL_METRICS_EXPORTER", Value: "otlp"},
+		{Name: "OTEL_LOGS_EXPORTER", Value: "none"},
+		{Name: "JAVA_TOOL_OPTIONS", Value: "-javaagent:/otel/opentelemetry-javaagent.jar"},
+	}, result.SDKs[0].Env)
+	assert.Contains(t, result.SDKs[1].Env, SDKEnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"})
+}
+
+func TestStarterKit_Descriptions(t *testing.T) {
+	tests := []struct {
+		description string
+		platform    string
+		languages   []string
+		backends    []BackendRoute
+		warnings    []string
+	}{
+		{
+			description: "Docker compose with Python, logs and traces to Honeycomb",
+			platform:    "docker",
+			languages:   []string{"python"},
+			backends:    []BackendRoute{{Backend: "honeycomb", Exporter: "otlp/honeycomb", Signals: []string{"logs", "traces"}}},
+			warnings:    []string{"honeycomb: set the HONEYCOMB_API_KEY environment variable of the collector"},
+		},
+		{
+			description: "GKE with node.js, Mimir for metrics and Loki for logs, traces to go to Jaeger",
+			platform:    "kubernetes",
+			languages:   []string{"js"},
+			backends: []BackendRoute{
+				{Backend: "mimir", Exporter: "prometheusremotewrite/mimir", Signals: []string{"metrics"}},
+				{Backend: "loki", Exporter: "otlphttp/loki", Signals: []string{"logs"}},
+				{Backend: "jaeger", Exporter: "otlp/jaeger", Signals: []string{"traces"}},
+			},
+			warnings: []string{"loki: Loki accepts OTLP from version 3.0, older versions need the loki exporter"},
+		},
+		{
+			description: "a few VMs sending metrics",
+			platform:    "host",
+			backends:    []BackendRoute{{Backend: "debug", Exporter: "debug", Signals: []string{"metrics"}}},
+			warnings: []string{
+				"no backend recognized, metrics are exported to the debug exporter, supported backends: elasticsearch, honeycomb, jaeger, loki, mimir, prometheus, tempo",
+				"no language recognized, the SDK settings apply to the SDKs of all languages",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result, err := StarterKit(StarterKitRequest{Description: test.description, Version: "0.139.0"})
+			require.NoError(t, err)
+			assert.Empty(t, result.Issues)
+			assert.Equal(t, test.platform, result.Environment.Platform)
+			assert.Equal(t, test.languages, result.Environment.Languages)
+			assert.Equal(t, test.backends, result.Environment.Backends)
+			assert.Equal(t, test.warnings, result.Warnings)
+			assert.Equal(t, test.platform == "kubernetes", len(result.Files) == 1)
+		})
+	}
+
+	_, err := StarterKit(StarterKitRequest{Description: " "})
+	assert.ErrorContains(t, err, "description must be set")
+}
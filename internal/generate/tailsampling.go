@@ -0,0 +1,163 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+const (
+	// defaultNumTraces is the tail_sampling processor num_traces default
+	defaultNumTraces = 50000
+	// estimatedSpanBytes is a rough in-memory size of a span used for memory estimates
+	estimatedSpanBytes = 1024
+)
+
+// TailSamplingConstraints are the high-level sampling constraints
+type TailSamplingConstraints struct {
+	KeepErrors         bool                `json:"keep_errors,omitempty"`
+	LatencyThresholdMs int                 `json:"latency_threshold_ms,omitempty"`
+	KeepServices       []string            `json:"keep_services,omitempty"`
+	KeepAttributes     map[string][]string `json:"keep_attributes,omitempty"`
+	DropServices       []string            `json:"drop_services,omitempty"`
+	SamplingPercentage float64             `json:"sampling_percentage,omitempty"`
+	DecisionWait       string              `json:"decision_wait,omitempty"`
+	// TracesPerSecond and SpansPerTrace are the expected load used to size num_traces
+	TracesPerSecond int `json:"traces_per_second,omitempty"`
+	SpansPerTrace   int `json:"spans_per_trace,omitempty"`
+}
+
+// TailSamplingResult is the generated tail_sampling processor configuration
+type TailSamplingResult struct {
+	Config   map[string]interface{}
+	Warnings []string
+}
+
+// TailSampling turns the sampling constraints into tail_sampling processor policies
+func TailSampling(constraints TailSamplingConstraints) (*TailSamplingResult, error) {
+	var warnings []string
+	var policies []interface{}
+
+	// A trace is sampled when any policy samples it, drop policies take precedence
+	for _, service := range constraints.DropServices {
+		policies = append(policies, map[string]interface{}{
+			"name": "drop-" + service,
+			"type": "drop",
+			"drop": map[string]interface{}{
+				"drop_sub_policy": []interface{}{
+					map[string]interface{}{
+						"name": "drop-" + service,
+						"type": "string_attribute",
+						"string_attribute": map[string]interface{}{
+							"key":    "service.name",
+							"values": []string{service},
+						},
+					},
+				},
+			},
+		})
+	}
+	if constraints.KeepErrors {
+		policies = append(policies, map[string]interface{}{
+			"name":        "keep-errors",
+			"type":        "status_code",
+			"status_code": map[string]interface{}{"status_codes": []string{"ERROR"}},
+		})
+	}
+	if constraints.LatencyThresholdMs < 0 {
+		return nil, fmt.Errorf("latency_threshold_ms must not be negative")
+	}
+	if constraints.LatencyThresholdMs > 0 {
+		policies = append(policies, map[string]interface{}{
+			"name":    "keep-slow",
+			"type":    "latency",
+			"latency": map[string]interface{}{"threshold_ms": constraints.LatencyThresholdMs},
+		})
+	}
+	if len(constraints.KeepServices) > 0 {
+		policies = append(policies, map[string]interface{}{
+			"name": "keep-services",
+			"type": "string_attribute",
+			"string_attribute": map[string]interface{}{
+				"key":    "service.name",
+				"values": constraints.KeepServices,
+			},
+		})
+	}
+	for _, key := range slices.Sorted(maps.Keys(constraints.KeepAttributes)) {
+		values := constraints.KeepAttributes[key]
+		if len(values) == 0 {
+			return nil, fmt.Errorf("keep_attributes %s must have at least one value", key)
+		}
+		policies = append(policies, map[string]interface{}{
+			"name": "keep-" + key,
+			"type": "string_attribute",
+			"string_attribute": map[string]interface{}{
+				"key":    key,
+				"values": values,
+			},
+		})
+	}
+
+	switch {
+	case constraints.SamplingPercentage < 0 || constraints.SamplingPercentage > 100:
+		return nil, fmt.Errorf("sampling_percentage must be between 0 and 100")
+	case constraints.SamplingPercentage == 100:
+		warnings = append(warnings, "sampling_percentage 100 keeps all traces, the other policies have no effect")
+		fallthrough
+	case constraints.SamplingPercentage > 0:
+		policies = append(policies, map[string]interface{}{
+			"name":          "sample-rest",
+			"type":          "probabilistic",
+			"probabilistic": map[string]interface{}{"sampling_percentage": constraints.SamplingPercentage},
+		})
+	}
+	if len(policies) == len(constraints.DropServices) {
+		return nil, fmt.Errorf("at least one policy keeping traces must be set, otherwise all traces are dropped")
+	}
+
+	decisionWait := 30 * time.Second
+	if constraints.DecisionWait != "" {
+		var err error
+		decisionWait, err = time.ParseDuration(constraints.DecisionWait)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decision_wait: %w", err)
+		}
+	}
+	if constraints.LatencyThresholdMs > 0 && time.Duration(constraints.LatencyThresholdMs)*time.Millisecond >= decisionWait {
+		warnings = append(warnings, fmt.Sprintf("latency_threshold_ms %d is not shorter than decision_wait %s, traces slower than decision_wait are decided before they complete", constraints.LatencyThresholdMs, decisionWait))
+	}
+
+	config := map[string]interface{}{
+		"decision_wait": decisionWait.String(),
+		"policies":      policies,
+	}
+
+	// Traces are kept in memory for decision_wait, num_traces has to hold all traces arriving in that window
+	numTraces := defaultNumTraces
+	if constraints.TracesPerSecond > 0 {
+		required := int(float64(constraints.TracesPerSecond) * decisionWait.Seconds() * 2)
+		if required > numTraces {
+			numTraces = required
+			warnings = append(warnings, fmt.Sprintf("num_traces is set to %d (2x traces_per_second * decision_wait), with the default %d traces are dropped from memory before a decision is made", numTraces, defaultNumTraces))
+		}
+		config["num_traces"] = numTraces
+		config["expected_new_traces_per_sec"] = constraints.TracesPerSecond
+
+		spansPerTrace := constraints.SpansPerTrace
+		if spansPerTrace <= 0 {
+			spansPerTrace = 20
+		}
+		memoryMiB := int64(constraints.TracesPerSecond) * int64(decisionWait.Seconds()) * int64(spansPerTrace) * estimatedSpanBytes / (1024 * 1024)
+		warnings = append(warnings, fmt.Sprintf("estimated memory for buffered spans is %d MiB per collector (%d spans per trace, ~1KiB per span), configure the memory_limiter processor before tail_sampling", memoryMiB, spansPerTrace))
+		if memoryMiB > 2048 {
+			warnings = append(warnings, "estimated memory exceeds 2 GiB, scale out with the loadbalancing exporter using routing_key traceID or shorten decision_wait")
+		}
+	} else {
+		warnings = append(warnings, "traces_per_second is not set, num_traces keeps the default 50000, size it to at least traces_per_second * decision_wait")
+	}
+	warnings = append(warnings, "all spans of a trace must reach the same collector, use the loadbalancing exporter with routing_key traceID when running more than one replica")
+
+	return &TailSamplingResult{Config: config, Warnings: warnings}, nil
+}
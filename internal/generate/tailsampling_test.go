@@ -0,0 +1,45 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailSampling(t *testing.T) {
+	result, err := TailSampling(TailSamplingConstraints{
+		KeepErrors:         true,
+		LatencyThresholdMs: 2000,
+		KeepServices:       []string{"checkout"},
+		SamplingPercentage: 10,
+		DecisionWait:       "10s",
+		TracesPerSecond:    10000,
+	})
+	require.NoError(t, err)
+
+	policies := result.Config["policies"].([]interface{})
+	require.Len(t, policies, 4)
+	var types []string
+	for _, policy := range policies {
+		types = append(types, policy.(map[string]interface{})["type"].(string))
+	}
+	assert.Equal(t, []string{"status_code", "latency", "string_attribute", "probabilistic"}, types)
+	assert.Equal(t, "10s", result.Config["decision_wait"])
+	assert.Equal(t, 200000, result.Config["num_traces"])
+	assert.Contains(t, result.Warnings[0], "num_traces is set to 200000")
+}
+
+func TestTailSampling_Invalid(t *testing.T) {
+	_, err := TailSampling(TailSamplingConstraints{})
+	require.Error(t, err)
+
+	_, err = TailSampling(TailSamplingConstraints{DropServices: []string{"health"}})
+	require.Error(t, err)
+
+	_, err = TailSampling(TailSamplingConstraints{SamplingPercentage: 120})
+	require.Error(t, err)
+
+	_, err = TailSampling(TailSamplingConstraints{KeepErrors: true, DecisionWait: "soon"})
+	require.Error(t, err)
+}
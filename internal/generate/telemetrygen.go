@@ -0,0 +1,220 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// otlpDefaultEndpoints are the OTLP receiver protocols and their default endpoints
+var otlpDefaultEndpoints = map[string]string{
+	"grpc": "localhost:4317",
+	"http": "localhost:4318",
+}
+
+// SmokeTestCommand is a CLI invocation sending synthetic telemetry to a receiver
+type SmokeTestCommand struct {
+	Receiver string `json:"receiver"`
+	Signal   string `json:"signal"`
+	Protocol string `json:"protocol"`
+	Endpoint string `json:"endpoint"`
+	Command  string `json:"command"`
+}
+
+// SmokeTestRequest configures the generated commands
+type SmokeTestRequest struct {
+	// Generator is telemetrygen (default) or otelgen
+	Generator string
+	// Count is the number of traces, metrics or logs sent, defaults to 10
+	Count int
+	// Host replaces wildcard listen addresses e.g. the collector service name, defaults to localhost
+	Host string
+}
+
+// SmokeTestResult contains the commands per receiver, protocol and signal
+type SmokeTestResult struct {
+	Commands []SmokeTestCommand
+	Warnings []string
+}
+
+// SmokeTest generates telemetrygen or otelgen invocations for the OTLP receivers of the configuration,
+// the TLS and authentication settings of the receivers are translated to client flags
+func SmokeTest(config *collectorconfig.Config, request SmokeTestRequest) (*SmokeTestResult, error) {
+	generator := request.Generator
+	if generator == "" {
+		generator = "telemetrygen"
+	}
+	if generator != "telemetrygen" && generator != "otelgen" {
+		return nil, fmt.Errorf("unsupported generator %q, must be telemetrygen or otelgen", generator)
+	}
+	count := request.Count
+	if count == 0 {
+		count = 10
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+	host := request.Host
+	if host == "" {
+		host = "localhost"
+	}
+
+	result := &SmokeTestResult{}
+	for _, id := range slices.Sorted(maps.Keys(config.Receivers)) {
+		var signals []string
+		for _, pipelineID := range slices.Sorted(maps.Keys(config.Service.Pipelines)) {
+			signal := collectorconfig.Signal(pipelineID)
+			if slices.Contains(config.Service.Pipelines[pipelineID].Receivers, id) && signal != "profiles" && !slices.Contains(signals, signal) {
+				signals = append(signals, signal)
+			}
+		}
+		if len(signals) == 0 {
+			continue
+		}
+		if collectorconfig.ComponentType(id) != "otlp" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("receiver %s is not an OTLP receiver, %s sends only OTLP, use the native client of the receiver", id, generator))
+			continue
+		}
+
+		receiverConfig, _ := config.Receivers[id].(map[string]interface{})
+		protocols, _ := receiverConfig["protocols"].(map[string]interface{})
+		if len(protocols) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("receiver %s has no protocols enabled", id))
+			continue
+		}
+		for _, protocol := range slices.Sorted(maps.Keys(protocols)) {
+			if _, supported := otlpDefaultEndpoints[protocol]; !supported {
+				continue
+			}
+			settings, _ := protocols[protocol].(map[string]interface{})
+			endpoint, warnings := clientEndpoint(id, protocol, settings, host)
+			result.Warnings = append(result.Warnings, warnings...)
+			flags, warnings := clientFlags(config, id, protocol, settings, generator)
+			result.Warnings = append(result.Warnings, warnings...)
+
+			for _, signal := range signals {
+				command := SmokeTestCommand{Receiver: id, Signal: signal, Protocol: protocol, Endpoint: endpoint}
+				if generator == "telemetrygen" {
+					command.Command = telemetrygenCommand(signal, protocol, endpoint, count, flags, settings)
+				} else {
+					command.Command = otelgenCommand(signal, endpoint, protocol, flags)
+				}
+				result.Commands = append(result.Commands, command)
+			}
+		}
+	}
+	if len(result.Commands) == 0 {
+		return nil, fmt.Errorf("no OTLP receiver used in a traces, metrics or logs pipeline found")
+	}
+	return result, nil
+}
+
+// clientEndpoint returns the address a client connects to for the receiver listen address
+func clientEndpoint(id, protocol string, settings map[string]interface{}, host string) (string, []string) {
+	endpoint, _ := settings["endpoint"].(string)
+	if endpoint == "" {
+		_, port, _ := net.SplitHostPort(otlpDefaultEndpoints[protocol])
+		return net.JoinHostPort(host, port), nil
+	}
+	if strings.Contains(endpoint, "${") {
+		return endpoint, []string{fmt.Sprintf("receiver %s %s endpoint %s uses environment variables, replace them in the commands", id, protocol, endpoint)}
+	}
+	listenHost, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint, []string{fmt.Sprintf("receiver %s %s endpoint %s is not a host:port address", id, protocol, endpoint)}
+	}
+	if listenHost == "" || listenHost == "0.0.0.0" || listenHost == "::" {
+		listenHost = host
+	}
+	return net.JoinHostPort(listenHost, port), nil
+}
+
+// clientFlags returns the TLS and authentication flags matching the receiver protocol settings
+func clientFlags(config *collectorconfig.Config, id, protocol string, settings map[string]interface{}, generator string) ([]string, []string) {
+	var flags, warnings []string
+	tls, hasTLS := settings["tls"].(map[string]interface{})
+	switch {
+	case !hasTLS && generator == "telemetrygen":
+		flags = append(flags, "--otlp-insecure")
+	case !hasTLS:
+		flags = append(flags, "--insecure")
+	case generator == "telemetrygen":
+		flags = append(flags, "--ca-cert <ca.pem>")
+		if _, mtls := tls["client_ca_file"]; mtls {
+			flags = append(flags, "--mtls", "--client-cert <client.pem>", "--client-key <client-key.pem>")
+		}
+	default:
+		warnings = append(warnings, fmt.Sprintf("receiver %s %s uses TLS, otelgen has to trust the server certificate e.g. via SSL_CERT_FILE", id, protocol))
+	}
+
+	auth, _ := settings["auth"].(map[string]interface{})
+	authenticator, _ := auth["authenticator"].(string)
+	if authenticator == "" {
+		return flags, warnings
+	}
+	var authorization string
+	switch collectorconfig.ComponentType(authenticator) {
+	case "basicauth":
+		authorization = "Basic <base64 user:password>"
+	case "bearertokenauth":
+		scheme := "Bearer"
+		if extension, ok := config.Extensions[authenticator].(map[string]interface{}); ok {
+			if s, ok := extension["scheme"].(string); ok {
+				scheme = s
+			}
+		}
+		authorization = scheme + " <token>"
+	case "oidc":
+		authorization = "Bearer <token from the identity provider>"
+	default:
+		warnings = append(warnings, fmt.Sprintf("receiver %s %s uses authenticator %s, add the credentials it expects as a header", id, protocol, authenticator))
+		return flags, warnings
+	}
+	// telemetrygen expects the header value as a quoted string
+	if generator == "telemetrygen" {
+		flags = append(flags, fmt.Sprintf(`--otlp-header 'Authorization="%s"'`, authorization))
+	} else {
+		flags = append(flags, fmt.Sprintf("--header 'Authorization=%s'", authorization))
+	}
+	return flags, warnings
+}
+
+// telemetrygenCommand returns the telemetrygen invocation for the signal
+func telemetrygenCommand(signal, protocol, endpoint string, count int, flags []string, settings map[string]interface{}) string {
+	args := []string{"telemetrygen", signal, "--otlp-endpoint", endpoint}
+	if protocol == "http" {
+		args = append(args, "--otlp-http")
+		if path, ok := settings[signal+"_url_path"].(string); ok {
+			args = append(args, "--otlp-http-url-path", path)
+		}
+	}
+	args = append(args, flags...)
+	switch signal {
+	case "traces":
+		args = append(args, "--traces", fmt.Sprint(count))
+	case "metrics":
+		args = append(args, "--metrics", fmt.Sprint(count))
+	case "logs":
+		args = append(args, "--logs", fmt.Sprint(count))
+	}
+	return strings.Join(args, " ")
+}
+
+// otelgenCommand returns the otelgen invocation for the signal
+func otelgenCommand(signal, endpoint, protocol string, flags []string) string {
+	args := []string{"otelgen", "--otel-exporter-otlp-endpoint", endpoint, "--protocol", protocol}
+	args = append(args, flags...)
+	switch signal {
+	case "traces":
+		args = append(args, "traces", "single")
+	case "metrics":
+		args = append(args, "metrics", "sum")
+	case "logs":
+		args = append(args, "logs", "single")
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+func TestSmokeTest(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+extensions:
+  bearertokenauth:
+    scheme: Token
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: server.pem
+          key_file: server-key.pem
+          client_ca_file: ca.pem
+      http:
+        endpoint: 0.0.0.0:14318
+        traces_url_path: /custom/traces
+        auth:
+          authenticator: bearertokenauth
+  zipkin:
+exporters:
+  debug:
+service:
+  extensions: [bearertokenauth]
+  pipelines:
+    traces:
+      receivers: [otlp, zipkin]
+      exporters: [debug]
+    logs:
+      receivers: [otlp]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	result, err := SmokeTest(config, SmokeTestRequest{Count: 3, Host: "collector"})
+	require.NoError(t, err)
+	require.Len(t, result.Commands, 4)
+
+	assert.Equal(t, "grpc", result.Commands[0].Protocol)
+	assert.Equal(t, "logs", result.Commands[0].Signal)
+	assert.Equal(t, "telemetrygen logs --otlp-endpoint collector:4317 --ca-cert <ca.pem> --mtls --client-cert <client.pem> --client-key <client-key.pem> --logs 3", result.Commands[0].Command)
+	assert.Equal(t, `telemetrygen traces --otlp-endpoint collector:14318 --otlp-http --otlp-http-url-path /custom/traces --otlp-insecure --otlp-header 'Authorization="Token <token>"' --traces 3`, result.Commands[3].Command)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "zipkin is not an OTLP receiver")
+
+	result, err = SmokeTest(config, SmokeTestRequest{Generator: "otelgen"})
+	require.NoError(t, err)
+	assert.Equal(t, "otelgen --otel-exporter-otlp-endpoint localhost:14318 --protocol http --insecure --header 'Authorization=Token <token>' traces single", result.Commands[3].Command)
+}
+
+func TestSmokeTest_Invalid(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+service:
+  pipelines: {}
+`))
+	require.NoError(t, err)
+	_, err = SmokeTest(config, SmokeTestRequest{})
+	assert.Error(t, err)
+	_, err = SmokeTest(config, SmokeTestRequest{Generator: "curl"})
+	assert.Error(t, err)
+}
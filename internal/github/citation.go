@@ -0,0 +1,41 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// coreModulePrefix and contribModulePrefix are the Go module prefixes of the collector repositories
+const (
+	coreModulePrefix    = "go.opentelemetry.io/collector/"
+	contribModulePrefix = "github.com/open-telemetry/opentelemetry-collector-contrib/"
+)
+
+// Citation are the canonical upstream sources of the documentation of a component version
+type Citation struct {
+	// Module is the Go module of the component e.g. go.opentelemetry.io/collector/receiver/otlpreceiver
+	Module string `json:"module"`
+	// SourceURL is the README on GitHub pinned to the release tag
+	SourceURL string `json:"sourceUrl"`
+	// RegistryURL is the OpenTelemetry registry search for the component
+	RegistryURL string `json:"registryUrl"`
+}
+
+// ComponentCitation returns the upstream sources of a component README of a collector version e.g. 0.139.0
+func ComponentCitation(componentType, componentName, version string) Citation {
+	repository, path := ComponentPath(componentType, componentName)
+	module := contribModulePrefix + path
+	if repository == coreRepository {
+		module = coreModulePrefix + path
+	}
+	registryQuery := url.Values{}
+	registryQuery.Set("s", componentName)
+	registryQuery.Set("component", componentType)
+	registryQuery.Set("language", "collector")
+	return Citation{
+		Module:      module,
+		SourceURL:   fmt.Sprintf("https://github.com/%s/blob/v%s/%s/README.md", repository, strings.TrimPrefix(version, "v"), path),
+		RegistryURL: "https://opentelemetry.io/ecosystem/registry/?" + registryQuery.Encode(),
+	}
+}
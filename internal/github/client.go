@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+)
+
+const (
+	coreRepository    = "open-telemetry/opentelemetry-collector"
+	contribRepository = "open-telemetry/opentelemetry-collector-contrib"
+	defaultBranch     = "main"
+)
+
+// coreComponents are the components living in the opentelemetry-collector repository
+var coreComponents = map[string][]string{
+	"receiver":  {"otlp", "nop"},
+	"processor": {"batch", "memory_limiter"},
+	"exporter":  {"otlp", "otlphttp", "debug", "nop"},
+	"extension": {"zpages", "memory_limiter"},
+	"connector": {"forward"},
+}
+
+// Issue is an open GitHub issue of a component
+type Issue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Client fetches component READMEs and issues from the collector GitHub repositories.
+// Responses are cached and requests are rate limited to stay within the GitHub API limits.
+type Client struct {
+	apiURL      string
+	rawURL      string
+	token       string
+	client      *http.Client
+	cache       *cache.Cache
+	cacheTTL    time.Duration
+	minInterval time.Duration
+
+	mutex       sync.Mutex
+	lastRequest time.Time
+	now         func() time.Time
+}
+
+// NewClient creates a GitHub client sending the requests with the HTTP client, the token is optional and raises the API
+// rate limit. The responses are kept in the shared cache for the cache TTL.
+func NewClient(token string, responseCache *cache.Cache, cacheTTL time.Duration, requestsPerMinute int, httpClient *http.Client) *Client {
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = 10
+	}
+	return &Client{
+		apiURL:      "https://api.github.com",
+		rawURL:      "https://raw.githubusercontent.com",
+		token:       token,
+		client:      httpClient,
+		cache:       responseCache,
+		cacheTTL:    cacheTTL,
+		minInterval: time.Minute / time.Duration(requestsPerMinute),
+		now:         time.Now,
+	}
+}
+
+// ComponentPath returns the repository and directory of a component e.g. receiver/redisreceiver
+func ComponentPath(componentType, componentName string) (string, string) {
+	for _, name := range coreComponents[componentType] {
+		if name == componentName {
+			return coreRepository, componentType + "/" + strings.ReplaceAll(componentName, "_", "") + componentType
+		}
+	}
+
+	compact := strings.ReplaceAll(componentName, "_", "")
+	if componentType == "extension" {
+		// Observers, storages and encodings are grouped in subdirectories
+		for _, group := range []string{"observer", "storage", "encoding"} {
+			if strings.HasSuffix(componentName, "_"+group) {
+				if group == "encoding" {
+					return contribRepository, "extension/encoding/" + compact + "extension"
+				}
+				return contribRepository, "extension/" + group + "/" + compact
+			}
+		}
+	}
+	return contribRepository, componentType + "/" + compact + componentType
+}
+
+// GetReadme returns the README of the component from the main branch
+func (c *Client) GetReadme(ctx context.Context, componentType, componentName string) (string, error) {
+	repository, path := ComponentPath(componentType, componentName)
+	readmeURL := fmt.Sprintf("%s/%s/%s/%s/README.md", c.rawURL, repository, defaultBranch, path)
+
+	body, err := c.cachedGet(ctx, readmeURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch README for %s %s: %w", componentType, componentName, err)
+	}
+	return string(body), nil
+}
+
+type searchIssuesResponse struct {
+	Items []struct {
+		Number    int       `json:"number"`
+		Title     string    `json:"title"`
+		HTMLURL   string    `json:"html_url"`
+		UpdatedAt time.Time `json:"updated_at"`
+	} `json:"items"`
+}
+
+// GetOpenIssues returns the most recently updated open issues labeled with the component e.g. receiver/redis
+func (c *Client) GetOpenIssues(ctx context.Context, componentType, componentName string, limit int) ([]Issue, error) {
+	repository, _ := ComponentPath(componentType, componentName)
+	query := fmt.Sprintf("repo:%s is:issue is:open label:\"%s/%s\"", repository, componentType, componentName)
+	searchURL := fmt.Sprintf("%s/search/issues?q=%s&sort=updated&order=desc&per_page=%d", c.apiURL, url.QueryEscape(query), limit)
+
+	body, err := c.cachedGet(ctx, searchURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues for %s %s: %w", componentType, componentName, err)
+	}
+	var response searchIssuesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch issues for %s %s: failed to parse issues response: %w", componentType, componentName, err)
+	}
+	issues := make([]Issue, 0, len(response.Items))
+	for _, item := range response.Items {
+		issues = append(issues, Issue{Number: item.Number, Title: item.Title, URL: item.HTMLURL, UpdatedAt: item.UpdatedAt})
+	}
+	return issues, nil
+}
+
+// cachedGet returns the cached response body of the URL or gets and caches it, cached responses are not rate limited
+func (c *Client) cachedGet(ctx context.Context, requestURL string) ([]byte, error) {
+	if body, found := c.cache.Get(cache.NamespaceGitHub, requestURL); found {
+		return body, nil
+	}
+	body, err := c.get(ctx, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	// A response that cannot be cached is still returned
+	_, _ = c.cache.Put(cache.NamespaceGitHub, requestURL, body, c.cacheTTL)
+	return body, nil
+}
+
+// get performs a rate limited GET request
+func (c *Client) get(ctx context.Context, requestURL string) ([]byte, error) {
+	c.mutex.Lock()
+	if wait := c.lastRequest.Add(c.minInterval).Sub(c.now()); wait > 0 {
+		c.mutex.Unlock()
+		return nil, fmt.Errorf("rate limit exceeded, retry in %s", wait.Round(time.Second))
+	}
+	c.lastRequest = c.now()
+	c.mutex.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if strings.HasPrefix(requestURL, c.apiURL) {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("not found: %s", requestURL)
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests:
+		return nil, fmt.Errorf("GitHub rate limit exceeded (status %d), configure a GitHub token or retry later", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GitHub returned status %d", resp.StatusCode)
+	}
+	return body, nil
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+)
+
+func TestComponentPath(t *testing.T) {
+	tests := []struct {
+		kind, name, repository, path string
+	}{
+		{"receiver", "otlp", coreRepository, "receiver/otlpreceiver"},
+		{"processor", "memory_limiter", coreRepository, "processor/memorylimiterprocessor"},
+		{"processor", "tail_sampling", contribRepository, "processor/tailsamplingprocessor"},
+		{"extension", "k8s_observer", contribRepository, "extension/observer/k8sobserver"},
+		{"extension", "file_storage", contribRepository, "extension/storage/filestorage"},
+		{"extension", "otlp_encoding", contribRepository, "extension/encoding/otlpencodingextension"},
+	}
+	for _, tt := range tests {
+		repository, path := ComponentPath(tt.kind, tt.name)
+		assert.Equal(t, tt.repository, repository)
+		assert.Equal(t, tt.path, path)
+	}
+}
+
+func TestComponentCitation(t *testing.T) {
+	citation := ComponentCitation("receiver", "otlp", "0.139.0")
+	assert.Equal(t, "go.opentelemetry.io/collector/receiver/otlpreceiver", citation.Module)
+	assert.Equal(t, "https://github.com/open-telemetry/opentelemetry-collector/blob/v0.139.0/receiver/otlpreceiver/README.md", citation.SourceURL)
+	assert.Equal(t, "https://opentelemetry.io/ecosystem/registry/?component=receiver&language=collector&s=otlp", citation.RegistryURL)
+
+	citation = ComponentCitation("extension", "file_storage", "v0.138.0")
+	assert.Equal(t, "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage", citation.Module)
+	assert.Equal(t, "https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/v0.138.0/extension/storage/filestorage/README.md", citation.SourceURL)
+}
+
+func TestClient(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/" + contribRepository + "/main/receiver/redisreceiver/README.md":
+			_, _ = w.Write([]byte("# Redis Receiver"))
+		case "/search/issues":
+			assert.Equal(t, `repo:open-telemetry/opentelemetry-collector-contrib is:issue is:open label:"receiver/redis"`, r.URL.Query().Get("q"))
+			_, _ = w.Write([]byte(`{"items": [{"number": 1, "title": "Crash", "html_url": "https://github.com/x/1", "updated_at": "2025-01-01T00:00:00Z"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	responseCache, err := cache.New("", 0)
+	require.NoError(t, err)
+	client := NewClient("", responseCache, time.Hour, 60, &http.Client{Timeout: time.Second})
+	client.apiURL = server.URL
+	client.rawURL = server.URL
+	client.now = func() time.Time { return now }
+
+	readme, err := client.GetReadme(context.Background(), "receiver", "redis")
+	require.NoError(t, err)
+	assert.Equal(t, "# Redis Receiver", readme)
+
+	// Cached responses are not rate limited
+	_, err = client.GetReadme(context.Background(), "receiver", "redis")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	_, err = client.GetOpenIssues(context.Background(), "receiver", "redis", 5)
+	require.ErrorContains(t, err, "rate limit exceeded")
+
+	now = now.Add(time.Second)
+	issues, err := client.GetOpenIssues(context.Background(), "receiver", "redis", 5)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "Crash", issues[0].Title)
+
+	now = now.Add(time.Second)
+	_, err = client.GetReadme(context.Background(), "receiver", "unknown")
+	require.ErrorContains(t, err, "not found")
+}
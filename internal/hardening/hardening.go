@@ -0,0 +1,340 @@
+// Package hardening applies security hardening profiles to collector configurations
+package hardening
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// Hardening profiles, each profile applies the changes of the previous one
+const (
+	ProfileDev     = "dev"
+	ProfileStaging = "staging"
+	ProfileProd    = "prod"
+)
+
+const (
+	// authenticatorID is the extension authenticating the exposed receivers
+	authenticatorID = "bearertokenauth/server"
+	// minTLSVersion is the minimum TLS version of the staging and prod profiles
+	minTLSVersion = "1.2"
+)
+
+// Profiles are the supported hardening profiles
+var Profiles = []string{ProfileDev, ProfileStaging, ProfileProd}
+
+// defaultProtocolEndpoints are the endpoints of the receiver protocols when the endpoint is not configured
+var defaultProtocolEndpoints = map[string]map[string]string{
+	"otlp":   {"grpc": "0.0.0.0:4317", "http": "0.0.0.0:4318"},
+	"jaeger": {"grpc": "0.0.0.0:14250", "thrift_http": "0.0.0.0:14268", "thrift_compact": "0.0.0.0:6831", "thrift_binary": "0.0.0.0:6832"},
+}
+
+// authProtocols are the receiver protocols supporting server authenticators
+var authProtocols = map[string][]string{
+	"otlp": {"grpc", "http"},
+}
+
+// listenerReceivers are receivers whose endpoint is a listen address and not a scrape target
+var listenerReceivers = []string{
+	"awsxray", "carbon", "datadog", "fluentforward", "influxdb", "loki", "opencensus", "sapm", "signalfx",
+	"splunk_hec", "statsd", "webhookevent", "zipkin",
+}
+
+// listenerExtensions are extensions serving an endpoint
+var listenerExtensions = []string{"health_check", "healthcheckv2", "pprof", "remotetap", "zpages"}
+
+// Options configures the hardening
+type Options struct {
+	Profile string
+	// Expose are the receivers kept listening on all interfaces e.g. otlp of a gateway, they require authentication
+	// in the staging and prod profiles
+	Expose []string
+}
+
+// Change is a change applied to the configuration
+type Change struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Result is the hardened configuration and the applied changes
+type Result struct {
+	Config   *collectorconfig.Config
+	Changes  []Change
+	Warnings []string
+}
+
+type hardener struct {
+	config  *collectorconfig.Config
+	options Options
+	result  *Result
+}
+
+// Harden applies a hardening profile to a copy of the configuration. The dev profile binds the listeners to
+// localhost and adds the memory_limiter processor, staging additionally authenticates the exposed receivers and
+// sets the minimum TLS version, prod additionally enables certificate verification and removes debug exporters.
+func Harden(config *collectorconfig.Config, options Options) (*Result, error) {
+	if !slices.Contains(Profiles, options.Profile) {
+		return nil, fmt.Errorf("unsupported profile %q, must be one of %v", options.Profile, Profiles)
+	}
+	for _, id := range options.Expose {
+		if _, ok := config.Receivers[id]; !ok {
+			return nil, fmt.Errorf("exposed receiver %s is not configured", id)
+		}
+	}
+	hardened, err := config.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &hardener{config: hardened, options: options, result: &Result{Config: hardened, Changes: []Change{}}}
+	h.bindListeners()
+	h.addMemoryLimiter()
+	if options.Profile == ProfileStaging || options.Profile == ProfileProd {
+		h.authenticateExposedReceivers()
+		h.setTLSMinimum()
+	}
+	if options.Profile == ProfileProd {
+		h.removeDebugExporters()
+		h.setLogLevel()
+	}
+	return h.result, nil
+}
+
+func (h *hardener) change(path, format string, args ...interface{}) {
+	h.result.Changes = append(h.result.Changes, Change{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// bindListeners binds the receivers that are not exposed and the extensions listening on all interfaces to localhost
+func (h *hardener) bindListeners() {
+	for _, id := range slices.Sorted(maps.Keys(h.config.Receivers)) {
+		if slices.Contains(h.options.Expose, id) {
+			continue
+		}
+		settings, _ := h.config.Receivers[id].(map[string]interface{})
+		componentType := collectorconfig.ComponentType(id)
+		if protocols, ok := settings["protocols"].(map[string]interface{}); ok {
+			for _, protocol := range slices.Sorted(maps.Keys(protocols)) {
+				protocolSettings, _ := protocols[protocol].(map[string]interface{})
+				if protocolSettings == nil {
+					protocolSettings = make(map[string]interface{})
+				}
+				endpoint, ok := protocolSettings["endpoint"].(string)
+				if !ok {
+					if endpoint = defaultProtocolEndpoints[componentType][protocol]; endpoint == "" {
+						continue
+					}
+				}
+				if local, changed := localEndpoint(endpoint); changed {
+					protocolSettings["endpoint"] = local
+					protocols[protocol] = protocolSettings
+					h.change(fmt.Sprintf("receivers::%s::protocols::%s::endpoint", id, protocol), "bound %s to %s, list the receiver in expose if remote clients send to it", endpoint, local)
+				}
+			}
+			continue
+		}
+		if slices.Contains(listenerReceivers, componentType) {
+			h.bindEndpoint("receivers::"+id, settings)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(h.config.Extensions)) {
+		if slices.Contains(listenerExtensions, collectorconfig.ComponentType(id)) {
+			settings, _ := h.config.Extensions[id].(map[string]interface{})
+			h.bindEndpoint("extensions::"+id, settings)
+		}
+	}
+}
+
+// bindEndpoint binds the endpoint of a component listening on all interfaces to localhost
+func (h *hardener) bindEndpoint(path string, settings map[string]interface{}) {
+	endpoint, ok := settings["endpoint"].(string)
+	if !ok {
+		return
+	}
+	if local, changed := localEndpoint(endpoint); changed {
+		settings["endpoint"] = local
+		h.change(path+"::endpoint", "bound %s to %s", endpoint, local)
+	}
+}
+
+// addMemoryLimiter adds the memory_limiter processor as the first processor of every pipeline
+func (h *hardener) addMemoryLimiter() {
+	memoryLimiterID := ""
+	for _, id := range slices.Sorted(maps.Keys(h.config.Processors)) {
+		if collectorconfig.ComponentType(id) == "memory_limiter" {
+			memoryLimiterID = id
+			break
+		}
+	}
+	if memoryLimiterID == "" {
+		memoryLimiterID = "memory_limiter"
+		h.config.Processors[memoryLimiterID] = map[string]interface{}{
+			"check_interval":         "1s",
+			"limit_percentage":       80,
+			"spike_limit_percentage": 25,
+		}
+		h.change("processors::memory_limiter", "added memory_limiter limiting the collector to 80%% of the available memory")
+	}
+
+	for _, pipelineID := range slices.Sorted(maps.Keys(h.config.Service.Pipelines)) {
+		pipeline := h.config.Service.Pipelines[pipelineID]
+		if len(pipeline.Processors) > 0 && collectorconfig.ComponentType(pipeline.Processors[0]) == "memory_limiter" {
+			continue
+		}
+		processors := []string{memoryLimiterID}
+		moved := false
+		for _, processor := range pipeline.Processors {
+			if processor == memoryLimiterID {
+				moved = true
+				continue
+			}
+			processors = append(processors, processor)
+		}
+		pipeline.Processors = processors
+		if moved {
+			h.change("service::pipelines::"+pipelineID+"::processors", "moved %s to the first processor to refuse data before other processors allocate memory", memoryLimiterID)
+		} else {
+			h.change("service::pipelines::"+pipelineID+"::processors", "added %s as the first processor", memoryLimiterID)
+		}
+	}
+}
+
+// authenticateExposedReceivers adds a bearer token authenticator to the exposed receivers
+func (h *hardener) authenticateExposedReceivers() {
+	authenticated := false
+	for _, id := range h.options.Expose {
+		settings, _ := h.config.Receivers[id].(map[string]interface{})
+		protocols, _ := settings["protocols"].(map[string]interface{})
+		supported := authProtocols[collectorconfig.ComponentType(id)]
+		if len(supported) == 0 || len(protocols) == 0 {
+			h.result.Warnings = append(h.result.Warnings, fmt.Sprintf("receiver %s is exposed without authentication, restrict the clients with network policies", id))
+			continue
+		}
+		for _, protocol := range slices.Sorted(maps.Keys(protocols)) {
+			if !slices.Contains(supported, protocol) {
+				h.result.Warnings = append(h.result.Warnings, fmt.Sprintf("protocol %s of receiver %s is exposed without authentication, restrict the clients with network policies", protocol, id))
+				continue
+			}
+			protocolSettings, _ := protocols[protocol].(map[string]interface{})
+			if protocolSettings == nil {
+				protocolSettings = make(map[string]interface{})
+				protocols[protocol] = protocolSettings
+			}
+			if _, ok := protocolSettings["auth"]; ok {
+				continue
+			}
+			protocolSettings["auth"] = map[string]interface{}{"authenticator": authenticatorID}
+			authenticated = true
+			h.change(fmt.Sprintf("receivers::%s::protocols::%s::auth", id, protocol), "clients of the exposed receiver must send the bearer token of %s", authenticatorID)
+		}
+	}
+	if !authenticated {
+		return
+	}
+	if _, ok := h.config.Extensions[authenticatorID]; !ok {
+		h.config.Extensions[authenticatorID] = map[string]interface{}{"token": "${env:COLLECTOR_AUTH_TOKEN}"}
+		h.change("extensions::"+authenticatorID, "added the bearer token authenticator reading the token from the COLLECTOR_AUTH_TOKEN environment variable")
+	}
+	if !slices.Contains(h.config.Service.Extensions, authenticatorID) {
+		h.config.Service.Extensions = append(h.config.Service.Extensions, authenticatorID)
+		h.change("service::extensions", "enabled %s", authenticatorID)
+	}
+}
+
+// setTLSMinimum sets the minimum TLS version of the configured TLS settings, prod enables certificate verification
+func (h *hardener) setTLSMinimum() {
+	for _, section := range []struct {
+		name       string
+		components map[string]interface{}
+	}{
+		{"receivers", h.config.Receivers},
+		{"exporters", h.config.Exporters},
+		{"extensions", h.config.Extensions},
+	} {
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			if settings, ok := section.components[id].(map[string]interface{}); ok {
+				h.hardenTLS(section.name+"::"+id, settings)
+			}
+		}
+	}
+}
+
+// hardenTLS hardens the tls settings of a component and its nested settings
+func (h *hardener) hardenTLS(path string, settings map[string]interface{}) {
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		nested, ok := settings[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if key != "tls" {
+			h.hardenTLS(path+"::"+key, nested)
+			continue
+		}
+		if insecure, _ := nested["insecure"].(bool); insecure {
+			h.result.Warnings = append(h.result.Warnings, fmt.Sprintf("%s::tls::insecure is true, the connection is not encrypted", path))
+			continue
+		}
+		if minVersion, _ := nested["min_version"].(string); minVersion == "" || minVersion < minTLSVersion {
+			nested["min_version"] = minTLSVersion
+			h.change(path+"::tls::min_version", "set the minimum TLS version to %s", minTLSVersion)
+		}
+		if skipVerify, _ := nested["insecure_skip_verify"].(bool); skipVerify && h.options.Profile == ProfileProd {
+			nested["insecure_skip_verify"] = false
+			h.change(path+"::tls::insecure_skip_verify", "enabled server certificate verification, configure ca_file if the certificate is not signed by a public CA")
+		}
+	}
+}
+
+// removeDebugExporters removes the debug and logging exporters from the pipelines with another exporter
+func (h *hardener) removeDebugExporters() {
+	for _, id := range slices.Sorted(maps.Keys(h.config.Exporters)) {
+		componentType := collectorconfig.ComponentType(id)
+		if componentType != "debug" && componentType != "logging" {
+			continue
+		}
+		used := false
+		for _, pipelineID := range slices.Sorted(maps.Keys(h.config.Service.Pipelines)) {
+			pipeline := h.config.Service.Pipelines[pipelineID]
+			if !slices.Contains(pipeline.Exporters, id) {
+				continue
+			}
+			if len(pipeline.Exporters) == 1 {
+				used = true
+				h.result.Warnings = append(h.result.Warnings, fmt.Sprintf("exporter %s is the only exporter of pipeline %s and was kept, configure a backend exporter", id, pipelineID))
+				continue
+			}
+			pipeline.Exporters = slices.DeleteFunc(pipeline.Exporters, func(v string) bool { return v == id })
+			h.change("service::pipelines::"+pipelineID+"::exporters", "removed %s, it writes telemetry including sensitive attributes to the collector logs", id)
+		}
+		if !used {
+			delete(h.config.Exporters, id)
+			h.change("exporters::"+id, "removed the unused %s exporter", id)
+		}
+	}
+}
+
+// setLogLevel lowers the debug log level of the collector telemetry
+func (h *hardener) setLogLevel() {
+	logs, _ := h.config.Service.Telemetry["logs"].(map[string]interface{})
+	if level, _ := logs["level"].(string); level == "debug" {
+		logs["level"] = "info"
+		h.change("service::telemetry::logs::level", "lowered the log level from debug to info")
+	}
+}
+
+// localEndpoint returns the endpoint bound to localhost if it listens on all interfaces
+func localEndpoint(endpoint string) (string, bool) {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint, false
+	}
+	switch host {
+	case "", "0.0.0.0", "::":
+		return net.JoinHostPort("localhost", port), true
+	}
+	return endpoint, false
+}
@@ -0,0 +1,149 @@
+package hardening
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const testConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+  zipkin:
+    endpoint: 0.0.0.0:9411
+  prometheus:
+    config: {}
+processors:
+  batch:
+  memory_limiter/custom:
+    limit_mib: 512
+exporters:
+  otlp:
+    endpoint: backend:4317
+    tls:
+      insecure_skip_verify: true
+  otlp/internal:
+    endpoint: internal:4317
+    tls:
+      insecure: true
+  debug:
+extensions:
+  health_check:
+    endpoint: 0.0.0.0:13133
+service:
+  extensions: [health_check]
+  telemetry:
+    logs:
+      level: debug
+  pipelines:
+    traces:
+      receivers: [otlp, zipkin]
+      processors: [batch, memory_limiter/custom]
+      exporters: [otlp, debug]
+    logs:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`
+
+func paths(changes []Change) []string {
+	result := make([]string, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, change.Path)
+	}
+	return result
+}
+
+func TestHarden_Dev(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(testConfig))
+	require.NoError(t, err)
+
+	result, err := Harden(config, Options{Profile: ProfileDev})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"receivers::otlp::protocols::grpc::endpoint",
+		"receivers::otlp::protocols::http::endpoint",
+		"receivers::zipkin::endpoint",
+		"extensions::health_check::endpoint",
+		"service::pipelines::logs::processors",
+		"service::pipelines::traces::processors",
+	}, paths(result.Changes))
+
+	otlp := result.Config.Receivers["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})
+	assert.Equal(t, "localhost:4317", otlp["grpc"].(map[string]interface{})["endpoint"])
+	assert.Equal(t, "localhost:4318", otlp["http"].(map[string]interface{})["endpoint"])
+	assert.Equal(t, []string{"memory_limiter/custom", "batch"}, result.Config.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, []string{"memory_limiter/custom", "batch"}, result.Config.Service.Pipelines["logs"].Processors)
+	assert.Contains(t, result.Config.Exporters, "debug")
+
+	// The original configuration is not modified
+	assert.Equal(t, "0.0.0.0:9411", config.Receivers["zipkin"].(map[string]interface{})["endpoint"])
+}
+
+func TestHarden_Prod(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(testConfig))
+	require.NoError(t, err)
+
+	result, err := Harden(config, Options{Profile: ProfileProd, Expose: []string{"otlp", "zipkin"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"extensions::health_check::endpoint",
+		"service::pipelines::logs::processors",
+		"service::pipelines::traces::processors",
+		"receivers::otlp::protocols::grpc::auth",
+		"receivers::otlp::protocols::http::auth",
+		"extensions::bearertokenauth/server",
+		"service::extensions",
+		"exporters::otlp::tls::min_version",
+		"exporters::otlp::tls::insecure_skip_verify",
+		"service::pipelines::traces::exporters",
+		"service::telemetry::logs::level",
+	}, paths(result.Changes))
+	assert.Equal(t, []string{
+		"receiver zipkin is exposed without authentication, restrict the clients with network policies",
+		"exporters::otlp/internal::tls::insecure is true, the connection is not encrypted",
+		"exporter debug is the only exporter of pipeline logs and was kept, configure a backend exporter",
+	}, result.Warnings)
+
+	assert.Equal(t, "0.0.0.0:9411", result.Config.Receivers["zipkin"].(map[string]interface{})["endpoint"])
+	assert.Equal(t, []string{"health_check", "bearertokenauth/server"}, result.Config.Service.Extensions)
+	assert.Equal(t, []string{"otlp"}, result.Config.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, map[string]interface{}{"insecure_skip_verify": false, "min_version": "1.2"}, result.Config.Exporters["otlp"].(map[string]interface{})["tls"])
+	assert.False(t, collectorconfig.HasErrors(result.Config.ValidateTopology()))
+}
+
+func TestHarden_AddsMemoryLimiter(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+exporters:
+  otlp:
+    endpoint: backend:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`))
+	require.NoError(t, err)
+
+	result, err := Harden(config, Options{Profile: ProfileStaging})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"processors::memory_limiter", "service::pipelines::traces::processors"}, paths(result.Changes))
+	assert.Equal(t, []string{"memory_limiter"}, result.Config.Service.Pipelines["traces"].Processors)
+
+	_, err = Harden(config, Options{Profile: "qa"})
+	assert.Error(t, err)
+	_, err = Harden(config, Options{Profile: ProfileProd, Expose: []string{"zipkin"}})
+	assert.Error(t, err)
+}
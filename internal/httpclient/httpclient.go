@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrOffline is returned for every request of the network features when the server runs with --offline
+var ErrOffline = errors.New("network access is disabled, the server runs with --offline")
+
+// DefaultRetries is the number of retries of a failed GET request
+const DefaultRetries = 2
+
+// Settings are the HTTP settings shared by the network features: the GitHub tool, the advisory and registry refresh,
+// the live collector configuration and the README translation. Proxies are read from the HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY environment variables.
+type Settings struct {
+	// Offline rejects every request with ErrOffline
+	Offline bool
+	// Timeout of a request including its retries, 0 keeps the timeout of the feature
+	Timeout time.Duration
+	// Retries of a GET request failing with a network error or a 502, 503 or 504 status
+	Retries int
+	// Backoff is the wait before the first retry, it doubles with every retry
+	Backoff time.Duration
+}
+
+// DefaultSettings are the settings of a server started without the HTTP flags
+var DefaultSettings = Settings{Retries: DefaultRetries, Backoff: 500 * time.Millisecond}
+
+// Client returns the HTTP client of a feature, the timeout of the settings overrides the timeout of the feature
+func (s Settings) Client(timeout time.Duration) *http.Client {
+	if s.Timeout > 0 {
+		timeout = s.Timeout
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = http.ProxyFromEnvironment
+	return &http.Client{Timeout: timeout, Transport: &transport{settings: s, base: base}}
+}
+
+// transport rejects the requests in offline mode and retries the idempotent requests failing with a transient error
+type transport struct {
+	settings Settings
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.settings.Offline {
+		return nil, ErrOffline
+	}
+	retries := t.settings.Retries
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		retries = 0
+	}
+
+	backoff := t.settings.Backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= retries || !retryable(req.Context(), resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, fmt.Errorf("%w after %d attempts", req.Context().Err(), attempt+1)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// retryable returns true for network errors and the statuses of an overloaded or restarting server
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1)) - 1
+		w.WriteHeader(statuses[min(call, len(statuses)-1)])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_Offline(t *testing.T) {
+	server, calls := newServer(t, http.StatusOK)
+	client := Settings{Offline: true}.Client(time.Second)
+
+	_, err := client.Get(server.URL)
+	require.ErrorIs(t, err, ErrOffline)
+	assert.Zero(t, calls.Load())
+}
+
+func TestClient_Retries(t *testing.T) {
+	server, calls := newServer(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+	client := Settings{Retries: 2, Backoff: time.Millisecond}.Client(time.Second)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	server, calls := newServer(t, http.StatusServiceUnavailable)
+	client := Settings{Retries: 1, Backoff: time.Millisecond}.Client(time.Second)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_NoRetries(t *testing.T) {
+	t.Run("post", func(t *testing.T) {
+		server, calls := newServer(t, http.StatusServiceUnavailable, http.StatusOK)
+		client := Settings{Retries: 2, Backoff: time.Millisecond}.Client(time.Second)
+
+		resp, err := client.Post(server.URL, "application/json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("client error", func(t *testing.T) {
+		server, calls := newServer(t, http.StatusNotFound, http.StatusOK)
+		client := Settings{Retries: 2, Backoff: time.Millisecond}.Client(time.Second)
+
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestClient_Timeout(t *testing.T) {
+	assert.Equal(t, 30*time.Second, DefaultSettings.Client(30*time.Second).Timeout)
+	assert.Equal(t, 5*time.Second, Settings{Timeout: 5 * time.Second}.Client(30*time.Second).Timeout)
+}
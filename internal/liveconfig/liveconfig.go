@@ -0,0 +1,234 @@
+// Package liveconfig retrieves the effective configuration of running collectors, so the tools validate what is
+// actually deployed instead of the configuration in the repository
+package liveconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const (
+	// SourceConfig is a configuration YAML or JSON e.g. the effective.yaml of the OpAMP supervisor served over http
+	SourceConfig = "config"
+	// SourceOpAMP is the JSON encoded EffectiveConfig message an OpAMP server received from the opamp extension
+	SourceOpAMP = "opamp"
+)
+
+// maxConfigSize is the maximum size of a fetched configuration in bytes
+const maxConfigSize = 4 * 1024 * 1024
+
+// redactedValue replaces the sensitive values in the effective configuration reported by the opamp extension
+const redactedValue = "[REDACTED]"
+
+// Fetcher retrieves effective configurations from the endpoints the server was allowed to call
+type Fetcher struct {
+	endpoints []string
+	client    *http.Client
+}
+
+// Result is a fetched effective configuration
+type Result struct {
+	Endpoint string `json:"endpoint"`
+	Source   string `json:"source"`
+	// Config is the normalized configuration YAML
+	Config string `json:"config"`
+	// Files are the names of the configuration files of an OpAMP effective config, merged in name order
+	Files []string `json:"files,omitempty"`
+	// Redacted are the paths of the values redacted by the collector
+	Redacted []string `json:"redacted,omitempty"`
+}
+
+// NewFetcher returns a fetcher calling only the endpoints with the HTTP client
+func NewFetcher(endpoints []string, client *http.Client) *Fetcher {
+	return &Fetcher{endpoints: endpoints, client: client}
+}
+
+// Endpoints returns the endpoints the fetcher is allowed to call
+func (f *Fetcher) Endpoints() []string {
+	return f.endpoints
+}
+
+// Fetch retrieves and normalizes the effective configuration of an endpoint, an empty endpoint is the first one
+func (f *Fetcher) Fetch(ctx context.Context, endpoint string) (*Result, error) {
+	if len(f.endpoints) == 0 {
+		return nil, fmt.Errorf("no effective configuration endpoint is configured")
+	}
+	if endpoint == "" {
+		endpoint = f.endpoints[0]
+	}
+	allowed := false
+	for _, e := range f.endpoints {
+		allowed = allowed || e == endpoint
+	}
+	if !allowed {
+		return nil, fmt.Errorf("endpoint %s is not allowed, it can be %s", endpoint, strings.Join(f.endpoints, ", "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create effective configuration request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the effective configuration: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the effective configuration: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxConfigSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the effective configuration: %w", err)
+	}
+	if len(data) > maxConfigSize {
+		return nil, fmt.Errorf("the effective configuration is larger than %d bytes", maxConfigSize)
+	}
+
+	result, err := Normalize(data)
+	if err != nil {
+		return nil, err
+	}
+	result.Endpoint = endpoint
+	return result, nil
+}
+
+// Normalize returns the normalized configuration of a configuration YAML or JSON or of an OpAMP effective config
+func Normalize(data []byte) (*Result, error) {
+	result := &Result{Source: SourceConfig}
+	var config map[string]interface{}
+
+	files, err := opampConfigFiles(data)
+	if err != nil {
+		return nil, err
+	}
+	if files != nil {
+		result.Source = SourceOpAMP
+		config = map[string]interface{}{}
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			var file map[string]interface{}
+			if err := yaml.Unmarshal(files[name], &file); err != nil {
+				return nil, fmt.Errorf("failed to parse the effective configuration file %q: %w", name, err)
+			}
+			config = merge(config, file)
+			result.Files = append(result.Files, name)
+		}
+	} else if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the effective configuration: %w", err)
+	}
+
+	// The configuration is marshalled in the order of the collector configuration sections
+	normalized, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the effective configuration: %w", err)
+	}
+	parsed, err := collectorconfig.Parse(normalized)
+	if err != nil {
+		return nil, err
+	}
+	configYAML, err := parsed.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	result.Config = string(configYAML)
+	result.Redacted = redactedPaths("", config)
+	return result, nil
+}
+
+// opampEffectiveConfig is the protobuf JSON encoding of the OpAMP EffectiveConfig message, the field names are
+// camelCase or the original snake_case names
+type opampEffectiveConfig struct {
+	ConfigMap      *opampConfigMap `json:"configMap"`
+	ConfigMapSnake *opampConfigMap `json:"config_map"`
+}
+
+type opampConfigMap struct {
+	ConfigMap      map[string]opampConfigFile `json:"configMap"`
+	ConfigMapSnake map[string]opampConfigFile `json:"config_map"`
+}
+
+type opampConfigFile struct {
+	// Body is base64 encoded as bytes fields of protobuf JSON
+	Body []byte `json:"body"`
+}
+
+// opampConfigFiles returns the configuration files of an OpAMP effective config by name, nil if the data is not one
+func opampConfigFiles(data []byte) (map[string][]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, nil
+	}
+	var effective opampEffectiveConfig
+	if err := json.Unmarshal(trimmed, &effective); err != nil {
+		// Not an OpAMP message but possibly a configuration in JSON
+		return nil, nil
+	}
+	configMap := effective.ConfigMap
+	if configMap == nil {
+		configMap = effective.ConfigMapSnake
+	}
+	if configMap == nil {
+		return nil, nil
+	}
+	entries := configMap.ConfigMap
+	if entries == nil {
+		entries = configMap.ConfigMapSnake
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the OpAMP effective configuration has no configuration files")
+	}
+	files := make(map[string][]byte, len(entries))
+	for name, file := range entries {
+		files[name] = file.Body
+	}
+	return files, nil
+}
+
+// merge merges the maps of the override into the base like the collector merges configuration files, other values
+// of the override replace the base values
+func merge(base, override map[string]interface{}) map[string]interface{} {
+	for key, value := range override {
+		baseMap, baseIsMap := base[key].(map[string]interface{})
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			base[key] = merge(baseMap, overrideMap)
+			continue
+		}
+		base[key] = value
+	}
+	return base
+}
+
+// redactedPaths returns the paths of the redacted values e.g. exporters::otlp::headers::api-key
+func redactedPaths(path string, value interface{}) []string {
+	var paths []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			childPath := key
+			if path != "" {
+				childPath = path + "::" + key
+			}
+			paths = append(paths, redactedPaths(childPath, v[key])...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			paths = append(paths, redactedPaths(fmt.Sprintf("%s::%d", path, i), item)...)
+		}
+	case string:
+		if v == redactedValue {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,75 @@
+package liveconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const effectiveConfig = `service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    headers:
+      api-key: "[REDACTED]"
+`
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/effective.yaml":
+			_, _ = w.Write([]byte(effectiveConfig))
+		case "/opamp":
+			// Two configuration files, the second one overrides the exporter endpoint
+			override := "exporters:\n  otlp:\n    endpoint: other:4317\n"
+			_, _ = fmt.Fprintf(w, `{"configMap": {"configMap": {"a.yaml": {"body": %q, "contentType": "text/yaml"}, "b.yaml": {"body": %q}}}}`,
+				base64.StdEncoding.EncodeToString([]byte(effectiveConfig)), base64.StdEncoding.EncodeToString([]byte(override)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher([]string{server.URL + "/effective.yaml", server.URL + "/opamp", server.URL + "/missing"}, &http.Client{Timeout: time.Second})
+	result, err := fetcher.Fetch(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, SourceConfig, result.Source)
+	assert.Equal(t, server.URL+"/effective.yaml", result.Endpoint)
+	assert.Equal(t, []string{"exporters::otlp::headers::api-key"}, result.Redacted)
+	// The sections are normalized to the collector configuration order
+	assert.Regexp(t, `(?s)^receivers:.*exporters:.*service:`, result.Config)
+
+	result, err = fetcher.Fetch(context.Background(), server.URL+"/opamp")
+	require.NoError(t, err)
+	assert.Equal(t, SourceOpAMP, result.Source)
+	assert.Equal(t, []string{"a.yaml", "b.yaml"}, result.Files)
+	assert.Contains(t, result.Config, "endpoint: other:4317")
+	assert.Contains(t, result.Config, "api-key: '[REDACTED]'")
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing")
+	assert.EqualError(t, err, "failed to fetch the effective configuration: status 404")
+	_, err = fetcher.Fetch(context.Background(), "http://169.254.169.254/")
+	assert.ErrorContains(t, err, "endpoint http://169.254.169.254/ is not allowed")
+}
+
+func TestNormalize_JSON(t *testing.T) {
+	result, err := Normalize([]byte(`{"receivers": {"otlp": {}}, "service": {"pipelines": {"logs": {"receivers": ["otlp"]}}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, SourceConfig, result.Source)
+	assert.Equal(t, "receivers:\n  otlp: {}\nservice:\n  pipelines:\n    logs:\n      receivers:\n        - otlp\n", result.Config)
+}
@@ -0,0 +1,257 @@
+// Package memorysettings recommends the memory runtime settings of a collector for its container memory limit: the
+// GOMEMLIMIT environment variable, the memory_limiter percentages and the removal of the deprecated memory ballast
+package memorysettings
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const (
+	// goMemLimitPercentage is the share of the container memory given to the Go heap, the rest is the headroom for the
+	// memory outside of the heap e.g. the goroutine stacks and the runtime
+	goMemLimitPercentage = 80
+	// limitPercentage and spikeLimitPercentage are the memory_limiter settings matching GOMEMLIMIT, the limiter refuses
+	// data at 55% of the container memory before the garbage collector runs continuously to stay under GOMEMLIMIT
+	limitPercentage      = 80
+	spikeLimitPercentage = 25
+	// maxGoMemLimitPercentage is the share of the container memory above which GOMEMLIMIT leaves no headroom
+	maxGoMemLimitPercentage = 90
+)
+
+// Options describe where the collector runs
+type Options struct {
+	// MemoryMiB is the memory limit of the collector container, GOMEMLIMIT is not computed without it
+	MemoryMiB float64
+	// GOMEMLIMIT is the current value of the environment variable e.g. 1600MiB, empty if it is not set
+	GOMEMLIMIT string
+	// Version is the collector version the settings are recommended for
+	Version string
+	// BallastExtension is true if the version still has the deprecated memory_ballast extension
+	BallastExtension bool
+	// BallastSizeSetting is true if the memory_limiter of the version still has the deprecated ballast_size_mib
+	BallastSizeSetting bool
+}
+
+// EnvVar is an environment variable to set on the collector container
+type EnvVar struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+// Change is a change applied to the configuration
+type Change struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Result is the configuration with the recommended memory settings, the environment variables to set and the applied
+// changes
+type Result struct {
+	Config   *collectorconfig.Config
+	Env      []EnvVar
+	Changes  []Change
+	Warnings []string
+}
+
+type recommender struct {
+	config  *collectorconfig.Config
+	options Options
+	result  *Result
+}
+
+// Recommend applies the recommended memory settings to a copy of the configuration. The memory_limiter limits the
+// collector to a percentage of the container memory and runs first in every pipeline, the memory ballast is removed
+// and GOMEMLIMIT is set to 80% of the container memory instead, the Go garbage collector then works harder close to
+// the limit instead of the ballast inflating the heap the memory_limiter measures.
+func Recommend(config *collectorconfig.Config, options Options) (*Result, error) {
+	var currentLimit float64
+	if options.GOMEMLIMIT != "" {
+		limit, err := ParseGOMEMLIMIT(options.GOMEMLIMIT)
+		if err != nil {
+			return nil, err
+		}
+		currentLimit = limit
+	}
+	recommended, err := config.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &recommender{config: recommended, options: options, result: &Result{Config: recommended, Env: []EnvVar{}, Changes: []Change{}}}
+	r.removeBallastExtensions()
+	r.setMemoryLimiter()
+	r.setGOMEMLIMIT(currentLimit)
+	return r.result, nil
+}
+
+func (r *recommender) change(path, format string, args ...interface{}) {
+	r.result.Changes = append(r.result.Changes, Change{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// removeBallastExtensions removes the memory_ballast extensions, GOMEMLIMIT replaces the ballast
+func (r *recommender) removeBallastExtensions() {
+	for _, id := range slices.Sorted(maps.Keys(r.config.Extensions)) {
+		if collectorconfig.ComponentType(id) != "memory_ballast" {
+			continue
+		}
+		delete(r.config.Extensions, id)
+		if r.options.BallastExtension {
+			r.change("extensions::"+id, "removed the deprecated %s extension, GOMEMLIMIT replaces the ballast", id)
+		} else {
+			r.change("extensions::"+id, "removed %s, version %s has no memory_ballast extension and the collector fails to start with an unknown type, GOMEMLIMIT replaces the ballast", id, r.options.Version)
+		}
+		if slices.Contains(r.config.Service.Extensions, id) {
+			r.config.Service.Extensions = slices.DeleteFunc(r.config.Service.Extensions, func(v string) bool { return v == id })
+			r.change("service::extensions", "disabled the removed %s", id)
+		}
+	}
+}
+
+// setMemoryLimiter adds the memory_limiter processor if it is missing, removes its ballast setting, sets its limits as
+// percentages of the container memory and runs it first in every pipeline
+func (r *recommender) setMemoryLimiter() {
+	var memoryLimiterIDs []string
+	for _, id := range slices.Sorted(maps.Keys(r.config.Processors)) {
+		if collectorconfig.ComponentType(id) == "memory_limiter" {
+			memoryLimiterIDs = append(memoryLimiterIDs, id)
+		}
+	}
+	if len(memoryLimiterIDs) == 0 {
+		r.config.Processors["memory_limiter"] = map[string]interface{}{
+			"check_interval":         "1s",
+			"limit_percentage":       limitPercentage,
+			"spike_limit_percentage": spikeLimitPercentage,
+		}
+		memoryLimiterIDs = []string{"memory_limiter"}
+		r.change("processors::memory_limiter", "added memory_limiter limiting the collector to %d%% of the container memory, it refuses data above %d%%", limitPercentage, limitPercentage-spikeLimitPercentage)
+	}
+
+	for _, id := range memoryLimiterIDs {
+		settings, _ := r.config.Processors[id].(map[string]interface{})
+		if settings == nil {
+			settings = make(map[string]interface{})
+			r.config.Processors[id] = settings
+		}
+		path := "processors::" + id
+		if _, ok := settings["ballast_size_mib"]; ok {
+			delete(settings, "ballast_size_mib")
+			if r.options.BallastSizeSetting {
+				r.change(path+"::ballast_size_mib", "removed the deprecated ballast_size_mib, the ballast is replaced by GOMEMLIMIT")
+			} else {
+				r.change(path+"::ballast_size_mib", "removed ballast_size_mib, the memory_limiter of version %s does not know it and the collector fails to start", r.options.Version)
+			}
+		}
+		r.setLimitPercentages(path, settings)
+	}
+
+	for _, pipelineID := range slices.Sorted(maps.Keys(r.config.Service.Pipelines)) {
+		pipeline := r.config.Service.Pipelines[pipelineID]
+		if len(pipeline.Processors) > 0 && collectorconfig.ComponentType(pipeline.Processors[0]) == "memory_limiter" {
+			continue
+		}
+		memoryLimiterID := memoryLimiterIDs[0]
+		for _, processor := range pipeline.Processors {
+			if collectorconfig.ComponentType(processor) == "memory_limiter" {
+				memoryLimiterID = processor
+				break
+			}
+		}
+		moved := slices.Contains(pipeline.Processors, memoryLimiterID)
+		pipeline.Processors = append([]string{memoryLimiterID}, slices.DeleteFunc(pipeline.Processors, func(v string) bool { return v == memoryLimiterID })...)
+		if moved {
+			r.change("service::pipelines::"+pipelineID+"::processors", "moved %s to the first processor to refuse data before other processors allocate memory", memoryLimiterID)
+		} else {
+			r.change("service::pipelines::"+pipelineID+"::processors", "added %s as the first processor", memoryLimiterID)
+		}
+	}
+}
+
+// setLimitPercentages replaces the limits in MiB with percentages following the container memory and lowers the
+// percentages leaving no headroom
+func (r *recommender) setLimitPercentages(path string, settings map[string]interface{}) {
+	limit, hasLimit := collectorconfig.Number(settings["limit_mib"])
+	if _, configured := settings["limit_mib"]; configured && !hasLimit {
+		// An environment variable reference is unknown
+		return
+	}
+	if hasLimit {
+		if r.options.MemoryMiB <= 0 {
+			r.result.Warnings = append(r.result.Warnings, fmt.Sprintf("%s::limit_mib %s is fixed, pass the container memory to replace it with limit_percentage following the container limit and GOMEMLIMIT", path, collectorconfig.FormatNumber(limit)))
+			return
+		}
+		delete(settings, "limit_mib")
+		delete(settings, "spike_limit_mib")
+		settings["limit_percentage"] = limitPercentage
+		settings["spike_limit_percentage"] = spikeLimitPercentage
+		r.change(path+"::limit_percentage", "replaced limit_mib %s (%s%% of the container memory of %s MiB) with limit_percentage %d and spike_limit_percentage %d, the percentages follow the container memory limit like GOMEMLIMIT",
+			collectorconfig.FormatNumber(limit), collectorconfig.FormatNumber(math.Round(limit/r.options.MemoryMiB*100)), collectorconfig.FormatNumber(r.options.MemoryMiB), limitPercentage, spikeLimitPercentage)
+		return
+	}
+
+	percentage, hasPercentage := collectorconfig.Number(settings["limit_percentage"])
+	if !hasPercentage {
+		settings["limit_percentage"] = limitPercentage
+		settings["spike_limit_percentage"] = spikeLimitPercentage
+		r.change(path+"::limit_percentage", "set limit_percentage %d and spike_limit_percentage %d, the memory_limiter requires a limit", limitPercentage, spikeLimitPercentage)
+		return
+	}
+	if percentage > limitPercentage {
+		settings["limit_percentage"] = limitPercentage
+		r.change(path+"::limit_percentage", "lowered limit_percentage from %s to %d, the memory outside of the Go heap gets the container OOM killed before the limiter refuses data", collectorconfig.FormatNumber(percentage), limitPercentage)
+		percentage = limitPercentage
+	}
+	if spike, ok := collectorconfig.Number(settings["spike_limit_percentage"]); ok && spike >= percentage {
+		settings["spike_limit_percentage"] = spikeLimitPercentage
+		r.change(path+"::spike_limit_percentage", "lowered spike_limit_percentage from %s to %d, it must be lower than limit_percentage", collectorconfig.FormatNumber(spike), spikeLimitPercentage)
+	}
+}
+
+// setGOMEMLIMIT recommends GOMEMLIMIT for the container memory, a current limit in MiB is checked against it
+func (r *recommender) setGOMEMLIMIT(currentLimit float64) {
+	if r.options.MemoryMiB <= 0 {
+		r.result.Warnings = append(r.result.Warnings, fmt.Sprintf("GOMEMLIMIT is not computed without the container memory, set it to %d%% of the container memory limit", goMemLimitPercentage))
+		return
+	}
+	recommended := math.Floor(r.options.MemoryMiB * goMemLimitPercentage / 100)
+	value := collectorconfig.FormatNumber(recommended) + "MiB"
+	switch {
+	case currentLimit == 0:
+		r.result.Env = append(r.result.Env, EnvVar{Name: "GOMEMLIMIT", Value: value, Message: fmt.Sprintf("%d%% of the container memory of %s MiB, the Go garbage collector works harder close to the limit instead of growing the heap until the container is OOM killed", goMemLimitPercentage, collectorconfig.FormatNumber(r.options.MemoryMiB))})
+	case currentLimit > r.options.MemoryMiB*maxGoMemLimitPercentage/100:
+		r.result.Env = append(r.result.Env, EnvVar{Name: "GOMEMLIMIT", Value: value, Message: fmt.Sprintf("the current %s is above %d%% of the container memory of %s MiB, the memory outside of the Go heap gets the container OOM killed before the garbage collector reacts", r.options.GOMEMLIMIT, maxGoMemLimitPercentage, collectorconfig.FormatNumber(r.options.MemoryMiB))})
+	case currentLimit < r.options.MemoryMiB*(limitPercentage-spikeLimitPercentage)/100:
+		r.result.Env = append(r.result.Env, EnvVar{Name: "GOMEMLIMIT", Value: value, Message: fmt.Sprintf("the current %s is below the %d%% of the container memory at which the memory_limiter refuses data, the garbage collector runs continuously to stay under it and the collector stalls", r.options.GOMEMLIMIT, limitPercentage-spikeLimitPercentage)})
+	}
+}
+
+// ParseGOMEMLIMIT returns the memory limit in MiB of a GOMEMLIMIT value in bytes with an optional B, KiB, MiB, GiB or
+// TiB unit, off is no limit
+func ParseGOMEMLIMIT(value string) (float64, error) {
+	if value == "off" {
+		return 0, nil
+	}
+	units := []struct {
+		suffix string
+		mib    float64
+	}{{"KiB", 1.0 / 1024}, {"MiB", 1}, {"GiB", 1024}, {"TiB", 1024 * 1024}, {"B", 1.0 / 1024 / 1024}}
+	number, scale := value, 1.0/1024/1024
+	for _, unit := range units {
+		if trimmed, ok := strings.CutSuffix(value, unit.suffix); ok {
+			number, scale = trimmed, unit.mib
+			break
+		}
+	}
+	parsed, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid GOMEMLIMIT %q, it is a number of bytes with an optional B, KiB, MiB, GiB or TiB unit e.g. 1600MiB", value)
+	}
+	return float64(parsed) * scale, nil
+}
@@ -0,0 +1,158 @@
+package memorysettings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const ballastConfig = `
+extensions:
+  memory_ballast:
+    size_in_percentage: 30
+  health_check:
+receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+  memory_limiter:
+    check_interval: 1s
+    limit_mib: 1800
+    spike_limit_mib: 500
+    ballast_size_mib: 600
+exporters:
+  debug:
+service:
+  extensions: [memory_ballast, health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch, memory_limiter]
+      exporters: [debug]
+    metrics:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+func TestRecommend(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(ballastConfig))
+	require.NoError(t, err)
+
+	result, err := Recommend(config, Options{MemoryMiB: 2048, Version: "0.139.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []EnvVar{{Name: "GOMEMLIMIT", Value: "1638MiB", Message: "80% of the container memory of 2048 MiB, the Go garbage collector works harder close to the limit instead of growing the heap until the container is OOM killed"}}, result.Env)
+
+	var paths []string
+	for _, change := range result.Changes {
+		paths = append(paths, change.Path)
+	}
+	assert.Equal(t, []string{
+		"extensions::memory_ballast",
+		"service::extensions",
+		"processors::memory_limiter::ballast_size_mib",
+		"processors::memory_limiter::limit_percentage",
+		"service::pipelines::metrics::processors",
+		"service::pipelines::traces::processors",
+	}, paths)
+	assert.Contains(t, result.Changes[0].Message, "version 0.139.0 has no memory_ballast extension")
+	assert.Equal(t, "replaced limit_mib 1800 (88% of the container memory of 2048 MiB) with limit_percentage 80 and spike_limit_percentage 25, the percentages follow the container memory limit like GOMEMLIMIT", result.Changes[3].Message)
+	assert.Empty(t, result.Warnings)
+
+	assert.NotContains(t, result.Config.Extensions, "memory_ballast")
+	assert.Equal(t, []string{"health_check"}, result.Config.Service.Extensions)
+	assert.Equal(t, map[string]interface{}{"check_interval": "1s", "limit_percentage": 80, "spike_limit_percentage": 25}, result.Config.Processors["memory_limiter"])
+	assert.Equal(t, []string{"memory_limiter", "batch"}, result.Config.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, []string{"memory_limiter"}, result.Config.Service.Pipelines["metrics"].Processors)
+
+	// The input configuration is not modified
+	assert.Contains(t, config.Extensions, "memory_ballast")
+
+	// A version with the ballast reports it as deprecated
+	result, err = Recommend(config, Options{MemoryMiB: 2048, Version: "0.96.0", BallastExtension: true, BallastSizeSetting: true})
+	require.NoError(t, err)
+	assert.Equal(t, "removed the deprecated memory_ballast extension, GOMEMLIMIT replaces the ballast", result.Changes[0].Message)
+	assert.Equal(t, "removed the deprecated ballast_size_mib, the ballast is replaced by GOMEMLIMIT", result.Changes[2].Message)
+}
+
+func TestRecommend_WithoutMemoryLimiter(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  memory_limiter/strict:
+    limit_percentage: 95
+    spike_limit_percentage: 95
+exporters:
+  debug:
+service:
+  pipelines:
+    logs:
+      receivers: [otlp]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	result, err := Recommend(config, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Env)
+	assert.Equal(t, []string{"GOMEMLIMIT is not computed without the container memory, set it to 80% of the container memory limit"}, result.Warnings)
+	assert.Equal(t, []Change{
+		{Path: "processors::memory_limiter/strict::limit_percentage", Message: "lowered limit_percentage from 95 to 80, the memory outside of the Go heap gets the container OOM killed before the limiter refuses data"},
+		{Path: "processors::memory_limiter/strict::spike_limit_percentage", Message: "lowered spike_limit_percentage from 95 to 25, it must be lower than limit_percentage"},
+		{Path: "service::pipelines::logs::processors", Message: "added memory_limiter/strict as the first processor"},
+	}, result.Changes)
+
+	config.Processors = map[string]interface{}{}
+	result, err = Recommend(config, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "processors::memory_limiter", result.Changes[0].Path)
+	assert.Equal(t, []string{"memory_limiter"}, result.Config.Service.Pipelines["logs"].Processors)
+}
+
+func TestRecommend_CurrentGOMEMLIMIT(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(ballastConfig))
+	require.NoError(t, err)
+
+	tests := []struct {
+		current string
+		message string
+	}{
+		{current: "1600MiB"},
+		{current: "2000MiB", message: "the current 2000MiB is above 90% of the container memory"},
+		{current: "512MiB", message: "the current 512MiB is below the 55% of the container memory"},
+		{current: "1GiB", message: "the current 1GiB is below the 55% of the container memory"},
+	}
+	for _, test := range tests {
+		t.Run(test.current, func(t *testing.T) {
+			result, err := Recommend(config, Options{MemoryMiB: 2048, GOMEMLIMIT: test.current})
+			require.NoError(t, err)
+			if test.message == "" {
+				assert.Empty(t, result.Env)
+				return
+			}
+			require.Len(t, result.Env, 1)
+			assert.Equal(t, "1638MiB", result.Env[0].Value)
+			assert.Contains(t, result.Env[0].Message, test.message)
+		})
+	}
+
+	_, err = Recommend(config, Options{MemoryMiB: 2048, GOMEMLIMIT: "2G"})
+	assert.ErrorContains(t, err, `invalid GOMEMLIMIT "2G"`)
+}
+
+func TestParseGOMEMLIMIT(t *testing.T) {
+	for value, expected := range map[string]float64{"1073741824": 1024, "1048576B": 1, "2048KiB": 2, "1600MiB": 1600, "2GiB": 2048, "1TiB": 1024 * 1024, "off": 0} {
+		parsed, err := ParseGOMEMLIMIT(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, parsed, value)
+	}
+	_, err := ParseGOMEMLIMIT("-1MiB")
+	assert.Error(t, err)
+}
@@ -0,0 +1,227 @@
+// Package metrics collects the MCP server metrics and exposes them in the Prometheus text format
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// durationBuckets are the upper bounds in seconds of the duration histograms, the Prometheus client defaults
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+	// otherVersion is the version label of the schema loads of versions that are not served e.g. a mistyped version
+	otherVersion = "other"
+)
+
+// Metrics collects the tool calls, cache accesses, schema loads and documentation searches of the server.
+// It implements collectorschema.Observer.
+type Metrics struct {
+	mutex         sync.Mutex
+	toolCalls     *counter
+	toolDurations *histogram
+	cacheAccesses *counter
+	schemaLoads   *counter
+	ragQueries    *counter
+	ragDurations  *histogram
+	// versions are the served collector versions, the version label is bounded by them
+	versions map[string]bool
+}
+
+var _ collectorschema.Observer = (*Metrics)(nil)
+
+// New creates the server metrics of the served collector versions
+func New(versions []string) *Metrics {
+	served := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		served[version] = true
+	}
+	return &Metrics{
+		versions:      served,
+		toolCalls:     newCounter("otel_mcp_tool_calls_total", "Number of tool calls by tool and result.", "tool", "result"),
+		toolDurations: newHistogram("otel_mcp_tool_call_duration_seconds", "Duration of the tool calls by tool.", "tool"),
+		cacheAccesses: newCounter("otel_mcp_cache_requests_total", "Number of cache lookups by cache and result, hit or miss.", "cache", "result"),
+		schemaLoads:   newCounter("otel_mcp_schema_loads_total", "Number of component schemas read from the schema files by kind, version and result.", "kind", "version", "result"),
+		ragQueries:    newCounter("otel_mcp_rag_queries_total", "Number of documentation searches by result.", "result"),
+		ragDurations:  newHistogram("otel_mcp_rag_query_duration_seconds", "Duration of the documentation searches."),
+	}
+}
+
+// ToolCall records a tool call, failed is true for an error result or a handler error
+func (m *Metrics) ToolCall(tool string, duration time.Duration, failed bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.toolCalls.inc(tool, result(failed))
+	m.toolDurations.observe(duration.Seconds(), tool)
+}
+
+// CacheAccess records a cache lookup
+func (m *Metrics) CacheAccess(cache string, hit bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if hit {
+		m.cacheAccesses.inc(cache, "hit")
+	} else {
+		m.cacheAccesses.inc(cache, "miss")
+	}
+}
+
+// SchemaLoaded records a schema read from the schema files, the loads of versions that are not served are recorded
+// with the other version
+func (m *Metrics) SchemaLoaded(componentType collectorschema.ComponentType, version string, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.versions[version] {
+		version = otherVersion
+	}
+	m.schemaLoads.inc(string(componentType), version, result(err != nil))
+}
+
+// RAGQuery records a documentation search
+func (m *Metrics) RAGQuery(duration time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.ragQueries.inc(result(err != nil))
+	m.ragDurations.observe(duration.Seconds())
+}
+
+// WriteTo writes the metrics in the Prometheus text format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var builder strings.Builder
+	m.toolCalls.write(&builder)
+	m.toolDurations.write(&builder)
+	m.cacheAccesses.write(&builder)
+	m.schemaLoads.write(&builder)
+	m.ragQueries.write(&builder)
+	m.ragDurations.write(&builder)
+	n, err := io.WriteString(w, builder.String())
+	return int64(n), err
+}
+
+// Handler returns the HTTP handler serving the metrics e.g. on /metrics
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = m.WriteTo(w)
+	})
+}
+
+func result(failed bool) string {
+	if failed {
+		return resultError
+	}
+	return resultSuccess
+}
+
+// counter is a counter with labels, the series are keyed by the joined label values
+type counter struct {
+	name   string
+	help   string
+	labels []string
+	values map[string]float64
+}
+
+func newCounter(name, help string, labels ...string) *counter {
+	return &counter{name: name, help: help, labels: labels, values: make(map[string]float64)}
+}
+
+func (c *counter) inc(labelValues ...string) {
+	c.values[strings.Join(labelValues, "\xff")]++
+}
+
+func (c *counter) write(builder *strings.Builder) {
+	fmt.Fprintf(builder, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(builder, "%s%s %v\n", c.name, formatLabels(c.labels, strings.Split(key, "\xff")), c.values[key])
+	}
+}
+
+// histogram is a histogram with labels and the durationBuckets
+type histogram struct {
+	name   string
+	help   string
+	labels []string
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+func newHistogram(name, help string, labels ...string) *histogram {
+	return &histogram{name: name, help: help, labels: labels, series: make(map[string]*histogramSeries)}
+}
+
+func (h *histogram) observe(value float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{buckets: make([]uint64, len(durationBuckets))}
+		h.series[key] = series
+	}
+	for i, bound := range durationBuckets {
+		if value <= bound {
+			series.buckets[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+func (h *histogram) write(builder *strings.Builder) {
+	fmt.Fprintf(builder, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		series := h.series[key]
+		var labelValues []string
+		if len(h.labels) > 0 {
+			labelValues = strings.Split(key, "\xff")
+		}
+		bucketLabels := append(append([]string{}, h.labels...), "le")
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(builder, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, append(append([]string{}, labelValues...), fmt.Sprint(bound))), series.buckets[i])
+		}
+		fmt.Fprintf(builder, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, append(append([]string{}, labelValues...), "+Inf")), series.count)
+		fmt.Fprintf(builder, "%s_sum%s %v\n", h.name, formatLabels(h.labels, labelValues), series.sum)
+		fmt.Fprintf(builder, "%s_count%s %d\n", h.name, formatLabels(h.labels, labelValues), series.count)
+	}
+}
+
+// formatLabels returns the label set of a series e.g. {tool="a",result="success"}
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(values[i])
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, value)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
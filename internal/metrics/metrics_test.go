@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := New([]string{"0.139.0"})
+	metrics.ToolCall("opentelemetry-collector-rag", 20*time.Millisecond, false)
+	metrics.ToolCall("opentelemetry-collector-rag", 3*time.Second, true)
+	metrics.CacheAccess(collectorschema.CacheSchema, false)
+	metrics.CacheAccess(collectorschema.CacheSchema, true)
+	metrics.CacheAccess(collectorschema.CacheSchema, true)
+	metrics.SchemaLoaded(collectorschema.ComponentTypeReceiver, "0.139.0", nil)
+	metrics.SchemaLoaded(collectorschema.ComponentTypeReceiver, "0.139.0", errors.New("not found"))
+	metrics.SchemaLoaded(collectorschema.ComponentTypeReceiver, "0.1390.0", errors.New("not found"))
+	metrics.SchemaLoaded(collectorschema.ComponentTypeReceiver, "latest", errors.New("not found"))
+	metrics.RAGQuery(7*time.Millisecond, nil)
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+	output := recorder.Body.String()
+
+	for _, line := range []string{
+		"# TYPE otel_mcp_tool_calls_total counter",
+		`otel_mcp_tool_calls_total{tool="opentelemetry-collector-rag",result="error"} 1`,
+		`otel_mcp_tool_calls_total{tool="opentelemetry-collector-rag",result="success"} 1`,
+		"# TYPE otel_mcp_tool_call_duration_seconds histogram",
+		`otel_mcp_tool_call_duration_seconds_bucket{tool="opentelemetry-collector-rag",le="0.025"} 1`,
+		`otel_mcp_tool_call_duration_seconds_bucket{tool="opentelemetry-collector-rag",le="5"} 2`,
+		`otel_mcp_tool_call_duration_seconds_bucket{tool="opentelemetry-collector-rag",le="+Inf"} 2`,
+		`otel_mcp_tool_call_duration_seconds_sum{tool="opentelemetry-collector-rag"} 3.02`,
+		`otel_mcp_tool_call_duration_seconds_count{tool="opentelemetry-collector-rag"} 2`,
+		`otel_mcp_cache_requests_total{cache="schema",result="hit"} 2`,
+		`otel_mcp_cache_requests_total{cache="schema",result="miss"} 1`,
+		`otel_mcp_schema_loads_total{kind="receiver",version="0.139.0",result="error"} 1`,
+		`otel_mcp_schema_loads_total{kind="receiver",version="0.139.0",result="success"} 1`,
+		`otel_mcp_schema_loads_total{kind="receiver",version="other",result="error"} 2`,
+		`otel_mcp_rag_queries_total{result="success"} 1`,
+		`otel_mcp_rag_query_duration_seconds_bucket{le="0.01"} 1`,
+		`otel_mcp_rag_query_duration_seconds_count 1`,
+	} {
+		assert.Contains(t, strings.Split(output, "\n"), line)
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	assert.Equal(t, "", formatLabels(nil, nil))
+	assert.Equal(t, `{a="x\"y",b="1\\2"}`, formatLabels([]string{"a", "b"}, []string{`x"y`, `1\2`}))
+	require.NotPanics(t, func() { New(nil).WriteTo(&strings.Builder{}) })
+}
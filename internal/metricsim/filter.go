@@ -0,0 +1,136 @@
+package metricsim
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/ottl"
+)
+
+type filterConfig struct {
+	Metrics filterMetricsConfig `json:"metrics"`
+}
+
+type filterMetricsConfig struct {
+	Include   *filterMatchConfig `json:"include"`
+	Exclude   *filterMatchConfig `json:"exclude"`
+	Metric    []string           `json:"metric"`
+	Datapoint []string           `json:"datapoint"`
+}
+
+type filterMatchConfig struct {
+	MatchType   string   `json:"match_type"`
+	MetricNames []string `json:"metric_names"`
+}
+
+// newFilter simulates the filter processor for metrics
+func newFilter(config filterConfig) (processFunc, []string, error) {
+	var warnings []string
+
+	include, includeWarnings, err := newNameMatcher(config.Metrics.Include)
+	if err != nil {
+		return nil, nil, fmt.Errorf("metrics.include: %w", err)
+	}
+	exclude, excludeWarnings, err := newNameMatcher(config.Metrics.Exclude)
+	if err != nil {
+		return nil, nil, fmt.Errorf("metrics.exclude: %w", err)
+	}
+	warnings = append(warnings, includeWarnings...)
+	warnings = append(warnings, excludeWarnings...)
+
+	var conditions []ottl.Node
+	for _, raw := range append(append([]string{}, config.Metrics.Metric...), config.Metrics.Datapoint...) {
+		condition, err := ottl.ParseCondition(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid condition %q: %w", raw, err)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	fn := func(metric Metric) ([]Metric, error) {
+		if include != nil && !include(metric.Name) {
+			return nil, nil
+		}
+		if exclude != nil && exclude(metric.Name) {
+			return nil, nil
+		}
+		for _, condition := range conditions {
+			drop, err := ottl.EvalCondition(condition, metricResolver(&metric))
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate condition %s: %w", condition, err)
+			}
+			if drop {
+				return nil, nil
+			}
+		}
+		return []Metric{metric}, nil
+	}
+	return fn, warnings, nil
+}
+
+// newNameMatcher creates a metric name matcher for the legacy include/exclude filter configuration
+func newNameMatcher(config *filterMatchConfig) (func(name string) bool, []string, error) {
+	if config == nil {
+		return nil, nil, nil
+	}
+
+	switch config.MatchType {
+	case "strict":
+		names := make(map[string]struct{}, len(config.MetricNames))
+		for _, name := range config.MetricNames {
+			names[name] = struct{}{}
+		}
+		return func(name string) bool {
+			_, ok := names[name]
+			return ok
+		}, nil, nil
+	case "regexp":
+		patterns := make([]*regexp.Regexp, len(config.MetricNames))
+		for i, pattern := range config.MetricNames {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid regexp %q: %w", pattern, err)
+			}
+			patterns[i] = re
+		}
+		return func(name string) bool {
+			for _, re := range patterns {
+				if re.MatchString(name) {
+					return true
+				}
+			}
+			return false
+		}, nil, nil
+	default:
+		warning := fmt.Sprintf("filter match_type %q is not simulated, the include/exclude rule is ignored", config.MatchType)
+		return nil, []string{warning}, nil
+	}
+}
+
+// metricResolver resolves OTTL metric and datapoint paths against the simulated metric
+func metricResolver(metric *Metric) ottl.Resolver {
+	return func(path *ottl.Path) (interface{}, error) {
+		switch path.Field() {
+		case "name":
+			return metric.Name, nil
+		case "attributes":
+			if path.Context() == "resource" || path.Context() == "scope" {
+				return nil, nil
+			}
+			if len(path.Keys) == 0 {
+				return metric.Labels, nil
+			}
+			key, err := ottl.EvalValue(path.Keys[0], metricResolver(metric))
+			if err != nil {
+				return nil, err
+			}
+			value, exists := metric.Labels[fmt.Sprintf("%v", key)]
+			if !exists {
+				return nil, nil
+			}
+			return value, nil
+		default:
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package metricsim
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type metricsTransformConfig struct {
+	Transforms []metricsTransformRule `json:"transforms"`
+}
+
+type metricsTransformRule struct {
+	Include     string                      `json:"include"`
+	MatchType   string                      `json:"match_type"`
+	MatchLabels map[string]string           `json:"experimental_match_labels"`
+	Action      string                      `json:"action"`
+	NewName     string                      `json:"new_name"`
+	Operations  []metricsTransformOperation `json:"operations"`
+}
+
+type metricsTransformOperation struct {
+	Action           string                        `json:"action"`
+	Label            string                        `json:"label"`
+	NewLabel         string                        `json:"new_label"`
+	NewValue         string                        `json:"new_value"`
+	LabelValue       string                        `json:"label_value"`
+	LabelSet         []string                      `json:"label_set"`
+	AggregatedValues []string                      `json:"aggregated_values"`
+	ValueActions     []metricsTransformValueAction `json:"value_actions"`
+}
+
+type metricsTransformValueAction struct {
+	Value    string `json:"value"`
+	NewValue string `json:"new_value"`
+}
+
+// newMetricsTransform simulates the metricstransform processor
+func newMetricsTransform(config metricsTransformConfig) (processFunc, []string, error) {
+	var warnings []string
+	matchers := make([]*regexp.Regexp, len(config.Transforms))
+	for i, rule := range config.Transforms {
+		if rule.Include == "" {
+			return nil, nil, fmt.Errorf("transforms[%d]: include must be set", i)
+		}
+		switch rule.Action {
+		case "update", "insert", "combine":
+		default:
+			return nil, nil, fmt.Errorf("transforms[%d]: unsupported action %q, must be update, insert or combine", i, rule.Action)
+		}
+		if rule.Action != "update" && rule.NewName == "" {
+			return nil, nil, fmt.Errorf("transforms[%d]: new_name must be set for action %s", i, rule.Action)
+		}
+		if rule.MatchType == "regexp" {
+			re, err := regexp.Compile(rule.Include)
+			if err != nil {
+				return nil, nil, fmt.Errorf("transforms[%d]: invalid include regexp: %w", i, err)
+			}
+			matchers[i] = re
+		} else if rule.Action == "combine" {
+			return nil, nil, fmt.Errorf("transforms[%d]: combine requires match_type regexp", i)
+		}
+		for _, op := range rule.Operations {
+			switch op.Action {
+			case "add_label", "update_label", "delete_label_value", "aggregate_labels", "aggregate_label_values":
+			default:
+				warnings = append(warnings, fmt.Sprintf("metricstransform operation %s does not change metric names or labels and is not simulated", op.Action))
+			}
+		}
+	}
+
+	fn := func(metric Metric) ([]Metric, error) {
+		outputs := []Metric{metric}
+		for i, rule := range config.Transforms {
+			var next []Metric
+			for _, m := range outputs {
+				next = append(next, applyMetricsTransformRule(rule, matchers[i], m)...)
+			}
+			outputs = next
+		}
+		return outputs, nil
+	}
+	return fn, warnings, nil
+}
+
+func applyMetricsTransformRule(rule metricsTransformRule, re *regexp.Regexp, metric Metric) []Metric {
+	var submatches []string
+	if re != nil {
+		submatches = re.FindStringSubmatch(metric.Name)
+		if submatches == nil {
+			return []Metric{metric}
+		}
+	} else if rule.Include != metric.Name {
+		return []Metric{metric}
+	}
+
+	for label, value := range rule.MatchLabels {
+		actual, exists := metric.Labels[label]
+		if !exists {
+			return []Metric{metric}
+		}
+		if re != nil {
+			if matched, _ := regexp.MatchString(value, actual); !matched {
+				return []Metric{metric}
+			}
+		} else if actual != value {
+			return []Metric{metric}
+		}
+	}
+
+	target := metric
+	if rule.Action == "insert" {
+		target = cloneMetric(metric)
+	}
+	if rule.NewName != "" {
+		target.Name = expandSubmatches(rule.NewName, submatches)
+	}
+	if rule.Action == "combine" && re != nil {
+		// Named capture groups become labels of the combined metric
+		for i, name := range re.SubexpNames() {
+			if name != "" && i < len(submatches) {
+				target.Labels[name] = submatches[i]
+			}
+		}
+	}
+
+	for _, op := range rule.Operations {
+		if !applyMetricsTransformOperation(op, &target) {
+			// The datapoint was deleted
+			if rule.Action == "insert" {
+				return []Metric{metric}
+			}
+			return nil
+		}
+	}
+
+	if rule.Action == "insert" {
+		return []Metric{metric, target}
+	}
+	return []Metric{target}
+}
+
+// applyMetricsTransformOperation applies the operation and returns false if the datapoint is deleted
+func applyMetricsTransformOperation(op metricsTransformOperation, metric *Metric) bool {
+	switch op.Action {
+	case "add_label":
+		metric.Labels[op.NewLabel] = op.NewValue
+	case "update_label":
+		value, exists := metric.Labels[op.Label]
+		if !exists {
+			return true
+		}
+		for _, valueAction := range op.ValueActions {
+			if valueAction.Value == value {
+				value = valueAction.NewValue
+				break
+			}
+		}
+		if op.NewLabel != "" {
+			delete(metric.Labels, op.Label)
+			metric.Labels[op.NewLabel] = value
+		} else {
+			metric.Labels[op.Label] = value
+		}
+	case "delete_label_value":
+		if value, exists := metric.Labels[op.Label]; exists && value == op.LabelValue {
+			return false
+		}
+	case "aggregate_labels":
+		keep := make(map[string]struct{}, len(op.LabelSet))
+		for _, label := range op.LabelSet {
+			keep[label] = struct{}{}
+		}
+		for label := range metric.Labels {
+			if _, ok := keep[label]; !ok {
+				delete(metric.Labels, label)
+			}
+		}
+	case "aggregate_label_values":
+		if value, exists := metric.Labels[op.Label]; exists {
+			for _, aggregated := range op.AggregatedValues {
+				if value == aggregated {
+					metric.Labels[op.Label] = op.NewValue
+					break
+				}
+			}
+		}
+	}
+	return true
+}
+
+// expandSubmatches replaces $1, $2, ... in the new name with the regexp submatches
+func expandSubmatches(newName string, submatches []string) string {
+	for i := len(submatches) - 1; i > 0; i-- {
+		newName = strings.ReplaceAll(newName, "$"+strconv.Itoa(i), submatches[i])
+	}
+	return newName
+}
@@ -0,0 +1,191 @@
+package metricsim
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Metric is a metric name together with the labels (datapoint attributes) of one of its series
+type Metric struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Processor is a processor of the pipeline, the processor type is derived from the ID e.g. filter/drop
+type Processor struct {
+	ID     string                 `json:"id"`
+	Config map[string]interface{} `json:"config"`
+}
+
+// Step records the effect of a single processor on a metric
+type Step struct {
+	Processor string   `json:"processor"`
+	Outputs   []Metric `json:"outputs"`
+	Changes   []string `json:"changes,omitempty"`
+	Dropped   bool     `json:"dropped,omitempty"`
+}
+
+// Result is the simulation result for a single input metric
+type Result struct {
+	Input     Metric   `json:"input"`
+	Outputs   []Metric `json:"outputs"`
+	DroppedBy string   `json:"droppedBy,omitempty"`
+	Steps     []Step   `json:"steps"`
+}
+
+// Simulation is the result of running metrics through a processor chain
+type Simulation struct {
+	Results  []Result `json:"results"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// processFunc applies a processor to a metric and returns the resulting metrics, nil means dropped
+type processFunc func(metric Metric) ([]Metric, error)
+
+// Simulate runs the metrics through the processors in order and records renames, label changes and drops
+func Simulate(metrics []Metric, processors []Processor) (*Simulation, error) {
+	simulation := &Simulation{Results: []Result{}}
+
+	funcs := make([]processFunc, len(processors))
+	for i, processor := range processors {
+		fn, warnings, err := newProcessFunc(processor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration of processor %s: %w", processor.ID, err)
+		}
+		funcs[i] = fn
+		simulation.Warnings = append(simulation.Warnings, warnings...)
+	}
+
+	for _, input := range metrics {
+		result := Result{Input: input, Steps: []Step{}}
+		current := []Metric{cloneMetric(input)}
+
+		for i, processor := range processors {
+			// The outputs are empty and not null when the processor drops the metric
+			outputs := []Metric{}
+			for _, metric := range current {
+				out, err := funcs[i](cloneMetric(metric))
+				if err != nil {
+					return nil, fmt.Errorf("processor %s failed for metric %s: %w", processor.ID, metric.Name, err)
+				}
+				outputs = append(outputs, out...)
+			}
+
+			step := Step{
+				Processor: processor.ID,
+				Outputs:   outputs,
+				Changes:   diffMetrics(current, outputs),
+				Dropped:   len(outputs) == 0,
+			}
+			result.Steps = append(result.Steps, step)
+
+			current = outputs
+			if len(current) == 0 {
+				result.DroppedBy = processor.ID
+				break
+			}
+		}
+
+		result.Outputs = current
+		simulation.Results = append(simulation.Results, result)
+	}
+
+	return simulation, nil
+}
+
+// newProcessFunc creates the simulation function for a processor based on its type
+func newProcessFunc(processor Processor) (processFunc, []string, error) {
+	processorType, _, _ := strings.Cut(processor.ID, "/")
+	switch processorType {
+	case "metricstransform":
+		var config metricsTransformConfig
+		if err := decodeConfig(processor.Config, &config); err != nil {
+			return nil, nil, err
+		}
+		return newMetricsTransform(config)
+	case "filter":
+		var config filterConfig
+		if err := decodeConfig(processor.Config, &config); err != nil {
+			return nil, nil, err
+		}
+		return newFilter(config)
+	case "transform":
+		var config transformConfig
+		if err := decodeConfig(processor.Config, &config); err != nil {
+			return nil, nil, err
+		}
+		return newTransform(config)
+	default:
+		passThrough := func(metric Metric) ([]Metric, error) {
+			return []Metric{metric}, nil
+		}
+		warning := fmt.Sprintf("processor %s is not simulated, metrics are passed through unchanged", processor.ID)
+		return passThrough, []string{warning}, nil
+	}
+}
+
+// decodeConfig converts the generic processor configuration into a typed configuration
+func decodeConfig(config map[string]interface{}, target interface{}) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func cloneMetric(metric Metric) Metric {
+	labels := make(map[string]string, len(metric.Labels))
+	for k, v := range metric.Labels {
+		labels[k] = v
+	}
+	return Metric{Name: metric.Name, Labels: labels}
+}
+
+// diffMetrics describes the differences between the metrics before and after a processor
+func diffMetrics(before, after []Metric) []string {
+	if len(after) == 0 {
+		return []string{"dropped"}
+	}
+
+	var changes []string
+	for i, out := range after {
+		// Additional outputs are metrics inserted by the processor
+		if i >= len(before) {
+			changes = append(changes, fmt.Sprintf("inserted metric %q", out.Name))
+			continue
+		}
+		in := before[i]
+		if in.Name != out.Name {
+			changes = append(changes, fmt.Sprintf("renamed %q to %q", in.Name, out.Name))
+		}
+
+		keys := make(map[string]struct{})
+		for k := range in.Labels {
+			keys[k] = struct{}{}
+		}
+		for k := range out.Labels {
+			keys[k] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+
+		for _, k := range sortedKeys {
+			oldValue, hadLabel := in.Labels[k]
+			newValue, hasLabel := out.Labels[k]
+			switch {
+			case hadLabel && !hasLabel:
+				changes = append(changes, fmt.Sprintf("%s: removed label %q", out.Name, k))
+			case !hadLabel && hasLabel:
+				changes = append(changes, fmt.Sprintf("%s: added label %q=%q", out.Name, k, newValue))
+			case oldValue != newValue:
+				changes = append(changes, fmt.Sprintf("%s: changed label %q from %q to %q", out.Name, k, oldValue, newValue))
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,119 @@
+package metricsim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	metrics := []Metric{
+		{Name: "http.server.duration", Labels: map[string]string{"http.method": "GET", "host": "a"}},
+		{Name: "system.cpu.time", Labels: map[string]string{"state": "idle"}},
+		{Name: "debug.counter"},
+	}
+	processors := []Processor{
+		{ID: "metricstransform", Config: map[string]interface{}{
+			"transforms": []interface{}{
+				map[string]interface{}{
+					"include":  "http.server.duration",
+					"action":   "update",
+					"new_name": "http_server_duration",
+					"operations": []interface{}{
+						map[string]interface{}{"action": "update_label", "label": "http.method", "new_label": "method"},
+					},
+				},
+			},
+		}},
+		{ID: "transform/cleanup", Config: map[string]interface{}{
+			"metric_statements": []interface{}{
+				map[string]interface{}{
+					"context":    "datapoint",
+					"statements": []interface{}{`delete_key(attributes, "host")`},
+				},
+				`set(metric.name, "cpu.time") where metric.name == "system.cpu.time"`,
+			},
+		}},
+		{ID: "filter/drop-debug", Config: map[string]interface{}{
+			"metrics": map[string]interface{}{
+				"metric": []interface{}{`IsMatch(name, "^debug\\.")`},
+			},
+		}},
+		{ID: "batch"},
+	}
+
+	simulation, err := Simulate(metrics, processors)
+	require.NoError(t, err)
+	require.Len(t, simulation.Results, 3)
+
+	http := simulation.Results[0]
+	require.Len(t, http.Outputs, 1)
+	assert.Equal(t, "http_server_duration", http.Outputs[0].Name)
+	assert.Equal(t, map[string]string{"method": "GET"}, http.Outputs[0].Labels)
+	assert.Contains(t, http.Steps[0].Changes, `renamed "http.server.duration" to "http_server_duration"`)
+
+	cpu := simulation.Results[1]
+	require.Len(t, cpu.Outputs, 1)
+	assert.Equal(t, "cpu.time", cpu.Outputs[0].Name)
+
+	debug := simulation.Results[2]
+	assert.Empty(t, debug.Outputs)
+	assert.Equal(t, "filter/drop-debug", debug.DroppedBy)
+
+	assert.Contains(t, simulation.Warnings, "processor batch is not simulated, metrics are passed through unchanged")
+}
+
+func TestSimulate_MetricsTransformInsertAndRegexp(t *testing.T) {
+	metrics := []Metric{{Name: "requests.total", Labels: map[string]string{"env": "prod"}}}
+	processors := []Processor{
+		{ID: "metricstransform", Config: map[string]interface{}{
+			"transforms": []interface{}{
+				map[string]interface{}{
+					"include":    "^requests\\.(.*)$",
+					"match_type": "regexp",
+					"action":     "insert",
+					"new_name":   "requests_$1",
+					"operations": []interface{}{
+						map[string]interface{}{"action": "aggregate_labels", "label_set": []interface{}{}, "aggregation_type": "sum"},
+					},
+				},
+			},
+		}},
+	}
+
+	simulation, err := Simulate(metrics, processors)
+	require.NoError(t, err)
+	require.Len(t, simulation.Results[0].Outputs, 2)
+	assert.Equal(t, "requests.total", simulation.Results[0].Outputs[0].Name)
+	assert.Equal(t, "requests_total", simulation.Results[0].Outputs[1].Name)
+	assert.Empty(t, simulation.Results[0].Outputs[1].Labels)
+}
+
+func TestSimulate_LegacyFilter(t *testing.T) {
+	metrics := []Metric{{Name: "keep.me"}, {Name: "drop.me"}}
+	processors := []Processor{
+		{ID: "filter", Config: map[string]interface{}{
+			"metrics": map[string]interface{}{
+				"include": map[string]interface{}{"match_type": "strict", "metric_names": []interface{}{"keep.me"}},
+			},
+		}},
+	}
+
+	simulation, err := Simulate(metrics, processors)
+	require.NoError(t, err)
+	assert.Len(t, simulation.Results[0].Outputs, 1)
+	assert.Equal(t, "filter", simulation.Results[1].DroppedBy)
+}
+
+func TestSimulate_InvalidConfig(t *testing.T) {
+	_, err := Simulate([]Metric{{Name: "a"}}, []Processor{
+		{ID: "transform", Config: map[string]interface{}{"metric_statements": []interface{}{`set(name, "b"`}}},
+	})
+	require.Error(t, err)
+
+	_, err = Simulate([]Metric{{Name: "a"}}, []Processor{
+		{ID: "metricstransform", Config: map[string]interface{}{"transforms": []interface{}{map[string]interface{}{"include": "a", "action": "rename"}}}},
+	})
+	require.Error(t, err)
+}
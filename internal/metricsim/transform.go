@@ -0,0 +1,237 @@
+package metricsim
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/ottl"
+)
+
+type transformConfig struct {
+	MetricStatements []transformStatementGroup `json:"metric_statements"`
+}
+
+// transformStatementGroup is either a single statement string or a group with a context
+type transformStatementGroup struct {
+	Context    string   `json:"context"`
+	Conditions []string `json:"conditions"`
+	Statements []string `json:"statements"`
+}
+
+func (g *transformStatementGroup) UnmarshalJSON(data []byte) error {
+	var statement string
+	if err := json.Unmarshal(data, &statement); err == nil {
+		g.Statements = []string{statement}
+		return nil
+	}
+	type group transformStatementGroup
+	return json.Unmarshal(data, (*group)(g))
+}
+
+type transformStatement struct {
+	statement  *ottl.Statement
+	conditions []ottl.Node
+}
+
+// newTransform simulates the transform processor for metric names and datapoint attributes
+func newTransform(config transformConfig) (processFunc, []string, error) {
+	var warnings []string
+	var statements []transformStatement
+
+	for _, group := range config.MetricStatements {
+		var conditions []ottl.Node
+		for _, raw := range group.Conditions {
+			condition, err := ottl.ParseCondition(raw)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid condition %q: %w", raw, err)
+			}
+			conditions = append(conditions, condition)
+		}
+		for _, raw := range group.Statements {
+			statement, err := ottl.ParseStatement(raw)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid statement %q: %w", raw, err)
+			}
+			if !isSimulatedEditor(statement.Editor.Name) {
+				warnings = append(warnings, fmt.Sprintf("transform statement %q is not simulated", raw))
+				continue
+			}
+			statements = append(statements, transformStatement{statement: statement, conditions: conditions})
+		}
+	}
+
+	fn := func(metric Metric) ([]Metric, error) {
+		for _, ts := range statements {
+			resolve := metricResolver(&metric)
+			apply := len(ts.conditions) == 0
+			for _, condition := range ts.conditions {
+				matched, err := ottl.EvalCondition(condition, resolve)
+				if err != nil {
+					return nil, err
+				}
+				if matched {
+					apply = true
+					break
+				}
+			}
+			if apply && ts.statement.Condition != nil {
+				matched, err := ottl.EvalCondition(ts.statement.Condition, resolve)
+				if err != nil {
+					return nil, err
+				}
+				apply = matched
+			}
+			if !apply {
+				continue
+			}
+			if err := applyEditor(ts.statement.Editor, &metric); err != nil {
+				return nil, fmt.Errorf("statement %q: %w", ts.statement.Raw, err)
+			}
+		}
+		return []Metric{metric}, nil
+	}
+	return fn, warnings, nil
+}
+
+func isSimulatedEditor(name string) bool {
+	switch name {
+	case "set", "delete_key", "delete_matching_keys", "keep_keys", "keep_matching_keys", "replace_pattern", "replace_all_patterns":
+		return true
+	}
+	return false
+}
+
+func applyEditor(editor *ottl.Call, metric *Metric) error {
+	resolve := metricResolver(metric)
+	args := make([]interface{}, len(editor.Args))
+	for i, arg := range editor.Args {
+		// The first argument is the target path which is not evaluated
+		if i == 0 {
+			continue
+		}
+		value, err := ottl.EvalValue(arg, resolve)
+		if err != nil {
+			return err
+		}
+		args[i] = value
+	}
+	if len(editor.Args) == 0 {
+		return fmt.Errorf("%s requires a target", editor.Name)
+	}
+	target, ok := editor.Args[0].(*ottl.Path)
+	if !ok {
+		return fmt.Errorf("%s target must be a path", editor.Name)
+	}
+
+	switch editor.Name {
+	case "set":
+		if len(args) != 2 {
+			return fmt.Errorf("set expects 2 arguments")
+		}
+		return setPath(target, args[1], metric)
+	case "replace_pattern":
+		if len(args) < 3 {
+			return fmt.Errorf("replace_pattern expects at least 3 arguments")
+		}
+		re, err := regexp.Compile(fmt.Sprintf("%v", args[1]))
+		if err != nil {
+			return err
+		}
+		current, err := resolve(target)
+		if err != nil || current == nil {
+			return err
+		}
+		return setPath(target, re.ReplaceAllString(fmt.Sprintf("%v", current), fmt.Sprintf("%v", args[2])), metric)
+	case "delete_key":
+		if len(args) != 2 {
+			return fmt.Errorf("delete_key expects 2 arguments")
+		}
+		if target.Field() == "attributes" {
+			delete(metric.Labels, fmt.Sprintf("%v", args[1]))
+		}
+	case "delete_matching_keys", "keep_matching_keys":
+		if len(args) != 2 {
+			return fmt.Errorf("%s expects 2 arguments", editor.Name)
+		}
+		re, err := regexp.Compile(fmt.Sprintf("%v", args[1]))
+		if err != nil {
+			return err
+		}
+		if target.Field() == "attributes" {
+			keep := editor.Name == "keep_matching_keys"
+			for key := range metric.Labels {
+				if re.MatchString(key) != keep {
+					delete(metric.Labels, key)
+				}
+			}
+		}
+	case "keep_keys":
+		if len(args) != 2 {
+			return fmt.Errorf("keep_keys expects 2 arguments")
+		}
+		keys, _ := args[1].([]interface{})
+		keep := make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			keep[fmt.Sprintf("%v", key)] = struct{}{}
+		}
+		if target.Field() == "attributes" {
+			for key := range metric.Labels {
+				if _, ok := keep[key]; !ok {
+					delete(metric.Labels, key)
+				}
+			}
+		}
+	case "replace_all_patterns":
+		if len(args) < 4 {
+			return fmt.Errorf("replace_all_patterns expects at least 4 arguments")
+		}
+		re, err := regexp.Compile(fmt.Sprintf("%v", args[2]))
+		if err != nil {
+			return err
+		}
+		replacement := fmt.Sprintf("%v", args[3])
+		if target.Field() == "attributes" {
+			updated := make(map[string]string, len(metric.Labels))
+			for key, value := range metric.Labels {
+				if args[1] == "key" {
+					key = re.ReplaceAllString(key, replacement)
+				} else {
+					value = re.ReplaceAllString(value, replacement)
+				}
+				updated[key] = value
+			}
+			metric.Labels = updated
+		}
+	}
+	return nil
+}
+
+// setPath sets the metric name or a datapoint attribute
+func setPath(target *ottl.Path, value interface{}, metric *Metric) error {
+	switch target.Field() {
+	case "name":
+		name, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("metric name must be a string")
+		}
+		metric.Name = name
+	case "attributes":
+		if target.Context() == "resource" || target.Context() == "scope" {
+			return nil
+		}
+		if len(target.Keys) == 0 {
+			return fmt.Errorf("setting all attributes is not simulated")
+		}
+		key, err := ottl.EvalValue(target.Keys[0], metricResolver(metric))
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			delete(metric.Labels, fmt.Sprintf("%v", key))
+		} else {
+			metric.Labels[fmt.Sprintf("%v", key)] = fmt.Sprintf("%v", value)
+		}
+	}
+	return nil
+}
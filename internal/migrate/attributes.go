@@ -0,0 +1,562 @@
+package migrate
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/ottl"
+)
+
+// signalStatements maps the pipeline signals to the transform processor statements and the OTTL context of the
+// telemetry the attributes processor modifies
+var signalStatements = map[string]struct {
+	key     string
+	context string
+}{
+	"traces":  {"trace_statements", "span"},
+	"metrics": {"metric_statements", "datapoint"},
+	"logs":    {"log_statements", "log"},
+}
+
+// convertFunctions maps the attributes processor converted_type to the OTTL converter
+var convertFunctions = map[string]string{
+	"int":    "Int",
+	"double": "Double",
+	"string": "String",
+}
+
+// matchProperties are the include and exclude properties of the attributes processor, their OTTL path and the
+// signals they can be used with
+var matchProperties = map[string]struct {
+	path    string
+	signals []string
+}{
+	"services":           {`resource.attributes["service.name"]`, []string{"traces", "logs"}},
+	"span_names":         {"name", []string{"traces"}},
+	"log_bodies":         {"body", []string{"logs"}},
+	"log_severity_texts": {"severity_text", []string{"logs"}},
+	"metric_names":       {"metric.name", []string{"metrics"}},
+}
+
+// actionsKeys maps the attributes and resource processors to the key of their actions, the resource processor keeps
+// its actions under attributes
+var actionsKeys = map[string]string{
+	"attributes": "actions",
+	"resource":   "attributes",
+}
+
+// MigrationResult is the configuration with the converted processors
+type MigrationResult struct {
+	Config *collectorconfig.Config
+	// Replaced maps the converted processor IDs to the processors replacing them
+	Replaced map[string][]string
+	Warnings []string
+}
+
+// AttributesToTransform converts the attributes and resource processors of the configuration to transform
+// processors and replaces them in the pipelines
+func AttributesToTransform(config *collectorconfig.Config) (*MigrationResult, error) {
+	migrated, err := config.Clone()
+	if err != nil {
+		return nil, err
+	}
+	result := &MigrationResult{Config: migrated, Replaced: make(map[string][]string)}
+	for _, id := range slices.Sorted(maps.Keys(config.Processors)) {
+		componentType := collectorconfig.ComponentType(id)
+		actionsKey, ok := actionsKeys[componentType]
+		if !ok {
+			continue
+		}
+		settings, _ := migrated.Processors[id].(map[string]interface{})
+		actions, _ := settings[actionsKey].([]interface{})
+		statements, err := actionStatements(actionsKey, actions)
+		if err != nil {
+			return nil, fmt.Errorf("processor %s: %w", id, err)
+		}
+
+		signals := pipelineSignals(config, id)
+		if len(signals) == 0 {
+			signals = slices.Sorted(maps.Keys(signalStatements))
+			result.Warnings = append(result.Warnings, fmt.Sprintf("processor %s is not used in any pipeline, it is converted for all signals", id))
+		}
+		transform := map[string]interface{}{
+			// The attributes processor skips telemetry it cannot modify
+			"error_mode": "ignore",
+		}
+		for _, signal := range signals {
+			context := "resource"
+			groupStatements := statements
+			if componentType == "attributes" {
+				context = signalStatements[signal].context
+				condition, err := matchCondition(settings, signal)
+				if err != nil {
+					return nil, fmt.Errorf("processor %s: %w", id, err)
+				}
+				groupStatements = withCondition(statements, condition)
+			}
+			transform[signalStatements[signal].key] = []interface{}{
+				map[string]interface{}{"context": context, "statements": groupStatements},
+			}
+		}
+		if hasAction(actions, "hash") {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("processor %s: hash is converted to SHA256, verify it matches the hash function of the attributes processor of the collector version when the hashes are compared", id))
+		}
+
+		transformID := "transform/" + strings.ReplaceAll(id, "/", "_")
+		if _, exists := migrated.Processors[transformID]; exists {
+			return nil, fmt.Errorf("processor %s cannot be converted, processor %s already exists", id, transformID)
+		}
+		migrated.Processors[transformID] = transform
+		replaceProcessor(migrated, id, []string{transformID})
+		result.Replaced[id] = []string{transformID}
+	}
+	return result, nil
+}
+
+// TransformToAttributes converts the transform processors consisting only of statements expressible as attributes
+// processor actions to attributes and resource processors and replaces them in the pipelines
+func TransformToAttributes(config *collectorconfig.Config) (*MigrationResult, error) {
+	migrated, err := config.Clone()
+	if err != nil {
+		return nil, err
+	}
+	result := &MigrationResult{Config: migrated, Replaced: make(map[string][]string)}
+	for _, id := range slices.Sorted(maps.Keys(config.Processors)) {
+		if collectorconfig.ComponentType(id) != "transform" {
+			continue
+		}
+		settings, _ := migrated.Processors[id].(map[string]interface{})
+
+		// The attributes processor applies the same actions to all signals
+		var attributeActions, resourceActions []interface{}
+		var converted, resourceConverted []string
+		for _, signal := range slices.Sorted(maps.Keys(signalStatements)) {
+			key := signalStatements[signal].key
+			groups, _ := settings[key].([]interface{})
+			var signalActions, signalResourceActions []interface{}
+			for i, group := range groups {
+				g, ok := group.(map[string]interface{})
+				context, _ := g["context"].(string)
+				if !ok || context == "" {
+					return nil, fmt.Errorf("processor %s: %s[%d] must set the context to be converted", id, key, i)
+				}
+				if _, ok := g["conditions"]; ok {
+					return nil, fmt.Errorf("processor %s: %s[%d] conditions cannot be converted to attributes processor actions", id, key, i)
+				}
+				if context != "resource" && context != signalStatements[signal].context {
+					return nil, fmt.Errorf("processor %s: statements in the %s context cannot be converted", id, context)
+				}
+				for _, raw := range stringList(g["statements"]) {
+					action, err := statementAction(raw, context)
+					if err != nil {
+						return nil, fmt.Errorf("processor %s: %w", id, err)
+					}
+					if context == "resource" {
+						signalResourceActions = append(signalResourceActions, action)
+					} else {
+						signalActions = append(signalActions, action)
+					}
+				}
+			}
+			if signalResourceActions != nil {
+				if resourceActions != nil && !reflect.DeepEqual(resourceActions, signalResourceActions) {
+					return nil, fmt.Errorf("processor %s: the resource statements differ between signals, a resource processor applies the same actions to all signals", id)
+				}
+				resourceActions = signalResourceActions
+				resourceConverted = append(resourceConverted, key)
+			}
+			if signalActions != nil {
+				if attributeActions != nil && !reflect.DeepEqual(attributeActions, signalActions) {
+					return nil, fmt.Errorf("processor %s: the statements differ between signals, an attributes processor applies the same actions to all signals", id)
+				}
+				attributeActions = signalActions
+				converted = append(converted, key)
+			}
+		}
+
+		for _, signal := range pipelineSignals(config, id) {
+			key := signalStatements[signal].key
+			if (attributeActions != nil && !slices.Contains(converted, key)) || (resourceActions != nil && !slices.Contains(resourceConverted, key)) {
+				return nil, fmt.Errorf("processor %s: it is used in %s pipelines without the same %s, the converted processors would modify them", id, signal, key)
+			}
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(id, "transform"), "/")
+		var replacements []string
+		for _, processor := range []struct {
+			componentType string
+			actions       []interface{}
+		}{{"resource", resourceActions}, {"attributes", attributeActions}} {
+			if len(processor.actions) == 0 {
+				continue
+			}
+			// Restore the names of processors converted by AttributesToTransform e.g. transform/attributes_pii
+			convertedName := strings.TrimPrefix(name, processor.componentType+"_")
+			if convertedName == processor.componentType {
+				convertedName = ""
+			}
+			convertedID := processor.componentType
+			if convertedName != "" {
+				convertedID += "/" + convertedName
+			}
+			if _, exists := migrated.Processors[convertedID]; exists {
+				return nil, fmt.Errorf("processor %s cannot be converted, processor %s already exists", id, convertedID)
+			}
+			migrated.Processors[convertedID] = map[string]interface{}{actionsKeys[processor.componentType]: processor.actions}
+			replacements = append(replacements, convertedID)
+		}
+		if len(replacements) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("processor %s has no statements, it is kept", id))
+			continue
+		}
+		replaceProcessor(migrated, id, replacements)
+		result.Replaced[id] = replacements
+	}
+	return result, nil
+}
+
+// actionStatements converts the attributes processor actions under actionsKey to OTTL statements
+func actionStatements(actionsKey string, actions []interface{}) ([]string, error) {
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("at least one action must be set in %s", actionsKey)
+	}
+	var statements []string
+	for i, item := range actions {
+		action, _ := item.(map[string]interface{})
+		key, _ := action["key"].(string)
+		pattern, _ := action["pattern"].(string)
+		name, _ := action["action"].(string)
+		path := fmt.Sprintf("attributes[%q]", key)
+		exists := path + " != nil"
+
+		switch name {
+		case "insert", "update", "upsert":
+			if key == "" {
+				return nil, fmt.Errorf("%s[%d]: key must be set", actionsKey, i)
+			}
+			if _, ok := action["from_context"]; ok {
+				return nil, fmt.Errorf("%s[%d]: from_context cannot be converted, the client metadata is not available in the transform processor", actionsKey, i)
+			}
+			var conditions []string
+			switch name {
+			case "insert":
+				conditions = append(conditions, path+" == nil")
+			case "update":
+				conditions = append(conditions, exists)
+			}
+			var value string
+			if source, ok := action["from_attribute"].(string); ok {
+				value = fmt.Sprintf("attributes[%q]", source)
+				conditions = append(conditions, value+" != nil")
+			} else {
+				literal, err := literalValue(action["value"])
+				if err != nil {
+					return nil, fmt.Errorf("%s[%d]: %w", actionsKey, i, err)
+				}
+				value = literal
+			}
+			statements = append(statements, statement(fmt.Sprintf("set(%s, %s)", path, value), conditions...))
+		case "delete":
+			if key == "" && pattern == "" {
+				return nil, fmt.Errorf("%s[%d]: key or pattern must be set", actionsKey, i)
+			}
+			if key != "" {
+				statements = append(statements, fmt.Sprintf("delete_key(attributes, %q)", key))
+			}
+			if pattern != "" {
+				statements = append(statements, fmt.Sprintf("delete_matching_keys(attributes, %q)", pattern))
+			}
+		case "hash":
+			if key == "" {
+				return nil, fmt.Errorf("%s[%d]: hash with a pattern cannot be converted, set the key", actionsKey, i)
+			}
+			statements = append(statements, statement(fmt.Sprintf("set(%s, SHA256(%s))", path, path), exists))
+		case "extract":
+			if key == "" || pattern == "" {
+				return nil, fmt.Errorf("%s[%d]: extract requires the key and pattern", actionsKey, i)
+			}
+			statements = append(statements, statement(fmt.Sprintf("merge_maps(attributes, ExtractPatterns(%s, %q), \"upsert\")", path, pattern), exists))
+		case "convert":
+			convertedType, _ := action["converted_type"].(string)
+			function, ok := convertFunctions[convertedType]
+			if key == "" || !ok {
+				return nil, fmt.Errorf("%s[%d]: convert requires the key and converted_type int, double or string", actionsKey, i)
+			}
+			statements = append(statements, statement(fmt.Sprintf("set(%s, %s(%s))", path, function, path), exists))
+		default:
+			return nil, fmt.Errorf("%s[%d]: unsupported action %q", actionsKey, i, name)
+		}
+	}
+	return statements, nil
+}
+
+// matchCondition converts the include and exclude properties of the attributes processor to an OTTL condition
+func matchCondition(settings map[string]interface{}, signal string) (string, error) {
+	var conditions []string
+	for _, key := range []string{"include", "exclude"} {
+		properties, ok := settings[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matchType, _ := properties["match_type"].(string)
+		if matchType != "strict" && matchType != "regexp" {
+			return "", fmt.Errorf("%s: match_type must be strict or regexp", key)
+		}
+		var all []string
+		for _, property := range slices.Sorted(maps.Keys(properties)) {
+			var values []string
+			switch property {
+			case "match_type":
+				continue
+			case "attributes", "resources":
+				prefix := "attributes"
+				if property == "resources" {
+					prefix = "resource.attributes"
+				}
+				entries, _ := properties[property].([]interface{})
+				for _, item := range entries {
+					entry, _ := item.(map[string]interface{})
+					attributeKey, _ := entry["key"].(string)
+					path := fmt.Sprintf("%s[%q]", prefix, attributeKey)
+					if value, ok := entry["value"]; ok {
+						all = append(all, matchExpression(matchType, path, fmt.Sprint(value)))
+					} else {
+						all = append(all, path+" != nil")
+					}
+				}
+				continue
+			}
+			match, ok := matchProperties[property]
+			if !ok {
+				return "", fmt.Errorf("%s.%s cannot be converted", key, property)
+			}
+			if !slices.Contains(match.signals, signal) {
+				return "", fmt.Errorf("%s.%s cannot be used with %s", key, property, signal)
+			}
+			for _, value := range stringList(properties[property]) {
+				values = append(values, matchExpression(matchType, match.path, value))
+			}
+			if len(values) > 0 {
+				all = append(all, "("+strings.Join(values, " or ")+")")
+			}
+		}
+		if len(all) == 0 {
+			continue
+		}
+		condition := strings.Join(all, " and ")
+		if key == "exclude" {
+			condition = "not (" + condition + ")"
+		}
+		conditions = append(conditions, condition)
+	}
+	return strings.Join(conditions, " and "), nil
+}
+
+func matchExpression(matchType, path, value string) string {
+	if matchType == "regexp" {
+		return fmt.Sprintf("IsMatch(%s, %q)", path, value)
+	}
+	return fmt.Sprintf("%s == %q", path, value)
+}
+
+// statementAction converts an OTTL statement to an attributes processor action
+func statementAction(raw, context string) (map[string]interface{}, error) {
+	unsupported := fmt.Errorf("statement %q has no attributes processor equivalent", raw)
+	statement, err := ottl.ParseStatement(raw)
+	if err != nil {
+		return nil, err
+	}
+	args := statement.Editor.Args
+
+	// The where clause may only check the existence of attributes
+	existing := make(map[string]bool)
+	for _, comparison := range conjunction(statement.Condition) {
+		c, ok := comparison.(*ottl.Comparison)
+		if !ok || !isNil(c.Right) || (c.Op != "==" && c.Op != "!=") {
+			return nil, unsupported
+		}
+		key, ok := attributeKey(c.Left, context)
+		if !ok {
+			return nil, unsupported
+		}
+		existing[key] = c.Op == "!="
+	}
+
+	switch statement.Editor.Name {
+	case "set":
+		key, ok := attributeKey(args[0], context)
+		if len(args) != 2 || !ok {
+			return nil, unsupported
+		}
+		if _, checked := existing[key]; len(existing) > 1 || (len(existing) == 1 && !checked) {
+			if _, isPath := args[1].(*ottl.Path); !isPath {
+				return nil, unsupported
+			}
+		}
+		action := map[string]interface{}{"key": key, "action": "upsert"}
+		if exists, checked := existing[key]; checked {
+			action["action"] = map[bool]string{true: "update", false: "insert"}[exists]
+		}
+		switch value := args[1].(type) {
+		case *ottl.Literal:
+			if value.Value == nil {
+				return nil, unsupported
+			}
+			action["value"] = value.Value
+		case *ottl.Path:
+			source, ok := attributeKey(value, context)
+			if !ok || source == key {
+				return nil, unsupported
+			}
+			action["from_attribute"] = source
+		case *ottl.Call:
+			if len(value.Args) != 1 || len(existing) != 1 || !existing[key] {
+				return nil, unsupported
+			}
+			if source, ok := attributeKey(value.Args[0], context); !ok || source != key {
+				return nil, unsupported
+			}
+			if value.Name == "SHA256" || value.Name == "SHA1" {
+				action["action"] = "hash"
+				return action, nil
+			}
+			for convertedType, function := range convertFunctions {
+				if value.Name == function {
+					action["action"] = "convert"
+					action["converted_type"] = convertedType
+					return action, nil
+				}
+			}
+			return nil, unsupported
+		default:
+			return nil, unsupported
+		}
+		return action, nil
+	case "delete_key", "delete_matching_keys":
+		if len(args) != 2 || len(existing) > 0 || !isAttributesMap(args[0], context) {
+			return nil, unsupported
+		}
+		value, ok := stringLiteral(args[1])
+		if !ok {
+			return nil, unsupported
+		}
+		if statement.Editor.Name == "delete_key" {
+			return map[string]interface{}{"key": value, "action": "delete"}, nil
+		}
+		return map[string]interface{}{"pattern": value, "action": "delete"}, nil
+	case "merge_maps":
+		if len(args) != 3 || !isAttributesMap(args[0], context) {
+			return nil, unsupported
+		}
+		extract, ok := args[1].(*ottl.Call)
+		if strategy, _ := stringLiteral(args[2]); !ok || extract.Name != "ExtractPatterns" || len(extract.Args) != 2 || strategy != "upsert" {
+			return nil, unsupported
+		}
+		key, isAttribute := attributeKey(extract.Args[0], context)
+		pattern, isPattern := stringLiteral(extract.Args[1])
+		if _, checked := existing[key]; !isAttribute || !isPattern || len(existing) > 1 || (len(existing) == 1 && !checked) {
+			return nil, unsupported
+		}
+		return map[string]interface{}{"key": key, "pattern": pattern, "action": "extract"}, nil
+	}
+	return nil, unsupported
+}
+
+// attributeKey returns the key of an attributes["key"] path of the context
+func attributeKey(node ottl.Node, context string) (string, bool) {
+	path, ok := node.(*ottl.Path)
+	if !ok || len(path.Keys) != 1 || path.Field() != "attributes" || (path.Context() != "" && path.Context() != context) {
+		return "", false
+	}
+	return stringLiteral(path.Keys[0])
+}
+
+// isAttributesMap returns true for the attributes map of the context
+func isAttributesMap(node ottl.Node, context string) bool {
+	path, ok := node.(*ottl.Path)
+	return ok && len(path.Keys) == 0 && path.Field() == "attributes" && (path.Context() == "" || path.Context() == context)
+}
+
+// conjunction returns the expressions of a condition joined with and
+func conjunction(node ottl.Node) []ottl.Node {
+	if node == nil {
+		return nil
+	}
+	if binary, ok := node.(*ottl.Binary); ok && binary.Op == "and" {
+		return append(conjunction(binary.Left), conjunction(binary.Right)...)
+	}
+	return []ottl.Node{node}
+}
+
+// stringLiteral returns the value of a string literal
+func stringLiteral(node ottl.Node) (string, bool) {
+	literal, ok := node.(*ottl.Literal)
+	if !ok {
+		return "", false
+	}
+	value, ok := literal.Value.(string)
+	return value, ok
+}
+
+func isNil(node ottl.Node) bool {
+	literal, ok := node.(*ottl.Literal)
+	return ok && literal.Value == nil
+}
+
+// literalValue returns the OTTL literal of an action value
+func literalValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v), nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", fmt.Errorf("value or from_attribute must be set")
+	default:
+		return "", fmt.Errorf("value %v cannot be converted, only string, number and bool values are supported", v)
+	}
+}
+
+// statement returns the statement with the conditions in the where clause
+func statement(editor string, conditions ...string) string {
+	var nonEmpty []string
+	for _, condition := range conditions {
+		if condition != "" {
+			nonEmpty = append(nonEmpty, condition)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return editor
+	}
+	return editor + " where " + strings.Join(nonEmpty, " and ")
+}
+
+// withCondition adds the condition to the where clause of the statements
+func withCondition(statements []string, condition string) []string {
+	if condition == "" {
+		return statements
+	}
+	result := make([]string, 0, len(statements))
+	for _, s := range statements {
+		editor, where, hasWhere := strings.Cut(s, " where ")
+		if hasWhere {
+			result = append(result, statement(editor, where, condition))
+		} else {
+			result = append(result, statement(editor, condition))
+		}
+	}
+	return result
+}
+
+func hasAction(actions []interface{}, name string) bool {
+	for _, item := range actions {
+		if action, _ := item.(map[string]interface{}); action["action"] == name {
+			return true
+		}
+	}
+	return false
+}
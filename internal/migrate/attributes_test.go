@@ -0,0 +1,157 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const attributesConfig = `
+receivers:
+  otlp:
+processors:
+  batch:
+  attributes/pii:
+    include:
+      match_type: strict
+      services: [checkout]
+    actions:
+      - key: user.email
+        action: delete
+      - key: env
+        value: prod
+        action: insert
+      - key: http.route
+        from_attribute: http.target
+        action: upsert
+      - key: user.id
+        action: hash
+      - key: http.status_code
+        action: convert
+        converted_type: int
+  resource:
+    attributes:
+      - key: deployment.environment
+        value: prod
+        action: upsert
+exporters:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [resource, attributes/pii, batch]
+      exporters: [otlp]
+    logs:
+      receivers: [otlp]
+      processors: [resource]
+      exporters: [otlp]
+`
+
+func TestAttributesToTransform(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(attributesConfig))
+	require.NoError(t, err)
+
+	result, err := AttributesToTransform(config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"attributes/pii": {"transform/attributes_pii"},
+		"resource":       {"transform/resource"},
+	}, result.Replaced)
+	assert.Equal(t, []string{"transform/resource", "transform/attributes_pii", "batch"}, result.Config.Service.Pipelines["traces"].Processors)
+	assert.NotContains(t, result.Config.Processors, "attributes/pii")
+
+	transform := result.Config.Processors["transform/attributes_pii"].(map[string]interface{})
+	assert.Equal(t, "ignore", transform["error_mode"])
+	assert.NotContains(t, transform, "log_statements")
+	group := transform["trace_statements"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "span", group["context"])
+	service := `resource.attributes["service.name"] == "checkout"`
+	assert.Equal(t, []string{
+		`delete_key(attributes, "user.email") where (` + service + `)`,
+		`set(attributes["env"], "prod") where attributes["env"] == nil and (` + service + `)`,
+		`set(attributes["http.route"], attributes["http.target"]) where attributes["http.target"] != nil and (` + service + `)`,
+		`set(attributes["user.id"], SHA256(attributes["user.id"])) where attributes["user.id"] != nil and (` + service + `)`,
+		`set(attributes["http.status_code"], Int(attributes["http.status_code"])) where attributes["http.status_code"] != nil and (` + service + `)`,
+	}, group["statements"])
+
+	resource := result.Config.Processors["transform/resource"].(map[string]interface{})
+	for _, key := range []string{"trace_statements", "log_statements"} {
+		group := resource[key].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "resource", group["context"])
+		assert.Equal(t, []string{`set(attributes["deployment.environment"], "prod")`}, group["statements"])
+	}
+}
+
+func TestAttributesToTransform_Unsupported(t *testing.T) {
+	for _, processor := range []map[string]interface{}{
+		{"actions": []interface{}{map[string]interface{}{"key": "tenant", "from_context": "metadata.tenant", "action": "insert"}}},
+		{"actions": []interface{}{map[string]interface{}{"pattern": "^user", "action": "hash"}}},
+		{"actions": []interface{}{map[string]interface{}{"key": "a", "value": "b", "action": "rename"}}},
+		{"actions": []interface{}{}},
+		{
+			"include": map[string]interface{}{"match_type": "strict", "libraries": []interface{}{map[string]interface{}{"name": "a"}}},
+			"actions": []interface{}{map[string]interface{}{"key": "a", "action": "delete"}},
+		},
+	} {
+		config := collectorconfig.NewConfig()
+		config.Processors["attributes"] = processor
+		_, err := AttributesToTransform(config)
+		assert.Error(t, err, "%v", processor)
+	}
+}
+
+func TestTransformToAttributes(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(attributesConfig))
+	require.NoError(t, err)
+	delete(config.Processors, "resource")
+	config.Service.Pipelines["traces"].Processors = []string{"attributes/pii"}
+	delete(config.Service.Pipelines, "logs")
+	delete(config.Processors["attributes/pii"].(map[string]interface{}), "include")
+
+	transformed, err := AttributesToTransform(config)
+	require.NoError(t, err)
+	result, err := TransformToAttributes(transformed.Config)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{"transform/attributes_pii": {"attributes/pii"}}, result.Replaced)
+	assert.Equal(t, config.Processors["attributes/pii"], result.Config.Processors["attributes/pii"])
+	assert.Equal(t, []string{"attributes/pii"}, result.Config.Service.Pipelines["traces"].Processors)
+}
+
+func TestTransformToAttributes_Resource(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(attributesConfig))
+	require.NoError(t, err)
+	delete(config.Processors, "attributes/pii")
+	config.Service.Pipelines["traces"].Processors = []string{"resource"}
+
+	transformed, err := AttributesToTransform(config)
+	require.NoError(t, err)
+	result, err := TransformToAttributes(transformed.Config)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{"transform/resource": {"resource"}}, result.Replaced)
+	assert.Equal(t, config.Processors["resource"], result.Config.Processors["resource"])
+	assert.Equal(t, []string{"resource"}, result.Config.Service.Pipelines["logs"].Processors)
+}
+
+func TestTransformToAttributes_Unsupported(t *testing.T) {
+	for _, statements := range []map[string]interface{}{
+		{"trace_statements": []interface{}{"set(span.attributes[\"a\"], \"b\")"}},
+		{"trace_statements": []interface{}{map[string]interface{}{"context": "span", "statements": []interface{}{`set(name, "a")`}}}},
+		{"trace_statements": []interface{}{map[string]interface{}{"context": "span", "statements": []interface{}{`set(attributes["a"], "b") where name == "c"`}}}},
+		{"trace_statements": []interface{}{map[string]interface{}{"context": "spanevent", "statements": []interface{}{`delete_key(attributes, "a")`}}}},
+		{
+			"trace_statements": []interface{}{map[string]interface{}{"context": "span", "statements": []interface{}{`delete_key(attributes, "a")`}}},
+			"log_statements":   []interface{}{map[string]interface{}{"context": "log", "statements": []interface{}{`delete_key(attributes, "b")`}}},
+		},
+	} {
+		config := collectorconfig.NewConfig()
+		config.Processors["transform"] = statements
+		_, err := TransformToAttributes(config)
+		assert.Error(t, err, "%v", statements)
+	}
+}
@@ -0,0 +1,227 @@
+package migrate
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// ComponentMigration describes the replacement of a deprecated or removed component
+type ComponentMigration struct {
+	ID          string `json:"id"`
+	Replacement string `json:"replacement"`
+	// RemovedIn is the collector version that no longer contains the component, empty if it is only deprecated
+	RemovedIn string `json:"removedIn,omitempty"`
+	// Removed is true when the component is not available in the target version
+	Removed bool     `json:"removed"`
+	Changes []string `json:"changes"`
+}
+
+// DeprecatedMigrationResult is the configuration with the deprecated components replaced
+type DeprecatedMigrationResult struct {
+	Config     *collectorconfig.Config
+	Migrations []ComponentMigration
+}
+
+// deprecatedComponent is a deprecated or removed component and its supported equivalent
+type deprecatedComponent struct {
+	replacement string
+	removedIn   string
+	// convert returns the settings of the replacement and the changes required outside the collector
+	convert func(settings map[string]interface{}) (map[string]interface{}, []string)
+}
+
+// sharedExporterSettings are the exporter helper and client settings kept by the replacement exporters
+var sharedExporterSettings = []string{"tls", "headers", "timeout", "retry_on_failure", "sending_queue", "compression", "auth", "keepalive", "balancer_name", "write_buffer_size", "read_buffer_size"}
+
+var deprecatedExporters = map[string]deprecatedComponent{
+	"jaeger": {
+		replacement: "otlp",
+		removedIn:   "0.85.0",
+		convert: func(settings map[string]interface{}) (map[string]interface{}, []string) {
+			converted := keep(settings, sharedExporterSettings)
+			endpoint, changes := replacePort(settings["endpoint"], "4317", "Jaeger collector gRPC")
+			converted["endpoint"] = endpoint
+			return converted, append(changes,
+				"Jaeger accepts OTLP since v1.35, make sure the OTLP receiver of the Jaeger collector is enabled (COLLECTOR_OTLP_ENABLED=true on releases where it is not on by default)",
+				"expose the Jaeger collector OTLP gRPC port 4317 in the Kubernetes service, load balancer and firewall rules instead of 14250",
+			)
+		},
+	},
+	"jaeger_thrift": {
+		replacement: "otlphttp",
+		removedIn:   "0.85.0",
+		convert: func(settings map[string]interface{}) (map[string]interface{}, []string) {
+			converted := keep(settings, sharedExporterSettings)
+			endpoint, changes := replacePort(settings["endpoint"], "4318", "Jaeger collector Thrift HTTP")
+			converted["endpoint"] = endpoint
+			return converted, append(changes,
+				"Jaeger accepts OTLP since v1.35, make sure the OTLP receiver of the Jaeger collector is enabled (COLLECTOR_OTLP_ENABLED=true on releases where it is not on by default)",
+				"expose the Jaeger collector OTLP HTTP port 4318 instead of 14268, the otlphttp exporter appends /v1/traces to the endpoint",
+			)
+		},
+	},
+	"logging": {
+		replacement: "debug",
+		removedIn:   "0.111.0",
+		convert: func(settings map[string]interface{}) (map[string]interface{}, []string) {
+			converted := keep(settings, []string{"verbosity", "sampling_initial", "sampling_thereafter"})
+			var changes []string
+			if level, ok := settings["loglevel"].(string); ok {
+				verbosity := map[string]string{"debug": "detailed", "info": "normal", "warn": "basic", "error": "basic"}[strings.ToLower(level)]
+				if verbosity == "" {
+					verbosity = "normal"
+				}
+				converted["verbosity"] = verbosity
+				changes = append(changes, fmt.Sprintf("loglevel %s is replaced by verbosity %s", level, verbosity))
+			}
+			return converted, changes
+		},
+	},
+	"opencensus": {
+		replacement: "otlp",
+		convert: func(settings map[string]interface{}) (map[string]interface{}, []string) {
+			converted := keep(settings, sharedExporterSettings)
+			endpoint, changes := replacePort(settings["endpoint"], "4317", "OpenCensus")
+			converted["endpoint"] = endpoint
+			return converted, append(changes, "the backend must accept OTLP gRPC, OpenCensus agents and collectors do not")
+		},
+	},
+}
+
+var deprecatedReceivers = map[string]deprecatedComponent{
+	"opencensus": {
+		replacement: "otlp",
+		convert: func(settings map[string]interface{}) (map[string]interface{}, []string) {
+			grpc := keep(settings, []string{"tls", "keepalive", "max_recv_msg_size_mib", "max_concurrent_streams", "include_metadata", "auth"})
+			grpc["endpoint"] = "0.0.0.0:4317"
+			if endpoint, ok := settings["endpoint"].(string); ok {
+				if host, _, err := net.SplitHostPort(endpoint); err == nil {
+					grpc["endpoint"] = net.JoinHostPort(host, "4317")
+				}
+			}
+			return map[string]interface{}{"protocols": map[string]interface{}{"grpc": grpc}}, []string{
+				"clients sending OpenCensus have to switch to OTLP exporters, the OpenCensus SDKs are not maintained",
+				fmt.Sprintf("clients connect to %s instead of the OpenCensus port 55678, update the Kubernetes service and firewall rules", grpc["endpoint"]),
+			}
+		},
+	},
+}
+
+// MigrateDeprecated replaces the deprecated and removed receivers and exporters of the configuration with their
+// supported equivalents and explains the changes required outside the collector
+func MigrateDeprecated(config *collectorconfig.Config, version string) (*DeprecatedMigrationResult, error) {
+	migrated, err := config.Clone()
+	if err != nil {
+		return nil, err
+	}
+	result := &DeprecatedMigrationResult{Config: migrated, Migrations: []ComponentMigration{}}
+	for _, section := range []struct {
+		name       string
+		components map[string]interface{}
+		deprecated map[string]deprecatedComponent
+	}{
+		{"receivers", migrated.Receivers, deprecatedReceivers},
+		{"exporters", migrated.Exporters, deprecatedExporters},
+	} {
+		for _, id := range slices.Sorted(maps.Keys(section.components)) {
+			deprecated, ok := section.deprecated[collectorconfig.ComponentType(id)]
+			if !ok {
+				continue
+			}
+			replacementID := deprecated.replacement + "/" + strings.ReplaceAll(id, "/", "_")
+			if _, exists := section.components[replacementID]; exists {
+				return nil, fmt.Errorf("%s %s cannot be replaced, %s already exists", section.name, id, replacementID)
+			}
+			settings, _ := section.components[id].(map[string]interface{})
+			converted, changes := deprecated.convert(settings)
+			for _, key := range slices.Sorted(maps.Keys(settings)) {
+				if key != "endpoint" && key != "loglevel" && !hasSetting(converted, key) {
+					changes = append(changes, fmt.Sprintf("setting %s is not supported by %s, it is dropped", key, deprecated.replacement))
+				}
+			}
+			delete(section.components, id)
+			section.components[replacementID] = converted
+			for _, pipeline := range migrated.Service.Pipelines {
+				pipeline.Receivers = replaceID(pipeline.Receivers, id, replacementID)
+				pipeline.Exporters = replaceID(pipeline.Exporters, id, replacementID)
+			}
+
+			migration := ComponentMigration{
+				ID:          section.name + "::" + id,
+				Replacement: section.name + "::" + replacementID,
+				RemovedIn:   deprecated.removedIn,
+				Removed:     deprecated.removedIn != "" && (version == "" || collectorschema.CompareVersions(version, deprecated.removedIn) >= 0),
+				Changes:     changes,
+			}
+			if migration.Changes == nil {
+				migration.Changes = []string{}
+			}
+			result.Migrations = append(result.Migrations, migration)
+		}
+	}
+	return result, nil
+}
+
+// replacePort replaces the port of a host:port or URL endpoint and explains the backend change
+func replacePort(value interface{}, port, previous string) (string, []string) {
+	endpoint, _ := value.(string)
+	if endpoint == "" {
+		return "<backend>:" + port, []string{fmt.Sprintf("the endpoint is not set, set it to the backend OTLP port %s", port)}
+	}
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		previousPort := u.Port()
+		u.Host = net.JoinHostPort(u.Hostname(), port)
+		u.Path = ""
+		return u.String(), []string{fmt.Sprintf("endpoint %s is replaced by %s, the %s port %s is not used", endpoint, u.String(), previous, previousPort)}
+	}
+	host, previousPort, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint, []string{fmt.Sprintf("endpoint %s cannot be parsed, set it to the backend OTLP port %s", endpoint, port)}
+	}
+	replaced := net.JoinHostPort(host, port)
+	return replaced, []string{fmt.Sprintf("endpoint %s is replaced by %s, the %s port %s is not used", endpoint, replaced, previous, previousPort)}
+}
+
+// keep returns the settings with the keys
+func keep(settings map[string]interface{}, keys []string) map[string]interface{} {
+	kept := make(map[string]interface{})
+	for _, key := range keys {
+		if value, ok := settings[key]; ok {
+			kept[key] = value
+		}
+	}
+	return kept
+}
+
+// hasSetting returns true if the settings or the OTLP receiver protocols contain the key
+func hasSetting(settings map[string]interface{}, key string) bool {
+	if _, ok := settings[key]; ok {
+		return true
+	}
+	protocols, _ := settings["protocols"].(map[string]interface{})
+	for _, protocol := range protocols {
+		if protocolSettings, ok := protocol.(map[string]interface{}); ok {
+			if _, ok := protocolSettings[key]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func replaceID(ids []string, id, replacement string) []string {
+	for i, v := range ids {
+		if v == id {
+			ids[i] = replacement
+		}
+	}
+	return ids
+}
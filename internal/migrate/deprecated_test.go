@@ -0,0 +1,83 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+const deprecatedConfig = `
+receivers:
+  opencensus:
+    endpoint: 0.0.0.0:55678
+exporters:
+  jaeger:
+    endpoint: jaeger-collector:14250
+    tls:
+      insecure: true
+    sending_queue:
+      enabled: true
+  jaeger_thrift:
+    endpoint: http://jaeger-collector:14268/api/traces
+    password: secret
+  logging:
+    loglevel: debug
+service:
+  pipelines:
+    traces:
+      receivers: [opencensus]
+      exporters: [jaeger, jaeger_thrift, logging]
+`
+
+func TestMigrateDeprecated(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(deprecatedConfig))
+	require.NoError(t, err)
+
+	result, err := MigrateDeprecated(config, "0.100.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"otlp/opencensus"}, result.Config.Service.Pipelines["traces"].Receivers)
+	assert.Equal(t, []string{"otlp/jaeger", "otlphttp/jaeger_thrift", "debug/logging"}, result.Config.Service.Pipelines["traces"].Exporters[:3])
+
+	assert.Equal(t, map[string]interface{}{
+		"endpoint":      "jaeger-collector:4317",
+		"tls":           map[string]interface{}{"insecure": true},
+		"sending_queue": map[string]interface{}{"enabled": true},
+	}, result.Config.Exporters["otlp/jaeger"])
+	assert.Equal(t, map[string]interface{}{"endpoint": "http://jaeger-collector:4318"}, result.Config.Exporters["otlphttp/jaeger_thrift"])
+	assert.Equal(t, map[string]interface{}{"verbosity": "detailed"}, result.Config.Exporters["debug/logging"])
+	assert.Equal(t, map[string]interface{}{
+		"protocols": map[string]interface{}{"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"}},
+	}, result.Config.Receivers["otlp/opencensus"])
+
+	require.Len(t, result.Migrations, 4)
+	receiver := result.Migrations[0]
+	assert.Equal(t, "receivers::opencensus", receiver.ID)
+	assert.False(t, receiver.Removed)
+	jaeger := result.Migrations[1]
+	assert.Equal(t, "exporters::otlp/jaeger", jaeger.Replacement)
+	assert.True(t, jaeger.Removed)
+	assert.Contains(t, jaeger.Changes[0], "endpoint jaeger-collector:14250 is replaced by jaeger-collector:4317")
+	thrift := result.Migrations[2]
+	assert.Contains(t, thrift.Changes, "setting password is not supported by otlphttp, it is dropped")
+	logging := result.Migrations[3]
+	assert.Equal(t, "0.111.0", logging.RemovedIn)
+	assert.False(t, logging.Removed)
+}
+
+func TestMigrateDeprecated_NoDeprecatedComponents(t *testing.T) {
+	config, err := collectorconfig.Parse([]byte(`
+receivers:
+  jaeger:
+exporters:
+  otlp:
+    endpoint: jaeger:4317
+`))
+	require.NoError(t, err)
+	result, err := MigrateDeprecated(config, "0.139.0")
+	require.NoError(t, err)
+	assert.Empty(t, result.Migrations)
+	assert.Equal(t, config.Exporters, result.Config.Exporters)
+}
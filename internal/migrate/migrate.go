@@ -0,0 +1,51 @@
+// Package migrate converts collector configurations between equivalent components
+package migrate
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// pipelineSignals returns the sorted signals of the pipelines using the component
+func pipelineSignals(config *collectorconfig.Config, id string) []string {
+	var signals []string
+	for pipelineID, pipeline := range config.Service.Pipelines {
+		signal := collectorconfig.Signal(pipelineID)
+		uses := slices.Contains(pipeline.Receivers, id) || slices.Contains(pipeline.Processors, id) || slices.Contains(pipeline.Exporters, id)
+		if uses && !slices.Contains(signals, signal) {
+			signals = append(signals, signal)
+		}
+	}
+	sort.Strings(signals)
+	return signals
+}
+
+// replaceProcessor replaces the processor in the configuration and its pipelines
+func replaceProcessor(config *collectorconfig.Config, id string, replacements []string) {
+	delete(config.Processors, id)
+	for _, pipeline := range config.Service.Pipelines {
+		var processors []string
+		for _, processor := range pipeline.Processors {
+			if processor == id {
+				processors = append(processors, replacements...)
+			} else {
+				processors = append(processors, processor)
+			}
+		}
+		pipeline.Processors = processors
+	}
+}
+
+// stringList returns the strings of a YAML list
+func stringList(value interface{}) []string {
+	var result []string
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
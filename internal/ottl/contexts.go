@@ -0,0 +1,157 @@
+package ottl
+
+import (
+	"fmt"
+	"slices"
+	"unicode"
+)
+
+// InferredContextSince is the first collector version resolving statements without an explicit context
+// from their prefixed paths e.g. set(span.attributes["k"], "v")
+const InferredContextSince = "0.120.0"
+
+// ContextMetadata describes the fields of an OTTL context and the other contexts reachable from it
+type ContextMetadata struct {
+	Fields []string
+	// Reachable are the contexts whose paths can be used e.g. resource and metric from datapoint
+	Reachable []string
+}
+
+// contextMetadata is the OTTL context metadata, scope and instrumentation_scope are aliases
+var contextMetadata = map[string]ContextMetadata{
+	"resource": {
+		Fields:    []string{"attributes", "dropped_attributes_count", "schema_url", "cache"},
+		Reachable: []string{"resource"},
+	},
+	"scope": {
+		Fields:    []string{"name", "version", "attributes", "dropped_attributes_count", "schema_url", "cache"},
+		Reachable: []string{"resource", "scope"},
+	},
+	"span": {
+		Fields: []string{
+			"trace_id", "span_id", "parent_span_id", "trace_state", "name", "kind", "start_time_unix_nano", "end_time_unix_nano",
+			"start_time", "end_time", "attributes", "dropped_attributes_count", "events", "dropped_events_count", "links",
+			"dropped_links_count", "status", "flags", "cache",
+		},
+		Reachable: []string{"resource", "scope", "span"},
+	},
+	"spanevent": {
+		Fields:    []string{"time_unix_nano", "time", "name", "attributes", "dropped_attributes_count", "event_index", "cache"},
+		Reachable: []string{"resource", "scope", "span", "spanevent"},
+	},
+	"metric": {
+		Fields:    []string{"name", "description", "unit", "type", "aggregation_temporality", "is_monotonic", "data_points", "metadata", "cache"},
+		Reachable: []string{"resource", "scope", "metric"},
+	},
+	"datapoint": {
+		Fields: []string{
+			"attributes", "start_time_unix_nano", "time_unix_nano", "start_time", "time", "value_double", "value_int", "exemplars",
+			"flags", "count", "sum", "min", "max", "bucket_counts", "explicit_bounds", "scale", "zero_count", "positive", "negative",
+			"quantile_values", "cache",
+		},
+		Reachable: []string{"resource", "scope", "metric", "datapoint"},
+	},
+	"log": {
+		Fields: []string{
+			"time_unix_nano", "observed_time_unix_nano", "time", "observed_time", "severity_number", "severity_text", "body",
+			"attributes", "dropped_attributes_count", "flags", "trace_id", "span_id", "event_name", "cache",
+		},
+		Reachable: []string{"resource", "scope", "log"},
+	},
+}
+
+// contextHints explain the usual fix when a field is used in the wrong context
+var contextHints = map[string]string{
+	"metric.attributes":  "metrics have no attributes, use the datapoint context for datapoint attributes",
+	"resource.name":      "resources have no name, use resource.attributes[\"service.name\"]",
+	"span.severity_text": "spans have no severity, use status.code",
+	"log.name":           "logs have no name, use body or event_name",
+	"datapoint.name":     "use metric.name to match the metric of the datapoint",
+	"spanevent.kind":     "use span.kind to match the span of the event",
+}
+
+// GetContextMetadata returns the metadata of the OTTL context
+func GetContextMetadata(context string) (ContextMetadata, bool) {
+	metadata, ok := contextMetadata[normalizeContext(context)]
+	return metadata, ok
+}
+
+// ValidatePaths checks that the paths of the node are fields of the context or of a context reachable from it
+func ValidatePaths(node Node, context string) []string {
+	metadata, ok := GetContextMetadata(context)
+	if !ok {
+		return []string{fmt.Sprintf("unknown OTTL context %q", context)}
+	}
+	context = normalizeContext(context)
+
+	var problems []string
+	for _, path := range Paths(node) {
+		if isEnum(path) {
+			continue
+		}
+		pathContext := normalizeContext(path.Context())
+		field := path.Segments[0]
+		if pathContext == "" {
+			pathContext = context
+		} else {
+			if !slices.Contains(metadata.Reachable, pathContext) {
+				problems = append(problems, fmt.Sprintf("path %s cannot be used in the %s context, %s is not reachable from %s, use the %s context", path, context, pathContext, context, pathContext))
+				continue
+			}
+			if len(path.Segments) < 2 {
+				continue
+			}
+			field = path.Segments[1]
+		}
+		if !slices.Contains(contextMetadata[pathContext].Fields, field) {
+			problem := fmt.Sprintf("path %s: %s is not a field of the %s context", path, field, pathContext)
+			if hint := contextHints[pathContext+"."+field]; hint != "" {
+				problem += ", " + hint
+			}
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// InferContext returns the most specific context of the prefixed paths of the nodes e.g. datapoint for
+// datapoint.attributes and resource.attributes, or an empty string when no path is prefixed
+func InferContext(nodes ...Node) string {
+	inferred := ""
+	for _, node := range nodes {
+		for _, path := range Paths(node) {
+			pathContext := normalizeContext(path.Context())
+			if pathContext == "" {
+				continue
+			}
+			if inferred == "" || slices.Contains(contextMetadata[pathContext].Reachable, inferred) {
+				inferred = pathContext
+			}
+		}
+	}
+	return inferred
+}
+
+// normalizeContext maps context aliases to the context name used in the metadata
+func normalizeContext(context string) string {
+	switch context {
+	case "instrumentation_scope":
+		return "scope"
+	case "log_record":
+		return "log"
+	}
+	return context
+}
+
+// isEnum returns true for enum paths e.g. SPAN_KIND_SERVER or SEVERITY_NUMBER_ERROR
+func isEnum(path *Path) bool {
+	if len(path.Segments) != 1 || len(path.Keys) > 0 {
+		return false
+	}
+	for _, r := range path.Segments[0] {
+		if unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePaths(t *testing.T) {
+	tests := []struct {
+		condition string
+		context   string
+		problems  int
+		contains  string
+	}{
+		{condition: `attributes["http.route"] == "/"`, context: "datapoint"},
+		{condition: `resource.attributes["service.name"] == "a" and metric.name == "b"`, context: "datapoint"},
+		{condition: `kind == SPAN_KIND_SERVER`, context: "span"},
+		{condition: `severity_number >= SEVERITY_NUMBER_ERROR`, context: "log_record"},
+		{condition: `attributes["http.route"] == "/"`, context: "metric", problems: 1, contains: "use the datapoint context"},
+		{condition: `datapoint.attributes["a"] == "b"`, context: "metric", problems: 1, contains: "datapoint is not reachable from metric"},
+		{condition: `span.name == "a"`, context: "spanevent"},
+		{condition: `resource.name == "a"`, context: "span", problems: 1, contains: "service.name"},
+		{condition: `name == "a"`, context: "profile", problems: 1, contains: "unknown OTTL context"},
+	}
+	for _, test := range tests {
+		node, err := ParseCondition(test.condition)
+		require.NoError(t, err)
+		problems := ValidatePaths(node, test.context)
+		require.Len(t, problems, test.problems, test.condition)
+		if test.contains != "" {
+			assert.Contains(t, problems[0], test.contains)
+		}
+	}
+}
+
+func TestInferContext(t *testing.T) {
+	statement, err := ParseStatement(`set(datapoint.attributes["a"], resource.attributes["b"])`)
+	require.NoError(t, err)
+	condition, err := ParseCondition(`metric.name == "a"`)
+	require.NoError(t, err)
+	assert.Equal(t, "datapoint", InferContext(condition, statement))
+	assert.Equal(t, "metric", InferContext(condition))
+
+	unprefixed, err := ParseCondition(`name == "a"`)
+	require.NoError(t, err)
+	assert.Equal(t, "", InferContext(unprefixed))
+}
@@ -0,0 +1,227 @@
+package ottl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Resolver resolves a path to its current value, nil is returned when the path is not set
+type Resolver func(path *Path) (interface{}, error)
+
+// EvalCondition evaluates a boolean expression using the resolver for paths
+func EvalCondition(node Node, resolve Resolver) (bool, error) {
+	switch n := node.(type) {
+	case *Binary:
+		left, err := EvalCondition(n.Left, resolve)
+		if err != nil {
+			return false, err
+		}
+		if n.Op == "and" && !left {
+			return false, nil
+		}
+		if n.Op == "or" && left {
+			return true, nil
+		}
+		return EvalCondition(n.Right, resolve)
+	case *Not:
+		value, err := EvalCondition(n.Expr, resolve)
+		if err != nil {
+			return false, err
+		}
+		return !value, nil
+	case *Comparison:
+		left, err := EvalValue(n.Left, resolve)
+		if err != nil {
+			return false, err
+		}
+		right, err := EvalValue(n.Right, resolve)
+		if err != nil {
+			return false, err
+		}
+		return compare(n.Op, left, right)
+	default:
+		value, err := EvalValue(node, resolve)
+		if err != nil {
+			return false, err
+		}
+		b, ok := value.(bool)
+		if !ok {
+			return false, fmt.Errorf("expression %s is not a boolean", node)
+		}
+		return b, nil
+	}
+}
+
+// EvalValue evaluates a value expression using the resolver for paths
+func EvalValue(node Node, resolve Resolver) (interface{}, error) {
+	switch n := node.(type) {
+	case *Literal:
+		return n.Value, nil
+	case *Path:
+		return resolve(n)
+	case *List:
+		items := make([]interface{}, len(n.Items))
+		for i, item := range n.Items {
+			value, err := EvalValue(item, resolve)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = value
+		}
+		return items, nil
+	case *Call:
+		return evalConverter(n, resolve)
+	case *Comparison, *Binary, *Not:
+		return EvalCondition(n, resolve)
+	default:
+		return nil, fmt.Errorf("unsupported expression %s", node)
+	}
+}
+
+func evalConverter(call *Call, resolve Resolver) (interface{}, error) {
+	args := make([]interface{}, len(call.Args))
+	for i, arg := range call.Args {
+		value, err := EvalValue(arg, resolve)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+
+	switch call.Name {
+	case "IsMatch":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("IsMatch expects 2 arguments")
+		}
+		pattern, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("IsMatch pattern must be a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IsMatch pattern %q: %w", pattern, err)
+		}
+		target, ok := args[0].(string)
+		return ok && re.MatchString(target), nil
+	case "HasPrefix", "HasSuffix":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s expects 2 arguments", call.Name)
+		}
+		target, _ := args[0].(string)
+		affix, _ := args[1].(string)
+		if call.Name == "HasPrefix" {
+			return strings.HasPrefix(target, affix), nil
+		}
+		return strings.HasSuffix(target, affix), nil
+	case "Concat":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("Concat expects 2 arguments")
+		}
+		items, _ := args[0].([]interface{})
+		delimiter, _ := args[1].(string)
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = fmt.Sprintf("%v", item)
+		}
+		return strings.Join(parts, delimiter), nil
+	case "ConvertCase":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("ConvertCase expects 2 arguments")
+		}
+		target, _ := args[0].(string)
+		switch args[1] {
+		case "lower":
+			return strings.ToLower(target), nil
+		case "upper":
+			return strings.ToUpper(target), nil
+		case "snake":
+			return strings.ToLower(regexp.MustCompile(`([a-z0-9])([A-Z])`).ReplaceAllString(target, "${1}_${2}")), nil
+		default:
+			return target, nil
+		}
+	case "IsString":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("IsString expects 1 argument")
+		}
+		_, ok := args[0].(string)
+		return ok, nil
+	case "Len":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("Len expects 1 argument")
+		}
+		switch v := args[0].(type) {
+		case string:
+			return int64(len(v)), nil
+		case []interface{}:
+			return int64(len(v)), nil
+		case map[string]string:
+			return int64(len(v)), nil
+		}
+		return int64(0), nil
+	default:
+		return nil, fmt.Errorf("unsupported function %s", call.Name)
+	}
+}
+
+func compare(op string, left, right interface{}) (bool, error) {
+	// nil comparisons are used to check if a value is set
+	if left == nil || right == nil {
+		switch op {
+		case "==":
+			return left == nil && right == nil, nil
+		case "!=":
+			return !(left == nil && right == nil), nil
+		default:
+			return false, nil
+		}
+	}
+
+	if lf, lok := toFloat(left); lok {
+		if rf, rok := toFloat(right); rok {
+			switch op {
+			case "==":
+				return lf == rf, nil
+			case "!=":
+				return lf != rf, nil
+			case "<":
+				return lf < rf, nil
+			case "<=":
+				return lf <= rf, nil
+			case ">":
+				return lf > rf, nil
+			case ">=":
+				return lf >= rf, nil
+			}
+		}
+	}
+
+	ls, rs := fmt.Sprintf("%v", left), fmt.Sprintf("%v", right)
+	switch op {
+	case "==":
+		return ls == rs, nil
+	case "!=":
+		return ls != rs, nil
+	case "<":
+		return ls < rs, nil
+	case "<=":
+		return ls <= rs, nil
+	case ">":
+		return ls > rs, nil
+	case ">=":
+		return ls >= rs, nil
+	}
+	return false, fmt.Errorf("unsupported operator %s", op)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
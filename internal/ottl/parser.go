@@ -0,0 +1,565 @@
+package ottl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Node is an element of a parsed OTTL statement or condition
+type Node interface {
+	String() string
+}
+
+// Literal represents a string, int64, float64, bool or nil literal
+type Literal struct {
+	Value interface{}
+}
+
+// Path represents a telemetry path e.g. datapoint.attributes["http.method"]
+type Path struct {
+	Segments []string
+	Keys     []Node
+}
+
+// Call represents an editor (lowercase) or converter (uppercase) invocation
+type Call struct {
+	Name string
+	Args []Node
+	Keys []Node
+}
+
+// List represents a list literal e.g. ["a", "b"]
+type List struct {
+	Items []Node
+}
+
+// Comparison represents a comparison of two values
+type Comparison struct {
+	Op    string
+	Left  Node
+	Right Node
+}
+
+// Not represents a negated boolean expression
+type Not struct {
+	Expr Node
+}
+
+// Binary represents a logical "and" or "or" of two boolean expressions
+type Binary struct {
+	Op    string
+	Left  Node
+	Right Node
+}
+
+// Statement represents an OTTL statement: an editor invocation with an optional where clause
+type Statement struct {
+	Raw       string
+	Editor    *Call
+	Condition Node
+}
+
+// Contexts are the OTTL context names that can prefix a path
+var Contexts = []string{"resource", "scope", "instrumentation_scope", "span", "spanevent", "metric", "datapoint", "log", "profile"}
+
+func (l *Literal) String() string {
+	switch v := l.Value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func (p *Path) String() string {
+	var sb strings.Builder
+	sb.WriteString(strings.Join(p.Segments, "."))
+	for _, key := range p.Keys {
+		sb.WriteString("[" + key.String() + "]")
+	}
+	return sb.String()
+}
+
+// Context returns the explicit OTTL context of the path (e.g. "datapoint") or an empty string
+func (p *Path) Context() string {
+	if len(p.Segments) > 1 {
+		for _, ctx := range Contexts {
+			if p.Segments[0] == ctx {
+				return ctx
+			}
+		}
+	}
+	return ""
+}
+
+// Field returns the path without its context prefix e.g. "attributes" for datapoint.attributes
+func (p *Path) Field() string {
+	if p.Context() != "" {
+		return strings.Join(p.Segments[1:], ".")
+	}
+	return strings.Join(p.Segments, ".")
+}
+
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = arg.String()
+	}
+	var sb strings.Builder
+	sb.WriteString(c.Name + "(" + strings.Join(args, ", ") + ")")
+	for _, key := range c.Keys {
+		sb.WriteString("[" + key.String() + "]")
+	}
+	return sb.String()
+}
+
+// IsEditor returns true if the call is an editor (functions starting with a lowercase letter)
+func (c *Call) IsEditor() bool {
+	return c.Name != "" && unicode.IsLower(rune(c.Name[0]))
+}
+
+func (l *List) String() string {
+	items := make([]string, len(l.Items))
+	for i, item := range l.Items {
+		items[i] = item.String()
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+func (c *Comparison) String() string {
+	return c.Left.String() + " " + c.Op + " " + c.Right.String()
+}
+
+func (n *Not) String() string {
+	return "not " + n.Expr.String()
+}
+
+func (b *Binary) String() string {
+	return "(" + b.Left.String() + " " + b.Op + " " + b.Right.String() + ")"
+}
+
+func (s *Statement) String() string {
+	if s.Condition == nil {
+		return s.Editor.String()
+	}
+	return s.Editor.String() + " where " + s.Condition.String()
+}
+
+// ParseStatement parses an OTTL statement e.g. set(metric.name, "new") where metric.name == "old"
+func ParseStatement(input string) (*Statement, error) {
+	p, err := newParser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	editor, ok := value.(*Call)
+	if !ok || !editor.IsEditor() {
+		return nil, fmt.Errorf("statement %q must start with an editor function e.g. set(...)", input)
+	}
+
+	statement := &Statement{Raw: input, Editor: editor}
+	if p.peekKeyword("where") {
+		p.next()
+		condition, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		statement.Condition = condition
+	}
+
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d in %q", p.peek().text, p.peek().pos, input)
+	}
+	return statement, nil
+}
+
+// ParseCondition parses an OTTL boolean expression e.g. metric.name == "x" and IsMatch(...)
+func ParseCondition(input string) (Node, error) {
+	p, err := newParser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d in %q", p.peek().text, p.peek().pos, input)
+	}
+	return condition, nil
+}
+
+// Walk calls fn for the node and all of its descendants
+func Walk(node Node, fn func(Node)) {
+	if node == nil {
+		return
+	}
+	fn(node)
+	switch n := node.(type) {
+	case *Path:
+		for _, key := range n.Keys {
+			Walk(key, fn)
+		}
+	case *Call:
+		for _, arg := range n.Args {
+			Walk(arg, fn)
+		}
+		for _, key := range n.Keys {
+			Walk(key, fn)
+		}
+	case *List:
+		for _, item := range n.Items {
+			Walk(item, fn)
+		}
+	case *Comparison:
+		Walk(n.Left, fn)
+		Walk(n.Right, fn)
+	case *Not:
+		Walk(n.Expr, fn)
+	case *Binary:
+		Walk(n.Left, fn)
+		Walk(n.Right, fn)
+	case *Statement:
+		Walk(n.Editor, fn)
+		Walk(n.Condition, fn)
+	}
+}
+
+// Paths returns all paths referenced by the node
+func Paths(node Node) []*Path {
+	var paths []*Path
+	Walk(node, func(n Node) {
+		if path, ok := n.(*Path); ok {
+			paths = append(paths, path)
+		}
+	})
+	return paths
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenPunct
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func newParser(input string) (*parser, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start})
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case strings.ContainsRune("=!<>", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "!" {
+				return nil, fmt.Errorf("invalid operator %q at position %d", op, start)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start})
+		case strings.ContainsRune("()[],.{}:", r):
+			tokens = append(tokens, token{kind: tokenPunct, text: string(r), pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+	return tokens, nil
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokenPunct, text: "<end>", pos: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && t.text == keyword
+}
+
+func (p *parser) peekPunct(punct string) bool {
+	t := p.peek()
+	return t.kind == tokenPunct && t.text == punct
+}
+
+func (p *parser) expectPunct(punct string) error {
+	t := p.next()
+	if t.kind != tokenPunct || t.text != punct {
+		return fmt.Errorf("expected %q but found %q at position %d", punct, t.text, t.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Node, error) {
+	if p.peekKeyword("not") {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr}, nil
+	}
+	if p.peekPunct("(") {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	left, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind == tokenOperator {
+		op := p.next().text
+		if op == "=" {
+			return nil, fmt.Errorf("invalid operator \"=\", use \"==\" for comparisons")
+		}
+		right, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return &Comparison{Op: op, Left: left, Right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseValue() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return &Literal{Value: t.text}, nil
+	case tokenNumber:
+		if strings.Contains(t.text, ".") {
+			f, err := strconv.ParseFloat(t.text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+			}
+			return &Literal{Value: f}, nil
+		}
+		i, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &Literal{Value: i}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &Literal{Value: true}, nil
+		case "false":
+			return &Literal{Value: false}, nil
+		case "nil":
+			return &Literal{Value: nil}, nil
+		}
+		if p.peekPunct("(") {
+			return p.parseCall(t.text)
+		}
+		return p.parsePath(t.text)
+	case tokenPunct:
+		if t.text == "[" {
+			return p.parseList()
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+func (p *parser) parseCall(name string) (Node, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	call := &Call{Name: name}
+	for !p.peekPunct(")") {
+		// Named arguments e.g. replace_pattern(..., function = SHA256) are parsed by value
+		if p.peek().kind == tokenIdent && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text == "=" {
+			p.pos += 2
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+		if !p.peekPunct(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	keys, err := p.parseKeys()
+	if err != nil {
+		return nil, err
+	}
+	call.Keys = keys
+	return call, nil
+}
+
+func (p *parser) parsePath(first string) (Node, error) {
+	path := &Path{Segments: []string{first}}
+	for p.peekPunct(".") {
+		p.next()
+		t := p.next()
+		if t.kind != tokenIdent {
+			return nil, fmt.Errorf("expected path segment but found %q at position %d", t.text, t.pos)
+		}
+		path.Segments = append(path.Segments, t.text)
+	}
+	keys, err := p.parseKeys()
+	if err != nil {
+		return nil, err
+	}
+	path.Keys = keys
+	return path, nil
+}
+
+func (p *parser) parseKeys() ([]Node, error) {
+	var keys []Node
+	for p.peekPunct("[") {
+		p.next()
+		key, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (p *parser) parseList() (Node, error) {
+	list := &List{}
+	for !p.peekPunct("]") {
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+		if !p.peekPunct(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expectPunct("]"); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
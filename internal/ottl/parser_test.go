@@ -0,0 +1,75 @@
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatement(t *testing.T) {
+	statement, err := ParseStatement(`set(metric.name, "http.duration") where metric.name == "http.server.duration" and not IsMatch(datapoint.attributes["http.route"], "^/health")`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "set", statement.Editor.Name)
+	assert.True(t, statement.Editor.IsEditor())
+	require.Len(t, statement.Editor.Args, 2)
+	assert.Equal(t, "metric.name", statement.Editor.Args[0].String())
+
+	paths := Paths(statement)
+	require.Len(t, paths, 3)
+	assert.Equal(t, "metric", paths[0].Context())
+	assert.Equal(t, "datapoint", paths[2].Context())
+	assert.Equal(t, "attributes", paths[2].Field())
+}
+
+func TestParseStatement_Invalid(t *testing.T) {
+	tests := []string{
+		``,
+		`IsMatch(name, "foo")`,
+		`set(metric.name, "foo"`,
+		`set(metric.name, "foo") where name = "bar"`,
+		`set(metric.name, "foo) where`,
+	}
+	for _, input := range tests {
+		_, err := ParseStatement(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestEvalCondition(t *testing.T) {
+	attributes := map[string]string{"http.method": "GET", "status": "200"}
+	resolve := func(path *Path) (interface{}, error) {
+		switch path.Field() {
+		case "name":
+			return "http.server.duration", nil
+		case "attributes":
+			value, ok := attributes[path.Keys[0].(*Literal).Value.(string)]
+			if !ok {
+				return nil, nil
+			}
+			return value, nil
+		}
+		return nil, nil
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{`name == "http.server.duration"`, true},
+		{`metric.name != "http.server.duration"`, false},
+		{`IsMatch(metric.name, "^http\\.")`, true},
+		{`attributes["http.method"] == "GET" and attributes["status"] == "500"`, false},
+		{`attributes["http.method"] == "POST" or (attributes["status"] == "200")`, true},
+		{`attributes["missing"] == nil`, true},
+		{`not HasPrefix(name, "rpc")`, true},
+	}
+	for _, tt := range tests {
+		condition, err := ParseCondition(tt.condition)
+		require.NoError(t, err, tt.condition)
+		result, err := EvalCondition(condition, resolve)
+		require.NoError(t, err, tt.condition)
+		assert.Equal(t, tt.expected, result, tt.condition)
+	}
+}
@@ -0,0 +1,356 @@
+// Package policy loads the compliance policy packs of an organization and checks collector configurations against
+// their rules e.g. all exporters use TLS, the debug exporter is forbidden or batches have at most 8192 items
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+// Severities of the rules, the values are the report severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNote    = "note"
+)
+
+var severities = []string{SeverityError, SeverityWarning, SeverityNote}
+
+// componentSections are the sections of the configuration the rules select components from
+var componentSections = []string{"receivers", "processors", "exporters", "connectors", "extensions"}
+
+// namePattern matches the pack names and rule IDs, the pack name is the namespace of its rule IDs e.g. acme/exporters-tls
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// Pack is a named set of rules, the name namespaces the rule IDs of the findings
+type Pack struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Rules       []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule checks a configuration path of the selected components, or of the configuration if no components are selected.
+// The value of a path that is not set is the default of the component schema.
+type Rule struct {
+	ID string `yaml:"id" json:"id"`
+	// Description states the policy e.g. exporters must use TLS, it starts the messages of the findings
+	Description string `yaml:"description" json:"description"`
+	// Documentation explains the rule and how to comply e.g. a link to the internal policy, it is returned with the
+	// findings
+	Documentation string `yaml:"documentation,omitempty" json:"documentation,omitempty"`
+	// Severity is error, warning or note. Defaults to error.
+	Severity string `yaml:"severity,omitempty" json:"severity,omitempty"`
+	// Components selects the components as <section>/<type or ID glob> e.g. exporters/*, exporters/otlp* or
+	// processors/batch, a section alone selects all its components
+	Components string `yaml:"components,omitempty" json:"components,omitempty"`
+	// HasField applies the rule only to the components whose schema has the field e.g. tls
+	HasField string `yaml:"has_field,omitempty" json:"has_field,omitempty"`
+
+	// Forbidden reports every selected component
+	Forbidden bool `yaml:"forbidden,omitempty" json:"forbidden,omitempty"`
+	// Path is the dot separated path of the checked value in the component configuration e.g. tls.insecure
+	Path      string        `yaml:"path,omitempty" json:"path,omitempty"`
+	Required  bool          `yaml:"required,omitempty" json:"required,omitempty"`
+	Equals    interface{}   `yaml:"equals,omitempty" json:"equals,omitempty"`
+	NotEquals interface{}   `yaml:"not_equals,omitempty" json:"not_equals,omitempty"`
+	OneOf     []interface{} `yaml:"one_of,omitempty" json:"one_of,omitempty"`
+	Pattern   string        `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Min       *float64      `yaml:"min,omitempty" json:"min,omitempty"`
+	Max       *float64      `yaml:"max,omitempty" json:"max,omitempty"`
+}
+
+// Violation is a component or a value of a configuration violating a rule
+type Violation struct {
+	// Rule is the rule ID namespaced by its pack e.g. acme/exporters-tls
+	Rule     string
+	Severity string
+	// Keys are the configuration keys of the violation e.g. exporters, otlp, tls, insecure
+	Keys          []string
+	Message       string
+	Documentation string
+}
+
+// Load loads the packs of the files, a file with an invalid pack or a pack name used by another file fails the load
+func Load(files ...string) ([]Pack, error) {
+	packs := make([]Pack, 0, len(files))
+	loaded := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the policy pack: %w", err)
+		}
+		pack, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if other, ok := loaded[pack.Name]; ok {
+			return nil, fmt.Errorf("%s: policy pack %s is already loaded from %s, the pack names namespace the rules and must be unique", file, pack.Name, other)
+		}
+		loaded[pack.Name] = file
+		packs = append(packs, pack)
+	}
+	return packs, nil
+}
+
+// Parse parses and validates a pack YAML, unknown keys e.g. a misspelled condition are rejected
+func Parse(data []byte) (Pack, error) {
+	var pack Pack
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&pack); err != nil {
+		return Pack{}, fmt.Errorf("failed to parse the policy pack YAML: %w", err)
+	}
+	return pack, pack.Validate()
+}
+
+// Validate returns all the problems of the pack and its rules
+func (p Pack) Validate() error {
+	var errs []error
+	if !namePattern.MatchString(p.Name) {
+		errs = append(errs, fmt.Errorf("policy pack name %q must be lowercase letters, digits, '.', '_' and '-'", p.Name))
+	}
+	if len(p.Rules) == 0 {
+		errs = append(errs, fmt.Errorf("policy pack %s has no rules", p.Name))
+	}
+	ids := make(map[string]bool, len(p.Rules))
+	for i, rule := range p.Rules {
+		if ids[rule.ID] {
+			errs = append(errs, fmt.Errorf("policy pack %s: rule %s is defined more than once", p.Name, rule.ID))
+		}
+		ids[rule.ID] = true
+		for _, err := range rule.validate() {
+			name := rule.ID
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			errs = append(errs, fmt.Errorf("policy pack %s: rule %s: %w", p.Name, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r Rule) validate() []error {
+	var errs []error
+	if !namePattern.MatchString(r.ID) {
+		errs = append(errs, fmt.Errorf("id %q must be lowercase letters, digits, '.', '_' and '-'", r.ID))
+	}
+	if strings.TrimSpace(r.Description) == "" {
+		errs = append(errs, errors.New("description must be set, it starts the messages of the findings"))
+	}
+	if r.Severity != "" && !slices.Contains(severities, r.Severity) {
+		errs = append(errs, fmt.Errorf("severity %q must be one of %s", r.Severity, strings.Join(severities, ", ")))
+	}
+	if r.Components != "" {
+		section, pattern, _ := strings.Cut(r.Components, "/")
+		if !slices.Contains(componentSections, section) {
+			errs = append(errs, fmt.Errorf("components %q must start with one of %s", r.Components, strings.Join(componentSections, ", ")))
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("components %q: %w", r.Components, err))
+		}
+	}
+	if r.HasField != "" && r.Components == "" {
+		errs = append(errs, errors.New("has_field checks the schemas of the components, it needs components"))
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			errs = append(errs, fmt.Errorf("pattern: %w", err))
+		}
+	}
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		errs = append(errs, fmt.Errorf("min %v is greater than max %v", *r.Min, *r.Max))
+	}
+
+	conditions := r.Required || r.Equals != nil || r.NotEquals != nil || len(r.OneOf) > 0 || r.Pattern != "" || r.Min != nil || r.Max != nil
+	switch {
+	case r.Forbidden && r.Components == "":
+		errs = append(errs, errors.New("forbidden needs components"))
+	case r.Forbidden && (r.Path != "" || conditions):
+		errs = append(errs, errors.New("forbidden cannot be combined with a path and its conditions"))
+	case !r.Forbidden && r.Path == "":
+		errs = append(errs, errors.New("either forbidden or a path with conditions must be set"))
+	case !r.Forbidden && !conditions:
+		errs = append(errs, fmt.Errorf("path %s needs a condition: required, equals, not_equals, one_of, pattern, min or max", r.Path))
+	}
+	return errs
+}
+
+// Check returns the violations of the rules of the packs by the configuration, the schemas of the version provide the
+// default values and the fields of the components
+func Check(packs []Pack, config map[string]interface{}, schemaManager *collectorschema.SchemaManager, version string) []Violation {
+	var violations []Violation
+	for _, pack := range packs {
+		for _, rule := range pack.Rules {
+			for _, violation := range rule.check(config, schemaManager, version) {
+				violation.Rule = pack.Name + "/" + rule.ID
+				violation.Severity = rule.Severity
+				if violation.Severity == "" {
+					violation.Severity = SeverityError
+				}
+				violation.Documentation = rule.Documentation
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations
+}
+
+func (r Rule) check(config map[string]interface{}, schemaManager *collectorschema.SchemaManager, version string) []Violation {
+	if r.Components == "" {
+		if violation, ok := r.checkValue(config, nil, nil); ok {
+			return []Violation{violation}
+		}
+		return nil
+	}
+
+	section, pattern, _ := strings.Cut(r.Components, "/")
+	components, _ := config[section].(map[string]interface{})
+	var violations []Violation
+	for _, id := range slices.Sorted(maps.Keys(components)) {
+		componentType, _, _ := strings.Cut(id, "/")
+		if pattern != "" && !match(pattern, componentType) && !match(pattern, id) {
+			continue
+		}
+		keys := []string{section, id}
+		if r.Forbidden {
+			violations = append(violations, Violation{Keys: keys, Message: fmt.Sprintf("%s: %s %s is configured", r.Description, strings.TrimSuffix(section, "s"), id)})
+			continue
+		}
+		var schema map[string]interface{}
+		if componentSchema, err := schemaManager.GetComponentSchema(collectorschema.ComponentTypeFromSection(section), componentType, version); err == nil {
+			schema = componentSchema.Schema
+		}
+		if r.HasField != "" && schemaField(schema, strings.Split(r.HasField, ".")) == nil {
+			continue
+		}
+		componentConfig, _ := components[id].(map[string]interface{})
+		if violation, ok := r.checkValue(componentConfig, schema, keys); ok {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// checkValue checks the value of the path in the configuration, or the schema default if it is not set. A value that
+// is neither set nor defaulted only violates required.
+func (r Rule) checkValue(config map[string]interface{}, schema map[string]interface{}, keys []string) (Violation, bool) {
+	fields := strings.Split(r.Path, ".")
+	keys = append(append([]string{}, keys...), fields...)
+	violation := func(format string, args ...interface{}) (Violation, bool) {
+		return Violation{Keys: keys, Message: r.Description + ": " + r.Path + " " + fmt.Sprintf(format, args...)}, true
+	}
+
+	value, set := lookup(config, fields)
+	if !set {
+		if r.Required {
+			return violation("is not set")
+		}
+		field := schemaField(schema, fields)
+		if field == nil || field["default"] == nil {
+			return Violation{}, false
+		}
+		value = field["default"]
+	}
+	// Placeholders are resolved by the collector at startup
+	if validation.IsPlaceholder(value) {
+		return Violation{}, false
+	}
+
+	switch {
+	case r.Equals != nil && !equal(value, r.Equals):
+		return violation("is %v, it must be %v", value, r.Equals)
+	case r.NotEquals != nil && equal(value, r.NotEquals):
+		return violation("must not be %v", value)
+	case len(r.OneOf) > 0 && !containsValue(r.OneOf, value):
+		return violation("is %v, it must be one of %v", value, r.OneOf)
+	case r.Pattern != "" && !regexp.MustCompile(r.Pattern).MatchString(fmt.Sprint(value)):
+		return violation("is %v, it must match %s", value, r.Pattern)
+	}
+	if r.Min != nil || r.Max != nil {
+		number, ok := toFloat(value)
+		switch {
+		case !ok:
+			return violation("is %v, it must be a number", value)
+		case r.Min != nil && number < *r.Min:
+			return violation("is %v, the minimum is %v", value, *r.Min)
+		case r.Max != nil && number > *r.Max:
+			return violation("is %v, the maximum is %v", value, *r.Max)
+		}
+	}
+	return Violation{}, false
+}
+
+// lookup returns the value of the fields in the configuration, a null value is not set
+func lookup(config map[string]interface{}, fields []string) (interface{}, bool) {
+	var value interface{} = config
+	for _, field := range fields {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[field]; !ok {
+			return nil, false
+		}
+	}
+	return value, value != nil
+}
+
+// schemaField returns the schema of the fields in a component schema, nil if the schema has no such field
+func schemaField(schema map[string]interface{}, fields []string) map[string]interface{} {
+	for _, field := range fields {
+		properties, _ := schema["properties"].(map[string]interface{})
+		if schema, _ = properties[field].(map[string]interface{}); schema == nil {
+			return nil
+		}
+	}
+	return schema
+}
+
+func match(pattern, name string) bool {
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+// equal compares the values of a configuration and a rule, numbers are compared by value e.g. 1 and 1.0
+func equal(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,163 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testPack = `
+name: acme
+description: ACME telemetry policies
+rules:
+  - id: exporters-tls
+    description: exporters must use TLS
+    documentation: Remove tls.insecure, see https://wiki.acme.com/otel/tls
+    components: exporters/*
+    has_field: tls
+    path: tls.insecure
+    not_equals: true
+  - id: no-debug
+    description: the debug exporter is forbidden
+    severity: warning
+    components: exporters/debug
+    forbidden: true
+  - id: batch-size
+    description: batches have at most 8192 items
+    components: processors/batch*
+    path: send_batch_size
+    max: 8192
+  - id: telemetry-level
+    description: the collector logs at info or above
+    path: service.telemetry.logs.level
+    one_of: [info, warn, error]
+`
+
+const testConfig = `
+receivers:
+  otlp:
+processors:
+  batch:
+  batch/large:
+    send_batch_size: 10000
+exporters:
+  otlp:
+    endpoint: tempo:4317
+    tls:
+      insecure: true
+  otlp/secure:
+    endpoint: tempo:4317
+  debug:
+service:
+  telemetry:
+    logs:
+      level: debug
+`
+
+func TestCheck(t *testing.T) {
+	pack, err := Parse([]byte(testPack))
+	require.NoError(t, err)
+	var config map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(testConfig), &config))
+
+	violations := Check([]Pack{pack}, config, collectorschema.NewSchemaManager(), "0.139.0")
+	assert.Equal(t, []Violation{
+		{
+			Rule:          "acme/exporters-tls",
+			Severity:      SeverityError,
+			Keys:          []string{"exporters", "otlp", "tls", "insecure"},
+			Message:       "exporters must use TLS: tls.insecure must not be true",
+			Documentation: "Remove tls.insecure, see https://wiki.acme.com/otel/tls",
+		},
+		{
+			Rule:     "acme/no-debug",
+			Severity: SeverityWarning,
+			Keys:     []string{"exporters", "debug"},
+			Message:  "the debug exporter is forbidden: exporter debug is configured",
+		},
+		{
+			Rule:     "acme/batch-size",
+			Severity: SeverityError,
+			Keys:     []string{"processors", "batch/large", "send_batch_size"},
+			Message:  "batches have at most 8192 items: send_batch_size is 10000, the maximum is 8192",
+		},
+		{
+			Rule:     "acme/telemetry-level",
+			Severity: SeverityError,
+			Keys:     []string{"service", "telemetry", "logs", "level"},
+			Message:  "the collector logs at info or above: service.telemetry.logs.level is debug, it must be one of [info warn error]",
+		},
+	}, violations)
+}
+
+func TestCheck_SchemaDefaults(t *testing.T) {
+	minimum := 10000.0
+	pack := Pack{Name: "acme", Rules: []Rule{
+		{ID: "batch-size", Description: "batches have at least 10000 items", Components: "processors/batch", Path: "send_batch_size", Min: &minimum},
+		{ID: "queue", Description: "exporters queue", Components: "exporters", Path: "sending_queue.enabled", Required: true},
+	}}
+	require.NoError(t, pack.Validate())
+	var config map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte("processors:\n  batch:\nexporters:\n  otlp:\n    sending_queue:\n      enabled: ${env:QUEUE}\n  debug:\n"), &config))
+
+	violations := Check([]Pack{pack}, config, collectorschema.NewSchemaManager(), "0.139.0")
+	require.Len(t, violations, 2)
+	// The batch processor sends 8192 items by default
+	assert.Equal(t, "batches have at least 10000 items: send_batch_size is 8192, the minimum is 10000", violations[0].Message)
+	assert.Equal(t, []string{"exporters", "debug", "sending_queue", "enabled"}, violations[1].Keys)
+	assert.Equal(t, "exporters queue: sending_queue.enabled is not set", violations[1].Message)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]byte(`
+name: ACME
+rules:
+  - id: tls
+    description: exporters must use TLS
+    components: exporter/*
+    path: tls.insecure
+  - id: tls
+    description: no debug
+    severity: fatal
+    forbidden: true
+    path: endpoint
+  - description: ""
+    path: endpoint
+    pattern: "("
+`))
+	require.Error(t, err)
+	assert.Equal(t, `policy pack name "ACME" must be lowercase letters, digits, '.', '_' and '-'
+policy pack ACME: rule tls: components "exporter/*" must start with one of receivers, processors, exporters, connectors, extensions
+policy pack ACME: rule tls: path tls.insecure needs a condition: required, equals, not_equals, one_of, pattern, min or max
+policy pack ACME: rule tls is defined more than once
+policy pack ACME: rule tls: severity "fatal" must be one of error, warning, note
+policy pack ACME: rule tls: forbidden needs components
+policy pack ACME: rule #3: id "" must be lowercase letters, digits, '.', '_' and '-'
+policy pack ACME: rule #3: description must be set, it starts the messages of the findings
+policy pack ACME: rule #3: pattern: error parsing regexp: missing closing ): `+"`(`", err.Error())
+
+	_, err = Parse([]byte("name: acme\nrules:\n  - id: tls\n    description: TLS\n    path: tls.insecure\n    not_equal: true\n"))
+	assert.ErrorContains(t, err, "field not_equal not found")
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "acme.yaml")
+	second := filepath.Join(dir, "acme-copy.yaml")
+	require.NoError(t, os.WriteFile(first, []byte(testPack), 0o644))
+	require.NoError(t, os.WriteFile(second, []byte(testPack), 0o644))
+
+	packs, err := Load(first)
+	require.NoError(t, err)
+	require.Len(t, packs, 1)
+	assert.Equal(t, "acme", packs[0].Name)
+	assert.Len(t, packs[0].Rules, 4)
+
+	_, err = Load(first, second)
+	assert.EqualError(t, err, second+": policy pack acme is already loaded from "+first+", the pack names namespace the rules and must be unique")
+}
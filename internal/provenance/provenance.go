@@ -0,0 +1,198 @@
+// Package provenance adds a header to the configurations generated by the tools recording the tool call they were
+// generated with, so GitOps workflows can tell generated content from hand-edited content and regenerate it
+package provenance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Generator is the name of the MCP server recorded in the header
+	Generator = "otel-mcp-server"
+	// GeneratorVersion is the version of the MCP server recorded in the header
+	GeneratorVersion = "1.0.0"
+)
+
+// keyPrefix starts the header lines holding a field e.g. # provenance.tool: opentelemetry-collector-count-generate
+const keyPrefix = "# provenance."
+
+// Header is the provenance of a generated configuration
+type Header struct {
+	Tool          string `json:"tool"`
+	ServerVersion string `json:"serverVersion"`
+	// SchemaVersion is the collector version of the schemas the configuration was generated and validated with
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// Parameters are the arguments of the tool call
+	Parameters map[string]interface{} `json:"parameters"`
+	// InputsHash is the hash of the tool, the schema version and the parameters
+	InputsHash string `json:"inputsHash"`
+	// ContentHash is the hash of the configuration without the header
+	ContentHash string `json:"contentHash"`
+}
+
+// Verification is the result of checking a configuration against its provenance header
+type Verification struct {
+	// Generated is true if the configuration has a provenance header of this server
+	Generated bool    `json:"generated"`
+	Header    *Header `json:"header,omitempty"`
+	// Modified is true if the configuration changed after it was generated e.g. edited by hand
+	Modified bool `json:"modified"`
+	// InputsChanged is true if the parameters differ from the recorded ones, it is not set without parameters
+	InputsChanged *bool    `json:"inputsChanged,omitempty"`
+	Notes         []string `json:"notes"`
+}
+
+// Stamp returns the configuration with the provenance header of a tool call, a header of a previous generation is
+// replaced
+func Stamp(content []byte, tool, schemaVersion string, parameters map[string]interface{}) ([]byte, error) {
+	_, body := split(content)
+	if parameters == nil {
+		parameters = map[string]interface{}{}
+	}
+	inputsHash, err := InputsHash(tool, schemaVersion, parameters)
+	if err != nil {
+		return nil, err
+	}
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the parameters: %w", err)
+	}
+
+	var header strings.Builder
+	fmt.Fprintf(&header, "# Generated by %s %s. Edit the parameters and regenerate it, or remove this header when editing\n", Generator, GeneratorVersion)
+	header.WriteString("# it by hand.\n")
+	fmt.Fprintf(&header, "%stool: %s\n", keyPrefix, tool)
+	fmt.Fprintf(&header, "%sserver-version: %s\n", keyPrefix, GeneratorVersion)
+	if schemaVersion != "" {
+		fmt.Fprintf(&header, "%sschema-version: %s\n", keyPrefix, schemaVersion)
+	}
+	fmt.Fprintf(&header, "%sparameters: %s\n", keyPrefix, parametersJSON)
+	fmt.Fprintf(&header, "%sinputs-hash: %s\n", keyPrefix, inputsHash)
+	fmt.Fprintf(&header, "%scontent-hash: %s\n", keyPrefix, ContentHash(body))
+	return append([]byte(header.String()), body...), nil
+}
+
+// Parse returns the provenance header of a configuration and the configuration without it, the header is nil if the
+// configuration has none
+func Parse(content []byte) (*Header, []byte, error) {
+	headerLines, body := split(content)
+	if len(headerLines) == 0 {
+		return nil, body, nil
+	}
+	header := &Header{}
+	for _, line := range headerLines {
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, keyPrefix), ": ")
+		if !ok || !strings.HasPrefix(line, keyPrefix) {
+			continue
+		}
+		switch key {
+		case "tool":
+			header.Tool = value
+		case "server-version":
+			header.ServerVersion = value
+		case "schema-version":
+			header.SchemaVersion = value
+		case "parameters":
+			if err := json.Unmarshal([]byte(value), &header.Parameters); err != nil {
+				return nil, nil, fmt.Errorf("invalid provenance parameters: %w", err)
+			}
+		case "inputs-hash":
+			header.InputsHash = value
+		case "content-hash":
+			header.ContentHash = value
+		}
+	}
+	if header.Tool == "" || header.ContentHash == "" {
+		return nil, nil, fmt.Errorf("incomplete provenance header, the tool or the content hash is missing")
+	}
+	return header, body, nil
+}
+
+// Verify checks a configuration against its provenance header, the parameters are compared with the recorded ones
+// unless they are nil
+func Verify(content []byte, parameters map[string]interface{}) (*Verification, error) {
+	header, body, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return &Verification{Notes: []string{fmt.Sprintf("the configuration has no provenance header, it was not generated by %s or the header was removed", Generator)}}, nil
+	}
+
+	verification := &Verification{Generated: true, Header: header, Notes: []string{}}
+	if ContentHash(body) != header.ContentHash {
+		verification.Modified = true
+		verification.Notes = append(verification.Notes, "the configuration was modified after it was generated, regenerating it overwrites the changes")
+	}
+	recordedInputsHash, err := InputsHash(header.Tool, header.SchemaVersion, header.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	if recordedInputsHash != header.InputsHash {
+		verification.Notes = append(verification.Notes, "the recorded parameters do not match the inputs hash, the header was modified")
+	}
+	if parameters != nil {
+		inputsHash, err := InputsHash(header.Tool, header.SchemaVersion, parameters)
+		if err != nil {
+			return nil, err
+		}
+		inputsChanged := inputsHash != header.InputsHash
+		verification.InputsChanged = &inputsChanged
+		if inputsChanged {
+			verification.Notes = append(verification.Notes, "the parameters differ from the recorded ones, regenerate the configuration")
+		}
+	}
+	if header.ServerVersion != GeneratorVersion {
+		verification.Notes = append(verification.Notes, fmt.Sprintf("the configuration was generated by %s %s, the server runs %s", Generator, header.ServerVersion, GeneratorVersion))
+	}
+	return verification, nil
+}
+
+// InputsHash returns the hash of the inputs of a generation, the parameters are hashed as JSON with sorted keys
+func InputsHash(tool, schemaVersion string, parameters map[string]interface{}) (string, error) {
+	if parameters == nil {
+		parameters = map[string]interface{}{}
+	}
+	inputs, err := json.Marshal(map[string]interface{}{
+		"tool":          tool,
+		"schemaVersion": schemaVersion,
+		"parameters":    parameters,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the parameters: %w", err)
+	}
+	return hash(inputs), nil
+}
+
+// ContentHash returns the hash of a configuration without the provenance header
+func ContentHash(body []byte) string {
+	return hash(body)
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// split returns the lines of the provenance header at the start of the configuration and the rest of it
+func split(content []byte) ([]string, []byte) {
+	if !bytes.HasPrefix(content, []byte("# Generated by "+Generator+" ")) {
+		return nil, content
+	}
+	var header []string
+	rest := content
+	for len(rest) > 0 && rest[0] == '#' {
+		line, remaining, _ := bytes.Cut(rest, []byte("\n"))
+		header = append(header, string(line))
+		rest = remaining
+		if strings.HasPrefix(string(line), keyPrefix+"content-hash: ") {
+			break
+		}
+	}
+	return header, rest
+}
@@ -0,0 +1,65 @@
+package provenance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const config = "connectors:\n  count:\n"
+
+func TestStamp(t *testing.T) {
+	parameters := map[string]interface{}{"signals": "logs", "count": float64(5)}
+	stamped, err := Stamp([]byte(config), "opentelemetry-collector-count-generate", "0.139.0", parameters)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(stamped), "\n"+config))
+	assert.Contains(t, string(stamped), `# provenance.parameters: {"count":5,"signals":"logs"}`)
+
+	header, body, err := Parse(stamped)
+	require.NoError(t, err)
+	assert.Equal(t, config, string(body))
+	assert.Equal(t, "opentelemetry-collector-count-generate", header.Tool)
+	assert.Equal(t, GeneratorVersion, header.ServerVersion)
+	assert.Equal(t, "0.139.0", header.SchemaVersion)
+	assert.Equal(t, parameters, header.Parameters)
+	assert.Equal(t, ContentHash([]byte(config)), header.ContentHash)
+
+	// Stamping again replaces the header
+	restamped, err := Stamp(stamped, "opentelemetry-collector-count-generate", "0.139.0", parameters)
+	require.NoError(t, err)
+	assert.Equal(t, string(stamped), string(restamped))
+}
+
+func TestVerify(t *testing.T) {
+	parameters := map[string]interface{}{"signals": "logs"}
+	stamped, err := Stamp([]byte(config), "opentelemetry-collector-count-generate", "0.139.0", parameters)
+	require.NoError(t, err)
+
+	verification, err := Verify(stamped, nil)
+	require.NoError(t, err)
+	assert.True(t, verification.Generated)
+	assert.False(t, verification.Modified)
+	assert.Nil(t, verification.InputsChanged)
+	assert.Empty(t, verification.Notes)
+
+	verification, err = Verify(append(stamped, "  # edited\n"...), map[string]interface{}{"signals": "traces"})
+	require.NoError(t, err)
+	assert.True(t, verification.Modified)
+	require.NotNil(t, verification.InputsChanged)
+	assert.True(t, *verification.InputsChanged)
+	assert.Len(t, verification.Notes, 2)
+
+	verification, err = Verify(stamped, map[string]interface{}{"signals": "logs"})
+	require.NoError(t, err)
+	assert.False(t, *verification.InputsChanged)
+
+	verification, err = Verify([]byte(config), nil)
+	require.NoError(t, err)
+	assert.False(t, verification.Generated)
+	assert.Nil(t, verification.Header)
+
+	_, err = Verify([]byte("# Generated by otel-mcp-server 1.0.0\n"+config), nil)
+	assert.EqualError(t, err, "incomplete provenance header, the tool or the content hash is missing")
+}
@@ -0,0 +1,253 @@
+// Package recording records the tool calls of the server sessions to a JSON lines file and compares the results of
+// replayed calls with the recorded ones, the recordings reproduce agent behavior reports and regression test schema
+// bundle updates against real-world interactions
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxDifferences is the maximum number of differences reported for a call
+const maxDifferences = 20
+
+// volatilePatterns match the parts of the results that differ between runs, the random artifact IDs and the timestamps
+var volatilePatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`artifact://[0-9a-f-]+`), "artifact://<id>"},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`), "<time>"},
+}
+
+// Call is a recorded tool call
+type Call struct {
+	Time    time.Time `json:"time"`
+	Session string    `json:"session"`
+	Tool    string    `json:"tool"`
+	// Arguments are the arguments as sent by the client, before the coercion and the resolution of the references
+	Arguments any `json:"arguments,omitempty"`
+	// Result is the result returned to the client, Error the error of a call failing without a result
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	// Line is the line of the call in the recording
+	Line int `json:"-"`
+}
+
+// NewCall returns the call of a tool with its result or the error of a call failing without a result
+func NewCall(session, tool string, arguments any, result *mcp.CallToolResult, callErr error) (Call, error) {
+	call := Call{Session: session, Tool: tool, Arguments: arguments}
+	if callErr != nil {
+		call.Error = callErr.Error()
+	}
+	if result != nil {
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			return call, fmt.Errorf("failed to marshal the result of %s: %w", tool, err)
+		}
+		call.Result = resultJSON
+	}
+	return call, nil
+}
+
+// Recorder appends the tool calls to a JSON lines file
+type Recorder struct {
+	path  string
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+// NewRecorder creates a recorder writing to the file, an existing recording is replaced
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	return &Recorder{path: path, now: time.Now}, nil
+}
+
+// Record appends a call to the recording, the time of the call is set by the recorder
+func (r *Recorder) Record(call Call) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	call.Time = r.now().UTC()
+	line, err := json.Marshal(call)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the call of %s: %w", call.Tool, err)
+	}
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// Load reads the calls of a recording in the recorded order
+func Load(path string) ([]Call, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+	var calls []Call
+	scanner := bufio.NewScanner(file)
+	// The results embed whole configurations and schemas
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var call Call
+		if err := json.Unmarshal(scanner.Bytes(), &call); err != nil {
+			return nil, fmt.Errorf("failed to parse recording line %d: %w", line, err)
+		}
+		if call.Tool == "" {
+			return nil, fmt.Errorf("recording line %d has no tool", line)
+		}
+		call.Line = line
+		calls = append(calls, call)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return calls, nil
+}
+
+// Difference is a value of a replayed call differing from the recorded call
+type Difference struct {
+	// Path is the JSON path of the value e.g. result.structuredContent.valid, empty for the whole call
+	Path     string `json:"path"`
+	Recorded string `json:"recorded"`
+	Replayed string `json:"replayed"`
+}
+
+// Compare returns the differences of the result and the error of a replayed call from the recorded call. The artifact
+// IDs and the timestamps are ignored, at most maxDifferences are returned.
+func Compare(recorded, replayed Call) ([]Difference, error) {
+	recordedValue, err := outcome(recorded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the recorded result: %w", err)
+	}
+	replayedValue, err := outcome(replayed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the replayed result: %w", err)
+	}
+	var differences []Difference
+	compareValues("", recordedValue, replayedValue, &differences)
+	return differences, nil
+}
+
+// outcome returns the result and the error of a call as a JSON value with the volatile parts replaced
+func outcome(call Call) (map[string]any, error) {
+	value := map[string]any{}
+	if call.Error != "" {
+		value["error"] = normalize(call.Error)
+	}
+	if len(call.Result) > 0 {
+		var result any
+		if err := json.Unmarshal([]byte(normalize(string(call.Result))), &result); err != nil {
+			return nil, err
+		}
+		// The text content of a structured result is the same JSON, it is compared once
+		if resultMap, ok := result.(map[string]any); ok && resultMap["structuredContent"] != nil {
+			delete(resultMap, "content")
+		}
+		value["result"] = result
+	}
+	return value, nil
+}
+
+// normalize replaces the volatile parts of a text
+func normalize(text string) string {
+	for _, volatile := range volatilePatterns {
+		text = volatile.pattern.ReplaceAllString(text, volatile.replacement)
+	}
+	return text
+}
+
+// compareValues appends the differences of two JSON values, the maps and lists are compared element by element
+func compareValues(path string, recorded, replayed any, differences *[]Difference) {
+	if len(*differences) >= maxDifferences {
+		return
+	}
+	switch recordedValue := recorded.(type) {
+	case map[string]any:
+		replayedValue, ok := replayed.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool)
+		for key := range recordedValue {
+			keys[key] = true
+		}
+		for key := range replayedValue {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			compareValues(keyPath, recordedValue[key], replayedValue[key], differences)
+		}
+		return
+	case []any:
+		replayedValue, ok := replayed.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < max(len(recordedValue), len(replayedValue)); i++ {
+			var recordedElement, replayedElement any
+			if i < len(recordedValue) {
+				recordedElement = recordedValue[i]
+			}
+			if i < len(replayedValue) {
+				replayedElement = replayedValue[i]
+			}
+			compareValues(path+"["+strconv.Itoa(i)+"]", recordedElement, replayedElement, differences)
+		}
+		return
+	}
+	if !reflect.DeepEqual(recorded, replayed) {
+		*differences = append(*differences, Difference{Path: path, Recorded: describe(recorded), Replayed: describe(replayed)})
+	}
+}
+
+// describe returns a short JSON representation of a value
+func describe(value any) string {
+	if value == nil {
+		return "nothing"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	const maxLength = 80
+	if text := string(data); len(text) > maxLength {
+		return text[:maxLength] + "..."
+	}
+	return string(data)
+}
@@ -0,0 +1,93 @@
+package recording
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	if err := os.WriteFile(path, []byte("{\"tool\":\"stale\"}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	calls := []Call{
+		{Session: "a", Tool: "opentelemetry-collector-versions", Result: json.RawMessage(`{"content":[]}`)},
+		{Session: "b", Tool: "opentelemetry-collector-component-schema", Arguments: map[string]any{"name": "otlp"}, Error: "failed"},
+	}
+	for _, call := range calls {
+		if err := recorder.Record(call); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The existing recording is replaced
+	if len(loaded) != 2 {
+		t.Fatalf("loaded %d calls, want 2", len(loaded))
+	}
+	if loaded[1].Tool != calls[1].Tool || loaded[1].Line != 2 || loaded[1].Error != "failed" || loaded[1].Arguments.(map[string]any)["name"] != "otlp" {
+		t.Errorf("loaded call = %+v", loaded[1])
+	}
+	if !loaded[0].Time.Equal(recorder.now()) {
+		t.Errorf("time = %v", loaded[0].Time)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	if err := os.WriteFile(path, []byte("{\"tool\":\"a\"}\n\n{\"session\":\"b\"}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || err.Error() != "recording line 3 has no tool" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	recorded := Call{Result: json.RawMessage(`{"content":[{"type":"text","text":"old"}],"structuredContent":{"valid":true,"resourceUri":"artifact://0a1b/report.txt","time":"2025-01-02T03:04:05Z","errors":["a"]}}`)}
+	same := Call{Result: json.RawMessage(`{"content":[{"type":"text","text":"new"}],"structuredContent":{"valid":true,"resourceUri":"artifact://ffee/report.txt","time":"2025-10-16T10:00:00.5+02:00","errors":["a"]}}`)}
+	differences, err := Compare(recorded, same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(differences) != 0 {
+		t.Errorf("differences of equal results = %v", differences)
+	}
+
+	changed := Call{Result: json.RawMessage(`{"structuredContent":{"valid":false,"resourceUri":"artifact://ffee/report.txt","time":"2025-10-16T10:00:00Z","errors":["a","b"]}}`)}
+	differences, err = Compare(recorded, changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Difference{
+		{Path: "result.structuredContent.errors[1]", Recorded: "nothing", Replayed: `"b"`},
+		{Path: "result.structuredContent.valid", Recorded: "true", Replayed: "false"},
+	}
+	if len(differences) != len(want) {
+		t.Fatalf("differences = %v, want %v", differences, want)
+	}
+	for i := range want {
+		if differences[i] != want[i] {
+			t.Errorf("difference %d = %v, want %v", i, differences[i], want[i])
+		}
+	}
+
+	differences, err = Compare(Call{Error: "failed"}, same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(differences) != 2 || differences[0].Path != "error" || differences[1].Path != "result" {
+		t.Errorf("differences of a failed call = %v", differences)
+	}
+}
@@ -0,0 +1,252 @@
+// Package registry searches the OpenTelemetry registry of instrumentation libraries, exporters and collector
+// components of all languages
+package registry
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed registry.yaml
+var embeddedRegistry []byte
+
+// Package is the package of a registry entry in a package registry e.g. pypi or npm
+type Package struct {
+	Registry string `yaml:"registry" json:"registry"`
+	Name     string `yaml:"name" json:"name"`
+}
+
+// Entry is an OpenTelemetry registry entry
+type Entry struct {
+	Title string `yaml:"title" json:"title"`
+	// RegistryType is the kind of the entry e.g. instrumentation, exporter or receiver
+	RegistryType string `yaml:"registryType" json:"registryType"`
+	// Language is the language of the entry e.g. python, js or collector
+	Language    string   `yaml:"language" json:"language"`
+	Description string   `yaml:"description" json:"description"`
+	Tags        []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Package     Package  `yaml:"package" json:"package"`
+	Repo        string   `yaml:"repo" json:"repo"`
+	License     string   `yaml:"license,omitempty" json:"license,omitempty"`
+	// RegistryURL is the registry search returning the entry
+	RegistryURL string `yaml:"-" json:"registryUrl"`
+}
+
+type registryDatabase struct {
+	Entries []Entry `yaml:"entries"`
+}
+
+// languageAliases maps the language names used in questions to the registry languages
+var languageAliases = map[string]string{
+	"python":     "python",
+	"java":       "java",
+	"kotlin":     "java",
+	"go":         "go",
+	"golang":     "go",
+	"js":         "js",
+	"javascript": "js",
+	"typescript": "js",
+	"node":       "js",
+	"nodejs":     "js",
+	"dotnet":     "dotnet",
+	".net":       "dotnet",
+	"csharp":     "dotnet",
+	"c#":         "dotnet",
+	"ruby":       "ruby",
+	"php":        "php",
+	"rust":       "rust",
+	"swift":      "swift",
+	"erlang":     "erlang",
+	"elixir":     "erlang",
+	"cpp":        "cpp",
+	"c++":        "cpp",
+	"collector":  "collector",
+}
+
+// registryTypes are the registry entry kinds, they rank the matching entries higher when used in a query
+var registryTypes = map[string]bool{
+	"instrumentation": true, "exporter": true, "receiver": true, "processor": true, "extension": true,
+	"connector": true, "utilities": true, "resource-detector": true, "provider": true,
+}
+
+// stopWords are ignored query words
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "any": true, "are": true, "does": true, "exist": true, "for": true,
+	"how": true, "in": true, "is": true, "library": true, "of": true, "opentelemetry": true, "or": true,
+	"otel": true, "support": true, "the": true, "there": true, "to": true, "what": true, "which": true,
+	"with": true,
+}
+
+// ParseEntries parses a registry snapshot YAML
+func ParseEntries(data []byte) ([]Entry, error) {
+	var database registryDatabase
+	if err := yaml.Unmarshal(data, &database); err != nil {
+		return nil, fmt.Errorf("failed to parse registry: %w", err)
+	}
+	for i, entry := range database.Entries {
+		if entry.Title == "" || entry.Language == "" || entry.RegistryType == "" {
+			return nil, fmt.Errorf("registry entry %d must set title, language and registryType", i)
+		}
+		query := url.Values{}
+		query.Set("s", entry.Title)
+		query.Set("language", entry.Language)
+		database.Entries[i].RegistryURL = "https://opentelemetry.io/ecosystem/registry/?" + query.Encode()
+	}
+	return database.Entries, nil
+}
+
+// Registry searches a registry snapshot, the embedded snapshot can be replaced with a downloaded one
+type Registry struct {
+	mutex   sync.RWMutex
+	entries []Entry
+	client  *http.Client
+}
+
+// NewRegistry returns a registry of the embedded snapshot
+func NewRegistry() (*Registry, error) {
+	entries, err := ParseEntries(embeddedRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return &Registry{entries: entries, client: http.DefaultClient}, nil
+}
+
+// SetHTTPClient configures the HTTP client downloading the registry snapshots
+func (r *Registry) SetHTTPClient(client *http.Client) {
+	r.client = client
+}
+
+// Search returns the entries matching the query words ordered by relevance. A language mentioned in the query
+// e.g. "kafka in python" restricts the results like the language argument, registryType restricts the kind.
+func (r *Registry) Search(query, language, registryType string, limit int) []Entry {
+	if language != "" {
+		if alias, ok := languageAliases[strings.ToLower(language)]; ok {
+			language = alias
+		}
+	}
+
+	var terms, types []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '?' || r == '!' || r == '/'
+	}) {
+		word = strings.TrimSuffix(word, ".")
+		switch {
+		case stopWords[word] || word == "":
+		case languageAliases[word] != "" && language == "":
+			language = languageAliases[word]
+		case registryTypes[strings.TrimSuffix(word, "s")]:
+			types = append(types, strings.TrimSuffix(word, "s"))
+		default:
+			terms = append(terms, word)
+		}
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	type scoredEntry struct {
+		entry Entry
+		score int
+	}
+	var matches []scoredEntry
+	for _, entry := range r.entries {
+		if language != "" && entry.Language != strings.ToLower(language) {
+			continue
+		}
+		if registryType != "" && entry.RegistryType != strings.ToLower(registryType) {
+			continue
+		}
+		score := 0
+		for _, term := range terms {
+			score += termScore(entry, term)
+		}
+		// Without other words the query only lists the entries of the language and kind
+		if score == 0 && len(terms) > 0 {
+			continue
+		}
+		for _, t := range types {
+			if entry.RegistryType == t {
+				score++
+			}
+		}
+		matches = append(matches, scoredEntry{entry: entry, score: score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].entry.Title < matches[j].entry.Title
+	})
+
+	results := make([]Entry, 0, len(matches))
+	for _, match := range matches {
+		if limit > 0 && len(results) == limit {
+			break
+		}
+		results = append(results, match.entry)
+	}
+	return results
+}
+
+// termScore scores a query word, matches in the title and tags rank higher than in the package and description
+func termScore(entry Entry, term string) int {
+	score := 0
+	if strings.Contains(strings.ToLower(entry.Title), term) {
+		score += 3
+	}
+	for _, tag := range entry.Tags {
+		if tag == term {
+			score += 3
+			break
+		}
+	}
+	if strings.Contains(strings.ToLower(entry.Package.Name), term) {
+		score += 2
+	}
+	if strings.Contains(strings.ToLower(entry.Description), term) {
+		score++
+	}
+	return score
+}
+
+// Refresh replaces the registry snapshot with the one downloaded from the URL
+func (r *Registry) Refresh(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create registry request: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download registry: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download registry: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read registry: %w", err)
+	}
+	entries, err := ParseEntries(data)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	r.entries = entries
+	r.mutex.Unlock()
+	return nil
+}
@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func titles(entries []Entry) []string {
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry.Title)
+	}
+	return result
+}
+
+func TestRegistry_Search(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	results := registry.Search("Is there an OTel instrumentation for Kafka in Python?", "", "", 0)
+	require.NotEmpty(t, results)
+	for _, entry := range results {
+		assert.Equal(t, "python", entry.Language)
+		assert.Contains(t, entry.Tags, "kafka")
+	}
+	assert.Equal(t, "pypi", results[0].Package.Registry)
+	assert.Contains(t, results[0].RegistryURL, "https://opentelemetry.io/ecosystem/registry/?")
+
+	results = registry.Search("kafka", "nodejs", "", 0)
+	assert.Equal(t, []string{"KafkaJS Instrumentation"}, titles(results))
+
+	results = registry.Search("kafka", "", "exporter", 0)
+	assert.Equal(t, []string{"Kafka Exporter"}, titles(results))
+
+	results = registry.Search("kafka", "", "", 2)
+	assert.Len(t, results, 2)
+
+	assert.Empty(t, registry.Search("cobol mainframe", "", "", 0))
+}
+
+func TestRegistry_Refresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+entries:
+  - title: Test Instrumentation
+    registryType: instrumentation
+    language: rust
+    description: test
+    tags: [test]
+`))
+	}))
+	defer server.Close()
+
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+	require.NoError(t, registry.Refresh(context.Background(), server.URL))
+	assert.Equal(t, []string{"Test Instrumentation"}, titles(registry.Search("test in rust", "", "", 0)))
+	assert.Empty(t, registry.Search("kafka", "", "", 0))
+
+	_, err = ParseEntries([]byte("entries:\n  - title: missing language\n"))
+	assert.Error(t, err)
+}
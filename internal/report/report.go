@@ -0,0 +1,270 @@
+// Package report writes the findings of the validate, lint and schema-diff commands as colored human-readable text,
+// JSON or SARIF for code review tools, and decides the exit code of the commands
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Severity of a finding, the values are the SARIF result levels
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// rank orders the severities from the most to the least severe
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Output formats
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Formats are the output formats of the commands
+var Formats = []string{FormatText, FormatJSON, FormatSARIF}
+
+// FailNever is the exit code policy never failing the command
+const FailNever = "never"
+
+// FailOnValues are the values of the exit code policy, the command fails on findings of the severity or more severe
+var FailOnValues = []string{string(SeverityError), string(SeverityWarning), string(SeverityNote), FailNever}
+
+// Finding is a problem or a change reported by a command
+type Finding struct {
+	Severity Severity `json:"severity"`
+	// Rule identifies the check e.g. schema, topology or conflicting-listener
+	Rule string `json:"rule"`
+	// Group groups the findings of the text output e.g. the configuration section or the schema file
+	Group   string `json:"group"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+	// File of the finding if it is not the file of the report e.g. a schema file of a directory
+	File string `json:"file,omitempty"`
+	// Line of the finding in the file, 0 if it is unknown
+	Line int `json:"line,omitempty"`
+	// Column of the finding in the line, 0 if it is unknown
+	Column int `json:"column,omitempty"`
+	// Documentation of the rule e.g. of a policy pack rule, empty for the built-in rules
+	Documentation string `json:"documentation,omitempty"`
+}
+
+// Summary counts the findings by severity
+type Summary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Notes    int `json:"notes"`
+}
+
+// Report are the findings of a command for a file or directory
+type Report struct {
+	// Command is the command that produced the report e.g. validate
+	Command  string    `json:"command"`
+	File     string    `json:"file"`
+	Findings []Finding `json:"findings"`
+	Summary  Summary   `json:"summary"`
+}
+
+// New creates the report of the findings sorted by group, severity and path
+func New(command, file string, findings []Finding) Report {
+	findings = append([]Finding{}, findings...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Group != findings[j].Group {
+			return findings[i].Group < findings[j].Group
+		}
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity.rank() < findings[j].Severity.rank()
+		}
+		return findings[i].Path < findings[j].Path
+	})
+	report := Report{Command: command, File: file, Findings: findings}
+	for _, finding := range findings {
+		switch finding.Severity {
+		case SeverityError:
+			report.Summary.Errors++
+		case SeverityWarning:
+			report.Summary.Warnings++
+		default:
+			report.Summary.Notes++
+		}
+	}
+	return report
+}
+
+// ValidateFormat returns an error if the output format is not supported
+func ValidateFormat(format string) error {
+	for _, supported := range Formats {
+		if format == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported format %q, it can be %s", format, strings.Join(Formats, ", "))
+}
+
+// ValidateFailOn returns an error if the exit code policy is not supported
+func ValidateFailOn(failOn string) error {
+	for _, supported := range FailOnValues {
+		if failOn == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported --fail-on %q, it can be %s", failOn, strings.Join(FailOnValues, ", "))
+}
+
+// Failed returns an error if the report has findings of the failOn severity or more severe, the command exits with a
+// non-zero code. failOn never does not fail.
+func (r Report) Failed(failOn string) error {
+	if err := ValidateFailOn(failOn); err != nil || failOn == FailNever {
+		return err
+	}
+	threshold := Severity(failOn)
+	failed := 0
+	for _, finding := range r.Findings {
+		if finding.Severity.rank() <= threshold.rank() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s found %d findings of severity %s or higher in %s", r.Command, failed, failOn, r.File)
+	}
+	return nil
+}
+
+// Write writes the report in the format, the text format is colored if color is true
+func (r Report) Write(out io.Writer, format string, color bool) error {
+	switch format {
+	case FormatJSON:
+		if r.Findings == nil {
+			r.Findings = []Finding{}
+		}
+		reportJSON, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the report: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(reportJSON))
+		return err
+	case FormatSARIF:
+		sarifJSON, err := json.MarshalIndent(r.sarif(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the SARIF report: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(sarifJSON))
+		return err
+	case FormatText:
+		return r.writeText(out, color)
+	}
+	return ValidateFormat(format)
+}
+
+// ANSI escape codes of the text format
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+	ansiGray   = "\033[90m"
+)
+
+// writeText writes the findings grouped by their group and a summary line
+func (r Report) writeText(out io.Writer, color bool) error {
+	paint := func(code, text string) string {
+		if !color {
+			return text
+		}
+		return code + text + ansiReset
+	}
+	severityColors := map[Severity]string{SeverityError: ansiRed, SeverityWarning: ansiYellow, SeverityNote: ansiCyan}
+
+	group := ""
+	for i, finding := range r.Findings {
+		if i == 0 || finding.Group != group {
+			group = finding.Group
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintln(out, paint(ansiBold, group))
+		}
+		var location []string
+		if finding.Line > 0 {
+			file := r.File
+			if finding.File != "" {
+				file = finding.File
+			}
+			position := fmt.Sprintf("%s:%d", file, finding.Line)
+			if finding.Column > 0 {
+				position += fmt.Sprintf(":%d", finding.Column)
+			}
+			location = append(location, position)
+		}
+		if finding.Path != "" {
+			location = append(location, finding.Path+":")
+		}
+		location = append(location, finding.Message)
+		fmt.Fprintf(out, "  %s %s %s\n",
+			paint(severityColors[finding.Severity], fmt.Sprintf("%-7s", finding.Severity)),
+			strings.Join(location, " "), paint(ansiGray, "["+finding.Rule+"]"))
+		if finding.Documentation != "" {
+			fmt.Fprintf(out, "          %s\n", paint(ansiGray, finding.Documentation))
+		}
+	}
+	if len(r.Findings) > 0 {
+		fmt.Fprintln(out)
+	}
+	summary := fmt.Sprintf("%s: %d errors, %d warnings, %d notes", r.File, r.Summary.Errors, r.Summary.Warnings, r.Summary.Notes)
+	switch {
+	case r.Summary.Errors > 0:
+		summary = paint(ansiRed, summary)
+	case r.Summary.Warnings > 0:
+		summary = paint(ansiYellow, summary)
+	}
+	_, err := fmt.Fprintln(out, summary)
+	return err
+}
+
+// Color modes of the text format
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// UseColor returns true if the text written to out is colored: always, never, or auto for a terminal unless the
+// NO_COLOR environment variable is set
+func UseColor(mode string, out io.Writer) (bool, error) {
+	switch mode {
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	case ColorAuto:
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		file, ok := out.(*os.File)
+		if !ok {
+			return false, nil
+		}
+		info, err := file.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	}
+	return false, fmt.Errorf("unsupported --color %q, it can be %s, %s or %s", mode, ColorAuto, ColorAlways, ColorNever)
+}
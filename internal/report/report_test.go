@@ -0,0 +1,118 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReport() Report {
+	return New("validate", "collector.yaml", []Finding{
+		{Severity: SeverityWarning, Rule: "topology", Group: "exporters", Path: "exporters::debug", Message: "exporter is defined but not used in any pipeline", Line: 7},
+		{Severity: SeverityError, Rule: "topology", Group: "service", Path: "service::pipelines::traces::receivers", Message: `references receiver "zipkin" which is not defined`, Line: 11},
+		{Severity: SeverityError, Rule: "schema", Group: "exporters", Path: "exporters::otlp", Message: "endpoint is required", Line: 5, Column: 5},
+	})
+}
+
+func TestNew(t *testing.T) {
+	report := newTestReport()
+	assert.Equal(t, Summary{Errors: 2, Warnings: 1}, report.Summary)
+	// Grouped and the errors first
+	var paths []string
+	for _, finding := range report.Findings {
+		paths = append(paths, finding.Path)
+	}
+	assert.Equal(t, []string{"exporters::otlp", "exporters::debug", "service::pipelines::traces::receivers"}, paths)
+}
+
+func TestReport_Failed(t *testing.T) {
+	report := newTestReport()
+	assert.EqualError(t, report.Failed("error"), "validate found 2 findings of severity error or higher in collector.yaml")
+	assert.EqualError(t, report.Failed("warning"), "validate found 3 findings of severity warning or higher in collector.yaml")
+	assert.NoError(t, report.Failed("never"))
+	assert.EqualError(t, report.Failed("fatal"), `unsupported --fail-on "fatal", it can be error, warning, note, never`)
+
+	warnings := New("lint", "collector.yaml", []Finding{{Severity: SeverityWarning, Rule: "grpc-message-size", Message: "too large"}})
+	assert.NoError(t, warnings.Failed("error"))
+	assert.Error(t, warnings.Failed("note"))
+}
+
+func TestReport_WriteText(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newTestReport().Write(&out, FormatText, false))
+	assert.Equal(t, `exporters
+  error   collector.yaml:5:5 exporters::otlp: endpoint is required [schema]
+  warning collector.yaml:7 exporters::debug: exporter is defined but not used in any pipeline [topology]
+
+service
+  error   collector.yaml:11 service::pipelines::traces::receivers: references receiver "zipkin" which is not defined [topology]
+
+collector.yaml: 2 errors, 1 warnings, 0 notes
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, newTestReport().Write(&out, FormatText, true))
+	assert.Contains(t, out.String(), "\033[31merror  \033[0m collector.yaml:5:5")
+
+	out.Reset()
+	require.NoError(t, New("lint", "collector.yaml", nil).Write(&out, FormatText, false))
+	assert.Equal(t, "collector.yaml: 0 errors, 0 warnings, 0 notes\n", out.String())
+}
+
+func TestReport_WriteSARIF(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newTestReport().Write(&out, FormatSARIF, false))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, []sarifRule{{ID: "schema"}, {ID: "topology"}}, log.Runs[0].Tool.Driver.Rules)
+	require.Len(t, log.Runs[0].Results, 3)
+	assert.Equal(t, sarifResult{
+		RuleID:  "schema",
+		Level:   SeverityError,
+		Message: sarifMessage{Text: "endpoint is required"},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "collector.yaml"}, Region: &sarifRegion{StartLine: 5, StartColumn: 5}},
+			LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "exporters::otlp"}},
+		}},
+	}, log.Runs[0].Results[0])
+}
+
+func TestReport_Documentation(t *testing.T) {
+	report := New("lint", "collector.yaml", []Finding{
+		{Severity: SeverityError, Rule: "acme/no-debug", Group: "exporters", Path: "exporters::debug", Message: "debug exporter is forbidden: exporter debug is configured", Line: 3, Documentation: "see https://wiki.acme.com/otel"},
+	})
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out, FormatText, false))
+	assert.Contains(t, out.String(), "exporter debug is configured [acme/no-debug]\n          see https://wiki.acme.com/otel\n")
+
+	out.Reset()
+	require.NoError(t, report.Write(&out, FormatSARIF, false))
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, []sarifRule{{ID: "acme/no-debug", Help: &sarifMessage{Text: "see https://wiki.acme.com/otel"}}}, log.Runs[0].Tool.Driver.Rules)
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, New("lint", "collector.yaml", nil).Write(&out, FormatJSON, false))
+	assert.JSONEq(t, `{"command": "lint", "file": "collector.yaml", "findings": [], "summary": {"errors": 0, "warnings": 0, "notes": 0}}`, out.String())
+
+	assert.EqualError(t, New("lint", "collector.yaml", nil).Write(&out, "xml", false), `unsupported format "xml", it can be text, json, sarif`)
+}
+
+func TestUseColor(t *testing.T) {
+	color, err := UseColor(ColorAlways, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.True(t, color)
+	color, err = UseColor(ColorAuto, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.False(t, color)
+	_, err = UseColor("rainbow", &bytes.Buffer{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// toolName and toolURI identify the server in the SARIF reports
+	toolName = "opentelemetry-mcp-server"
+	toolURI  = "https://github.com/pavolloffay/opentelemetry-mcp-server"
+)
+
+// sarifLog is the subset of SARIF 2.1.0 read by code review tools e.g. GitHub code scanning
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string        `json:"id"`
+	Help *sarifMessage `json:"help,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     Severity        `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarif returns the report as a SARIF log with a run of the server, the findings without a line are reported on the
+// file and their path as logical location
+func (r Report) sarif() sarifLog {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: toolName, InformationURI: toolURI, Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	// The rules map to their documentation, the help text of the SARIF rule
+	rules := make(map[string]string)
+	for _, finding := range r.Findings {
+		if rules[finding.Rule] == "" {
+			rules[finding.Rule] = finding.Documentation
+		}
+		file := r.File
+		if finding.File != "" {
+			file = finding.File
+		}
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)}}}
+		if finding.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: finding.Line, StartColumn: finding.Column}
+		}
+		if finding.Path != "" {
+			location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: finding.Path}}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    finding.Rule,
+			Level:     finding.Severity,
+			Message:   sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+	for rule, documentation := range rules {
+		sarifRule := sarifRule{ID: rule}
+		if documentation != "" {
+			sarifRule.Help = &sarifMessage{Text: documentation}
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule)
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool { return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID })
+	return sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}
+}
@@ -0,0 +1,247 @@
+// Package searchlog records the documentation search queries and the feedback of the agents on their results,
+// operators use the report of low-scoring and failed queries to tune the search
+package searchlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EntryQuery is a search query
+	EntryQuery = "query"
+	// EntryFeedback is a vote on a search result
+	EntryFeedback = "feedback"
+
+	// RatingUp is a relevant result
+	RatingUp = "up"
+	// RatingDown is an irrelevant result
+	RatingDown = "down"
+
+	// maxEntries is the maximum number of entries kept in memory, the oldest are dropped
+	maxEntries = 10000
+)
+
+// Entry is a recorded search query or feedback
+type Entry struct {
+	Type      string    `json:"type"`
+	Time      time.Time `json:"time"`
+	Query     string    `json:"query"`
+	Version   string    `json:"version,omitempty"`
+	Component string    `json:"component,omitempty"`
+	// ResultIDs are the IDs of the results of a query ordered by score
+	ResultIDs []string `json:"resultIds,omitempty"`
+	// TopScore is the score of the best result of a query
+	TopScore float32 `json:"topScore,omitempty"`
+	Error    string  `json:"error,omitempty"`
+	// ResultID is the result the feedback is about
+	ResultID string `json:"resultId,omitempty"`
+	Rating   string `json:"rating,omitempty"`
+}
+
+// Store keeps the entries in memory and appends them to a JSON lines file if one is configured
+type Store struct {
+	path    string
+	mutex   sync.Mutex
+	entries []Entry
+	now     func() time.Time
+}
+
+// NewStore creates a search log, the entries are persisted to the file unless the path is empty.
+// Entries of an existing file are loaded.
+func NewStore(path string) (*Store, error) {
+	store := &Store{path: path, now: time.Now}
+	if path == "" {
+		return store, nil
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open search log: %w", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse search log line %d: %w", line, err)
+		}
+		store.append(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read search log: %w", err)
+	}
+	return store, nil
+}
+
+// RecordQuery records a search query with its results or the error
+func (s *Store) RecordQuery(entry Entry) error {
+	entry.Type = EntryQuery
+	return s.record(entry)
+}
+
+// RecordFeedback records a vote on a search result of a query
+func (s *Store) RecordFeedback(query, resultID, rating string) error {
+	if rating != RatingUp && rating != RatingDown {
+		return fmt.Errorf("invalid rating %q, it can be %s or %s", rating, RatingUp, RatingDown)
+	}
+	if resultID == "" {
+		return fmt.Errorf("the result ID is required for feedback")
+	}
+	return s.record(Entry{Type: EntryFeedback, Query: query, ResultID: resultID, Rating: rating})
+}
+
+// Entries returns the recorded entries ordered by time
+func (s *Store) Entries() []Entry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Entry(nil), s.entries...)
+}
+
+func (s *Store) record(entry Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entry.Time = s.now()
+	if s.path != "" {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal search log entry: %w", err)
+		}
+		file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open search log: %w", err)
+		}
+		defer file.Close()
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("failed to write search log: %w", err)
+		}
+	}
+	s.append(entry)
+	return nil
+}
+
+// append adds an entry dropping the oldest ones over the limit, the caller must hold the mutex
+func (s *Store) append(entry Entry) {
+	s.entries = append(s.entries, entry)
+	if len(s.entries) > maxEntries {
+		s.entries = s.entries[len(s.entries)-maxEntries:]
+	}
+}
+
+// QueryStats aggregates the executions of the same query
+type QueryStats struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+	// TopScore is the best score of the query results across the executions
+	TopScore float32 `json:"topScore"`
+	Errors   int     `json:"errors,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// ResultFeedback aggregates the votes on a search result
+type ResultFeedback struct {
+	ResultID string   `json:"resultId"`
+	Up       int      `json:"up"`
+	Down     int      `json:"down"`
+	Queries  []string `json:"queries"`
+}
+
+// Report lists the queries the search served poorly and the feedback on the results
+type Report struct {
+	Queries    int              `json:"queries"`
+	Failed     []QueryStats     `json:"failed"`
+	LowScoring []QueryStats     `json:"lowScoring"`
+	Empty      []QueryStats     `json:"empty"`
+	Feedback   []ResultFeedback `json:"feedback"`
+}
+
+// Report aggregates the entries, queries whose best result scored below minScore are low-scoring.
+// Feedback is ordered by the most down votes first.
+func (s *Store) Report(minScore float32) Report {
+	report := Report{Failed: []QueryStats{}, LowScoring: []QueryStats{}, Empty: []QueryStats{}, Feedback: []ResultFeedback{}}
+	queries := make(map[string]*QueryStats)
+	feedback := make(map[string]*ResultFeedback)
+	for _, entry := range s.Entries() {
+		switch entry.Type {
+		case EntryQuery:
+			report.Queries++
+			stats, ok := queries[entry.Query]
+			if !ok {
+				stats = &QueryStats{Query: entry.Query}
+				queries[entry.Query] = stats
+			}
+			stats.Count++
+			if entry.Error != "" {
+				stats.Errors++
+				stats.Error = entry.Error
+			}
+			stats.TopScore = max(stats.TopScore, entry.TopScore)
+		case EntryFeedback:
+			resultFeedback, ok := feedback[entry.ResultID]
+			if !ok {
+				resultFeedback = &ResultFeedback{ResultID: entry.ResultID}
+				feedback[entry.ResultID] = resultFeedback
+			}
+			if entry.Rating == RatingUp {
+				resultFeedback.Up++
+			} else {
+				resultFeedback.Down++
+			}
+			if entry.Query != "" && !slices.Contains(resultFeedback.Queries, entry.Query) {
+				resultFeedback.Queries = append(resultFeedback.Queries, entry.Query)
+			}
+		}
+	}
+
+	for _, stats := range queries {
+		switch {
+		case stats.Errors > 0:
+			report.Failed = append(report.Failed, *stats)
+		case stats.TopScore == 0:
+			report.Empty = append(report.Empty, *stats)
+		case stats.TopScore < minScore:
+			report.LowScoring = append(report.LowScoring, *stats)
+		}
+	}
+	for _, resultFeedback := range feedback {
+		report.Feedback = append(report.Feedback, *resultFeedback)
+	}
+
+	byCount := func(stats []QueryStats) func(i, j int) bool {
+		return func(i, j int) bool {
+			if stats[i].Count != stats[j].Count {
+				return stats[i].Count > stats[j].Count
+			}
+			return stats[i].Query < stats[j].Query
+		}
+	}
+	sort.Slice(report.Failed, byCount(report.Failed))
+	sort.Slice(report.Empty, byCount(report.Empty))
+	sort.Slice(report.LowScoring, func(i, j int) bool {
+		if report.LowScoring[i].TopScore != report.LowScoring[j].TopScore {
+			return report.LowScoring[i].TopScore < report.LowScoring[j].TopScore
+		}
+		return report.LowScoring[i].Query < report.LowScoring[j].Query
+	})
+	sort.Slice(report.Feedback, func(i, j int) bool {
+		if report.Feedback[i].Down != report.Feedback[j].Down {
+			return report.Feedback[i].Down > report.Feedback[j].Down
+		}
+		return report.Feedback[i].ResultID < report.Feedback[j].ResultID
+	})
+	return report
+}
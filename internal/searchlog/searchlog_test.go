@@ -0,0 +1,54 @@
+package searchlog
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Report(t *testing.T) {
+	store, err := NewStore("")
+	require.NoError(t, err)
+
+	require.NoError(t, store.RecordQuery(Entry{Query: "kafka exporter", ResultIDs: []string{"0.139.0/exporter_kafka"}, TopScore: 0.9}))
+	require.NoError(t, store.RecordQuery(Entry{Query: "limit memory", ResultIDs: []string{"0.139.0/processor_memory_limiter"}, TopScore: 0.2}))
+	require.NoError(t, store.RecordQuery(Entry{Query: "limit memory", ResultIDs: []string{"0.139.0/processor_memory_limiter"}, TopScore: 0.25}))
+	require.NoError(t, store.RecordQuery(Entry{Query: "unknown", Error: "failed to query RAG database"}))
+	require.NoError(t, store.RecordQuery(Entry{Query: "nothing", Version: "0.1.0"}))
+	require.NoError(t, store.RecordFeedback("limit memory", "0.139.0/processor_memory_limiter", RatingDown))
+	require.NoError(t, store.RecordFeedback("kafka exporter", "0.139.0/exporter_kafka", RatingUp))
+	assert.Error(t, store.RecordFeedback("kafka exporter", "0.139.0/exporter_kafka", "meh"))
+	assert.Error(t, store.RecordFeedback("kafka exporter", "", RatingUp))
+
+	report := store.Report(0.3)
+	assert.Equal(t, 5, report.Queries)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, "unknown", report.Failed[0].Query)
+	assert.Equal(t, "failed to query RAG database", report.Failed[0].Error)
+	require.Len(t, report.Empty, 1)
+	assert.Equal(t, "nothing", report.Empty[0].Query)
+	require.Len(t, report.LowScoring, 1)
+	assert.Equal(t, QueryStats{Query: "limit memory", Count: 2, TopScore: 0.25}, report.LowScoring[0])
+	require.Len(t, report.Feedback, 2)
+	assert.Equal(t, ResultFeedback{ResultID: "0.139.0/processor_memory_limiter", Down: 1, Queries: []string{"limit memory"}}, report.Feedback[0])
+}
+
+func TestStore_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.jsonl")
+	store, err := NewStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.RecordQuery(Entry{Query: "kafka exporter", TopScore: 0.9}))
+	require.NoError(t, store.RecordFeedback("kafka exporter", "0.139.0/exporter_kafka", RatingUp))
+
+	store, err = NewStore(path)
+	require.NoError(t, err)
+	entries := store.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, EntryQuery, entries[0].Type)
+	assert.Equal(t, float32(0.9), entries[0].TopScore)
+	assert.Equal(t, EntryFeedback, entries[1].Type)
+	assert.Equal(t, RatingUp, entries[1].Rating)
+	assert.False(t, entries[1].Time.IsZero())
+}
@@ -0,0 +1,181 @@
+// Package snapshots stores named collector configurations of a client session so tools can reference them
+// instead of receiving the full configuration in every call
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+// URIScheme is the scheme of the snapshot references e.g. snapshot://current-prod
+const URIScheme = "snapshot"
+
+const (
+	// maxSnapshotSize is the maximum size of a snapshot in bytes
+	maxSnapshotSize = 1024 * 1024
+	// maxSnapshots is the maximum number of snapshots of a session
+	maxSnapshots = 50
+)
+
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,63}$`)
+
+// Snapshot is a named configuration
+type Snapshot struct {
+	Name    string    `json:"name"`
+	Config  string    `json:"config,omitempty"`
+	Size    int       `json:"size"`
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// URI returns the reference of the snapshot e.g. snapshot://current-prod
+func (s *Snapshot) URI() string {
+	return URIScheme + "://" + s.Name
+}
+
+// Store keeps the snapshots of each session in the storage of the server
+type Store struct {
+	storage storage.Store
+	// mutex serializes the saves checking the number of snapshots of a session
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+// NewStore creates a snapshot store keeping the snapshots in the storage
+func NewStore(store storage.Store) *Store {
+	return &Store{storage: store, now: time.Now}
+}
+
+// NameFromURI returns the snapshot name of a snapshot reference e.g. current-prod for snapshot://current-prod
+func NameFromURI(value string) (string, bool) {
+	return strings.CutPrefix(value, URIScheme+"://")
+}
+
+// Save stores the configuration as a snapshot of the session, an existing snapshot with the name is replaced
+func (s *Store) Save(session, name, config string) (*Snapshot, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid snapshot name %q, use up to 64 letters, digits, '.', '_' and '-'", name)
+	}
+	if len(config) > maxSnapshotSize {
+		return nil, fmt.Errorf("snapshot %s is %d bytes, the maximum is %d", name, len(config), maxSnapshotSize)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	names, err := s.names(session)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(names, name) && len(names) >= maxSnapshots {
+		return nil, fmt.Errorf("the session has %d snapshots, delete one before saving %s", maxSnapshots, name)
+	}
+
+	snapshot := &Snapshot{Name: name, Config: config, Size: len(config), SavedAt: s.now()}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot %s: %w", name, err)
+	}
+	if err := s.storage.Put(storage.NamespaceSnapshots, key(session, name), data); err != nil {
+		return nil, fmt.Errorf("failed to persist snapshot %s: %w", name, err)
+	}
+	return snapshot, nil
+}
+
+// Get returns a snapshot of the session
+func (s *Store) Get(session, name string) (*Snapshot, error) {
+	snapshot, err := s.get(session, name)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		names, err := s.names(session)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("snapshot %s not found, saved snapshots: %s", name, strings.Join(names, ", "))
+	}
+	return snapshot, nil
+}
+
+// List returns the snapshots of the session without their configuration ordered by name
+func (s *Store) List(session string) ([]Snapshot, error) {
+	names, err := s.names(session)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Snapshot, 0, len(names))
+	for _, name := range names {
+		snapshot, err := s.get(session, name)
+		if err != nil {
+			return nil, err
+		}
+		// The snapshot was deleted concurrently
+		if snapshot == nil {
+			continue
+		}
+		snapshot.Config = ""
+		result = append(result, *snapshot)
+	}
+	return result, nil
+}
+
+// Delete removes a snapshot of the session
+func (s *Store) Delete(session, name string) error {
+	snapshot, err := s.get(session, name)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("snapshot %s not found", name)
+	}
+	if err := s.storage.Delete(storage.NamespaceSnapshots, key(session, name)); err != nil {
+		return fmt.Errorf("failed to delete snapshot %s: %w", name, err)
+	}
+	return nil
+}
+
+// get returns a snapshot of the session, nil if it is not found
+func (s *Store) get(session, name string) (*Snapshot, error) {
+	if !namePattern.MatchString(name) {
+		return nil, nil
+	}
+	data, found, err := s.storage.Get(storage.NamespaceSnapshots, key(session, name))
+	if err != nil || !found {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", name, err)
+	}
+	return &snapshot, nil
+}
+
+// names returns the sorted names of the snapshots of the session
+func (s *Store) names(session string) ([]string, error) {
+	prefix := sessionPrefix(session)
+	keys, err := s.storage.Keys(storage.NamespaceSnapshots, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, strings.TrimPrefix(key, prefix))
+	}
+	return names, nil
+}
+
+// key returns the storage key of a snapshot of the session
+func key(session, name string) string {
+	return sessionPrefix(session) + name
+}
+
+// sessionPrefix returns the prefix of the storage keys of the session snapshots
+func sessionPrefix(session string) string {
+	return storage.SessionKey(session) + "/"
+}
@@ -0,0 +1,84 @@
+package snapshots
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(storage.NewMemory())
+
+	snapshot, err := store.Save("session-a", "current-prod", "receivers: {}")
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot://current-prod", snapshot.URI())
+	_, err = store.Save("session-a", "proposed", "exporters: {}")
+	require.NoError(t, err)
+
+	snapshot, err = store.Get("session-a", "current-prod")
+	require.NoError(t, err)
+	assert.Equal(t, "receivers: {}", snapshot.Config)
+
+	// Sessions do not see each other's snapshots
+	_, err = store.Get("session-b", "current-prod")
+	assert.Error(t, err)
+
+	list, err := store.List("session-a")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "current-prod", list[0].Name)
+	assert.Empty(t, list[0].Config)
+	assert.Equal(t, 13, list[0].Size)
+
+	require.NoError(t, store.Delete("session-a", "proposed"))
+	_, err = store.Get("session-a", "proposed")
+	assert.ErrorContains(t, err, "saved snapshots: current-prod")
+	assert.Error(t, store.Delete("session-a", "proposed"))
+
+	_, err = store.Save("session-a", "../etc/passwd", "")
+	assert.Error(t, err)
+	_, err = store.Save("session-a", "large", strings.Repeat("a", maxSnapshotSize+1))
+	assert.Error(t, err)
+	for i := 0; i < maxSnapshots-1; i++ {
+		_, err = store.Save("session-a", fmt.Sprintf("s%d", i), "")
+		require.NoError(t, err)
+	}
+	_, err = store.Save("session-a", "one-too-many", "")
+	assert.Error(t, err)
+	_, err = store.Save("session-a", "current-prod", "replaced")
+	assert.NoError(t, err)
+}
+
+func TestStore_Persistence(t *testing.T) {
+	dir := t.TempDir()
+	newStore := func() *Store {
+		disk, err := storage.NewDisk(dir)
+		require.NoError(t, err)
+		return NewStore(disk)
+	}
+	_, err := newStore().Save("stdio", "current-prod", "receivers: {}")
+	require.NoError(t, err)
+
+	store := newStore()
+	snapshot, err := store.Get("stdio", "current-prod")
+	require.NoError(t, err)
+	assert.Equal(t, "receivers: {}", snapshot.Config)
+	assert.False(t, snapshot.SavedAt.IsZero())
+
+	require.NoError(t, store.Delete("stdio", "current-prod"))
+	_, err = newStore().Get("stdio", "current-prod")
+	assert.Error(t, err)
+}
+
+func TestNameFromURI(t *testing.T) {
+	name, ok := NameFromURI("snapshot://proposed")
+	assert.True(t, ok)
+	assert.Equal(t, "proposed", name)
+	_, ok = NameFromURI("receivers: {}")
+	assert.False(t, ok)
+}
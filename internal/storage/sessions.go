@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sessionNamespaces are the namespaces keeping the state of the client sessions under their SessionKey, the keys of a
+// session are the SessionKey or start with it and a slash
+var sessionNamespaces = []string{NamespaceVersionPins, NamespaceValidationProfiles, NamespaceSnapshots}
+
+// SessionExpiry records when the client sessions were last seen and deletes the state of the sessions idle for longer
+// than the TTL, the clients do not reliably end their sessions
+type SessionExpiry struct {
+	storage Store
+	ttl     time.Duration
+	now     func() time.Time
+
+	mutex        sync.Mutex
+	lastEviction time.Time
+}
+
+// NewSessionExpiry creates the expiry of the session state kept in the storage
+func NewSessionExpiry(store Store, ttl time.Duration) *SessionExpiry {
+	return &SessionExpiry{storage: store, ttl: ttl, now: time.Now}
+}
+
+// Touch records that the session was seen and deletes the state of the expired sessions, the expired sessions are
+// looked up at most once per minute or TTL if it is shorter
+func (e *SessionExpiry) Touch(session string) error {
+	now := e.now()
+	if err := e.storage.Put(NamespaceSessions, SessionKey(session), []byte(now.UTC().Format(time.RFC3339Nano))); err != nil {
+		return fmt.Errorf("failed to record the session: %w", err)
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if now.Sub(e.lastEviction) < min(e.ttl, time.Minute) {
+		return nil
+	}
+	e.lastEviction = now
+	return e.evictExpired(now)
+}
+
+// evictExpired deletes the state of the sessions last seen before the TTL
+func (e *SessionExpiry) evictExpired(now time.Time) error {
+	keys, err := e.storage.Keys(NamespaceSessions, "")
+	if err != nil {
+		return fmt.Errorf("failed to list the sessions: %w", err)
+	}
+	for _, key := range keys {
+		value, found, err := e.storage.Get(NamespaceSessions, key)
+		if err != nil {
+			return err
+		}
+		// The session was deleted concurrently
+		if !found {
+			continue
+		}
+		// A session with an unreadable time is expired
+		seen, _ := time.Parse(time.RFC3339Nano, string(value))
+		if now.Sub(seen) <= e.ttl {
+			continue
+		}
+		if err := e.deleteSession(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteSession deletes the state of the session key in every namespace and then the session
+func (e *SessionExpiry) deleteSession(key string) error {
+	for _, namespace := range sessionNamespaces {
+		keys, err := e.storage.Keys(namespace, key)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", namespace, err)
+		}
+		for _, stateKey := range keys {
+			if err := e.storage.Delete(namespace, stateKey); err != nil {
+				return err
+			}
+		}
+	}
+	return e.storage.Delete(NamespaceSessions, key)
+}
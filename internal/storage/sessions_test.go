@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionExpiry(t *testing.T) {
+	store := NewMemory()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiry := NewSessionExpiry(store, time.Hour)
+	expiry.now = func() time.Time { return now }
+
+	idle, active := SessionKey("idle"), SessionKey("active")
+	require.NoError(t, expiry.Touch("idle"))
+	require.NoError(t, store.Put(NamespaceVersionPins, idle, []byte("0.139.0")))
+	require.NoError(t, store.Put(NamespaceValidationProfiles, idle, []byte("ci")))
+	require.NoError(t, store.Put(NamespaceSnapshots, idle+"/prod", []byte("receivers: {}")))
+	require.NoError(t, store.Put(NamespaceVersionPins, active, []byte("0.138.0")))
+	require.NoError(t, store.Put(NamespaceArtifacts, "3f2a", []byte("{}")))
+
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, expiry.Touch("active"))
+	now = now.Add(time.Hour)
+	require.NoError(t, expiry.Touch("active"))
+
+	for _, namespace := range sessionNamespaces {
+		keys, err := store.Keys(namespace, idle)
+		require.NoError(t, err)
+		assert.Empty(t, keys, namespace)
+	}
+	keys, err := store.Keys(NamespaceSessions, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{active}, keys)
+	_, found, err := store.Get(NamespaceVersionPins, active)
+	require.NoError(t, err)
+	assert.True(t, found)
+	_, found, err = store.Get(NamespaceArtifacts, "3f2a")
+	require.NoError(t, err)
+	assert.True(t, found)
+}
@@ -0,0 +1,244 @@
+// Package storage stores the state of the server: the session preferences, the config snapshots and the artifacts.
+// The state is kept in memory, or in a directory so a long-lived server keeps it across restarts and several servers
+// can later share it on a shared volume.
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The kinds of storage selected by the --storage flag
+const (
+	KindMemory = "memory"
+	KindDisk   = "disk"
+)
+
+// The namespaces of the features using the storage
+const (
+	NamespaceVersionPins        = "version-pins"
+	NamespaceValidationProfiles = "validation-profiles"
+	NamespaceSnapshots          = "snapshots"
+	NamespaceArtifacts          = "artifacts"
+	NamespaceSessions           = "sessions"
+)
+
+// maxKeyLength is the maximum length of a key, the disk storage names the files after the hex encoded keys
+const maxKeyLength = 120
+
+// Store stores values by namespace and key. The implementations are safe for concurrent use.
+type Store interface {
+	// Get returns the value of the key, false if the key is not stored
+	Get(namespace, key string) ([]byte, bool, error)
+	// Put stores the value of the key, an existing value is replaced
+	Put(namespace, key string, value []byte) error
+	// Delete removes the key, removing a key that is not stored is not an error
+	Delete(namespace, key string) error
+	// Keys returns the sorted keys of the namespace starting with the prefix
+	Keys(namespace, prefix string) ([]string, error)
+}
+
+// New returns the storage of the kind, dir is the directory of the disk storage
+func New(kind, dir string) (Store, error) {
+	switch kind {
+	case KindMemory, "":
+		if dir != "" {
+			return nil, fmt.Errorf("the %s storage has no directory, use the %s storage to persist the state in %s", KindMemory, KindDisk, dir)
+		}
+		return NewMemory(), nil
+	case KindDisk:
+		return NewDisk(dir)
+	default:
+		return nil, fmt.Errorf("unsupported storage %q, supported storages: %s, %s", kind, KindMemory, KindDisk)
+	}
+}
+
+// Memory keeps the values in memory, they are lost when the server stops
+type Memory struct {
+	mutex      sync.Mutex
+	namespaces map[string]map[string][]byte
+}
+
+// NewMemory creates an empty memory storage
+func NewMemory() *Memory {
+	return &Memory{namespaces: make(map[string]map[string][]byte)}
+}
+
+// Get implements Store
+func (m *Memory) Get(namespace, key string) ([]byte, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	value, ok := m.namespaces[namespace][key]
+	return value, ok, nil
+}
+
+// Put implements Store
+func (m *Memory) Put(namespace, key string, value []byte) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.namespaces[namespace] == nil {
+		m.namespaces[namespace] = make(map[string][]byte)
+	}
+	// The caller may reuse the value
+	m.namespaces[namespace][key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete implements Store
+func (m *Memory) Delete(namespace, key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.namespaces[namespace], key)
+	return nil
+}
+
+// Keys implements Store
+func (m *Memory) Keys(namespace, prefix string) ([]string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	keys := []string{}
+	for key := range m.namespaces[namespace] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Disk stores a value per file in a directory per namespace, the files are named after the hex encoded keys. The
+// values are written atomically, so servers sharing the directory see the previous or the new value.
+type Disk struct {
+	dir string
+}
+
+// NewDisk creates a disk storage in the directory, the values of a previous run are kept
+func NewDisk(dir string) (*Disk, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("the %s storage requires a directory", KindDisk)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &Disk{dir: dir}, nil
+}
+
+// Dir returns the directory of the storage
+func (d *Disk) Dir() string {
+	return d.dir
+}
+
+// Get implements Store
+func (d *Disk) Get(namespace, key string) ([]byte, bool, error) {
+	if checkKey(key) != nil {
+		return nil, false, nil
+	}
+	value, err := os.ReadFile(d.path(namespace, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s %s: %w", namespace, key, err)
+	}
+	return value, true, nil
+}
+
+// Put implements Store
+func (d *Disk) Put(namespace, key string, value []byte) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(d.dir, namespace), 0o700); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	if err := writeFile(d.path(namespace, key), value); err != nil {
+		return fmt.Errorf("failed to store %s %s: %w", namespace, key, err)
+	}
+	return nil
+}
+
+// Delete implements Store
+func (d *Disk) Delete(namespace, key string) error {
+	if checkKey(key) != nil {
+		return nil
+	}
+	if err := os.Remove(d.path(namespace, key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s %s: %w", namespace, key, err)
+	}
+	return nil
+}
+
+// Keys implements Store
+func (d *Disk) Keys(namespace, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(d.dir, namespace))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list %s: %w", namespace, err)
+	}
+	keys := []string{}
+	for _, entry := range entries {
+		// The temporary files of the atomic writes and foreign files are skipped
+		decoded, err := hex.DecodeString(entry.Name())
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		if key := string(decoded); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (d *Disk) path(namespace, key string) string {
+	return filepath.Join(d.dir, namespace, hex.EncodeToString([]byte(key)))
+}
+
+// SessionKey returns the key of the state of a client session, session IDs are hashed as they are client provided
+func SessionKey(session string) string {
+	hash := sha256.Sum256([]byte(session))
+	return hex.EncodeToString(hash[:8])
+}
+
+// checkKey rejects the keys the disk storage cannot name a file after, both storages reject them so a server behaves
+// the same with either
+func checkKey(key string) error {
+	if key == "" {
+		return errors.New("the storage key is empty")
+	}
+	if len(key) > maxKeyLength {
+		return fmt.Errorf("the storage key %s... is longer than %d bytes", key[:16], maxKeyLength)
+	}
+	return nil
+}
+
+// writeFile writes the file atomically, a concurrent reader sees the previous or the new content
+func writeFile(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	disk, err := NewDisk(t.TempDir())
+	require.NoError(t, err)
+	for name, store := range map[string]Store{"memory": NewMemory(), "disk": disk} {
+		t.Run(name, func(t *testing.T) {
+			_, found, err := store.Get(NamespaceSnapshots, "a1/prod")
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			require.NoError(t, store.Put(NamespaceSnapshots, "a1/prod", []byte("receivers: {}")))
+			require.NoError(t, store.Put(NamespaceSnapshots, "a1/../staging", []byte("exporters: {}")))
+			require.NoError(t, store.Put(NamespaceSnapshots, "b2/prod", []byte("processors: {}")))
+			require.NoError(t, store.Put(NamespaceVersionPins, "a1", []byte("0.139.0")))
+
+			value, found, err := store.Get(NamespaceSnapshots, "a1/prod")
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, "receivers: {}", string(value))
+
+			keys, err := store.Keys(NamespaceSnapshots, "a1/")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a1/../staging", "a1/prod"}, keys)
+
+			require.NoError(t, store.Delete(NamespaceSnapshots, "a1/prod"))
+			require.NoError(t, store.Delete(NamespaceSnapshots, "a1/prod"))
+			keys, err = store.Keys(NamespaceSnapshots, "")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a1/../staging", "b2/prod"}, keys)
+
+			keys, err = store.Keys(NamespaceArtifacts, "")
+			require.NoError(t, err)
+			assert.Empty(t, keys)
+
+			assert.Error(t, store.Put(NamespaceSnapshots, "", nil))
+			assert.Error(t, store.Put(NamespaceSnapshots, strings.Repeat("a", maxKeyLength+1), nil))
+		})
+	}
+}
+
+func TestDisk_Restart(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDisk(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(NamespaceVersionPins, "session", []byte("0.139.0")))
+	// A leftover temporary file of an interrupted write is not a key
+	require.NoError(t, os.WriteFile(filepath.Join(dir, NamespaceVersionPins, "73657373696f6e.tmp123"), nil, 0o600))
+
+	restarted, err := NewDisk(dir)
+	require.NoError(t, err)
+	value, found, err := restarted.Get(NamespaceVersionPins, "session")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "0.139.0", string(value))
+	keys, err := restarted.Keys(NamespaceVersionPins, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"session"}, keys)
+}
+
+func TestNew(t *testing.T) {
+	store, err := New(KindMemory, "")
+	require.NoError(t, err)
+	assert.IsType(t, &Memory{}, store)
+
+	store, err = New(KindDisk, t.TempDir())
+	require.NoError(t, err)
+	assert.IsType(t, &Disk{}, store)
+
+	_, err = New(KindDisk, "")
+	assert.ErrorContains(t, err, "requires a directory")
+	_, err = New(KindMemory, t.TempDir())
+	assert.ErrorContains(t, err, "has no directory")
+	_, err = New("bolt", "")
+	assert.ErrorContains(t, err, "unsupported storage")
+}
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// AdvisoryReport lists the advisories affecting a collector version
+type AdvisoryReport struct {
+	Version    string                     `json:"version"`
+	Advisories []collectorschema.Advisory `json:"advisories"`
+	// AffectedComponents are the components of the configuration affected by the advisories
+	AffectedComponents map[string][]string `json:"affectedComponents,omitempty"`
+	// FixedIn is the lowest version fixing all reported advisories, empty if an advisory has no fix
+	FixedIn string `json:"fixedIn,omitempty"`
+	// Unfixed are the IDs of the reported advisories without a fixed version, no upgrade resolves them
+	Unfixed []string `json:"unfixed,omitempty"`
+}
+
+// GetAdvisoryTools returns the opt-in security advisory tools, advisoriesURL enables refreshing the embedded advisories
+func GetAdvisoryTools(schemaManager *collectorschema.SchemaManager, advisoriesURL string) []Tool {
+	return []Tool{
+		getAdvisoryLookupTool(schemaManager, advisoriesURL),
+	}
+}
+
+// getAdvisoryLookupTool returns the security advisory lookup tool
+func getAdvisoryLookupTool(schemaManager *collectorschema.SchemaManager, advisoriesURL string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-advisories",
+		mcp.WithDescription("Check an OpenTelemetry collector version against known CVEs and security advisories. Returns the affected components and the version fixing them, advisories without a fix are listed as unfixed."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(advisoriesURL != ""),
+		mcp.WithOutputSchema[AdvisoryReport](),
+		mcp.WithString("version",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("config",
+			mcp.Description("The OpenTelemetry Collector configuration YAML. If provided only advisories affecting the configured components are reported."),
+		),
+		mcp.WithBoolean("refresh",
+			mcp.Description("Download the latest advisories before the lookup. Requires the server to be started with --advisories-url."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version, err := request.RequireString("version")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("version argument is required: %v", err)), nil
+		}
+
+		if request.GetBool("refresh", false) {
+			if advisoriesURL == "" {
+				return mcp.NewToolResultError("refreshing advisories is disabled, start the server with --advisories-url"), nil
+			}
+			if err := schemaManager.RefreshAdvisories(ctx, advisoriesURL); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to refresh advisories: %v", err)), nil
+			}
+		}
+
+		advisories, err := schemaManager.GetAdvisories(version)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get advisories for %s: %v", version, err)), nil
+		}
+
+		report := AdvisoryReport{Version: version, Advisories: []collectorschema.Advisory{}}
+		if configYAML := request.GetString("config", ""); configYAML != "" {
+			config, err := collectorconfig.Parse([]byte(configYAML))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			report.AffectedComponents = make(map[string][]string)
+			for _, advisory := range advisories {
+				components := configuredComponents(config, advisory)
+				if len(components) > 0 {
+					report.Advisories = append(report.Advisories, advisory)
+					report.AffectedComponents[advisory.ID] = components
+				}
+			}
+		} else {
+			report.Advisories = append(report.Advisories, advisories...)
+		}
+
+		for _, advisory := range report.Advisories {
+			if advisory.Fixed == "" {
+				report.Unfixed = append(report.Unfixed, advisory.ID)
+			} else if collectorschema.CompareVersions(advisory.Fixed, report.FixedIn) > 0 {
+				report.FixedIn = advisory.Fixed
+			}
+		}
+		if len(report.Unfixed) > 0 {
+			report.FixedIn = ""
+		}
+		return mcp.NewToolResultJSON(report)
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// configuredComponents returns the component IDs of the configuration affected by the advisory
+func configuredComponents(config *collectorconfig.Config, advisory collectorschema.Advisory) []string {
+	sections := []struct {
+		componentType collectorschema.ComponentType
+		components    map[string]interface{}
+	}{
+		{collectorschema.ComponentTypeReceiver, config.Receivers},
+		{collectorschema.ComponentTypeProcessor, config.Processors},
+		{collectorschema.ComponentTypeExporter, config.Exporters},
+		{collectorschema.ComponentTypeConnector, config.Connectors},
+		{collectorschema.ComponentTypeExtension, config.Extensions},
+	}
+
+	var affected []string
+	for _, section := range sections {
+		for id := range section.components {
+			if advisory.AffectsComponent(section.componentType, collectorconfig.ComponentType(id)) {
+				affected = append(affected, string(section.componentType)+"s::"+id)
+			}
+		}
+	}
+	sort.Strings(affected)
+	return affected
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func TestAdvisoryLookupTool_Unfixed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+advisories:
+  - id: GHSA-fixed
+    summary: fixed
+    severity: high
+    components: [receiver/otlp]
+    introduced: 0.0.0
+    fixed: 0.140.0
+  - id: GHSA-unfixed
+    summary: not fixed yet
+    severity: medium
+    components: [exporter/debug]
+    introduced: 0.0.0
+`))
+	}))
+	defer server.Close()
+
+	tool := getAdvisoryLookupTool(collectorschema.NewSchemaManager(), server.URL)
+	lookup := func(args map[string]any) AdvisoryReport {
+		t.Helper()
+		result := callTool(t, tool, args)
+		require.False(t, result.IsError, resultText(result))
+		var report AdvisoryReport
+		require.NoError(t, json.Unmarshal([]byte(resultText(result)), &report))
+		return report
+	}
+
+	// No version fixes every reported advisory
+	report := lookup(map[string]any{"version": testCollectorVersion, "refresh": true})
+	assert.Len(t, report.Advisories, 2)
+	assert.Empty(t, report.FixedIn)
+	assert.Equal(t, []string{"GHSA-unfixed"}, report.Unfixed)
+
+	// Only the fixed advisory affects the configured components
+	report = lookup(map[string]any{"version": testCollectorVersion, "config": "receivers:\n  otlp:\n"})
+	assert.Len(t, report.Advisories, 1)
+	assert.Equal(t, "0.140.0", report.FixedIn)
+	assert.Empty(t, report.Unfixed)
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// getConfigAnnotateTool returns the tool commenting a collector configuration with the schema field descriptions
+func getConfigAnnotateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-annotate",
+		mcp.WithDescription("Annotate a collector configuration with YAML comments explaining each component field, sourced from the component schema descriptions of the collector version. Existing comments, key order and anchors are kept."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[AnnotatedConfigResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("locale",
+			mcp.Description("Locale of the comments e.g. de or pt-BR, fields without a translation bundled with the schemas are explained in English. Defaults to English."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		locale := request.GetString("locale", "")
+
+		document, err := collectorconfig.ParseDocument([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		descriptions := make(map[string]map[string]string)
+		missingSchemas := []string{}
+		annotated := document.Annotate(func(section, id, path string) string {
+			key := section + "::" + collectorconfig.ComponentType(id)
+			componentDescriptions, loaded := descriptions[key]
+			if !loaded {
+				componentType := collectorschema.ComponentTypeFromSection(section)
+				componentDescriptions, err = schemaManager.GetFieldDescriptionsLocalized(componentType, collectorconfig.ComponentType(id), version, locale)
+				if err != nil {
+					missingSchemas = append(missingSchemas, key)
+				}
+				descriptions[key] = componentDescriptions
+			}
+			return componentDescriptions[path]
+		})
+
+		annotatedYAML, err := document.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &AnnotatedConfigResponse{Config: string(annotatedYAML), Annotated: annotated, MissingSchemas: missingSchemas}
+		text := string(annotatedYAML)
+		if len(missingSchemas) > 0 {
+			text += fmt.Sprintf("\nno schema for version %s: %s", version, strings.Join(missingSchemas, ", "))
+		}
+		return artifactResult(artifactStore, "annotated.yaml", "application/yaml", text, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
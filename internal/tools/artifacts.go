@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+)
+
+// artifactInlineLimit is the result size in bytes above which results are returned as resource links
+const artifactInlineLimit = 16 * 1024
+
+// ResourceTemplate represents an MCP resource template with its handler
+type ResourceTemplate struct {
+	Template mcp.ResourceTemplate
+	Handler  func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)
+}
+
+// GetArtifactResourceTemplate returns the resource template serving large tool results from the artifact store
+func GetArtifactResourceTemplate(artifactStore *artifacts.Store) ResourceTemplate {
+	template := mcp.NewResourceTemplate(
+		artifacts.URIScheme+"://{id}/{name}",
+		"Generated artifact",
+		mcp.WithTemplateDescription(fmt.Sprintf("Large tool results e.g. generated collector configurations and schemas. Artifacts expire %s after they are created.", artifactStore.TTL())),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		artifact, err := artifactStore.Get(request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      artifact.URI(),
+				MIMEType: artifact.MIMEType,
+				Text:     artifact.Content,
+			},
+		}, nil
+	}
+
+	return ResourceTemplate{Template: template, Handler: handler}
+}
+
+// artifactResponse is a structured tool response whose large content can be replaced by an artifact resource URI
+type artifactResponse interface {
+	setResourceURI(uri string)
+}
+
+// artifactResult returns the text inline if it is small, otherwise it stores it as an artifact and returns a resource link.
+// The structured response is returned in both cases, with the resource URI instead of the content for artifacts.
+func artifactResult(artifactStore *artifacts.Store, name, mimeType, text string, response artifactResponse) *mcp.CallToolResult {
+	if artifactStore == nil || len(text) <= artifactInlineLimit {
+		return mcp.NewToolResultStructured(response, text)
+	}
+
+	artifact, err := artifactStore.Put(name, mimeType, text)
+	if err != nil {
+		// Fall back to inlining the result, it is still correct just large
+		return mcp.NewToolResultStructured(response, text)
+	}
+	response.setResourceURI(artifact.URI())
+	summary := fmt.Sprintf("The result is %d bytes and is available as resource %s. Read the resource to get the full content, it expires in %s.", len(text), artifact.URI(), artifactStore.TTL())
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(summary),
+			mcp.NewResourceLink(artifact.URI(), name, fmt.Sprintf("%s (%d bytes)", name, len(text)), mimeType),
+		},
+		StructuredContent: response,
+	}
+}
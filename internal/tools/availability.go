@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getComponentAvailabilityTool returns the component availability timeline tool
+func getComponentAvailabilityTool(schemaManager *collectorschema.SchemaManager) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-component-availability",
+		mcp.WithDescription("Report the availability history of an OpenTelemetry collector component across the known collector versions: the first and last version including it, the versions without it and the versions in which its configuration schema changed with the added and removed fields. Answers which collector version is needed for a component or setting."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[collectorschema.ComponentAvailability](),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component name e.g. failover"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+
+		availability, err := schemaManager.GetComponentAvailability(componentType, componentName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		lines := []string{fmt.Sprintf("%s is available in %s to %s", availability.Component, availability.FirstVersion, availability.LastVersion)}
+		if len(availability.MissingVersions) > 0 {
+			lines = append(lines, fmt.Sprintf("not available in %s", strings.Join(availability.MissingVersions, ", ")))
+		}
+		for _, change := range availability.Changes {
+			lines = append(lines, fmt.Sprintf("schema changed in %s (since %s), added fields: %v, removed fields: %v", change.Version, change.Previous, change.AddedFields, change.RemovedFields))
+		}
+		return mcp.NewToolResultStructured(availability, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
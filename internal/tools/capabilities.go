@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetCapabilitiesTool returns the tool describing the served tools, the worked examples of a tool are served on
+// request instead of in the metadata of every listed tool
+func GetCapabilitiesTool(tools []Tool) (Tool, error) {
+	examples, err := loadToolExamples()
+	if err != nil {
+		return Tool{}, err
+	}
+	tool := mcp.NewTool("opentelemetry-mcp-capabilities",
+		mcp.WithDescription("List the tools of this server with their required arguments. Pass a tool to get its worked examples of calls: the arguments and the truncated result. Use the examples to learn the correct usage of a tool before calling it or for few-shot prompting."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[CapabilitiesResponse](),
+		mcp.WithString("tool",
+			mcp.Description("Name of a tool to describe with its examples e.g. opentelemetry-collector-component-schema, all tools without examples if not set"),
+		),
+	)
+
+	capabilities := make([]ToolCapability, 0, len(tools))
+	for _, t := range tools {
+		capabilities = append(capabilities, ToolCapability{
+			Name:         t.Tool.Name,
+			Description:  t.Tool.Description,
+			Required:     t.Tool.InputSchema.Required,
+			ExampleCount: len(examples[t.Tool.Name]),
+		})
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := request.GetString("tool", "")
+		response := CapabilitiesResponse{Tools: capabilities}
+		if name != "" {
+			response.Tools = nil
+			for _, capability := range capabilities {
+				if capability.Name == name {
+					capability.Examples = examples[name]
+					response.Tools = append(response.Tools, capability)
+				}
+			}
+			if len(response.Tools) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s is not served, call the capabilities tool without a tool to list the tools", name)), nil
+			}
+		}
+
+		var lines []string
+		for _, capability := range response.Tools {
+			lines = append(lines, fmt.Sprintf("%s: %d examples, required arguments: %v", capability.Name, capability.ExampleCount, capability.Required))
+			for _, example := range capability.Examples {
+				arguments, err := json.Marshal(example.Arguments)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal the example arguments: %v", err)), nil
+				}
+				lines = append(lines, fmt.Sprintf("\narguments: %s\nresult:\n%s", arguments, example.Output))
+			}
+		}
+		return mcp.NewToolResultStructured(response, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}, nil
+}
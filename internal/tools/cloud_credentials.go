@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getCloudCredentialsTool returns the cloud exporter credential setup tool
+func getCloudCredentialsTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-cloud-credentials",
+		mcp.WithDescription("Generate the credential related configuration of the AWS, Google Cloud and Azure exporters (awsxray, awsemf, googlecloud, azuremonitor) for an auth method e.g. EKS IAM roles for service accounts, GKE Workload Identity or an Application Insights connection string, with the IAM permissions and roles, the environment variables, the region or project and the setup outside of the collector. Use it before writing a cloud exporter configuration so it does not fail at authentication."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[CloudCredentialsResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("exporter",
+			mcp.Required(),
+			mcp.Description("Cloud exporter name"),
+			mcp.Enum(collectorschema.CloudExporterNames()...),
+		),
+		mcp.WithString("auth",
+			mcp.Description("Auth method. It can be irsa, pod_identity, instance_profile, assume_role, env (AWS), workload_identity, metadata, impersonation, service_account_key (Google Cloud) and connection_string (Azure). Defaults to irsa, workload_identity or connection_string."),
+		),
+		mcp.WithString("location",
+			mcp.Description("AWS region e.g. us-east-1 or Google Cloud project ID the telemetry is sent to"),
+		),
+		mcp.WithString("role_arn",
+			mcp.Description("ARN of the IAM role of the assume_role auth e.g. arn:aws:iam::123456789012:role/otel-collector"),
+		),
+		mcp.WithString("service_account",
+			mcp.Description("Email of the service account of the impersonation auth e.g. otel-collector@my-project.iam.gserviceaccount.com"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		exporter, err := request.RequireString("exporter")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("exporter argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		setup, err := collectorschema.GetCloudCredentialSetup(exporter, request.GetString("auth", ""), request.GetString("location", ""), map[string]string{
+			"role_arn":        request.GetString("role_arn", ""),
+			"service_account": request.GetString("service_account", ""),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// The credential fields have to match the schema of the exporter in the version
+		warnings := setup.Warnings
+		configJSON, err := json.Marshal(setup.Config)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal %s config: %v", exporter, err)), nil
+		}
+		validationResult, err := schemaManager.ValidateComponentJSON(collectorschema.ComponentTypeExporter, exporter, version, configJSON)
+		var notFound *collectorschema.ComponentNotFoundError
+		switch {
+		case errors.As(err, &notFound):
+			warnings = append(warnings, fmt.Sprintf("exporter %s has no schema in version %s, its configuration is not validated", exporter, version))
+		case err != nil:
+			return mcp.NewToolResultError(fmt.Sprintf("failed to validate %s config for version %s: %v", exporter, version, err)), nil
+		case !validationResult.Valid():
+			return mcp.NewToolResultError(fmt.Sprintf("%s config is not valid for version %s: %v", exporter, version, validationResult.Errors())), nil
+		}
+
+		configYAML, err := yaml.Marshal(map[string]interface{}{
+			"exporters": map[string]interface{}{exporter: setup.Config},
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal %s config: %v", exporter, err)), nil
+		}
+		configYAML, err = stampConfig(request, version, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := CloudCredentialsResponse{
+			Exporter:     exporter,
+			Cloud:        setup.Exporter.Cloud,
+			Auth:         setup.Auth.Name,
+			Config:       string(configYAML),
+			Permissions:  setup.Exporter.Permissions,
+			Roles:        setup.Exporter.Roles,
+			Env:          setup.Env,
+			Setup:        setup.Setup,
+			Warnings:     warnings,
+			AuthMethods:  setup.AuthMethods,
+			Verification: verification,
+		}
+
+		lines := []string{fmt.Sprintf("%s with %s auth: %s", exporter, setup.Auth.Name, setup.Auth.Description), string(configYAML)}
+		for _, env := range setup.Env {
+			lines = append(lines, fmt.Sprintf("env %s: %s", env.Name, env.Description))
+		}
+		for i, step := range setup.Setup {
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, step))
+		}
+		lines = append(lines, fmt.Sprintf("warnings: %v", warnings), fmt.Sprintf("auth methods: %s", strings.Join(setup.AuthMethods, ", ")), verification.String())
+		return mcp.NewToolResultStructured(response, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
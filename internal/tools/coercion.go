@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithArgumentCoercion converts the arguments of the tool calls to the types of the tool input schemas before the
+// handler reads them, agents often pass a version as a number, a configuration as an object or a list as a comma
+// separated string. The handlers read the arguments with the typed getters of the request, which return the default
+// of an argument of another type, so a version 0.139 would otherwise silently become the latest version. Arguments
+// that cannot be converted are rejected with the expected type and an example.
+func WithArgumentCoercion(tools []Tool) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		handler := tool.Handler
+		properties := tool.Tool.InputSchema.Properties
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			arguments := request.GetArguments()
+			if len(arguments) == 0 || len(properties) == 0 {
+				return handler(ctx, request)
+			}
+			coerced := make(map[string]any, len(arguments))
+			keys := make([]string, 0, len(arguments))
+			for key := range arguments {
+				keys = append(keys, key)
+			}
+			// The first argument that cannot be converted is reported in a stable order
+			sort.Strings(keys)
+			for _, key := range keys {
+				property, ok := properties[key].(map[string]any)
+				if !ok {
+					coerced[key] = arguments[key]
+					continue
+				}
+				value, err := coerceArgument(property, arguments[key])
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid %s argument: %v", key, err)), nil
+				}
+				coerced[key] = value
+			}
+			request.Params.Arguments = coerced
+			return handler(ctx, request)
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
+
+// coerceArgument converts an argument to the type of its property schema, null is kept so the handler applies the
+// default
+func coerceArgument(property map[string]any, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	propertyType, _ := property["type"].(string)
+	switch propertyType {
+	case "string":
+		s, err := coerceString(value)
+		if err != nil {
+			return nil, err
+		}
+		return coerceEnum(property, s), nil
+	case "number", "integer":
+		return coerceNumber(propertyType, value)
+	case "boolean":
+		return coerceBoolean(value)
+	case "array":
+		return coerceArray(property, value)
+	}
+	return value, nil
+}
+
+// coerceString converts numbers and booleans to their text e.g. 1.38 and objects and arrays to JSON
+func coerceString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("expected a string, the %T value cannot be converted to JSON: %w", v, err)
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("expected a string, got %T", value)
+}
+
+// coerceEnum returns the allowed value matching a value ignoring the case e.g. receiver for Receiver, other values are
+// returned as is for the handler to report them
+func coerceEnum(property map[string]any, value string) string {
+	for _, allowed := range enumValues(property) {
+		if strings.EqualFold(allowed, strings.TrimSpace(value)) {
+			return allowed
+		}
+	}
+	return value
+}
+
+// coerceNumber parses numbers passed as strings e.g. "10"
+func coerceNumber(propertyType string, value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		if _, isNumber := value.(float64); isNumber {
+			return value, nil
+		}
+		return nil, fmt.Errorf("expected a %s e.g. 10, got %s", propertyType, describeValue(value))
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected a %s e.g. 10, got %q", propertyType, s)
+	}
+	if propertyType == "integer" && number != float64(int64(number)) {
+		return nil, fmt.Errorf("expected an integer e.g. 10, got %q", s)
+	}
+	return number, nil
+}
+
+// coerceBoolean parses booleans passed as strings e.g. "true"
+func coerceBoolean(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		if _, isBool := value.(bool); isBool {
+			return value, nil
+		}
+		return nil, fmt.Errorf("expected true or false, got %s", describeValue(value))
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("expected true or false, got %q", s)
+	}
+	return b, nil
+}
+
+// coerceArray converts a JSON array or a comma separated string e.g. "otlp, batch" to an array and a single value to
+// an array of it, the items are converted to the item type
+func coerceArray(property map[string]any, value any) (any, error) {
+	var items []any
+	switch v := value.(type) {
+	case []any:
+		items = v
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if strings.HasPrefix(trimmed, "[") {
+			if err := json.Unmarshal([]byte(trimmed), &items); err != nil {
+				return nil, fmt.Errorf(`expected an array e.g. ["a", "b"] or a comma separated list e.g. "a, b", the JSON array is not valid: %v`, err)
+			}
+			break
+		}
+		items = []any{}
+		for _, item := range strings.Split(trimmed, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	default:
+		items = []any{v}
+	}
+
+	itemSchema, ok := property["items"].(map[string]any)
+	if !ok {
+		return items, nil
+	}
+	coerced := make([]any, 0, len(items))
+	for i, item := range items {
+		value, err := coerceArgument(itemSchema, item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		coerced = append(coerced, value)
+	}
+	return coerced, nil
+}
+
+// enumValues returns the allowed string values of a property schema
+func enumValues(property map[string]any) []string {
+	switch values := property["enum"].(type) {
+	case []string:
+		return values
+	case []any:
+		strs := make([]string, 0, len(values))
+		for _, value := range values {
+			if s, ok := value.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	}
+	return nil
+}
+
+// describeValue returns the JSON type of a value for the error messages
+func describeValue(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithArgumentCoercion(t *testing.T) {
+	var arguments map[string]any
+	tool := Tool{
+		Tool: mcp.NewTool("test",
+			mcp.WithString("version"),
+			mcp.WithString("config"),
+			withComponentKind(),
+			mcp.WithArray("names", mcp.WithStringItems()),
+			mcp.WithNumber("limit"),
+			mcp.WithBoolean("draft"),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			arguments = request.GetArguments()
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+	coerced := WithArgumentCoercion([]Tool{tool})[0]
+
+	result := callTool(t, coerced, map[string]any{
+		"version": 1.38,
+		"config":  map[string]any{"receivers": map[string]any{"otlp": nil}},
+		"kind":    "Receiver",
+		"names":   "otlp, batch,",
+		"limit":   "10",
+		"draft":   "true",
+		"other":   42.0,
+	})
+	assert.False(t, result.IsError)
+	assert.Equal(t, map[string]any{
+		"version": "1.38",
+		"config":  `{"receivers":{"otlp":null}}`,
+		"kind":    "receiver",
+		"names":   []any{"otlp", "batch"},
+		"limit":   10.0,
+		"draft":   true,
+		"other":   42.0,
+	}, arguments)
+
+	callTool(t, coerced, map[string]any{"names": `["otlp", 5]`})
+	assert.Equal(t, map[string]any{"names": []any{"otlp", "5"}}, arguments)
+
+	callTool(t, coerced, map[string]any{"names": "otlp"})
+	assert.Equal(t, map[string]any{"names": []any{"otlp"}}, arguments)
+
+	arguments = nil
+	result = callTool(t, coerced, map[string]any{"limit": "ten"})
+	assert.True(t, result.IsError)
+	assert.Nil(t, arguments)
+	assert.Equal(t, `invalid limit argument: expected a number e.g. 10, got "ten"`, resultText(result))
+
+	result = callTool(t, coerced, map[string]any{"draft": map[string]any{}})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "invalid draft argument: expected true or false, got an object", resultText(result))
+
+	result = callTool(t, coerced, map[string]any{"names": `["otlp"`})
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(result), `invalid names argument: expected an array e.g. ["a", "b"] or a comma separated list e.g. "a, b"`)
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getCommonSettingsTool returns the tool returning the documentation and the schema of the settings shared by the
+// components
+func getCommonSettingsTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-common-settings",
+		mcp.WithDescription("Get the documentation and the JSON schema fragment of the settings shared by many OpenTelemetry collector components independently of a component: the TLS client and server settings (configtls), the gRPC and HTTP client and server settings (configgrpc, confighttp) and the timeout, retry_on_failure and sending_queue settings of the exporters (exporterhelper). Use it instead of reading these blocks from the large component schemas. Without a name the common settings are listed."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[CommonSettingsResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The common settings"),
+			mcp.Enum(collectorschema.CommonSettingNames()...),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		name := request.GetString("name", "")
+
+		if name == "" {
+			settings := collectorschema.CommonSettings()
+			lines := make([]string, 0, len(settings))
+			for _, setting := range settings {
+				lines = append(lines, fmt.Sprintf("%s: %s e.g. %s", setting.Name, setting.Description, setting.Example))
+			}
+			return mcp.NewToolResultStructured(CommonSettingsResponse{Version: version, Settings: settings}, strings.Join(lines, "\n")), nil
+		}
+
+		setting, err := schemaManager.GetCommonSetting(name, version)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		schema, err := json.MarshalIndent(setting.Schema, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal the schema of %s: %v", name, err)), nil
+		}
+		response := CommonSettingsResponse{Version: version, Setting: setting}
+		text := fmt.Sprintf("%s\n\nConfigured e.g. at %s, schema:\n%s", setting.Documentation, setting.Example, schema)
+		return mcp.NewToolResultStructured(response, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
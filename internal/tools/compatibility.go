@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getSDKCompatibilityTool returns the language SDK and collector compatibility tool
+func getSDKCompatibilityTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-sdk-compatibility",
+		mcp.WithDescription("Check whether an OpenTelemetry language SDK version is compatible with a collector version e.g. opentelemetry-java 1.38 and collector 0.139: the OTLP versions of both, the stable signals, the default export protocol and the semantic conventions version the SDK emits. Use it to plan cross-component upgrades."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[collectorschema.CompatibilityReport](),
+		mcp.WithString("language",
+			mcp.Required(),
+			mcp.Description("The SDK language: java, python, go, js or dotnet"),
+		),
+		mcp.WithString("sdkVersion",
+			mcp.Required(),
+			mcp.Description("The SDK version e.g. 1.38.0"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		language, err := request.RequireString("language")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("language argument is required: %v", err)), nil
+		}
+		sdkVersion, err := request.RequireString("sdkVersion")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("sdkVersion argument is required: %v", err)), nil
+		}
+		version := strings.TrimPrefix(request.GetString("version", latestCollectorVersion), "v")
+
+		matrix, err := schemaManager.GetCompatibilityMatrix()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		report, err := matrix.Check(language, strings.TrimPrefix(sdkVersion, "v"), version)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		verdict := "compatible"
+		if !report.Compatible {
+			verdict = "not compatible"
+		}
+		text := fmt.Sprintf("%s SDK %s (OTLP %s, semconv %s) is %s with collector %s (OTLP %s)\n- %s",
+			report.Language, report.SDKVersion, report.SDKOTLP, report.Semconv, verdict, report.CollectorVersion, report.CollectorOTLP, strings.Join(report.Notes, "\n- "))
+		return mcp.NewToolResultStructured(report, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
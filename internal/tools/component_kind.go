@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// componentKindDescription is the description of the kind argument of the tools taking a component
+const componentKindDescription = "Collector component kind. It can be receiver, processor, exporter, connector and extension."
+
+// withComponentKind adds the kind argument with the component types as enum, the options e.g. mcp.Required() are
+// applied after the defaults
+func withComponentKind(opts ...mcp.PropertyOption) mcp.ToolOption {
+	defaults := []mcp.PropertyOption{
+		mcp.Description(componentKindDescription),
+		mcp.Enum(collectorschema.ComponentTypeNames()...),
+	}
+	return mcp.WithString("kind", append(defaults, opts...)...)
+}
+
+// requireComponentType returns the component type of the required kind argument
+func requireComponentType(request mcp.CallToolRequest) (collectorschema.ComponentType, error) {
+	kind, err := request.RequireString("kind")
+	if err != nil {
+		return "", fmt.Errorf("kind argument is required: %w", err)
+	}
+	return collectorschema.ParseComponentType(kind)
+}
+
+// optionalComponentType returns the component type of the optional kind argument, it is empty if the argument is not
+// set
+func optionalComponentType(request mcp.CallToolRequest) (collectorschema.ComponentType, error) {
+	kind := request.GetString("kind", "")
+	if kind == "" {
+		return "", nil
+	}
+	return collectorschema.ParseComponentType(kind)
+}
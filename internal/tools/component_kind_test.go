@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+const testCollectorVersion = "0.139.0"
+
+func callTool(t *testing.T, tool Tool, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool.Tool.Name
+	request.Params.Arguments = arguments
+	result, err := tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	return result
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
+
+func TestComponentKind_Connector(t *testing.T) {
+	schemaManager := collectorschema.NewSchemaManager()
+	connector := map[string]any{"kind": "connector", "name": "spanmetrics", "version": testCollectorVersion}
+
+	tests := []struct {
+		tool      Tool
+		arguments map[string]any
+		contains  string
+	}{
+		{tool: getCollectorComponentsTool(schemaManager, testCollectorVersion), arguments: map[string]any{"kind": "connector", "version": testCollectorVersion}, contains: "spanmetrics"},
+		{tool: getCollectorReadmeTool(schemaManager, nil, testCollectorVersion), arguments: connector, contains: "spanmetrics"},
+		{tool: getCollectorSchemaGetTool(schemaManager, nil, testCollectorVersion), arguments: connector, contains: "metrics_flush_interval"},
+		{tool: getCollectorSchemaSummaryTool(schemaManager, testCollectorVersion), arguments: connector, contains: "spanmetrics"},
+		{tool: getCollectorSchemaValidationTool(schemaManager, validation.NewSessions(storage.NewMemory()), testCollectorVersion), arguments: map[string]any{"kind": "connector", "name": "spanmetrics", "version": testCollectorVersion, "config": "{}"}, contains: "is valid: true"},
+		{tool: getComponentModuleTool(schemaManager, testCollectorVersion), arguments: connector, contains: "spanmetrics"},
+		{tool: getEditorSchemaTool(schemaManager, nil, testCollectorVersion), arguments: connector, contains: "spanmetrics"},
+	}
+	for _, test := range tests {
+		t.Run(test.tool.Tool.Name, func(t *testing.T) {
+			result := callTool(t, test.tool, test.arguments)
+			assert.False(t, result.IsError, resultText(result))
+			assert.Contains(t, resultText(result), test.contains)
+		})
+	}
+}
+
+func TestComponentKind_Enum(t *testing.T) {
+	schemaManager := collectorschema.NewSchemaManager()
+	tool := getCollectorComponentsTool(schemaManager, testCollectorVersion)
+	kind, ok := tool.Tool.InputSchema.Properties["kind"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, []string{"receiver", "processor", "exporter", "connector", "extension"}, kind["enum"])
+	assert.Contains(t, tool.Tool.InputSchema.Required, "kind")
+}
+
+func TestComponentKind_Parse(t *testing.T) {
+	schemaManager := collectorschema.NewSchemaManager()
+	tool := getCollectorComponentsTool(schemaManager, testCollectorVersion)
+
+	result := callTool(t, tool, map[string]any{"kind": "connectors", "version": testCollectorVersion})
+	assert.False(t, result.IsError, resultText(result))
+	assert.Contains(t, resultText(result), "forward")
+
+	result = callTool(t, tool, map[string]any{"kind": "connectr", "version": testCollectorVersion})
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(result), `invalid component type "connectr"`)
+
+	result = callTool(t, tool, map[string]any{"version": testCollectorVersion})
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(result), "kind argument is required")
+}
@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getComponentModuleTool returns the tool mapping Go modules to the collector components they provide and back
+func getComponentModuleTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-component-module",
+		mcp.WithDescription("Map between OpenTelemetry collector components and the Go modules providing them. Given a kind and name it returns the module of the component as used in go.mod and the gomod entries of a collector builder (OCB) manifest. Given a module path, a package import path or a go.mod require line it returns the components the module provides with the names used in the collector configuration."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ComponentModulesResponse](),
+		mcp.WithString("module",
+			mcp.Description("Go module path, import path or go.mod require line e.g. github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver v0.139.0. Set either module or kind and name."),
+		),
+		withComponentKind(),
+		mcp.WithString("name",
+			mcp.Description("Collector component name e.g. kafka"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		modulePath := request.GetString("module", "")
+		componentName := request.GetString("name", "")
+		componentType, err := optionalComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		response := &ComponentModulesResponse{Version: version, Components: []collectorschema.ComponentModule{}}
+		switch {
+		case modulePath != "":
+			components, err := schemaManager.FindComponentsByModule(modulePath, version)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to find components of module %s: %v", modulePath, err)), nil
+			}
+			if len(components) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("module %s provides no component of the OpenTelemetry Collector %s", modulePath, version)), nil
+			}
+			response.Components = components
+		case componentType != "" && componentName != "":
+			component, err := schemaManager.GetComponentModule(componentType, componentName, version)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			response.Components = append(response.Components, *component)
+		default:
+			return mcp.NewToolResultError("either the module argument or the kind and name arguments are required"), nil
+		}
+
+		lines := make([]string, 0, len(response.Components))
+		for _, component := range response.Components {
+			lines = append(lines, fmt.Sprintf("%s %s is provided by %s", component.Type, component.Name, component.GoMod()))
+		}
+		return mcp.NewToolResultStructured(response, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
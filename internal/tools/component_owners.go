@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getComponentOwnersTool returns the tool reporting the code owners and the support status of a component
+func getComponentOwnersTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-component-owners",
+		mcp.WithDescription("Get the upstream code owners (the CODEOWNERS entries) and the support status of an OpenTelemetry collector component: the active and emeritus code owners, whether new code owners are sought and the stability of each signal. Unmaintained components have no active code owners and are removed, check the status before adopting a component."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ComponentOwnersResponse](),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component name e.g. kafka"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		status, err := schemaManager.GetComponentStatus(componentType, componentName, version)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &ComponentOwnersResponse{
+			Kind:            string(componentType),
+			Name:            componentName,
+			Version:         version,
+			ComponentStatus: *status,
+			Unmaintained:    status.Unmaintained(),
+			Deprecated:      status.Deprecated(),
+		}
+
+		lines := []string{fmt.Sprintf("%s %s v%s", componentType, componentName, version)}
+		if len(status.Codeowners) > 0 {
+			lines = append(lines, "code owners: "+githubHandles(status.Codeowners))
+		} else {
+			lines = append(lines, "code owners: none")
+		}
+		if len(status.Emeritus) > 0 {
+			lines = append(lines, "emeritus: "+githubHandles(status.Emeritus))
+		}
+		if status.SeekingNewOwners {
+			lines = append(lines, "seeking new code owners")
+		}
+		levels := make([]string, 0, len(status.Stability))
+		for level := range status.Stability {
+			levels = append(levels, level)
+		}
+		sort.Strings(levels)
+		for _, level := range levels {
+			lines = append(lines, fmt.Sprintf("%s: %s", level, strings.Join(status.Stability[level], ", ")))
+		}
+		if warning := status.Warning(); warning != "" {
+			lines = append(lines, "WARNING: the component is "+warning)
+		}
+		return mcp.NewToolResultStructured(response, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// componentStatusWarning returns the warning for configurations relying on a deprecated or unmaintained component,
+// components without a known status are not reported
+func componentStatusWarning(schemaManager *collectorschema.SchemaManager, componentType collectorschema.ComponentType, componentName string, version string) string {
+	status, err := schemaManager.GetComponentStatus(componentType, componentName, version)
+	if err != nil {
+		return ""
+	}
+	return status.Warning()
+}
+
+// githubHandles returns the GitHub handles as they are written in CODEOWNERS e.g. @owner
+func githubHandles(handles []string) string {
+	mentions := make([]string, 0, len(handles))
+	for _, handle := range handles {
+		mentions = append(mentions, "@"+strings.TrimPrefix(handle, "@"))
+	}
+	return strings.Join(mentions, ", ")
+}
@@ -0,0 +1,363 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/analysis"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getConfigComplexityTool returns the collector configuration complexity report tool
+func getConfigComplexityTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-complexity",
+		mcp.WithDescription("Report size and complexity metrics of a collector configuration (pipelines, components, processors per pipeline, config depth, duplicated blocks) and suggest refactors e.g. YAML anchors or shared processors via connectors"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[analysis.ComplexityReport](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultJSON(analysis.Complexity(config))
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigConflictsTool returns the duplicate and conflicting component detection tool
+func getConfigConflictsTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-conflicts",
+		mcp.WithDescription("Find duplicate component definitions that are identical except the name, receivers and extensions listening on the same port and memory_limiter processors with different budgets in a collector configuration"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ConflictsResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		findings := analysis.Conflicts(config)
+		if len(findings) == 0 {
+			return mcp.NewToolResultStructured(ConflictsResponse{Findings: []analysis.Finding{}}, "no duplicate or conflicting components found"), nil
+		}
+		return mcp.NewToolResultJSON(ConflictsResponse{Findings: findings})
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigTuningTool returns the tool checking a collector configuration against known hard limits of its environment
+func getConfigTuningTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-tuning",
+		mcp.WithDescription("Check a collector configuration against known hard limits: otlp exporter batches larger than the gRPC max_recv_msg_size of the backend, memory_limiter budgets above the container memory or with a spike limit not lower than the limit, in-memory sending queues that fill the container memory and more queue consumers than the container CPUs can run"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ConflictsResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithNumber("container_memory_mib",
+			mcp.Description("Memory limit of the collector container in MiB. The memory checks are skipped without it."),
+		),
+		mcp.WithNumber("container_cpus",
+			mcp.Description("CPU limit of the collector container e.g. 0.5. The queue consumer check is skipped without it."),
+		),
+		mcp.WithNumber("backend_max_recv_msg_size_mib",
+			mcp.Description("Maximum gRPC message size of the backend the otlp exporters send to in MiB. Defaults to 4."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		findings := analysis.Tuning(config, analysis.Environment{
+			MemoryMiB:         request.GetFloat("container_memory_mib", 0),
+			CPUs:              request.GetFloat("container_cpus", 0),
+			MaxRecvMsgSizeMiB: request.GetFloat("backend_max_recv_msg_size_mib", 0),
+		})
+		if len(findings) == 0 {
+			return mcp.NewToolResultStructured(ConflictsResponse{Findings: []analysis.Finding{}}, "no known limits exceeded"), nil
+		}
+		return mcp.NewToolResultJSON(ConflictsResponse{Findings: findings})
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigEndpointsTool returns the tool checking the endpoint syntax of the components of a collector configuration
+func getConfigEndpointsTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-endpoints",
+		mcp.WithDescription("Check the endpoints of a collector configuration against the format their component expects and suggest the corrected endpoint: gRPC exporters e.g. otlp send to host:port without a path, http exporters e.g. otlphttp to a URL with an http or https scheme to which the otlphttp exporter appends the signal path, receivers, extensions and the prometheus exporter listen on host:port without a scheme. Also reports the OTLP/gRPC port 4317 used for http and the OTLP/HTTP port 4318 used for gRPC."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ConflictsResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		findings := analysis.Endpoints(config)
+		if len(findings) == 0 {
+			return mcp.NewToolResultStructured(ConflictsResponse{Findings: []analysis.Finding{}}, "all endpoints match the format of their component"), nil
+		}
+		return mcp.NewToolResultJSON(ConflictsResponse{Findings: findings})
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigWhatIfRemoveTool returns the component removal impact analysis tool
+func getConfigWhatIfRemoveTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-what-if-remove",
+		mcp.WithDescription("Explain what happens if a component is removed from a collector configuration: the pipelines and signals that stop reaching an exporter, components that no longer process telemetry, resulting validation issues and performance implications. Returns the configuration without the component."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[analysis.RemovalReport](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component ID as used in the configuration e.g. batch or otlp/backend"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		report, err := analysis.WhatIfRemove(config, componentType.Section(), componentName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to analyze removal of %s %s: %v", componentType, componentName, err)), nil
+		}
+		return mcp.NewToolResultJSON(report)
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigExplainTool returns the tool explaining the data flow, the components and the endpoints of a configuration
+func getConfigExplainTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-explain",
+		mcp.WithDescription("Explain a full collector configuration in one call: a narrative of what data flows where in each pipeline including connectors, what each component does from its documentation, the pipelines using it, the addresses the collector listens on and the external endpoints it exports to or scrapes, and the components defined but not used."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[analysis.Explanation](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		explanation := analysis.Explain(config, func(section, id string) string {
+			componentType := collectorschema.ComponentTypeFromSection(section)
+			summary, err := schemaManager.GetComponentSummary(componentType, collectorconfig.ComponentType(id), version)
+			if err != nil {
+				return ""
+			}
+			return summary.Description
+		})
+
+		var sb strings.Builder
+		for _, pipeline := range explanation.Pipelines {
+			fmt.Fprintf(&sb, "%s\n", pipeline.Narrative)
+		}
+		sb.WriteString("\nComponents:\n")
+		for _, component := range explanation.Components {
+			fmt.Fprintf(&sb, "- %s: %s\n", component.ID, component.Description)
+		}
+		for _, endpoints := range []struct {
+			title     string
+			endpoints []analysis.Endpoint
+		}{{"Listens on", explanation.Listeners}, {"Connects to", explanation.Destinations}} {
+			if len(endpoints.endpoints) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n%s:\n", endpoints.title)
+			for _, endpoint := range endpoints.endpoints {
+				fmt.Fprintf(&sb, "- %s (%s)\n", endpoint.Address, endpoint.Path)
+			}
+		}
+		if len(explanation.UnusedComponents) > 0 {
+			fmt.Fprintf(&sb, "\nUnused components: %s\n", strings.Join(explanation.UnusedComponents, ", "))
+		}
+		return mcp.NewToolResultStructured(explanation, sb.String()), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigGraphTool returns the tool returning the pipeline graph of a configuration with the schema and stability
+// metadata of its components
+func getConfigGraphTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-graph",
+		mcp.WithDescription("Return the pipeline model of a collector configuration as a graph for clients running their own analyses without parsing the YAML: the components are the nodes with their pipelines, signals and the schema and stability metadata of their type in the version, the edges are the data flow between consecutive components of a pipeline and the connectors linking the pipelines they export from to the pipelines they receive to. Components used by a pipeline but not defined are nodes marked undefined."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[analysis.Graph](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version of the component metadata e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		graph := analysis.BuildGraph(config, func(section, id string) *analysis.NodeMetadata {
+			componentType := collectorschema.ComponentTypeFromSection(section)
+			componentName := collectorconfig.ComponentType(id)
+			metadata := &analysis.NodeMetadata{}
+			if summary, err := schemaManager.GetComponentSummary(componentType, componentName, version); err == nil {
+				metadata.Schema = true
+				metadata.Description = summary.Description
+				metadata.FieldCount = summary.FieldCount
+			}
+			if status, err := schemaManager.GetComponentStatus(componentType, componentName, version); err == nil {
+				metadata.Stability = status.Stability
+				metadata.Deprecated = status.Deprecated()
+				metadata.Unmaintained = status.Unmaintained()
+			}
+			return metadata
+		})
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%d components, %d edges\n", len(graph.Nodes), len(graph.Edges))
+		for _, edge := range graph.Edges {
+			if edge.Kind == analysis.EdgeConnector {
+				fmt.Fprintf(&sb, "%s: %s -> %s\n", edge.From, edge.Pipeline, edge.ToPipeline)
+				continue
+			}
+			fmt.Fprintf(&sb, "%s -> %s (%s)\n", edge.From, edge.To, edge.Pipeline)
+		}
+		return mcp.NewToolResultStructured(graph, sb.String()), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigOTTLValidationTool returns the OTTL context validation tool
+func getConfigOTTLValidationTool(latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-ottl-validation",
+		mcp.WithDescription("Validate the OTTL statements and conditions of transform and filter processors, tail_sampling ottl_condition policies and count and sum connectors against the context they run in e.g. attributes in the metric context or datapoint paths in the metric context, and statements without a context on collectors older than 0.120.0"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[IssuesResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		issues := analysis.ValidateOTTL(config, version)
+		response := IssuesResponse{Valid: !collectorconfig.HasErrors(issues), Issues: issues}
+		if response.Issues == nil {
+			response.Issues = []collectorconfig.Issue{}
+		}
+		return mcp.NewToolResultStructured(response, fmt.Sprintf("is valid: %v, issues: %v", response.Valid, issues)), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/analysis"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/configcheck"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/policy"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+// The checks of the config check tool
+const (
+	checkValidate = "validate"
+	checkLint     = "lint"
+	checkAll      = "all"
+)
+
+// reportArtifactTypes are the file extensions and MIME types of the report formats stored as artifacts
+var reportArtifactTypes = map[string]struct{ extension, mimeType string }{
+	report.FormatText:  {"txt", "text/plain"},
+	report.FormatJSON:  {"json", "application/json"},
+	report.FormatSARIF: {"sarif", "application/sarif+json"},
+}
+
+// getConfigCheckTool returns the tool running the validate and lint checks of the command line on a configuration, the
+// lint checks enforce the rules of the policy packs loaded by the server
+func getConfigCheckTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, validationProfiles *validation.Sessions, policyPacks []policy.Pack, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-check",
+		mcp.WithDescription("Run the validate and lint checks of the command line on a collector configuration: the configuration schema, the pipeline topology and component naming rules, the OTTL statements, duplicate and conflicting components, misconfigured scraper intervals and timeouts, malformed endpoints and settings exceeding known hard limits and the rules of the organization policy packs loaded by the server e.g. all exporters must use TLS, reported with the rule namespaced by its pack and the rule documentation. The findings have a rule, a severity and the line and column of their key in the YAML. The report is returned as text, JSON or SARIF 2.1.0, which GitHub code scanning and other SARIF consumers ingest directly."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ConfigCheckResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		withValidationProfile(),
+		mcp.WithString("checks",
+			mcp.Description("The checks to run: validate, lint or all. Defaults to all."),
+			mcp.Enum(checkValidate, checkLint, checkAll),
+		),
+		mcp.WithString("format",
+			mcp.Description("Format of the returned report: text, json or sarif. Defaults to text."),
+			mcp.Enum(report.Formats...),
+		),
+		mcp.WithString("file",
+			mcp.Description("Path of the configuration in its repository e.g. deploy/collector.yaml, the findings are located in this file. Defaults to collector.yaml."),
+		),
+		mcp.WithNumber("container_memory_mib",
+			mcp.Description("Memory limit of the collector container in MiB. The memory lint checks are skipped without it."),
+		),
+		mcp.WithNumber("container_cpus",
+			mcp.Description("CPU limit of the collector container e.g. 0.5. The queue consumer lint check is skipped without it."),
+		),
+		mcp.WithNumber("backend_max_recv_msg_size_mib",
+			mcp.Description("Maximum gRPC message size of the backend the otlp exporters send to in MiB. Defaults to 4."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		file := request.GetString("file", "collector.yaml")
+		checks := request.GetString("checks", checkAll)
+		if !slices.Contains([]string{checkValidate, checkLint, checkAll}, checks) {
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported checks %q, it can be %s, %s or %s", checks, checkValidate, checkLint, checkAll)), nil
+		}
+		format := request.GetString("format", report.FormatText)
+		if err := report.ValidateFormat(format); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		profile, err := validationProfiles.Resolve(sessionID(ctx), request.GetString("profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var findings []report.Finding
+		if checks != checkLint {
+			validateFindings, err := configcheck.Validate(schemaManager, []byte(configYAML), version, profile)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to validate the configuration: %v", err)), nil
+			}
+			findings = append(findings, validateFindings...)
+		}
+		if checks != checkValidate {
+			lintFindings, err := configcheck.Lint([]byte(configYAML), analysis.Environment{
+				MemoryMiB:         request.GetFloat("container_memory_mib", 0),
+				CPUs:              request.GetFloat("container_cpus", 0),
+				MaxRecvMsgSizeMiB: request.GetFloat("backend_max_recv_msg_size_mib", 0),
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to lint the configuration: %v", err)), nil
+			}
+			findings = append(findings, lintFindings...)
+			policyFindings, err := configcheck.Policies(schemaManager, []byte(configYAML), version, policyPacks)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to check the policies: %v", err)), nil
+			}
+			findings = append(findings, policyFindings...)
+		}
+
+		r := report.New("check", file, findings)
+		var text strings.Builder
+		if err := r.Write(&text, format, false); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to write the report: %v", err)), nil
+		}
+		response := &ConfigCheckResponse{Version: version, Profile: profile.Name, Findings: r.Findings, Summary: r.Summary}
+		if checks != checkValidate {
+			for _, pack := range policyPacks {
+				response.PolicyPacks = append(response.PolicyPacks, pack.Name)
+			}
+		}
+		if response.Findings == nil {
+			response.Findings = []report.Finding{}
+		}
+		artifactType := reportArtifactTypes[format]
+		name := strings.TrimSuffix(path.Base(file), path.Ext(file)) + "." + artifactType.extension
+		return artifactResult(artifactStore, name, artifactType.mimeType, text.String(), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+)
+
+// getConfigSchemaTool returns the tool serving the JSON Schema of a full collector configuration
+func getConfigSchemaTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-schema",
+		mcp.WithDescription("Get the draft-07 JSON Schema of a full OpenTelemetry collector configuration of a version. The receivers, processors, exporters, extensions and connectors sections validate the component configurations by the component ID e.g. otlp/backend, so a whole configuration is validated in a single pass by any standard JSON Schema validator."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SchemaResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		schema, err := schemaManager.GetConfigSchema(version)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get config schema for version %s: %v", version, err)), nil
+		}
+
+		schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal config schema: %v", err)), nil
+		}
+		fileName := fmt.Sprintf("otelcol-%s.schema.json", version)
+		text := fmt.Sprintf("JSON Schema of the OpenTelemetry Collector %s configuration, validate a configuration converted to JSON against it:\n\n%s", version, schemaJSON)
+		response := &SchemaResponse{Version: version, FileName: fileName, Schema: schema}
+		return artifactResult(artifactStore, fileName, "application/schema+json", text, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
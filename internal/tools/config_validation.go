@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/configcheck"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+// getConfigValidationTool returns the tool running the validate checks of the command line on a full configuration
+func getConfigValidationTool(schemaManager *collectorschema.SchemaManager, validationProfiles *validation.Sessions, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-validation",
+		mcp.WithDescription("Validate a full OpenTelemetry collector configuration YAML like the validate command: every receiver, processor, exporter, connector and extension against the configuration schema of the version, the pipeline topology e.g. components referenced by service.extensions and service.pipelines that are not defined, and the OTTL statements. Returns the findings with their rule, severity and the line and column of their key in the YAML."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ConfigValidationResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		withValidationProfile(),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		config, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		profile, err := validationProfiles.Resolve(sessionID(ctx), request.GetString("profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		findings, err := configcheck.Validate(schemaManager, []byte(config), version, profile)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to validate the configuration for version %s: %v", version, err)), nil
+		}
+		r := report.New("validate", "collector.yaml", findings)
+		response := ConfigValidationResponse{
+			Valid:   profile.Valid(r.Summary.Errors, r.Summary.Warnings),
+			Version: version,
+			Profile: profile.Name,
+			Summary: r.Summary,
+		}
+		// The summary counts every finding, the profile limits the returned ones
+		r.Findings, response.Omitted = validation.Limit(profile, r.Findings)
+		response.Findings = r.Findings
+		if response.Findings == nil {
+			response.Findings = []report.Finding{}
+		}
+
+		var text strings.Builder
+		fmt.Fprintf(&text, "is valid: %v, version: %s, profile: %s\n", response.Valid, version, profile.Name)
+		if err := r.Write(&text, report.FormatText, false); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to write the findings: %v", err)), nil
+		}
+		return mcp.NewToolResultStructured(response, strings.TrimSuffix(text.String(), "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+// getConfigVersionsValidationTool returns the tool validating a full configuration against the config schemas of
+// several versions
+func getConfigVersionsValidationTool(schemaManager *collectorschema.SchemaManager, validationProfiles *validation.Sessions) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-versions-validation",
+		mcp.WithDescription("Validate a full OpenTelemetry collector configuration against the configuration schema of several collector versions e.g. to find the versions a configuration can be upgraded to. Returns the versions the configuration is valid for and the errors of the others. The validation of each version is reported as a progress notification when the call has a progress token."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ConfigVersionsValidationResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithArray("versions",
+			mcp.WithStringItems(),
+			mcp.Description("The OpenTelemetry Collector versions e.g. [\"0.138.0\", \"0.139.0\"]. Defaults to all versions."),
+		),
+		withValidationProfile(),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		config, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		profile, err := validationProfiles.Resolve(sessionID(ctx), request.GetString("profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		allVersions, err := schemaManager.GetAllVersions()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get versions: %v", err)), nil
+		}
+		versions := request.GetStringSlice("versions", nil)
+		if len(versions) == 0 {
+			versions = allVersions
+		}
+		for _, version := range versions {
+			if !slices.Contains(allVersions, version) {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported version %q, must be one of %s", version, strings.Join(allVersions, ", "))), nil
+			}
+		}
+		versions = slices.Clone(versions)
+		sort.Slice(versions, func(i, j int) bool { return collectorschema.CompareVersions(versions[i], versions[j]) < 0 })
+
+		// Each version is reported when it is validated, so clients see the partial results of long validations
+		progress := newProgressReporter(ctx, request)
+		response := ConfigVersionsValidationResponse{Profile: profile.Name, ValidVersions: []string{}, Results: make([]ConfigVersionValidation, 0, len(versions))}
+		lines := make([]string, 0, len(versions))
+		for i, version := range versions {
+			validationResult, err := schemaManager.ValidateConfigYAML(version, []byte(config))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to validate the configuration for version %s: %v", version, err)), nil
+			}
+			schemaErrors := validation.SchemaErrors(profile, validationResult.Errors())
+			result := ConfigVersionValidation{Version: version, Valid: profile.Valid(len(schemaErrors), 0)}
+			result.Errors, result.Omitted = validation.Limit(profile, schemaErrors)
+			if result.Errors == nil {
+				result.Errors = []string{}
+			}
+			response.Results = append(response.Results, result)
+			line := fmt.Sprintf("%s: valid", version)
+			if result.Valid {
+				response.ValidVersions = append(response.ValidVersions, version)
+			} else {
+				line = fmt.Sprintf("%s: %d errors %v", version, len(schemaErrors), result.Errors)
+			}
+			lines = append(lines, line)
+			progress.report(i+1, len(versions), line)
+		}
+		text := fmt.Sprintf("valid for versions: %v, profile: %s\n%s", response.ValidVersions, profile.Name, strings.Join(lines, "\n"))
+		return mcp.NewToolResultStructured(response, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getConnectorConversionsTool returns the tool finding the connectors converting between pipeline signals
+func getConnectorConversionsTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-connector-conversions",
+		mcp.WithDescription("Find the OpenTelemetry collector connectors converting one pipeline signal to another e.g. which connectors convert logs to metrics. A connector is an exporter of a pipeline of the from signal and a receiver of a pipeline of the to signal. Without from and to all connectors of the version and their conversions are listed."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ConnectorConversionsResponse](),
+		mcp.WithString("from",
+			mcp.Description("The signal of the pipeline the connector exports from"),
+			mcp.Enum("traces", "metrics", "logs", "profiles"),
+		),
+		mcp.WithString("to",
+			mcp.Description("The signal of the pipeline the connector receives in. same matches connectors keeping the signal e.g. forward."),
+			mcp.Enum("traces", "metrics", "logs", "profiles", "same"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		from := request.GetString("from", "")
+		to := request.GetString("to", "")
+
+		connectors, err := schemaManager.FindConnectorConversions(from, to, version)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := ConnectorConversionsResponse{Version: version, Connectors: connectors}
+		if len(connectors) == 0 {
+			return mcp.NewToolResultStructured(response, fmt.Sprintf("no connector of version %s converts %s to %s", version, signalText(from), signalText(to))), nil
+		}
+		lines := make([]string, 0, len(connectors))
+		for _, connector := range connectors {
+			lines = append(lines, fmt.Sprintf("%s (%s): %s", connector.Name, strings.Join(connector.Conversions, ", "), connector.Description))
+		}
+		return mcp.NewToolResultStructured(response, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// signalText returns the text of a from or to argument of the tool
+func signalText(signal string) string {
+	switch signal {
+	case "":
+		return "any signal"
+	case "same":
+		return "the same signal"
+	}
+	return signal
+}
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getCoreDocsTool returns the tool returning the collector core documentation pages
+func getCoreDocsTool(artifactStore *artifacts.Store) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-core-docs",
+		mcp.WithDescription("Explain the OpenTelemetry collector core configuration that is not part of a component: service (pipelines and the collector's own telemetry e.g. service::telemetry::logs::level and the internal metrics), confmap (config providers, merging and ${env:VAR} expansion), configtls, configgrpc and confighttp (the TLS, gRPC and HTTP client and server settings shared by components), exporterhelper (the timeout, retry and sending queue of the exporters). Without a name the available pages are listed."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[CoreDocResponse](),
+		mcp.WithString("name",
+			mcp.Description("Core documentation page"),
+			mcp.Enum(collectorschema.CoreDocNames()...),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := request.GetString("name", "")
+
+		if name == "" {
+			docs, err := collectorschema.ListCoreDocs()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lines := make([]string, 0, len(docs))
+			for _, doc := range docs {
+				lines = append(lines, fmt.Sprintf("%s: %s", doc.Name, doc.Title))
+			}
+			return mcp.NewToolResultStructured(CoreDocResponse{Docs: docs}, strings.Join(lines, "\n")), nil
+		}
+
+		doc, err := collectorschema.GetCoreDoc(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &CoreDocResponse{Name: name, Path: collectorschema.CoreDocPath(name), Doc: doc}
+		return artifactResult(artifactStore, fmt.Sprintf("core_%s.md", name), "text/markdown", doc, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getCountGenerateTool returns the count and sum connector setup tool
+func getCountGenerateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-count-generate",
+		mcp.WithDescription("Configure the count or sum connector to turn logs, spans, span events, metrics or data points into metrics e.g. count ERROR logs per service. Generates the OTTL conditions, metric attributes and the pipelines wiring, the connector is validated against its schema."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("connector",
+			mcp.Description("Connector to configure. It can be count and sum. Defaults to count."),
+		),
+		mcp.WithString("metrics",
+			mcp.Required(),
+			mcp.Description("Metrics JSON array e.g. [{\"name\": \"log.error.count\", \"signal\": \"logs\", \"severity\": \"ERROR\", \"attributes\": [\"service.name\"]}]. Signal can be spans, spanevents, metrics, datapoints and logs. Supported keys: name, description, signal, severity (logs only, the minimum severity), conditions (OTTL, all of them must match), attributes (name=default sets a default value), source_attribute (sum connector only)."),
+		),
+		mcp.WithString("metrics_exporters",
+			mcp.Description("Comma-separated exporters receiving the generated metrics e.g. prometheus"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		metricsJSON, err := request.RequireString("metrics")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("metrics argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		countRequest := generate.CountRequest{
+			Connector:        request.GetString("connector", ""),
+			MetricsExporters: splitList(request.GetString("metrics_exporters", "")),
+		}
+		if err := json.Unmarshal([]byte(metricsJSON), &countRequest.Metrics); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse metrics JSON: %v", err)), nil
+		}
+		result, err := generate.Count(countRequest)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate connector config: %v", err)), nil
+		}
+
+		for connectorName, connectorConfig := range result.Config.Connectors {
+			configJSON, err := json.Marshal(connectorConfig)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to marshal %s connector config: %v", connectorName, err)), nil
+			}
+			validationResult, err := schemaManager.ValidateComponentJSON(collectorschema.ComponentTypeConnector, connectorName, version, configJSON)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to validate %s connector for version %s: %v", connectorName, version, err)), nil
+			}
+			if !validationResult.Valid() {
+				return mcp.NewToolResultError(fmt.Sprintf("%s connector config is not valid for version %s: %v", connectorName, version, validationResult.Errors())), nil
+			}
+		}
+
+		configYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		configYAML, err = stampConfig(request, version, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(configYAML), Issues: result.Issues, Warnings: result.Warnings, Verification: verification}
+		return artifactResult(artifactStore, "count.yaml", "application/yaml", fmt.Sprintf("%s\nwarnings: %v\nissues: %v\n%s", configYAML, result.Warnings, result.Issues, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/defaults"
+)
+
+// getConfigMinimizeTool returns the tool removing the fields set to their default value from a collector configuration
+func getConfigMinimizeTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-minimize",
+		mcp.WithDescription("Remove every component field whose value equals the schema default of the collector version and return the minimal configuration. Durations are compared by length e.g. 0.2s equals 200ms. Comments and key order are kept and sections left empty are kept as null values e.g. protocols.grpc."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[MinimizedConfigResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		document, err := collectorconfig.ParseDocument([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		removed, missingSchemas := defaults.Minimize(document, func(section, componentType string) (map[string]interface{}, error) {
+			return schemaManager.GetFieldDefaults(collectorschema.ComponentTypeFromSection(section), componentType, version)
+		})
+		if removed == nil {
+			removed = []string{}
+		}
+
+		minimizedYAML, err := document.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &MinimizedConfigResponse{Config: string(minimizedYAML), Removed: removed, MissingSchemas: missingSchemas}
+		text := fmt.Sprintf("%s\nremoved: %s", minimizedYAML, strings.Join(removed, ", "))
+		if len(missingSchemas) > 0 {
+			text += fmt.Sprintf("\nno schema for version %s: %s", version, strings.Join(missingSchemas, ", "))
+		}
+		return artifactResult(artifactStore, "minimized.yaml", "application/yaml", text, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getConfigExpandTool returns the tool adding the fields that are not set with their default value to a collector configuration
+func getConfigExpandTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-expand",
+		mcp.WithDescription("Fill in the default value of every component field that is not set, marked with a # default comment, to show the configuration the collector runs with. Defaults come from the component schemas of the collector version. Nested settings are only expanded in sections present in the configuration because adding a section can enable a feature e.g. protocols.http of the otlp receiver."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ExpandedConfigResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		document, err := collectorconfig.ParseDocument([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		added, missingSchemas, err := defaults.Expand(document, func(section, componentType string) (map[string]interface{}, error) {
+			return schemaManager.GetFieldDefaults(collectorschema.ComponentTypeFromSection(section), componentType, version)
+		})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if added == nil {
+			added = []string{}
+		}
+
+		expandedYAML, err := document.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &ExpandedConfigResponse{Config: string(expandedYAML), Added: added, MissingSchemas: missingSchemas}
+		text := fmt.Sprintf("%s\nadded: %s", expandedYAML, strings.Join(added, ", "))
+		if len(missingSchemas) > 0 {
+			text += fmt.Sprintf("\nno schema for version %s: %s", version, strings.Join(missingSchemas, ", "))
+		}
+		return artifactResult(artifactStore, "expanded.yaml", "application/yaml", text, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
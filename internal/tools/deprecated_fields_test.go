@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func TestDeprecatedFieldsText(t *testing.T) {
+	text := deprecatedFieldsText([]DeprecatedComponentFields{
+		{ComponentName: "otlp", DeprecatedFields: []collectorschema.DeprecatedField{}},
+		{ComponentName: "kafka", DeprecatedFields: []collectorschema.DeprecatedField{{
+			Name:        "topic",
+			Type:        "string",
+			Description: "Deprecated: use traces::topic instead.",
+			ReplacedBy:  "traces.topic",
+			Migration:   "traces:\n  topic: otlp_spans\n",
+		}}},
+	})
+	assert.Equal(t, `otlp: no deprecated fields
+kafka: 1 deprecated fields
+- topic (string): Deprecated: use traces::topic instead.
+  replaced by traces.topic
+  migration:
+    traces:
+      topic: otlp_spans`, text)
+}
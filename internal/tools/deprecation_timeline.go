@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getDeprecationTimelineTool returns the component deprecation timeline tool
+func getDeprecationTimelineTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-deprecation-timeline",
+		mcp.WithDescription("Return when OpenTelemetry collector component fields were deprecated and removed and what replaces them e.g. when will the kafka exporter brokers be removed. Combines the changelog deprecations and breaking changes with the schema deprecation flags of all known collector versions."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[collectorschema.DeprecationTimeline](),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component name e.g. kafka"),
+		),
+		mcp.WithString("field",
+			mcp.Description("Restrict the timeline to a field e.g. brokers, nested fields are dotted e.g. traces.topic"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+		field := request.GetString("field", "")
+
+		timeline, err := schemaManager.GetDeprecationTimeline(componentType, componentName, field)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get deprecation timeline for %s/%s: %v", componentType, componentName, err)), nil
+		}
+
+		lines := make([]string, 0, len(timeline.Fields))
+		for _, deprecation := range timeline.Fields {
+			name := deprecation.Field
+			if name == "" {
+				name = timeline.Component
+			}
+			line := fmt.Sprintf("- %s:", name)
+			if deprecation.DeprecatedIn != "" {
+				line += fmt.Sprintf(" deprecated in %s,", deprecation.DeprecatedIn)
+			}
+			if deprecation.RemovedIn != "" {
+				line += fmt.Sprintf(" removed in %s", deprecation.RemovedIn)
+			} else {
+				line += fmt.Sprintf(" not removed as of %s", latestCollectorVersion)
+			}
+			if deprecation.Replacement != "" {
+				line += fmt.Sprintf(", replaced by %s", deprecation.Replacement)
+			}
+			lines = append(lines, line)
+		}
+		text := fmt.Sprintf("No deprecations of %s in versions %s", timeline.Component, strings.Join(timeline.Versions, ", "))
+		if len(lines) > 0 {
+			text = fmt.Sprintf("Deprecations of %s in versions %s:\n%s", timeline.Component, strings.Join(timeline.Versions, ", "), strings.Join(lines, "\n"))
+		}
+		return mcp.NewToolResultStructured(timeline, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/dryrun"
+)
+
+// GetDryRunTools returns the opt-in tools validating configurations with collector binaries
+func GetDryRunTools(validator *dryrun.Validator, latestCollectorVersion string) []Tool {
+	return []Tool{
+		getDryRunTool(validator, latestCollectorVersion),
+	}
+}
+
+// getDryRunTool returns the tool checking whether the collector would start with a configuration
+func getDryRunTool(validator *dryrun.Validator, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-dry-run",
+		mcp.WithDescription("Check whether the collector would start with a configuration by running the validate command of a collector binary. It runs the collector's own unmarshalling and Validate() rules and catches semantic errors the JSON schemas miss, it is slower than the schema validation. Environment variables are not set during the check. Errors of a running collector are explained by opentelemetry-collector-failure-modes."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[dryrun.Result](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		result, err := validator.Validate(ctx, version, []byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to validate the configuration: %v", err)), nil
+		}
+		text := fmt.Sprintf("the collector %s would start with the configuration", version)
+		if !result.Valid {
+			text = fmt.Sprintf("the collector %s would not start:\n- %s", version, strings.Join(result.Errors, "\n- "))
+		}
+		if len(result.Warnings) > 0 {
+			text += "\nwarnings: " + strings.Join(result.Warnings, ", ")
+		}
+		return mcp.NewToolResultStructured(result, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
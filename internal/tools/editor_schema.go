@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+)
+
+// getEditorSchemaTool returns the YAML language server schema export tool
+func getEditorSchemaTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-editor-schema",
+		mcp.WithDescription("Export a JSON Schema for YAML language servers (yaml-language-server, VSCode) to enable editor autocomplete and validation. Returns the schema of a component or, without kind and name, the schema of a full collector configuration."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SchemaResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		withComponentKind(),
+		mcp.WithString("name",
+			mcp.Description("Collector component name e.g. otlp"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		componentName := request.GetString("name", "")
+		componentType, err := optionalComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if (componentType == "") != (componentName == "") {
+			return mcp.NewToolResultError("kind and name have to be provided together"), nil
+		}
+
+		var schema map[string]interface{}
+		fileName := fmt.Sprintf("otelcol-%s.schema.json", version)
+		if componentType == "" {
+			schema, err = schemaManager.GetEditorConfigSchema(version)
+		} else {
+			schema, err = schemaManager.GetEditorSchema(componentType, componentName, version)
+			fileName = fmt.Sprintf("%s_%s-%s.schema.json", componentType, componentName, version)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get editor schema for version %s: %v", version, err)), nil
+		}
+
+		schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal editor schema: %v", err)), nil
+		}
+		text := fmt.Sprintf("Save the schema as %s and reference it from the YAML file with:\n# yaml-language-server: $schema=./%s\n\n%s", fileName, fileName, schemaJSON)
+		response := &SchemaResponse{Kind: string(componentType), Name: componentName, Version: version, FileName: fileName, Schema: schema}
+		return artifactResult(artifactStore, fileName, "application/schema+json", text, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// exampleOutputLines and exampleOutputBytes bound the recorded output of an example, the examples show the shape
+	// of a result and not all of it
+	exampleOutputLines = 12
+	exampleOutputBytes = 800
+	// exampleTruncation marks a truncated example output
+	exampleTruncation = "..."
+)
+
+// examplesYAML are the examples of the tools by tool name, recorded from the server integration test cases marked as
+// examples by go test . -run TestServer -update
+//
+//go:embed examples.yaml
+var examplesYAML []byte
+
+// ToolExample is a worked example of a tool call for few-shot prompting: the arguments and the truncated text result
+type ToolExample struct {
+	Arguments map[string]any `yaml:"arguments" json:"arguments"`
+	Output    string         `yaml:"output" json:"output"`
+}
+
+// loadToolExamples returns the embedded examples by tool name
+func loadToolExamples() (map[string][]ToolExample, error) {
+	var examples map[string][]ToolExample
+	if err := yaml.Unmarshal(examplesYAML, &examples); err != nil {
+		return nil, fmt.Errorf("failed to parse tool examples: %w", err)
+	}
+	return examples, nil
+}
+
+// TruncateExampleOutput returns the first lines of a tool result as recorded in an example
+func TruncateExampleOutput(output string) string {
+	output = strings.TrimRight(output, "\n")
+	truncated := false
+	if lines := strings.Split(output, "\n"); len(lines) > exampleOutputLines {
+		output = strings.Join(lines[:exampleOutputLines], "\n")
+		truncated = true
+	}
+	if len(output) > exampleOutputBytes {
+		output = strings.ToValidUTF8(output[:exampleOutputBytes], "")
+		truncated = true
+	}
+	if truncated {
+		output += "\n" + exampleTruncation
+	}
+	return output
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func TestTruncateExampleOutput(t *testing.T) {
+	assert.Equal(t, "short", TruncateExampleOutput("short\n"))
+	lines := strings.Repeat("line\n", exampleOutputLines+3)
+	assert.Equal(t, strings.Repeat("line\n", exampleOutputLines)+exampleTruncation, TruncateExampleOutput(lines))
+	assert.Equal(t, strings.Repeat("a", exampleOutputBytes)+"\n"+exampleTruncation, TruncateExampleOutput(strings.Repeat("a", exampleOutputBytes+1)))
+}
+
+func TestCapabilitiesTool(t *testing.T) {
+	schemaManager := collectorschema.NewSchemaManager()
+	contentCache, err := cache.New("", 0)
+	require.NoError(t, err)
+	state := storage.NewMemory()
+	tools, err := GetAllTools(schemaManager, artifacts.NewStore(time.Minute, contentCache, state), state, nil, nil)
+	require.NoError(t, err)
+	capabilities, err := GetCapabilitiesTool(tools)
+	require.NoError(t, err)
+
+	// The listed tools carry the number of their examples and not the examples
+	result, err := capabilities.Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	response, ok := result.StructuredContent.(CapabilitiesResponse)
+	require.True(t, ok)
+	require.Len(t, response.Tools, len(tools))
+	for _, capability := range response.Tools {
+		assert.Empty(t, capability.Examples, capability.Name)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"tool": "opentelemetry-collector-get-versions"}
+	result, err = capabilities.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	response, ok = result.StructuredContent.(CapabilitiesResponse)
+	require.True(t, ok)
+	require.Len(t, response.Tools, 1)
+	assert.Equal(t, "opentelemetry-collector-get-versions", response.Tools[0].Name)
+	assert.NotEmpty(t, response.Tools[0].Examples)
+	assert.Equal(t, len(response.Tools[0].Examples), response.Tools[0].ExampleCount)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "arguments: {}\nresult:\n")
+
+	request.Params.Arguments = map[string]any{"tool": "unknown"}
+	result, err = capabilities.Handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getFailoverGenerateTool returns the failover and high availability setup generation tool
+func getFailoverGenerateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-failover-generate",
+		mcp.WithDescription("Generate a failover setup from a primary exporter configuration: the failover connector sends the telemetry to the primary exporter and switches to fallback exporters copying its configuration with their endpoint while it fails, with the health_check extension wired in. Versions without the failover connector send to every exporter. The exporters are validated against their schemas and the whole configuration end to end. Returns the configuration and its failover behavior."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("primary",
+			mcp.Required(),
+			mcp.Description("The primary exporter configuration YAML keyed by its ID e.g. \"otlp/primary:\\n  endpoint: tempo:4317\". The exporter must set an endpoint."),
+		),
+		mcp.WithString("fallback_endpoints",
+			mcp.Required(),
+			mcp.Description("Comma-separated endpoints of the fallback exporters in priority order e.g. tempo-dr:4317"),
+		),
+		mcp.WithString("signal",
+			mcp.Description("Pipeline signal that fails over. It can be traces, metrics and logs. Defaults to traces."),
+		),
+		mcp.WithString("receivers",
+			mcp.Description("Comma-separated receivers of the pipeline. Defaults to otlp."),
+		),
+		mcp.WithString("retry_interval",
+			mcp.Description("How often the failover connector retries the higher priority exporters e.g. 5m. Defaults to 10m."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		primaryYAML, err := request.RequireString("primary")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("primary argument is required: %v", err)), nil
+		}
+		fallbackEndpoints, err := request.RequireString("fallback_endpoints")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("fallback_endpoints argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		var primary map[string]map[string]interface{}
+		if err := yaml.Unmarshal([]byte(primaryYAML), &primary); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse the primary exporter YAML, it must be the exporter configuration keyed by its ID: %v", err)), nil
+		}
+		if len(primary) != 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("the primary exporter YAML must have one exporter ID, got %d", len(primary))), nil
+		}
+		var primaryID string
+		for id := range primary {
+			primaryID = id
+		}
+		if primary[primaryID] == nil {
+			primary[primaryID] = map[string]interface{}{}
+		}
+		primaryType := collectorconfig.ComponentType(primaryID)
+
+		// The fallback exporters copy the primary exporter, an invalid primary exporter makes the whole setup invalid
+		primaryJSON, err := json.Marshal(primary[primaryID])
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal exporter %s config: %v", primaryID, err)), nil
+		}
+		validationResult, err := schemaManager.ValidateComponentJSON(collectorschema.ComponentTypeExporter, primaryType, version, primaryJSON)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to validate exporter %s for version %s: %v", primaryID, version, err)), nil
+		}
+		if !validationResult.Valid() {
+			return mcp.NewToolResultError(fmt.Sprintf("exporter %s config is not valid for version %s: %v", primaryID, version, validationResult.Errors())), nil
+		}
+		connector, err := failoverConnectorAvailable(schemaManager, version)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		result, err := generate.Failover(generate.FailoverRequest{
+			Signal:            request.GetString("signal", ""),
+			Receivers:         splitList(request.GetString("receivers", "")),
+			Primary:           primaryID,
+			PrimaryConfig:     primary[primaryID],
+			FallbackEndpoints: splitList(fallbackEndpoints),
+			RetryInterval:     request.GetString("retry_interval", ""),
+			Connector:         connector,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate failover config: %v", err)), nil
+		}
+		configYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		configYAML, err = stampConfig(request, version, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(configYAML), Issues: result.Issues, Warnings: result.Warnings, Behavior: result.Behavior, Verification: verification}
+		return artifactResult(artifactStore, "failover.yaml", "application/yaml", fmt.Sprintf("%s\nbehavior:\n- %s\nwarnings: %v\nissues: %v\n%s", configYAML, strings.Join(result.Behavior, "\n- "), result.Warnings, result.Issues, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// failoverConnectorAvailable returns true if the version has the failover connector
+func failoverConnectorAvailable(schemaManager *collectorschema.SchemaManager, version string) (bool, error) {
+	_, err := schemaManager.GetComponentSchema(collectorschema.ComponentTypeConnector, "failover", version)
+	var notFound *collectorschema.ComponentNotFoundError
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get failover connector schema for version %s: %w", version, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getFailureModesTool returns the tool explaining collector errors with the curated failure modes of the components
+func getFailureModesTool(latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-failure-modes",
+		mcp.WithDescription(fmt.Sprintf("Explain an OpenTelemetry collector error message or log line with the curated failure modes of popular components e.g. 429 responses of the prometheusremotewrite exporter or gRPC message size errors of the otlp exporter, with their cause and the configuration fixing them. Without an error the failure modes of a component are listed. Failure modes are curated for %s.", strings.Join(collectorschema.FailureModeComponents(), ", "))),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[FailureModesResponse](),
+		mcp.WithString("error",
+			mcp.Description("The error message or log line of the collector to explain"),
+		),
+		withComponentKind(),
+		mcp.WithString("name",
+			mcp.Description("The component name e.g. otlp, it limits the failure modes to the component. Requires kind."),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		errorMessage := request.GetString("error", "")
+		name := request.GetString("name", "")
+		componentType, err := optionalComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if name != "" && componentType == "" {
+			return mcp.NewToolResultError("kind argument is required with name"), nil
+		}
+		if errorMessage == "" && name == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("error or name argument is required, failure modes are curated for %s", strings.Join(collectorschema.FailureModeComponents(), ", "))), nil
+		}
+		response := FailureModesResponse{Version: version, Matches: []collectorschema.FailureModeMatch{}}
+
+		if errorMessage == "" {
+			failureModes, err := collectorschema.GetFailureModes(componentType, name, version)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get failure modes: %v", err)), nil
+			}
+			for _, failureMode := range failureModes {
+				response.Matches = append(response.Matches, collectorschema.FailureModeMatch{Component: string(componentType) + "/" + name, FailureMode: failureMode})
+			}
+			if len(response.Matches) == 0 {
+				return mcp.NewToolResultStructured(response, fmt.Sprintf("no failure modes of %s %s are curated for version %s", componentType, name, version)), nil
+			}
+			return mcp.NewToolResultStructured(response, failureModesText(response.Matches)), nil
+		}
+
+		matches, err := collectorschema.MatchFailureModes(errorMessage, componentType, name, version)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to match failure modes: %v", err)), nil
+		}
+		if len(matches) == 0 {
+			return mcp.NewToolResultStructured(response, fmt.Sprintf("no curated failure mode of version %s matches the error, failure modes are curated for %s", version, strings.Join(collectorschema.FailureModeComponents(), ", "))), nil
+		}
+		response.Matches = matches
+		return mcp.NewToolResultStructured(response, failureModesText(matches)), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// failureModesText returns the text of the failure modes with their cause and fix
+func failureModesText(matches []collectorschema.FailureModeMatch) string {
+	sections := make([]string, 0, len(matches))
+	for _, match := range matches {
+		section := fmt.Sprintf("%s %s: %s\ncause: %s\nfix: %s", match.Component, match.ID, match.Title, match.Cause, match.Fix)
+		if match.Config != "" {
+			section += "\n" + strings.TrimSuffix(match.Config, "\n")
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n\n")
+}
@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/github"
+)
+
+// GetGitHubTools returns the opt-in tools fetching live component documentation from GitHub
+func GetGitHubTools(client *github.Client, artifactStore *artifacts.Store) []Tool {
+	return []Tool{
+		getGitHubComponentTool(client, artifactStore),
+	}
+}
+
+// getGitHubComponentTool returns the tool fetching the upstream README or open issues of a component
+func getGitHubComponentTool(client *github.Client, artifactStore *artifacts.Store) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-github-component",
+		mcp.WithDescription("Fetch the latest upstream README or the open issues of an OpenTelemetry collector component from GitHub. Use it only when newer documentation than the bundled versions is explicitly requested."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithOutputSchema[GitHubComponentResponse](),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component name e.g. otlp"),
+		),
+		mcp.WithString("content",
+			mcp.Description("Content to fetch. It can be readme and issues. Defaults to readme."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of returned issues. Defaults to 10."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+
+		switch content := request.GetString("content", "readme"); content {
+		case "readme":
+			readme, err := client.GetReadme(ctx, string(componentType), componentName)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			response := &GitHubComponentResponse{Kind: string(componentType), Name: componentName, Readme: readme}
+			return artifactResult(artifactStore, fmt.Sprintf("%s_%s_README.md", componentType, componentName), "text/markdown", readme, response), nil
+		case "issues":
+			limit := request.GetInt("limit", 10)
+			if limit <= 0 || limit > 100 {
+				return mcp.NewToolResultError("limit must be between 1 and 100"), nil
+			}
+			issues, err := client.GetOpenIssues(ctx, string(componentType), componentName, limit)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			response := GitHubComponentResponse{Kind: string(componentType), Name: componentName, Issues: issues}
+			if len(issues) == 0 {
+				return mcp.NewToolResultStructured(response, fmt.Sprintf("no open issues found for %s %s", componentType, componentName)), nil
+			}
+			return mcp.NewToolResultJSON(response)
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported content %q, must be readme or issues", content)), nil
+		}
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getGoldenTestGenerateTool returns the golden test harness generation tool
+func getGoldenTestGenerateTool(artifactStore *artifacts.Store) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-golden-test-generate",
+		mcp.WithDescription("Generate a golden test harness to regression-test the processors of a collector configuration: a test configuration with file exporters, telemetrygen inputs, a jq normalization and a script comparing the outputs with the recorded expected outputs"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GoldenTestResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("collector",
+			mcp.Description("Collector binary started by the script. Defaults to otelcol-contrib."),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of traces, metrics and logs sent per signal. Defaults to 5."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := generate.GoldenTest(config, generate.GoldenTestRequest{
+			Collector: request.GetString("collector", ""),
+			Count:     request.GetInt("count", 0),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate golden test harness: %v", err)), nil
+		}
+
+		var text strings.Builder
+		text.WriteString("Save the files in a directory, run ./run.sh --update once to record testdata/expected and ./run.sh to test changes of the configuration.\n")
+		for _, file := range result.Files {
+			text.WriteString(fmt.Sprintf("\n==> %s <==\n%s", file.Path, file.Content))
+		}
+		if len(result.Warnings) > 0 {
+			text.WriteString(fmt.Sprintf("\nwarnings: %v", result.Warnings))
+		}
+		response := &GoldenTestResponse{Files: result.Files, Warnings: result.Warnings}
+		return artifactResult(artifactStore, "golden-test.txt", "text/plain", text.String(), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/hardening"
+)
+
+// getConfigHardenTool returns the tool applying a security hardening profile to a collector configuration
+func getConfigHardenTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-harden",
+		mcp.WithDescription("Apply a security hardening profile to a collector configuration and report every change. dev binds receivers and extensions to localhost and adds the memory_limiter processor, staging additionally requires a bearer token on exposed receivers and sets TLS 1.2 as minimum version, prod additionally enables certificate verification, removes debug and logging exporters and lowers the debug log level."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[HardenedConfigResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("profile",
+			mcp.Description("The hardening profile. It can be dev, staging and prod. Defaults to prod."),
+		),
+		mcp.WithString("expose",
+			mcp.Description("Comma separated receiver IDs that keep listening on all interfaces e.g. otlp when the collector is a gateway receiving from other hosts"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		profile := request.GetString("profile", hardening.ProfileProd)
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := hardening.Harden(config, hardening.Options{Profile: profile, Expose: splitList(request.GetString("expose", ""))})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to harden the configuration: %v", err)), nil
+		}
+
+		warnings := result.Warnings
+		issues := topologyIssues([]byte(configYAML), result.Config)
+		validated := make(map[string]bool)
+		for _, change := range result.Changes {
+			section, rest, _ := strings.Cut(change.Path, "::")
+			id, _, _ := strings.Cut(rest, "::")
+			var components map[string]interface{}
+			switch section {
+			case "receivers":
+				components = result.Config.Receivers
+			case "processors":
+				components = result.Config.Processors
+			case "exporters":
+				components = result.Config.Exporters
+			case "extensions":
+				components = result.Config.Extensions
+			}
+			component, ok := components[id]
+			if !ok || validated[section+"::"+id] {
+				continue
+			}
+			validated[section+"::"+id] = true
+			configJSON, err := json.Marshal(component)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to marshal %s config: %v", id, err)), nil
+			}
+			componentType := collectorschema.ComponentTypeFromSection(section)
+			validationResult, err := schemaManager.ValidateComponentJSON(componentType, collectorconfig.ComponentType(id), version, configJSON)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s %s was not validated, no schema for version %s: %v", componentType, id, version, err))
+				continue
+			}
+			for _, validationError := range validationResult.Errors() {
+				issues = append(issues, collectorconfig.Issue{Severity: collectorconfig.SeverityError, Path: section + "::" + id, Message: validationError.String()})
+			}
+		}
+
+		hardenedYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		lines := make([]string, 0, len(result.Changes))
+		for _, change := range result.Changes {
+			lines = append(lines, fmt.Sprintf("- %s: %s", change.Path, change.Message))
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, []byte(configYAML), hardenedYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &HardenedConfigResponse{Config: string(hardenedYAML), Profile: profile, Changes: result.Changes, Warnings: warnings, Issues: issues, Verification: verification}
+		text := fmt.Sprintf("%s\nchanges:\n%s\nwarnings: %v\nissues: %v\n%s", hardenedYAML, strings.Join(lines, "\n"), warnings, issues, verification)
+		return artifactResult(artifactStore, "hardened.yaml", "application/yaml", text, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
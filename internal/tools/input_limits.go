@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// WithInputLimits rejects the tool calls with a string argument over the input limits before the handler parses it.
+// Every string argument is checked as YAML, a superset of JSON, so the configurations, manifests and JSON arguments
+// are covered with a single check.
+func WithInputLimits(tools []Tool, limits collectorschema.InputLimits) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			arguments := request.GetArguments()
+			keys := make([]string, 0, len(arguments))
+			for key := range arguments {
+				keys = append(keys, key)
+			}
+			// The first argument over a limit is reported in a stable order
+			sort.Strings(keys)
+			for _, key := range keys {
+				if err := checkArgument(limits, arguments[key]); err != nil {
+					return inputTooLargeResult(key, err), nil
+				}
+			}
+			return handler(ctx, request)
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
+
+// checkArgument checks a string argument or the strings of an array argument
+func checkArgument(limits collectorschema.InputLimits, value any) error {
+	switch v := value.(type) {
+	case string:
+		return limits.CheckYAML([]byte(v))
+	case []any:
+		for _, item := range v {
+			if err := checkArgument(limits, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// inputTooLargeResult returns the error result of an argument over the input limits with the exceeded limit as
+// structured content
+func inputTooLargeResult(argument string, err error) *mcp.CallToolResult {
+	var tooLarge *collectorschema.InputTooLargeError
+	if !errors.As(err, &tooLarge) {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to check the %s argument: %v", argument, err))
+	}
+	response := InputTooLargeResponse{Error: "input too large", Argument: argument, InputTooLargeError: *tooLarge}
+	result := mcp.NewToolResultStructured(response, fmt.Sprintf("the %s argument is rejected, %v", argument, err))
+	result.IsError = true
+	return result
+}
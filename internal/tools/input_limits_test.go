@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func TestWithInputLimits(t *testing.T) {
+	called := false
+	tool := Tool{
+		Tool: mcp.NewTool("test"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			called = true
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+	limited := WithInputLimits([]Tool{tool}, collectorschema.InputLimits{MaxSize: 100, MaxDepth: 3})[0]
+
+	result := callTool(t, limited, map[string]any{"config": "receivers:\n  otlp:\n", "count": 5})
+	assert.False(t, result.IsError)
+	assert.True(t, called)
+
+	called = false
+	result = callTool(t, limited, map[string]any{"config": "a:\n  b:\n    c:\n      d: e\n"})
+	assert.True(t, result.IsError)
+	assert.False(t, called)
+	assert.Equal(t, "the config argument is rejected, input too large: the nesting exceeds the maximum depth of 3 at line 4", resultText(result))
+	response, ok := result.StructuredContent.(InputTooLargeResponse)
+	require.True(t, ok)
+	assert.Equal(t, InputTooLargeResponse{
+		Error:              "input too large",
+		Argument:           "config",
+		InputTooLargeError: collectorschema.InputTooLargeError{Limit: collectorschema.LimitDepth, Maximum: 3, Line: 4},
+	}, response)
+
+	result = callTool(t, limited, map[string]any{"names": []any{"otlp", strings.Repeat("a", 101)}})
+	assert.True(t, result.IsError)
+	assert.False(t, called)
+	assert.Contains(t, resultText(result), "the names argument is rejected, input too large: 101 bytes exceed the maximum size of 100 bytes")
+}
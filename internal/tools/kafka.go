@@ -0,0 +1,223 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getKafkaGenerateTool returns the kafka exporter and receiver setup tool
+func getKafkaGenerateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-kafka-generate",
+		mcp.WithDescription("Configure both ends of a Kafka pipeline: a collector exporting to Kafka with the kafka exporter and a collector consuming from it with the kafka receiver, with matching topic, encoding, SASL/PLAIN, SCRAM, mTLS or MSK IAM authentication and partitioning. The kafka components are validated against their schemas and the deprecated fields of the version e.g. the top-level topic are reported with their replacement. Returns both collector configurations."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("signal",
+			mcp.Description("Pipeline signal sent through Kafka. It can be traces, metrics and logs. Defaults to traces."),
+		),
+		mcp.WithString("brokers",
+			mcp.Required(),
+			mcp.Description("Comma-separated Kafka brokers e.g. kafka-1:9092,kafka-2:9092"),
+		),
+		mcp.WithString("topic",
+			mcp.Description("Kafka topic. Defaults to otlp_spans, otlp_metrics or otlp_logs."),
+		),
+		mcp.WithString("encoding",
+			mcp.Description("Message encoding. It can be otlp_proto, otlp_json, jaeger_proto, jaeger_json, zipkin_proto, zipkin_json (traces) and raw (logs). Defaults to otlp_proto."),
+		),
+		mcp.WithString("partitioning",
+			mcp.Description("Partitioning of the messages. It can be none, trace_id (traces) and resource (metrics, logs). Defaults to none."),
+		),
+		mcp.WithString("auth",
+			mcp.Description("Authentication with the brokers. It can be none, plain, scram-sha-256, scram-sha-512, mtls and aws_msk_iam. Asked for when not set."),
+		),
+		mcp.WithString("username",
+			mcp.Description("SASL username of the plain and scram auth, the password is read from the KAFKA_PASSWORD environment variable"),
+		),
+		mcp.WithString("region",
+			mcp.Description("AWS region of the MSK cluster for the aws_msk_iam auth e.g. us-east-1"),
+		),
+		mcp.WithString("ca_file",
+			mcp.Description("CA certificate file verifying the brokers, the system roots are used if not set"),
+		),
+		mcp.WithString("cert_file",
+			mcp.Description("Client certificate file of the mtls auth"),
+		),
+		mcp.WithString("key_file",
+			mcp.Description("Client key file of the mtls auth"),
+		),
+		mcp.WithString("group_id",
+			mcp.Description("Consumer group of the consuming collectors. Defaults to otel-collector."),
+		),
+		mcp.WithString("protocol_version",
+			mcp.Description("Kafka protocol version. Defaults to 2.1.0."),
+		),
+		mcp.WithString("backend_endpoint",
+			mcp.Description("OTLP endpoint the consuming collectors export to e.g. tempo:4317. Asked for when not set."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		brokers, err := request.RequireString("brokers")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("brokers argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		signal := request.GetString("signal", "traces")
+
+		// Versions before the signal specific topic settings only have the top-level topic and encoding
+		legacyTopic, err := kafkaLegacyTopic(schemaManager, version)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := generate.Kafka(generate.KafkaRequest{
+			Signal:          signal,
+			Brokers:         splitList(brokers),
+			Topic:           request.GetString("topic", ""),
+			Encoding:        request.GetString("encoding", ""),
+			Partitioning:    request.GetString("partitioning", ""),
+			Auth:            request.GetString("auth", ""),
+			Username:        request.GetString("username", ""),
+			Region:          request.GetString("region", ""),
+			CAFile:          request.GetString("ca_file", ""),
+			CertFile:        request.GetString("cert_file", ""),
+			KeyFile:         request.GetString("key_file", ""),
+			GroupID:         request.GetString("group_id", ""),
+			ProtocolVersion: request.GetString("protocol_version", ""),
+			BackendEndpoint: request.GetString("backend_endpoint", ""),
+			LegacyTopic:     legacyTopic,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate kafka config: %v", err)), nil
+		}
+
+		// The kafka components have to match the schemas of the collector version, a component missing in the version
+		// is reported as a warning as its configuration cannot be checked
+		warnings := result.Warnings
+		components := []struct {
+			componentType collectorschema.ComponentType
+			config        interface{}
+		}{
+			{collectorschema.ComponentTypeExporter, result.Producer.Exporters["kafka"]},
+			{collectorschema.ComponentTypeReceiver, result.Consumer.Receivers["kafka"]},
+		}
+		for _, component := range components {
+			configJSON, err := json.Marshal(component.config)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to marshal kafka %s config: %v", component.componentType, err)), nil
+			}
+			validationResult, err := schemaManager.ValidateComponentJSON(component.componentType, "kafka", version, configJSON)
+			var notFound *collectorschema.ComponentNotFoundError
+			if errors.As(err, &notFound) {
+				warnings = append(warnings, fmt.Sprintf("%s kafka has no schema in version %s, its configuration is not validated", component.componentType, version))
+				continue
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to validate %s kafka for version %s: %v", component.componentType, version, err)), nil
+			}
+			if !validationResult.Valid() {
+				return mcp.NewToolResultError(fmt.Sprintf("%s kafka config is not valid for version %s: %v", component.componentType, version, validationResult.Errors())), nil
+			}
+			deprecationWarnings, err := kafkaDeprecationWarnings(schemaManager, component.componentType, version, component.config.(map[string]interface{}))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			warnings = append(warnings, deprecationWarnings...)
+		}
+
+		producerYAML, err := result.Producer.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		consumerYAML, err := result.Consumer.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		producerYAML, err = stampConfig(request, version, producerYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		consumerYAML, err = stampConfig(request, version, consumerYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, producerYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		downstreamVerification, err := verifyGeneratedConfig(schemaManager, version, nil, consumerYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(producerYAML), DownstreamConfig: string(consumerYAML), Issues: result.Issues, Warnings: warnings, Verification: verification, DownstreamVerification: downstreamVerification}
+		return artifactResult(artifactStore, "kafka.yaml", "application/yaml", fmt.Sprintf("# producer collector\n%s\n# consumer collector\n%s\nwarnings: %v\nissues: %v\nproducer %s\nconsumer %s", producerYAML, consumerYAML, warnings, result.Issues, verification, downstreamVerification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// kafkaLegacyTopic returns true if the top-level topic of the kafka exporter of the version is not deprecated, the
+// signal specific topics e.g. traces::topic replaced it in v0.124.0
+func kafkaLegacyTopic(schemaManager *collectorschema.SchemaManager, version string) (bool, error) {
+	schema, err := schemaManager.GetComponentSchema(collectorschema.ComponentTypeExporter, "kafka", version)
+	var notFound *collectorschema.ComponentNotFoundError
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get kafka exporter schema for version %s: %w", version, err)
+	}
+	properties, _ := schema.Schema["properties"].(map[string]interface{})
+	topic, ok := properties["topic"].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	deprecated, _ := topic["deprecated"].(bool)
+	return !deprecated, nil
+}
+
+// kafkaDeprecationWarnings returns the deprecated fields of a kafka component set in its generated configuration with
+// their replacement
+func kafkaDeprecationWarnings(schemaManager *collectorschema.SchemaManager, componentType collectorschema.ComponentType, version string, config map[string]interface{}) ([]string, error) {
+	deprecatedFields, err := schemaManager.GetDeprecatedFields(componentType, "kafka", version)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, field := range deprecatedFields {
+		if !hasConfigPath(config, field.Name) {
+			continue
+		}
+		warning := fmt.Sprintf("%s kafka field %s is deprecated in version %s", componentType, field.Name, version)
+		if field.ReplacedBy != "" {
+			warning += fmt.Sprintf(", use %s instead", field.ReplacedBy)
+		} else if field.Description != "" {
+			warning += ": " + field.Description
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings, nil
+}
+
+// hasConfigPath returns true if the dotted path e.g. traces.topic is set in the configuration
+func hasConfigPath(config map[string]interface{}, fieldPath string) bool {
+	key, rest, nested := strings.Cut(fieldPath, ".")
+	value, ok := config[key]
+	if !ok || !nested {
+		return ok
+	}
+	child, ok := value.(map[string]interface{})
+	return ok && hasConfigPath(child, rest)
+}
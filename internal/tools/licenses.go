@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+)
+
+// getLicenseReportTool returns the tool reporting the licenses of the components of a configuration or OCB manifest
+func getLicenseReportTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-licenses",
+		mcp.WithDescription("Report the licenses of the OpenTelemetry collector components used in a configuration or a collector builder (OCB) manifest: the license of each component module and the licenses other than Apache-2.0 pulled in by their dependencies. Returns a NOTICE-style summary of the modules by license for compliance reviews of custom distributions."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[collectorschema.LicenseReport](),
+		mcp.WithString("config",
+			mcp.Description("The OpenTelemetry Collector configuration YAML, the licenses of the configured components are reported. Set either config or manifest."),
+		),
+		mcp.WithString("manifest",
+			mcp.Description("The OpenTelemetry Collector builder (OCB) manifest YAML, the licenses of the components of its gomod entries are reported. Set either config or manifest."),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		configYAML := request.GetString("config", "")
+		manifestYAML := request.GetString("manifest", "")
+
+		components := make(map[collectorschema.ComponentType][]string)
+		var unknownModules []string
+		switch {
+		case configYAML != "":
+			config, err := collectorconfig.Parse([]byte(configYAML))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for componentType, ids := range map[collectorschema.ComponentType]map[string]interface{}{
+				collectorschema.ComponentTypeReceiver:  config.Receivers,
+				collectorschema.ComponentTypeProcessor: config.Processors,
+				collectorschema.ComponentTypeExporter:  config.Exporters,
+				collectorschema.ComponentTypeConnector: config.Connectors,
+				collectorschema.ComponentTypeExtension: config.Extensions,
+			} {
+				// Components configured more than once e.g. otlp and otlp/backend are reported once
+				names := make(map[string]bool)
+				for id := range ids {
+					names[collectorconfig.ComponentType(id)] = true
+				}
+				for name := range names {
+					components[componentType] = append(components[componentType], name)
+				}
+			}
+		case manifestYAML != "":
+			builderModules, err := collectorschema.ParseBuilderManifest([]byte(manifestYAML))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse builder manifest: %v", err)), nil
+			}
+			for _, gomods := range builderModules {
+				for _, gomod := range gomods {
+					provided, err := schemaManager.FindComponentsByModule(gomod, version)
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("failed to find components of module %s: %v", gomod, err)), nil
+					}
+					if len(provided) == 0 {
+						unknownModules = append(unknownModules, gomod)
+					}
+					for _, component := range provided {
+						components[component.Type] = append(components[component.Type], component.Name)
+					}
+				}
+			}
+		default:
+			return mcp.NewToolResultError("either the config or the manifest argument is required"), nil
+		}
+
+		report, err := schemaManager.GetLicenseReport(version, components)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get licenses for version %s: %v", version, err)), nil
+		}
+		sort.Strings(unknownModules)
+		report.Unknown = append(report.Unknown, unknownModules...)
+		return mcp.NewToolResultStructured(report, report.Notice()), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
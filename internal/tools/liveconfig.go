@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/liveconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/snapshots"
+)
+
+// LiveConfigResponse is the effective configuration of a running collector with its topology issues
+type LiveConfigResponse struct {
+	liveconfig.Result
+	// Snapshot is the reference of the snapshot the configuration was saved as e.g. snapshot://live
+	Snapshot string                  `json:"snapshot,omitempty"`
+	Issues   []collectorconfig.Issue `json:"issues"`
+}
+
+// GetLiveConfigTools returns the opt-in tools fetching the effective configuration of running collectors, the
+// configurations are saved as snapshots of the client session for the other tools
+func GetLiveConfigTools(fetcher *liveconfig.Fetcher, snapshotStore *snapshots.Store) []Tool {
+	return []Tool{
+		getLiveConfigTool(fetcher, snapshotStore),
+	}
+}
+
+// getLiveConfigTool returns the tool fetching the effective configuration of a running collector
+func getLiveConfigTool(fetcher *liveconfig.Fetcher, snapshotStore *snapshots.Store) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-live-config",
+		mcp.WithDescription("Fetch the effective configuration of a running collector from an endpoint the server is configured with: a configuration YAML served over http e.g. the effective.yaml of the OpAMP supervisor or the effective config an OpAMP server received from the opamp extension. The configuration is normalized and checked for topology issues. Save it as a snapshot and pass snapshot://<name> to the validation and analysis tools to check what is actually deployed."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithOutputSchema[LiveConfigResponse](),
+		mcp.WithString("endpoint",
+			mcp.Description("The effective configuration endpoint. Defaults to the first configured endpoint."),
+			mcp.Enum(fetcher.Endpoints()...),
+		),
+		mcp.WithString("snapshot",
+			mcp.Description("Save the configuration as a snapshot of the session under the name e.g. live"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := fetcher.Fetch(ctx, request.GetString("endpoint", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		config, err := collectorconfig.Parse([]byte(result.Config))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := LiveConfigResponse{Result: *result, Issues: config.ValidateTopology()}
+		if response.Issues == nil {
+			response.Issues = []collectorconfig.Issue{}
+		}
+
+		if name := request.GetString("snapshot", ""); name != "" {
+			snapshot, err := snapshotStore.Save(sessionID(ctx), name, result.Config)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to save snapshot: %v", err)), nil
+			}
+			response.Snapshot = snapshot.URI()
+		}
+
+		text := fmt.Sprintf("effective configuration of %s (%s):\n%s", result.Endpoint, result.Source, result.Config)
+		if len(result.Redacted) > 0 {
+			text += fmt.Sprintf("\nredacted by the collector: %s", strings.Join(result.Redacted, ", "))
+		}
+		text += fmt.Sprintf("\nissues: %v", response.Issues)
+		if response.Snapshot != "" {
+			text += fmt.Sprintf("\nsaved as %s", response.Snapshot)
+		}
+		return mcp.NewToolResultStructured(response, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
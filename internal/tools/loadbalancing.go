@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getLoadBalancingGenerateTool returns the loadbalancing exporter topology generation tool
+func getLoadBalancingGenerateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-loadbalancing-generate",
+		mcp.WithDescription("Generate a loadbalancing exporter tier and the matching downstream collector configuration e.g. for tail sampling architectures. Returns both collector configurations."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("signal",
+			mcp.Description("Pipeline signal that is load balanced. It can be traces, metrics and logs. Defaults to traces."),
+		),
+		mcp.WithString("routing_key",
+			mcp.Description("Consistent hashing key. It can be traceID (traces, logs), service (traces, metrics), attributes (traces), resource, metric and streamID (metrics). Defaults to traceID, or service for metrics."),
+		),
+		mcp.WithString("routing_attributes",
+			mcp.Description("Comma-separated span attributes used with routing_key attributes"),
+		),
+		mcp.WithString("resolver",
+			mcp.Description("Resolver discovering the downstream collectors. It can be static, dns and k8s. Defaults to static."),
+		),
+		mcp.WithString("hostnames",
+			mcp.Required(),
+			mcp.Description("Comma-separated downstream collector hostnames for the static resolver, the hostname for the dns resolver or the service (name.namespace) for the k8s resolver"),
+		),
+		mcp.WithNumber("port",
+			mcp.Description("OTLP gRPC port of the downstream collectors. Defaults to 4317."),
+		),
+		mcp.WithString("backend_endpoint",
+			mcp.Description("OTLP endpoint the downstream collectors export to e.g. tempo:4317. Asked for when not set."),
+		),
+		mcp.WithBoolean("tail_sampling",
+			mcp.Description("Add the tail_sampling processor to the downstream collectors. Requires routing_key traceID."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		hostnames, err := request.RequireString("hostnames")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("hostnames argument is required: %v", err)), nil
+		}
+
+		result, err := generate.LoadBalancing(generate.LoadBalancingRequest{
+			Signal:            request.GetString("signal", ""),
+			RoutingKey:        request.GetString("routing_key", ""),
+			RoutingAttributes: splitList(request.GetString("routing_attributes", "")),
+			Resolver:          request.GetString("resolver", ""),
+			Hostnames:         splitList(hostnames),
+			Port:              request.GetInt("port", 0),
+			BackendEndpoint:   request.GetString("backend_endpoint", ""),
+			TailSampling:      request.GetBool("tail_sampling", false),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate loadbalancing config: %v", err)), nil
+		}
+
+		loadBalancerYAML, err := result.LoadBalancer.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		downstreamYAML, err := result.Downstream.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		loadBalancerYAML, err = stampConfig(request, "", loadBalancerYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		downstreamYAML, err = stampConfig(request, "", downstreamYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, latestCollectorVersion, nil, loadBalancerYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		downstreamVerification, err := verifyGeneratedConfig(schemaManager, latestCollectorVersion, nil, downstreamYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(loadBalancerYAML), DownstreamConfig: string(downstreamYAML), Issues: result.Issues, Verification: verification, DownstreamVerification: downstreamVerification}
+		return artifactResult(artifactStore, "loadbalancing.yaml", "application/yaml", fmt.Sprintf("# load balancer collector\n%s\n# downstream collectors\n%s\nissues: %v\nload balancer %s\ndownstream %s", loadBalancerYAML, downstreamYAML, result.Issues, verification, downstreamVerification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
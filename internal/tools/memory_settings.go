@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/memorysettings"
+)
+
+// getMemorySettingsTool returns the tool recommending the memory runtime settings of a collector for its container
+func getMemorySettingsTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-memory-settings",
+		mcp.WithDescription("Recommend the memory runtime settings of a collector for its container memory limit and return the environment variables to set and the changed configuration: GOMEMLIMIT at 80% of the container memory, the memory_limiter as the first processor of every pipeline with limit_percentage 80 and spike_limit_percentage 25 instead of fixed limits in MiB, and the removal of the memory_ballast extension and the memory_limiter ballast_size_mib, deprecated in favor of GOMEMLIMIT and rejected by the versions that removed them. A current GOMEMLIMIT is checked against the container memory and the memory_limiter."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[MemorySettingsResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithNumber("container_memory_mib",
+			mcp.Description("Memory limit of the collector container in MiB. GOMEMLIMIT is not computed and fixed memory_limiter limits are kept without it."),
+		),
+		mcp.WithString("gomemlimit",
+			mcp.Description("The current GOMEMLIMIT environment variable of the collector e.g. 1600MiB"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// The ballast is removed from a version when its schemas no longer have it
+		_, ballastErr := schemaManager.GetComponentSchema(collectorschema.ComponentTypeExtension, "memory_ballast", version)
+		result, err := memorysettings.Recommend(config, memorysettings.Options{
+			MemoryMiB:          request.GetFloat("container_memory_mib", 0),
+			GOMEMLIMIT:         request.GetString("gomemlimit", ""),
+			Version:            version,
+			BallastExtension:   ballastErr == nil,
+			BallastSizeSetting: hasSchemaField(schemaManager, collectorschema.ComponentTypeProcessor, "memory_limiter", version, "ballast_size_mib"),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		recommendedYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, []byte(configYAML), recommendedYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &MemorySettingsResponse{Config: string(recommendedYAML), Version: version, Env: result.Env, Changes: result.Changes, Warnings: result.Warnings, Verification: verification}
+		lines := make([]string, 0, len(result.Env)+len(result.Changes))
+		for _, env := range result.Env {
+			lines = append(lines, fmt.Sprintf("- env %s=%s: %s", env.Name, env.Value, env.Message))
+		}
+		for _, change := range result.Changes {
+			lines = append(lines, fmt.Sprintf("- %s: %s", change.Path, change.Message))
+		}
+		text := fmt.Sprintf("%s\nchanges:\n%s\nwarnings: %v\n%s", recommendedYAML, strings.Join(lines, "\n"), result.Warnings, verification)
+		return artifactResult(artifactStore, "memory-settings.yaml", "application/yaml", text, response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// hasSchemaField returns true if the schema of the component in the version has the top level field
+func hasSchemaField(schemaManager *collectorschema.SchemaManager, componentType collectorschema.ComponentType, componentName, version, field string) bool {
+	schema, err := schemaManager.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return false
+	}
+	properties, _ := schema.Schema["properties"].(map[string]interface{})
+	_, ok := properties[field]
+	return ok
+}
@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/metrics"
+)
+
+// WithMetrics records the duration and the result of every tool call in the server metrics
+func WithMetrics(tools []Tool, serverMetrics *metrics.Metrics) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		handler := tool.Handler
+		name := tool.Tool.Name
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := handler(ctx, request)
+			serverMetrics.ToolCall(name, time.Since(start), err != nil || (result != nil && result.IsError))
+			return result, err
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
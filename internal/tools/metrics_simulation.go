@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/metricsim"
+)
+
+// getMetricsProcessorSimulationTool returns the metrics processor chain simulation tool
+func getMetricsProcessorSimulationTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-metrics-processor-simulation",
+		mcp.WithDescription("Dry-run metrics through a chain of metricstransform, transform and filter processors. Returns the resulting metric names and labels and reports renames and silent drops."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[metricsim.Simulation](),
+		mcp.WithString("metrics",
+			mcp.Required(),
+			mcp.Description("Input metrics JSON array e.g. [{\"name\": \"http.server.duration\", \"labels\": {\"http.method\": \"GET\"}}]"),
+		),
+		mcp.WithString("processors",
+			mcp.Required(),
+			mcp.Description("Ordered processors JSON array as they appear in the pipeline e.g. [{\"id\": \"filter/drop\", \"config\": {\"metrics\": {\"metric\": [\"name == \\\"foo\\\"\"]}}}]"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		metricsJSON, err := request.RequireString("metrics")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("metrics argument is required: %v", err)), nil
+		}
+		processorsJSON, err := request.RequireString("processors")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("processors argument is required: %v", err)), nil
+		}
+
+		var metrics []metricsim.Metric
+		if err := json.Unmarshal([]byte(metricsJSON), &metrics); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse metrics JSON: %v", err)), nil
+		}
+		var processors []metricsim.Processor
+		if err := json.Unmarshal([]byte(processorsJSON), &processors); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse processors JSON: %v", err)), nil
+		}
+
+		simulation, err := metricsim.Simulate(metrics, processors)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to simulate processors: %v", err)), nil
+		}
+		return mcp.NewToolResultJSON(simulation)
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/analysis"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/migrate"
+)
+
+// getAttributesTransformMigrationTool returns the attributes and resource processor to transform processor migration tool
+func getAttributesTransformMigrationTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-attributes-transform-migration",
+		mcp.WithDescription("Convert the attributes and resource processors of a collector configuration to equivalent transform processor OTTL statements, including include and exclude match properties, or convert transform processors back to attributes and resource processors where the statements have an equivalent action. The converted processors replace the originals in the pipelines and are validated against the schemas and OTTL contexts of the collector version."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("direction",
+			mcp.Description("The conversion direction. It can be to-transform and to-attributes. Defaults to to-transform."),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var result *migrate.MigrationResult
+		switch direction := request.GetString("direction", "to-transform"); direction {
+		case "to-transform":
+			result, err = migrate.AttributesToTransform(config)
+		case "to-attributes":
+			result, err = migrate.TransformToAttributes(config)
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported direction %q, must be to-transform or to-attributes", direction)), nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to convert processors: %v", err)), nil
+		}
+		if len(result.Replaced) == 0 {
+			return mcp.NewToolResultError("the configuration has no processors to convert"), nil
+		}
+
+		warnings := result.Warnings
+		issues := topologyIssues([]byte(configYAML), result.Config)
+		issues = append(issues, analysis.ValidateOTTL(result.Config, version)...)
+		var converted []string
+		for _, ids := range result.Replaced {
+			converted = append(converted, ids...)
+		}
+		sort.Strings(converted)
+		for _, id := range converted {
+			name := collectorconfig.ComponentType(id)
+			configJSON, err := json.Marshal(result.Config.Processors[id])
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to marshal %s config: %v", id, err)), nil
+			}
+			validationResult, err := schemaManager.ValidateComponentJSON(collectorschema.ComponentTypeProcessor, name, version, configJSON)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("processor %s was not validated, no schema for version %s: %v", id, version, err))
+				continue
+			}
+			for _, validationError := range validationResult.Errors() {
+				issues = append(issues, collectorconfig.Issue{Severity: collectorconfig.SeverityError, Path: "processors::" + id, Message: validationError.String()})
+			}
+		}
+
+		migratedYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, []byte(configYAML), migratedYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(migratedYAML), Issues: issues, Warnings: warnings, Verification: verification}
+		return artifactResult(artifactStore, "migrated.yaml", "application/yaml", fmt.Sprintf("%s\nreplaced: %v\nwarnings: %v\nissues: %v\n%s", migratedYAML, result.Replaced, warnings, issues, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getDeprecatedComponentsMigrationTool returns the deprecated and removed components migration tool
+func getDeprecatedComponentsMigrationTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-deprecated-components-migration",
+		mcp.WithDescription("Detect deprecated and removed receivers and exporters in a collector configuration e.g. the jaeger, jaeger_thrift, logging and opencensus exporters and rewrite them to supported equivalents (otlp, otlphttp, debug) with the right endpoints. Explains the port and endpoint changes required on the backend and client side."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[DeprecatedMigrationResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := migrate.MigrateDeprecated(config, version)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to migrate deprecated components: %v", err)), nil
+		}
+		if len(result.Migrations) == 0 {
+			return mcp.NewToolResultStructured(DeprecatedMigrationResponse{Migrations: result.Migrations}, "no deprecated or removed receivers and exporters found"), nil
+		}
+
+		issues := topologyIssues([]byte(configYAML), result.Config)
+		for _, migration := range result.Migrations {
+			section, id, _ := strings.Cut(migration.Replacement, "::")
+			componentType, components := collectorschema.ComponentTypeExporter, result.Config.Exporters
+			if section == "receivers" {
+				componentType, components = collectorschema.ComponentTypeReceiver, result.Config.Receivers
+			}
+			configJSON, err := json.Marshal(components[id])
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to marshal %s config: %v", id, err)), nil
+			}
+			validationResult, err := schemaManager.ValidateComponentJSON(componentType, collectorconfig.ComponentType(id), version, configJSON)
+			if err != nil {
+				// The replacement is still valid configuration when its schema is not available
+				continue
+			}
+			for _, validationError := range validationResult.Errors() {
+				issues = append(issues, collectorconfig.Issue{Severity: collectorconfig.SeverityError, Path: migration.Replacement, Message: validationError.String()})
+			}
+		}
+
+		migratedYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, []byte(configYAML), migratedYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &DeprecatedMigrationResponse{Config: string(migratedYAML), Migrations: result.Migrations, Issues: issues, Verification: verification}
+		var summary strings.Builder
+		for _, migration := range result.Migrations {
+			fmt.Fprintf(&summary, "%s -> %s (removed: %v)\n", migration.ID, migration.Replacement, migration.Removed)
+			for _, change := range migration.Changes {
+				fmt.Fprintf(&summary, "  - %s\n", change)
+			}
+		}
+		return artifactResult(artifactStore, "migrated.yaml", "application/yaml", fmt.Sprintf("%s\n%sissues: %v\n%s", migratedYAML, summary.String(), issues, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"context"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter sends MCP progress notifications for a long-running tool call. The streamable HTTP server streams
+// them to the client as server-sent events while the call runs, so the client can show progress instead of timing
+// out on a silent call.
+type progressReporter struct {
+	ctx   context.Context
+	token mcp.ProgressToken
+}
+
+// newProgressReporter returns the progress reporter of a tool call, it reports nothing if the client did not send a
+// progress token
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest) *progressReporter {
+	reporter := &progressReporter{ctx: ctx}
+	if request.Params.Meta != nil {
+		reporter.token = request.Params.Meta.ProgressToken
+	}
+	return reporter
+}
+
+// report sends a progress notification of done of total steps, the message describes the completed step e.g. a
+// partial result
+func (p *progressReporter) report(done, total int, message string) {
+	if p.token == nil {
+		return
+	}
+	mcpServer := server.ServerFromContext(p.ctx)
+	if mcpServer == nil {
+		return
+	}
+	err := mcpServer.SendNotificationToClient(p.ctx, "notifications/progress", map[string]any{
+		"progressToken": p.token,
+		"progress":      done,
+		"total":         total,
+		"message":       message,
+	})
+	if err != nil {
+		log.Printf("failed to send the progress notification: %v", err)
+	}
+}
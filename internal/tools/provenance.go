@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/provenance"
+)
+
+// stampConfig adds the provenance header of the tool call to a generated configuration, the schema version is empty
+// for configurations not validated against the component schemas
+func stampConfig(request mcp.CallToolRequest, schemaVersion string, config []byte) ([]byte, error) {
+	return provenance.Stamp(config, request.Params.Name, schemaVersion, request.GetArguments())
+}
+
+// getConfigProvenanceTool returns the tool verifying the provenance header of a generated configuration, the
+// generators are the tools the configuration is regenerated with
+func getConfigProvenanceTool(generators []Tool, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-provenance",
+		mcp.WithDescription("Verify the provenance header the generate tools add to a collector configuration: whether it was generated by this server, whether it was edited by hand since, whether its parameters changed and whether regenerating it with the recorded parameters reproduces it. Use it in GitOps workflows to tell generated from hand-edited content."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ProvenanceResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The generated OpenTelemetry Collector configuration YAML with its provenance header"),
+		),
+		mcp.WithString("parameters",
+			mcp.Description(`JSON object of the current parameters of the generate tool e.g. {"signals": "logs"} to check whether the inputs changed since the generation`),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		var parameters map[string]interface{}
+		if parametersJSON := request.GetString("parameters", ""); parametersJSON != "" {
+			if err := json.Unmarshal([]byte(parametersJSON), &parameters); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse parameters JSON: %v", err)), nil
+			}
+		}
+
+		verification, err := provenance.Verify([]byte(configYAML), parameters)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := ProvenanceResponse{Verification: *verification}
+		if verification.Generated {
+			header := verification.Header
+			if header.SchemaVersion != "" && header.SchemaVersion != latestCollectorVersion {
+				response.Notes = append(response.Notes, fmt.Sprintf("the configuration was generated with the schemas of %s, the latest version is %s", header.SchemaVersion, latestCollectorVersion))
+			}
+			reproducible, note := regenerate(ctx, generators, header)
+			response.Reproducible = reproducible
+			if note != "" {
+				response.Notes = append(response.Notes, note)
+			}
+		}
+
+		lines := []string{fmt.Sprintf("generated: %t", response.Generated)}
+		if response.Generated {
+			lines = append(lines, fmt.Sprintf("tool: %s", response.Header.Tool), fmt.Sprintf("modified: %t", response.Modified))
+			if response.InputsChanged != nil {
+				lines = append(lines, fmt.Sprintf("inputs changed: %t", *response.InputsChanged))
+			}
+			if response.Reproducible != nil {
+				lines = append(lines, fmt.Sprintf("reproducible: %t", *response.Reproducible))
+			}
+		}
+		lines = append(lines, response.Notes...)
+		return mcp.NewToolResultStructured(response, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// regenerate calls the generate tool of a provenance header with the recorded parameters and returns whether it
+// generates the same configuration, it is nil with a note if the configuration cannot be regenerated
+func regenerate(ctx context.Context, generators []Tool, header *provenance.Header) (*bool, string) {
+	var generator *Tool
+	for i := range generators {
+		if generators[i].Tool.Name == header.Tool {
+			generator = &generators[i]
+		}
+	}
+	if generator == nil {
+		return nil, fmt.Sprintf("the tool %s does not exist anymore, the configuration cannot be regenerated", header.Tool)
+	}
+
+	arguments := make(map[string]interface{}, len(header.Parameters)+1)
+	for key, value := range header.Parameters {
+		arguments[key] = value
+	}
+	// The configuration is regenerated with the schemas it was generated with even if the latest version changed
+	if _, ok := generator.Tool.InputSchema.Properties["version"]; ok && arguments["version"] == nil && header.SchemaVersion != "" {
+		arguments["version"] = header.SchemaVersion
+	}
+	request := mcp.CallToolRequest{}
+	request.Params.Name = header.Tool
+	request.Params.Arguments = arguments
+	result, err := generator.Handler(ctx, request)
+	if err != nil || result.IsError {
+		return nil, "regenerating the configuration with the recorded parameters failed"
+	}
+
+	var response *GeneratedConfigResponse
+	switch structured := result.StructuredContent.(type) {
+	case *GeneratedConfigResponse:
+		response = structured
+	case GeneratedConfigResponse:
+		response = &structured
+	}
+	if response == nil || response.ResourceURI != "" {
+		return nil, "the regenerated configuration cannot be compared"
+	}
+
+	reproducible := false
+	for _, config := range []string{response.Config, response.DownstreamConfig} {
+		if regenerated, body, err := provenance.Parse([]byte(config)); err == nil && regenerated != nil && provenance.ContentHash(body) == header.ContentHash {
+			reproducible = true
+		}
+	}
+	if !reproducible {
+		return &reproducible, "regenerating the configuration with the recorded parameters produces a different configuration, the generator changed since"
+	}
+	return &reproducible, ""
+}
@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// askedArgument is an argument of a generation tool that is asked for instead of applying a guessed default, e.g. the
+// backend endpoint or the authentication of the brokers
+type askedArgument struct {
+	name string
+	// when returns whether the argument is needed by the other arguments of the call, nil if it is always needed
+	when   func(arguments map[string]any) bool
+	reason string
+}
+
+// askedArguments are the arguments asked for per tool on top of the required arguments of the input schema
+var askedArguments = map[string][]askedArgument{
+	"opentelemetry-collector-kafka-generate": {
+		{name: "auth", reason: "the authentication of the brokers cannot be guessed, none connects without authentication"},
+		{name: "username", when: argumentIn("auth", "plain", "scram-sha-256", "scram-sha-512"), reason: "the plain and scram auth authenticate with a SASL username"},
+		{name: "cert_file", when: argumentIn("auth", "mtls"), reason: "the mtls auth authenticates with a client certificate"},
+		{name: "key_file", when: argumentIn("auth", "mtls"), reason: "the mtls auth authenticates with a client key"},
+		{name: "region", when: argumentIn("auth", "aws_msk_iam"), reason: "the aws_msk_iam auth signs the requests for the region of the MSK cluster"},
+		{name: "backend_endpoint", reason: "the backend the consuming collectors export to cannot be guessed"},
+	},
+	"opentelemetry-collector-loadbalancing-generate": {
+		{name: "backend_endpoint", reason: "the backend the downstream collectors export to cannot be guessed"},
+	},
+}
+
+// argumentIn returns whether an argument is one of the values
+func argumentIn(name string, values ...string) func(arguments map[string]any) bool {
+	return func(arguments map[string]any) bool {
+		value, _ := arguments[name].(string)
+		for _, v := range values {
+			if strings.EqualFold(strings.TrimSpace(value), v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// WithQuestions asks for the missing arguments of the generation tools instead of generating a configuration from
+// guessed values. The required arguments of the input schema and the arguments needed by the other arguments e.g.
+// the username of the scram auth are elicited from the user when the client supports elicitation, the tool is then
+// called with the answers. Otherwise the call returns the questions with the schema of each argument, so the client
+// asks the user and calls the tool again with the answers. The elicited answers are converted to the schema types and
+// checked against the input limits like the arguments of the call.
+func WithQuestions(tools []Tool, limits collectorschema.InputLimits) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if !strings.HasSuffix(tool.Tool.Name, "-generate") {
+			wrapped = append(wrapped, tool)
+			continue
+		}
+		handler := tool.Handler
+		schema := tool.Tool.InputSchema
+		name := tool.Tool.Name
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			arguments := request.GetArguments()
+			questions := missingArguments(name, schema, arguments)
+			if len(questions) == 0 {
+				return handler(ctx, request)
+			}
+			answers, ok := elicitAnswers(ctx, name, questions)
+			if !ok {
+				return questionsResult(name, questions, arguments), nil
+			}
+			// The answers are elicited after the coercion and the limits checked the arguments of the call
+			answers, invalid := validateAnswers(schema, limits, answers)
+			if invalid != nil {
+				return invalid, nil
+			}
+			merged := make(map[string]any, len(arguments)+len(answers))
+			for key, value := range arguments {
+				merged[key] = value
+			}
+			for key, value := range answers {
+				merged[key] = value
+			}
+			// The answers can need more answers e.g. the username of the selected scram auth
+			if questions := missingArguments(name, schema, merged); len(questions) > 0 {
+				return questionsResult(name, questions, merged), nil
+			}
+			request.Params.Arguments = merged
+			return handler(ctx, request)
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
+
+// validateAnswers converts the elicited answers to the types of the input schema and checks them against the input
+// limits, it returns the error result of the first invalid answer in a stable order
+func validateAnswers(schema mcp.ToolInputSchema, limits collectorschema.InputLimits, answers map[string]any) (map[string]any, *mcp.CallToolResult) {
+	validated := make(map[string]any, len(answers))
+	keys := make([]string, 0, len(answers))
+	for key := range answers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := answers[key]
+		if property, ok := schema.Properties[key].(map[string]any); ok {
+			coerced, err := coerceArgument(property, value)
+			if err != nil {
+				return nil, mcp.NewToolResultError(fmt.Sprintf("invalid %s answer: %v", key, err))
+			}
+			value = coerced
+		}
+		if err := checkArgument(limits, value); err != nil {
+			return nil, inputTooLargeResult(key, err)
+		}
+		validated[key] = value
+	}
+	return validated, nil
+}
+
+// missingArguments returns the questions for the required and asked arguments the call does not set
+func missingArguments(toolName string, schema mcp.ToolInputSchema, arguments map[string]any) []Question {
+	var questions []Question
+	asked := make(map[string]bool)
+	ask := func(name, reason string) {
+		if asked[name] || isSet(arguments[name]) {
+			return
+		}
+		asked[name] = true
+		property, _ := schema.Properties[name].(map[string]any)
+		description, _ := property["description"].(string)
+		questions = append(questions, Question{Argument: name, Question: description, Reason: reason, Schema: property})
+	}
+	required := append([]string(nil), schema.Required...)
+	sort.Strings(required)
+	for _, name := range required {
+		ask(name, "the argument is required")
+	}
+	for _, argument := range askedArguments[toolName] {
+		if argument.when == nil || argument.when(arguments) {
+			ask(argument.name, argument.reason)
+		}
+	}
+	return questions
+}
+
+// isSet returns whether an argument has a value, an empty string is not an answer
+func isSet(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return strings.TrimSpace(v) != ""
+	case []any:
+		return len(v) > 0
+	}
+	return true
+}
+
+// elicitAnswers asks the user for the missing arguments when the client supports elicitation, it returns false if
+// the client does not support it or the user declined or cancelled
+func elicitAnswers(ctx context.Context, toolName string, questions []Question) (map[string]any, bool) {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok || session.GetClientCapabilities().Elicitation == nil {
+		return nil, false
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return nil, false
+	}
+
+	properties := make(map[string]any, len(questions))
+	required := make([]string, 0, len(questions))
+	for _, question := range questions {
+		properties[question.Argument] = elicitationProperty(question)
+		required = append(required, question.Argument)
+	}
+	result, err := mcpServer.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message:         fmt.Sprintf("%s needs %s to generate the configuration", toolName, strings.Join(required, ", ")),
+			RequestedSchema: map[string]any{"type": "object", "properties": properties, "required": required},
+		},
+	})
+	if err != nil || result == nil || result.Action != mcp.ElicitationResponseActionAccept {
+		return nil, false
+	}
+	answers, ok := result.Content.(map[string]any)
+	return answers, ok
+}
+
+// elicitationProperty returns the elicitation schema of a question, elicitation only supports flat properties of
+// primitive types
+func elicitationProperty(question Question) map[string]any {
+	property := map[string]any{"type": "string", "title": question.Argument}
+	if propertyType, ok := question.Schema["type"].(string); ok && propertyType != "array" && propertyType != "object" {
+		property["type"] = propertyType
+	}
+	description := question.Question
+	if description == "" {
+		description = question.Reason
+	}
+	property["description"] = description
+	if enum, ok := question.Schema["enum"]; ok {
+		property["enum"] = enum
+	}
+	return property
+}
+
+// questionsResult returns the error result asking for the missing arguments with the questions as structured content
+func questionsResult(toolName string, questions []Question, arguments map[string]any) *mcp.CallToolResult {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s needs more information, ask the user and call it again with the answers added to the arguments:\n", toolName)
+	for _, question := range questions {
+		fmt.Fprintf(&text, "- %s: %s (%s)\n", question.Argument, question.Question, question.Reason)
+	}
+	if arguments == nil {
+		arguments = map[string]any{}
+	}
+	result := mcp.NewToolResultStructured(QuestionsResponse{Tool: toolName, Questions: questions, Arguments: arguments}, text.String())
+	result.IsError = true
+	return result
+}
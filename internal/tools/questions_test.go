@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func TestWithQuestions(t *testing.T) {
+	var called map[string]any
+	tool := Tool{
+		Tool: mcp.NewTool("opentelemetry-collector-kafka-generate",
+			mcp.WithString("brokers", mcp.Required(), mcp.Description("Kafka brokers")),
+			mcp.WithString("auth", mcp.Description("Authentication with the brokers")),
+			mcp.WithString("username", mcp.Description("SASL username")),
+			mcp.WithString("backend_endpoint", mcp.Description("OTLP endpoint")),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			called = request.GetArguments()
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+	wrapped := WithQuestions([]Tool{tool}, collectorschema.DefaultInputLimits)[0]
+
+	result := callTool(t, wrapped, map[string]any{"auth": "scram-sha-512", "backend_endpoint": " "})
+	assert.True(t, result.IsError)
+	assert.Nil(t, called)
+	response, ok := result.StructuredContent.(QuestionsResponse)
+	require.True(t, ok)
+	assert.Equal(t, "opentelemetry-collector-kafka-generate", response.Tool)
+	assert.Equal(t, map[string]any{"auth": "scram-sha-512", "backend_endpoint": " "}, response.Arguments)
+	var arguments []string
+	for _, question := range response.Questions {
+		arguments = append(arguments, question.Argument)
+	}
+	assert.Equal(t, []string{"brokers", "username", "backend_endpoint"}, arguments)
+	assert.Equal(t, Question{
+		Argument: "brokers",
+		Question: "Kafka brokers",
+		Reason:   "the argument is required",
+		Schema:   map[string]any{"type": "string", "description": "Kafka brokers"},
+	}, response.Questions[0])
+	assert.Contains(t, resultText(result), "- username: SASL username (the plain and scram auth authenticate with a SASL username)")
+
+	result = callTool(t, wrapped, map[string]any{"brokers": "kafka:9092", "auth": "none", "backend_endpoint": "tempo:4317"})
+	assert.False(t, result.IsError)
+	assert.Equal(t, "kafka:9092", called["brokers"])
+
+	// The tools that do not generate configurations are not wrapped
+	other := Tool{Tool: mcp.NewTool("opentelemetry-collector-readme", mcp.WithString("name", mcp.Required())), Handler: tool.Handler}
+	result = callTool(t, WithQuestions([]Tool{other}, collectorschema.DefaultInputLimits)[0], map[string]any{})
+	assert.False(t, result.IsError)
+}
+
+type answeringElicitationHandler struct {
+	requests []mcp.ElicitationRequest
+	answers  []map[string]any
+}
+
+func (h *answeringElicitationHandler) Elicit(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	h.requests = append(h.requests, request)
+	if len(h.answers) == 0 {
+		return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}}, nil
+	}
+	answer := h.answers[0]
+	h.answers = h.answers[1:]
+	return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: answer}}, nil
+}
+
+func TestWithQuestions_Elicitation(t *testing.T) {
+	var called map[string]any
+	tool := Tool{
+		Tool: mcp.NewTool("opentelemetry-collector-loadbalancing-generate",
+			mcp.WithString("hostnames", mcp.Required(), mcp.Description("Downstream collector hostnames")),
+			mcp.WithString("backend_endpoint", mcp.Description("OTLP endpoint")),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			called = request.GetArguments()
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true), server.WithElicitation())
+	wrapped := WithQuestions([]Tool{tool}, collectorschema.InputLimits{MaxSize: 100, MaxDepth: 3})[0]
+	s.AddTool(wrapped.Tool, wrapped.Handler)
+
+	handler := &answeringElicitationHandler{answers: []map[string]any{{"backend_endpoint": "tempo:4317"}}}
+	c := client.NewClient(transport.NewInProcessTransportWithOptions(s, transport.WithElicitationHandler(handler)))
+	ctx := context.Background()
+	require.NoError(t, c.Start(ctx))
+	defer c.Close()
+	_, err := c.Initialize(ctx, mcp.InitializeRequest{Params: mcp.InitializeParams{
+		ProtocolVersion: mcp.LATEST_PROTOCOL_VERSION,
+		Capabilities:    mcp.ClientCapabilities{Elicitation: &struct{}{}},
+	}})
+	require.NoError(t, err)
+
+	call := func(arguments map[string]any) *mcp.CallToolResult {
+		result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: wrapped.Tool.Name, Arguments: arguments}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"hostnames": "collector-1"})
+	assert.False(t, result.IsError)
+	assert.Equal(t, map[string]any{"hostnames": "collector-1", "backend_endpoint": "tempo:4317"}, called)
+	require.Len(t, handler.requests, 1)
+	assert.Equal(t, "opentelemetry-collector-loadbalancing-generate needs backend_endpoint to generate the configuration", handler.requests[0].Params.Message)
+	assert.Equal(t, []string{"backend_endpoint"}, handler.requests[0].Params.RequestedSchema.(map[string]any)["required"])
+
+	// The declined elicitation returns the questions
+	called = nil
+	result = call(map[string]any{"hostnames": "collector-1"})
+	assert.True(t, result.IsError)
+	assert.Nil(t, called)
+	assert.Len(t, handler.requests, 2)
+
+	// The answers are converted to the schema types and checked against the input limits like the arguments
+	handler.answers = []map[string]any{{"backend_endpoint": 4317.0}, {"backend_endpoint": strings.Repeat("a", 200)}}
+	result = call(map[string]any{"hostnames": "collector-1"})
+	assert.False(t, result.IsError)
+	assert.Equal(t, "4317", called["backend_endpoint"])
+	called = nil
+	result = call(map[string]any{"hostnames": "collector-1"})
+	assert.True(t, result.IsError)
+	assert.Nil(t, called)
+	assert.Contains(t, resultText(result), "backend_endpoint")
+}
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// readmeAssetURIScheme is the scheme of the README image resources e.g. readme-asset://0.139.0/receiver/otlp/images/arch.png
+const readmeAssetURIScheme = "readme-asset"
+
+// readmeAssetURI returns the resource URI of a README image
+func readmeAssetURI(componentType collectorschema.ComponentType, componentName, version, assetPath string) string {
+	return fmt.Sprintf("%s://%s/%s/%s/%s", readmeAssetURIScheme, version, componentType, componentName, assetPath)
+}
+
+// parseReadmeAssetURI returns the component, version and path of a README image resource URI
+func parseReadmeAssetURI(uri string) (collectorschema.ComponentType, string, string, string, error) {
+	rest, ok := strings.CutPrefix(uri, readmeAssetURIScheme+"://")
+	parts := strings.SplitN(rest, "/", 4)
+	if !ok || len(parts) != 4 || parts[0] == "" || parts[2] == "" || parts[3] == "" {
+		return "", "", "", "", fmt.Errorf("invalid README image URI %q, must be %s://{version}/{kind}/{name}/{path}", uri, readmeAssetURIScheme)
+	}
+	componentType, err := collectorschema.ParseComponentType(parts[1])
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return componentType, parts[2], parts[0], parts[3], nil
+}
+
+// GetReadmeAssetResourceTemplate returns the resource template serving the images referenced by the component READMEs
+func GetReadmeAssetResourceTemplate(schemaManager *collectorschema.SchemaManager) ResourceTemplate {
+	template := mcp.NewResourceTemplate(
+		readmeAssetURIScheme+"://{version}/{kind}/{name}/{+path}",
+		"Component README image",
+		mcp.WithTemplateDescription("Images e.g. architecture diagrams referenced by the README of a collector component, the path is the image path in the README."),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		componentType, componentName, version, assetPath, err := parseReadmeAssetURI(request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+		asset, data, err := schemaManager.GetReadmeAsset(componentType, componentName, version, assetPath)
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{
+			mcp.BlobResourceContents{
+				URI:      readmeAssetURI(componentType, componentName, version, asset.Path),
+				MIMEType: asset.MimeType,
+				Blob:     base64.StdEncoding.EncodeToString(data),
+			},
+		}, nil
+	}
+
+	return ResourceTemplate{Template: template, Handler: handler}
+}
+
+// getReadmeAssetsTool returns the README images tool
+func getReadmeAssetsTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-readme-assets",
+		mcp.WithDescription("List or fetch the images e.g. architecture diagrams referenced by the README of an OpenTelemetry collector component, returned by opentelemetry-collector-readme. Without a path the images are returned as resource links, with the path of an image as referenced by the README e.g. images/arch.png the image is returned base64 encoded."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ReadmeAssetsResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component name e.g. otlp"),
+		),
+		mcp.WithString("path",
+			mcp.Description("Path of an image as referenced by the README e.g. images/arch.png, all images are listed if not set"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		response := &ReadmeAssetsResponse{Kind: string(componentType), Name: componentName, Version: version, Assets: []ReadmeAsset{}}
+
+		if assetPath := request.GetString("path", ""); assetPath != "" {
+			asset, data, err := schemaManager.GetReadmeAsset(componentType, componentName, version, assetPath)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get README image for %s %s: %v", componentType, componentName, err)), nil
+			}
+			uri := readmeAssetURI(componentType, componentName, version, asset.Path)
+			response.Assets = append(response.Assets, ReadmeAsset{ReadmeAsset: *asset, URI: uri})
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("%s (%s, %d bytes) of %s %s v%s", asset.Path, asset.MimeType, asset.Size, componentType, componentName, version)),
+					mcp.NewImageContent(base64.StdEncoding.EncodeToString(data), asset.MimeType),
+				},
+				StructuredContent: response,
+			}, nil
+		}
+
+		assets, err := schemaManager.GetReadmeAssets(componentType, componentName, version)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get README images for %s %s: %v", componentType, componentName, err)), nil
+		}
+		if len(assets) == 0 {
+			return mcp.NewToolResultStructured(response, fmt.Sprintf("The README of %s %s v%s references no images", componentType, componentName, version)), nil
+		}
+		content := []mcp.Content{mcp.NewTextContent(fmt.Sprintf("The README of %s %s v%s references %d images, read the resources or call the tool with the path of an image to get it", componentType, componentName, version, len(assets)))}
+		for _, asset := range assets {
+			uri := readmeAssetURI(componentType, componentName, version, asset.Path)
+			response.Assets = append(response.Assets, ReadmeAsset{ReadmeAsset: asset, URI: uri})
+			content = append(content, mcp.NewResourceLink(uri, asset.Path, fmt.Sprintf("%s (%d bytes)", asset.Path, asset.Size), asset.MimeType))
+		}
+		return &mcp.CallToolResult{Content: content, StructuredContent: response}, nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
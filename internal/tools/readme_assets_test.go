@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func newReadmeAssetsSchemaManager(t *testing.T) *collectorschema.SchemaManager {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"1.0.0/receiver_foo.yaml":                   "type: object\n",
+		"1.0.0/receiver_foo.md":                     "# foo\n\n![Architecture](images/arch.png)\n",
+		"1.0.0/assets/receiver_foo/images/arch.png": "png",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	schemaManager, err := collectorschema.NewSchemaManagerFromDir(dir)
+	require.NoError(t, err)
+	return schemaManager
+}
+
+func TestReadmeAssetsTool(t *testing.T) {
+	tool := getReadmeAssetsTool(newReadmeAssetsSchemaManager(t), "1.0.0")
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"kind": "receiver", "name": "foo"}
+	result, err := tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	link, ok := result.Content[1].(mcp.ResourceLink)
+	require.True(t, ok)
+	assert.Equal(t, "readme-asset://1.0.0/receiver/foo/images/arch.png", link.URI)
+	assert.Equal(t, "image/png", link.MIMEType)
+
+	request.Params.Arguments = map[string]any{"kind": "receiver", "name": "foo", "path": "./images/arch.png"}
+	result, err = tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	image, ok := result.Content[1].(mcp.ImageContent)
+	require.True(t, ok)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("png")), image.Data)
+	assert.Equal(t, "image/png", image.MIMEType)
+
+	request.Params.Arguments = map[string]any{"kind": "receiver", "name": "foo", "path": "images/missing.png"}
+	result, err = tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestReadmeAssetResourceTemplate(t *testing.T) {
+	template := GetReadmeAssetResourceTemplate(newReadmeAssetsSchemaManager(t))
+	uri := "readme-asset://1.0.0/receiver/foo/images/arch.png"
+	assert.True(t, template.Template.URITemplate.Regexp().MatchString(uri))
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	contents, err := template.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	blob, ok := contents[0].(mcp.BlobResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, uri, blob.URI)
+	assert.Equal(t, "image/png", blob.MIMEType)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("png")), blob.Blob)
+
+	for _, invalid := range []string{"readme-asset://1.0.0/receiver/foo", "readme-asset://1.0.0/unknown/foo/arch.png", "artifact://1.0.0/receiver/foo/arch.png"} {
+		request.Params.URI = invalid
+		_, err := template.Handler(context.Background(), request)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getReceiverCreatorGenerateTool returns the receiver_creator configuration generation tool
+func getReceiverCreatorGenerateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-receiver-creator-generate",
+		mcp.WithDescription("Generate receiver_creator and observer extension configuration that starts a receiver for dynamically discovered endpoints e.g. discover redis pods by annotation and scrape them"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("observer",
+			mcp.Required(),
+			mcp.Description("Observer extension discovering the endpoints. It can be k8s_observer, docker_observer, host_observer and ecs_observer."),
+		),
+		mcp.WithString("receiver",
+			mcp.Required(),
+			mcp.Description("Receiver started for each discovered endpoint e.g. redis"),
+		),
+		mcp.WithString("endpoint_type",
+			mcp.Description("Discovered endpoint type. It can be port, pod, pod.container, k8s.node, k8s.service, k8s.ingress (k8s_observer), container (docker_observer, ecs_observer) and hostport (host_observer)."),
+		),
+		mcp.WithString("match",
+			mcp.Description("Match criteria JSON e.g. {\"port\": 6379, \"pod_annotations\": {\"redis.io/scrape\": \"true\"}}. Supported keys: port, name, namespace, image, process_name, pod_labels, pod_annotations."),
+		),
+		mcp.WithString("config",
+			mcp.Description("Receiver configuration JSON. Defaults to {\"endpoint\": \"`endpoint`\"}, backtick expressions are resolved from the discovered endpoint."),
+		),
+		mcp.WithString("signal",
+			mcp.Description("Pipeline signal the receiver is added to. It can be traces, metrics and logs. Defaults to metrics."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		observer, err := request.RequireString("observer")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("observer argument is required: %v", err)), nil
+		}
+		receiver, err := request.RequireString("receiver")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("receiver argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		rcRequest := generate.ReceiverCreatorRequest{
+			Observer:     observer,
+			EndpointType: request.GetString("endpoint_type", ""),
+			Receiver:     receiver,
+			Signal:       request.GetString("signal", ""),
+		}
+		if match := request.GetString("match", ""); match != "" {
+			if err := json.Unmarshal([]byte(match), &rcRequest.Match); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse match JSON: %v", err)), nil
+			}
+		}
+		if config := request.GetString("config", ""); config != "" {
+			if err := json.Unmarshal([]byte(config), &rcRequest.Config); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse config JSON: %v", err)), nil
+			}
+		}
+
+		// The receiver has to exist in the collector version and its config has to match the schema
+		receiverName, _, _ := strings.Cut(receiver, "/")
+		if _, err := schemaManager.GetComponentSchema(collectorschema.ComponentTypeReceiver, receiverName, version); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("receiver %s is not available in version %s: %v", receiverName, version, err)), nil
+		}
+
+		result, err := generate.ReceiverCreator(rcRequest)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate receiver_creator config: %v", err)), nil
+		}
+
+		receiverConfig, err := json.Marshal(result.ReceiverConfig)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal receiver config: %v", err)), nil
+		}
+		validationResult, err := schemaManager.ValidateComponentJSON(collectorschema.ComponentTypeReceiver, receiverName, version, receiverConfig)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to validate receiver config: %v", err)), nil
+		}
+		if !validationResult.Valid() {
+			return mcp.NewToolResultError(fmt.Sprintf("receiver config is not valid for %s@%s: %v", receiverName, version, validationResult.Errors())), nil
+		}
+
+		configYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		configYAML, err = stampConfig(request, version, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(configYAML), Rule: result.Rule, Verification: verification}
+		return artifactResult(artifactStore, "receiver_creator.yaml", "application/yaml", fmt.Sprintf("rule: %s\n\n%s\n%s", result.Rule, configYAML, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getReceiverCreatorRuleValidationTool returns the receiver_creator rule validation tool
+func getReceiverCreatorRuleValidationTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-receiver-creator-rule-validation",
+		mcp.WithDescription("Validate the syntax of a receiver_creator rule expression and the endpoint variables it uses"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ValidationResponse](),
+		mcp.WithString("rule",
+			mcp.Required(),
+			mcp.Description("receiver_creator rule e.g. type == \"port\" && port == 6379"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		rule, err := request.RequireString("rule")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("rule argument is required: %v", err)), nil
+		}
+
+		problems := generate.ValidateReceiverCreatorRule(rule)
+		response := ValidationResponse{Valid: len(problems) == 0, Errors: problems}
+		if response.Errors == nil {
+			response.Errors = []string{}
+		}
+		return mcp.NewToolResultStructured(response, fmt.Sprintf("is valid: %v, errors: %v", len(problems) == 0, problems)), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/recording"
+)
+
+// WithRecording records every tool call with the arguments of the client and the returned result for the replay
+// command, a failed recording is logged and does not fail the call
+func WithRecording(tools []Tool, recorder *recording.Recorder) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		handler := tool.Handler
+		name := tool.Tool.Name
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := handler(ctx, request)
+			call, recordErr := recording.NewCall(sessionID(ctx), name, request.Params.Arguments, result, err)
+			if recordErr == nil {
+				recordErr = recorder.Record(call)
+			}
+			if recordErr != nil {
+				log.Printf("failed to record the call of %s: %v", name, recordErr)
+			}
+			return result, err
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
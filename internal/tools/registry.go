@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/registry"
+)
+
+// RegistrySearchResponse lists the registry entries matching a query
+type RegistrySearchResponse struct {
+	Query   string           `json:"query"`
+	Entries []registry.Entry `json:"entries"`
+}
+
+// GetRegistryTools returns the opt-in OpenTelemetry registry tools, registryURL enables refreshing the embedded snapshot
+func GetRegistryTools(otelRegistry *registry.Registry, registryURL string) []Tool {
+	return []Tool{
+		getRegistrySearchTool(otelRegistry, registryURL),
+	}
+}
+
+// getRegistrySearchTool returns the OpenTelemetry registry search tool
+func getRegistrySearchTool(otelRegistry *registry.Registry, registryURL string) Tool {
+	tool := mcp.NewTool("opentelemetry-registry-search",
+		mcp.WithDescription("Search the OpenTelemetry registry for instrumentation libraries, SDK exporters and collector components of all languages e.g. is there an instrumentation for Kafka in Python. Returns the packages, repositories and registry links."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(registryURL != ""),
+		mcp.WithOutputSchema[RegistrySearchResponse](),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The library, framework or backend to search for e.g. kafka python. A language in the query restricts the results."),
+		),
+		mcp.WithString("language",
+			mcp.Description("Restrict the results to a language: python, java, go, js, dotnet, ruby, php, rust, swift, erlang, cpp or collector."),
+		),
+		mcp.WithString("type",
+			mcp.Description("Restrict the results to a registry type e.g. instrumentation, exporter, receiver or processor."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of results. Defaults to 10."),
+		),
+		mcp.WithBoolean("refresh",
+			mcp.Description("Download the latest registry snapshot before the search. Requires the server to be started with --registry-url."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query, err := request.RequireString("query")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("query argument is required: %v", err)), nil
+		}
+
+		if request.GetBool("refresh", false) {
+			if registryURL == "" {
+				return mcp.NewToolResultError("refreshing the registry is disabled, start the server with --registry-url"), nil
+			}
+			if err := otelRegistry.Refresh(ctx, registryURL); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to refresh the registry: %v", err)), nil
+			}
+		}
+
+		entries := otelRegistry.Search(query, request.GetString("language", ""), request.GetString("type", ""), request.GetInt("limit", 10))
+		response := RegistrySearchResponse{Query: query, Entries: entries}
+		if len(entries) == 0 {
+			return mcp.NewToolResultStructured(response, fmt.Sprintf("no registry entries found for %q", query)), nil
+		}
+		lines := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			lines = append(lines, fmt.Sprintf("- %s (%s %s): %s %s", entry.Title, entry.Language, entry.RegistryType, entry.Package.Name, entry.Repo))
+		}
+		return mcp.NewToolResultStructured(response, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
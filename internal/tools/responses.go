@@ -0,0 +1,478 @@
+package tools
+
+import (
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/analysis"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/github"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/hardening"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/memorysettings"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/migrate"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/provenance"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// The response models below are the structured results of the tools, their JSON schemas are advertised as tool output schemas.
+// Responses with large content implement artifactResponse, the content is replaced by a resource URI when it is too large.
+
+// VersionsResponse lists the supported collector versions
+type VersionsResponse struct {
+	Versions []string `json:"versions"`
+}
+
+// ComponentsResponse lists the components of a kind
+type ComponentsResponse struct {
+	Kind       string   `json:"kind"`
+	Version    string   `json:"version"`
+	Components []string `json:"components"`
+	// Warnings are the deprecated and unmaintained components by name
+	Warnings map[string]string `json:"warnings,omitempty"`
+}
+
+// ReadmeResponse contains a component README
+type ReadmeResponse struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	Readme      string `json:"readme,omitempty"`
+	ResourceURI string `json:"resourceUri,omitempty" jsonschema:"description=Set instead of readme when the README is returned as a resource"`
+	// Citation are the upstream sources to cite, pinned to the version
+	Citation *github.Citation `json:"citation,omitempty"`
+}
+
+func (r *ReadmeResponse) setResourceURI(uri string) {
+	r.Readme = ""
+	r.ResourceURI = uri
+}
+
+// ReadmeAssetsResponse contains the images referenced by the README of a component, or the requested image without
+// its content which is returned as image content
+type ReadmeAssetsResponse struct {
+	Kind    string        `json:"kind"`
+	Name    string        `json:"name"`
+	Version string        `json:"version"`
+	Assets  []ReadmeAsset `json:"assets"`
+}
+
+// ReadmeAsset is an image referenced by a README with the URI of its resource
+type ReadmeAsset struct {
+	collectorschema.ReadmeAsset
+	URI string `json:"uri"`
+}
+
+// ChangelogResponse contains the changelog of a version
+type ChangelogResponse struct {
+	Version     string `json:"version"`
+	Source      string `json:"source" jsonschema:"description=The changelog of the core repository, the contrib repository or both merged (all)"`
+	Changelog   string `json:"changelog,omitempty"`
+	ResourceURI string `json:"resourceUri,omitempty" jsonschema:"description=Set instead of changelog when the changelog is returned as a resource"`
+}
+
+func (r *ChangelogResponse) setResourceURI(uri string) {
+	r.Changelog = ""
+	r.ResourceURI = uri
+}
+
+// CoreDocResponse contains a core documentation page or lists the pages
+type CoreDocResponse struct {
+	Name string `json:"name,omitempty"`
+	// Path is the package of the opentelemetry-collector repository the page documents e.g. config/configtls
+	Path        string                    `json:"path,omitempty"`
+	Docs        []collectorschema.CoreDoc `json:"docs,omitempty" jsonschema:"description=The core documentation pages when no name is requested"`
+	Doc         string                    `json:"doc,omitempty"`
+	ResourceURI string                    `json:"resourceUri,omitempty" jsonschema:"description=Set instead of doc when the page is returned as a resource"`
+}
+
+func (r *CoreDocResponse) setResourceURI(uri string) {
+	r.Doc = ""
+	r.ResourceURI = uri
+}
+
+// CommonSettingsResponse contains the documentation and the schema of common settings or lists the common settings
+type CommonSettingsResponse struct {
+	Version  string                                  `json:"version"`
+	Settings []collectorschema.CommonSetting         `json:"settings,omitempty" jsonschema:"description=The common settings when no name is requested"`
+	Setting  *collectorschema.CommonSettingReference `json:"setting,omitempty"`
+}
+
+// ConnectorConversionsResponse lists the connectors converting between pipeline signals
+type ConnectorConversionsResponse struct {
+	Version    string                                 `json:"version"`
+	Connectors []collectorschema.ConnectorConversions `json:"connectors"`
+}
+
+// CloudCredentialsResponse is the credential configuration of a cloud exporter with the permissions, environment
+// variables and setup outside of the collector it needs
+type CloudCredentialsResponse struct {
+	Exporter    string                        `json:"exporter"`
+	Cloud       string                        `json:"cloud"`
+	Auth        string                        `json:"auth"`
+	Config      string                        `json:"config"`
+	Permissions []string                      `json:"permissions,omitempty"`
+	Roles       []string                      `json:"roles,omitempty"`
+	Env         []collectorschema.CloudEnvVar `json:"env,omitempty"`
+	Setup       []string                      `json:"setup"`
+	Warnings    []string                      `json:"warnings,omitempty"`
+	// AuthMethods are the supported auth methods of the exporter
+	AuthMethods  []string            `json:"authMethods"`
+	Verification *ConfigVerification `json:"verification,omitempty" jsonschema:"description=The validation report of the returned configuration"`
+}
+
+// FailureModesResponse are the curated failure modes matching an error message or of a component
+type FailureModesResponse struct {
+	Version string                             `json:"version"`
+	Matches []collectorschema.FailureModeMatch `json:"matches"`
+}
+
+// SchemaResponse contains a JSON schema
+type SchemaResponse struct {
+	Kind        string                 `json:"kind,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Version     string                 `json:"version"`
+	FileName    string                 `json:"fileName,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	CUE         string                 `json:"cue,omitempty" jsonschema:"description=The schema converted to a CUE definition, set instead of schema for the cue format"`
+	Locale      string                 `json:"locale,omitempty" jsonschema:"description=Locale of the translated field descriptions, empty for the English descriptions"`
+	ResourceURI string                 `json:"resourceUri,omitempty" jsonschema:"description=Set instead of schema when the schema is returned as a resource"`
+}
+
+func (r *SchemaResponse) setResourceURI(uri string) {
+	r.Schema = nil
+	r.CUE = ""
+	r.ResourceURI = uri
+}
+
+// SchemaBundleResponse contains the schema bundle of a version
+type SchemaBundleResponse struct {
+	Manifest    collectorschema.SchemaBundleManifest `json:"manifest"`
+	FileName    string                               `json:"fileName"`
+	Bundle      *collectorschema.SchemaBundle        `json:"bundle,omitempty"`
+	ResourceURI string                               `json:"resourceUri,omitempty" jsonschema:"description=Set instead of bundle when the bundle is returned as a resource"`
+}
+
+func (r *SchemaBundleResponse) setResourceURI(uri string) {
+	r.Bundle = nil
+	r.ResourceURI = uri
+}
+
+// ValidationResponse is the result of a validation
+type ValidationResponse struct {
+	Valid       bool                            `json:"valid"`
+	Profile     string                          `json:"profile,omitempty"`
+	Errors      []string                        `json:"errors"`
+	Suggestions []collectorschema.KeySuggestion `json:"suggestions,omitempty"`
+	Warnings    []string                        `json:"warnings,omitempty"`
+	Omitted     int                             `json:"omitted,omitempty" jsonschema:"description=Number of errors and suggestions omitted by the message limit of the validation profile"`
+}
+
+// ConfigValidationResponse are the findings of the validate checks of a configuration
+type ConfigValidationResponse struct {
+	Valid    bool             `json:"valid"`
+	Version  string           `json:"version"`
+	Profile  string           `json:"profile"`
+	Findings []report.Finding `json:"findings"`
+	Summary  report.Summary   `json:"summary"`
+	Omitted  int              `json:"omitted,omitempty" jsonschema:"description=Number of findings omitted by the message limit of the validation profile"`
+}
+
+// ConfigVersionsValidationResponse is the validation of a configuration against the config schemas of several versions
+type ConfigVersionsValidationResponse struct {
+	Profile string `json:"profile"`
+	// ValidVersions are the versions the configuration is valid for
+	ValidVersions []string                  `json:"validVersions"`
+	Results       []ConfigVersionValidation `json:"results"`
+}
+
+// ConfigVersionValidation is the validation of a configuration against the config schema of a version
+type ConfigVersionValidation struct {
+	Version string   `json:"version"`
+	Valid   bool     `json:"valid"`
+	Errors  []string `json:"errors"`
+	Omitted int      `json:"omitted,omitempty" jsonschema:"description=Number of errors omitted by the message limit of the validation profile"`
+}
+
+// IssuesResponse is the result of a configuration validation
+type IssuesResponse struct {
+	Valid  bool                    `json:"valid"`
+	Issues []collectorconfig.Issue `json:"issues"`
+}
+
+// SpellingResponse lists the configuration keys resembling a schema property with the correct key
+type SpellingResponse struct {
+	Valid       bool                            `json:"valid"`
+	Profile     string                          `json:"profile,omitempty"`
+	Suggestions []collectorschema.KeySuggestion `json:"suggestions"`
+	Warnings    []string                        `json:"warnings,omitempty"`
+	Omitted     int                             `json:"omitted,omitempty" jsonschema:"description=Number of suggestions omitted by the message limit of the validation profile"`
+}
+
+// DeprecatedFieldsResponse lists the deprecated fields of components
+type DeprecatedFieldsResponse struct {
+	Components []DeprecatedComponentFields `json:"components"`
+}
+
+// SampleConfigResponse contains a sample configuration of a component generated from its schema
+type SampleConfigResponse struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Fill    string `json:"fill"`
+	// Config is the sample under the section of the component e.g. exporters::otlp
+	Config       string              `json:"config,omitempty"`
+	Verification *ConfigVerification `json:"verification,omitempty" jsonschema:"description=The validation report of the returned configuration"`
+	ResourceURI  string              `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *SampleConfigResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.ResourceURI = uri
+}
+
+// GeneratedConfigResponse contains a generated collector configuration YAML
+type GeneratedConfigResponse struct {
+	Config string `json:"config,omitempty"`
+	// DownstreamConfig is the configuration of the second collector tier e.g. behind a load balancer
+	DownstreamConfig       string                  `json:"downstreamConfig,omitempty"`
+	Rule                   string                  `json:"rule,omitempty"`
+	Issues                 []collectorconfig.Issue `json:"issues,omitempty"`
+	Warnings               []string                `json:"warnings,omitempty"`
+	Behavior               []string                `json:"behavior,omitempty" jsonschema:"description=How the returned configuration behaves e.g. when an exporter fails"`
+	Verification           *ConfigVerification     `json:"verification,omitempty" jsonschema:"description=The validation report of the returned configuration"`
+	DownstreamVerification *ConfigVerification     `json:"downstreamVerification,omitempty" jsonschema:"description=The validation report of the returned downstream configuration"`
+	ResourceURI            string                  `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *GeneratedConfigResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.DownstreamConfig = ""
+	r.ResourceURI = uri
+}
+
+// StarterKitResponse is the starter kit of an environment described in free text
+type StarterKitResponse struct {
+	Environment  generate.Environment           `json:"environment" jsonschema:"description=The platform, languages and backends recognized in the description"`
+	Components   []generate.ComponentSuggestion `json:"components"`
+	Config       string                         `json:"config,omitempty"`
+	Files        []generate.GeneratedFile       `json:"files,omitempty" jsonschema:"description=The Kubernetes manifests deploying the collector"`
+	SDKs         []generate.SDKSettings         `json:"sdks" jsonschema:"description=The environment variables of the instrumented services per language"`
+	Issues       []collectorconfig.Issue        `json:"issues,omitempty"`
+	Warnings     []string                       `json:"warnings,omitempty"`
+	Verification *ConfigVerification            `json:"verification,omitempty" jsonschema:"description=The validation report of the returned configuration"`
+	ResourceURI  string                         `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config and files when the starter kit is returned as a resource"`
+}
+
+func (r *StarterKitResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.Files = nil
+	r.ResourceURI = uri
+}
+
+// ProvenanceResponse is the verification of the provenance header of a generated configuration
+type ProvenanceResponse struct {
+	provenance.Verification
+	// Reproducible is true if the generate tool generates the same configuration with the recorded parameters, it is
+	// not set if the configuration cannot be regenerated
+	Reproducible *bool `json:"reproducible,omitempty"`
+}
+
+// ComponentModulesResponse lists the components with the Go modules providing them
+type ComponentModulesResponse struct {
+	Version    string                            `json:"version"`
+	Components []collectorschema.ComponentModule `json:"components"`
+}
+
+// ComponentOwnersResponse contains the code owners and the support status of a component
+type ComponentOwnersResponse struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	collectorschema.ComponentStatus
+	Unmaintained bool `json:"unmaintained" jsonschema:"description=A signal of the component has no active code owners and is removed if none are found"`
+	Deprecated   bool `json:"deprecated" jsonschema:"description=A signal of the component is deprecated and slated for removal"`
+}
+
+// ConflictsResponse lists duplicate and conflicting components, settings exceeding known limits or malformed endpoints
+type ConflictsResponse struct {
+	Findings []analysis.Finding `json:"findings"`
+}
+
+// ConfigCheckResponse are the validate and lint findings of a configuration, the report in the requested format is
+// returned as text
+type ConfigCheckResponse struct {
+	Version  string           `json:"version"`
+	Profile  string           `json:"profile"`
+	Findings []report.Finding `json:"findings"`
+	Summary  report.Summary   `json:"summary"`
+	// PolicyPacks are the names of the policy packs enforced by the lint checks
+	PolicyPacks []string `json:"policyPacks,omitempty"`
+	// ResourceURI is the artifact of the report if it is too large to be returned as text
+	ResourceURI string `json:"resourceUri,omitempty"`
+}
+
+func (r *ConfigCheckResponse) setResourceURI(uri string) {
+	r.ResourceURI = uri
+}
+
+// GitHubComponentResponse contains the upstream README or open issues of a component
+type GitHubComponentResponse struct {
+	Kind        string         `json:"kind"`
+	Name        string         `json:"name"`
+	Readme      string         `json:"readme,omitempty"`
+	Issues      []github.Issue `json:"issues,omitempty"`
+	ResourceURI string         `json:"resourceUri,omitempty" jsonschema:"description=Set instead of readme when the README is returned as a resource"`
+}
+
+func (r *GitHubComponentResponse) setResourceURI(uri string) {
+	r.Readme = ""
+	r.ResourceURI = uri
+}
+
+// GoldenTestResponse contains the files of a generated golden test harness
+type GoldenTestResponse struct {
+	Files       []generate.GeneratedFile `json:"files,omitempty"`
+	Warnings    []string                 `json:"warnings,omitempty"`
+	ResourceURI string                   `json:"resourceUri,omitempty" jsonschema:"description=Set instead of files when the harness is returned as a resource"`
+}
+
+func (r *GoldenTestResponse) setResourceURI(uri string) {
+	r.Files = nil
+	r.ResourceURI = uri
+}
+
+// SmokeTestResponse contains CLI invocations sending synthetic telemetry to the receivers
+type SmokeTestResponse struct {
+	Commands []generate.SmokeTestCommand `json:"commands"`
+	Warnings []string                    `json:"warnings,omitempty"`
+}
+
+// DeprecatedMigrationResponse contains the configuration with the deprecated components replaced
+type DeprecatedMigrationResponse struct {
+	Config       string                       `json:"config,omitempty"`
+	Migrations   []migrate.ComponentMigration `json:"migrations"`
+	Issues       []collectorconfig.Issue      `json:"issues,omitempty"`
+	Verification *ConfigVerification          `json:"verification,omitempty" jsonschema:"description=The validation report of the returned configuration"`
+	ResourceURI  string                       `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *DeprecatedMigrationResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.ResourceURI = uri
+}
+
+// AnnotatedConfigResponse contains the configuration commented with the field descriptions
+type AnnotatedConfigResponse struct {
+	Config    string `json:"config,omitempty"`
+	Annotated int    `json:"annotated" jsonschema:"description=The number of commented fields"`
+	// MissingSchemas are the component types without a schema for the collector version e.g. receivers::otlp
+	MissingSchemas []string `json:"missingSchemas,omitempty"`
+	ResourceURI    string   `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *AnnotatedConfigResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.ResourceURI = uri
+}
+
+// MinimizedConfigResponse contains the configuration without the fields set to their default value
+type MinimizedConfigResponse struct {
+	Config string `json:"config,omitempty"`
+	// Removed are the removed fields e.g. processors::batch::timeout
+	Removed []string `json:"removed"`
+	// MissingSchemas are the component types without a schema for the collector version e.g. receivers::otlp
+	MissingSchemas []string `json:"missingSchemas,omitempty"`
+	ResourceURI    string   `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *MinimizedConfigResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.ResourceURI = uri
+}
+
+// ExpandedConfigResponse contains the configuration with the fields that are not set filled with their default value
+type ExpandedConfigResponse struct {
+	Config string `json:"config,omitempty"`
+	// Added are the added fields e.g. processors::batch::timeout
+	Added []string `json:"added"`
+	// MissingSchemas are the component types without a schema for the collector version e.g. receivers::otlp
+	MissingSchemas []string `json:"missingSchemas,omitempty"`
+	ResourceURI    string   `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *ExpandedConfigResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.ResourceURI = uri
+}
+
+// MemorySettingsResponse contains the configuration with the recommended memory settings, the environment variables to
+// set on the collector container and the applied changes
+type MemorySettingsResponse struct {
+	Config       string                  `json:"config,omitempty"`
+	Version      string                  `json:"version"`
+	Env          []memorysettings.EnvVar `json:"env" jsonschema:"description=The environment variables to set on the collector container e.g. GOMEMLIMIT"`
+	Changes      []memorysettings.Change `json:"changes"`
+	Warnings     []string                `json:"warnings,omitempty"`
+	Verification *ConfigVerification     `json:"verification,omitempty" jsonschema:"description=The validation report of the returned configuration"`
+	ResourceURI  string                  `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *MemorySettingsResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.ResourceURI = uri
+}
+
+// HardenedConfigResponse contains the configuration with a hardening profile applied and the applied changes
+type HardenedConfigResponse struct {
+	Config       string                  `json:"config,omitempty"`
+	Profile      string                  `json:"profile"`
+	Changes      []hardening.Change      `json:"changes"`
+	Warnings     []string                `json:"warnings,omitempty"`
+	Issues       []collectorconfig.Issue `json:"issues,omitempty"`
+	Verification *ConfigVerification     `json:"verification,omitempty" jsonschema:"description=The validation report of the returned configuration"`
+	ResourceURI  string                  `json:"resourceUri,omitempty" jsonschema:"description=Set instead of config when the configuration is returned as a resource"`
+}
+
+func (r *HardenedConfigResponse) setResourceURI(uri string) {
+	r.Config = ""
+	r.ResourceURI = uri
+}
+
+// InputTooLargeResponse is the error result of a tool call with an argument over the input limits
+type InputTooLargeResponse struct {
+	Error string `json:"error"`
+	// Argument is the rejected argument
+	Argument string `json:"argument"`
+	collectorschema.InputTooLargeError
+}
+
+// CapabilitiesResponse lists the served tools, the worked examples are listed for a requested tool
+type CapabilitiesResponse struct {
+	Tools []ToolCapability `json:"tools"`
+}
+
+// ToolCapability is a served tool with its required arguments and the number of its worked examples, the examples
+// are set for a requested tool
+type ToolCapability struct {
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	Required     []string      `json:"required,omitempty"`
+	ExampleCount int           `json:"exampleCount"`
+	Examples     []ToolExample `json:"examples,omitempty"`
+}
+
+// QuestionsResponse is the error result of a generation tool call with missing arguments, the tool is called again
+// with the arguments and the answers
+type QuestionsResponse struct {
+	Tool      string         `json:"tool"`
+	Questions []Question     `json:"questions"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// Question asks for a missing argument, the schema is the property of the argument in the tool input schema
+type Question struct {
+	Argument string         `json:"argument"`
+	Question string         `json:"question"`
+	Reason   string         `json:"reason"`
+	Schema   map[string]any `json:"schema,omitempty"`
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getRoutingGenerateTool returns the routing connector configuration generation tool
+func getRoutingGenerateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-routing-generate",
+		mcp.WithDescription("Generate routing connector configuration from routing intents e.g. route by resource attribute or by tenant header. Returns the OTTL routing conditions, the multi-pipeline wiring and topology validation issues."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("signal",
+			mcp.Description("Pipeline signal that is routed. It can be traces, metrics and logs. Defaults to traces."),
+		),
+		mcp.WithString("receivers",
+			mcp.Description("Comma-separated receivers of the incoming pipeline. Defaults to otlp."),
+		),
+		mcp.WithString("routes",
+			mcp.Required(),
+			mcp.Description("Routes JSON array e.g. [{\"source\": \"request\", \"attribute\": \"X-Tenant\", \"values\": [\"acme\"], \"exporters\": [\"otlp/acme\"]}]. Source can be resource (default), request, span, metric, datapoint and log. A custom OTTL condition can be set instead of attribute and values."),
+		),
+		mcp.WithString("default_exporters",
+			mcp.Description("Comma-separated exporters receiving telemetry that does not match any route"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		routesJSON, err := request.RequireString("routes")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("routes argument is required: %v", err)), nil
+		}
+
+		routingRequest := generate.RoutingRequest{
+			Signal:           request.GetString("signal", ""),
+			Receivers:        splitList(request.GetString("receivers", "")),
+			DefaultExporters: splitList(request.GetString("default_exporters", "")),
+		}
+		if err := json.Unmarshal([]byte(routesJSON), &routingRequest.Routes); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse routes JSON: %v", err)), nil
+		}
+
+		result, err := generate.Routing(routingRequest)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate routing config: %v", err)), nil
+		}
+		configYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		configYAML, err = stampConfig(request, "", configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, latestCollectorVersion, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(configYAML), Issues: result.Issues, Verification: verification}
+		return artifactResult(artifactStore, "routing.yaml", "application/yaml", fmt.Sprintf("%s\nissues: %v\n%s", configYAML, result.Issues, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getRoutingValidationTool returns the routing connector and processor validation tool
+func getRoutingValidationTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-routing-validation",
+		mcp.WithDescription("Validate routing connector and routing processor statements and conditions, the pipelines they route to and the topology of the collector configuration"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[IssuesResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		issues := generate.ValidateRouting(config)
+		response := IssuesResponse{Valid: !collectorconfig.HasErrors(issues), Issues: issues}
+		if response.Issues == nil {
+			response.Issues = []collectorconfig.Issue{}
+		}
+		return mcp.NewToolResultStructured(response, fmt.Sprintf("is valid: %v, issues: %v", !collectorconfig.HasErrors(issues), issues)), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// splitList splits a comma-separated list and trims the values
+func splitList(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+)
+
+// getSampleConfigTool returns the tool generating a sample configuration of a component from its schema
+func getSampleConfigTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-sample-config",
+		mcp.WithDescription("Generate a sample configuration of a collector component from its schema. The values are the schema defaults, the first allowed values or examples and otherwise placeholders matching the field type, pattern and name e.g. 5s for durations and localhost:4317 for endpoints. The sample is valid against the schema of the version, review the placeholders before using it."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SampleConfigResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component name e.g. otlp"),
+		),
+		mcp.WithString("fill",
+			mcp.Description("The fields of the sample: minimal are the required fields and endpoints, typical adds the fields of the component and its settings without deeply nested ones and full adds all fields. Deprecated fields are never added. Defaults to typical."),
+			mcp.Enum(collectorschema.SampleFills...),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		fill := request.GetString("fill", collectorschema.SampleFillTypical)
+
+		sample, err := schemaManager.GenerateSampleConfig(componentType, componentName, version, fill)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate sample config for %s %s: %v", componentType, componentName, err)), nil
+		}
+		configYAML, err := marshalSampleConfig(componentType.Section(), componentName, sample)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &SampleConfigResponse{Kind: string(componentType), Name: componentName, Version: version, Fill: fill, Config: string(configYAML), Verification: verification}
+		return artifactResult(artifactStore, fmt.Sprintf("%s_%s.yaml", componentType, componentName), "application/yaml", fmt.Sprintf("%s\n%s", configYAML, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// marshalSampleConfig returns the YAML of a component sample under its configuration section e.g. exporters::otlp
+func marshalSampleConfig(section, componentName string, sample map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(map[string]interface{}{section: map[string]interface{}{componentName: sample}}); err != nil {
+		return nil, fmt.Errorf("failed to marshal sample config YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal sample config YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+)
+
+// getSchemaBundleTool returns the tool packaging all schemas of a version into a single document
+func getSchemaBundleTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-schema-bundle",
+		mcp.WithDescription("Export all schemas of an OpenTelemetry collector version as a single JSON document for offline tooling: the JSON Schema of a full configuration and every component with its manifest entry, JSON Schema, README and field defaults. The bundle is returned as a resource, its manifest describes the format and the number of components."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SchemaBundleResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+		bundle, err := schemaManager.GetSchemaBundle(version)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get schema bundle for version %s: %v", version, err)), nil
+		}
+
+		bundleJSON, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal schema bundle: %v", err)), nil
+		}
+		fileName := fmt.Sprintf("otelcol-%s.bundle.json", version)
+		response := &SchemaBundleResponse{Manifest: bundle.Manifest, FileName: fileName, Bundle: bundle}
+		return artifactResult(artifactStore, fileName, "application/json", string(bundleJSON), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
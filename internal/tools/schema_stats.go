@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// getSchemaStatsTool returns the component schema size tool
+func getSchemaStatsTool(schemaManager *collectorschema.SchemaManager) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-component-schema-stats",
+		mcp.WithDescription("Report the size of an OpenTelemetry collector component configuration schema: the number of fields including the nested ones, the top-level, required and deprecated fields, the nesting depth and the JSON size of the full schema and of the summary. Without a version the stats of every version including the component are returned to track the schema growth. Use it to decide between opentelemetry-collector-component-schema and opentelemetry-collector-component-summary."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[collectorschema.ComponentSchemaStats](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0, all versions including the component if not set"),
+		),
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Collector component name e.g. otlp"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+
+		var componentStats *collectorschema.ComponentSchemaStats
+		if version := request.GetString("version", ""); version != "" {
+			stats, err := schemaManager.GetSchemaStats(componentType, componentName, version)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get schema stats for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+			}
+			componentStats = &collectorschema.ComponentSchemaStats{
+				Component: fmt.Sprintf("%s/%s", componentType, componentName),
+				Versions:  []collectorschema.SchemaStats{*stats},
+			}
+		} else {
+			componentStats, err = schemaManager.GetComponentSchemaStats(componentType, componentName)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		lines := []string{componentStats.Component}
+		for _, stats := range componentStats.Versions {
+			lines = append(lines, fmt.Sprintf("%s: %d fields (%d top-level, %d required, %d deprecated, depth %d), schema %d bytes, summary %d bytes",
+				stats.Version, stats.Fields, stats.TopLevelFields, stats.Required, stats.Deprecated, stats.MaxDepth, stats.SchemaBytes, stats.SummaryBytes))
+		}
+		return mcp.NewToolResultStructured(componentStats, strings.Join(lines, "\n")), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
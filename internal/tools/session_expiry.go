@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"context"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+// WithSessionExpiry records the session of every tool call so the state of the sessions that are no longer used, the
+// version pins, validation profiles and snapshots, expires. A failure is logged and does not fail the call.
+func WithSessionExpiry(tools []Tool, expiry *storage.SessionExpiry) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := expiry.Touch(sessionID(ctx)); err != nil {
+				log.Printf("failed to expire the session state: %v", err)
+			}
+			return handler(ctx, request)
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/snapshots"
+)
+
+// defaultSession is the session of requests without a client session e.g. in-process calls
+const defaultSession = "default"
+
+// SnapshotResponse is the result of a snapshot action, the list action returns the snapshots without their configuration
+type SnapshotResponse struct {
+	Action    string               `json:"action"`
+	Snapshot  *snapshots.Snapshot  `json:"snapshot,omitempty"`
+	Snapshots []snapshots.Snapshot `json:"snapshots,omitempty"`
+}
+
+// GetSnapshotTools returns the tools managing the config snapshots of the client session
+func GetSnapshotTools(snapshotStore *snapshots.Store) []Tool {
+	return []Tool{
+		getConfigSnapshotTool(snapshotStore),
+	}
+}
+
+// GetSnapshotResourceTemplate returns the resource template serving the config snapshots of the client session
+func GetSnapshotResourceTemplate(snapshotStore *snapshots.Store) ResourceTemplate {
+	template := mcp.NewResourceTemplate(
+		snapshots.URIScheme+"://{name}",
+		"Collector config snapshot",
+		mcp.WithTemplateDescription("Collector configurations saved with the opentelemetry-collector-config-snapshot tool."),
+		mcp.WithTemplateMIMEType("application/yaml"),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		name, _ := snapshots.NameFromURI(request.Params.URI)
+		snapshot, err := snapshotStore.Get(sessionID(ctx), name)
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      snapshot.URI(),
+				MIMEType: "application/yaml",
+				Text:     snapshot.Config,
+			},
+		}, nil
+	}
+
+	return ResourceTemplate{Template: template, Handler: handler}
+}
+
+// WithSnapshotReferences replaces the snapshot references e.g. snapshot://current-prod in the string arguments of
+// the tools with the snapshot configuration, so any config argument accepts a saved snapshot
+func WithSnapshotReferences(tools []Tool, snapshotStore *snapshots.Store) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			arguments := request.GetArguments()
+			resolved := make(map[string]any, len(arguments))
+			for key, value := range arguments {
+				resolved[key] = value
+				reference, ok := value.(string)
+				if !ok {
+					continue
+				}
+				name, ok := snapshots.NameFromURI(reference)
+				if !ok {
+					continue
+				}
+				snapshot, err := snapshotStore.Get(sessionID(ctx), name)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to resolve %s argument: %v", key, err)), nil
+				}
+				resolved[key] = snapshot.Config
+			}
+			request.Params.Arguments = resolved
+			return handler(ctx, request)
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
+
+// getConfigSnapshotTool returns the tool saving, reading, listing and deleting config snapshots
+func getConfigSnapshotTool(snapshotStore *snapshots.Store) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-snapshot",
+		mcp.WithDescription("Save a collector configuration under a name e.g. current-prod or proposed, then pass snapshot://<name> as the config argument of the other tools instead of the full configuration. Snapshots belong to the client session. Actions: save, get, list and delete."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SnapshotResponse](),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Description("The action: save, get, list or delete."),
+			mcp.Enum("save", "get", "list", "delete"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The snapshot name e.g. current-prod, required by the save, get and delete actions."),
+		),
+		mcp.WithString("config",
+			mcp.Description("The collector configuration YAML to save, required by the save action."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		action, err := request.RequireString("action")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("action argument is required: %v", err)), nil
+		}
+		session := sessionID(ctx)
+		response := SnapshotResponse{Action: action}
+
+		if action == "list" {
+			if response.Snapshots, err = snapshotStore.List(session); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list snapshots: %v", err)), nil
+			}
+			return mcp.NewToolResultStructured(response, fmt.Sprintf("%d snapshots", len(response.Snapshots))), nil
+		}
+
+		name, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+		switch action {
+		case "save":
+			config, err := request.RequireString("config")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+			}
+			if response.Snapshot, err = snapshotStore.Save(session, name, config); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to save snapshot: %v", err)), nil
+			}
+			saved := *response.Snapshot
+			saved.Config = ""
+			response.Snapshot = &saved
+			return mcp.NewToolResultStructured(response, fmt.Sprintf("Saved %s, pass it as the config argument of the other tools.", saved.URI())), nil
+		case "get":
+			if response.Snapshot, err = snapshotStore.Get(session, name); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get snapshot: %v", err)), nil
+			}
+			return mcp.NewToolResultStructured(response, response.Snapshot.Config), nil
+		case "delete":
+			if err := snapshotStore.Delete(session, name); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete snapshot: %v", err)), nil
+			}
+			return mcp.NewToolResultStructured(response, fmt.Sprintf("Deleted snapshot %s", name)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("unknown action %q, use save, get, list or delete", action)), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// sessionID returns the ID of the client session of the request
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil && session.SessionID() != "" {
+		return session.SessionID()
+	}
+	return defaultSession
+}
@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getSpanMetricsGenerateTool returns the spanmetrics connector setup tool
+func getSpanMetricsGenerateTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-spanmetrics-generate",
+		mcp.WithDescription("Configure the spanmetrics connector end-to-end: dimensions with cardinality warnings, histogram buckets, exemplars, the traces and metrics pipelines and the Prometheus, Prometheus remote write or OTLP metrics exporter. The connector and exporter are validated against their schemas."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("dimensions",
+			mcp.Description("Comma-separated span or resource attributes added as metric labels e.g. http.route,deployment.environment=unknown. name=value sets a default value. service.name, span.name, span.kind and status.code are always added."),
+		),
+		mcp.WithString("histogram",
+			mcp.Description("Duration histogram type. It can be explicit and exponential. Defaults to explicit."),
+		),
+		mcp.WithString("buckets",
+			mcp.Description("Comma-separated explicit histogram bucket bounds e.g. 10ms,50ms,100ms,500ms,1s,5s"),
+		),
+		mcp.WithNumber("exponential_max_size",
+			mcp.Description("Maximum number of exponential histogram buckets"),
+		),
+		mcp.WithBoolean("exemplars",
+			mcp.Description("Attach trace exemplars to the metrics"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Metric name namespace e.g. traces.span.metrics"),
+		),
+		mcp.WithString("export",
+			mcp.Description("Metrics exporter. It can be prometheus, prometheusremotewrite and otlp. Defaults to prometheus."),
+		),
+		mcp.WithString("endpoint",
+			mcp.Description("Prometheus scrape endpoint (default 0.0.0.0:8889), remote write URL or OTLP metrics backend endpoint"),
+		),
+		mcp.WithString("traces_exporters",
+			mcp.Description("Comma-separated exporters receiving the spans e.g. otlp/tempo"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version := request.GetString("version", latestCollectorVersion)
+
+		result, err := generate.SpanMetrics(generate.SpanMetricsRequest{
+			Dimensions:         splitList(request.GetString("dimensions", "")),
+			Histogram:          request.GetString("histogram", ""),
+			Buckets:            splitList(request.GetString("buckets", "")),
+			ExponentialMaxSize: request.GetInt("exponential_max_size", 0),
+			Exemplars:          request.GetBool("exemplars", false),
+			Namespace:          request.GetString("namespace", ""),
+			Export:             request.GetString("export", ""),
+			Endpoint:           request.GetString("endpoint", ""),
+			TracesExporters:    splitList(request.GetString("traces_exporters", "")),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate spanmetrics config: %v", err)), nil
+		}
+
+		// The generated connector and metrics exporter have to match the schemas of the collector version
+		metricsExporter := result.Config.Service.Pipelines["metrics/spanmetrics"].Exporters[0]
+		components := []struct {
+			componentType collectorschema.ComponentType
+			id            string
+			config        interface{}
+		}{
+			{collectorschema.ComponentTypeConnector, "spanmetrics", result.Config.Connectors["spanmetrics"]},
+			{collectorschema.ComponentTypeExporter, metricsExporter, result.Config.Exporters[metricsExporter]},
+		}
+		for _, component := range components {
+			name := collectorconfig.ComponentType(component.id)
+			configJSON, err := json.Marshal(component.config)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to marshal %s config: %v", component.id, err)), nil
+			}
+			validationResult, err := schemaManager.ValidateComponentJSON(component.componentType, name, version, configJSON)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to validate %s %s for version %s: %v", component.componentType, name, version, err)), nil
+			}
+			if !validationResult.Valid() {
+				return mcp.NewToolResultError(fmt.Sprintf("%s %s config is not valid for version %s: %v", component.componentType, name, version, validationResult.Errors())), nil
+			}
+		}
+
+		configYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		configYAML, err = stampConfig(request, version, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &GeneratedConfigResponse{Config: string(configYAML), Issues: result.Issues, Warnings: result.Warnings, Verification: verification}
+		return artifactResult(artifactStore, "spanmetrics.yaml", "application/yaml", fmt.Sprintf("%s\nwarnings: %v\nissues: %v\n%s", configYAML, result.Warnings, result.Issues, verification), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// configSkeletonSchema is the schema of the collector configuration structure outside of the components
+var configSkeletonSchema = map[string]interface{}{
+	"properties": map[string]interface{}{
+		"receivers":  map[string]interface{}{"additionalProperties": true},
+		"processors": map[string]interface{}{"additionalProperties": true},
+		"exporters":  map[string]interface{}{"additionalProperties": true},
+		"connectors": map[string]interface{}{"additionalProperties": true},
+		"extensions": map[string]interface{}{"additionalProperties": true},
+		"service": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"extensions": map[string]interface{}{},
+				"telemetry":  map[string]interface{}{"additionalProperties": true},
+				"pipelines": map[string]interface{}{
+					"additionalProperties": map[string]interface{}{
+						"properties": map[string]interface{}{
+							"receivers":  map[string]interface{}{},
+							"processors": map[string]interface{}{},
+							"exporters":  map[string]interface{}{},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// getConfigSpellingTool returns the collector configuration key spell-check tool
+func getConfigSpellingTool(schemaManager *collectorschema.SchemaManager, validationProfiles *validation.Sessions, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-config-spellcheck",
+		mcp.WithDescription("Find misspelled keys in a collector configuration: keys differing from a schema field only by case, underscores vs dashes or a small edit distance e.g. sendBatchSize vs send_batch_size. Returns did you mean suggestions with the exact paths, the schemas accept these keys but the collector rejects them at startup. Configured components that are deprecated or unmaintained and slated for removal are reported as warnings."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SpellingResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		withValidationProfile(),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		profile, err := validationProfiles.Resolve(sessionID(ctx), request.GetString("profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var config map[string]interface{}
+		if err := yaml.Unmarshal([]byte(configYAML), &config); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse collector config YAML: %v", err)), nil
+		}
+
+		response := SpellingResponse{Profile: profile.Name, Suggestions: []collectorschema.KeySuggestion{}}
+		for _, suggestion := range collectorschema.SuggestKeys(configSkeletonSchema, config) {
+			suggestion.Path = strings.ReplaceAll(suggestion.Path, ".", "::")
+			response.Suggestions = append(response.Suggestions, suggestion)
+		}
+		for _, section := range collectorconfig.ComponentSections {
+			components, _ := config[section].(map[string]interface{})
+			componentType := collectorschema.ComponentTypeFromSection(section)
+			ids := make([]string, 0, len(components))
+			for id := range components {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			for _, id := range ids {
+				if warning := componentStatusWarning(schemaManager, componentType, collectorconfig.ComponentType(id), version); warning != "" {
+					response.Warnings = append(response.Warnings, fmt.Sprintf("%s::%s relies on a component %s", section, id, warning))
+				}
+				schema, err := schemaManager.GetComponentSchema(componentType, collectorconfig.ComponentType(id), version)
+				if err != nil {
+					response.Warnings = append(response.Warnings, fmt.Sprintf("%s::%s was not checked: %v", section, id, err))
+					continue
+				}
+				for _, suggestion := range collectorschema.SuggestKeys(schema.Schema, components[id]) {
+					suggestion.Path = section + "::" + id + "::" + suggestion.Path
+					response.Suggestions = append(response.Suggestions, suggestion)
+				}
+			}
+		}
+		// Misspelled keys are warnings, the profile decides whether they fail the check
+		misspelled := len(response.Suggestions)
+		response.Valid = profile.Valid(0, misspelled)
+		response.Suggestions, response.Omitted = validation.Limit(profile, response.Suggestions)
+
+		lines := make([]string, 0, len(response.Suggestions))
+		for _, suggestion := range response.Suggestions {
+			lines = append(lines, "- "+suggestion.String())
+		}
+		text := fmt.Sprintf("%d misspelled keys\n%s\nwarnings: %v", misspelled, strings.Join(lines, "\n"), response.Warnings)
+		return mcp.NewToolResultStructured(response, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
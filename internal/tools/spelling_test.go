@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// TestMisspelledKeysAreWarnings checks a misspelled key is a suggestion that fails only the profiles treating the
+// warnings as errors
+func TestMisspelledKeysAreWarnings(t *testing.T) {
+	schemaManager := collectorschema.NewSchemaManager()
+	validationProfiles := validation.NewSessions(storage.NewMemory())
+
+	t.Run("component-schema-validation", func(t *testing.T) {
+		tool := getCollectorSchemaValidationTool(schemaManager, validationProfiles, testCollectorVersion)
+		arguments := map[string]any{"kind": "processor", "name": "batch", "version": testCollectorVersion, "config": `{"sendBatchSize": 100}`}
+		for profile, valid := range map[string]bool{validation.ProfileAgent: true, validation.ProfileEditor: true, validation.ProfileCI: false} {
+			arguments["profile"] = profile
+			result := callTool(t, tool, arguments)
+			require.False(t, result.IsError, resultText(result))
+			response, ok := result.StructuredContent.(ValidationResponse)
+			require.True(t, ok)
+			assert.Equal(t, valid, response.Valid, profile)
+			require.Len(t, response.Suggestions, 1, profile)
+			assert.Equal(t, "send_batch_size", response.Suggestions[0].Suggestion)
+			assert.Empty(t, response.Errors, profile)
+		}
+	})
+
+	t.Run("config-spellcheck", func(t *testing.T) {
+		tool := getConfigSpellingTool(schemaManager, validationProfiles, testCollectorVersion)
+		result := callTool(t, tool, map[string]any{"config": "processors:\n  batch:\n    sendBatchSize: 100\n", "version": testCollectorVersion})
+		require.False(t, result.IsError, resultText(result))
+		response, ok := result.StructuredContent.(SpellingResponse)
+		require.True(t, ok)
+		assert.True(t, response.Valid)
+		require.Len(t, response.Suggestions, 1)
+		assert.Equal(t, "processors::batch::sendBatchSize", response.Suggestions[0].Path)
+		assert.Contains(t, resultText(result), `did you mean "send_batch_size"?`)
+	})
+}
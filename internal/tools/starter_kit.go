@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getStarterKitTool returns the getting started tool generating a complete starter kit from a description of the
+// environment
+func getStarterKitTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-getting-started",
+		mcp.WithDescription("Generate a complete OpenTelemetry starter kit in one call from a free-text description of the environment e.g. \"EKS cluster, Java services, want traces to Tempo and metrics to Mimir\": the recognized platform, languages and backends, the suggested components with the reason for each, the verified collector configuration, the Kubernetes manifests deploying it and the SDK environment variables per language. Use it as the first step of a new setup instead of planning the component, pipeline, deployment and SDK steps separately."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[StarterKitResponse](),
+		mcp.WithString("description",
+			mcp.Required(),
+			mcp.Description("Free-text description of the environment: the platform e.g. EKS, GKE, Kubernetes, Docker or VMs, the service languages and the backends per signal e.g. traces to Tempo, metrics to Mimir, logs to Loki"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Kubernetes namespace of the collector. Defaults to observability."),
+		),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		description, err := request.RequireString("description")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("description argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		matrix, err := schemaManager.GetCompatibilityMatrix()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := generate.StarterKit(generate.StarterKitRequest{
+			Description:  description,
+			Namespace:    request.GetString("namespace", ""),
+			Version:      version,
+			SDKProtocols: sdkDefaultProtocols(matrix),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate starter kit: %v", err)), nil
+		}
+
+		configYAML, err := result.Config.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		configYAML, err = stampConfig(request, version, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := &StarterKitResponse{
+			Environment:  result.Environment,
+			Components:   result.Components,
+			Config:       string(configYAML),
+			Files:        result.Files,
+			SDKs:         result.SDKs,
+			Issues:       result.Issues,
+			Warnings:     result.Warnings,
+			Verification: verification,
+		}
+
+		var text strings.Builder
+		fmt.Fprintf(&text, "environment: %s %s, languages: %v\ncomponents:\n", result.Environment.Platform, result.Environment.Cloud, result.Environment.Languages)
+		for _, component := range result.Components {
+			fmt.Fprintf(&text, "- %s %s: %s\n", component.Kind, component.Name, component.Reason)
+		}
+		fmt.Fprintf(&text, "# collector configuration\n%s\n", configYAML)
+		for _, file := range result.Files {
+			fmt.Fprintf(&text, "# %s\n%s\n", file.Path, file.Content)
+		}
+		for _, sdk := range result.SDKs {
+			fmt.Fprintf(&text, "# %s SDK\n", sdk.Language)
+			for _, env := range sdk.Env {
+				fmt.Fprintf(&text, "%s=%s\n", env.Name, env.Value)
+			}
+			for _, note := range sdk.Notes {
+				fmt.Fprintf(&text, "- %s\n", note)
+			}
+		}
+		fmt.Fprintf(&text, "issues: %v\nwarnings: %v\n%s", result.Issues, result.Warnings, verification)
+		return artifactResult(artifactStore, "starter-kit.yaml", "application/yaml", text.String(), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// sdkDefaultProtocols returns the default OTLP protocol of the latest SDK release of each language in the matrix
+func sdkDefaultProtocols(matrix *collectorschema.CompatibilityMatrix) map[string]string {
+	latest := make(map[string]collectorschema.SDKCompatibility)
+	for _, sdk := range matrix.SDKs {
+		if current, ok := latest[sdk.Language]; !ok || collectorschema.CompareVersions(sdk.Since, current.Since) > 0 {
+			latest[sdk.Language] = sdk
+		}
+	}
+	protocols := make(map[string]string, len(latest))
+	for language, sdk := range latest {
+		protocols[language] = sdk.DefaultProtocol
+	}
+	return protocols
+}
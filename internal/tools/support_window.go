@@ -0,0 +1,192 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// SupportWindowReport describes how far a collector version is behind the latest release
+type SupportWindowReport struct {
+	Version       string `json:"version"`
+	LatestVersion string `json:"latestVersion"`
+	Status        string `json:"status"`
+	// MinorVersionsBehind is the number of minor versions between the version and the latest version, every regular
+	// release is a minor version
+	MinorVersionsBehind int `json:"minorVersionsBehind"`
+	// ReleaseDate is the release date of the version or of its minor version, empty for versions older than the oldest
+	// embedded release date
+	ReleaseDate       string `json:"releaseDate,omitempty"`
+	LatestReleaseDate string `json:"latestReleaseDate"`
+	// DaysBehind is the number of days between the release of the version and the latest release
+	DaysBehind int `json:"daysBehind"`
+	// DaysBehindIsMinimum is true if the version is older than the oldest embedded release date and DaysBehind counts
+	// from that release
+	DaysBehindIsMinimum bool     `json:"daysBehindIsMinimum,omitempty"`
+	BreakingChanges     []string `json:"breakingChanges,omitempty"`
+	// UnknownChangelogs are versions between the current and latest version without an embedded changelog
+	UnknownChangelogs []string `json:"unknownChangelogs,omitempty"`
+	Advisories        []string `json:"advisories,omitempty"`
+	UpgradePath       []string `json:"upgradePath"`
+	Recommendation    string   `json:"recommendation"`
+	SupportPolicy     string   `json:"supportPolicy"`
+}
+
+// getSupportWindowTool returns the end-of-life and support window advisor tool
+func getSupportWindowTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-support-window",
+		mcp.WithDescription("Explain whether an OpenTelemetry collector version is still reasonable to run: how many minor versions and days it is behind the latest release, the breaking changes in between and the recommended upgrade path"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SupportWindowReport](),
+		mcp.WithString("version",
+			mcp.Required(),
+			mcp.Description("The running OpenTelemetry Collector version e.g. 0.128.0"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		version, err := request.RequireString("version")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("version argument is required: %v", err)), nil
+		}
+		version = strings.TrimPrefix(version, "v")
+		if err := collectorschema.ValidateVersion(version); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		policy, err := schemaManager.GetReleasePolicy()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		versions, err := schemaManager.GetAllVersions()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get all supported versions by this tool: %v", err)), nil
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			return collectorschema.CompareVersions(versions[i], versions[j]) < 0
+		})
+
+		report := SupportWindowReport{
+			Version:       version,
+			LatestVersion: latestCollectorVersion,
+			SupportPolicy: policy.Support,
+		}
+		if collectorschema.CompareVersions(version, latestCollectorVersion) > 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("version %s is newer than the latest known version %s", version, latestCollectorVersion)), nil
+		}
+
+		report.MinorVersionsBehind = minorVersion(latestCollectorVersion) - minorVersion(version)
+		latestDate, ok := policy.ReleaseDate(latestCollectorVersion)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("the release date of the latest version %s is not known", latestCollectorVersion)), nil
+		}
+		report.LatestReleaseDate = latestDate.Format(time.DateOnly)
+		releaseDate, ok := policy.ReleaseDate(version)
+		if !ok {
+			// Patch releases are dated like their minor version
+			releaseDate, ok = policy.ReleaseDate(fmt.Sprintf("0.%d.0", minorVersion(version)))
+		}
+		if ok {
+			report.ReleaseDate = releaseDate.Format(time.DateOnly)
+		} else {
+			oldest, found := policy.OldestRelease()
+			if !found || collectorschema.CompareVersions(version, oldest.Version) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("the release date of version %s is not known", version)), nil
+			}
+			releaseDate, _ = policy.ReleaseDate(oldest.Version)
+			report.DaysBehindIsMinimum = true
+		}
+		report.DaysBehind = int(latestDate.Sub(releaseDate) / (24 * time.Hour))
+
+		// Breaking changes of every release after the running version
+		var breakingVersions []string
+		for minor := minorVersion(version) + 1; minor <= minorVersion(latestCollectorVersion); minor++ {
+			release := embeddedRelease(versions, minor)
+			if release == "" {
+				report.UnknownChangelogs = append(report.UnknownChangelogs, fmt.Sprintf("0.%d.x", minor))
+				continue
+			}
+			changes, err := schemaManager.GetBreakingChanges(release)
+			if err != nil {
+				report.UnknownChangelogs = append(report.UnknownChangelogs, release)
+				continue
+			}
+			for _, change := range changes {
+				report.BreakingChanges = append(report.BreakingChanges, fmt.Sprintf("%s: %s", release, change))
+			}
+			if len(changes) > 0 {
+				breakingVersions = append(breakingVersions, release)
+			}
+		}
+
+		advisories, err := schemaManager.GetAdvisories(version)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get advisories for %s: %v", version, err)), nil
+		}
+		for _, advisory := range advisories {
+			fixed := "not fixed yet"
+			if advisory.Fixed != "" {
+				fixed = "fixed in " + advisory.Fixed
+			}
+			report.Advisories = append(report.Advisories, fmt.Sprintf("%s (%s, %s): %s", advisory.ID, advisory.Severity, fixed, advisory.Summary))
+		}
+
+		// Upgrading through the releases with breaking changes allows fixing the configuration one step at a time
+		report.UpgradePath = breakingVersions
+		if len(breakingVersions) == 0 || breakingVersions[len(breakingVersions)-1] != latestCollectorVersion {
+			report.UpgradePath = append(report.UpgradePath, latestCollectorVersion)
+		}
+
+		// Only the latest release receives fixes, the previous releases are classified by the days since the latest release
+		switch {
+		case report.MinorVersionsBehind == 0:
+			report.Status = "latest"
+			report.Recommendation = "the version is the latest release and receives fixes"
+			report.UpgradePath = []string{}
+		case report.DaysBehind <= policy.CurrentDays:
+			report.Status = "current"
+			report.Recommendation = "only the latest release receives fixes, the version is reasonable to run until the next regular maintenance upgrade"
+		case report.DaysBehind <= policy.OutdatedDays:
+			report.Status = "outdated"
+			report.Recommendation = "only the latest release receives fixes, plan an upgrade and validate the configuration against the latest schemas"
+		default:
+			report.Status = "unsupported"
+			report.Recommendation = "the version is far behind and only the latest release receives fixes, upgrade soon and step through the releases in the upgrade path to review the breaking changes"
+		}
+		if len(report.Advisories) > 0 {
+			report.Recommendation += fmt.Sprintf(", %d security advisories affect this version so upgrade urgently", len(report.Advisories))
+		}
+
+		return mcp.NewToolResultJSON(report)
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// minorVersion returns the minor version number e.g. 128 for 0.128.0
+func minorVersion(version string) int {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return 0
+	}
+	minor, _ := strconv.Atoi(parts[1])
+	return minor
+}
+
+// embeddedRelease returns the latest embedded version with the minor version or an empty string
+func embeddedRelease(versions []string, minor int) string {
+	release := ""
+	for _, version := range versions {
+		if minorVersion(version) == minor {
+			release = version
+		}
+	}
+	return release
+}
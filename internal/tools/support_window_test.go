@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+func TestSupportWindowTool(t *testing.T) {
+	tool := getSupportWindowTool(collectorschema.NewSchemaManager(), testCollectorVersion)
+
+	supportWindow := func(version string) SupportWindowReport {
+		t.Helper()
+		result := callTool(t, tool, map[string]any{"version": version})
+		require.False(t, result.IsError, resultText(result))
+		var report SupportWindowReport
+		require.NoError(t, json.Unmarshal([]byte(resultText(result)), &report))
+		return report
+	}
+
+	report := supportWindow("v" + testCollectorVersion)
+	assert.Equal(t, "latest", report.Status)
+	assert.Equal(t, 0, report.MinorVersionsBehind)
+	assert.NotNil(t, report.UpgradePath)
+	assert.Empty(t, report.UpgradePath)
+
+	report = supportWindow("0.135.0")
+	assert.Equal(t, "outdated", report.Status)
+	assert.Equal(t, 4, report.MinorVersionsBehind)
+	assert.Equal(t, "2025-09-09", report.ReleaseDate)
+	assert.Equal(t, "2025-11-04", report.LatestReleaseDate)
+	assert.Equal(t, 56, report.DaysBehind)
+	assert.False(t, report.DaysBehindIsMinimum)
+	assert.Contains(t, report.Recommendation, "only the latest release receives fixes")
+	assert.Equal(t, testCollectorVersion, report.UpgradePath[len(report.UpgradePath)-1])
+	assert.NotEmpty(t, report.SupportPolicy)
+
+	// Versions older than the embedded release dates are at least as far behind as the oldest release
+	report = supportWindow("0.128.0")
+	assert.Equal(t, 11, report.MinorVersionsBehind)
+	assert.Empty(t, report.ReleaseDate)
+	assert.Equal(t, 56, report.DaysBehind)
+	assert.True(t, report.DaysBehindIsMinimum)
+
+	result := callTool(t, tool, map[string]any{"version": "0.140.0"})
+	assert.True(t, result.IsError)
+	result = callTool(t, tool, map[string]any{"version": "latest"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(result), "invalid version")
+}
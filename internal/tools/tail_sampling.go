@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getTailSamplingGenerateTool returns the tail_sampling policy builder tool
+func getTailSamplingGenerateTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-tail-sampling-generate",
+		mcp.WithDescription("Build tail_sampling processor policies from sampling constraints e.g. keep all errors, 10% of the rest, always keep the checkout service and traces slower than 2s. The config is validated against the processor schema and memory and num_traces sizing warnings are returned."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[GeneratedConfigResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
+		),
+		mcp.WithString("constraints",
+			mcp.Required(),
+			mcp.Description("Sampling constraints JSON e.g. {\"keep_errors\": true, \"latency_threshold_ms\": 2000, \"keep_services\": [\"checkout\"], \"sampling_percentage\": 10}. Supported keys: keep_errors, latency_threshold_ms, keep_services, keep_attributes, drop_services, sampling_percentage, decision_wait, traces_per_second, spans_per_trace."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		constraintsJSON, err := request.RequireString("constraints")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("constraints argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+
+		var constraints generate.TailSamplingConstraints
+		if err := json.Unmarshal([]byte(constraintsJSON), &constraints); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse constraints JSON: %v", err)), nil
+		}
+		result, err := generate.TailSampling(constraints)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate tail_sampling config: %v", err)), nil
+		}
+
+		configJSON, err := json.Marshal(result.Config)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal tail_sampling config: %v", err)), nil
+		}
+		validationResult, err := schemaManager.ValidateComponentJSON(collectorschema.ComponentTypeProcessor, "tail_sampling", version, configJSON)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to validate tail_sampling config for version %s: %v", version, err)), nil
+		}
+		if !validationResult.Valid() {
+			return mcp.NewToolResultError(fmt.Sprintf("tail_sampling config is not valid for version %s: %v", version, validationResult.Errors())), nil
+		}
+
+		configYAML, err := yaml.Marshal(map[string]interface{}{
+			"processors": map[string]interface{}{"tail_sampling": result.Config},
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal tail_sampling config: %v", err)), nil
+		}
+		configYAML, err = stampConfig(request, version, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		verification, err := verifyGeneratedConfig(schemaManager, version, nil, configYAML)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		response := GeneratedConfigResponse{Config: string(configYAML), Warnings: result.Warnings, Verification: verification}
+		return mcp.NewToolResultStructured(response, fmt.Sprintf("%s\nwarnings: %v\n%s", configYAML, result.Warnings, verification)), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
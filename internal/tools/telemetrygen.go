@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/generate"
+)
+
+// getTelemetrygenCommandsTool returns the tool generating telemetrygen and otelgen smoke test invocations
+func getTelemetrygenCommandsTool() Tool {
+	tool := mcp.NewTool("opentelemetry-collector-telemetrygen-commands",
+		mcp.WithDescription("Generate telemetrygen or otelgen commands sending synthetic traces, metrics and logs to the OTLP receivers of a collector configuration to smoke-test its pipelines. The receiver TLS and authentication settings are translated to client flags."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SmokeTestResponse](),
+		mcp.WithString("config",
+			mcp.Required(),
+			mcp.Description("The OpenTelemetry Collector configuration YAML"),
+		),
+		mcp.WithString("generator",
+			mcp.Description("CLI to generate the commands for. It can be telemetrygen and otelgen. Defaults to telemetrygen."),
+		),
+		mcp.WithString("host",
+			mcp.Description("Host the commands connect to when a receiver listens on all interfaces e.g. the collector service name. Defaults to localhost."),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of traces, metrics or logs sent per command. Defaults to 10."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configYAML, err := request.RequireString("config")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
+		}
+
+		config, err := collectorconfig.Parse([]byte(configYAML))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := generate.SmokeTest(config, generate.SmokeTestRequest{
+			Generator: request.GetString("generator", ""),
+			Host:      request.GetString("host", ""),
+			Count:     request.GetInt("count", 0),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to generate commands: %v", err)), nil
+		}
+
+		var text strings.Builder
+		for _, command := range result.Commands {
+			text.WriteString(fmt.Sprintf("# %s %s %s\n%s\n", command.Receiver, command.Protocol, command.Signal, command.Command))
+		}
+		if len(result.Warnings) > 0 {
+			text.WriteString(fmt.Sprintf("\nwarnings: %v", result.Warnings))
+		}
+		response := SmokeTestResponse{Commands: result.Commands, Warnings: result.Warnings}
+		return mcp.NewToolResultStructured(response, text.String()), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
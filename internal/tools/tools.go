@@ -2,10 +2,20 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/github"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/policy"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/searchlog"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
 )
 
 // Tool represents an MCP tool with its handler
@@ -14,24 +24,83 @@ type Tool struct {
 	Handler func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 }
 
-// GetAllTools returns a list of all available MCP tools
-func GetAllTools() ([]Tool, error) {
-	schemaManager := collectorschema.NewSchemaManager()
+// GetAllTools returns a list of all available MCP tools, large results are stored in the artifact store, the session
+// preferences in the storage and the documentation searches are recorded in the search log unless it is nil. The lint
+// checks of the config check tool enforce the rules of the policy packs.
+func GetAllTools(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, state storage.Store, searchLog *searchlog.Store, policyPacks []policy.Pack) ([]Tool, error) {
 	latestCollectorVersion, err := schemaManager.GetLatestVersion()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest collector version: %v", err)
 	}
 
+	// The validation profile is selected per call or set for the session with the validation profile tool
+	validationProfiles := validation.NewSessions(state)
+
 	tools := []Tool{
 		getCollectorVersionsTool(schemaManager),
 		getCollectorComponentsTool(schemaManager, latestCollectorVersion),
-		getCollectorReadmeTool(schemaManager, latestCollectorVersion),
-		getCollectorSchemaGetTool(schemaManager, latestCollectorVersion),
-		getCollectorSchemaValidationTool(schemaManager, latestCollectorVersion),
+		getCollectorReadmeTool(schemaManager, artifactStore, latestCollectorVersion),
+		getReadmeAssetsTool(schemaManager, latestCollectorVersion),
+		getCollectorSchemaGetTool(schemaManager, artifactStore, latestCollectorVersion),
+		getCollectorSchemaSummaryTool(schemaManager, latestCollectorVersion),
+		getCollectorSchemaValidationTool(schemaManager, validationProfiles, latestCollectorVersion),
+		getConfigValidationTool(schemaManager, validationProfiles, latestCollectorVersion),
+		getConfigSpellingTool(schemaManager, validationProfiles, latestCollectorVersion),
+		getConfigVersionsValidationTool(schemaManager, validationProfiles),
+		getConfigCheckTool(schemaManager, artifactStore, validationProfiles, policyPacks, latestCollectorVersion),
+		getValidationProfileTool(validationProfiles),
 		getCollectorComponentDeprecatedTool(schemaManager, latestCollectorVersion),
-		getCollectorChangelogTool(schemaManager, latestCollectorVersion),
-		getCollectorDocumentationRAG(schemaManager, latestCollectorVersion),
+		getDeprecationTimelineTool(schemaManager, latestCollectorVersion),
+		getComponentAvailabilityTool(schemaManager),
+		getSchemaStatsTool(schemaManager),
+		getComponentModuleTool(schemaManager, latestCollectorVersion),
+		getComponentOwnersTool(schemaManager, latestCollectorVersion),
+		getLicenseReportTool(schemaManager, latestCollectorVersion),
+		getCollectorChangelogTool(schemaManager, artifactStore, latestCollectorVersion),
+		getCoreDocsTool(artifactStore),
+		getCommonSettingsTool(schemaManager, latestCollectorVersion),
+		getConnectorConversionsTool(schemaManager, latestCollectorVersion),
+		getCollectorDocumentationRAG(schemaManager, searchLog, latestCollectorVersion),
+		getMetricsProcessorSimulationTool(),
+		getReceiverCreatorGenerateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getReceiverCreatorRuleValidationTool(),
+		getRoutingGenerateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getRoutingValidationTool(),
+		getConfigOTTLValidationTool(latestCollectorVersion),
+		getAttributesTransformMigrationTool(schemaManager, artifactStore, latestCollectorVersion),
+		getDeprecatedComponentsMigrationTool(schemaManager, artifactStore, latestCollectorVersion),
+		getLoadBalancingGenerateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getTailSamplingGenerateTool(schemaManager, latestCollectorVersion),
+		getGoldenTestGenerateTool(artifactStore),
+		getTelemetrygenCommandsTool(),
+		getSpanMetricsGenerateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getCountGenerateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getKafkaGenerateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getFailoverGenerateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getCloudCredentialsTool(schemaManager, latestCollectorVersion),
+		getFailureModesTool(latestCollectorVersion),
+		getConfigComplexityTool(),
+		getConfigConflictsTool(),
+		getConfigTuningTool(),
+		getConfigEndpointsTool(),
+		getConfigWhatIfRemoveTool(),
+		getConfigExplainTool(schemaManager, latestCollectorVersion),
+		getConfigGraphTool(schemaManager, latestCollectorVersion),
+		getConfigAnnotateTool(schemaManager, artifactStore, latestCollectorVersion),
+		getConfigMinimizeTool(schemaManager, artifactStore, latestCollectorVersion),
+		getConfigExpandTool(schemaManager, artifactStore, latestCollectorVersion),
+		getConfigHardenTool(schemaManager, artifactStore, latestCollectorVersion),
+		getMemorySettingsTool(schemaManager, artifactStore, latestCollectorVersion),
+		getSupportWindowTool(schemaManager, latestCollectorVersion),
+		getSDKCompatibilityTool(schemaManager, latestCollectorVersion),
+		getStarterKitTool(schemaManager, artifactStore, latestCollectorVersion),
+		getEditorSchemaTool(schemaManager, artifactStore, latestCollectorVersion),
+		getSampleConfigTool(schemaManager, artifactStore, latestCollectorVersion),
+		getConfigSchemaTool(schemaManager, artifactStore, latestCollectorVersion),
+		getSchemaBundleTool(schemaManager, artifactStore, latestCollectorVersion),
 	}
+	// The provenance tool regenerates the configurations with the generate tools
+	tools = append(tools, getConfigProvenanceTool(tools, latestCollectorVersion))
 
 	return tools, nil
 }
@@ -42,6 +111,7 @@ func getCollectorVersionsTool(schemaManager *collectorschema.SchemaManager) Tool
 		mcp.WithDescription("Get all supported OpenTelemetry collector versions by this tool"),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[VersionsResponse](),
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -49,7 +119,7 @@ func getCollectorVersionsTool(schemaManager *collectorschema.SchemaManager) Tool
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to get all supported versions by this tool: %v", err)), nil
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("versions: %s", versions)), nil
+		return mcp.NewToolResultStructured(VersionsResponse{Versions: versions}, fmt.Sprintf("versions: %s", versions)), nil
 	}
 
 	return Tool{Tool: tool, Handler: handler}
@@ -58,112 +128,204 @@ func getCollectorVersionsTool(schemaManager *collectorschema.SchemaManager) Tool
 // getCollectorComponentsTool returns the collector components tool
 func getCollectorComponentsTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
 	tool := mcp.NewTool("opentelemetry-collector-components",
-		mcp.WithDescription("Get all OpenTelemetry collector components"),
+		mcp.WithDescription("Get all OpenTelemetry collector components of a kind, deprecated and unmaintained components are reported as warnings"),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ComponentsResponse](),
 		mcp.WithString("version",
 			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 		),
-		mcp.WithString("kind",
-			mcp.Required(),
-			mcp.Description("Collector component kind. It can be receiver, exporter, processor, connector and extension."),
-		),
+		withComponentKind(mcp.Required()),
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		componentKind, err := request.RequireString("kind")
+		componentType, err := requireComponentType(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("kind argument is required: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 		version := request.GetString("version", latestCollectorVersion)
 
-		components, err := schemaManager.GetComponentNames(collectorschema.ComponentType(componentKind), version)
+		components, err := schemaManager.GetComponentNames(componentType, version)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to get components for %s: %v", componentKind, err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get components for %s: %v", componentType, err)), nil
+		}
+		response := ComponentsResponse{Kind: string(componentType), Version: version, Components: components}
+		text := fmt.Sprintf("%s", components)
+		for _, component := range components {
+			if warning := componentStatusWarning(schemaManager, componentType, component, version); warning != "" {
+				if response.Warnings == nil {
+					response.Warnings = make(map[string]string)
+				}
+				response.Warnings[component] = warning
+				text += fmt.Sprintf("\nwarning: %s is %s", component, warning)
+			}
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("%s", components)), nil
+		return mcp.NewToolResultStructured(response, text), nil
 	}
 
 	return Tool{Tool: tool, Handler: handler}
 }
 
 // getCollectorReadmeTool returns the collector readme tool
-func getCollectorReadmeTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+func getCollectorReadmeTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
 	tool := mcp.NewTool("opentelemetry-collector-readme",
-		mcp.WithDescription("Explain OpenTelemetry collector processor, receiver, exporter, extension functionality and use-cases"),
+		mcp.WithDescription("Explain OpenTelemetry collector receiver, processor, exporter, connector, extension functionality and use-cases"),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ReadmeResponse](),
 		mcp.WithString("version",
 			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 		),
-		mcp.WithString("kind",
-			mcp.Required(),
-			mcp.Description("Collector component kind. It can be receiver, exporter, processor, connector and extension."),
-		),
+		withComponentKind(mcp.Required()),
 		mcp.WithString("name",
 			mcp.Required(),
 			mcp.Description("Collector component name e.g. otlp"),
 		),
+		mcp.WithString("locale",
+			mcp.Description("Locale of the returned README e.g. de or pt-BR. Defaults to English."),
+		),
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		componentKind, err := request.RequireString("kind")
+		componentType, err := requireComponentType(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("kind argument is required: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 		componentName, err := request.RequireString("name")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
 		}
 		version := request.GetString("version", latestCollectorVersion)
+		locale := request.GetString("locale", "")
 
-		readme, err := schemaManager.GetComponentReadme(collectorschema.ComponentType(componentKind), componentName, version)
+		readme, err := schemaManager.GetComponentReadmeLocalized(ctx, componentType, componentName, version, locale)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to get readme for %s %s: %v", componentKind, componentName, err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get readme for %s %s: %v", componentType, componentName, err)), nil
 		}
-		return mcp.NewToolResultText(readme), nil
+		citation := github.ComponentCitation(string(componentType), componentName, version)
+		response := &ReadmeResponse{Kind: string(componentType), Name: componentName, Version: version, Locale: locale, Readme: readme, Citation: &citation}
+		return artifactResult(artifactStore, fmt.Sprintf("%s_%s_README.md", componentType, componentName), "text/markdown", readme, response), nil
 	}
 
 	return Tool{Tool: tool, Handler: handler}
 }
 
 // getCollectorChangelogTool returns the collector changelog tool
-func getCollectorChangelogTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+func getCollectorChangelogTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
 	tool := mcp.NewTool("opentelemetry-collector-changelog",
-		mcp.WithDescription("Returns OpenTelemetry collector changelog"),
+		mcp.WithDescription("Returns OpenTelemetry collector changelog. The core (confmap, service telemetry, OTLP components) and contrib repositories publish separate changelogs, by default both are merged so breaking changes in core are not missed during upgrades."),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ChangelogResponse](),
 		mcp.WithString("version",
 			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 		),
+		mcp.WithString("source",
+			mcp.Description("The changelog of the core repository, the contrib repository or both merged (default all)"),
+			mcp.Enum(collectorschema.ChangelogSourceNames()...),
+		),
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		version := request.GetString("version", latestCollectorVersion)
+		source, err := collectorschema.ParseChangelogSource(request.GetString("source", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-		readme, err := schemaManager.GetChangelog(version)
+		readme, err := schemaManager.GetSourceChangelog(version, source)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to get changelog for %s: %v", version, err)), nil
 		}
-		return mcp.NewToolResultText(readme), nil
+		response := &ChangelogResponse{Version: version, Source: string(source), Changelog: readme}
+		fileName := fmt.Sprintf("CHANGELOG-%s.md", version)
+		if source != collectorschema.ChangelogSourceAll {
+			fileName = fmt.Sprintf("CHANGELOG-%s-%s.md", source, version)
+		}
+		return artifactResult(artifactStore, fileName, "text/markdown", readme, response), nil
 	}
 
 	return Tool{Tool: tool, Handler: handler}
 }
 
 // getCollectorSchemaGetTool returns the collector schema get tool
-func getCollectorSchemaGetTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+func getCollectorSchemaGetTool(schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, latestCollectorVersion string) Tool {
 	tool := mcp.NewTool("opentelemetry-collector-component-schema",
 		mcp.WithDescription("Explain OpenTelemetry collector receiver, exporter, processor, connector and extension configuration schema"),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[SchemaResponse](),
 		mcp.WithString("version",
 			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 		),
-		mcp.WithString("kind",
+		withComponentKind(mcp.Required()),
+		mcp.WithString("name",
 			mcp.Required(),
-			mcp.Description("Collector component kind. It can be receiver, exporter, processor, connector and extension."),
+			mcp.Description("Collector component name e.g. otlp"),
+		),
+		mcp.WithString("format",
+			mcp.Description("The format of the schema: json is the JSON schema and cue a CUE definition of the component configuration for validating configurations with cue vet. Defaults to json."),
+			mcp.Enum(collectorschema.SchemaFormats...),
+		),
+		mcp.WithString("locale",
+			mcp.Description("Locale of the field descriptions of the json schema e.g. de or pt-BR, fields without a translation bundled with the schemas keep their English description. Defaults to English."),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		componentType, err := requireComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		componentName, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("name argument is required: %v", err)), nil
+		}
+		version := request.GetString("version", latestCollectorVersion)
+		format := request.GetString("format", collectorschema.SchemaFormatJSON)
+
+		switch format {
+		case collectorschema.SchemaFormatJSON:
+		case collectorschema.SchemaFormatCUE:
+			cue, err := schemaManager.GetComponentCUE(componentType, componentName, version)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get schema for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+			}
+			response := &SchemaResponse{Kind: string(componentType), Name: componentName, Version: version, CUE: cue}
+			return artifactResult(artifactStore, fmt.Sprintf("%s_%s_%s.cue", componentType, componentName, version), "text/x-cue", cue, response), nil
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported format %q, must be json or cue", format)), nil
+		}
+
+		schema, err := schemaManager.GetComponentSchemaLocalized(componentType, componentName, version, request.GetString("locale", ""))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get schema for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+		}
+		schemaJSON, err := json.MarshalIndent(schema.Schema, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal schema for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+		}
+		response := &SchemaResponse{Kind: string(componentType), Name: componentName, Version: version, Locale: schema.Locale}
+		if err := json.Unmarshal(schemaJSON, &response.Schema); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse schema for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+		}
+		return artifactResult(artifactStore, fmt.Sprintf("%s_%s_%s.json", componentType, componentName, version), "application/json", string(schemaJSON), response), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// getCollectorSchemaSummaryTool returns the collector component summary tool
+func getCollectorSchemaSummaryTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-component-summary",
+		mcp.WithDescription("Summarize an OpenTelemetry collector component configuration: a one-paragraph description, the top 10 fields with their types, the required fields and the defaults. Use it before opentelemetry-collector-component-schema, the full schema is only needed for the nested settings."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[collectorschema.ComponentSummary](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 		),
+		withComponentKind(mcp.Required()),
 		mcp.WithString("name",
 			mcp.Required(),
 			mcp.Description("Collector component name e.g. otlp"),
@@ -171,9 +333,9 @@ func getCollectorSchemaGetTool(schemaManager *collectorschema.SchemaManager, lat
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		componentKind, err := request.RequireString("kind")
+		componentType, err := requireComponentType(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("kind argument is required: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 		componentName, err := request.RequireString("name")
 		if err != nil {
@@ -181,29 +343,27 @@ func getCollectorSchemaGetTool(schemaManager *collectorschema.SchemaManager, lat
 		}
 		version := request.GetString("version", latestCollectorVersion)
 
-		schemaJSON, err := schemaManager.GetComponentSchemaJSON(collectorschema.ComponentType(componentKind), componentName, version)
+		summary, err := schemaManager.GetComponentSummary(componentType, componentName, version)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to get schema for %s/%s@%s: %v", componentKind, componentName, version, err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get summary for %s/%s@%s: %v", componentType, componentName, version, err)), nil
 		}
-		return mcp.NewToolResultText(string(schemaJSON)), nil
+		return mcp.NewToolResultJSON(summary)
 	}
 
 	return Tool{Tool: tool, Handler: handler}
 }
 
 // getCollectorSchemaValidationTool returns the collector schema validation tool
-func getCollectorSchemaValidationTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+func getCollectorSchemaValidationTool(schemaManager *collectorschema.SchemaManager, validationProfiles *validation.Sessions, latestCollectorVersion string) Tool {
 	tool := mcp.NewTool("opentelemetry-collector-component-schema-validation",
-		mcp.WithDescription("Validate OpenTelemetry collector processor, receiver, exporter, extension configuration JSON"),
+		mcp.WithDescription("Validate OpenTelemetry collector receiver, processor, exporter, connector, extension configuration JSON. Keys differing from a schema field by case, separators or a typo are reported with did you mean suggestions. Deprecated and unmaintained components are reported as warnings."),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ValidationResponse](),
 		mcp.WithString("version",
 			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 		),
-		mcp.WithString("kind",
-			mcp.Required(),
-			mcp.Description("Collector component kind. It can be receiver, exporter, processor, connector and extension."),
-		),
+		withComponentKind(mcp.Required()),
 		mcp.WithString("name",
 			mcp.Required(),
 			mcp.Description("Collector component name e.g. otlp"),
@@ -212,12 +372,13 @@ func getCollectorSchemaValidationTool(schemaManager *collectorschema.SchemaManag
 			mcp.Required(),
 			mcp.Description("Collector component configuration JSON"),
 		),
+		withValidationProfile(),
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		componentKind, err := request.RequireString("kind")
+		componentType, err := requireComponentType(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("kind argument is required: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 		componentName, err := request.RequireString("name")
 		if err != nil {
@@ -228,12 +389,36 @@ func getCollectorSchemaValidationTool(schemaManager *collectorschema.SchemaManag
 			return mcp.NewToolResultError(fmt.Sprintf("config argument is required: %v", err)), nil
 		}
 		version := request.GetString("version", latestCollectorVersion)
+		profile, err := validationProfiles.Resolve(sessionID(ctx), request.GetString("profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-		validationResult, err := schemaManager.ValidateComponentJSON(collectorschema.ComponentType(componentKind), componentName, version, []byte(config))
+		validationResult, err := schemaManager.ValidateComponentJSON(componentType, componentName, version, []byte(config))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to validate json for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+		}
+		suggestions, err := schemaManager.CheckKeySpelling(componentType, componentName, version, []byte(config))
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to validate json for %s/%s@%s: %v", componentKind, componentName, version, err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("failed to check keys for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+		}
+		schemaErrors := validation.SchemaErrors(profile, validationResult.Errors())
+		if profile.UnknownFields {
+			unknown, err := unknownComponentKeys(schemaManager, componentType, componentName, version, []byte(config), suggestions)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to check keys for %s/%s@%s: %v", componentType, componentName, version, err)), nil
+			}
+			schemaErrors = append(schemaErrors, unknown...)
+		}
+		response := ValidationResponse{Valid: profile.Valid(len(schemaErrors), len(suggestions)), Profile: profile.Name}
+		if warning := componentStatusWarning(schemaManager, componentType, componentName, version); warning != "" {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("%s %s is %s", componentType, componentName, warning))
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("is valid: %v, errors: %v", validationResult.Valid(), validationResult.Errors())), nil
+		var omittedErrors, omittedSuggestions int
+		response.Errors, omittedErrors = validation.Limit(profile, schemaErrors)
+		response.Suggestions, omittedSuggestions = validation.Limit(profile, suggestions)
+		response.Omitted = omittedErrors + omittedSuggestions
+		return mcp.NewToolResultStructured(response, fmt.Sprintf("is valid: %v, profile: %s, errors: %v, suggestions: %v, warnings: %v, omitted: %d", response.Valid, profile.Name, response.Errors, response.Suggestions, response.Warnings, response.Omitted)), nil
 	}
 
 	return Tool{Tool: tool, Handler: handler}
@@ -247,16 +432,14 @@ type DeprecatedComponentFields struct {
 // getCollectorComponentDeprecatedTool returns the collector schema validation tool
 func getCollectorComponentDeprecatedTool(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
 	tool := mcp.NewTool("opentelemetry-collector-component-deprecated-fields",
-		mcp.WithDescription("Return deprecated OpenTelemetry collector receiver, exporter, processor, connector and extension configuration fields"),
+		mcp.WithDescription("Return deprecated OpenTelemetry collector receiver, exporter, processor, connector and extension configuration fields with their replacement and a YAML migration snippet when the replacement field is known"),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[DeprecatedFieldsResponse](),
 		mcp.WithString("version",
 			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 		),
-		mcp.WithString("kind",
-			mcp.Required(),
-			mcp.Description("Collector component kind. It can be receiver, exporter, extension."),
-		),
+		withComponentKind(mcp.Required()),
 		mcp.WithArray("names",
 			mcp.WithStringItems(),
 			mcp.Required(),
@@ -265,9 +448,9 @@ func getCollectorComponentDeprecatedTool(schemaManager *collectorschema.SchemaMa
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		componentKind, err := request.RequireString("kind")
+		componentType, err := requireComponentType(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("kind argument is required: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 		componentNames, err := request.RequireStringSlice("names")
 		if err != nil {
@@ -275,36 +458,81 @@ func getCollectorComponentDeprecatedTool(schemaManager *collectorschema.SchemaMa
 		}
 		version := request.GetString("version", latestCollectorVersion)
 
-		var deprecations []DeprecatedComponentFields
+		deprecations := make([]DeprecatedComponentFields, 0, len(componentNames))
 		for _, componentName := range componentNames {
-			deprecatedFields, err := schemaManager.GetDeprecatedFields(collectorschema.ComponentType(componentKind), componentName, version)
+			deprecatedFields, err := schemaManager.GetDeprecatedFields(componentType, componentName, version)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to validate json for %s/%s@%s: %v", componentKind, componentName, version, err)), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to validate json for %s/%s@%s: %v", componentType, componentName, version, err)), nil
 			}
 			deprecations = append(deprecations, DeprecatedComponentFields{
 				ComponentName:    componentName,
 				DeprecatedFields: deprecatedFields,
 			})
 		}
-		if len(deprecations) > 0 {
-			return mcp.NewToolResultText(fmt.Sprintf("deprecated fields: %+v", deprecations)), nil
-		}
-		return mcp.NewToolResultText("no deprecated fields found"), nil
+		return mcp.NewToolResultStructured(DeprecatedFieldsResponse{Components: deprecations}, deprecatedFieldsText(deprecations)), nil
 	}
 
 	return Tool{Tool: tool, Handler: handler}
 }
 
+// deprecatedFieldsText summarizes the deprecated fields of the components with their replacements and migrations
+func deprecatedFieldsText(deprecations []DeprecatedComponentFields) string {
+	var sb strings.Builder
+	for _, component := range deprecations {
+		if len(component.DeprecatedFields) == 0 {
+			fmt.Fprintf(&sb, "%s: no deprecated fields\n", component.ComponentName)
+			continue
+		}
+		fmt.Fprintf(&sb, "%s: %d deprecated fields\n", component.ComponentName, len(component.DeprecatedFields))
+		for _, field := range component.DeprecatedFields {
+			fmt.Fprintf(&sb, "- %s (%s): %s\n", field.Name, field.Type, field.Description)
+			if field.ReplacedBy != "" {
+				fmt.Fprintf(&sb, "  replaced by %s\n", field.ReplacedBy)
+			}
+			if field.Migration != "" {
+				fmt.Fprintf(&sb, "  migration:\n    %s\n", strings.ReplaceAll(strings.TrimRight(field.Migration, "\n"), "\n", "\n    "))
+			}
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
 type DocumentationSearchResult struct {
-	Results []collectorschema.DocumentSearchResult `json:"results"`
+	Results  []CitedSearchResult `json:"results"`
+	Feedback string              `json:"feedback,omitempty"`
+}
+
+// CitedSearchResult is a documentation search result with the upstream sources of the component README
+type CitedSearchResult struct {
+	collectorschema.DocumentSearchResult
+	Citation *github.Citation `json:"citation,omitempty"`
 }
 
-// getCollectorDocumentationRAG returns the query from the RAG
-func getCollectorDocumentationRAG(schemaManager *collectorschema.SchemaManager, latestCollectorVersion string) Tool {
+// citeSearchResults adds the upstream sources to the search results of component READMEs, the relevance explanations are kept only in debug mode
+func citeSearchResults(results []collectorschema.DocumentSearchResult, debug bool) []CitedSearchResult {
+	cited := make([]CitedSearchResult, 0, len(results))
+	for _, result := range results {
+		if !debug {
+			result.Explanation = nil
+		}
+		citedResult := CitedSearchResult{DocumentSearchResult: result}
+		componentType, componentName := result.Metadata["component_type"], result.Metadata["component_name"]
+		if componentType != "" && componentName != "" && result.Version != "" {
+			citation := github.ComponentCitation(componentType, componentName, result.Version)
+			citedResult.Citation = &citation
+		}
+		cited = append(cited, citedResult)
+	}
+	return cited
+}
+
+// getCollectorDocumentationRAG returns the query from the RAG, queries and feedback are recorded in the search log unless it is nil
+func getCollectorDocumentationRAG(schemaManager *collectorschema.SchemaManager, searchLog *searchlog.Store, latestCollectorVersion string) Tool {
 	tool := mcp.NewTool("opentelemetry-collector-rag",
-		mcp.WithDescription("Answer questions about OpenTelemetry collector"),
+		mcp.WithDescription("Answer questions about OpenTelemetry collector. Searches component READMEs, the collector core documentation (service pipelines and telemetry e.g. the collector log level, confmap providers and environment variables, TLS and HTTP settings), changelog entries and the fields of the component schemas (with the field_path metadata) e.g. setting to limit memory usage, by vector similarity combined with keyword matching of titles and component names."),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[DocumentationSearchResult](),
 		mcp.WithString("query",
 			mcp.Description("Query about OpenTelemetry collector's documentation"),
 			mcp.Required(),
@@ -313,18 +541,25 @@ func getCollectorDocumentationRAG(schemaManager *collectorschema.SchemaManager,
 			mcp.Description("The OpenTelemetry Collector version e.g. 0.138.0"),
 			mcp.Required(),
 		),
-		mcp.WithString("kind",
-			mcp.Description("Collector component kind. It can be receiver, exporter, processor, connector and extension. If kind is provided name has to be provided as well."),
-		),
+		withComponentKind(),
 		mcp.WithString("name",
 			mcp.Description("Collector component name e.g. otlp. If name is provided kind has to be provided as well."),
 		),
+		mcp.WithBoolean("debug",
+			mcp.Description("Explain the relevance of each result: vector similarity, keyword score, component name boost and the matched terms"),
+		),
+		mcp.WithString("feedback",
+			mcp.Description("Rate a result of a previous call with the same query instead of searching: up if it answered the query, down if it was irrelevant. Requires result_id."),
+			mcp.Enum(searchlog.RatingUp, searchlog.RatingDown),
+		),
+		mcp.WithString("result_id",
+			mcp.Description("The id of the rated result e.g. 0.139.0/exporter_kafka"),
+		),
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		undefined := "none"
-		componentKind := request.GetString("kind", undefined)
-		componentName := request.GetString("name", undefined)
+		debug := request.GetBool("debug", false)
+		componentName := request.GetString("name", "")
 		version, err := request.RequireString("version")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("version argument is required: %v", err)), nil
@@ -334,26 +569,57 @@ func getCollectorDocumentationRAG(schemaManager *collectorschema.SchemaManager,
 			return mcp.NewToolResultError(fmt.Sprintf("query argument is required: %v", err)), nil
 		}
 
-		if (componentKind == undefined && componentName != undefined) && (componentName == undefined && componentKind != undefined) {
-			return mcp.NewToolResultError(fmt.Sprintf("if kind is provided name has to be provided as well: %v", err)), nil
+		componentType, err := optionalComponentType(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if componentName != "" && componentType == "" {
+			return mcp.NewToolResultError("if name is provided kind has to be provided as well"), nil
 		}
 
-		var result DocumentationSearchResult
-		if componentKind == undefined {
-			results, err := schemaManager.QueryDocumentation(query, version, 3)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to process query %s: %v", query, err)), nil
+		if rating := request.GetString("feedback", ""); rating != "" {
+			if searchLog == nil {
+				return mcp.NewToolResultError("feedback is not recorded, the server was started without --search-log"), nil
 			}
-			result = DocumentationSearchResult{Results: results}
+			resultID := request.GetString("result_id", "")
+			if err := searchLog.RecordFeedback(query, resultID, rating); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to record feedback: %v", err)), nil
+			}
+			return mcp.NewToolResultJSON(DocumentationSearchResult{Results: []CitedSearchResult{}, Feedback: fmt.Sprintf("recorded %s vote for %s", rating, resultID)})
+		}
+
+		// The first search builds the index, its progress is reported to the clients waiting for it
+		if err := schemaManager.BuildRAGDatabase(newProgressReporter(ctx, request).report); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to build the documentation index: %v", err)), nil
+		}
+
+		var results []collectorschema.DocumentSearchResult
+		logEntry := searchlog.Entry{Query: query, Version: version}
+		if componentType == "" {
+			results, err = schemaManager.QueryDocumentation(query, version, 5)
 		} else {
-			results, err := schemaManager.QueryDocumentationWithFilters(query, 3, componentKind, componentName, version)
+			logEntry.Component = string(componentType) + "/" + componentName
+			results, err = schemaManager.QueryDocumentationWithFilters(query, 5, string(componentType), componentName, version)
+		}
+		if searchLog != nil {
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to process query %s: %v", query, err)), nil
+				logEntry.Error = err.Error()
 			}
-			result = DocumentationSearchResult{Results: results}
+			for _, result := range results {
+				logEntry.ResultIDs = append(logEntry.ResultIDs, result.ID)
+			}
+			if len(results) > 0 {
+				logEntry.TopScore = results[0].Score
+			}
+			if logErr := searchLog.RecordQuery(logEntry); logErr != nil {
+				log.Printf("failed to record the search query: %v", logErr)
+			}
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to process query %s: %v", query, err)), nil
 		}
 
-		return mcp.NewToolResultJSON(result)
+		return mcp.NewToolResultJSON(DocumentationSearchResult{Results: citeSearchResults(results, debug)})
 	}
 
 	return Tool{Tool: tool, Handler: handler}
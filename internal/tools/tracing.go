@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/tracing"
+)
+
+// WithTracing creates a server span for every tool call. The span continues the trace of the traceparent HTTP header
+// or, e.g. over stdio, of the traceparent field of the request _meta.
+func WithTracing(tools []Tool, tracer trace.Tracer) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		handler := tool.Handler
+		name := tool.Tool.Name
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if traceparent, ok := metaTraceparent(request); ok {
+				ctx = tracing.ContextWithTraceparent(ctx, traceparent)
+			}
+			ctx, span := tracer.Start(ctx, "tools/call "+name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+				attribute.String("mcp.method.name", "tools/call"),
+				attribute.String("gen_ai.tool.name", name),
+				attribute.String("mcp.session.id", sessionID(ctx)),
+			))
+			defer span.End()
+
+			result, err := handler(ctx, request)
+			switch {
+			case err != nil:
+				span.SetStatus(codes.Error, err.Error())
+			case result != nil && result.IsError:
+				span.SetStatus(codes.Error, "tool returned an error result")
+			}
+			return result, err
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
+
+// metaTraceparent returns the traceparent field of the request _meta
+func metaTraceparent(request mcp.CallToolRequest) (string, bool) {
+	if request.Params.Meta == nil {
+		return "", false
+	}
+	traceparent, ok := request.Params.Meta.AdditionalFields[tracing.TraceparentHeader].(string)
+	return traceparent, ok
+}
@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+// ValidationProfileResponse is the validation profile of the session and the available profiles
+type ValidationProfileResponse struct {
+	Profile  validation.Profile   `json:"profile"`
+	Profiles []validation.Profile `json:"profiles"`
+}
+
+// withValidationProfile returns the profile argument of the validation tools
+func withValidationProfile() mcp.ToolOption {
+	return mcp.WithString("profile",
+		mcp.Description(fmt.Sprintf("The validation profile: editor accepts ${...} placeholders and reports misspelled keys as warnings, agent accepts placeholders and reports misspelled keys as warnings with at most 20 messages, ci fails on placeholders, unknown and misspelled keys and reports all messages. Defaults to the profile of the session, %s if none is set.", validation.DefaultProfile)),
+		mcp.Enum(validation.Names()...),
+	)
+}
+
+// getValidationProfileTool returns the tool setting the validation profile of the session
+func getValidationProfileTool(validationProfiles *validation.Sessions) Tool {
+	tool := mcp.NewTool("opentelemetry-collector-validation-profile",
+		mcp.WithDescription("Get or set the validation profile of the session used by the validation tools without a profile argument. The editor profile accepts ${...} placeholders and reports misspelled keys as warnings, the agent profile accepts placeholders, reports misspelled keys as warnings and limits the messages, the ci profile fails on placeholders, unknown and misspelled keys and reports all messages."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[ValidationProfileResponse](),
+		mcp.WithString("profile",
+			mcp.Description("The validation profile to set for the session, the current profile is returned if not provided"),
+			mcp.Enum(validation.Names()...),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session := sessionID(ctx)
+		var response ValidationProfileResponse
+		var err error
+		if name := request.GetString("profile", ""); name != "" {
+			response.Profile, err = validationProfiles.Set(session, name)
+		} else {
+			response.Profile, err = validationProfiles.Resolve(session, "")
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		for _, name := range validation.Names() {
+			profile, _ := validation.Get(name)
+			response.Profiles = append(response.Profiles, profile)
+		}
+
+		profileJSON, _ := json.Marshal(response.Profile)
+		return mcp.NewToolResultStructured(response, fmt.Sprintf("validation profile of the session: %s\navailable profiles: %s", profileJSON, strings.Join(validation.Names(), ", "))), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
+
+// unknownComponentKeys returns the errors of the component configuration keys that are not schema properties,
+// the misspelled keys are reported as suggestions
+func unknownComponentKeys(schemaManager *collectorschema.SchemaManager, componentType collectorschema.ComponentType, componentName, version string, configJSON []byte, suggestions []collectorschema.KeySuggestion) ([]string, error) {
+	schema, err := schemaManager.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+	var config interface{}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config JSON: %w", err)
+	}
+	misspelled := make(map[string]bool, len(suggestions))
+	for _, suggestion := range suggestions {
+		misspelled[suggestion.Path] = true
+	}
+	var unknown []string
+	for _, path := range collectorschema.UnknownKeys(schema.Schema, config) {
+		if !misspelled[path] {
+			unknown = append(unknown, fmt.Sprintf("%s: unknown key", path))
+		}
+	}
+	return unknown, nil
+}
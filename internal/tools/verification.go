@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/collectorconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/configcheck"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+// ConfigVerification is the validation report of a configuration generated by a tool, the tools re-parse and validate
+// their configurations before returning them
+type ConfigVerification struct {
+	Version  string           `json:"version"`
+	Valid    bool             `json:"valid"`
+	Findings []report.Finding `json:"findings"`
+	Summary  report.Summary   `json:"summary"`
+}
+
+// String returns the summary of the verification for the text results
+func (v *ConfigVerification) String() string {
+	return fmt.Sprintf("verified for %s: %d errors, %d warnings", v.Version, v.Summary.Errors, v.Summary.Warnings)
+}
+
+// verifyGeneratedConfig re-parses a configuration generated by a tool and validates it against the schemas of the
+// version, the pipeline topology and the OTTL statements before it is returned, fragments are validated without the
+// topology. The input is the configuration a migration or hardening tool transformed, its errors are inherited and
+// do not fail the verification, it is nil for the generate tools. An error is returned for a configuration the tool
+// broke itself, the tool returns the error instead of the configuration.
+func verifyGeneratedConfig(schemaManager *collectorschema.SchemaManager, version string, input, output []byte) (*ConfigVerification, error) {
+	// Generated configurations reference secrets as placeholders
+	profile, err := validation.Get(validation.ProfileAgent)
+	if err != nil {
+		return nil, err
+	}
+	findings, err := configcheck.Verify(schemaManager, output, version, profile)
+	if err != nil {
+		return nil, fmt.Errorf("the generated configuration cannot be verified for version %s: %w", version, err)
+	}
+	inherited := make(map[report.Finding]bool)
+	if input != nil {
+		// The input is not located like the output, the findings are compared by their rule, path and message
+		inputFindings, _ := configcheck.Verify(schemaManager, input, version, profile)
+		for _, finding := range inputFindings {
+			inherited[report.Finding{Rule: finding.Rule, Path: finding.Path, Message: finding.Message}] = true
+		}
+	}
+
+	r := report.New("verify", "", findings)
+	verification := &ConfigVerification{Version: version, Valid: r.Summary.Errors == 0, Findings: r.Findings, Summary: r.Summary}
+	if verification.Findings == nil {
+		verification.Findings = []report.Finding{}
+	}
+	var introduced []string
+	for _, finding := range r.Findings {
+		if finding.Severity == report.SeverityError && !inherited[report.Finding{Rule: finding.Rule, Path: finding.Path, Message: finding.Message}] {
+			introduced = append(introduced, strings.TrimPrefix(finding.Path+": "+finding.Message, ": "))
+		}
+	}
+	if len(introduced) > 0 {
+		return verification, fmt.Errorf("the generated configuration is not valid for version %s: %s", version, strings.Join(introduced, "; "))
+	}
+	return verification, nil
+}
+
+// topologyIssues returns the pipeline topology issues of a configuration transformed by a tool, a fragment of the
+// input has no topology and is transformed into a fragment
+func topologyIssues(input []byte, config *collectorconfig.Config) []collectorconfig.Issue {
+	if configcheck.IsFragment(input) {
+		return nil
+	}
+	return config.ValidateTopology()
+}
@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+const (
+	// versionPinToolName is the name of the tool pinning the collector version of the session
+	versionPinToolName = "opentelemetry-collector-version-pin"
+	// VersionMetaKey is the result metadata key of the collector version the result was produced for
+	VersionMetaKey = "collectorVersion"
+	// VersionSourceMetaKey is the result metadata key of where the version came from: argument, pin or default
+	VersionSourceMetaKey = "collectorVersionSource"
+)
+
+// The sources of the version stamped on the results
+const (
+	versionSourceArgument = "argument"
+	versionSourcePin      = "pin"
+	versionSourceDefault  = "default"
+)
+
+// unpinnedTools are the tools whose omitted version means all versions instead of the latest version
+var unpinnedTools = map[string]bool{
+	versionPinToolName:                     true,
+	"opentelemetry-collector-schema-stats": true,
+}
+
+// VersionPins keeps the collector version pinned by each client session in the storage of the server
+type VersionPins struct {
+	storage storage.Store
+}
+
+// NewVersionPins creates the session version pins kept in the storage, sessions use the version argument or the
+// latest version until they pin a version
+func NewVersionPins(store storage.Store) *VersionPins {
+	return &VersionPins{storage: store}
+}
+
+// Set pins the version of a session
+func (p *VersionPins) Set(session, version string) error {
+	if err := p.storage.Put(storage.NamespaceVersionPins, storage.SessionKey(session), []byte(version)); err != nil {
+		return fmt.Errorf("failed to store the pinned version: %w", err)
+	}
+	return nil
+}
+
+// Clear removes the pinned version of a session
+func (p *VersionPins) Clear(session string) error {
+	if err := p.storage.Delete(storage.NamespaceVersionPins, storage.SessionKey(session)); err != nil {
+		return fmt.Errorf("failed to clear the pinned version: %w", err)
+	}
+	return nil
+}
+
+// Get returns the pinned version of a session
+func (p *VersionPins) Get(session string) (string, bool, error) {
+	version, found, err := p.storage.Get(storage.NamespaceVersionPins, storage.SessionKey(session))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read the pinned version: %w", err)
+	}
+	return string(version), found, nil
+}
+
+// WithVersionPinning sets the version argument the tool call omits to the version pinned by the session, so a chain
+// of calls e.g. search, readme, schema and validate answers for one version. The results of the tools with a version
+// argument are stamped with the version they were produced for in the collectorVersion metadata, the error results are
+// not.
+func WithVersionPinning(tools []Tool, pins *VersionPins, latestVersion string) []Tool {
+	wrapped := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if _, ok := tool.Tool.InputSchema.Properties["version"]; !ok || unpinnedTools[tool.Tool.Name] {
+			wrapped = append(wrapped, tool)
+			continue
+		}
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			version, source := request.GetString("version", ""), versionSourceArgument
+			if version == "" {
+				version, source = latestVersion, versionSourceDefault
+				pinned, ok, err := pins.Get(sessionID(ctx))
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					version, source = pinned, versionSourcePin
+					arguments := maps.Clone(request.GetArguments())
+					if arguments == nil {
+						arguments = make(map[string]any, 1)
+					}
+					arguments["version"] = pinned
+					request.Params.Arguments = arguments
+				}
+			}
+			result, err := handler(ctx, request)
+			if result != nil && !result.IsError {
+				fields := map[string]any{VersionMetaKey: version, VersionSourceMetaKey: source}
+				if result.Meta != nil {
+					fields = maps.Clone(result.Meta.AdditionalFields)
+					fields[VersionMetaKey], fields[VersionSourceMetaKey] = version, source
+				}
+				result.Meta = mcp.NewMetaFromMap(fields)
+			}
+			return result, err
+		}
+		wrapped = append(wrapped, tool)
+	}
+	return wrapped
+}
+
+// VersionPinResponse is the collector version pinned by the session
+type VersionPinResponse struct {
+	Version       string   `json:"version,omitempty" jsonschema:"description=The pinned version, empty if the session has no pinned version"`
+	LatestVersion string   `json:"latestVersion"`
+	Versions      []string `json:"versions"`
+}
+
+// GetVersionPinTools returns the tools pinning the collector version of the client session
+func GetVersionPinTools(schemaManager *collectorschema.SchemaManager, pins *VersionPins) []Tool {
+	return []Tool{
+		getVersionPinTool(schemaManager, pins),
+	}
+}
+
+// getVersionPinTool returns the tool getting, setting and clearing the collector version of the session
+func getVersionPinTool(schemaManager *collectorschema.SchemaManager, pins *VersionPins) Tool {
+	tool := mcp.NewTool(versionPinToolName,
+		mcp.WithDescription("Get, pin or clear the OpenTelemetry collector version of the session. Tools called without a version argument use the pinned version instead of the latest version, so a chain of calls e.g. search, README, schema and validation answers for one version and docs of different versions are not mixed. Every result of a tool with a version argument reports the version it was produced for in its collectorVersion metadata."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithOutputSchema[VersionPinResponse](),
+		mcp.WithString("version",
+			mcp.Description("The OpenTelemetry Collector version to pin e.g. 0.138.0, the pinned version is returned if not provided"),
+		),
+		mcp.WithBoolean("clear",
+			mcp.Description("Remove the pinned version, the tools use the latest version again"),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		versions, err := schemaManager.GetAllVersions()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get versions: %v", err)), nil
+		}
+		latestVersion, err := schemaManager.GetLatestVersion()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get the latest version: %v", err)), nil
+		}
+
+		session := sessionID(ctx)
+		version := strings.TrimPrefix(request.GetString("version", ""), "v")
+		switch {
+		case request.GetBool("clear", false):
+			if version != "" {
+				return mcp.NewToolResultError("version and clear cannot be set together"), nil
+			}
+			if err := pins.Clear(session); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case version != "":
+			if !slices.Contains(versions, version) {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported version %q, must be one of %s", version, strings.Join(versions, ", "))), nil
+			}
+			if err := pins.Set(session, version); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		response := VersionPinResponse{LatestVersion: latestVersion, Versions: versions}
+		response.Version, _, err = pins.Get(session)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		text := fmt.Sprintf("no pinned version, tools without a version argument use the latest version %s", latestVersion)
+		if response.Version != "" {
+			text = fmt.Sprintf("pinned version of the session: %s, tools without a version argument use it", response.Version)
+		}
+		return mcp.NewToolResultStructured(response, text), nil
+	}
+
+	return Tool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+func TestWithVersionPinning(t *testing.T) {
+	var arguments map[string]any
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments = request.GetArguments()
+		if request.GetString("name", "") == "missing" {
+			return mcp.NewToolResultError("not found"), nil
+		}
+		result := mcp.NewToolResultText("ok")
+		result.Meta = mcp.NewMetaFromMap(map[string]any{"other": "kept"})
+		return result, nil
+	}
+	pins := NewVersionPins(storage.NewMemory())
+	wrapped := WithVersionPinning([]Tool{
+		{Tool: mcp.NewTool("versioned", mcp.WithString("version"), mcp.WithString("name")), Handler: handler},
+		{Tool: mcp.NewTool("opentelemetry-collector-schema-stats", mcp.WithString("version")), Handler: handler},
+		{Tool: mcp.NewTool("unversioned", mcp.WithString("name")), Handler: handler},
+	}, pins, "0.139.0")
+
+	result := callTool(t, wrapped[0], map[string]any{"name": "otlp"})
+	assert.Equal(t, map[string]any{"name": "otlp"}, arguments)
+	assert.Equal(t, map[string]any{"other": "kept", VersionMetaKey: "0.139.0", VersionSourceMetaKey: "default"}, result.Meta.AdditionalFields)
+
+	require.NoError(t, pins.Set(defaultSession, "0.138.0"))
+	result = callTool(t, wrapped[0], map[string]any{"name": "otlp"})
+	assert.Equal(t, map[string]any{"name": "otlp", "version": "0.138.0"}, arguments)
+	assert.Equal(t, "pin", result.Meta.AdditionalFields[VersionSourceMetaKey])
+	result = callTool(t, wrapped[0], map[string]any{"version": "0.137.0"})
+	assert.Equal(t, map[string]any{"version": "0.137.0"}, arguments)
+	assert.Equal(t, []any{"0.137.0", "argument"}, []any{result.Meta.AdditionalFields[VersionMetaKey], result.Meta.AdditionalFields[VersionSourceMetaKey]})
+
+	// Error results are not stamped
+	result = callTool(t, wrapped[0], map[string]any{"name": "missing"})
+	require.True(t, result.IsError)
+	assert.Nil(t, result.Meta)
+
+	// An omitted version of the schema stats means all versions
+	result = callTool(t, wrapped[1], map[string]any{})
+	assert.Empty(t, arguments)
+	assert.NotContains(t, result.Meta.AdditionalFields, VersionMetaKey)
+	result = callTool(t, wrapped[2], map[string]any{})
+	assert.NotContains(t, result.Meta.AdditionalFields, VersionMetaKey)
+}
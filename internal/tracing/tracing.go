@@ -0,0 +1,48 @@
+// Package tracing continues the W3C trace context of the MCP clients through the tool calls and exports the server
+// spans with the OpenTelemetry SDK, so the agent to MCP server to tool spans form a single trace
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// TraceparentHeader is the W3C trace context header and the MCP _meta field carrying the parent span
+const TraceparentHeader = "traceparent"
+
+// ScopeName is the instrumentation scope of the server spans
+const ScopeName = "github.com/pavolloffay/opentelemetry-mcp-server"
+
+var propagator = propagation.TraceContext{}
+
+// NewTracerProvider creates a tracer provider exporting the spans in batches to the OTLP/HTTP endpoint
+// e.g. http://localhost:4318. Spans of not sampled parents are propagated but not exported.
+func NewTracerProvider(endpoint, serviceName string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+"/v1/traces"))
+	if err != nil {
+		return nil, err
+	}
+	serviceResource, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(serviceResource)), nil
+}
+
+// ContextWithTraceparent returns a context carrying the span of the W3C traceparent value as the parent of the next
+// span, invalid values return the context unchanged
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier{TraceparentHeader: traceparent})
+}
+
+// HTTPContextFunc extracts the traceparent header of an HTTP request into the request context
+func HTTPContextFunc(ctx context.Context, r *http.Request) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(r.Header))
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestContextWithTraceparent(t *testing.T) {
+	sc := trace.SpanContextFromContext(ContextWithTraceparent(context.Background(), traceparent))
+	require.True(t, sc.IsValid())
+	assert.True(t, sc.IsRemote())
+	assert.True(t, sc.IsSampled())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+
+	for _, value := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		assert.False(t, trace.SpanContextFromContext(ContextWithTraceparent(context.Background(), value)).IsValid(), value)
+	}
+}
+
+func TestHTTPContextFunc(t *testing.T) {
+	request := httptest.NewRequest("POST", "/mcp", nil)
+	request.Header.Set(TraceparentHeader, traceparent)
+	sc := trace.SpanContextFromContext(HTTPContextFunc(context.Background(), request))
+	require.True(t, sc.IsValid())
+	assert.Equal(t, "00f067aa0ba902b7", sc.SpanID().String())
+
+	assert.False(t, trace.SpanContextFromContext(HTTPContextFunc(context.Background(), httptest.NewRequest("POST", "/mcp", nil))).IsValid())
+}
+
+func TestChildSpanOfTraceparent(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { require.NoError(t, provider.Shutdown(context.Background())) }()
+
+	_, span := provider.Tracer(ScopeName).Start(ContextWithTraceparent(context.Background(), traceparent), "tools/call")
+	span.End()
+	_, notSampled := provider.Tracer(ScopeName).Start(ContextWithTraceparent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"), "tools/call")
+	notSampled.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	provider, err := NewTracerProvider("http://localhost:4318/", "otel-mcp-server")
+	require.NoError(t, err)
+	require.NoError(t, provider.Shutdown(context.Background()))
+}
@@ -0,0 +1,170 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// LibreTranslate translates documentation using a LibreTranslate compatible API
+type LibreTranslate struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// NewLibreTranslate creates a translator for the given LibreTranslate /translate endpoint URL sending the requests with
+// the HTTP client
+func NewLibreTranslate(url string, apiKey string, client *http.Client) *LibreTranslate {
+	return &LibreTranslate{
+		url:    url,
+		apiKey: apiKey,
+		client: client,
+	}
+}
+
+type libreTranslateRequest struct {
+	Query  []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+type libreTranslateResponse struct {
+	TranslatedText []string `json:"translatedText"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// Translate translates the markdown text from English into the target locale, the fenced code blocks e.g. the YAML
+// configuration examples are not translated
+func (lt *LibreTranslate) Translate(ctx context.Context, text string, locale string) (string, error) {
+	segments := splitMarkdown(text)
+	var prose []string
+	for _, segment := range segments {
+		if !segment.code && segment.text != "" {
+			prose = append(prose, segment.text)
+		}
+	}
+	if len(prose) == 0 {
+		return text, nil
+	}
+	translated, err := lt.translate(ctx, prose, locale)
+	if err != nil {
+		return "", err
+	}
+	if len(translated) != len(prose) {
+		return "", fmt.Errorf("translation service returned %d texts for %d", len(translated), len(prose))
+	}
+
+	var result strings.Builder
+	for _, segment := range segments {
+		if !segment.code && segment.text != "" {
+			segment.text, translated = translated[0], translated[1:]
+		}
+		result.WriteString(segment.leading + segment.text + segment.trailing)
+	}
+	return result.String(), nil
+}
+
+// translate translates the texts from English into the target locale in a single request
+func (lt *LibreTranslate) translate(ctx context.Context, texts []string, locale string) ([]string, error) {
+	// LibreTranslate uses language codes without region except for a few languages (e.g. zh-Hant)
+	target := locale
+	if base, _, found := strings.Cut(locale, "-"); found && base != "zh" {
+		target = base
+	}
+
+	body, err := json.Marshal(libreTranslateRequest{
+		Query:  texts,
+		Source: "en",
+		Target: target,
+		Format: "text",
+		APIKey: lt.apiKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal translation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, lt.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := lt.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("translation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation response: %w", err)
+	}
+
+	var result libreTranslateResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse translation response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("translation service returned status %d: %s", resp.StatusCode, result.Error)
+	}
+
+	return result.TranslatedText, nil
+}
+
+// markdownSegment is a fenced code block or the prose between them, the whitespace around the prose is kept out of the
+// translation
+type markdownSegment struct {
+	code     bool
+	leading  string
+	text     string
+	trailing string
+}
+
+// splitMarkdown splits markdown into the fenced code blocks opened by ``` or ~~~ and the prose between them
+func splitMarkdown(text string) []markdownSegment {
+	var segments []markdownSegment
+	var current strings.Builder
+	fence := ""
+	flush := func(code bool) {
+		if current.Len() == 0 {
+			return
+		}
+		value := current.String()
+		current.Reset()
+		if code {
+			segments = append(segments, markdownSegment{code: true, text: value})
+			return
+		}
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			segments = append(segments, markdownSegment{leading: value})
+			return
+		}
+		start := strings.Index(value, trimmed)
+		segments = append(segments, markdownSegment{leading: value[:start], text: trimmed, trailing: value[start+len(trimmed):]})
+	}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		marker := strings.TrimSpace(line)
+		switch {
+		case fence == "" && (strings.HasPrefix(marker, "```") || strings.HasPrefix(marker, "~~~")):
+			flush(false)
+			fence = marker[:3]
+			current.WriteString(line)
+		case fence != "" && strings.HasPrefix(marker, fence) && strings.Trim(marker, fence[:1]) == "":
+			current.WriteString(line)
+			flush(true)
+			fence = ""
+		default:
+			current.WriteString(line)
+		}
+	}
+	flush(fence != "")
+	return segments
+}
@@ -0,0 +1,46 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibreTranslate_Translate(t *testing.T) {
+	var request libreTranslateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		var response libreTranslateResponse
+		for _, text := range request.Query {
+			response.TranslatedText = append(response.TranslatedText, strings.ToUpper(text))
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	readme := "# OTLP Receiver\n\nReceives data via gRPC.\n\n```yaml\nreceivers:\n  otlp:\n```\n\nExample:\n~~~\nendpoint: localhost\n~~~\n"
+	translated, err := NewLibreTranslate(server.URL, "key", server.Client()).Translate(context.Background(), readme, "pt-br")
+	require.NoError(t, err)
+
+	// The fenced code blocks are not sent and kept as they are
+	assert.Equal(t, []string{"# OTLP Receiver\n\nReceives data via gRPC.", "Example:"}, request.Query)
+	assert.Equal(t, "pt", request.Target)
+	assert.Equal(t, "# OTLP RECEIVER\n\nRECEIVES DATA VIA GRPC.\n\n```yaml\nreceivers:\n  otlp:\n```\n\nEXAMPLE:\n~~~\nendpoint: localhost\n~~~\n", translated)
+}
+
+func TestLibreTranslate_Translate_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "de is not supported"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewLibreTranslate(server.URL, "", server.Client()).Translate(context.Background(), "Receives data.", "de")
+	assert.ErrorContains(t, err, "de is not supported")
+}
@@ -0,0 +1,141 @@
+// Package validation bundles the settings of the validation tools into named profiles selectable per tool call and
+// per client session
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+const (
+	// ProfileEditor is for configurations being edited: placeholders are accepted and findings are warnings
+	ProfileEditor = "editor"
+	// ProfileAgent is for configurations generated by agents: placeholders are accepted, misspelled keys are warnings
+	// and the messages are limited to keep the tool results small
+	ProfileAgent = "agent"
+	// ProfileCI is for rendered configurations validated in CI: placeholders and unknown keys fail the validation and
+	// every message is reported
+	ProfileCI = "ci"
+
+	// DefaultProfile is the profile of the sessions without a profile
+	DefaultProfile = ProfileAgent
+)
+
+// Profile is a named set of validation settings
+type Profile struct {
+	Name string `json:"name"`
+	// Placeholders accepts ${env:VAR} and ${VAR} placeholders in place of any value, the values are resolved by the
+	// collector at startup
+	Placeholders bool `json:"placeholders"`
+	// UnknownFields reports every key that is not a schema property, not only the keys resembling one
+	UnknownFields bool `json:"unknownFields"`
+	// WarningsAsErrors fails the validation on warnings e.g. misspelled keys
+	WarningsAsErrors bool `json:"warningsAsErrors"`
+	// MaxMessages is the number of reported errors and warnings each, 0 reports all messages
+	MaxMessages int `json:"maxMessages"`
+}
+
+var profiles = map[string]Profile{
+	ProfileEditor: {Name: ProfileEditor, Placeholders: true, MaxMessages: 100},
+	ProfileAgent:  {Name: ProfileAgent, Placeholders: true, MaxMessages: 20},
+	ProfileCI:     {Name: ProfileCI, UnknownFields: true, WarningsAsErrors: true},
+}
+
+// placeholderPattern matches the collector config providers and environment variables e.g. ${env:ENDPOINT}
+var placeholderPattern = regexp.MustCompile(`\$\{[^}]+\}`)
+
+// Names returns the names of the profiles in sorted order
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the profile of the name
+func Get(name string) (Profile, error) {
+	profile, ok := profiles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown validation profile %q, use one of %s", name, strings.Join(Names(), ", "))
+	}
+	return profile, nil
+}
+
+// IsPlaceholder returns true if the value is a string with a ${...} placeholder
+func IsPlaceholder(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && placeholderPattern.MatchString(s)
+}
+
+// SchemaErrors returns the messages of the schema validation errors, the errors of placeholder values are dropped if
+// the profile accepts placeholders
+func SchemaErrors[T interface {
+	Value() interface{}
+	String() string
+}](profile Profile, errs []T) []string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if profile.Placeholders && IsPlaceholder(err.Value()) {
+			continue
+		}
+		messages = append(messages, err.String())
+	}
+	return messages
+}
+
+// Valid returns true if a validation with the number of errors and warnings passes
+func (p Profile) Valid(errors, warnings int) bool {
+	return errors == 0 && (!p.WarningsAsErrors || warnings == 0)
+}
+
+// Limit returns the first MaxMessages messages and the number of omitted messages
+func Limit[T any](profile Profile, messages []T) ([]T, int) {
+	if profile.MaxMessages <= 0 || len(messages) <= profile.MaxMessages {
+		return messages, 0
+	}
+	return messages[:profile.MaxMessages], len(messages) - profile.MaxMessages
+}
+
+// Sessions keeps the validation profile of each client session in the storage of the server
+type Sessions struct {
+	storage storage.Store
+}
+
+// NewSessions creates the session profiles kept in the storage, sessions use DefaultProfile until they set a profile
+func NewSessions(store storage.Store) *Sessions {
+	return &Sessions{storage: store}
+}
+
+// Set sets the profile of a session
+func (s *Sessions) Set(session, name string) (Profile, error) {
+	profile, err := Get(name)
+	if err != nil {
+		return Profile{}, err
+	}
+	if err := s.storage.Put(storage.NamespaceValidationProfiles, storage.SessionKey(session), []byte(profile.Name)); err != nil {
+		return Profile{}, fmt.Errorf("failed to store the validation profile: %w", err)
+	}
+	return profile, nil
+}
+
+// Resolve returns the profile of the name if it is set, otherwise the profile of the session
+func (s *Sessions) Resolve(session, name string) (Profile, error) {
+	if name != "" {
+		return Get(name)
+	}
+	sessionProfile, found, err := s.storage.Get(storage.NamespaceValidationProfiles, storage.SessionKey(session))
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to read the validation profile: %w", err)
+	}
+	// A profile stored by another server version may not exist
+	if profile, ok := profiles[string(sessionProfile)]; found && ok {
+		return profile, nil
+	}
+	return profiles[DefaultProfile], nil
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+)
+
+type resultError struct {
+	value   interface{}
+	message string
+}
+
+func (e resultError) Value() interface{} { return e.value }
+func (e resultError) String() string     { return e.message }
+
+func TestGet(t *testing.T) {
+	profile, err := Get(" CI ")
+	require.NoError(t, err)
+	assert.Equal(t, ProfileCI, profile.Name)
+	assert.False(t, profile.Placeholders)
+
+	_, err = Get("lenient")
+	assert.EqualError(t, err, `unknown validation profile "lenient", use one of agent, ci, editor`)
+}
+
+func TestSchemaErrors(t *testing.T) {
+	errs := []resultError{
+		{value: "${env:BATCH_SIZE}", message: "send_batch_size: Invalid type. Expected: integer, given: string"},
+		{value: "abc", message: "timeout: Does not match pattern"},
+	}
+
+	editor, _ := Get(ProfileEditor)
+	assert.Equal(t, []string{"timeout: Does not match pattern"}, SchemaErrors(editor, errs))
+	ci, _ := Get(ProfileCI)
+	assert.Len(t, SchemaErrors(ci, errs), 2)
+}
+
+func TestProfileValid(t *testing.T) {
+	editor, _ := Get(ProfileEditor)
+	agent, _ := Get(ProfileAgent)
+	ci, _ := Get(ProfileCI)
+	assert.True(t, editor.Valid(0, 3))
+	assert.True(t, agent.Valid(0, 3))
+	assert.False(t, ci.Valid(0, 3))
+	assert.False(t, editor.Valid(1, 0))
+}
+
+func TestLimit(t *testing.T) {
+	agent, _ := Get(ProfileAgent)
+	messages := make([]string, 25)
+	limited, omitted := Limit(agent, messages)
+	assert.Len(t, limited, 20)
+	assert.Equal(t, 5, omitted)
+
+	ci, _ := Get(ProfileCI)
+	limited, omitted = Limit(ci, messages)
+	assert.Len(t, limited, 25)
+	assert.Zero(t, omitted)
+}
+
+func TestSessions(t *testing.T) {
+	sessions := NewSessions(storage.NewMemory())
+	profile, err := sessions.Resolve("a", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, profile.Name)
+
+	_, err = sessions.Set("a", ProfileCI)
+	require.NoError(t, err)
+	profile, _ = sessions.Resolve("a", "")
+	assert.Equal(t, ProfileCI, profile.Name)
+	profile, _ = sessions.Resolve("b", "")
+	assert.Equal(t, DefaultProfile, profile.Name)
+
+	// The profile of a call overrides the profile of the session
+	profile, _ = sessions.Resolve("a", ProfileEditor)
+	assert.Equal(t, ProfileEditor, profile.Name)
+	_, err = sessions.Set("a", "unknown")
+	assert.Error(t, err)
+}
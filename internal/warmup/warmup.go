@@ -0,0 +1,103 @@
+// Package warmup runs the startup tasks warming the caches of the server in the background and reports their status
+// on the readiness endpoint, so latency-sensitive deployments route traffic to the server only once it is warm
+package warmup
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// States of a warm-up task
+const (
+	StateRunning = "running"
+	StateDone    = "done"
+	StateFailed  = "failed"
+)
+
+// Task is the status of a warm-up task
+type Task struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+	// Duration is the duration of the finished task e.g. 1.2s
+	Duration string `json:"duration,omitempty"`
+}
+
+// Status is the readiness of the server, it is ready once all warm-up tasks are done
+type Status struct {
+	Ready bool   `json:"ready"`
+	Tasks []Task `json:"tasks"`
+}
+
+// Warmup tracks the warm-up tasks
+type Warmup struct {
+	mutex sync.Mutex
+	tasks []*Task
+	wg    sync.WaitGroup
+}
+
+// New creates a warm-up without tasks, it is ready until a task is started
+func New() *Warmup {
+	return &Warmup{}
+}
+
+// Start runs a warm-up task in the background
+func (w *Warmup) Start(name string, fn func() error) {
+	task := &Task{Name: name, State: StateRunning}
+	w.mutex.Lock()
+	w.tasks = append(w.tasks, task)
+	w.mutex.Unlock()
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		start := time.Now()
+		err := fn()
+		duration := time.Since(start).Round(time.Millisecond)
+
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		task.Duration = duration.String()
+		if err != nil {
+			task.State = StateFailed
+			task.Error = err.Error()
+			log.Printf("Warm-up %s failed after %s: %v", name, duration, err)
+			return
+		}
+		task.State = StateDone
+		log.Printf("Warm-up %s done in %s", name, duration)
+	}()
+}
+
+// Wait waits for the started tasks to finish
+func (w *Warmup) Wait() {
+	w.wg.Wait()
+}
+
+// Status returns the readiness and the status of the tasks, a failed task keeps the server not ready
+func (w *Warmup) Status() Status {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	status := Status{Ready: true, Tasks: make([]Task, 0, len(w.tasks))}
+	for _, task := range w.tasks {
+		status.Ready = status.Ready && task.State == StateDone
+		status.Tasks = append(status.Tasks, *task)
+	}
+	return status
+}
+
+// Handler returns the HTTP handler of the readiness endpoint e.g. on /readyz, it responds with 503 Service Unavailable
+// until the server is ready
+func (w *Warmup) Handler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		status := w.Status()
+		rw.Header().Set("Content-Type", "application/json")
+		if !status.Ready {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(rw).Encode(status)
+	})
+}
@@ -0,0 +1,55 @@
+package warmup
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmup(t *testing.T) {
+	w := New()
+	assert.Equal(t, Status{Ready: true, Tasks: []Task{}}, w.Status())
+
+	release := make(chan struct{})
+	w.Start("precache", func() error {
+		<-release
+		return nil
+	})
+	status, code := readiness(t, w)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, status.Ready)
+	assert.Equal(t, []Task{{Name: "precache", State: StateRunning}}, status.Tasks)
+
+	close(release)
+	w.Wait()
+	status, code = readiness(t, w)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, status.Ready)
+	assert.Equal(t, StateDone, status.Tasks[0].State)
+	assert.NotEmpty(t, status.Tasks[0].Duration)
+
+	w.Start("rag", func() error {
+		return errors.New("index failed")
+	})
+	w.Wait()
+	status, code = readiness(t, w)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, status.Ready)
+	assert.Equal(t, StateFailed, status.Tasks[1].State)
+	assert.Equal(t, "index failed", status.Tasks[1].Error)
+}
+
+func readiness(t *testing.T, w *Warmup) (Status, int) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	w.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var status Status
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	return status, recorder.Code
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/analysis"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/configcheck"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/policy"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Report duplicate, conflicting and over-sized settings of a collector configuration",
+	Long: `Lint a collector configuration file: duplicate components, listeners conflicting on a port, memory_limiter
+processors with different budgets, scraper collection intervals, timeouts and initial delays that do not fit together,
+endpoints not matching the format of their component and settings exceeding known hard limits e.g. batches larger than the gRPC message size of the backend. The findings are grouped by configuration section and reported as colored text, JSON or SARIF for
+code review tools, --fail-on decides the exit code. The rules of the --policy-pack files are enforced as well, their
+findings are namespaced by the pack e.g. acme/exporters-tls and carry the documentation of the rule.`,
+	Example: `  opentelemetry-mcp-server lint --config collector.yaml --memory-mib 512 --fail-on warning
+  opentelemetry-mcp-server lint --config collector.yaml --policy-pack acme-policies.yaml --format sarif`,
+	RunE: runLint,
+}
+
+func init() {
+	lintCmd.Flags().String("config", "", "Collector configuration YAML file")
+	lintCmd.Flags().Float64("memory-mib", 0, "Memory limit of the collector container in MiB, the memory checks are skipped if 0")
+	lintCmd.Flags().Float64("cpus", 0, "CPU limit of the collector container e.g. 0.5, the CPU checks are skipped if 0")
+	lintCmd.Flags().Float64("max-recv-msg-size-mib", analysis.DefaultGRPCMaxRecvMsgSizeMiB, "Maximum gRPC message size in MiB of the backends the collector exports to")
+	lintCmd.Flags().StringSlice("policy-pack", nil, "Policy pack YAML file whose rules are enforced, repeat the flag for more packs")
+	lintCmd.Flags().String("version", "", "OpenTelemetry Collector version whose schema defaults the policy rules read, defaults to the latest version")
+	addReportFlags(lintCmd, string(report.SeverityError))
+	_ = lintCmd.MarkFlagRequired("config")
+	rootCmd.AddCommand(lintCmd)
+}
+
+func runLint(cmd *cobra.Command, _ []string) error {
+	path, _ := cmd.Flags().GetString("config")
+	var environment analysis.Environment
+	environment.MemoryMiB, _ = cmd.Flags().GetFloat64("memory-mib")
+	environment.CPUs, _ = cmd.Flags().GetFloat64("cpus")
+	environment.MaxRecvMsgSizeMiB, _ = cmd.Flags().GetFloat64("max-recv-msg-size-mib")
+	if err := validateReportFlags(cmd); err != nil {
+		return err
+	}
+	configYAML, err := loadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	policyPacks, _ := cmd.Flags().GetStringSlice("policy-pack")
+	packs, err := policy.Load(policyPacks...)
+	if err != nil {
+		return err
+	}
+	findings, err := configcheck.Lint(configYAML, environment)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if len(packs) > 0 {
+		schemaManager, err := newSchemaManager(cmd)
+		if err != nil {
+			return err
+		}
+		version, _ := cmd.Flags().GetString("version")
+		if version == "" {
+			if version, err = schemaManager.GetLatestVersion(); err != nil {
+				return err
+			}
+		}
+		policyFindings, err := configcheck.Policies(schemaManager, configYAML, version, packs)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		findings = append(findings, policyFindings...)
+	}
+	return writeReport(cmd, report.New("lint", path, findings))
+}
@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/cobra"
 
-	"github.com/pavolloffay/opentelemetry-mcp-server/internal/tools"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/httpclient"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+	"github.com/pavolloffay/opentelemetry-mcp-server/mcpserver"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
 )
 
 var rootCmd = &cobra.Command{
@@ -18,42 +27,142 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.Flags().String("protocol", "stdio", "Transport protocol: stdio or http")
+	rootCmd.Flags().Bool("no-filesystem", false, "Never read or write the local disk, the flags using it e.g. --schemas-dir, --rag-index and --search-log are rejected")
 	rootCmd.Flags().String("addr", ":8080", "Listen address for http protocol")
+	rootCmd.PersistentFlags().String("schemas-dir", "", "Directory of the schemas of a custom distribution generated from a collector builder manifest, the embedded schemas are served if empty")
+	rootCmd.PersistentFlags().String("cache-dir", "", "Directory of the cache shared by the GitHub responses, the embeddings of an embeddings API and the generated artifacts, the cache is kept in memory if empty")
+	rootCmd.PersistentFlags().Int64("cache-size", cache.DefaultMaxSize, "Maximum size in bytes of the cached content, the least recently used entries are evicted above it, 0 disables the limit")
+	rootCmd.Flags().String("translation-url", "", "LibreTranslate compatible /translate endpoint used to translate READMEs into the requested locale")
+	rootCmd.Flags().String("translation-api-key", "", "API key for the translation endpoint")
+	rootCmd.Flags().Bool("enable-github", false, "Enable the tool fetching live component READMEs and issues from GitHub")
+	rootCmd.Flags().String("github-token", "", "GitHub token used by the GitHub tool to raise the API rate limit")
+	rootCmd.Flags().Bool("enable-advisories", false, "Enable the tool checking collector versions against known security advisories")
+	rootCmd.Flags().String("advisories-url", "", "URL of an advisory database YAML used to refresh the embedded advisories")
+	rootCmd.Flags().Bool("enable-registry", false, "Enable the tool searching the OpenTelemetry registry for instrumentation libraries of all languages")
+	rootCmd.Flags().String("registry-url", "", "URL of a registry snapshot YAML used to refresh the embedded registry snapshot")
+	rootCmd.Flags().String("otelcol-binary", "", "Collector binary enabling the dry-run validation tool, {version} in the path is replaced with the validated collector version")
+	rootCmd.Flags().StringSlice("live-config-endpoint", nil, "Effective configuration endpoint of a running collector enabling the live config tool e.g. the effective.yaml of the OpAMP supervisor served over http or the effective config API of an OpAMP server, repeat the flag for more collectors")
+	rootCmd.Flags().StringSlice("policy-pack", nil, "Policy pack YAML file whose rules the lint checks of the config check tool enforce e.g. all exporters must use TLS, repeat the flag for more packs")
+	rootCmd.Flags().String("storage", storage.KindMemory, "Storage of the session preferences, the config snapshots and the artifacts: memory, or disk to keep them across restarts in --storage-dir")
+	rootCmd.Flags().String("storage-dir", "", "Directory of the disk storage, servers sharing the directory share the session state")
+	rootCmd.Flags().String("snapshot-dir", "", "Directory persisting the config snapshots, snapshots are kept in memory only if empty")
+	_ = rootCmd.Flags().MarkDeprecated("snapshot-dir", "use --storage disk --storage-dir instead")
+	rootCmd.Flags().Float64("rag-keyword-weight", float64(collectorschema.DefaultSearchWeights.Keyword), "Weight of the keyword matching in the documentation search between 0 (vector similarity only) and 1 (keywords only)")
+	rootCmd.Flags().String("rag-index", "", "Directory of a documentation index precomputed with the rag build command, the documents are indexed at startup if empty")
+	rootCmd.Flags().String("rag-api-key", "", "API key embedding the search queries of an index built with the openai provider, defaults to the OPENAI_API_KEY environment variable")
+	rootCmd.Flags().String("precache", "", "Comma separated components whose schemas are loaded at startup e.g. receiver/otlp@0.139.0,processor/batch@latest, the version defaults to latest")
+	rootCmd.Flags().Bool("prewarm-rag", false, "Build the documentation search index at startup instead of on the first search")
+	rootCmd.Flags().Duration("not-found-cache-ttl", collectorschema.DefaultNotFoundCacheTTL, "How long the lookups of components missing in a version are cached, 0 disables the cache")
+	rootCmd.Flags().String("search-log", "", "JSON lines file recording the documentation search queries and the result feedback for the search-report command")
+	rootCmd.Flags().String("record", "", "JSON lines file recording the tool calls of all sessions with their arguments and results for the replay command, an existing file is replaced")
+	rootCmd.Flags().String("metrics-addr", "", "Listen address of a separate HTTP server exposing the Prometheus metrics on /metrics e.g. :9464, the http protocol always serves /metrics")
+	rootCmd.Flags().String("otlp-endpoint", "", "OTLP/HTTP endpoint e.g. http://localhost:4318 receiving the tool call spans, the spans continue the traceparent of the MCP client requests")
+	rootCmd.Flags().String("service-name", "otel-mcp-server", "Service name of the exported spans")
+	rootCmd.Flags().Duration("artifact-ttl", 30*time.Minute, "How long large tool results are kept as downloadable MCP resources")
+	rootCmd.Flags().Duration("session-ttl", 24*time.Hour, "How long the pinned version, validation profile and snapshots of a session without tool calls are kept, 0 keeps them")
+	rootCmd.Flags().Int("max-input-size", collectorschema.DefaultInputLimits.MaxSize, "Maximum size in bytes of a tool argument e.g. a collector configuration, 0 disables the limit")
+	rootCmd.Flags().Int("max-input-depth", collectorschema.DefaultInputLimits.MaxDepth, "Maximum nesting depth of the maps and lists of a tool argument, 0 disables the limit")
+	rootCmd.Flags().Int("max-input-map-keys", collectorschema.DefaultInputLimits.MaxMappingEntries, "Maximum number of keys of a map in a tool argument, 0 disables the limit")
+	rootCmd.Flags().Int("max-alias-expansion", collectorschema.DefaultInputLimits.MaxAliasExpansion, "Maximum number of nodes the YAML aliases of a tool argument expand to, 0 disables the limit")
+	rootCmd.Flags().Bool("offline", false, "Disable the network features for air-gapped environments: the GitHub, live config, advisory and registry refresh tools and the README translation return an error instead of calling the network")
+	rootCmd.Flags().Duration("http-timeout", 0, "Timeout of the HTTP requests of the network features including the retries, 0 keeps the timeout of each feature. Proxies are read from HTTP_PROXY, HTTPS_PROXY and NO_PROXY.")
+	rootCmd.Flags().Int("http-retries", httpclient.DefaultSettings.Retries, "Retries of the GET requests of the network features failing with a network error or a 502, 503 or 504 status")
+	rootCmd.Flags().Duration("parse-timeout", collectorschema.DefaultInputLimits.ParseTimeout, "Maximum duration of parsing a tool argument, 0 disables the limit")
+}
+
+// filesystemFlags are the server flags reading or writing the local disk, they are rejected with --no-filesystem. A new
+// flag using the disk has to be added here.
+var filesystemFlags = []string{"schemas-dir", "rag-index", "search-log", "snapshot-dir", "storage-dir", "otelcol-binary", "cache-dir", "record", "policy-pack"}
+
+// requiredFlags are the flags that have no effect without another flag
+var requiredFlags = map[string]string{
+	"translation-api-key": "translation-url",
+	"github-token":        "enable-github",
+	"advisories-url":      "enable-advisories",
+	"registry-url":        "enable-registry",
+	"rag-api-key":         "rag-index",
+}
+
+// validateFlags rejects the conflicting flags before the server starts
+func validateFlags(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	if noFilesystem, _ := flags.GetBool("no-filesystem"); noFilesystem {
+		var conflicting []string
+		for _, name := range filesystemFlags {
+			if flags.Changed(name) {
+				conflicting = append(conflicting, "--"+name)
+			}
+		}
+		if len(conflicting) > 0 {
+			return fmt.Errorf("--no-filesystem conflicts with %s, the flags read or write the local disk", strings.Join(conflicting, ", "))
+		}
+	}
+	names := make([]string, 0, len(requiredFlags))
+	for name := range requiredFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if flags.Changed(name) && !flags.Changed(requiredFlags[name]) {
+			return fmt.Errorf("--%s requires --%s", name, requiredFlags[name])
+		}
+	}
+	return nil
 }
 
 func runServer(cmd *cobra.Command, _ []string) error {
+	if err := validateFlags(cmd); err != nil {
+		return err
+	}
+	if noFilesystem, _ := cmd.Flags().GetBool("no-filesystem"); noFilesystem {
+		log.Println("Filesystem access is disabled, the embedded schemas are served and the state is kept in memory")
+	}
 	protocol, _ := cmd.Flags().GetString("protocol")
 	addr, _ := cmd.Flags().GetString("addr")
+	metricsAddr, _ := cmd.Flags().GetString("metrics-addr")
 
-	// Create a new MCP server
-	s := server.NewMCPServer(
-		"otel-mcp-server",
-		"1.0.0",
-		server.WithToolCapabilities(true),
-		server.WithRecovery(),
-	)
-
-	// Get all tools from the tools package
-	allTools, err := tools.GetAllTools()
+	opts, err := optionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if opts.Offline {
+		log.Println("Network access is disabled, the network features return an error")
+	}
+	s, err := mcpserver.New(opts)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.Shutdown(ctx); err != nil {
+			log.Printf("failed to shut down the server: %v", err)
+		}
+	}()
 
-	// Register all tools with the server
-	for _, tool := range allTools {
-		s.AddTool(tool.Tool, tool.Handler)
+	if metricsAddr != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", s.MetricsHandler())
+		metricsMux.Handle("/readyz", s.ReadyHandler())
+		go func() {
+			log.Printf("Serving metrics on http at %s/metrics...", metricsAddr)
+			if err := http.ListenAndServe(metricsAddr, metricsMux); err != nil {
+				log.Printf("metrics server failed: %v", err)
+			}
+		}()
 	}
 
 	// Handle different protocols
 	switch protocol {
 	case "stdio":
 		log.Println("Starting MCP server on stdio...")
-		return server.ServeStdio(s)
+		return server.ServeStdio(s.MCPServer)
 	case "http":
 		log.Printf("Starting MCP server on http at %s...", addr)
 		mux := http.NewServeMux()
-		httpServer := server.NewStreamableHTTPServer(s)
-		mux.Handle("/mcp", httpServer)
+		mux.Handle("/mcp", s.HTTPHandler())
+		mux.Handle("/metrics", s.MetricsHandler())
+		mux.Handle("/readyz", s.ReadyHandler())
 
 		return http.ListenAndServe(addr, mux)
 	default:
@@ -62,6 +171,112 @@ func runServer(cmd *cobra.Command, _ []string) error {
 	}
 }
 
+// optionsFromFlags returns the options of the server configured by the flags
+func optionsFromFlags(cmd *cobra.Command) (mcpserver.Options, error) {
+	flags := cmd.Flags()
+	opts := mcpserver.DefaultOptions()
+	schemaManager, err := newSchemaManager(cmd)
+	if err != nil {
+		return opts, err
+	}
+	opts.SchemaManager = schemaManager
+	opts.Storage, err = newStorage(cmd)
+	if err != nil {
+		return opts, err
+	}
+	opts.CacheDir, _ = flags.GetString("cache-dir")
+	opts.CacheSize, _ = flags.GetInt64("cache-size")
+	opts.ArtifactTTL, _ = flags.GetDuration("artifact-ttl")
+	opts.SessionTTL, _ = flags.GetDuration("session-ttl")
+	opts.InputLimits = inputLimitsFromFlags(cmd)
+	opts.NotFoundCacheTTL, _ = flags.GetDuration("not-found-cache-ttl")
+	ragKeywordWeight, _ := flags.GetFloat64("rag-keyword-weight")
+	if ragKeywordWeight < 0 || ragKeywordWeight > 1 {
+		return opts, fmt.Errorf("rag-keyword-weight must be between 0 and 1: %v", ragKeywordWeight)
+	}
+	opts.SearchWeights = collectorschema.SearchWeights{
+		Vector:         float32(1 - ragKeywordWeight),
+		Keyword:        float32(ragKeywordWeight),
+		ComponentBoost: collectorschema.DefaultSearchWeights.ComponentBoost,
+	}
+	opts.Offline, _ = flags.GetBool("offline")
+	opts.HTTPTimeout, _ = flags.GetDuration("http-timeout")
+	opts.HTTPRetries, _ = flags.GetInt("http-retries")
+	opts.TranslationURL, _ = flags.GetString("translation-url")
+	opts.TranslationAPIKey, _ = flags.GetString("translation-api-key")
+	opts.RAGIndex, _ = flags.GetString("rag-index")
+	opts.RAGAPIKey, _ = flags.GetString("rag-api-key")
+	precache, _ := flags.GetString("precache")
+	opts.Precache, err = collectorschema.ParsePrecacheEntries(precache)
+	if err != nil {
+		return opts, err
+	}
+	opts.PrewarmRAG, _ = flags.GetBool("prewarm-rag")
+	opts.SearchLog, _ = flags.GetString("search-log")
+	opts.Record, _ = flags.GetString("record")
+	opts.GitHub, _ = flags.GetBool("enable-github")
+	opts.GitHubToken, _ = flags.GetString("github-token")
+	opts.Advisories, _ = flags.GetBool("enable-advisories")
+	opts.AdvisoriesURL, _ = flags.GetString("advisories-url")
+	opts.Registry, _ = flags.GetBool("enable-registry")
+	opts.RegistryURL, _ = flags.GetString("registry-url")
+	opts.OtelcolBinary, _ = flags.GetString("otelcol-binary")
+	opts.LiveConfigEndpoints, _ = flags.GetStringSlice("live-config-endpoint")
+	opts.PolicyPacks, _ = flags.GetStringSlice("policy-pack")
+	opts.OTLPEndpoint, _ = flags.GetString("otlp-endpoint")
+	opts.ServiceName, _ = flags.GetString("service-name")
+	return opts, nil
+}
+
+// inputLimitsFromFlags returns the limits of the tool arguments
+func inputLimitsFromFlags(cmd *cobra.Command) collectorschema.InputLimits {
+	var limits collectorschema.InputLimits
+	limits.MaxSize, _ = cmd.Flags().GetInt("max-input-size")
+	limits.MaxDepth, _ = cmd.Flags().GetInt("max-input-depth")
+	limits.MaxMappingEntries, _ = cmd.Flags().GetInt("max-input-map-keys")
+	limits.MaxAliasExpansion, _ = cmd.Flags().GetInt("max-alias-expansion")
+	limits.ParseTimeout, _ = cmd.Flags().GetDuration("parse-timeout")
+	return limits
+}
+
+// newSchemaManager returns the schema manager of the schemas directory of a custom distribution if one is configured,
+// otherwise of the embedded schemas
+func newSchemaManager(cmd *cobra.Command) (*collectorschema.SchemaManager, error) {
+	schemasDir, _ := cmd.Flags().GetString("schemas-dir")
+	if schemasDir == "" {
+		return collectorschema.NewSchemaManager(), nil
+	}
+	return collectorschema.NewSchemaManagerFromDir(schemasDir)
+}
+
+// newCache creates the cache shared by the features in the --cache-dir directory, or in memory if it is not set
+func newCache(cmd *cobra.Command) (*cache.Cache, error) {
+	dir, _ := cmd.Flags().GetString("cache-dir")
+	maxSize, _ := cmd.Flags().GetInt64("cache-size")
+	return cache.New(dir, maxSize)
+}
+
+// newStorage creates the storage selected by --storage. The deprecated --snapshot-dir selects the disk storage in its
+// directory.
+func newStorage(cmd *cobra.Command) (storage.Store, error) {
+	kind, _ := cmd.Flags().GetString("storage")
+	dir, _ := cmd.Flags().GetString("storage-dir")
+	if snapshotDir, _ := cmd.Flags().GetString("snapshot-dir"); snapshotDir != "" && !cmd.Flags().Changed("storage") && dir == "" {
+		kind, dir = storage.KindDisk, snapshotDir
+	}
+	state, err := storage.New(kind, dir)
+	if err != nil {
+		return nil, err
+	}
+	if kind == storage.KindDisk {
+		log.Printf("Storing the session state in %s", dir)
+		if cacheDir, _ := cmd.Flags().GetString("cache-dir"); cacheDir == "" {
+			log.Println("The artifact content is kept in the memory cache, set --cache-dir to keep the artifacts across restarts")
+		}
+	}
+	return state, nil
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
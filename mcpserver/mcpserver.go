@@ -0,0 +1,378 @@
+// Package mcpserver builds the OpenTelemetry MCP server for embedding in a Go process. The returned server embeds the
+// configured mcp-go *server.MCPServer, it can be bound to any transport supported by mcp-go e.g. stdio, streamable
+// http, an in-process client or a custom transport, the binary of this repository is one of its users.
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/artifacts"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/cache"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/dryrun"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/github"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/httpclient"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/liveconfig"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/metrics"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/policy"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/provenance"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/recording"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/registry"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/searchlog"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/snapshots"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/storage"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/tools"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/tracing"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/translation"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/warmup"
+)
+
+// Store keeps the session preferences, the config snapshots and the artifact metadata by namespace and key, servers
+// sharing a disk store share the session state
+type Store = storage.Store
+
+// NewMemoryStore returns a store keeping the session state in memory
+func NewMemoryStore() Store {
+	return storage.NewMemory()
+}
+
+// NewDiskStore returns a store keeping the session state in the directory across restarts
+func NewDiskStore(dir string) (Store, error) {
+	return storage.NewDisk(dir)
+}
+
+// Options configure the server, the zero value of a limit disables it. DefaultOptions returns the options of the binary
+// started without flags.
+type Options struct {
+	// SchemaManager serves the collector schemas, the embedded schemas are served if nil
+	SchemaManager *collectorschema.SchemaManager
+	// Storage keeps the session state, it is kept in memory if nil
+	Storage Store
+	// CacheDir is the directory of the cache shared by the GitHub responses, the embeddings and the artifacts, the
+	// cache is kept in memory if empty
+	CacheDir string
+	// CacheSize is the maximum size in bytes of the cached content
+	CacheSize int64
+	// ArtifactTTL is how long large tool results are kept as downloadable MCP resources
+	ArtifactTTL time.Duration
+	// SessionTTL is how long the state of a session without tool calls is kept, 0 keeps it until the server stops
+	SessionTTL time.Duration
+	// InputLimits limit the tool arguments
+	InputLimits collectorschema.InputLimits
+	// NotFoundCacheTTL is how long the lookups of components missing in a version are cached
+	NotFoundCacheTTL time.Duration
+	// SearchWeights weight the keyword matching and the vector similarity of the documentation search
+	SearchWeights collectorschema.SearchWeights
+
+	// Offline disables the network features, they return an error instead of calling the network
+	Offline bool
+	// HTTPTimeout is the timeout of the HTTP requests of the network features, 0 keeps the timeout of each feature
+	HTTPTimeout time.Duration
+	// HTTPRetries are the retries of the failing GET requests of the network features
+	HTTPRetries int
+
+	// TranslationURL is a LibreTranslate compatible /translate endpoint translating the READMEs
+	TranslationURL    string
+	TranslationAPIKey string
+	// RAGIndex is the directory of a precomputed documentation index, the documents are indexed on the first search if
+	// empty. RAGAPIKey defaults to the OPENAI_API_KEY environment variable.
+	RAGIndex  string
+	RAGAPIKey string
+	// Precache are the component schemas loaded in the background at startup
+	Precache []collectorschema.PrecacheEntry
+	// PrewarmRAG builds the documentation search index in the background at startup
+	PrewarmRAG bool
+	// SearchLog is a JSON lines file recording the documentation search queries and their feedback
+	SearchLog string
+	// Record is a JSON lines file recording the tool calls for the replay command, an existing file is replaced
+	Record string
+
+	// GitHub enables the tool fetching live component READMEs and issues, the token raises the rate limit
+	GitHub      bool
+	GitHubToken string
+	// Advisories enables the security advisory tool, the URL refreshes the embedded advisories
+	Advisories    bool
+	AdvisoriesURL string
+	// Registry enables the registry search tool, the URL refreshes the embedded registry snapshot
+	Registry    bool
+	RegistryURL string
+	// OtelcolBinary enables the dry-run validation tool, {version} in the path is the validated collector version
+	OtelcolBinary string
+	// LiveConfigEndpoints enable the live config tool reading the effective configuration of running collectors
+	LiveConfigEndpoints []string
+	// PolicyPacks are the policy pack YAML files whose rules the lint checks of the config check tool enforce
+	PolicyPacks []string
+
+	// OTLPEndpoint is the OTLP/HTTP endpoint receiving the tool call spans
+	OTLPEndpoint string
+	// ServiceName is the service name of the exported spans
+	ServiceName string
+}
+
+// DefaultOptions returns the options of the binary started without flags
+func DefaultOptions() Options {
+	return Options{
+		CacheSize:        cache.DefaultMaxSize,
+		ArtifactTTL:      30 * time.Minute,
+		SessionTTL:       24 * time.Hour,
+		InputLimits:      collectorschema.DefaultInputLimits,
+		NotFoundCacheTTL: collectorschema.DefaultNotFoundCacheTTL,
+		SearchWeights:    collectorschema.DefaultSearchWeights,
+		HTTPRetries:      httpclient.DefaultRetries,
+		ServiceName:      "otel-mcp-server",
+	}
+}
+
+// Server is the configured MCP server with the metrics and the readiness of the background warm-up
+type Server struct {
+	*server.MCPServer
+
+	inputLimits    collectorschema.InputLimits
+	metrics        *metrics.Metrics
+	warm           *warmup.Warmup
+	cache          *cache.Cache
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// New returns the server serving the tools and the artifact, snapshot and README image resources configured by the
+// options
+func New(opts Options) (*Server, error) {
+	httpSettings := httpclient.DefaultSettings
+	httpSettings.Offline = opts.Offline
+	httpSettings.Timeout = opts.HTTPTimeout
+	httpSettings.Retries = opts.HTTPRetries
+
+	schemaManager := opts.SchemaManager
+	if schemaManager == nil {
+		schemaManager = collectorschema.NewSchemaManager()
+	}
+	versions, err := schemaManager.GetAllVersions()
+	if err != nil {
+		return nil, err
+	}
+	serverMetrics := metrics.New(versions)
+	schemaManager.SetObserver(serverMetrics)
+	contentCache, err := cache.New(opts.CacheDir, opts.CacheSize)
+	if err != nil {
+		return nil, err
+	}
+	contentCache.SetObserver(serverMetrics)
+	schemaManager.SetContentCache(contentCache)
+	schemaManager.SetInputLimits(opts.InputLimits)
+	schemaManager.SetNotFoundCacheTTL(opts.NotFoundCacheTTL)
+	schemaManager.SetHTTPClient(httpSettings.Client(30 * time.Second))
+	if opts.TranslationURL != "" {
+		schemaManager.SetTranslator(translation.NewLibreTranslate(opts.TranslationURL, opts.TranslationAPIKey, httpSettings.Client(60*time.Second)))
+	}
+	if opts.RAGIndex != "" {
+		ragAPIKey := opts.RAGAPIKey
+		if ragAPIKey == "" {
+			ragAPIKey = os.Getenv("OPENAI_API_KEY")
+		}
+		info, err := schemaManager.LoadRAGIndex(opts.RAGIndex, ragAPIKey)
+		if err != nil {
+			return nil, err
+		}
+		// The embeddings API client is created by the vector database, it is not covered by the offline transport
+		if httpSettings.Offline && info.Embedding.Provider == collectorschema.EmbeddingProviderOpenAI {
+			return nil, fmt.Errorf("offline conflicts with the documentation index %s, the index embeds the search queries with the %s embeddings API, build it with the %s provider", opts.RAGIndex, info.Embedding.Provider, collectorschema.EmbeddingProviderSimple)
+		}
+		log.Printf("Loaded documentation index with %d documents of versions %v", info.Documents, info.Versions)
+	}
+	schemaManager.SetSearchWeights(opts.SearchWeights)
+
+	// The caches are warmed in the background, the readiness handler reports ready once the warm-up is done
+	precacheEntries, err := schemaManager.ResolvePrecacheEntries(opts.Precache)
+	if err != nil {
+		return nil, err
+	}
+	warm := warmup.New()
+	if len(precacheEntries) > 0 {
+		warm.Start("precache", func() error {
+			return schemaManager.Precache(precacheEntries)
+		})
+	}
+	if opts.PrewarmRAG {
+		warm.Start("rag", schemaManager.PrewarmRAG)
+	}
+
+	// The session preferences, the snapshots and the artifacts are kept in the storage
+	state := opts.Storage
+	if state == nil {
+		state = storage.NewMemory()
+	}
+	// Large tool results are served as resources from the artifact store
+	artifactStore := artifacts.NewStore(opts.ArtifactTTL, contentCache, state)
+	// Config snapshots are referenced by the tools as snapshot://<name>
+	snapshotStore := snapshots.NewStore(state)
+
+	// Documentation searches and their feedback are recorded only if a search log is configured
+	var searchLog *searchlog.Store
+	if opts.SearchLog != "" {
+		searchLog, err = searchlog.NewStore(opts.SearchLog)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The packs are validated at startup, a server never runs with a part of the policies
+	policyPacks, err := policy.Load(opts.PolicyPacks...)
+	if err != nil {
+		return nil, err
+	}
+
+	allTools, err := tools.GetAllTools(schemaManager, artifactStore, state, searchLog, policyPacks)
+	if err != nil {
+		return nil, err
+	}
+	if opts.GitHub {
+		githubClient := github.NewClient(opts.GitHubToken, contentCache, time.Hour, 10, httpSettings.Client(30*time.Second))
+		allTools = append(allTools, tools.GetGitHubTools(githubClient, artifactStore)...)
+	}
+	if opts.Advisories {
+		allTools = append(allTools, tools.GetAdvisoryTools(schemaManager, opts.AdvisoriesURL)...)
+	}
+	if opts.Registry {
+		otelRegistry, err := registry.NewRegistry()
+		if err != nil {
+			return nil, err
+		}
+		otelRegistry.SetHTTPClient(httpSettings.Client(30 * time.Second))
+		allTools = append(allTools, tools.GetRegistryTools(otelRegistry, opts.RegistryURL)...)
+	}
+	latestCollectorVersion, err := schemaManager.GetLatestVersion()
+	if err != nil {
+		return nil, err
+	}
+	if opts.OtelcolBinary != "" {
+		allTools = append(allTools, tools.GetDryRunTools(dryrun.NewValidator(opts.OtelcolBinary, time.Minute), latestCollectorVersion)...)
+	}
+	if len(opts.LiveConfigEndpoints) > 0 {
+		allTools = append(allTools, tools.GetLiveConfigTools(liveconfig.NewFetcher(opts.LiveConfigEndpoints, httpSettings.Client(30*time.Second)), snapshotStore)...)
+	}
+
+	allTools, err = serveTools(allTools, schemaManager, state, snapshotStore, opts.InputLimits, latestCollectorVersion)
+	if err != nil {
+		return nil, err
+	}
+	// The clients do not reliably end their sessions, the state of the sessions without tool calls expires
+	if opts.SessionTTL > 0 {
+		allTools = tools.WithSessionExpiry(allTools, storage.NewSessionExpiry(state, opts.SessionTTL))
+	}
+	allTools = tools.WithMetrics(allTools, serverMetrics)
+	// The calls are recorded with the arguments sent by the client, the replay passes them through the same wrappers
+	if opts.Record != "" {
+		recorder, err := recording.NewRecorder(opts.Record)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Recording the tool calls to %s", opts.Record)
+		allTools = tools.WithRecording(allTools, recorder)
+	}
+	var tracerProvider *sdktrace.TracerProvider
+	if opts.OTLPEndpoint != "" {
+		tracerProvider, err = tracing.NewTracerProvider(opts.OTLPEndpoint, opts.ServiceName)
+		if err != nil {
+			return nil, err
+		}
+		allTools = tools.WithTracing(allTools, tracerProvider.Tracer(tracing.ScopeName))
+	}
+
+	return &Server{
+		MCPServer:      newMCPServer(allTools, schemaManager, artifactStore, snapshotStore),
+		inputLimits:    opts.InputLimits,
+		metrics:        serverMetrics,
+		warm:           warm,
+		cache:          contentCache,
+		tracerProvider: tracerProvider,
+	}, nil
+}
+
+// HTTPHandler returns the streamable http handler of the server, the traceparent header of the MCP requests is the
+// parent of the tool call spans and the size of the requests is limited by the input limits
+func (s *Server) HTTPHandler() http.Handler {
+	handler := http.Handler(server.NewStreamableHTTPServer(s.MCPServer, server.WithHTTPContextFunc(tracing.HTTPContextFunc)))
+	if s.inputLimits.MaxSize <= 0 {
+		return handler
+	}
+	// A tool call can carry several arguments up to the input size limit, escaped as JSON strings
+	return http.MaxBytesHandler(handler, 4*int64(s.inputLimits.MaxSize))
+}
+
+// MetricsHandler returns the handler serving the Prometheus metrics of the server
+func (s *Server) MetricsHandler() http.Handler {
+	return s.metrics.Handler()
+}
+
+// ReadyHandler returns the handler reporting whether the background warm-up of the caches is done
+func (s *Server) ReadyHandler() http.Handler {
+	return s.warm.Handler()
+}
+
+// Shutdown writes the pending cache index and exports the remaining tool call spans
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.cache.Close()
+	if s.tracerProvider != nil {
+		err = errors.Join(err, s.tracerProvider.Shutdown(ctx))
+	}
+	return err
+}
+
+// serveTools adds the snapshot, version pin and capabilities tools to the tools and wraps them with the questions, the
+// input limits, the snapshot references, the version pinning and the argument coercion
+func serveTools(allTools []tools.Tool, schemaManager *collectorschema.SchemaManager, state storage.Store, snapshotStore *snapshots.Store, inputLimits collectorschema.InputLimits, latestCollectorVersion string) ([]tools.Tool, error) {
+	allTools = append(allTools, tools.GetSnapshotTools(snapshotStore)...)
+	versionPins := tools.NewVersionPins(state)
+	allTools = append(allTools, tools.GetVersionPinTools(schemaManager, versionPins)...)
+	capabilities, err := tools.GetCapabilitiesTool(allTools)
+	if err != nil {
+		return nil, err
+	}
+	allTools = append(allTools, capabilities)
+	// The generation tools ask for the missing arguments of the coerced, pinned and resolved call
+	allTools = tools.WithQuestions(allTools, inputLimits)
+	// The limits check the configurations of the resolved snapshot references as well
+	allTools = tools.WithInputLimits(allTools, inputLimits)
+	allTools = tools.WithSnapshotReferences(allTools, snapshotStore)
+	// The version omitted by a call is the version pinned by the session, it reads the version after the coercion
+	allTools = tools.WithVersionPinning(allTools, versionPins, latestCollectorVersion)
+	// The arguments are converted to the schema types first, e.g. a configuration passed as an object is a string
+	// for the snapshot references and the limits
+	allTools = tools.WithArgumentCoercion(allTools)
+	return allTools, nil
+}
+
+// newMCPServer returns the MCP server serving the tools and the artifact, snapshot and README image resources
+func newMCPServer(allTools []tools.Tool, schemaManager *collectorschema.SchemaManager, artifactStore *artifacts.Store, snapshotStore *snapshots.Store) *server.MCPServer {
+	s := server.NewMCPServer(
+		provenance.Generator,
+		provenance.GeneratorVersion,
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, false),
+		server.WithElicitation(),
+		server.WithRecovery(),
+	)
+
+	artifactTemplate := tools.GetArtifactResourceTemplate(artifactStore)
+	s.AddResourceTemplate(artifactTemplate.Template, artifactTemplate.Handler)
+	snapshotTemplate := tools.GetSnapshotResourceTemplate(snapshotStore)
+	s.AddResourceTemplate(snapshotTemplate.Template, snapshotTemplate.Handler)
+	readmeAssetTemplate := tools.GetReadmeAssetResourceTemplate(schemaManager)
+	s.AddResourceTemplate(readmeAssetTemplate.Template, readmeAssetTemplate.Handler)
+
+	// Register all tools with the server
+	for _, tool := range allTools {
+		s.AddTool(tool.Tool, tool.Handler)
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package mcpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInProcessClient(t *testing.T, s *Server) *client.Client {
+	t.Helper()
+	c, err := client.NewInProcessClient(s.MCPServer)
+	require.NoError(t, err)
+	require.NoError(t, c.Start(context.Background()))
+	t.Cleanup(func() { _ = c.Close() })
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{Name: "embedding-test", Version: "1.0.0"}
+	_, err = c.Initialize(context.Background(), request)
+	require.NoError(t, err)
+	return c
+}
+
+func toolNames(t *testing.T, c *client.Client) []string {
+	t.Helper()
+	listed, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range listed.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestNew_InProcess(t *testing.T) {
+	s, err := New(DefaultOptions())
+	require.NoError(t, err)
+	c := newInProcessClient(t, s)
+
+	names := toolNames(t, c)
+	assert.Contains(t, names, "opentelemetry-collector-get-versions")
+	assert.Contains(t, names, "opentelemetry-mcp-capabilities")
+	assert.NotContains(t, names, "opentelemetry-collector-github-component")
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "opentelemetry-collector-get-versions"
+	result, err := c.CallTool(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func TestNew_Options(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Storage = NewMemoryStore()
+	opts.GitHub = true
+	opts.Offline = true
+	s, err := New(opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, toolNames(t, newInProcessClient(t, s)), "opentelemetry-collector-github-component")
+
+	opts = DefaultOptions()
+	opts.RAGIndex = t.TempDir()
+	_, err = New(opts)
+	assert.Error(t, err)
+}
+
+func TestServer_HTTPHandler(t *testing.T) {
+	opts := DefaultOptions()
+	opts.InputLimits.MaxSize = 16
+	s, err := New(opts)
+	require.NoError(t, err)
+
+	// The requests are limited to four times the input size limit
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping","params":{"padding":"`+strings.Repeat("x", 64)+`"}}`))
+	request.Header.Set("Content-Type", "application/json")
+	s.HTTPHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	s.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	recorder = httptest.NewRecorder()
+	s.ReadyHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NoError(t, s.Shutdown(context.Background()))
+}
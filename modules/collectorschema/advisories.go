@@ -0,0 +1,174 @@
+package collectorschema
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed advisories.yaml
+var embeddedAdvisories []byte
+
+// maxAdvisoriesSize is the maximum size of a downloaded advisory database
+const maxAdvisoriesSize = 10 << 20
+
+// Advisory represents a security advisory affecting collector components
+type Advisory struct {
+	ID         string   `yaml:"id" json:"id"`
+	Aliases    []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	Summary    string   `yaml:"summary" json:"summary"`
+	Severity   string   `yaml:"severity" json:"severity"`
+	URL        string   `yaml:"url" json:"url"`
+	Components []string `yaml:"components" json:"components"`
+	Introduced string   `yaml:"introduced" json:"introduced"`
+	Fixed      string   `yaml:"fixed,omitempty" json:"fixed,omitempty"`
+}
+
+type advisoryDatabase struct {
+	Advisories []Advisory `yaml:"advisories"`
+}
+
+// Affects returns true if the collector version is in the affected version range
+func (a Advisory) Affects(version string) bool {
+	if a.Introduced != "" && CompareVersions(version, a.Introduced) < 0 {
+		return false
+	}
+	return a.Fixed == "" || CompareVersions(version, a.Fixed) < 0
+}
+
+// AffectsComponent returns true if the advisory affects the component e.g. receiver/otlp
+func (a Advisory) AffectsComponent(componentType ComponentType, componentName string) bool {
+	for _, component := range a.Components {
+		if component == string(componentType)+"/"+componentName {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAdvisories parses an advisory database YAML
+func ParseAdvisories(data []byte) ([]Advisory, error) {
+	var database advisoryDatabase
+	if err := yaml.Unmarshal(data, &database); err != nil {
+		return nil, fmt.Errorf("failed to parse advisories: %w", err)
+	}
+	for _, advisory := range database.Advisories {
+		if advisory.ID == "" {
+			return nil, fmt.Errorf("advisory ID must be set")
+		}
+		if len(advisory.Components) == 0 {
+			return nil, fmt.Errorf("advisory %s must list affected components", advisory.ID)
+		}
+		for _, version := range []string{advisory.Introduced, advisory.Fixed} {
+			if version == "" {
+				continue
+			}
+			if err := ValidateVersion(version); err != nil {
+				return nil, fmt.Errorf("advisory %s: %w", advisory.ID, err)
+			}
+		}
+	}
+	return database.Advisories, nil
+}
+
+// GetAdvisories returns the advisories affecting the collector version
+func (sm *SchemaManager) GetAdvisories(version string) ([]Advisory, error) {
+	if err := ValidateVersion(version); err != nil {
+		return nil, err
+	}
+	sm.advisoriesMutex.Lock()
+	defer sm.advisoriesMutex.Unlock()
+
+	if sm.advisories == nil {
+		advisories, err := ParseAdvisories(embeddedAdvisories)
+		if err != nil {
+			return nil, err
+		}
+		sm.advisories = advisories
+	}
+
+	var affecting []Advisory
+	for _, advisory := range sm.advisories {
+		if advisory.Affects(version) {
+			affecting = append(affecting, advisory)
+		}
+	}
+	return affecting, nil
+}
+
+// RefreshAdvisories replaces the embedded advisory database with the one downloaded from the URL
+func (sm *SchemaManager) RefreshAdvisories(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create advisories request: %w", err)
+	}
+	resp, err := sm.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download advisories: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download advisories: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAdvisoriesSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read advisories: %w", err)
+	}
+	if len(data) > maxAdvisoriesSize {
+		return fmt.Errorf("advisories exceed the maximum size of %d bytes", maxAdvisoriesSize)
+	}
+	advisories, err := ParseAdvisories(data)
+	if err != nil {
+		return err
+	}
+
+	sm.advisoriesMutex.Lock()
+	sm.advisories = advisories
+	sm.advisoriesMutex.Unlock()
+	return nil
+}
+
+// ValidateVersion returns an error if the version is not a collector version of dot separated numbers e.g. 0.138.0 or
+// v0.138.0
+func ValidateVersion(version string) error {
+	for _, part := range strings.Split(strings.TrimPrefix(version, "v"), ".") {
+		if number, err := strconv.Atoi(part); err != nil || number < 0 {
+			return fmt.Errorf("invalid version %q, it must be dot separated numbers e.g. 0.138.0", version)
+		}
+	}
+	return nil
+}
+
+// CompareVersions compares two collector versions e.g. 0.102.1 and 0.138.0 and returns -1, 0 or 1. The versions are
+// expected to be valid, see ValidateVersion, a part that is not a number compares as 0.
+func CompareVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numB, _ = strconv.Atoi(partsB[i])
+		}
+		if numA != numB {
+			if numA < numB {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
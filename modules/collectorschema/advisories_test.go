@@ -0,0 +1,89 @@
+package collectorschema
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, CompareVersions("0.99.0", "0.102.1"))
+	assert.Equal(t, 0, CompareVersions("v0.102.1", "0.102.1"))
+	assert.Equal(t, 1, CompareVersions("0.138.0", "0.102.1"))
+	assert.Equal(t, -1, CompareVersions("0.102", "0.102.1"))
+}
+
+func TestValidateVersion(t *testing.T) {
+	assert.NoError(t, ValidateVersion("0.138.0"))
+	assert.NoError(t, ValidateVersion("v0.138.0"))
+	for _, version := range []string{"", "latest", "0.138.x", "0..1", "0.-1.0", "0.138.0-rc1"} {
+		assert.Error(t, ValidateVersion(version), version)
+	}
+}
+
+func TestSchemaManager_GetAdvisories(t *testing.T) {
+	sm := NewSchemaManager()
+
+	advisories, err := sm.GetAdvisories("0.100.0")
+	require.NoError(t, err)
+	require.NotEmpty(t, advisories)
+	assert.Equal(t, "GHSA-c74f-6mfw-mm4v", advisories[0].ID)
+	assert.True(t, advisories[0].AffectsComponent(ComponentTypeReceiver, "otlp"))
+
+	advisories, err = sm.GetAdvisories("0.102.1")
+	require.NoError(t, err)
+	for _, advisory := range advisories {
+		assert.NotEqual(t, "GHSA-c74f-6mfw-mm4v", advisory.ID)
+	}
+
+	_, err = sm.GetAdvisories("0.x.0")
+	assert.EqualError(t, err, `invalid version "0.x.0", it must be dot separated numbers e.g. 0.138.0`)
+}
+
+func TestParseAdvisories_InvalidVersion(t *testing.T) {
+	_, err := ParseAdvisories([]byte("advisories:\n  - id: GHSA-test\n    components: [processor/batch]\n    fixed: 0.139.x\n"))
+	assert.EqualError(t, err, `advisory GHSA-test: invalid version "0.139.x", it must be dot separated numbers e.g. 0.138.0`)
+}
+
+func TestSchemaManager_RefreshAdvisories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+advisories:
+  - id: GHSA-test
+    summary: test
+    severity: low
+    components: [processor/batch]
+    introduced: 0.130.0
+    fixed: 0.139.0
+`))
+	}))
+	defer server.Close()
+
+	sm := NewSchemaManager()
+	require.NoError(t, sm.RefreshAdvisories(context.Background(), server.URL))
+
+	advisories, err := sm.GetAdvisories("0.138.0")
+	require.NoError(t, err)
+	require.Len(t, advisories, 1)
+	assert.Equal(t, "GHSA-test", advisories[0].ID)
+
+	advisories, err = sm.GetAdvisories("0.139.0")
+	require.NoError(t, err)
+	assert.Empty(t, advisories)
+}
+
+func TestSchemaManager_RefreshAdvisories_TooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("advisories: []\n" + strings.Repeat("#", maxAdvisoriesSize)))
+	}))
+	defer server.Close()
+
+	sm := NewSchemaManager()
+	err := sm.RefreshAdvisories(context.Background(), server.URL)
+	assert.ErrorContains(t, err, "advisories exceed the maximum size")
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// assetsDir is the directory of the README images in the output directory, the images of a component are in
+// assets/<kind>_<name> at the path referenced by its README. It must match the assets directory of the collectorschema
+// package.
+const assetsDir = "assets"
+
+// maxAssetSize is the size of the largest copied image, the schemas are embedded in the MCP server binary
+const maxAssetSize = 1 << 20
+
+var (
+	// markdownImagePattern matches the target of a markdown image e.g. ![Architecture](images/arch.png "title")
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	// htmlImagePattern matches the source of an HTML image e.g. <img src="./images/arch.svg" width="600">
+	htmlImagePattern = regexp.MustCompile(`(?i)<img\s[^>]*src\s*=\s*["']([^"']+)["']`)
+	// imageExtensions are the copied image types
+	imageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp"}
+)
+
+// readmeImages returns the local images referenced by a README as cleaned slash separated paths relative to the
+// README, the remote images, the absolute paths and the paths outside of the module directory are skipped
+func readmeImages(readme string) []string {
+	images := make(map[string]bool)
+	for _, pattern := range []*regexp.Regexp{markdownImagePattern, htmlImagePattern} {
+		for _, match := range pattern.FindAllStringSubmatch(readme, -1) {
+			if image, ok := localImagePath(match[1]); ok {
+				images[image] = true
+			}
+		}
+	}
+	paths := make([]string, 0, len(images))
+	for image := range images {
+		paths = append(paths, image)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// localImagePath returns the cleaned path of a local image reference without its query and fragment
+func localImagePath(reference string) (string, bool) {
+	if strings.Contains(reference, "://") || strings.HasPrefix(reference, "//") || strings.HasPrefix(reference, "/") || strings.HasPrefix(reference, "data:") {
+		return "", false
+	}
+	if i := strings.IndexAny(reference, "?#"); i >= 0 {
+		reference = reference[:i]
+	}
+	cleaned := path.Clean(reference)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	if !slices.Contains(imageExtensions, strings.ToLower(path.Ext(cleaned))) {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// copyReadmeAssets copies the local images referenced by the README of a component in the module directory to
+// assets/<kind>_<name> of the output directory, the missing and too large images are reported as warnings
+func (sg *SchemaGenerator) copyReadmeAssets(moduleDir, base string, readme []byte) error {
+	for _, image := range readmeImages(string(readme)) {
+		src := filepath.Join(moduleDir, filepath.FromSlash(image))
+		info, err := os.Stat(src)
+		if err != nil {
+			fmt.Printf("Warning: image %s referenced by the README of %s not found\n", image, base)
+			continue
+		}
+		if info.Size() > maxAssetSize {
+			fmt.Printf("Warning: image %s referenced by the README of %s is larger than %d bytes, skipping it\n", image, base, maxAssetSize)
+			continue
+		}
+		dst := filepath.Join(sg.outputDir, assetsDir, base, filepath.FromSlash(image))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := sg.copyFile(src, dst); err != nil {
+			return fmt.Errorf("failed to copy image %s of %s: %w", image, base, err)
+		}
+	}
+	return nil
+}
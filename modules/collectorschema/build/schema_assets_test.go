@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestReadmeImages tests the detection of the local images of a README
+func TestReadmeImages(t *testing.T) {
+	readme := `# Component
+
+![Architecture](images/arch.png "The architecture")
+![Flow](<./images/flow.svg>)
+<img src="./images/flow.svg" width="600">
+<IMG alt="pipeline" SRC='docs/pipeline.JPG?raw=true'>
+![Badge](https://img.shields.io/badge/stability-beta-green.svg)
+![Root](/images/root.png)
+![Outside](../images/outside.png)
+![Data](data:image/png;base64,iVBORw0KGgo=)
+[Not an image](images/arch.png)
+![Text](docs/notes.txt)
+`
+	want := []string{"docs/pipeline.JPG", "images/arch.png", "images/flow.svg"}
+	if got := readmeImages(readme); !reflect.DeepEqual(got, want) {
+		t.Errorf("readmeImages() = %v, want %v", got, want)
+	}
+}
+
+// TestCopyReadmeAssets tests that the images of a README are copied to the assets directory of the component
+func TestCopyReadmeAssets(t *testing.T) {
+	moduleDir := t.TempDir()
+	outputDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(moduleDir, "images"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "images", "arch.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "large.png"), make([]byte, maxAssetSize+1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sg := NewSchemaGenerator(outputDir)
+	readme := []byte("![Architecture](images/arch.png)\n![Missing](images/missing.png)\n![Large](large.png)\n")
+	if err := sg.copyReadmeAssets(moduleDir, "receiver_foo", readme); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, assetsDir, "receiver_foo", "images", "arch.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "png" {
+		t.Errorf("copied image = %q, want png", data)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, assetsDir, "receiver_foo", "large.png")); !os.IsNotExist(err) {
+		t.Errorf("the image larger than %d bytes was copied", maxAssetSize)
+	}
+}
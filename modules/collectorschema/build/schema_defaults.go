@@ -0,0 +1,108 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// addDefaults sets the default of the scalar and scalar list properties to the values of the default config
+func (sg *SchemaGenerator) addDefaults(properties map[string]interface{}, value reflect.Value) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous {
+			sg.addDefaults(properties, value.Field(i))
+			continue
+		}
+
+		property, ok := properties[sg.getFieldName(field)].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if nested, ok := property["properties"].(map[string]interface{}); ok {
+			sg.addDefaults(nested, value.Field(i))
+			continue
+		}
+		if defaultValue, ok := defaultValue(value.Field(i)); ok {
+			property["default"] = defaultValue
+		}
+	}
+}
+
+// defaultValue returns the schema default of a config value, only scalars and lists of scalars have a default
+func defaultValue(value reflect.Value) (interface{}, bool) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return formatDuration(time.Duration(value.Int())), true
+	}
+	switch value.Kind() {
+	case reflect.String:
+		// An empty string is the unset value rather than a default
+		return value.String(), value.String() != ""
+	case reflect.Bool:
+		return value.Bool(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.Slice, reflect.Array:
+		if value.Len() == 0 {
+			return nil, false
+		}
+		items := make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, ok := defaultValue(value.Index(i))
+			if !ok {
+				return nil, false
+			}
+			items = append(items, item)
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// formatDuration formats a duration in the largest unit it is a whole multiple of e.g. 5m instead of 5m0s to match
+// the duration pattern of the schema
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	for _, unit := range []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+	} {
+		if d%unit.duration == 0 {
+			return strconv.FormatInt(int64(d/unit.duration), 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns"
+}
@@ -19,7 +19,6 @@ import (
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
-	"gopkg.in/yaml.v3"
 )
 
 // SchemaGenerator generates YAML schemas for OpenTelemetry collector component configurations
@@ -27,6 +26,8 @@ type SchemaGenerator struct {
 	outputDir    string
 	commentCache map[string]map[string]string // packagePath -> typeName.fieldName -> comment
 	fileSetCache map[string]*token.FileSet    // packagePath -> FileSet
+	// translationsDir has the translated field descriptions by locale, no translations are generated if it is empty
+	translationsDir string
 }
 
 // NewSchemaGenerator creates a new schema generator that outputs to the specified directory
@@ -77,6 +78,16 @@ func (sg *SchemaGenerator) GenerateAllSchemas() error {
 		return fmt.Errorf("failed to copy README files: %w", err)
 	}
 
+	// Translated field descriptions are files of the components listed in the manifest
+	if err := sg.generateDescriptionTranslations(); err != nil {
+		return fmt.Errorf("failed to generate translated field descriptions: %w", err)
+	}
+
+	// List the components, their files and signals for the MCP server, it is written after all component files
+	if err := sg.generateManifest(&factories); err != nil {
+		return fmt.Errorf("failed to generate component manifest: %w", err)
+	}
+
 	return nil
 }
 
@@ -84,8 +95,8 @@ func (sg *SchemaGenerator) GenerateAllSchemas() error {
 func (sg *SchemaGenerator) generateExtensionSchemas(factories map[component.Type]extension.Factory) error {
 	fmt.Printf("Generating schemas for %d extensions...\n", len(factories))
 
-	for componentType, factory := range factories {
-		if err := sg.generateSchemaForComponent("extension", componentType, factory); err != nil {
+	for _, componentType := range sortedComponentTypes(factories) {
+		if err := sg.generateSchemaForComponent("extension", componentType, factories[componentType]); err != nil {
 			fmt.Printf("Warning: failed to generate schema for extension %s: %v\n", componentType, err)
 			continue
 		}
@@ -97,8 +108,8 @@ func (sg *SchemaGenerator) generateExtensionSchemas(factories map[component.Type
 func (sg *SchemaGenerator) generateReceiverSchemas(factories map[component.Type]receiver.Factory) error {
 	fmt.Printf("Generating schemas for %d receivers...\n", len(factories))
 
-	for componentType, factory := range factories {
-		if err := sg.generateSchemaForComponent("receiver", componentType, factory); err != nil {
+	for _, componentType := range sortedComponentTypes(factories) {
+		if err := sg.generateSchemaForComponent("receiver", componentType, factories[componentType]); err != nil {
 			fmt.Printf("Warning: failed to generate schema for receiver %s: %v\n", componentType, err)
 			continue
 		}
@@ -110,8 +121,8 @@ func (sg *SchemaGenerator) generateReceiverSchemas(factories map[component.Type]
 func (sg *SchemaGenerator) generateProcessorSchemas(factories map[component.Type]processor.Factory) error {
 	fmt.Printf("Generating schemas for %d processors...\n", len(factories))
 
-	for componentType, factory := range factories {
-		if err := sg.generateSchemaForComponent("processor", componentType, factory); err != nil {
+	for _, componentType := range sortedComponentTypes(factories) {
+		if err := sg.generateSchemaForComponent("processor", componentType, factories[componentType]); err != nil {
 			fmt.Printf("Warning: failed to generate schema for processor %s: %v\n", componentType, err)
 			continue
 		}
@@ -123,8 +134,8 @@ func (sg *SchemaGenerator) generateProcessorSchemas(factories map[component.Type
 func (sg *SchemaGenerator) generateExporterSchemas(factories map[component.Type]exporter.Factory) error {
 	fmt.Printf("Generating schemas for %d exporters...\n", len(factories))
 
-	for componentType, factory := range factories {
-		if err := sg.generateSchemaForComponent("exporter", componentType, factory); err != nil {
+	for _, componentType := range sortedComponentTypes(factories) {
+		if err := sg.generateSchemaForComponent("exporter", componentType, factories[componentType]); err != nil {
 			fmt.Printf("Warning: failed to generate schema for exporter %s: %v\n", componentType, err)
 			continue
 		}
@@ -136,8 +147,8 @@ func (sg *SchemaGenerator) generateExporterSchemas(factories map[component.Type]
 func (sg *SchemaGenerator) generateConnectorSchemas(factories map[component.Type]connector.Factory) error {
 	fmt.Printf("Generating schemas for %d connectors...\n", len(factories))
 
-	for componentType, factory := range factories {
-		if err := sg.generateSchemaForComponent("connector", componentType, factory); err != nil {
+	for _, componentType := range sortedComponentTypes(factories) {
+		if err := sg.generateSchemaForComponent("connector", componentType, factories[componentType]); err != nil {
 			fmt.Printf("Warning: failed to generate schema for connector %s: %v\n", componentType, err)
 			continue
 		}
@@ -193,6 +204,9 @@ func (sg *SchemaGenerator) generateYAMLSchema(config component.Config) (map[stri
 		return nil, err
 	}
 
+	// Record the values of the default config as the property defaults
+	sg.addDefaults(properties, reflect.ValueOf(config))
+
 	return schema, nil
 }
 
@@ -735,12 +749,13 @@ func (sg *SchemaGenerator) isFieldDeprecated(field reflect.StructField, descript
 	return false
 }
 
-// writeSchemaToFile writes a YAML schema to a file
+// writeSchemaToFile writes a YAML schema to a file, the schema is normalized so regenerating it on another machine or
+// Go version writes the same file
 func (sg *SchemaGenerator) writeSchemaToFile(filePath string, schema map[string]interface{}) error {
-	// Pretty print YAML
-	data, err := yaml.Marshal(schema)
+	normalizeSchema(schema)
+	data, err := marshalSchema(schema)
 	if err != nil {
-		return fmt.Errorf("failed to marshal schema to YAML: %w", err)
+		return err
 	}
 
 	// Write to file
@@ -823,6 +838,15 @@ func (sg *SchemaGenerator) copyReadmeForComponent(componentCategory string, comp
 		return fmt.Errorf("failed to copy file from %s to %s: %w", readmePath, destPath, err)
 	}
 
+	// Copy the images of the README e.g. architecture diagrams, only the README would be embedded otherwise
+	readme, err := os.ReadFile(readmePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", readmePath, err)
+	}
+	if err := sg.copyReadmeAssets(filepath.Dir(readmePath), fmt.Sprintf("%s_%s", componentCategory, componentType), readme); err != nil {
+		return err
+	}
+
 	fmt.Printf("Copied README for %s %s -> %s\n", componentCategory, componentType, destFilename)
 	return nil
 }
@@ -21,6 +21,7 @@ func TestGenerateAllSchemas(t *testing.T) {
 
 	// Create schema generator
 	generator := NewSchemaGenerator(schemaOutputDir)
+	generator.translationsDir = os.Getenv("SCHEMA_TRANSLATIONS_DIR")
 
 	// Generate all schemas
 	if err := generator.GenerateAllSchemas(); err != nil {
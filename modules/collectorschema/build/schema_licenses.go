@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	licenseApache2 = "Apache-2.0"
+	licenseUnknown = "unknown"
+)
+
+// licenseFiles are the file names of module licenses in the order they are looked up
+var licenseFiles = []string{"LICENSE", "LICENSE.txt", "LICENSE.md", "LICENCE", "COPYING", "LICENSE-MIT", "LICENSE-APACHE"}
+
+// moduleLicense is the license of a Go module, it must match the ModuleLicense of the collectorschema package
+type moduleLicense struct {
+	Module  string `yaml:"module"`
+	Version string `yaml:"version,omitempty"`
+	License string `yaml:"license"`
+}
+
+// licenseResolver detects the licenses of the modules in the module cache, the licenses are cached by module
+type licenseResolver struct {
+	licenses map[string]string
+}
+
+func newLicenseResolver() *licenseResolver {
+	return &licenseResolver{licenses: make(map[string]string)}
+}
+
+// componentLicenses returns the license of the module of a component and the licenses of the modules it depends on
+// other than Apache-2.0, the license of most collector dependencies
+func (r *licenseResolver) componentLicenses(module string) (string, []moduleLicense, error) {
+	cmd := exec.Command("go", "list", "-deps", "-f", "{{with .Module}}{{.Path}}\t{{.Version}}\t{{.Dir}}{{end}}", module)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("go list failed for module %s: %w", module, err)
+	}
+
+	license := licenseUnknown
+	dependencies := make(map[string]moduleLicense)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 3 || fields[2] == "" {
+			continue
+		}
+		dependency := moduleLicense{Module: fields[0], Version: fields[1], License: r.license(fields[2])}
+		if dependency.Module == module {
+			license = dependency.License
+			continue
+		}
+		if dependency.License != licenseApache2 {
+			dependencies[dependency.Module] = dependency
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+
+	result := make([]moduleLicense, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		result = append(result, dependency)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Module < result[j].Module })
+	return license, result, nil
+}
+
+// license returns the license of the module in the directory
+func (r *licenseResolver) license(dir string) string {
+	if license, ok := r.licenses[dir]; ok {
+		return license
+	}
+	license := licenseUnknown
+	for _, name := range licenseFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			license = classifyLicense(string(data))
+			break
+		}
+	}
+	r.licenses[dir] = license
+	return license
+}
+
+// classifyLicense returns the SPDX identifier of a license text, it recognizes the licenses common in Go modules
+func classifyLicense(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	switch {
+	case strings.Contains(text, "Apache License") && strings.Contains(text, "Version 2.0"):
+		return licenseApache2
+	case strings.Contains(text, "Mozilla Public License Version 2.0") || strings.Contains(text, "Mozilla Public License, version 2.0"):
+		return "MPL-2.0"
+	case strings.Contains(text, "GNU LESSER GENERAL PUBLIC LICENSE"):
+		return "LGPL"
+	case strings.Contains(text, "GNU GENERAL PUBLIC LICENSE"):
+		return "GPL"
+	case strings.Contains(text, "Permission is hereby granted, free of charge"):
+		return "MIT"
+	case strings.Contains(text, "Permission to use, copy, modify, and/or distribute this software"):
+		return "ISC"
+	case strings.Contains(text, "Redistribution and use in source and binary forms"):
+		if strings.Contains(text, "Neither the name") || strings.Contains(text, "names of its contributors") {
+			return "BSD-3-Clause"
+		}
+		return "BSD-2-Clause"
+	case strings.Contains(text, "This is free and unencumbered software released into the public domain"):
+		return "Unlicense"
+	}
+	return licenseUnknown
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+// TestClassifyLicense tests the detection of the licenses common in Go modules
+func TestClassifyLicense(t *testing.T) {
+	tests := map[string]string{
+		"\n                                 Apache License\n                           Version 2.0, January 2004\n": "Apache-2.0",
+		"MIT License\n\nPermission is hereby granted, free of charge, to any person obtaining a copy":               "MIT",
+		"Redistribution and use in source and binary forms, with or without\nmodification. Neither the name of":     "BSD-3-Clause",
+		"Redistribution and use in source and binary forms, with or without modification":                           "BSD-2-Clause",
+		"Mozilla Public License Version 2.0\n==================================":                                    "MPL-2.0",
+		"All rights reserved.": "unknown",
+	}
+	for text, want := range tests {
+		if got := classifyLicense(text); got != want {
+			t.Errorf("classifyLicense(%q) = %s, want %s", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestFile is the manifest of the components of a version, it must match the manifest of the collectorschema
+// package
+const manifestFile = "components.yaml"
+
+// manifestComponent is a component of the manifest with its files in the output directory
+type manifestComponent struct {
+	Type    string   `yaml:"type"`
+	Name    string   `yaml:"name"`
+	Files   []string `yaml:"files"`
+	Signals []string `yaml:"signals,omitempty"`
+	// Module is the Go module providing the component and ModuleVersion its version e.g. v0.139.0
+	Module        string `yaml:"module,omitempty"`
+	ModuleVersion string `yaml:"module_version,omitempty"`
+	// License is the license of the module and DependencyLicenses the licenses of its dependencies other than Apache-2.0
+	License            string          `yaml:"license,omitempty"`
+	DependencyLicenses []moduleLicense `yaml:"dependency_licenses,omitempty"`
+	// Status are the stability and code owners of the component from its metadata.yaml
+	Status *componentStatus `yaml:"status,omitempty"`
+}
+
+// componentManifest lists the components of a version, the MCP server reads it instead of parsing the file names
+type componentManifest struct {
+	Components []manifestComponent `yaml:"components"`
+}
+
+// signalsStability are the stability levels of the signals of a component, undefined signals are not supported
+type signalsStability struct {
+	traces  component.StabilityLevel
+	metrics component.StabilityLevel
+	logs    component.StabilityLevel
+}
+
+func (s signalsStability) signals() []string {
+	var signals []string
+	if s.traces != component.StabilityLevelUndefined {
+		signals = append(signals, "traces")
+	}
+	if s.metrics != component.StabilityLevelUndefined {
+		signals = append(signals, "metrics")
+	}
+	if s.logs != component.StabilityLevelUndefined {
+		signals = append(signals, "logs")
+	}
+	return signals
+}
+
+// generateManifest writes the manifest of the components with a schema in the output directory
+func (sg *SchemaGenerator) generateManifest(factories *otelcol.Factories) error {
+	signals := make(map[string][]string)
+	for componentType, factory := range factories.Receivers {
+		signals["receiver_"+componentType.String()] = signalsStability{factory.TracesStability(), factory.MetricsStability(), factory.LogsStability()}.signals()
+	}
+	for componentType, factory := range factories.Processors {
+		signals["processor_"+componentType.String()] = signalsStability{factory.TracesStability(), factory.MetricsStability(), factory.LogsStability()}.signals()
+	}
+	for componentType, factory := range factories.Exporters {
+		signals["exporter_"+componentType.String()] = signalsStability{factory.TracesStability(), factory.MetricsStability(), factory.LogsStability()}.signals()
+	}
+	for componentType, factory := range factories.Connectors {
+		// A connector supports a signal it consumes or produces
+		signals["connector_"+componentType.String()] = signalsStability{
+			traces:  maxStability(factory.TracesToTracesStability(), factory.TracesToMetricsStability(), factory.TracesToLogsStability(), factory.MetricsToTracesStability(), factory.LogsToTracesStability()),
+			metrics: maxStability(factory.MetricsToMetricsStability(), factory.MetricsToTracesStability(), factory.MetricsToLogsStability(), factory.TracesToMetricsStability(), factory.LogsToMetricsStability()),
+			logs:    maxStability(factory.LogsToLogsStability(), factory.LogsToTracesStability(), factory.LogsToMetricsStability(), factory.TracesToLogsStability(), factory.MetricsToLogsStability()),
+		}.signals()
+	}
+
+	// The modules are "<module path> <version>" as in the builder manifest gomod entries
+	modules := make(map[string]string)
+	for category, categoryModules := range map[string]map[component.Type]string{
+		"extension": factories.ExtensionModules,
+		"receiver":  factories.ReceiverModules,
+		"processor": factories.ProcessorModules,
+		"exporter":  factories.ExporterModules,
+		"connector": factories.ConnectorModules,
+	} {
+		for componentType, module := range categoryModules {
+			modules[category+"_"+componentType.String()] = module
+		}
+	}
+
+	schemaFiles, err := filepath.Glob(filepath.Join(sg.outputDir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	licenses := newLicenseResolver()
+	var manifest componentManifest
+	for _, schemaFile := range schemaFiles {
+		base := strings.TrimSuffix(filepath.Base(schemaFile), ".yaml")
+		category, name, ok := strings.Cut(base, "_")
+		if !ok || base == strings.TrimSuffix(manifestFile, ".yaml") {
+			continue
+		}
+		files, err := filepath.Glob(filepath.Join(sg.outputDir, base+".*"))
+		if err != nil {
+			return err
+		}
+		entry := manifestComponent{Type: category, Name: name, Signals: signals[base]}
+		if module := strings.Fields(modules[base]); len(module) > 0 {
+			entry.Module = module[0]
+			if len(module) > 1 {
+				entry.ModuleVersion = module[1]
+			}
+			entry.License, entry.DependencyLicenses, err = licenses.componentLicenses(entry.Module)
+			if err != nil {
+				fmt.Printf("Warning: failed to detect licenses of %s %s: %v\n", category, name, err)
+			}
+			entry.Status, err = sg.componentStatus(entry.Module)
+			if err != nil {
+				fmt.Printf("Warning: failed to read status of %s %s: %v\n", category, name, err)
+			}
+		}
+		for _, file := range files {
+			entry.Files = append(entry.Files, filepath.Base(file))
+		}
+		sort.Strings(entry.Files)
+		manifest.Components = append(manifest.Components, entry)
+	}
+	sort.Slice(manifest.Components, func(i, j int) bool {
+		if manifest.Components[i].Type != manifest.Components[j].Type {
+			return manifest.Components[i].Type < manifest.Components[j].Type
+		}
+		return manifest.Components[i].Name < manifest.Components[j].Name
+	})
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal component manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(sg.outputDir, manifestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write component manifest: %w", err)
+	}
+	fmt.Printf("Generated manifest of %d components\n", len(manifest.Components))
+	return nil
+}
+
+// maxStability returns the highest defined stability level
+func maxStability(levels ...component.StabilityLevel) component.StabilityLevel {
+	result := component.StabilityLevelUndefined
+	for _, level := range levels {
+		if level > result {
+			result = level
+		}
+	}
+	return result
+}
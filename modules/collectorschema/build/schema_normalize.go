@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaKeyOrder is the order of the keywords of a schema in the generated files, other keywords follow in
+// alphabetical order. The properties are sorted by name, so the files do not depend on the struct field order, the
+// map iteration order or the platform the generator runs on.
+var schemaKeyOrder = []string{
+	"$schema",
+	"type",
+	"pattern",
+	"format",
+	"description",
+	"deprecated",
+	"default",
+	"properties",
+	"items",
+	"additionalProperties",
+}
+
+// normalizeSchema removes the values of a schema that depend on the host the generator runs on, the defaults set
+// from e.g. the hostname or the temporary directory would differ between the machines regenerating the schemas
+func normalizeSchema(schema map[string]interface{}) {
+	hostValues := hostDependentValues()
+	var normalize func(schema map[string]interface{})
+	normalize = func(schema map[string]interface{}) {
+		if defaultValue, ok := schema["default"].(string); ok && isHostDependent(defaultValue, hostValues) {
+			delete(schema, "default")
+		}
+		for _, key := range []string{"items", "additionalProperties"} {
+			if nested, ok := schema[key].(map[string]interface{}); ok {
+				normalize(nested)
+			}
+		}
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			for _, property := range properties {
+				if nested, ok := property.(map[string]interface{}); ok {
+					normalize(nested)
+				}
+			}
+		}
+	}
+	normalize(schema)
+}
+
+// hostDependentValues returns the hostname and the directories of the host the generator runs on
+func hostDependentValues() []string {
+	var values []string
+	if hostname, err := os.Hostname(); err == nil {
+		values = append(values, hostname)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		values = append(values, home)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		values = append(values, wd)
+	}
+	values = append(values, filepath.Clean(os.TempDir()))
+	return values
+}
+
+// isHostDependent returns true if a default is a host value, a path under a host directory or a hostname:port
+func isHostDependent(value string, hostValues []string) bool {
+	for _, hostValue := range hostValues {
+		// The root directory would match every absolute path
+		if hostValue == "" || hostValue == string(filepath.Separator) {
+			continue
+		}
+		if value == hostValue || strings.HasPrefix(value, hostValue+string(filepath.Separator)) || strings.HasPrefix(value, hostValue+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalSchema returns the YAML of a schema with the keywords in the schemaKeyOrder and the properties sorted by name
+func marshalSchema(schema map[string]interface{}) ([]byte, error) {
+	node, err := schemaNode(schema)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to marshal schema to YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal schema to YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// schemaNode returns the YAML node of a schema, the nested schemas of properties, items and additionalProperties are
+// ordered as well
+func schemaNode(schema map[string]interface{}) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range orderedSchemaKeys(schema) {
+		var valueNode *yaml.Node
+		var err error
+		switch value := schema[key].(type) {
+		case map[string]interface{}:
+			if key == "properties" {
+				valueNode, err = propertiesNode(value)
+			} else {
+				valueNode, err = schemaNode(value)
+			}
+		default:
+			valueNode = &yaml.Node{}
+			err = valueNode.Encode(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode schema keyword %s: %w", key, err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode)
+	}
+	return node, nil
+}
+
+// propertiesNode returns the YAML node of the properties of a schema sorted by name
+func propertiesNode(properties map[string]interface{}) (*yaml.Node, error) {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, name := range names {
+		var valueNode *yaml.Node
+		var err error
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			valueNode, err = schemaNode(property)
+		} else {
+			valueNode = &yaml.Node{}
+			err = valueNode.Encode(properties[name])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode property %s: %w", name, err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, valueNode)
+	}
+	return node, nil
+}
+
+// orderedSchemaKeys returns the keywords of a schema in the schemaKeyOrder followed by the other keywords sorted
+func orderedSchemaKeys(schema map[string]interface{}) []string {
+	keys := make([]string, 0, len(schema))
+	for _, key := range schemaKeyOrder {
+		if _, ok := schema[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	var others []string
+	for key := range schema {
+		if !slices.Contains(schemaKeyOrder, key) {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	return append(keys, others...)
+}
+
+// sortedComponentTypes returns the component types of the factories in alphabetical order, the components are
+// generated in a stable order so the output and the warnings of two runs can be compared
+func sortedComponentTypes[F any](factories map[component.Type]F) []component.Type {
+	types := make([]component.Type, 0, len(factories))
+	for componentType := range factories {
+		types = append(types, componentType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+	return types
+}
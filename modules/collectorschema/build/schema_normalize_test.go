@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMarshalSchema(t *testing.T) {
+	schema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"timeout":  map[string]interface{}{"default": "5s", "pattern": "^[0-9]+(ns|us|µs|ms|s|m|h)$", "type": "string"},
+			"endpoint": map[string]interface{}{"type": "string"},
+		},
+		"type":    "object",
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+	}
+	data, err := marshalSchema(schema)
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+	expected := `$schema: https://json-schema.org/draft/2020-12/schema
+type: object
+properties:
+  endpoint:
+    type: string
+  timeout:
+    type: string
+    pattern: ^[0-9]+(ns|us|µs|ms|s|m|h)$
+    default: 5s
+`
+	if string(data) != expected {
+		t.Errorf("unexpected schema YAML:\n%s\nexpected:\n%s", data, expected)
+	}
+}
+
+func TestNormalizeSchema(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("no hostname: %v", err)
+	}
+	schema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"endpoint":  map[string]interface{}{"type": "string", "default": hostname + ":4317"},
+			"directory": map[string]interface{}{"type": "string", "default": filepath.Join(os.TempDir(), "otelcol")},
+			"protocol":  map[string]interface{}{"type": "string", "default": "tcp"},
+		},
+	}
+	normalizeSchema(schema)
+	properties := schema["properties"].(map[string]interface{})
+	for _, name := range []string{"endpoint", "directory"} {
+		if _, ok := properties[name].(map[string]interface{})["default"]; ok {
+			t.Errorf("the host dependent default of %s is not removed", name)
+		}
+	}
+	if properties["protocol"].(map[string]interface{})["default"] != "tcp" {
+		t.Errorf("the default of protocol is removed")
+	}
+}
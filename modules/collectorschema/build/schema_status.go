@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// componentStatus is the status of a component from its metadata.yaml, it must match the ComponentStatus of the
+// collectorschema package
+type componentStatus struct {
+	Stability        map[string][]string `yaml:"stability,omitempty"`
+	Distributions    []string            `yaml:"distributions,omitempty"`
+	Codeowners       []string            `yaml:"codeowners,omitempty"`
+	Emeritus         []string            `yaml:"emeritus,omitempty"`
+	SeekingNewOwners bool                `yaml:"seeking_new_owners,omitempty"`
+}
+
+// metadataFile is the mdatagen metadata of a component, the contrib CODEOWNERS file is generated from its code owners
+type metadataFile struct {
+	Status struct {
+		Stability     map[string][]string `yaml:"stability"`
+		Distributions []string            `yaml:"distributions"`
+		Codeowners    struct {
+			Active     []string `yaml:"active"`
+			Emeritus   []string `yaml:"emeritus"`
+			SeekingNew bool     `yaml:"seeking_new"`
+		} `yaml:"codeowners"`
+	} `yaml:"status"`
+}
+
+// componentStatus reads the status of the metadata.yaml in the package directory of a component module, it returns
+// nil if the component has no metadata.yaml
+func (sg *SchemaGenerator) componentStatus(module string) (*componentStatus, error) {
+	dir, err := sg.findPackageWithGoList(module)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "metadata.yaml"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata metadataFile
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata.yaml of %s: %w", module, err)
+	}
+	return &componentStatus{
+		Stability:        metadata.Status.Stability,
+		Distributions:    metadata.Status.Distributions,
+		Codeowners:       metadata.Status.Codeowners.Active,
+		Emeritus:         metadata.Status.Codeowners.Emeritus,
+		SeekingNewOwners: metadata.Status.Codeowners.SeekingNew,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// descriptionsSuffix is the suffix of the translated field descriptions written next to the schemas, it must match the
+// suffix of the collectorschema package: receiver_otlp.de.descriptions.json
+const descriptionsSuffix = ".descriptions.json"
+
+// generateDescriptionTranslations writes the translated field descriptions of the translations directory next to the
+// schemas. The directory has a directory per locale e.g. de or pt-BR with a <kind>_<name>.yaml file per component
+// mapping the dotted field paths to their translated description. Translations of fields without a description in
+// the generated schema are dropped, so stale translations of renamed or removed fields do not reach the bundle.
+func (sg *SchemaGenerator) generateDescriptionTranslations() error {
+	if sg.translationsDir == "" {
+		return nil
+	}
+	localeDirs, err := os.ReadDir(sg.translationsDir)
+	if err != nil {
+		return fmt.Errorf("failed to read translations directory: %w", err)
+	}
+	generated := 0
+	for _, localeDir := range localeDirs {
+		if !localeDir.IsDir() {
+			continue
+		}
+		// The locale is normalized like the locale argument of the server e.g. pt_BR -> pt-br
+		locale := strings.ReplaceAll(strings.ToLower(localeDir.Name()), "_", "-")
+		files, err := filepath.Glob(filepath.Join(sg.translationsDir, localeDir.Name(), "*.yaml"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			base := strings.TrimSuffix(filepath.Base(file), ".yaml")
+			written, err := sg.writeDescriptionTranslations(file, base, locale)
+			if err != nil {
+				return err
+			}
+			if written {
+				generated++
+			}
+		}
+	}
+	fmt.Printf("Generated %d translated field descriptions\n", generated)
+	return nil
+}
+
+// writeDescriptionTranslations writes the translations of the file for the component schema <base>.yaml, it returns
+// false if the component has no schema or no translated field
+func (sg *SchemaGenerator) writeDescriptionTranslations(file, base, locale string) (bool, error) {
+	schemaData, err := os.ReadFile(filepath.Join(sg.outputDir, base+".yaml"))
+	if err != nil {
+		fmt.Printf("Warning: translations %s have no schema %s.yaml, skipping them\n", file, base)
+		return false, nil
+	}
+	var schema map[string]interface{}
+	if err := yaml.Unmarshal(schemaData, &schema); err != nil {
+		return false, fmt.Errorf("failed to parse schema %s.yaml: %w", base, err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read translations %s: %w", file, err)
+	}
+	var translations map[string]string
+	if err := yaml.Unmarshal(data, &translations); err != nil {
+		return false, fmt.Errorf("failed to parse translations %s: %w", file, err)
+	}
+
+	descriptions := make(map[string]string)
+	collectDescriptions(schema, "", descriptions)
+	var stale []string
+	for fieldPath, description := range translations {
+		if descriptions[fieldPath] == "" || description == "" {
+			stale = append(stale, fieldPath)
+			delete(translations, fieldPath)
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		fmt.Printf("Warning: %s translates fields without a description in %s.yaml: %s\n", file, base, strings.Join(stale, ", "))
+	}
+	if len(translations) == 0 {
+		return false, nil
+	}
+
+	output, err := json.MarshalIndent(translations, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal translations %s: %w", file, err)
+	}
+	if err := os.WriteFile(filepath.Join(sg.outputDir, base+"."+locale+descriptionsSuffix), output, 0644); err != nil {
+		return false, fmt.Errorf("failed to write translations of %s: %w", base, err)
+	}
+	return true, nil
+}
+
+// collectDescriptions records the descriptions of the fields of a schema and its nested properties by dotted path, the
+// fields of array items share the path of the array
+func collectDescriptions(schema map[string]interface{}, path string, descriptions map[string]string) {
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		collectDescriptions(items, path, descriptions)
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name, property := range properties {
+		fieldSchema, ok := property.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		if description, ok := fieldSchema["description"].(string); ok {
+			descriptions[fieldPath] = description
+		}
+		collectDescriptions(fieldSchema, fieldPath, descriptions)
+	}
+}
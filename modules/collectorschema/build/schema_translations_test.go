@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestGenerateDescriptionTranslations tests that the translations of the fields of a schema are written next to it
+func TestGenerateDescriptionTranslations(t *testing.T) {
+	outputDir := t.TempDir()
+	translationsDir := t.TempDir()
+	files := map[string]string{
+		filepath.Join(outputDir, "receiver_otlp.yaml"): `type: object
+properties:
+  endpoint:
+    type: string
+    description: The address to listen on
+  timeout:
+    type: string
+`,
+		filepath.Join(translationsDir, "pt_BR", "receiver_otlp.yaml"): `endpoint: O endereço de escuta
+timeout: O tempo limite
+`,
+		filepath.Join(translationsDir, "pt_BR", "receiver_removed.yaml"): "endpoint: O endereço de escuta\n",
+	}
+	for file, content := range files {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sg := NewSchemaGenerator(outputDir)
+	sg.translationsDir = translationsDir
+	if err := sg.generateDescriptionTranslations(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "receiver_otlp.pt-br.descriptions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var translations map[string]string
+	if err := json.Unmarshal(data, &translations); err != nil {
+		t.Fatal(err)
+	}
+	// The timeout has no description to translate
+	if want := map[string]string{"endpoint": "O endereço de escuta"}; !reflect.DeepEqual(translations, want) {
+		t.Errorf("translations = %v, want %v", translations, want)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "receiver_removed.pt-br.descriptions.json")); !os.IsNotExist(err) {
+		t.Errorf("translations of a component without a schema were written: %v", err)
+	}
+}
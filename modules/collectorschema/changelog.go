@@ -0,0 +1,115 @@
+package collectorschema
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// ChangelogSource is the repository a changelog is published by
+type ChangelogSource string
+
+const (
+	// ChangelogSourceCore is the changelog of open-telemetry/opentelemetry-collector e.g. confmap, service telemetry
+	ChangelogSourceCore ChangelogSource = "core"
+	// ChangelogSourceContrib is the changelog of open-telemetry/opentelemetry-collector-contrib
+	ChangelogSourceContrib ChangelogSource = "contrib"
+	// ChangelogSourceAll is the core and contrib changelogs merged
+	ChangelogSourceAll ChangelogSource = "all"
+)
+
+// changelogSources are the changelog sources in the order of the merged changelog
+var changelogSources = []ChangelogSource{ChangelogSourceCore, ChangelogSourceContrib}
+
+// changelogFileNames are the changelog files of a version by source, changelog.md is the single changelog of versions
+// generated before core and contrib were stored separately
+var changelogFileNames = map[ChangelogSource]string{
+	ChangelogSourceCore:    "changelog-core.md",
+	ChangelogSourceContrib: "changelog-contrib.md",
+	ChangelogSourceAll:     "changelog.md",
+}
+
+// changelogTitles are the headings of the sources in the merged changelog
+var changelogTitles = map[ChangelogSource]string{
+	ChangelogSourceCore:    "# opentelemetry-collector",
+	ChangelogSourceContrib: "# opentelemetry-collector-contrib",
+}
+
+// ChangelogSourceNames returns the names of the changelog sources
+func ChangelogSourceNames() []string {
+	return []string{string(ChangelogSourceAll), string(ChangelogSourceCore), string(ChangelogSourceContrib)}
+}
+
+// ParseChangelogSource parses a changelog source, an empty source is the merged changelog
+func ParseChangelogSource(source string) (ChangelogSource, error) {
+	switch ChangelogSource(strings.ToLower(strings.TrimSpace(source))) {
+	case "", ChangelogSourceAll:
+		return ChangelogSourceAll, nil
+	case ChangelogSourceCore:
+		return ChangelogSourceCore, nil
+	case ChangelogSourceContrib:
+		return ChangelogSourceContrib, nil
+	}
+	return "", fmt.Errorf("unknown changelog source %q, must be one of %s", source, strings.Join(ChangelogSourceNames(), ", "))
+}
+
+// changelogFile is a changelog file of a version
+type changelogFile struct {
+	Source  ChangelogSource
+	Path    string
+	Content string
+}
+
+// changelogFiles returns the core and contrib changelogs of a version, or the single changelog of versions without
+// separate changelogs
+func (sm *SchemaManager) changelogFiles(version string) []changelogFile {
+	schemaPath := fmt.Sprintf("schemas/%s", version)
+	var files []changelogFile
+	for _, source := range changelogSources {
+		filePath := filepath.Join(schemaPath, changelogFileNames[source])
+		if data, err := fs.ReadFile(sm.schemas, filePath); err == nil {
+			files = append(files, changelogFile{Source: source, Path: filePath, Content: string(data)})
+		}
+	}
+	if len(files) > 0 {
+		return files
+	}
+	filePath := filepath.Join(schemaPath, changelogFileNames[ChangelogSourceAll])
+	if data, err := fs.ReadFile(sm.schemas, filePath); err == nil {
+		files = append(files, changelogFile{Source: ChangelogSourceAll, Path: filePath, Content: string(data)})
+	}
+	return files
+}
+
+// GetChangelog returns the changelog content for a specific collector version, the core and contrib changelogs are
+// merged
+func (sm *SchemaManager) GetChangelog(version string) (string, error) {
+	return sm.GetSourceChangelog(version, ChangelogSourceAll)
+}
+
+// GetSourceChangelog returns the core, contrib or merged changelog of a collector version. Every source of the merged
+// changelog starts with the heading of its repository.
+func (sm *SchemaManager) GetSourceChangelog(version string, source ChangelogSource) (string, error) {
+	files := sm.changelogFiles(version)
+	if len(files) == 0 {
+		return "", fmt.Errorf("changelog not found for version %s", version)
+	}
+
+	if source == ChangelogSourceAll {
+		if len(files) == 1 && files[0].Source == ChangelogSourceAll {
+			return files[0].Content, nil
+		}
+		sections := make([]string, 0, len(files))
+		for _, file := range files {
+			sections = append(sections, changelogTitles[file.Source]+"\n\n"+strings.TrimSpace(file.Content)+"\n")
+		}
+		return strings.Join(sections, "\n"), nil
+	}
+	for _, file := range files {
+		if file.Source == source {
+			return file.Content, nil
+		}
+	}
+	return "", fmt.Errorf("%s changelog not found for version %s", source, version)
+}
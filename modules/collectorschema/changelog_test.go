@@ -0,0 +1,79 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCoreChangelog    = "## v1.0.0/v0.1.0\n\n### 🛑 Breaking changes 🛑\n\n- `confmap`: Remove the deprecated `expandconverter` (#100)\n"
+	testContribChangelog = "## v0.1.0\n\n### 🚩 Deprecations 🚩\n\n- `exporter/kafka`: Deprecate `topic` (#200)\n"
+)
+
+func TestSchemaManager_GetSourceChangelog(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/0.1.0/changelog-core.md":    {Data: []byte(testCoreChangelog)},
+		"schemas/0.1.0/changelog-contrib.md": {Data: []byte(testContribChangelog)},
+		"schemas/0.2.0/changelog-contrib.md": {Data: []byte(testContribChangelog)},
+		"schemas/0.3.0/changelog.md":         {Data: []byte("# changelog\n")},
+	})
+
+	core, err := sm.GetSourceChangelog("0.1.0", ChangelogSourceCore)
+	require.NoError(t, err)
+	assert.Equal(t, testCoreChangelog, core)
+	contrib, err := sm.GetSourceChangelog("0.1.0", ChangelogSourceContrib)
+	require.NoError(t, err)
+	assert.Equal(t, testContribChangelog, contrib)
+
+	merged, err := sm.GetChangelog("0.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "# opentelemetry-collector\n\n"+testCoreChangelog+"\n# opentelemetry-collector-contrib\n\n"+testContribChangelog, merged)
+
+	// A version released without a core changelog
+	_, err = sm.GetSourceChangelog("0.2.0", ChangelogSourceCore)
+	assert.EqualError(t, err, "core changelog not found for version 0.2.0")
+	merged, err = sm.GetChangelog("0.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "# opentelemetry-collector-contrib\n\n"+testContribChangelog, merged)
+
+	// The single changelog of versions generated before core and contrib were stored separately
+	merged, err = sm.GetChangelog("0.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, "# changelog\n", merged)
+	_, err = sm.GetSourceChangelog("0.3.0", ChangelogSourceContrib)
+	assert.Error(t, err)
+
+	_, err = sm.GetSourceChangelog("0.4.0", ChangelogSourceAll)
+	assert.EqualError(t, err, "changelog not found for version 0.4.0")
+}
+
+func TestSchemaManager_GetBreakingChanges_Core(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/0.1.0/changelog-core.md":    {Data: []byte(testCoreChangelog)},
+		"schemas/0.1.0/changelog-contrib.md": {Data: []byte(testContribChangelog)},
+	})
+
+	changes, err := sm.GetBreakingChanges("0.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"`confmap`: Remove the deprecated `expandconverter` (#100)"}, changes)
+
+	docs := sm.changelogDocuments("0.1.0")
+	require.Len(t, docs, 2)
+	assert.Equal(t, "core", docs[0].Metadata["source"])
+	assert.Equal(t, "schemas/0.1.0/changelog-core.md", docs[0].Metadata["file_path"])
+	assert.Equal(t, "contrib", docs[1].Metadata["source"])
+	assert.Equal(t, "kafka", docs[1].Metadata["component_name"])
+}
+
+func TestParseChangelogSource(t *testing.T) {
+	for input, expected := range map[string]ChangelogSource{"": ChangelogSourceAll, "all": ChangelogSourceAll, "Core": ChangelogSourceCore, "contrib": ChangelogSourceContrib} {
+		source, err := ParseChangelogSource(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, source)
+	}
+	_, err := ParseChangelogSource("releases")
+	assert.EqualError(t, err, `unknown changelog source "releases", must be one of all, core, contrib`)
+}
@@ -0,0 +1,174 @@
+package collectorschema
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed cloud_credentials.yaml
+var embeddedCloudCredentials []byte
+
+// CloudExporter are the credential requirements of a cloud exporter e.g. awsxray
+type CloudExporter struct {
+	Name    string   `yaml:"name" json:"name"`
+	Cloud   string   `yaml:"cloud" json:"cloud"`
+	Signals []string `yaml:"signals" json:"signals"`
+	// Permissions are the IAM actions the exporter calls
+	Permissions []string `yaml:"permissions" json:"permissions,omitempty"`
+	// Roles are the managed policies or predefined roles granting the permissions
+	Roles    []string       `yaml:"roles" json:"roles,omitempty"`
+	Location *CloudLocation `yaml:"location" json:"location,omitempty"`
+	// Auth are the supported auth methods, the first one is the default
+	Auth []string `yaml:"auth" json:"auth"`
+}
+
+// CloudLocation is the exporter field selecting the region or project the telemetry is sent to
+type CloudLocation struct {
+	Field       string `yaml:"field" json:"field"`
+	Env         string `yaml:"env" json:"env"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// CloudAuthMethod is a way to provide the credentials of a cloud exporter e.g. EKS IAM roles for service accounts
+type CloudAuthMethod struct {
+	Name        string        `yaml:"name" json:"name"`
+	Cloud       string        `yaml:"cloud" json:"cloud"`
+	Description string        `yaml:"description" json:"description"`
+	Env         []CloudEnvVar `yaml:"env" json:"env,omitempty"`
+	// Inputs are the inputs replacing the {name} placeholders of the config
+	Inputs []string `yaml:"inputs" json:"inputs,omitempty"`
+	// Config are the exporter fields of the auth method
+	Config   map[string]interface{} `yaml:"config" json:"config,omitempty"`
+	Setup    []string               `yaml:"setup" json:"setup,omitempty"`
+	Warnings []string               `yaml:"warnings" json:"warnings,omitempty"`
+}
+
+// CloudEnvVar is an environment variable of the collector read by an auth method
+type CloudEnvVar struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// CloudCredentialSetup is the credential related configuration of a cloud exporter with the setup it needs outside of
+// the collector
+type CloudCredentialSetup struct {
+	Exporter    CloudExporter          `json:"exporter"`
+	Auth        CloudAuthMethod        `json:"auth"`
+	Config      map[string]interface{} `json:"config"`
+	Env         []CloudEnvVar          `json:"env,omitempty"`
+	Setup       []string               `json:"setup"`
+	Warnings    []string               `json:"warnings,omitempty"`
+	AuthMethods []string               `json:"authMethods"`
+}
+
+type cloudCredentialDatabase struct {
+	Exporters   []CloudExporter   `yaml:"exporters"`
+	AuthMethods []CloudAuthMethod `yaml:"auth_methods"`
+}
+
+var cloudCredentials = sync.OnceValues(func() (*cloudCredentialDatabase, error) {
+	var database cloudCredentialDatabase
+	if err := yaml.Unmarshal(embeddedCloudCredentials, &database); err != nil {
+		return nil, fmt.Errorf("failed to parse cloud credentials: %w", err)
+	}
+	return &database, nil
+})
+
+// CloudExporterNames returns the names of the cloud exporters with curated credential setups
+func CloudExporterNames() []string {
+	database, err := cloudCredentials()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(database.Exporters))
+	for _, exporter := range database.Exporters {
+		names = append(names, exporter.Name)
+	}
+	return names
+}
+
+// GetCloudCredentialSetup returns the credential configuration of a cloud exporter for an auth method, the default auth
+// method of the exporter if it is empty. The location is the region or project, the inputs replace the placeholders of
+// the auth method config e.g. role_arn.
+func GetCloudCredentialSetup(exporterName, authName, location string, inputs map[string]string) (*CloudCredentialSetup, error) {
+	database, err := cloudCredentials()
+	if err != nil {
+		return nil, err
+	}
+	var exporter *CloudExporter
+	for i := range database.Exporters {
+		if database.Exporters[i].Name == exporterName {
+			exporter = &database.Exporters[i]
+		}
+	}
+	if exporter == nil {
+		return nil, fmt.Errorf("unsupported exporter %q, must be %s", exporterName, strings.Join(CloudExporterNames(), ", "))
+	}
+	if authName == "" {
+		authName = exporter.Auth[0]
+	}
+	if !slices.Contains(exporter.Auth, authName) {
+		return nil, fmt.Errorf("unsupported auth %q for %s, must be %s", authName, exporterName, strings.Join(exporter.Auth, ", "))
+	}
+	var auth *CloudAuthMethod
+	for i := range database.AuthMethods {
+		if database.AuthMethods[i].Name == authName && database.AuthMethods[i].Cloud == exporter.Cloud {
+			auth = &database.AuthMethods[i]
+		}
+	}
+	if auth == nil {
+		return nil, fmt.Errorf("auth %s of %s is not described", authName, exporterName)
+	}
+	for _, input := range auth.Inputs {
+		if inputs[input] == "" {
+			return nil, fmt.Errorf("%s must be set for the %s auth", input, authName)
+		}
+	}
+
+	setup := &CloudCredentialSetup{
+		Exporter:    *exporter,
+		Auth:        *auth,
+		Config:      map[string]interface{}{},
+		Env:         auth.Env,
+		Setup:       auth.Setup,
+		Warnings:    slices.Clone(auth.Warnings),
+		AuthMethods: exporter.Auth,
+	}
+	if auth.Config != nil {
+		setup.Config = expandCloudConfig(auth.Config, inputs).(map[string]interface{})
+	}
+	if exporter.Location != nil {
+		if location != "" {
+			setup.Config[exporter.Location.Field] = location
+		} else {
+			setup.Warnings = append(setup.Warnings, fmt.Sprintf("%s is not set, it is %s, set it or the %s environment variable outside of the cloud", exporter.Location.Field, exporter.Location.Description, exporter.Location.Env))
+		}
+	}
+	if len(exporter.Permissions) > 0 {
+		setup.Setup = append([]string{fmt.Sprintf("grant %s to the credentials, %s includes them", strings.Join(exporter.Permissions, ", "), strings.Join(exporter.Roles, ", "))}, setup.Setup...)
+	}
+	return setup, nil
+}
+
+// expandCloudConfig returns a copy of the auth method config with the {name} placeholders replaced by the inputs
+func expandCloudConfig(value interface{}, inputs map[string]string) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			expanded[key] = expandCloudConfig(item, inputs)
+		}
+		return expanded
+	case string:
+		for name, input := range inputs {
+			value = strings.ReplaceAll(value, "{"+name+"}", input)
+		}
+		return value
+	}
+	return value
+}
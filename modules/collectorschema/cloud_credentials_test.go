@@ -0,0 +1,56 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCloudCredentialSetup(t *testing.T) {
+	setup, err := GetCloudCredentialSetup("awsxray", "", "us-east-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "irsa", setup.Auth.Name)
+	assert.Equal(t, map[string]interface{}{"region": "us-east-1"}, setup.Config)
+	assert.Contains(t, setup.Setup[0], "xray:PutTraceSegments")
+	assert.Equal(t, []string{"AWS_ROLE_ARN", "AWS_WEB_IDENTITY_TOKEN_FILE"}, []string{setup.Env[0].Name, setup.Env[1].Name})
+
+	setup, err = GetCloudCredentialSetup("awsemf", "assume_role", "", map[string]string{"role_arn": "arn:aws:iam::123456789012:role/otel"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/otel"}, setup.Config)
+	assert.Contains(t, setup.Warnings[0], "region is not set")
+
+	setup, err = GetCloudCredentialSetup("googlecloud", "impersonation", "my-project", map[string]string{"service_account": "otel@my-project.iam.gserviceaccount.com"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"project":     "my-project",
+		"impersonate": map[string]interface{}{"target_principal": "otel@my-project.iam.gserviceaccount.com"},
+	}, setup.Config)
+
+	setup, err = GetCloudCredentialSetup("azuremonitor", "", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"connection_string": "${env:APPLICATIONINSIGHTS_CONNECTION_STRING}"}, setup.Config)
+	assert.Empty(t, setup.Exporter.Permissions)
+}
+
+func TestGetCloudCredentialSetup_Invalid(t *testing.T) {
+	_, err := GetCloudCredentialSetup("datadog", "", "", nil)
+	assert.EqualError(t, err, `unsupported exporter "datadog", must be awsxray, awsemf, googlecloud, azuremonitor`)
+	_, err = GetCloudCredentialSetup("awsxray", "workload_identity", "", nil)
+	assert.EqualError(t, err, `unsupported auth "workload_identity" for awsxray, must be irsa, pod_identity, instance_profile, assume_role, env`)
+	_, err = GetCloudCredentialSetup("awsxray", "assume_role", "", nil)
+	assert.EqualError(t, err, "role_arn must be set for the assume_role auth")
+}
+
+// TestCloudCredentials checks that the auth methods of the exporters are described
+func TestCloudCredentials(t *testing.T) {
+	for _, name := range CloudExporterNames() {
+		setup, err := GetCloudCredentialSetup(name, "", "", nil)
+		require.NoError(t, err)
+		for _, auth := range setup.AuthMethods {
+			inputs := map[string]string{"role_arn": "role", "service_account": "account"}
+			_, err := GetCloudCredentialSetup(name, auth, "", inputs)
+			assert.NoError(t, err, "%s %s", name, auth)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package collectorschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CommonSetting is a block of settings shared by the components e.g. the TLS settings of the clients, its schema
+// fragment is the block of the example in the component schema of a version
+type CommonSetting struct {
+	// Name of the settings e.g. tls_client
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Example is where the block is configured in a component e.g. exporters::otlp::tls, its schema is the schema
+	// fragment of the settings
+	Example string `json:"example"`
+	// Doc is the core documentation page of the settings, Sections are the anchors of its sections documenting them
+	Doc      string   `json:"doc"`
+	Sections []string `json:"sections"`
+	// excluded are the properties of the example block that are not part of the settings
+	excluded []string
+}
+
+// CommonSettingReference is the documentation and the schema fragment of common settings of a version
+type CommonSettingReference struct {
+	CommonSetting
+	Version string `json:"version"`
+	// Path is the directory of the package of the settings in the opentelemetry-collector repository
+	Path          string                 `json:"path"`
+	Documentation string                 `json:"documentation"`
+	Schema        map[string]interface{} `json:"schema"`
+}
+
+// exporterHelperSettings are the exporterhelper settings of the exporters next to their client settings
+var exporterHelperSettings = []string{"timeout", "sending_queue", "retry_on_failure"}
+
+// commonSettings are the settings of the configtls, configgrpc, confighttp, configretry and exporterhelper packages
+var commonSettings = []CommonSetting{
+	{Name: "tls_client", Description: "TLS settings of the gRPC and HTTP clients", Example: "exporters::otlp::tls", Doc: "configtls", Sections: []string{"common-settings", "client-settings"}},
+	{Name: "tls_server", Description: "TLS settings of the gRPC and HTTP servers", Example: "receivers::otlp::protocols::grpc::tls", Doc: "configtls", Sections: []string{"common-settings", "server-settings"}},
+	{Name: "grpc_client", Description: "gRPC client settings of the exporters", Example: "exporters::otlp", Doc: "configgrpc", Sections: []string{"client-settings"}, excluded: exporterHelperSettings},
+	{Name: "grpc_server", Description: "gRPC server settings of the receivers", Example: "receivers::otlp::protocols::grpc", Doc: "configgrpc", Sections: []string{"server-settings"}},
+	{Name: "http_client", Description: "HTTP client settings of the exporters", Example: "exporters::otlphttp", Doc: "confighttp", Sections: []string{"client-settings"}, excluded: exporterHelperSettings},
+	{Name: "http_server", Description: "HTTP server settings of the receivers", Example: "receivers::otlp::protocols::http", Doc: "confighttp", Sections: []string{"server-settings"}},
+	{Name: "retry", Description: "Retry with exponential backoff of the exporters", Example: "exporters::otlp::retry_on_failure", Doc: "exporterhelper", Sections: []string{"retry"}},
+	{Name: "queue", Description: "Sending queue of the exporters", Example: "exporters::otlp::sending_queue", Doc: "exporterhelper", Sections: []string{"sending-queue"}},
+	{Name: "timeout", Description: "Timeout of the export requests", Example: "exporters::otlp::timeout", Doc: "exporterhelper", Sections: []string{"timeout"}},
+}
+
+// CommonSettings returns the settings shared by the components
+func CommonSettings() []CommonSetting {
+	return commonSettings
+}
+
+// CommonSettingNames returns the names of the settings shared by the components
+func CommonSettingNames() []string {
+	names := make([]string, 0, len(commonSettings))
+	for _, setting := range commonSettings {
+		names = append(names, setting.Name)
+	}
+	return names
+}
+
+// GetCommonSetting returns the documentation and the schema fragment of common settings e.g. tls_client
+func (sm *SchemaManager) GetCommonSetting(name string, version string) (*CommonSettingReference, error) {
+	var setting *CommonSetting
+	for i := range commonSettings {
+		if commonSettings[i].Name == name {
+			setting = &commonSettings[i]
+		}
+	}
+	if setting == nil {
+		return nil, fmt.Errorf("unknown common settings %q, it can be %s", name, strings.Join(CommonSettingNames(), ", "))
+	}
+
+	schema, err := sm.commonSettingSchema(setting, version)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := GetCoreDoc(setting.Doc)
+	if err != nil {
+		return nil, err
+	}
+	var documentation []string
+	for _, section := range markdownSections(doc) {
+		if slices.Contains(setting.Sections, section.anchor) {
+			documentation = append(documentation, section.content)
+		}
+	}
+
+	return &CommonSettingReference{
+		CommonSetting: *setting,
+		Version:       version,
+		Path:          CoreDocPath(setting.Doc),
+		Documentation: strings.Join(documentation, "\n\n"),
+		Schema:        schema,
+	}, nil
+}
+
+// commonSettingSchema returns the schema of the example block of the settings in the component schema of a version,
+// the component schemas generated for the version are the reference of the fields of the settings
+func (sm *SchemaManager) commonSettingSchema(setting *CommonSetting, version string) (map[string]interface{}, error) {
+	parts := strings.Split(setting.Example, "::")
+	componentType := ComponentType(strings.TrimSuffix(parts[0], "s"))
+	componentSchema, err := sm.GetComponentSchema(componentType, parts[1], version)
+	if err != nil {
+		return nil, fmt.Errorf("common settings %s not found for version %s: %w", setting.Name, version, err)
+	}
+	block := componentSchema.Schema
+	for _, key := range parts[2:] {
+		properties, _ := block["properties"].(map[string]interface{})
+		block, _ = properties[key].(map[string]interface{})
+		if block == nil {
+			return nil, fmt.Errorf("common settings %s not found in the schema of %s %s version %s", setting.Name, componentType, parts[1], version)
+		}
+	}
+
+	// The component schema is shared, the excluded properties are removed from a copy
+	schema := make(map[string]interface{}, len(block))
+	for key, value := range block {
+		schema[key] = value
+	}
+	if properties, ok := block["properties"].(map[string]interface{}); ok && len(setting.excluded) > 0 {
+		filtered := make(map[string]interface{}, len(properties))
+		for key, value := range properties {
+			if !slices.Contains(setting.excluded, key) {
+				filtered[key] = value
+			}
+		}
+		schema["properties"] = filtered
+	}
+	delete(schema, "$schema")
+	return schema, nil
+}
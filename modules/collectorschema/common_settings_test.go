@@ -0,0 +1,53 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCommonSetting(t *testing.T) {
+	sm := NewSchemaManager()
+	versions, err := sm.GetAllVersions()
+	require.NoError(t, err)
+	for _, version := range versions {
+		for _, name := range CommonSettingNames() {
+			setting, err := sm.GetCommonSetting(name, version)
+			require.NoError(t, err, "%s %s", name, version)
+			assert.NotEmpty(t, setting.Documentation, "%s %s", name, version)
+			assert.NotEmpty(t, setting.Schema["type"], "%s %s", name, version)
+		}
+	}
+
+	setting, err := sm.GetCommonSetting("tls_client", "0.139.0")
+	require.NoError(t, err)
+	assert.Equal(t, "config/configtls", setting.Path)
+	assert.Equal(t, "exporters::otlp::tls", setting.Example)
+	assert.Contains(t, setting.Documentation, "## Common settings")
+	assert.Contains(t, setting.Documentation, "`insecure_skip_verify`")
+	assert.NotContains(t, setting.Documentation, "`client_ca_file`")
+	exporter, err := sm.GetComponentSchema(ComponentTypeExporter, "otlp", "0.139.0")
+	require.NoError(t, err)
+	exporterProperties := exporter.Schema["properties"].(map[string]interface{})
+	assert.Equal(t, exporterProperties["tls"], setting.Schema)
+
+	// The schema fragments are the blocks of the component schemas of the version
+	setting, err = sm.GetCommonSetting("queue", "0.139.0")
+	require.NoError(t, err)
+	assert.Equal(t, "exporter/exporterhelper", setting.Path)
+	assert.Equal(t, exporterProperties["sending_queue"], setting.Schema)
+
+	setting, err = sm.GetCommonSetting("grpc_client", "0.139.0")
+	require.NoError(t, err)
+	properties := setting.Schema["properties"].(map[string]interface{})
+	assert.Contains(t, properties, "endpoint")
+	assert.Contains(t, properties, "tls")
+	assert.NotContains(t, properties, "sending_queue")
+	assert.Contains(t, exporterProperties, "sending_queue")
+
+	_, err = sm.GetCommonSetting("keepalive", "0.139.0")
+	require.EqualError(t, err, `unknown common settings "keepalive", it can be tls_client, tls_server, grpc_client, grpc_server, http_client, http_server, retry, queue, timeout`)
+	_, err = sm.GetCommonSetting("retry", "0.100.0")
+	require.ErrorContains(t, err, "common settings retry not found for version 0.100.0")
+}
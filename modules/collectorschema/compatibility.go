@@ -0,0 +1,168 @@
+package collectorschema
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed compatibility.yaml
+var embeddedCompatibility []byte
+
+// CompatibilityMatrix lists the OTLP and semantic conventions versions of the language SDKs and the collector
+type CompatibilityMatrix struct {
+	OTLP      []OTLPRelease            `yaml:"otlp" json:"otlp"`
+	Collector []CollectorCompatibility `yaml:"collector" json:"collector"`
+	SDKs      []SDKCompatibility       `yaml:"sdks" json:"sdks"`
+}
+
+// OTLPRelease is an OTLP protocol release changing the signal stability
+type OTLPRelease struct {
+	Version       string   `yaml:"version" json:"version"`
+	StableSignals []string `yaml:"stable_signals,omitempty" json:"stableSignals,omitempty"`
+	Notes         string   `yaml:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// CollectorCompatibility is the OTLP version of the collector releases since a version
+type CollectorCompatibility struct {
+	Since string `yaml:"since" json:"since"`
+	OTLP  string `yaml:"otlp" json:"otlp"`
+}
+
+// SDKCompatibility is the OTLP and semantic conventions version of the SDK releases of a language since a version
+type SDKCompatibility struct {
+	Language        string `yaml:"language" json:"language"`
+	Since           string `yaml:"since" json:"since"`
+	OTLP            string `yaml:"otlp" json:"otlp"`
+	Semconv         string `yaml:"semconv" json:"semconv"`
+	DefaultProtocol string `yaml:"default_protocol,omitempty" json:"defaultProtocol,omitempty"`
+	Notes           string `yaml:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// CompatibilityReport is the compatibility of an SDK version with a collector version
+type CompatibilityReport struct {
+	Language         string `json:"language"`
+	SDKVersion       string `json:"sdkVersion"`
+	CollectorVersion string `json:"collectorVersion"`
+	SDKOTLP          string `json:"sdkOtlp"`
+	CollectorOTLP    string `json:"collectorOtlp"`
+	// Semconv is the semantic conventions version emitted by the SDK, the collector forwards the attributes unchanged
+	Semconv         string `json:"semconv"`
+	DefaultProtocol string `json:"defaultProtocol,omitempty"`
+	Compatible      bool   `json:"compatible"`
+	// StableSignals are the signals covered by the OTLP stability guarantees of both versions
+	StableSignals []string `json:"stableSignals"`
+	Notes         []string `json:"notes"`
+}
+
+// GetCompatibilityMatrix returns the embedded SDK and collector compatibility matrix
+func (sm *SchemaManager) GetCompatibilityMatrix() (*CompatibilityMatrix, error) {
+	var matrix CompatibilityMatrix
+	if err := yaml.Unmarshal(embeddedCompatibility, &matrix); err != nil {
+		return nil, fmt.Errorf("failed to parse compatibility matrix: %w", err)
+	}
+	return &matrix, nil
+}
+
+// SDK returns the compatibility of an SDK version of a language e.g. java 1.38.0
+func (m *CompatibilityMatrix) SDK(language, version string) (*SDKCompatibility, bool) {
+	var found *SDKCompatibility
+	for i, sdk := range m.SDKs {
+		if sdk.Language != strings.ToLower(language) || CompareVersions(sdk.Since, version) > 0 {
+			continue
+		}
+		if found == nil || CompareVersions(sdk.Since, found.Since) > 0 {
+			found = &m.SDKs[i]
+		}
+	}
+	return found, found != nil
+}
+
+// CollectorOTLP returns the OTLP version of a collector version
+func (m *CompatibilityMatrix) CollectorOTLP(version string) (string, bool) {
+	var found *CollectorCompatibility
+	for i, collector := range m.Collector {
+		if CompareVersions(collector.Since, version) > 0 {
+			continue
+		}
+		if found == nil || CompareVersions(collector.Since, found.Since) > 0 {
+			found = &m.Collector[i]
+		}
+	}
+	if found == nil {
+		return "", false
+	}
+	return found.OTLP, true
+}
+
+// Check returns the compatibility of an SDK version of a language with a collector version
+func (m *CompatibilityMatrix) Check(language, sdkVersion, collectorVersion string) (*CompatibilityReport, error) {
+	sdk, ok := m.SDK(language, sdkVersion)
+	if !ok {
+		var known []string
+		for _, s := range m.SDKs {
+			if s.Language == strings.ToLower(language) {
+				known = append(known, s.Since)
+			}
+		}
+		if len(known) == 0 {
+			return nil, fmt.Errorf("no compatibility data for the %s SDK", language)
+		}
+		return nil, fmt.Errorf("no compatibility data for the %s SDK %s, the matrix starts at %s", language, sdkVersion, known[0])
+	}
+	collectorOTLP, ok := m.CollectorOTLP(collectorVersion)
+	if !ok {
+		return nil, fmt.Errorf("no compatibility data for collector %s", collectorVersion)
+	}
+
+	report := &CompatibilityReport{
+		Language:         strings.ToLower(language),
+		SDKVersion:       sdkVersion,
+		CollectorVersion: collectorVersion,
+		SDKOTLP:          sdk.OTLP,
+		CollectorOTLP:    collectorOTLP,
+		Semconv:          sdk.Semconv,
+		DefaultProtocol:  sdk.DefaultProtocol,
+		StableSignals:    []string{},
+		Notes:            []string{},
+	}
+	if majorVersion(sdk.OTLP) != majorVersion(collectorOTLP) {
+		report.Notes = append(report.Notes, fmt.Sprintf("OTLP %s and %s have different major versions and are not wire compatible", sdk.OTLP, collectorOTLP))
+		return report, nil
+	}
+	report.Compatible = true
+
+	oldest := sdk.OTLP
+	if CompareVersions(collectorOTLP, oldest) < 0 {
+		oldest = collectorOTLP
+		report.Notes = append(report.Notes, fmt.Sprintf("the SDK uses the newer OTLP %s, fields added after OTLP %s are dropped by the collector", sdk.OTLP, collectorOTLP))
+	}
+	for _, release := range m.OTLP {
+		if CompareVersions(release.Version, oldest) > 0 {
+			continue
+		}
+		report.StableSignals = append(report.StableSignals, release.StableSignals...)
+		if release.Notes != "" {
+			report.Notes = append(report.Notes, release.Notes)
+		}
+	}
+
+	switch sdk.DefaultProtocol {
+	case "grpc":
+		report.Notes = append(report.Notes, "the SDK exports OTLP over gRPC by default, enable protocols.grpc of the otlp receiver (port 4317)")
+	case "http/protobuf":
+		report.Notes = append(report.Notes, "the SDK exports OTLP over HTTP by default, enable protocols.http of the otlp receiver (port 4318)")
+	}
+	if sdk.Notes != "" {
+		report.Notes = append(report.Notes, sdk.Notes)
+	}
+	report.Notes = append(report.Notes, fmt.Sprintf("the SDK emits semantic conventions %s, the collector forwards the attribute names unchanged, align other versions with the transform processor", sdk.Semconv))
+	return report, nil
+}
+
+// majorVersion returns the major version of a version e.g. 1 for 1.7.0
+func majorVersion(version string) string {
+	return strings.SplitN(strings.TrimPrefix(version, "v"), ".", 2)[0]
+}
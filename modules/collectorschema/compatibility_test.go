@@ -0,0 +1,43 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompatibilityMatrix_Check(t *testing.T) {
+	sm := NewSchemaManager()
+	matrix, err := sm.GetCompatibilityMatrix()
+	require.NoError(t, err)
+
+	report, err := matrix.Check("Java", "1.38.0", "0.139.0")
+	require.NoError(t, err)
+	assert.True(t, report.Compatible)
+	assert.Equal(t, "1.25.0", report.Semconv)
+	assert.Equal(t, "1.3.1", report.SDKOTLP)
+	assert.Equal(t, "1.7.0", report.CollectorOTLP)
+	assert.Equal(t, []string{"traces", "metrics", "logs"}, report.StableSignals)
+
+	sdk, ok := matrix.SDK("java", "1.45.0")
+	require.True(t, ok)
+	assert.Equal(t, "1.42.0", sdk.Since)
+
+	_, err = matrix.Check("java", "1.0.0", "0.139.0")
+	assert.ErrorContains(t, err, "the matrix starts at 1.38.0")
+	_, err = matrix.Check("cobol", "1.0.0", "0.139.0")
+	assert.Error(t, err)
+	_, err = matrix.Check("java", "1.38.0", "0.100.0")
+	assert.Error(t, err)
+
+	matrix.Collector = append(matrix.Collector, CollectorCompatibility{Since: "0.140.0", OTLP: "1.2.0"})
+	report, err = matrix.Check("java", "1.47.0", "0.140.0")
+	require.NoError(t, err)
+	assert.Contains(t, report.Notes, "the SDK uses the newer OTLP 1.5.0, fields added after OTLP 1.2.0 are dropped by the collector")
+
+	matrix.Collector = append(matrix.Collector, CollectorCompatibility{Since: "0.141.0", OTLP: "2.0.0"})
+	report, err = matrix.Check("java", "1.47.0", "0.141.0")
+	require.NoError(t, err)
+	assert.False(t, report.Compatible)
+}
@@ -0,0 +1,256 @@
+package collectorschema
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ComponentNotFoundError is returned when a component has no schema in the requested version. Versions lists the
+// versions that include the component so callers can point to the nearest one.
+type ComponentNotFoundError struct {
+	ComponentType ComponentType
+	ComponentName string
+	Version       string
+	// Versions are the versions including the component in ascending order
+	Versions []string
+	// Suggestions are the names of the components of the type closest to the name, set if no version includes it
+	Suggestions []string
+}
+
+func (e *ComponentNotFoundError) Error() string {
+	message := fmt.Sprintf("schema not found for component %s %s", e.ComponentType, e.ComponentName)
+	if len(e.Versions) == 0 {
+		if len(e.Suggestions) > 0 {
+			return fmt.Sprintf("%s, did you mean %s?", message, strings.Join(e.Suggestions, ", "))
+		}
+		return message
+	}
+	return fmt.Sprintf("%s in version %s, it is available in versions %s to %s, nearest versions: %s",
+		message, e.Version, e.FirstVersion(), e.LastVersion(), strings.Join(e.NearestVersions(), ", "))
+}
+
+// FirstVersion returns the first version including the component
+func (e *ComponentNotFoundError) FirstVersion() string {
+	if len(e.Versions) == 0 {
+		return ""
+	}
+	return e.Versions[0]
+}
+
+// LastVersion returns the last version including the component
+func (e *ComponentNotFoundError) LastVersion() string {
+	if len(e.Versions) == 0 {
+		return ""
+	}
+	return e.Versions[len(e.Versions)-1]
+}
+
+// NearestVersions returns the closest older and newer versions including the component
+func (e *ComponentNotFoundError) NearestVersions() []string {
+	var nearest []string
+	older, newer := "", ""
+	for _, version := range e.Versions {
+		if CompareVersions(version, e.Version) < 0 {
+			older = version
+		} else if newer == "" {
+			newer = version
+		}
+	}
+	if older != "" {
+		nearest = append(nearest, older)
+	}
+	if newer != "" {
+		nearest = append(nearest, newer)
+	}
+	return nearest
+}
+
+// indexedVersion is a version including a component and the hash of the component schema in the version
+type indexedVersion struct {
+	version string
+	hash    [sha256.Size]byte
+}
+
+// buildComponentIndex returns the versions including each component by type_name e.g. receiver_otlp
+func buildComponentIndex(schemas fs.FS) map[string][]indexedVersion {
+	index := make(map[string][]indexedVersion)
+	versions, err := fs.ReadDir(schemas, "schemas")
+	if err != nil {
+		return index
+	}
+	for _, version := range versions {
+		if !version.IsDir() {
+			continue
+		}
+		manifest, err := loadComponentManifest(schemas, version.Name())
+		if err != nil {
+			continue
+		}
+		for _, component := range manifest.Components {
+			data, err := fs.ReadFile(schemas, filepath.Join("schemas", version.Name(), component.SchemaFile()))
+			if err != nil {
+				continue
+			}
+			key := fmt.Sprintf("%s_%s", component.Type, component.Name)
+			index[key] = append(index[key], indexedVersion{version: version.Name(), hash: sha256.Sum256(data)})
+		}
+	}
+	for _, componentVersions := range index {
+		sort.Slice(componentVersions, func(i, j int) bool {
+			return CompareVersions(componentVersions[i].version, componentVersions[j].version) < 0
+		})
+	}
+	return index
+}
+
+// GetComponentVersions returns the versions including a component in ascending order
+func (sm *SchemaManager) GetComponentVersions(componentType ComponentType, componentName string) []string {
+	var versions []string
+	for _, indexed := range sm.componentIndex[fmt.Sprintf("%s_%s", componentType, componentName)] {
+		versions = append(versions, indexed.version)
+	}
+	return versions
+}
+
+// SchemaChange is a version in which the schema of a component differs from the previous version including it
+type SchemaChange struct {
+	Version       string   `json:"version"`
+	Previous      string   `json:"previous"`
+	AddedFields   []string `json:"addedFields,omitempty"`
+	RemovedFields []string `json:"removedFields,omitempty"`
+}
+
+// ComponentAvailability is the availability history of a component across the embedded versions
+type ComponentAvailability struct {
+	Component    string   `json:"component"`
+	FirstVersion string   `json:"firstVersion"`
+	LastVersion  string   `json:"lastVersion"`
+	Versions     []string `json:"versions"`
+	// MissingVersions are the embedded versions after the first version that do not include the component
+	MissingVersions []string       `json:"missingVersions,omitempty"`
+	Changes         []SchemaChange `json:"changes,omitempty"`
+}
+
+// GetComponentAvailability returns the versions including a component and the versions in which its schema changed.
+// Changes without added or removed fields are changes of the field types, descriptions or defaults.
+func (sm *SchemaManager) GetComponentAvailability(componentType ComponentType, componentName string) (*ComponentAvailability, error) {
+	indexed := sm.componentIndex[fmt.Sprintf("%s_%s", componentType, componentName)]
+	if len(indexed) == 0 {
+		return nil, fmt.Errorf("component %s %s is not available in any version", componentType, componentName)
+	}
+	allVersions, err := sm.GetAllVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	availability := &ComponentAvailability{
+		Component:    fmt.Sprintf("%s/%s", componentType, componentName),
+		FirstVersion: indexed[0].version,
+		LastVersion:  indexed[len(indexed)-1].version,
+		Versions:     sm.GetComponentVersions(componentType, componentName),
+	}
+	sort.Slice(allVersions, func(i, j int) bool { return CompareVersions(allVersions[i], allVersions[j]) < 0 })
+	for _, version := range allVersions {
+		if CompareVersions(version, availability.FirstVersion) > 0 && !slices.Contains(availability.Versions, version) {
+			availability.MissingVersions = append(availability.MissingVersions, version)
+		}
+	}
+
+	for i := 1; i < len(indexed); i++ {
+		if indexed[i].hash == indexed[i-1].hash {
+			continue
+		}
+		change := SchemaChange{Version: indexed[i].version, Previous: indexed[i-1].version}
+		previousFields, err := sm.fieldPaths(componentType, componentName, change.Previous)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := sm.fieldPaths(componentType, componentName, change.Version)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range sortedKeys(fields) {
+			if !previousFields[field] {
+				change.AddedFields = append(change.AddedFields, field)
+			}
+		}
+		for _, field := range sortedKeys(previousFields) {
+			if !fields[field] {
+				change.RemovedFields = append(change.RemovedFields, field)
+			}
+		}
+		availability.Changes = append(availability.Changes, change)
+	}
+	return availability, nil
+}
+
+// fieldPaths returns the dotted field paths of a component schema
+func (sm *SchemaManager) fieldPaths(componentType ComponentType, componentName string, version string) (map[string]bool, error) {
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+	paths := make(map[string]bool)
+	walkFields(schema.Schema, "", func(fieldPath string, _ map[string]interface{}) {
+		paths[fieldPath] = true
+	})
+	return paths, nil
+}
+
+// componentNotFound returns the error of a component without a schema in the version
+func (sm *SchemaManager) componentNotFound(componentType ComponentType, componentName string, version string) *ComponentNotFoundError {
+	notFound := &ComponentNotFoundError{
+		ComponentType: componentType,
+		ComponentName: componentName,
+		Version:       version,
+		Versions:      sm.GetComponentVersions(componentType, componentName),
+	}
+	if len(notFound.Versions) == 0 {
+		notFound.Suggestions = sm.similarComponentNames(componentType, componentName)
+	}
+	return notFound
+}
+
+// maxComponentSuggestions is the number of similar component names suggested for a missing component
+const maxComponentSuggestions = 3
+
+// similarComponentNames returns the names of the components of a type in any version closest to a name: the names
+// equal but for the case and separators, the names within a small edit distance and the names containing it or
+// contained in it e.g. otlp for otlpreceiver
+func (sm *SchemaManager) similarComponentNames(componentType ComponentType, componentName string) []string {
+	normalizedName := normalizeKey(componentName)
+	if normalizedName == "" {
+		return nil
+	}
+	prefix := string(componentType) + "_"
+	distances := make(map[string]int)
+	for key := range sm.componentIndex {
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		normalized := normalizeKey(name)
+		distance := editDistance(normalizedName, normalized)
+		switch {
+		case distance <= maxKeyDistance(normalizedName):
+		case len(normalizedName) >= 3 && strings.Contains(normalized, normalizedName):
+		case len(normalized) >= 3 && strings.Contains(normalizedName, normalized):
+		default:
+			continue
+		}
+		distances[name] = distance
+	}
+	names := sortedKeys(distances)
+	sort.SliceStable(names, func(i, j int) bool {
+		return distances[names[i]] < distances[names[j]]
+	})
+	if len(names) > maxComponentSuggestions {
+		names = names[:maxComponentSuggestions]
+	}
+	return names
+}
@@ -0,0 +1,57 @@
+package collectorschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentNotFoundError(t *testing.T) {
+	sm := NewSchemaManager()
+	assert.Equal(t, []string{"0.136.0", "0.137.0", "0.138.0", "0.139.0"}, sm.GetComponentVersions(ComponentTypeConnector, "failover"))
+
+	_, err := sm.GetComponentSchema(ComponentTypeConnector, "failover", "0.135.0")
+	var notFound *ComponentNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "0.136.0", notFound.FirstVersion())
+	assert.Equal(t, "0.139.0", notFound.LastVersion())
+	assert.Equal(t, []string{"0.136.0"}, notFound.NearestVersions())
+	assert.Equal(t, "schema not found for component connector failover in version 0.135.0, it is available in versions 0.136.0 to 0.139.0, nearest versions: 0.136.0", err.Error())
+
+	// Wrapped errors keep the versions
+	_, err = sm.GetFieldDefaults(ComponentTypeConnector, "failover", "0.135.0")
+	assert.ErrorContains(t, err, "nearest versions: 0.136.0")
+	_, err = sm.GetComponentReadme(ComponentTypeConnector, "failover", "0.135.0")
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestComponentNotFoundError_NearestVersions(t *testing.T) {
+	err := &ComponentNotFoundError{Version: "0.137.0", Versions: []string{"0.130.0", "0.135.0", "0.139.0"}}
+	assert.Equal(t, []string{"0.135.0", "0.139.0"}, err.NearestVersions())
+	err = &ComponentNotFoundError{Version: "0.140.0", Versions: []string{"0.130.0", "0.135.0"}}
+	assert.Equal(t, []string{"0.135.0"}, err.NearestVersions())
+}
+
+func TestGetComponentAvailability(t *testing.T) {
+	sm := NewSchemaManager()
+	availability, err := sm.GetComponentAvailability(ComponentTypeConnector, "failover")
+	require.NoError(t, err)
+	assert.Equal(t, "connector/failover", availability.Component)
+	assert.Equal(t, "0.136.0", availability.FirstVersion)
+	assert.Equal(t, "0.139.0", availability.LastVersion)
+	assert.Empty(t, availability.MissingVersions)
+	assert.Empty(t, availability.Changes)
+
+	// A version without the component and a changed schema
+	index := sm.componentIndex["exporter_kafka"]
+	sm.componentIndex["exporter_kafka"] = []indexedVersion{index[0], index[1], {version: index[3].version, hash: [32]byte{1}}}
+	availability, err = sm.GetComponentAvailability(ComponentTypeExporter, "kafka")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0.137.0", "0.139.0"}, availability.MissingVersions)
+	assert.Equal(t, []SchemaChange{{Version: "0.138.0", Previous: "0.136.0"}}, availability.Changes)
+
+	_, err = sm.GetComponentAvailability(ComponentTypeReceiver, "nonexistent")
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package collectorschema
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the manifest of the components of a version in the schemas directory of the version
+const ManifestFile = "components.yaml"
+
+// ComponentManifest lists the components of a version with their files and signals
+type ComponentManifest struct {
+	Components []ManifestComponent `yaml:"components" json:"components"`
+}
+
+// ManifestComponent is a component of a version e.g. receiver otlp with its schema and README files
+type ManifestComponent struct {
+	Type    ComponentType `yaml:"type" json:"type"`
+	Name    string        `yaml:"name" json:"name"`
+	Files   []string      `yaml:"files" json:"files"`
+	Signals []string      `yaml:"signals,omitempty" json:"signals,omitempty"`
+	// Module is the Go module providing the component and ModuleVersion its version e.g. v0.139.0
+	Module        string `yaml:"module,omitempty" json:"module,omitempty"`
+	ModuleVersion string `yaml:"module_version,omitempty" json:"moduleVersion,omitempty"`
+	// License is the license of the module and DependencyLicenses the licenses of its dependencies other than Apache-2.0
+	License            string          `yaml:"license,omitempty" json:"license,omitempty"`
+	DependencyLicenses []ModuleLicense `yaml:"dependency_licenses,omitempty" json:"dependencyLicenses,omitempty"`
+	// Status are the stability and code owners of the component from its metadata.yaml
+	Status *ComponentStatus `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// SchemaFile returns the schema file of the component e.g. receiver_otlp.yaml
+func (c ManifestComponent) SchemaFile() string {
+	return c.file(".yaml")
+}
+
+// ReadmeFile returns the README of the component e.g. receiver_otlp.md, empty if the component has no README
+func (c ManifestComponent) ReadmeFile() string {
+	return c.file(".md")
+}
+
+// file returns the file of the component with the suffix directly after the type_name base, skipping e.g. the
+// pre-translated READMEs type_name.locale.md
+func (c ManifestComponent) file(suffix string) string {
+	base := fmt.Sprintf("%s_%s", c.Type, c.Name)
+	for _, file := range c.Files {
+		if file == base+suffix {
+			return file
+		}
+	}
+	return ""
+}
+
+// GetComponentManifest returns the components of a version from the manifest of the version. Schemas generated
+// without a manifest are listed from the file names.
+func (sm *SchemaManager) GetComponentManifest(version string) (*ComponentManifest, error) {
+	sm.manifestMutex.Lock()
+	defer sm.manifestMutex.Unlock()
+	if manifest, ok := sm.manifests[version]; ok {
+		return manifest, nil
+	}
+	manifest, err := loadComponentManifest(sm.schemas, version)
+	if err != nil {
+		return nil, err
+	}
+	sm.manifests[version] = manifest
+	return manifest, nil
+}
+
+// loadComponentManifest reads the manifest of a version, or scans the version directory if it has no manifest
+func loadComponentManifest(schemas fs.FS, version string) (*ComponentManifest, error) {
+	schemaPath := filepath.Join("schemas", version)
+	data, err := fs.ReadFile(schemas, filepath.Join(schemaPath, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return scanComponentManifest(schemas, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read component manifest for version %s: %w", version, err)
+	}
+
+	var manifest ComponentManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse component manifest for version %s: %w", version, err)
+	}
+	for _, component := range manifest.Components {
+		if !component.Type.Valid() || component.Name == "" {
+			return nil, fmt.Errorf("invalid component %q %q in the component manifest for version %s", component.Type, component.Name, version)
+		}
+	}
+	return &manifest, nil
+}
+
+// scanComponentManifest returns the manifest of a version without a manifest file from the type_name.* file names,
+// files of unknown component types e.g. changelog.md are not components
+func scanComponentManifest(schemas fs.FS, version string) (*ComponentManifest, error) {
+	entries, err := fs.ReadDir(schemas, filepath.Join("schemas", version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema directory for version %s: %w", version, err)
+	}
+
+	components := make(map[string]*ManifestComponent)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		// The base ends at the first dot, component names do not contain dots: receiver_otlp.de.md
+		base, _, _ := strings.Cut(entry.Name(), ".")
+		componentType, componentName, ok := strings.Cut(base, "_")
+		if !ok || componentName == "" || !ComponentType(componentType).Valid() {
+			continue
+		}
+		component, exists := components[base]
+		if !exists {
+			component = &ManifestComponent{Type: ComponentType(componentType), Name: componentName}
+			components[base] = component
+		}
+		component.Files = append(component.Files, entry.Name())
+	}
+
+	manifest := &ComponentManifest{}
+	for _, base := range sortedKeys(components) {
+		// Only components with a schema are listed, e.g. a stray README is not a component
+		if components[base].SchemaFile() != "" {
+			manifest.Components = append(manifest.Components, *components[base])
+		}
+	}
+	return manifest, nil
+}
+
+// componentsByType returns the names of the components of the manifest by type in the manifest order
+func (m *ComponentManifest) componentsByType() map[ComponentType][]string {
+	components := make(map[ComponentType][]string)
+	for _, component := range m.Components {
+		components[component.Type] = append(components[component.Type], component.Name)
+	}
+	return components
+}
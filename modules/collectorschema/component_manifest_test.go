@@ -0,0 +1,90 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetComponentManifest(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/components.yaml": {Data: []byte(`components:
+  - type: receiver
+    name: foo_bar
+    files: [receiver_foo_bar.yaml, receiver_foo_bar.md, receiver_foo_bar.de.md]
+    signals: [traces, logs]
+  - type: exporter
+    name: debug
+    files: [exporter_debug.yaml]
+`)},
+		"schemas/1.0.0/receiver_foo_bar.yaml":  {Data: []byte("type: object\n")},
+		"schemas/1.0.0/receiver_foo_bar.md":    {Data: []byte("# foo_bar\n")},
+		"schemas/1.0.0/receiver_foo_bar.de.md": {Data: []byte("# foo_bar de\n")},
+		"schemas/1.0.0/exporter_debug.yaml":    {Data: []byte("type: object\n")},
+		// Files not listed in the manifest are not components
+		"schemas/1.0.0/receiver_unlisted.yaml": {Data: []byte("type: object\n")},
+		"schemas/1.0.0/changelog.md":           {Data: []byte("# changelog\n")},
+	})
+
+	manifest, err := sm.GetComponentManifest("1.0.0")
+	require.NoError(t, err)
+	require.Len(t, manifest.Components, 2)
+	assert.Equal(t, []string{"traces", "logs"}, manifest.Components[0].Signals)
+	assert.Equal(t, "receiver_foo_bar.yaml", manifest.Components[0].SchemaFile())
+	assert.Equal(t, "receiver_foo_bar.md", manifest.Components[0].ReadmeFile())
+	assert.Empty(t, manifest.Components[1].ReadmeFile())
+
+	components, err := sm.ListAvailableComponents("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, map[ComponentType][]string{
+		ComponentTypeReceiver: {"foo_bar"},
+		ComponentTypeExporter: {"debug"},
+	}, components)
+	assert.Equal(t, []string{"1.0.0"}, sm.GetComponentVersions(ComponentTypeReceiver, "foo_bar"))
+	assert.Empty(t, sm.GetComponentVersions(ComponentTypeReceiver, "unlisted"))
+
+	docs, err := sm.markdownDocuments("1.0.0")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "receiver", docs[0].Metadata["component_type"])
+	assert.Equal(t, "foo_bar", docs[0].Metadata["component_name"])
+}
+
+func TestGetComponentManifest_WithoutManifestFile(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/receiver_foo_bar.yaml":    {Data: []byte("type: object\n")},
+		"schemas/1.0.0/receiver_foo_bar.md":      {Data: []byte("# foo_bar\n")},
+		"schemas/1.0.0/receiver_foo_bar.de.md":   {Data: []byte("# foo_bar de\n")},
+		"schemas/1.0.0/processor_only_readme.md": {Data: []byte("# only_readme\n")},
+		"schemas/1.0.0/changelog.md":             {Data: []byte("# changelog\n")},
+		"schemas/1.0.0/unknown_component.yaml":   {Data: []byte("type: object\n")},
+	})
+
+	manifest, err := sm.GetComponentManifest("1.0.0")
+	require.NoError(t, err)
+	require.Len(t, manifest.Components, 1)
+	assert.Equal(t, ManifestComponent{
+		Type:  ComponentTypeReceiver,
+		Name:  "foo_bar",
+		Files: []string{"receiver_foo_bar.de.md", "receiver_foo_bar.md", "receiver_foo_bar.yaml"},
+	}, manifest.Components[0])
+
+	names, err := sm.GetComponentNames(ComponentTypeReceiver, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo_bar"}, names)
+	_, err = sm.GetComponentNames(ComponentTypeProcessor, "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestGetComponentManifest_Invalid(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/components.yaml": {Data: []byte("components:\n  - type: changelog\n    name: md\n")},
+	})
+	_, err := sm.GetComponentManifest("1.0.0")
+	assert.ErrorContains(t, err, "invalid component")
+
+	_, err = sm.GetComponentManifest("2.0.0")
+	assert.ErrorContains(t, err, "failed to read schema directory for version 2.0.0")
+}
@@ -0,0 +1,175 @@
+package collectorschema
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// embeddedBuilderManifests are the collector builder manifests the embedded schemas are generated from
+//
+//go:embed manifest-*.yaml
+var embeddedBuilderManifests embed.FS
+
+// BuilderManifestFile is the collector builder manifest of a custom distribution in the schemas directory of a version
+const BuilderManifestFile = "manifest.yaml"
+
+// ComponentModule is the Go module providing a component
+type ComponentModule struct {
+	Type          ComponentType `json:"type"`
+	Name          string        `json:"name"`
+	Module        string        `json:"module"`
+	ModuleVersion string        `json:"moduleVersion,omitempty"`
+}
+
+// GoMod returns the module as a go.mod require and builder manifest gomod entry e.g.
+// go.opentelemetry.io/collector/receiver/otlpreceiver v0.139.0
+func (m ComponentModule) GoMod() string {
+	return strings.TrimSpace(m.Module + " " + m.ModuleVersion)
+}
+
+// GetComponentModules returns the Go modules of the components of a version. The modules are read from the component
+// manifest, schemas generated without the modules are matched with the builder manifest of the version by the
+// <name><kind> module naming convention e.g. receiver otlp is provided by .../receiver/otlpreceiver.
+func (sm *SchemaManager) GetComponentModules(version string) ([]ComponentModule, error) {
+	manifest, err := sm.GetComponentManifest(version)
+	if err != nil {
+		return nil, err
+	}
+	builderModules, err := sm.builderManifestModules(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var modules []ComponentModule
+	for _, component := range manifest.Components {
+		module := ComponentModule{Type: component.Type, Name: component.Name, Module: component.Module, ModuleVersion: component.ModuleVersion}
+		if module.Module == "" {
+			gomod, ok := builderModules[moduleKey(component.Type, normalizeKey(component.Name))]
+			if !ok {
+				continue
+			}
+			module.Module, module.ModuleVersion, _ = strings.Cut(gomod, " ")
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// GetComponentModule returns the Go module providing a component
+func (sm *SchemaManager) GetComponentModule(componentType ComponentType, componentName string, version string) (*ComponentModule, error) {
+	modules, err := sm.GetComponentModules(version)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range modules {
+		if module.Type == componentType && module.Name == componentName {
+			return &module, nil
+		}
+	}
+	if versions := sm.GetComponentVersions(componentType, componentName); !slices.Contains(versions, version) {
+		return nil, sm.componentNotFound(componentType, componentName, version)
+	}
+	return nil, fmt.Errorf("module of component %s %s v%s is unknown", componentType, componentName, version)
+}
+
+// FindComponentsByModule returns the components provided by a Go module or by the module of an import path e.g.
+// github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadata. A trailing
+// module version e.g. from a go.mod require line is ignored.
+func (sm *SchemaManager) FindComponentsByModule(modulePath string, version string) ([]ComponentModule, error) {
+	fields := strings.Fields(modulePath)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("module path is empty")
+	}
+	importPath := strings.TrimSuffix(fields[0], "/")
+
+	modules, err := sm.GetComponentModules(version)
+	if err != nil {
+		return nil, err
+	}
+	// The longest module containing the import path provides the package, nested modules e.g.
+	// .../extension/storage/filestorage are more specific than their parents
+	var found []ComponentModule
+	longest := 0
+	for _, module := range modules {
+		if importPath != module.Module && !strings.HasPrefix(importPath, module.Module+"/") {
+			continue
+		}
+		if len(module.Module) > longest {
+			found, longest = nil, len(module.Module)
+		}
+		if len(module.Module) == longest {
+			found = append(found, module)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Type != found[j].Type {
+			return found[i].Type < found[j].Type
+		}
+		return found[i].Name < found[j].Name
+	})
+	return found, nil
+}
+
+// builderManifestModules returns the gomod entries of the builder manifest of a version by component type and the
+// normalized component name derived from the module path, it is empty if the version has no builder manifest
+func (sm *SchemaManager) builderManifestModules(version string) (map[string]string, error) {
+	data, err := fs.ReadFile(sm.schemas, filepath.Join("schemas", version, BuilderManifestFile))
+	if errors.Is(err, fs.ErrNotExist) && sm.builderManifests != nil {
+		data, err = fs.ReadFile(sm.builderManifests, fmt.Sprintf("manifest-%s.yaml", version))
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read builder manifest for version %s: %w", version, err)
+	}
+
+	builderModules, err := ParseBuilderManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse builder manifest for version %s: %w", version, err)
+	}
+	modules := make(map[string]string)
+	for componentType, gomods := range builderModules {
+		for _, gomod := range gomods {
+			modulePath, _, _ := strings.Cut(gomod, " ")
+			name := strings.TrimSuffix(path.Base(modulePath), string(componentType))
+			modules[moduleKey(componentType, normalizeKey(name))] = gomod
+		}
+	}
+	return modules, nil
+}
+
+// ParseBuilderManifest returns the gomod entries "<module path> <version>" of a collector builder (OCB) manifest by
+// component type
+func ParseBuilderManifest(data []byte) (map[ComponentType][]string, error) {
+	var manifest map[string]interface{}
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	modules := make(map[ComponentType][]string)
+	// The builder manifest sections are named like the configuration sections
+	for _, componentType := range componentTypes {
+		entries, _ := manifest[componentType.Section()].([]interface{})
+		for _, entry := range entries {
+			fields, _ := entry.(map[string]interface{})
+			gomod, _ := fields["gomod"].(string)
+			if gomod = strings.Join(strings.Fields(gomod), " "); gomod != "" {
+				modules[componentType] = append(modules[componentType], gomod)
+			}
+		}
+	}
+	return modules, nil
+}
+
+func moduleKey(componentType ComponentType, normalizedName string) string {
+	return fmt.Sprintf("%s/%s", componentType, normalizedName)
+}
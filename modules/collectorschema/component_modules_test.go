@@ -0,0 +1,77 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentModules(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/components.yaml": {Data: []byte(`components:
+  - type: receiver
+    name: otlp
+    files: [receiver_otlp.yaml]
+    module: go.opentelemetry.io/collector/receiver/otlpreceiver
+    module_version: v1.0.0
+  - type: extension
+    name: file_storage
+    files: [extension_file_storage.yaml]
+  - type: processor
+    name: memory_limiter
+    files: [processor_memory_limiter.yaml]
+  - type: exporter
+    name: unknown
+    files: [exporter_unknown.yaml]
+`)},
+		// Schemas generated without the modules are matched with the builder manifest
+		"schemas/1.0.0/manifest.yaml": {Data: []byte(`extensions:
+  - gomod: github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v1.0.0
+  - gomod: github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage v1.0.0
+processors:
+  - gomod: go.opentelemetry.io/collector/processor/memorylimiterprocessor v1.0.0
+`)},
+		"schemas/1.0.0/receiver_otlp.yaml":            {Data: []byte("type: object\n")},
+		"schemas/1.0.0/extension_file_storage.yaml":   {Data: []byte("type: object\n")},
+		"schemas/1.0.0/processor_memory_limiter.yaml": {Data: []byte("type: object\n")},
+		"schemas/1.0.0/exporter_unknown.yaml":         {Data: []byte("type: object\n")},
+	})
+
+	modules, err := sm.GetComponentModules("1.0.0")
+	require.NoError(t, err)
+	assert.Len(t, modules, 3)
+
+	module, err := sm.GetComponentModule(ComponentTypeProcessor, "memory_limiter", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "go.opentelemetry.io/collector/processor/memorylimiterprocessor v1.0.0", module.GoMod())
+	_, err = sm.GetComponentModule(ComponentTypeExporter, "unknown", "1.0.0")
+	assert.EqualError(t, err, "module of component exporter unknown v1.0.0 is unknown")
+	_, err = sm.GetComponentModule(ComponentTypeExporter, "missing", "1.0.0")
+	var notFound *ComponentNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	// go.mod require lines and import paths of packages in the module
+	found, err := sm.FindComponentsByModule("go.opentelemetry.io/collector/receiver/otlpreceiver v1.0.0 // indirect", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "otlp", found[0].Name)
+	found, err = sm.FindComponentsByModule("github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/bbolt", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "file_storage", found[0].Name)
+	found, err = sm.FindComponentsByModule("go.opentelemetry.io/collector/receiver", "1.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+	_, err = sm.FindComponentsByModule(" ", "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestEmbeddedComponentModules(t *testing.T) {
+	sm := NewSchemaManager()
+	module, err := sm.GetComponentModule(ComponentTypeReceiver, "otlp", "0.139.0")
+	require.NoError(t, err)
+	assert.Equal(t, "go.opentelemetry.io/collector/receiver/otlpreceiver", module.Module)
+	assert.Equal(t, "v0.139.0", module.ModuleVersion)
+}
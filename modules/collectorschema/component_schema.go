@@ -5,13 +5,18 @@ import (
 	"crypto/md5"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"io/fs"
 	"math"
+	"net/http"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/philippgille/chromem-go"
 	"github.com/xeipuuv/gojsonschema"
@@ -21,16 +26,8 @@ import (
 //go:embed schemas
 var embeddedSchemas embed.FS
 
-// ComponentType represents the type of OpenTelemetry component
-type ComponentType string
-
-const (
-	ComponentTypeReceiver  ComponentType = "receiver"
-	ComponentTypeProcessor ComponentType = "processor"
-	ComponentTypeExporter  ComponentType = "exporter"
-	ComponentTypeExtension ComponentType = "extension"
-	ComponentTypeConnector ComponentType = "connector"
-)
+// maxTranslationCacheEntries bounds the translated README cache, every component, version and locale is a README
+const maxTranslationCacheEntries = 256
 
 // ComponentSchema represents a YAML schema for an OpenTelemetry component
 type ComponentSchema struct {
@@ -38,6 +35,8 @@ type ComponentSchema struct {
 	Type    ComponentType          `json:"type"`
 	Version string                 `json:"version,omitempty"`
 	Schema  map[string]interface{} `json:"schema"`
+	// Locale of the translated field descriptions, empty for the English descriptions
+	Locale string `json:"locale,omitempty"`
 }
 
 // DeprecatedField represents a deprecated field with its information
@@ -45,22 +44,136 @@ type DeprecatedField struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Type        string `json:"type"`
+	// ReplacedBy is the replacement field path parsed from the description e.g. protocols.grpc
+	ReplacedBy string `json:"replacedBy,omitempty"`
+	// Migration is a YAML snippet moving the field to its replacement, set if the replacement is a schema field
+	Migration string `json:"migration,omitempty"`
+}
+
+// Translator translates documentation text into the target locale (e.g. "de", "pt-BR")
+type Translator interface {
+	Translate(ctx context.Context, text string, locale string) (string, error)
 }
 
 // SchemaManager manages component schemas and documentation RAG database
 type SchemaManager struct {
+	schemas        fs.FS
 	cache          map[string]*ComponentSchema
-	ragDB          *chromem.DB
-	ragCollection  *chromem.Collection
-	ragMutex       sync.RWMutex
-	ragInit        sync.Once
+	cacheMutex     sync.RWMutex
+	componentIndex map[string][]indexedVersion
+	searchWeights  SearchWeights
+	inputLimits    InputLimits
+	embeddingFunc  chromem.EmbeddingFunc
+	ragIndex       *ragIndex
+	// ragMutex guards the lifecycle of the documentation search index, the searches read the published ragSearch
+	ragMutex  sync.Mutex
+	ragStatus ragStatus
+	ragSearch *ragSearchIndex
+	ragErr    error
+	ragDone   chan struct{}
+
+	manifests         map[string]*ComponentManifest
+	manifestMutex     sync.Mutex
+	configSchemas     map[string][]byte
+	configSchemaMutex sync.Mutex
+	// builderManifests are the builder manifests of the embedded schemas, nil for the schemas of a directory
+	builderManifests fs.FS
+
+	translator       Translator
+	httpClient       *http.Client
+	contentCache     ContentCache
+	observer         Observer
+	translationCache map[string]string
+	// translationCalls are the running translations by cache key, concurrent requests of a README wait for them
+	translationCalls map[string]*translationCall
+	translationMutex sync.Mutex
+
+	advisories      []Advisory
+	advisoriesMutex sync.Mutex
+
+	// notFound caches the errors of the components missing in a version by cache key
+	notFound      map[string]notFoundEntry
+	notFoundTTL   time.Duration
+	notFoundMutex sync.Mutex
+	now           func() time.Time
 }
 
-// NewSchemaManager creates a new schema manager
+// NewSchemaManager creates a new schema manager serving the embedded schemas
 func NewSchemaManager() *SchemaManager {
+	sm := newSchemaManager(embeddedSchemas)
+	sm.builderManifests = embeddedBuilderManifests
+	return sm
+}
+
+// NewSchemaManagerFromDir creates a schema manager serving the schemas of a directory with the layout of the embedded
+// schemas: a directory per version e.g. 0.139.0/receiver_otlp.yaml. It serves custom distributions generated from
+// a collector builder manifest.
+func NewSchemaManagerFromDir(dir string) (*SchemaManager, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schemas directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("schemas directory %s is not a directory", dir)
+	}
+	sm := newSchemaManager(dirSchemas{os.DirFS(dir)})
+	if _, err := sm.GetAllVersions(); err != nil {
+		return nil, fmt.Errorf("%w in %s", err, dir)
+	}
+	return sm, nil
+}
+
+func newSchemaManager(schemas fs.FS) *SchemaManager {
 	return &SchemaManager{
-		cache: make(map[string]*ComponentSchema),
+		schemas:          schemas,
+		cache:            make(map[string]*ComponentSchema),
+		componentIndex:   buildComponentIndex(schemas),
+		manifests:        make(map[string]*ComponentManifest),
+		configSchemas:    make(map[string][]byte),
+		searchWeights:    DefaultSearchWeights,
+		inputLimits:      DefaultInputLimits,
+		embeddingFunc:    createSimpleEmbeddingFunc(),
+		translationCache: make(map[string]string),
+		translationCalls: make(map[string]*translationCall),
+		httpClient:       http.DefaultClient,
+		observer:         noopObserver{},
+		notFound:         make(map[string]notFoundEntry),
+		notFoundTTL:      DefaultNotFoundCacheTTL,
+		now:              time.Now,
+	}
+}
+
+// dirSchemas serves a directory of versions under the schemas path of the embedded schemas
+type dirSchemas struct {
+	fs.FS
+}
+
+// Open opens schemas/<name> of the embedded layout as <name> of the directory
+func (d dirSchemas) Open(name string) (fs.File, error) {
+	if name == "schemas" {
+		return d.FS.Open(".")
 	}
+	rest, ok := strings.CutPrefix(name, "schemas/")
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return d.FS.Open(rest)
+}
+
+// SetEmbeddingFunc configures the embedding of the documents and queries, it has to be set before the first query.
+// The default is a hash based embedding without external dependencies.
+func (sm *SchemaManager) SetEmbeddingFunc(embeddingFunc chromem.EmbeddingFunc) {
+	sm.embeddingFunc = embeddingFunc
+}
+
+// SetTranslator configures the translator used when a README is not available in the requested locale
+func (sm *SchemaManager) SetTranslator(translator Translator) {
+	sm.translator = translator
+}
+
+// SetHTTPClient configures the HTTP client downloading the advisory databases
+func (sm *SchemaManager) SetHTTPClient(client *http.Client) {
+	sm.httpClient = client
 }
 
 // createSimpleEmbeddingFunc creates a simple hash-based embedding function for testing
@@ -118,99 +231,47 @@ func createSimpleEmbeddingFunc() chromem.EmbeddingFunc {
 	}
 }
 
-// initRAGDatabase initializes the RAG database and indexes all markdown files
-func (sm *SchemaManager) initRAGDatabase() error {
-	var err error
-	sm.ragInit.Do(func() {
-		// Create a new ChromaDB instance
-		sm.ragDB = chromem.NewDB()
-
-		// Create a collection for documentation
-		embeddingFunc := createSimpleEmbeddingFunc()
-		metadata := map[string]string{
-			"description": "OpenTelemetry Collector Component Documentation",
-		}
-
-		collection, collErr := sm.ragDB.CreateCollection("otel-docs", metadata, embeddingFunc)
-		if collErr != nil {
-			err = fmt.Errorf("failed to create RAG collection: %w", collErr)
-			return
-		}
-		sm.ragCollection = collection
-
-		// Get all versions to index documentation from all versions
-		versions, vErr := sm.GetAllVersions()
-		if vErr != nil {
-			err = fmt.Errorf("failed to get versions for RAG indexing: %w", vErr)
-			return
-		}
-
-		// Index all markdown files across all versions
-		for _, version := range versions {
-			if indexErr := sm.indexMarkdownFiles(version); indexErr != nil {
-				err = fmt.Errorf("failed to index markdown files for version %s: %w", version, indexErr)
-				return
-			}
-		}
-	})
-	return err
-}
-
-// indexMarkdownFiles indexes all markdown files for a specific version
-func (sm *SchemaManager) indexMarkdownFiles(version string) error {
-	schemaPath := fmt.Sprintf("schemas/%s", version)
-	entries, err := fs.ReadDir(embeddedSchemas, schemaPath)
+// markdownDocuments returns the documents of the READMEs of the components listed in the manifest of a version
+func (sm *SchemaManager) markdownDocuments(version string) ([]chromem.Document, error) {
+	manifest, err := sm.GetComponentManifest(version)
 	if err != nil {
-		return fmt.Errorf("failed to read schema directory for version %s: %w", version, err)
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+	schemaPath := fmt.Sprintf("schemas/%s", version)
+	var docs []chromem.Document
+	for _, component := range manifest.Components {
+		// Only the original READMEs are indexed, not the pre-translated type_name.locale.md
+		readme := component.ReadmeFile()
+		if readme == "" {
 			continue
 		}
 
 		// Read the markdown file
-		filePath := filepath.Join(schemaPath, entry.Name())
-		content, err := fs.ReadFile(embeddedSchemas, filePath)
+		filePath := filepath.Join(schemaPath, readme)
+		content, err := fs.ReadFile(sm.schemas, filePath)
 		if err != nil {
 			// Log warning but continue with other files
 			fmt.Printf("Warning: failed to read markdown file %s: %v\n", filePath, err)
 			continue
 		}
 
-		// Create document metadata
-		componentName := strings.TrimSuffix(entry.Name(), ".md")
-		metadata := map[string]string{
-			"version":    version,
-			"component":  componentName,
-			"file_path":  filePath,
-			"file_type":  "markdown",
-		}
-
-		// Parse component type and name
-		parts := strings.SplitN(componentName, "_", 2)
-		if len(parts) == 2 {
-			metadata["component_type"] = parts[0]
-			metadata["component_name"] = parts[1]
-		}
-
-		// Create document for RAG database
-		docID := fmt.Sprintf("%s/%s", version, componentName)
-		doc := chromem.Document{
-			ID:       docID,
-			Content:  string(content),
-			Metadata: metadata,
-		}
-
-		// Add document to RAG collection
-		if err := sm.ragCollection.AddDocument(context.Background(), doc); err != nil {
-			// Log warning but continue with other files
-			fmt.Printf("Warning: failed to add document %s to RAG database: %v\n", docID, err)
-			continue
-		}
-	}
-
-	return nil
+		componentName := fmt.Sprintf("%s_%s", component.Type, component.Name)
+		docs = append(docs, chromem.Document{
+			ID:      fmt.Sprintf("%s/%s", version, componentName),
+			Content: string(content),
+			Metadata: map[string]string{
+				"version":        version,
+				"component":      componentName,
+				"component_type": string(component.Type),
+				"component_name": component.Name,
+				"file_path":      filePath,
+				"file_type":      "markdown",
+			},
+		})
+	}
+
+	return docs, nil
 }
 
 // GetComponentSchema returns the YAML schema for a specific component
@@ -219,18 +280,33 @@ func (sm *SchemaManager) GetComponentSchema(componentType ComponentType, compone
 	cacheKey := fmt.Sprintf("%s_%s_%s", componentType, componentName, version)
 
 	// Check cache first
-	if schema, exists := sm.cache[cacheKey]; exists {
+	sm.cacheMutex.RLock()
+	schema, exists := sm.cache[cacheKey]
+	sm.cacheMutex.RUnlock()
+	if exists {
+		sm.observer.CacheAccess(CacheSchema, true)
 		return schema, nil
 	}
+	sm.observer.CacheAccess(CacheSchema, false)
+	if notFound, ok := sm.cachedNotFound(cacheKey); ok {
+		return nil, notFound
+	}
 
 	// Load schema from file
 	schema, err := sm.loadSchemaFromFile(componentType, componentName, version)
+	sm.observer.SchemaLoaded(componentType, version, err)
 	if err != nil {
+		var notFound *ComponentNotFoundError
+		if errors.As(err, &notFound) {
+			sm.cacheNotFound(cacheKey, notFound)
+		}
 		return nil, err
 	}
 
 	// Cache the result
+	sm.cacheMutex.Lock()
 	sm.cache[cacheKey] = schema
+	sm.cacheMutex.Unlock()
 
 	return schema, nil
 }
@@ -252,6 +328,10 @@ func (sm *SchemaManager) ListAvailableComponents(version string) (map[ComponentT
 
 // ValidateComponentJSON validates a component configuration JSON against its schema
 func (sm *SchemaManager) ValidateComponentJSON(componentType ComponentType, componentName string, version string, jsonData []byte) (*gojsonschema.Result, error) {
+	if err := sm.inputLimits.CheckJSON(jsonData); err != nil {
+		return nil, err
+	}
+
 	// Get the component schema
 	componentSchema, err := sm.GetComponentSchema(componentType, componentName, version)
 	if err != nil {
@@ -282,9 +362,9 @@ func (sm *SchemaManager) ValidateComponentJSON(componentType ComponentType, comp
 // ValidateComponentYAML validates a component configuration YAML against its schema
 func (sm *SchemaManager) ValidateComponentYAML(componentType ComponentType, componentName string, version string, yamlData []byte) (*gojsonschema.Result, error) {
 	// Parse YAML data to interface{}
-	var data interface{}
-	if err := yaml.Unmarshal(yamlData, &data); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML data: %w", err)
+	data, err := sm.inputLimits.ParseYAML(yamlData)
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert to JSON for validation
@@ -305,64 +385,128 @@ func (sm *SchemaManager) GetComponentReadme(componentType ComponentType, compone
 	// Load from embedded filesystem
 	schemaPath := fmt.Sprintf("schemas/%s", version)
 	embeddedFilepath := filepath.Join(schemaPath, filename)
-	data, err := fs.ReadFile(embeddedSchemas, embeddedFilepath)
+	data, err := fs.ReadFile(sm.schemas, embeddedFilepath)
 	if err != nil {
+		if versions := sm.GetComponentVersions(componentType, componentName); len(versions) > 0 && !slices.Contains(versions, version) {
+			return "", sm.componentNotFound(componentType, componentName, version)
+		}
 		return "", fmt.Errorf("README not found for component %s %s v%s", componentType, componentName, version)
 	}
 
 	return string(data), nil
 }
 
-// GetChangelog returns the changelog content for a specific collector version
-func (sm *SchemaManager) GetChangelog(version string) (string, error) {
-	// Load changelog.md from embedded filesystem
-	schemaPath := fmt.Sprintf("schemas/%s", version)
-	embeddedFilepath := filepath.Join(schemaPath, "changelog.md")
-	data, err := fs.ReadFile(embeddedSchemas, embeddedFilepath)
-	if err != nil {
-		return "", fmt.Errorf("changelog not found for version %s", version)
+// GetComponentReadmeLocalized returns the README content for a specific component in the requested locale.
+// A pre-translated README from the schema bundle (format: type_name.locale.md) is preferred,
+// otherwise the README is translated with the configured translator and cached.
+func (sm *SchemaManager) GetComponentReadmeLocalized(ctx context.Context, componentType ComponentType, componentName string, version string, locale string) (string, error) {
+	locale = normalizeLocale(locale)
+	if locale == "" || locale == "en" || strings.HasPrefix(locale, "en-") {
+		return sm.GetComponentReadme(componentType, componentName, version)
 	}
 
-	return string(data), nil
-}
-
-// listEmbeddedComponents lists components from embedded filesystem
-func (sm *SchemaManager) listEmbeddedComponents(version string) (map[ComponentType][]string, error) {
-	components := make(map[ComponentType][]string)
-
-	// Read embedded directory
+	// Look for a pre-translated README, falling back from e.g. pt-br to pt
 	schemaPath := fmt.Sprintf("schemas/%s", version)
-	entries, err := fs.ReadDir(embeddedSchemas, schemaPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read embedded schema directory: %w", err)
+	for _, candidate := range localeCandidates(locale) {
+		filename := fmt.Sprintf("%s_%s.%s.md", componentType, componentName, candidate)
+		data, err := fs.ReadFile(sm.schemas, filepath.Join(schemaPath, filename))
+		if err == nil {
+			return string(data), nil
+		}
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
-			continue
+	cacheKey := fmt.Sprintf("%s_%s_%s_%s", componentType, componentName, version, locale)
+	sm.translationMutex.Lock()
+	if translated, exists := sm.translationCache[cacheKey]; exists {
+		sm.translationMutex.Unlock()
+		sm.observer.CacheAccess(CacheTranslation, true)
+		return translated, nil
+	}
+	sm.observer.CacheAccess(CacheTranslation, false)
+	if call, running := sm.translationCalls[cacheKey]; running {
+		sm.translationMutex.Unlock()
+		select {
+		case <-call.done:
+			return call.translated, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
 		}
+	}
+	translator := sm.translator
+	if translator == nil {
+		sm.translationMutex.Unlock()
+		return "", fmt.Errorf("README for component %s %s v%s is not available in locale %s and no translator is configured", componentType, componentName, version, locale)
+	}
+	call := &translationCall{done: make(chan struct{})}
+	sm.translationCalls[cacheKey] = call
+	sm.translationMutex.Unlock()
 
-		// Remove .yaml extension
-		name := strings.TrimSuffix(entry.Name(), ".yaml")
+	// The lock is not held during the translation, a slow translation service does not block the other READMEs
+	call.translated, call.err = sm.translateReadme(ctx, translator, componentType, componentName, version, locale)
 
-		// Parse component type and name from filename (format: type_name.yaml)
-		parts := strings.SplitN(name, "_", 2)
-		if len(parts) != 2 {
-			continue // Skip files that don't match the expected format
-		}
+	sm.translationMutex.Lock()
+	delete(sm.translationCalls, cacheKey)
+	if call.err == nil {
+		sm.cacheTranslation(cacheKey, call.translated)
+	}
+	sm.translationMutex.Unlock()
+	close(call.done)
+	return call.translated, call.err
+}
 
-		componentType := ComponentType(parts[0])
-		componentName := parts[1]
+// translationCall is a running translation of a README, done is closed when the translation finished
+type translationCall struct {
+	done       chan struct{}
+	translated string
+	err        error
+}
 
-		// Validate component type
-		if !isValidComponentType(componentType) {
-			continue
+// translateReadme translates the README of a component with the translator
+func (sm *SchemaManager) translateReadme(ctx context.Context, translator Translator, componentType ComponentType, componentName string, version string, locale string) (string, error) {
+	readme, err := sm.GetComponentReadme(componentType, componentName, version)
+	if err != nil {
+		return "", err
+	}
+	translated, err := translator.Translate(ctx, readme, locale)
+	if err != nil {
+		return "", fmt.Errorf("failed to translate README for component %s %s v%s to %s: %w", componentType, componentName, version, locale, err)
+	}
+	return translated, nil
+}
+
+// cacheTranslation caches a translated README, when the cache is full an arbitrary translation is dropped. The caller
+// holds the translation mutex.
+func (sm *SchemaManager) cacheTranslation(cacheKey string, translated string) {
+	if len(sm.translationCache) >= maxTranslationCacheEntries {
+		for key := range sm.translationCache {
+			delete(sm.translationCache, key)
+			break
 		}
+	}
+	sm.translationCache[cacheKey] = translated
+}
+
+// normalizeLocale lowercases the locale and uses "-" as the region separator (e.g. pt_BR -> pt-br)
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
 
-		components[componentType] = append(components[componentType], componentName)
+// localeCandidates returns the locale followed by its base language if it has a region (e.g. pt-br, pt)
+func localeCandidates(locale string) []string {
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found && base != "" {
+		candidates = append(candidates, base)
 	}
+	return candidates
+}
 
-	return components, nil
+// listEmbeddedComponents lists the components of the manifest of a version
+func (sm *SchemaManager) listEmbeddedComponents(version string) (map[ComponentType][]string, error) {
+	manifest, err := sm.GetComponentManifest(version)
+	if err != nil {
+		return nil, err
+	}
+	return manifest.componentsByType(), nil
 }
 
 // loadSchemaFromFile loads a schema from embedded files
@@ -373,9 +517,9 @@ func (sm *SchemaManager) loadSchemaFromFile(componentType ComponentType, compone
 	// Load from embedded filesystem
 	schemaPath := fmt.Sprintf("schemas/%s", version)
 	embeddedFilepath := filepath.Join(schemaPath, filename)
-	data, err := fs.ReadFile(embeddedSchemas, embeddedFilepath)
+	data, err := fs.ReadFile(sm.schemas, embeddedFilepath)
 	if err != nil {
-		return nil, fmt.Errorf("schema not found for component %s %s", componentType, componentName)
+		return nil, sm.componentNotFound(componentType, componentName, version)
 	}
 
 	// Parse YAML schema
@@ -395,19 +539,9 @@ func (sm *SchemaManager) loadSchemaFromFile(componentType ComponentType, compone
 	}, nil
 }
 
-// isValidComponentType checks if the component type is valid
-func isValidComponentType(componentType ComponentType) bool {
-	switch componentType {
-	case ComponentTypeReceiver, ComponentTypeProcessor, ComponentTypeExporter, ComponentTypeExtension, ComponentTypeConnector:
-		return true
-	default:
-		return false
-	}
-}
-
 // GetLatestVersion returns the latest version available in the schemas directory
 func (sm *SchemaManager) GetLatestVersion() (string, error) {
-	entries, err := fs.ReadDir(embeddedSchemas, "schemas")
+	entries, err := fs.ReadDir(sm.schemas, "schemas")
 	if err != nil {
 		return "", fmt.Errorf("failed to read schemas directory: %w", err)
 	}
@@ -434,7 +568,7 @@ func (sm *SchemaManager) GetLatestVersion() (string, error) {
 
 // GetAllVersions returns all versions available in the schemas directory
 func (sm *SchemaManager) GetAllVersions() ([]string, error) {
-	entries, err := fs.ReadDir(embeddedSchemas, "schemas")
+	entries, err := fs.ReadDir(sm.schemas, "schemas")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read schemas directory: %w", err)
 	}
@@ -460,35 +594,15 @@ func (sm *SchemaManager) GetAllVersions() ([]string, error) {
 // GetComponentNames returns all component names for a given version and component type
 func (sm *SchemaManager) GetComponentNames(componentType ComponentType, version string) ([]string, error) {
 	// Validate component type
-	if !isValidComponentType(componentType) {
+	if !componentType.Valid() {
 		return nil, fmt.Errorf("invalid component type: %s", componentType)
 	}
 
-	// Read embedded directory for the specific version
-	schemaPath := fmt.Sprintf("schemas/%s", version)
-	entries, err := fs.ReadDir(embeddedSchemas, schemaPath)
+	manifest, err := sm.GetComponentManifest(version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read schema directory for version %s: %w", version, err)
-	}
-
-	var componentNames []string
-	prefix := string(componentType) + "_"
-
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
-			continue
-		}
-
-		// Check if the file matches the component type pattern (e.g., "receiver_otlp.yaml")
-		if strings.HasPrefix(entry.Name(), prefix) {
-			// Extract component name by removing prefix and .yaml suffix
-			name := strings.TrimSuffix(entry.Name(), ".yaml")
-			componentName := strings.TrimPrefix(name, prefix)
-			if componentName != "" {
-				componentNames = append(componentNames, componentName)
-			}
-		}
+		return nil, err
 	}
+	componentNames := manifest.componentsByType()[componentType]
 
 	if len(componentNames) == 0 {
 		return nil, fmt.Errorf("no %s components found for version %s", componentType, version)
@@ -499,22 +613,22 @@ func (sm *SchemaManager) GetComponentNames(componentType ComponentType, version
 
 // DocumentSearchResult represents a search result from the RAG database
 type DocumentSearchResult struct {
-	ID          string            `json:"id"`
-	Content     string            `json:"content"`
-	Metadata    map[string]string `json:"metadata"`
-	Similarity  float32           `json:"similarity"`
-	Component   string            `json:"component,omitempty"`
-	Version     string            `json:"version,omitempty"`
-	FilePath    string            `json:"file_path,omitempty"`
+	ID          string             `json:"id"`
+	Content     string             `json:"content"`
+	Metadata    map[string]string  `json:"metadata"`
+	Similarity  float32            `json:"similarity"`
+	Score       float32            `json:"score"`
+	Explanation *SearchExplanation `json:"explanation,omitempty"`
+	Component   string             `json:"component,omitempty"`
+	Version     string             `json:"version,omitempty"`
+	FilePath    string             `json:"file_path,omitempty"`
 }
 
 // QueryDocumentation searches the RAG database for relevant documentation based on the query text for a specific version
 func (sm *SchemaManager) QueryDocumentation(query string, version string, maxResults int) ([]DocumentSearchResult, error) {
-	sm.ragMutex.RLock()
-	defer sm.ragMutex.RUnlock()
-
-	// Initialize RAG database if not already done
-	if err := sm.initRAGDatabase(); err != nil {
+	// The index is built by the first search, a reload does not block the searches
+	index, err := sm.readyRAGSearchIndex(nil)
+	if err != nil {
 		return nil, fmt.Errorf("failed to initialize RAG database: %w", err)
 	}
 
@@ -524,35 +638,11 @@ func (sm *SchemaManager) QueryDocumentation(query string, version string, maxRes
 	}
 
 	// Perform the search with version filter
-	results, err := sm.ragCollection.Query(context.Background(), query, maxResults, where, nil)
+	searchResults, err := sm.hybridQuery(index, query, maxResults, where)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query RAG database: %w", err)
 	}
 
-	// Convert chromem results to our result structure
-	searchResults := make([]DocumentSearchResult, len(results))
-	for i, result := range results {
-		searchResult := DocumentSearchResult{
-			ID:         result.ID,
-			Content:    result.Content,
-			Metadata:   result.Metadata,
-			Similarity: result.Similarity,
-		}
-
-		// Extract commonly used metadata fields for easier access
-		if component, exists := result.Metadata["component"]; exists {
-			searchResult.Component = component
-		}
-		if resultVersion, exists := result.Metadata["version"]; exists {
-			searchResult.Version = resultVersion
-		}
-		if filePath, exists := result.Metadata["file_path"]; exists {
-			searchResult.FilePath = filePath
-		}
-
-		searchResults[i] = searchResult
-	}
-
 	return searchResults, nil
 }
 
@@ -560,11 +650,9 @@ func (sm *SchemaManager) QueryDocumentation(query string, version string, maxRes
 // Use this method when you need to filter by component type, component name, or version.
 // For simple version-scoped searches, use QueryDocumentation instead.
 func (sm *SchemaManager) QueryDocumentationWithFilters(query string, maxResults int, componentType, componentName, version string) ([]DocumentSearchResult, error) {
-	sm.ragMutex.RLock()
-	defer sm.ragMutex.RUnlock()
-
-	// Initialize RAG database if not already done
-	if err := sm.initRAGDatabase(); err != nil {
+	// The index is built by the first search, a reload does not block the searches
+	index, err := sm.readyRAGSearchIndex(nil)
+	if err != nil {
 		return nil, fmt.Errorf("failed to initialize RAG database: %w", err)
 	}
 
@@ -581,35 +669,11 @@ func (sm *SchemaManager) QueryDocumentationWithFilters(query string, maxResults
 	}
 
 	// Perform the search with filters
-	results, err := sm.ragCollection.Query(context.Background(), query, maxResults, where, nil)
+	searchResults, err := sm.hybridQuery(index, query, maxResults, where)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query RAG database with filters: %w", err)
 	}
 
-	// Convert chromem results to our result structure
-	searchResults := make([]DocumentSearchResult, len(results))
-	for i, result := range results {
-		searchResult := DocumentSearchResult{
-			ID:         result.ID,
-			Content:    result.Content,
-			Metadata:   result.Metadata,
-			Similarity: result.Similarity,
-		}
-
-		// Extract commonly used metadata fields for easier access
-		if component, exists := result.Metadata["component"]; exists {
-			searchResult.Component = component
-		}
-		if resultVersion, exists := result.Metadata["version"]; exists {
-			searchResult.Version = resultVersion
-		}
-		if filePath, exists := result.Metadata["file_path"]; exists {
-			searchResult.FilePath = filePath
-		}
-
-		searchResults[i] = searchResult
-	}
-
 	return searchResults, nil
 }
 
@@ -621,11 +685,22 @@ func (sm *SchemaManager) GetDeprecatedFields(componentType ComponentType, compon
 		return nil, fmt.Errorf("failed to get schema for %s %s v%s: %w", componentType, componentName, version, err)
 	}
 
-	var deprecatedFields []DeprecatedField
+	deprecatedFields := []DeprecatedField{}
 
 	// Recursively traverse the schema to find deprecated fields
 	sm.findDeprecatedFields(schema.Schema, "", &deprecatedFields)
 
+	// Suggest a migration for the fields whose replacement exists in the schema
+	fieldPaths := make(map[string]bool)
+	walkFields(schema.Schema, "", func(fieldPath string, _ map[string]interface{}) {
+		fieldPaths[fieldPath] = true
+	})
+	for i, field := range deprecatedFields {
+		if field.ReplacedBy != "" && fieldPaths[field.ReplacedBy] {
+			deprecatedFields[i].Migration = migrationSnippet(field.Name, field.ReplacedBy)
+		}
+	}
+
 	return deprecatedFields, nil
 }
 
@@ -667,6 +742,7 @@ func (sm *SchemaManager) findDeprecatedFields(schema map[string]interface{}, cur
 							Name:        fieldPath,
 							Description: description,
 							Type:        fieldType,
+							ReplacedBy:  ReplacementFromDescription(description),
 						}
 
 						*deprecatedFields = append(*deprecatedFields, deprecatedField)
@@ -679,3 +755,58 @@ func (sm *SchemaManager) findDeprecatedFields(schema map[string]interface{}, cur
 		}
 	}
 }
+
+// GetFieldDescriptions returns the descriptions of the component fields by their dotted path e.g. protocols.grpc.endpoint,
+// fields of array items share the path of the array
+func (sm *SchemaManager) GetFieldDescriptions(componentType ComponentType, componentName string, version string) (map[string]string, error) {
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema for %s %s v%s: %w", componentType, componentName, version, err)
+	}
+
+	descriptions := make(map[string]string)
+	walkFields(schema.Schema, "", func(fieldPath string, fieldSchema map[string]interface{}) {
+		if description, ok := fieldSchema["description"].(string); ok && description != "" {
+			descriptions[fieldPath] = description
+		}
+	})
+	return descriptions, nil
+}
+
+// GetFieldDefaults returns the default values of the component fields by their dotted path e.g. timeout, durations
+// are strings e.g. 200ms
+func (sm *SchemaManager) GetFieldDefaults(componentType ComponentType, componentName string, version string) (map[string]interface{}, error) {
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema for %s %s v%s: %w", componentType, componentName, version, err)
+	}
+
+	defaults := make(map[string]interface{})
+	walkFields(schema.Schema, "", func(fieldPath string, fieldSchema map[string]interface{}) {
+		if defaultValue, ok := fieldSchema["default"]; ok {
+			defaults[fieldPath] = defaultValue
+		}
+	})
+	return defaults, nil
+}
+
+// walkFields recursively calls fn with the dotted path and schema of the fields of a JSON schema
+func walkFields(schema map[string]interface{}, currentPath string, fn func(fieldPath string, fieldSchema map[string]interface{})) {
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		walkFields(items, currentPath, fn)
+	}
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for fieldName, fieldSchema := range properties {
+		fieldPath := fieldName
+		if currentPath != "" {
+			fieldPath = currentPath + "." + fieldName
+		}
+		if fieldSchemaMap, ok := fieldSchema.(map[string]interface{}); ok {
+			fn(fieldPath, fieldSchemaMap)
+			walkFields(fieldSchemaMap, fieldPath, fn)
+		}
+	}
+}
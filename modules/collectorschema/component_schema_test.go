@@ -1,10 +1,16 @@
 package collectorschema
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -671,6 +677,127 @@ func TestSchemaManager_QueryDocumentation_NoResults(t *testing.T) {
 	t.Logf("Unlikely query returned %d results for version 0.139.0", len(results))
 }
 
+type countingTranslator struct {
+	calls int
+}
+
+func (ct *countingTranslator) Translate(ctx context.Context, text string, locale string) (string, error) {
+	ct.calls++
+	return "[" + locale + "] " + text, nil
+}
+
+func TestSchemaManager_GetComponentReadmeLocalized(t *testing.T) {
+	manager := NewSchemaManager()
+
+	original, err := manager.GetComponentReadme(ComponentTypeReceiver, "otlp", "0.138.0")
+	require.NoError(t, err)
+
+	// English locale returns the original README
+	readme, err := manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "otlp", "0.138.0", "en-US")
+	require.NoError(t, err)
+	assert.Equal(t, original, readme)
+
+	// Without a translator a missing translation is an error
+	_, err = manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "otlp", "0.138.0", "de")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no translator is configured")
+
+	translator := &countingTranslator{}
+	manager.SetTranslator(translator)
+
+	readme, err = manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "otlp", "0.138.0", "pt_BR")
+	require.NoError(t, err)
+	assert.Equal(t, "[pt-br] "+original, readme)
+
+	// The translation is cached
+	_, err = manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "otlp", "0.138.0", "pt-BR")
+	require.NoError(t, err)
+	assert.Equal(t, 1, translator.calls)
+
+	_, err = manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "nonexistent", "0.138.0", "de")
+	require.Error(t, err)
+}
+
+// blockingTranslator blocks the translations of a locale until release is closed
+type blockingTranslator struct {
+	locale  string
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (bt *blockingTranslator) Translate(ctx context.Context, text string, locale string) (string, error) {
+	bt.calls.Add(1)
+	if locale == bt.locale {
+		<-bt.release
+	}
+	return "[" + locale + "] " + text, nil
+}
+
+func TestSchemaManager_GetComponentReadmeLocalized_Concurrent(t *testing.T) {
+	manager := NewSchemaManager()
+	translator := &blockingTranslator{locale: "de", release: make(chan struct{})}
+	manager.SetTranslator(translator)
+
+	var wg sync.WaitGroup
+	readmes := make([]string, 3)
+	for i := range readmes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			readmes[i], _ = manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "otlp", "0.138.0", "de")
+		}()
+	}
+
+	// A running translation does not block the translations of the other READMEs
+	require.Eventually(t, func() bool { return translator.calls.Load() == 1 }, time.Second, time.Millisecond)
+	readme, err := manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "otlp", "0.138.0", "fr")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(readme, "[fr] "))
+
+	close(translator.release)
+	wg.Wait()
+	for _, readme := range readmes {
+		assert.True(t, strings.HasPrefix(readme, "[de] "))
+	}
+	// The concurrent requests of the same README share a translation
+	assert.Equal(t, int32(2), translator.calls.Load())
+}
+
+func TestSchemaManager_GetComponentReadmeLocalized_CacheLimit(t *testing.T) {
+	manager := NewSchemaManager()
+	manager.SetTranslator(&countingTranslator{})
+	for i := 0; i < maxTranslationCacheEntries; i++ {
+		manager.translationCache[fmt.Sprintf("key-%d", i)] = "translated"
+	}
+
+	_, err := manager.GetComponentReadmeLocalized(context.Background(), ComponentTypeReceiver, "otlp", "0.138.0", "de")
+	require.NoError(t, err)
+	assert.Len(t, manager.translationCache, maxTranslationCacheEntries)
+	assert.Contains(t, manager.translationCache, "receiver_otlp_0.138.0_de")
+}
+
+func TestSchemaManager_GetFieldDescriptions(t *testing.T) {
+	manager := NewSchemaManager()
+
+	descriptions, err := manager.GetFieldDescriptions(ComponentTypeReceiver, "otlp", "0.139.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Endpoint configures the address for this network connection.", descriptions["protocols.grpc.endpoint"])
+	assert.NotContains(t, descriptions, "protocols.grpc.transport")
+
+	_, err = manager.GetFieldDescriptions(ComponentTypeReceiver, "nonexistent", "0.139.0")
+	assert.Error(t, err)
+}
+
+func TestSchemaManager_GetFieldDefaults(t *testing.T) {
+	manager := NewSchemaManager()
+
+	defaults, err := manager.GetFieldDefaults(ComponentTypeProcessor, "batch", "0.139.0")
+	require.NoError(t, err)
+	assert.Equal(t, "200ms", defaults["timeout"])
+	assert.Equal(t, 8192, defaults["send_batch_size"])
+	assert.NotContains(t, defaults, "metadata_keys")
+}
+
 func BenchmarkSchemaManager_GetComponentSchema(b *testing.B) {
 	manager := NewSchemaManager()
 
@@ -700,3 +827,37 @@ func BenchmarkSchemaManager_QueryDocumentation(b *testing.B) {
 		}
 	}
 }
+
+func TestNewSchemaManagerFromDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "1.2.0"), 0o755))
+	for _, name := range []string{"receiver_otlp.yaml", "receiver_otlp.md", "exporter_debug.yaml"} {
+		data, err := os.ReadFile(filepath.Join("schemas", "0.139.0", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "1.2.0", name), data, 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1.2.0", "manifest.yaml"), []byte("dist:\n  name: otelcol-acme\n"), 0o644))
+
+	sm, err := NewSchemaManagerFromDir(dir)
+	require.NoError(t, err)
+	versions, err := sm.GetAllVersions()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.2.0"}, versions)
+	components, err := sm.ListAvailableComponents("1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, map[ComponentType][]string{ComponentTypeReceiver: {"otlp"}, ComponentTypeExporter: {"debug"}}, components)
+	_, err = sm.GetComponentSchema(ComponentTypeReceiver, "otlp", "1.2.0")
+	assert.NoError(t, err)
+	readme, err := sm.GetComponentReadme(ComponentTypeReceiver, "otlp", "1.2.0")
+	require.NoError(t, err)
+	assert.NotEmpty(t, readme)
+
+	// Components of the contrib distribution are not served
+	_, err = sm.GetComponentSchema(ComponentTypeProcessor, "batch", "1.2.0")
+	assert.Error(t, err)
+
+	_, err = NewSchemaManagerFromDir(t.TempDir())
+	assert.ErrorContains(t, err, "no versions found")
+	_, err = NewSchemaManagerFromDir(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package collectorschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// StabilityUnmaintained is the stability of components without active code owners, they are removed after 3
+	// months without new code owners
+	StabilityUnmaintained = "unmaintained"
+	// StabilityDeprecated is the stability of components slated for removal
+	StabilityDeprecated = "deprecated"
+)
+
+// ComponentStatus is the stability and the code owners of a component from its metadata.yaml, the contrib CODEOWNERS
+// file is generated from the code owners
+type ComponentStatus struct {
+	// Stability are the signals by stability level e.g. beta: [traces, metrics]
+	Stability     map[string][]string `yaml:"stability,omitempty" json:"stability,omitempty"`
+	Distributions []string            `yaml:"distributions,omitempty" json:"distributions,omitempty"`
+	// Codeowners are the GitHub handles of the active code owners
+	Codeowners       []string `yaml:"codeowners,omitempty" json:"codeowners,omitempty"`
+	Emeritus         []string `yaml:"emeritus,omitempty" json:"emeritus,omitempty"`
+	SeekingNewOwners bool     `yaml:"seeking_new_owners,omitempty" json:"seekingNewOwners,omitempty"`
+}
+
+// Unmaintained returns true if a signal of the component is unmaintained
+func (s *ComponentStatus) Unmaintained() bool {
+	return len(s.Stability[StabilityUnmaintained]) > 0
+}
+
+// Deprecated returns true if a signal of the component is deprecated
+func (s *ComponentStatus) Deprecated() bool {
+	return len(s.Stability[StabilityDeprecated]) > 0
+}
+
+// Warning returns the warning for configurations relying on the component, it is empty if no signal of the component
+// is deprecated or unmaintained
+func (s *ComponentStatus) Warning() string {
+	var warnings []string
+	if s.Deprecated() {
+		warnings = append(warnings, fmt.Sprintf("deprecated (%s) and slated for removal", strings.Join(s.Stability[StabilityDeprecated], ", ")))
+	}
+	if s.Unmaintained() {
+		warnings = append(warnings, fmt.Sprintf("unmaintained (%s) and removed unless new code owners are found", strings.Join(s.Stability[StabilityUnmaintained], ", ")))
+	}
+	return strings.Join(warnings, ", ")
+}
+
+var (
+	// readmeStatusRow is a row of the status table of a component README e.g. | Stability | [beta]: traces |
+	readmeStatusRow = regexp.MustCompile(`^\|\s*(.*?)\s*\|\s*(.*?)\s*\|$`)
+	// readmeStability is a stability level with its signals e.g. [beta]: traces, metrics
+	readmeStability = regexp.MustCompile(`\[(\w+)\]:\s*([\w, ]+)`)
+	// readmeLink is a markdown link or link reference e.g. [@owner] or [Code Owners](https://...)
+	readmeLink = regexp.MustCompile(`\[@?([^\]]+)\](\([^)]*\))?`)
+)
+
+// GetComponentStatus returns the stability and the code owners of a component captured when its schema was
+// generated, schemas generated without them are read from the status table of the component README
+func (sm *SchemaManager) GetComponentStatus(componentType ComponentType, componentName string, version string) (*ComponentStatus, error) {
+	manifest, err := sm.GetComponentManifest(version)
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range manifest.Components {
+		if component.Type != componentType || component.Name != componentName {
+			continue
+		}
+		if component.Status != nil {
+			return component.Status, nil
+		}
+		readme, err := sm.GetComponentReadme(componentType, componentName, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status of %s %s v%s: %w", componentType, componentName, version, err)
+		}
+		return parseReadmeStatus(readme), nil
+	}
+	return nil, sm.componentNotFound(componentType, componentName, version)
+}
+
+// parseReadmeStatus parses the status table generated by mdatagen at the top of a component README
+func parseReadmeStatus(readme string) *ComponentStatus {
+	status := &ComponentStatus{}
+	key := ""
+	inTable := false
+	for _, line := range strings.Split(readme, "\n") {
+		match := readmeStatusRow.FindStringSubmatch(strings.ReplaceAll(strings.TrimSpace(line), `\|`, "&#124;"))
+		if match == nil {
+			if inTable {
+				break
+			}
+			continue
+		}
+		inTable = true
+		// Continuation rows of a multi-line value have an empty key
+		if match[1] != "" {
+			key = strings.ToLower(readmeLink.ReplaceAllString(match[1], "$1"))
+		}
+		value := strings.Split(match[2], "&#124;")[0]
+
+		switch key {
+		case "stability":
+			for _, stability := range readmeStability.FindAllStringSubmatch(value, -1) {
+				if status.Stability == nil {
+					status.Stability = make(map[string][]string)
+				}
+				for _, signal := range strings.Split(stability[2], ",") {
+					if signal = strings.TrimSpace(signal); signal != "" {
+						status.Stability[stability[1]] = append(status.Stability[stability[1]], signal)
+					}
+				}
+			}
+		case "distributions":
+			status.Distributions = append(status.Distributions, readmeLinks(value)...)
+		case "code owners":
+			status.Codeowners = append(status.Codeowners, readmeLinks(value)...)
+			status.SeekingNewOwners = status.SeekingNewOwners || strings.Contains(match[2], "Seeking")
+		case "emeritus":
+			status.Emeritus = append(status.Emeritus, readmeLinks(value)...)
+		}
+	}
+	return status
+}
+
+// readmeLinks returns the text of the markdown links of a status table value e.g. owner of [@owner]
+func readmeLinks(value string) []string {
+	var links []string
+	for _, link := range readmeLink.FindAllStringSubmatch(value, -1) {
+		links = append(links, link[1])
+	}
+	return links
+}
@@ -0,0 +1,70 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetComponentStatus(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/components.yaml": {Data: []byte(`components:
+  - type: receiver
+    name: kafka
+    files: [receiver_kafka.yaml]
+    status:
+      stability:
+        beta: [metrics, logs, traces]
+      distributions: [contrib]
+      codeowners: [pavolloffay, MovieStoreGuy]
+  - type: exporter
+    name: legacy
+    files: [exporter_legacy.yaml, exporter_legacy.md]
+`)},
+		"schemas/1.0.0/receiver_kafka.yaml":  {Data: []byte("type: object\n")},
+		"schemas/1.0.0/exporter_legacy.yaml": {Data: []byte("type: object\n")},
+		// Schemas generated without the status are read from the README
+		"schemas/1.0.0/exporter_legacy.md": {Data: []byte(`# Legacy Exporter
+<!-- status autogenerated section -->
+| Status        |           |
+| ------------- |-----------|
+| Stability     | [deprecated]: traces   |
+|               | [unmaintained]: metrics, logs   |
+| Distributions | [contrib] |
+| [Code Owners](https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/main/CONTRIBUTING.md#becoming-a-code-owner)    | [@alice] \| Seeking more code owners! |
+| Emeritus      | [@bob], [@carol] |
+
+[deprecated]: https://github.com/open-telemetry/opentelemetry-collector/blob/main/docs/component-stability.md#deprecated
+<!-- end autogenerated section -->
+
+| Setting | Default |
+| ------- | ------- |
+| timeout | 5s |
+`)},
+	})
+
+	status, err := sm.GetComponentStatus(ComponentTypeReceiver, "kafka", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pavolloffay", "MovieStoreGuy"}, status.Codeowners)
+	assert.False(t, status.Unmaintained())
+	assert.Empty(t, status.Warning())
+
+	status, err = sm.GetComponentStatus(ComponentTypeExporter, "legacy", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, &ComponentStatus{
+		Stability:        map[string][]string{"deprecated": {"traces"}, "unmaintained": {"metrics", "logs"}},
+		Distributions:    []string{"contrib"},
+		Codeowners:       []string{"alice"},
+		Emeritus:         []string{"bob", "carol"},
+		SeekingNewOwners: true,
+	}, status)
+	assert.True(t, status.Unmaintained())
+	assert.True(t, status.Deprecated())
+	assert.Equal(t, "deprecated (traces) and slated for removal, unmaintained (metrics, logs) and removed unless new code owners are found", status.Warning())
+
+	_, err = sm.GetComponentStatus(ComponentTypeExporter, "missing", "1.0.0")
+	var notFound *ComponentNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
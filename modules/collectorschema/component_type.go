@@ -0,0 +1,73 @@
+package collectorschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ComponentType represents the type of OpenTelemetry component
+type ComponentType string
+
+const (
+	ComponentTypeReceiver  ComponentType = "receiver"
+	ComponentTypeProcessor ComponentType = "processor"
+	ComponentTypeExporter  ComponentType = "exporter"
+	ComponentTypeExtension ComponentType = "extension"
+	ComponentTypeConnector ComponentType = "connector"
+)
+
+// componentTypes are the component types in the order of the pipeline stages
+var componentTypes = []ComponentType{
+	ComponentTypeReceiver,
+	ComponentTypeProcessor,
+	ComponentTypeExporter,
+	ComponentTypeConnector,
+	ComponentTypeExtension,
+}
+
+// ComponentTypes returns all component types
+func ComponentTypes() []ComponentType {
+	return append([]ComponentType(nil), componentTypes...)
+}
+
+// ComponentTypeNames returns the names of all component types e.g. for the enum of a tool argument
+func ComponentTypeNames() []string {
+	names := make([]string, 0, len(componentTypes))
+	for _, componentType := range componentTypes {
+		names = append(names, string(componentType))
+	}
+	return names
+}
+
+// ParseComponentType parses a component type, the configuration section names e.g. receivers and any case are
+// accepted
+func ParseComponentType(kind string) (ComponentType, error) {
+	normalized := strings.ToLower(strings.TrimSpace(kind))
+	for _, componentType := range componentTypes {
+		if normalized == string(componentType) || normalized == componentType.Section() {
+			return componentType, nil
+		}
+	}
+	return "", fmt.Errorf("invalid component type %q, use one of %s", kind, strings.Join(ComponentTypeNames(), ", "))
+}
+
+// ComponentTypeFromSection returns the component type of a configuration section e.g. receivers, the type is invalid
+// for other sections
+func ComponentTypeFromSection(section string) ComponentType {
+	return ComponentType(strings.TrimSuffix(section, "s"))
+}
+
+// Valid returns true if the component type is known
+func (c ComponentType) Valid() bool {
+	for _, componentType := range componentTypes {
+		if c == componentType {
+			return true
+		}
+	}
+	return false
+}
+
+// Section returns the configuration section of the component type e.g. receivers
+func (c ComponentType) Section() string {
+	return string(c) + "s"
+}
@@ -0,0 +1,42 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComponentType(t *testing.T) {
+	for _, kind := range []string{"connector", "Connector", "connectors", " connector "} {
+		componentType, err := ParseComponentType(kind)
+		require.NoError(t, err)
+		assert.Equal(t, ComponentTypeConnector, componentType)
+	}
+
+	_, err := ParseComponentType("pipeline")
+	assert.EqualError(t, err, `invalid component type "pipeline", use one of receiver, processor, exporter, connector, extension`)
+}
+
+func TestComponentType(t *testing.T) {
+	assert.Len(t, ComponentTypes(), 5)
+	assert.True(t, ComponentTypeConnector.Valid())
+	assert.False(t, ComponentType("pipeline").Valid())
+	assert.Equal(t, "connectors", ComponentTypeConnector.Section())
+	assert.Equal(t, ComponentTypeConnector, ComponentTypeFromSection("connectors"))
+}
+
+func TestConnectorComponents(t *testing.T) {
+	sm := NewSchemaManager()
+	names, err := sm.GetComponentNames(ComponentTypeConnector, "0.139.0")
+	require.NoError(t, err)
+	assert.Contains(t, names, "spanmetrics")
+
+	schema, err := sm.GetComponentSchema(ComponentTypeConnector, "spanmetrics", "0.139.0")
+	require.NoError(t, err)
+	assert.Equal(t, ComponentTypeConnector, schema.Type)
+	_, err = sm.GetComponentReadme(ComponentTypeConnector, "spanmetrics", "0.139.0")
+	require.NoError(t, err)
+	_, err = sm.GetComponentSummary(ComponentTypeConnector, "spanmetrics", "0.139.0")
+	require.NoError(t, err)
+}
@@ -0,0 +1,159 @@
+package collectorschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ConfigSchemaFile is the JSON Schema of a full collector configuration in the schemas directory of a version
+const ConfigSchemaFile = "config.schema.json"
+
+// GetConfigSchema returns the draft-07 JSON Schema of a full collector configuration of the version with the
+// receivers, processors, exporters, extensions, connectors and service sections. The schema generated with the
+// component schemas is served, versions generated without it are composed from the component schemas.
+func (sm *SchemaManager) GetConfigSchema(version string) (map[string]interface{}, error) {
+	data, err := sm.configSchemaJSON(version)
+	if err != nil {
+		return nil, err
+	}
+	// Every caller gets its own copy of the cached schema
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse config schema for version %s: %w", version, err)
+	}
+	return schema, nil
+}
+
+// ValidateConfigYAML validates a full collector configuration YAML against the config schema of the version in a
+// single pass
+func (sm *SchemaManager) ValidateConfigYAML(version string, yamlData []byte) (*gojsonschema.Result, error) {
+	data, err := sm.inputLimits.ParseYAML(yamlData)
+	if err != nil {
+		return nil, err
+	}
+	return sm.validateConfigData(version, data)
+}
+
+// validateConfigData validates a parsed configuration against the config schema of the version
+func (sm *SchemaManager) validateConfigData(version string, data interface{}) (*gojsonschema.Result, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert YAML to JSON for validation: %w", err)
+	}
+
+	schemaData, err := sm.configSchemaJSON(version)
+	if err != nil {
+		return nil, err
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schemaData), gojsonschema.NewBytesLoader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("validation failed for the configuration of version %s: %w", version, err)
+	}
+	return result, nil
+}
+
+// configSchemaJSON returns the cached config schema of the version, it reads the generated schema file or composes
+// the schema on the first call
+func (sm *SchemaManager) configSchemaJSON(version string) ([]byte, error) {
+	sm.configSchemaMutex.Lock()
+	defer sm.configSchemaMutex.Unlock()
+	if data, ok := sm.configSchemas[version]; ok {
+		sm.observer.CacheAccess(CacheConfigSchema, true)
+		return data, nil
+	}
+	sm.observer.CacheAccess(CacheConfigSchema, false)
+
+	data, err := fs.ReadFile(sm.schemas, filepath.Join("schemas", version, ConfigSchemaFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		schema, buildErr := sm.buildConfigSchema(version)
+		if buildErr != nil {
+			return nil, buildErr
+		}
+		data, err = json.Marshal(schema)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config schema for version %s: %w", version, err)
+	}
+	sm.configSchemas[version] = data
+	return data, nil
+}
+
+// buildConfigSchema composes the JSON Schema of a full collector configuration from the component schemas of the
+// version. Component configurations are validated by the component schemas matched by the component ID e.g. otlp/backend.
+func (sm *SchemaManager) buildConfigSchema(version string) (map[string]interface{}, error) {
+	components, err := sm.ListAvailableComponents(version)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make(map[string]interface{})
+	properties := make(map[string]interface{})
+	for _, section := range sortedSectionNames() {
+		componentType := componentSections[section]
+		names := append([]string(nil), components[componentType]...)
+		sort.Strings(names)
+
+		patternProperties := make(map[string]interface{})
+		for _, name := range names {
+			componentSchema, err := sm.GetComponentSchema(componentType, name, version)
+			if err != nil {
+				return nil, err
+			}
+			definition := fmt.Sprintf("%s_%s", componentType, name)
+			definitions[definition] = nullableObjects(ToDraft07(componentSchema.Schema))
+			patternProperties["^"+regexpQuote(name)+"(/.+)?$"] = map[string]interface{}{
+				"$ref": "#/definitions/" + definition,
+			}
+		}
+		properties[section] = map[string]interface{}{
+			"type":                 "object",
+			"patternProperties":    patternProperties,
+			"additionalProperties": false,
+		}
+	}
+
+	componentList := map[string]interface{}{
+		"type":  "array",
+		"items": map[string]interface{}{"type": "string"},
+	}
+	properties["service"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"extensions": componentList,
+			"pipelines": map[string]interface{}{
+				"type": "object",
+				"patternProperties": map[string]interface{}{
+					"^(traces|metrics|logs|profiles)(/.+)?$": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"receivers":  componentList,
+							"processors": componentList,
+							"exporters":  componentList,
+						},
+						"required":             []string{"receivers", "exporters"},
+						"additionalProperties": false,
+					},
+				},
+				"additionalProperties": false,
+			},
+			"telemetry": map[string]interface{}{"type": "object"},
+		},
+		"additionalProperties": false,
+	}
+
+	return map[string]interface{}{
+		"$schema":              draft07,
+		"title":                fmt.Sprintf("OpenTelemetry Collector configuration %s", version),
+		"type":                 "object",
+		"properties":           properties,
+		"required":             []string{"service"},
+		"additionalProperties": false,
+		"definitions":          definitions,
+	}, nil
+}
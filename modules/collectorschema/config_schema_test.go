@@ -0,0 +1,74 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaManager_ValidateConfigYAML(t *testing.T) {
+	sm := NewSchemaManager()
+
+	result, err := sm.ValidateConfigYAML("0.138.0", []byte(`
+receivers:
+  otlp/in:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp/in]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), result.Errors())
+
+	result, err = sm.ValidateConfigYAML("0.138.0", []byte(`
+receivers:
+  unknown:
+service:
+  pipelines:
+    spans:
+      receivers: [unknown]
+      exporters: []
+`))
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+	assert.Len(t, result.Errors(), 2)
+
+	_, err = sm.ValidateConfigYAML("0.138.0", []byte("receivers: ["))
+	assert.ErrorContains(t, err, "failed to parse YAML data")
+}
+
+func TestSchemaManager_GetConfigSchema(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/receiver_otlp.yaml": {Data: []byte("type: object\nproperties:\n  endpoint:\n    type: string\n")},
+		"schemas/2.0.0/receiver_otlp.yaml": {Data: []byte("type: object\n")},
+		// The generated config schema is served instead of composing it from the component schemas
+		"schemas/2.0.0/" + ConfigSchemaFile: {Data: []byte(`{"title": "generated", "type": "object", "required": ["service"]}`)},
+	})
+
+	schema, err := sm.GetConfigSchema("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, draft07, schema["$schema"])
+	assert.Contains(t, schema["definitions"], "receiver_otlp")
+	// Callers get a copy of the cached schema
+	schema["title"] = "modified"
+	schema, err = sm.GetConfigSchema("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "OpenTelemetry Collector configuration 1.0.0", schema["title"])
+
+	schema, err = sm.GetConfigSchema("2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "generated", schema["title"])
+	result, err := sm.ValidateConfigYAML("2.0.0", []byte("receivers: {}\n"))
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+
+	_, err = sm.GetConfigSchema("3.0.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,344 @@
+package collectorschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Severities of the configuration issues
+const (
+	IssueSeverityError   = "error"
+	IssueSeverityWarning = "warning"
+)
+
+// PipelineSignals are the signals of the pipeline IDs e.g. traces for traces/backend
+var PipelineSignals = []string{"traces", "metrics", "logs", "profiles"}
+
+// ConfigValidation is the validation of a full collector configuration: the configuration against the config schema
+// of the version and the component references and the pipeline topology of the service
+type ConfigValidation struct {
+	Version string
+	// Schema is the validation of the configuration against the config schema of the version
+	Schema *gojsonschema.Result
+	// Issues are the errors and the warnings of the component references and the pipeline topology of the service
+	Issues []ConfigIssue
+}
+
+// ConfigIssue is a problem of the component references or the pipeline topology of a configuration
+type ConfigIssue struct {
+	// Severity is IssueSeverityError for configurations the collector fails to start with, else IssueSeverityWarning
+	Severity string `json:"severity"`
+	// Path is the "::" separated path of the issue e.g. service::pipelines::traces::exporters
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Valid returns true if the configuration is valid against the schema and has no error issues, warnings e.g. an
+// unused receiver do not make a configuration invalid
+func (c *ConfigValidation) Valid() bool {
+	if c.Schema != nil && !c.Schema.Valid() {
+		return false
+	}
+	for _, issue := range c.Issues {
+		if issue.Severity == IssueSeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// Topology is the component IDs defined in the sections of a configuration and the service referencing them
+type Topology struct {
+	Receivers  []string
+	Processors []string
+	Exporters  []string
+	Connectors []string
+	Extensions []string
+	// ServiceExtensions are the extensions enabled in service::extensions
+	ServiceExtensions []string
+	// Pipelines are the pipelines of service::pipelines by pipeline ID
+	Pipelines map[string]TopologyPipeline
+}
+
+// TopologyPipeline is the component IDs of a pipeline
+type TopologyPipeline struct {
+	Receivers  []string
+	Processors []string
+	Exporters  []string
+}
+
+// ValidateConfig validates a full collector configuration YAML against the config schema of the version and checks
+// the component references and the pipeline topology of the service with ValidateTopology
+func (sm *SchemaManager) ValidateConfig(version string, yamlData []byte) (*ConfigValidation, error) {
+	data, err := sm.inputLimits.ParseYAML(yamlData)
+	if err != nil {
+		return nil, err
+	}
+	config, ok := data.(map[string]interface{})
+	if !ok && data != nil {
+		return nil, fmt.Errorf("the configuration must be a map of the receivers, processors, exporters, connectors, extensions and service sections")
+	}
+	schema, err := sm.validateConfigData(version, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigValidation{Version: version, Schema: schema, Issues: ValidateTopology(configTopology(config))}, nil
+}
+
+// configTopology returns the topology of a parsed configuration, the sections and the lists of other types are
+// reported by the schema validation and skipped
+func configTopology(config map[string]interface{}) Topology {
+	ids := func(section interface{}) []string {
+		components, _ := section.(map[string]interface{})
+		return sortedKeys(components)
+	}
+	list := func(value interface{}) []string {
+		items, _ := value.([]interface{})
+		var values []string
+		for _, item := range items {
+			if id, ok := item.(string); ok {
+				values = append(values, id)
+			}
+		}
+		return values
+	}
+	service, _ := config["service"].(map[string]interface{})
+	topology := Topology{
+		Receivers:         ids(config["receivers"]),
+		Processors:        ids(config["processors"]),
+		Exporters:         ids(config["exporters"]),
+		Connectors:        ids(config["connectors"]),
+		Extensions:        ids(config["extensions"]),
+		ServiceExtensions: list(service["extensions"]),
+		Pipelines:         map[string]TopologyPipeline{},
+	}
+	pipelines, _ := service["pipelines"].(map[string]interface{})
+	for id, value := range pipelines {
+		pipeline, _ := value.(map[string]interface{})
+		topology.Pipelines[id] = TopologyPipeline{
+			Receivers:  list(pipeline["receivers"]),
+			Processors: list(pipeline["processors"]),
+			Exporters:  list(pipeline["exporters"]),
+		}
+	}
+	return topology
+}
+
+// ValidateTopology validates that pipelines reference defined components, connectors are wired on both ends with
+// signals they convert, pipelines do not form cycles and reports defined but unused components
+func ValidateTopology(topology Topology) []ConfigIssue {
+	var issues []ConfigIssue
+	errorf := func(path, format string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{Severity: IssueSeverityError, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	warnf := func(path, format string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{Severity: IssueSeverityWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(topology.Pipelines) == 0 {
+		errorf("service::pipelines", "at least one pipeline has to be configured")
+	}
+
+	usedReceivers := make(map[string]bool)
+	usedProcessors := make(map[string]bool)
+	usedExporters := make(map[string]bool)
+	connectorAsExporter := make(map[string][]string)
+	connectorAsReceiver := make(map[string][]string)
+
+	for _, pipelineID := range sortedKeys(topology.Pipelines) {
+		pipeline := topology.Pipelines[pipelineID]
+		path := "service::pipelines::" + pipelineID
+		if !slices.Contains(PipelineSignals, pipelineSignal(pipelineID)) {
+			errorf(path, "unknown signal %q, pipeline ID has to start with one of %s", pipelineSignal(pipelineID), strings.Join(PipelineSignals, ", "))
+		}
+		if len(pipeline.Receivers) == 0 {
+			errorf(path, "pipeline must have at least one receiver")
+		}
+		if len(pipeline.Exporters) == 0 {
+			errorf(path, "pipeline must have at least one exporter")
+		}
+
+		for _, id := range pipeline.Receivers {
+			switch {
+			case slices.Contains(topology.Receivers, id):
+				usedReceivers[id] = true
+			case slices.Contains(topology.Connectors, id):
+				connectorAsReceiver[id] = append(connectorAsReceiver[id], pipelineID)
+			default:
+				errorf(path+"::receivers", "references receiver %q which is not defined", id)
+			}
+		}
+		seenProcessors := make(map[string]bool)
+		for _, id := range pipeline.Processors {
+			if !slices.Contains(topology.Processors, id) {
+				errorf(path+"::processors", "references processor %q which is not defined", id)
+			}
+			if seenProcessors[id] {
+				errorf(path+"::processors", "processor %q is referenced more than once", id)
+			}
+			seenProcessors[id] = true
+			usedProcessors[id] = true
+		}
+		for _, id := range pipeline.Exporters {
+			switch {
+			case slices.Contains(topology.Exporters, id):
+				usedExporters[id] = true
+			case slices.Contains(topology.Connectors, id):
+				connectorAsExporter[id] = append(connectorAsExporter[id], pipelineID)
+			default:
+				errorf(path+"::exporters", "references exporter %q which is not defined", id)
+			}
+		}
+	}
+
+	for _, id := range slices.Sorted(slices.Values(topology.Connectors)) {
+		path := "connectors::" + id
+		_, exported := connectorAsExporter[id]
+		_, received := connectorAsReceiver[id]
+		switch {
+		case !exported && !received:
+			warnf(path, "connector is defined but not used in any pipeline")
+		case !exported:
+			errorf(path, "connector is used as a receiver but not as an exporter in any pipeline")
+		case !received:
+			errorf(path, "connector is used as an exporter but not as a receiver in any pipeline")
+		default:
+			for _, issue := range connectorConversionIssues(id, connectorAsExporter[id], connectorAsReceiver[id]) {
+				errorf(path, "%s", issue)
+			}
+		}
+	}
+
+	for _, id := range slices.Sorted(slices.Values(topology.Receivers)) {
+		if !usedReceivers[id] {
+			warnf("receivers::"+id, "receiver is defined but not used in any pipeline")
+		}
+	}
+	for _, id := range slices.Sorted(slices.Values(topology.Processors)) {
+		if !usedProcessors[id] {
+			warnf("processors::"+id, "processor is defined but not used in any pipeline")
+		}
+	}
+	for _, id := range slices.Sorted(slices.Values(topology.Exporters)) {
+		if !usedExporters[id] {
+			warnf("exporters::"+id, "exporter is defined but not used in any pipeline")
+		}
+	}
+
+	usedExtensions := make(map[string]bool)
+	for _, id := range topology.ServiceExtensions {
+		if !slices.Contains(topology.Extensions, id) {
+			errorf("service::extensions", "references extension %q which is not defined", id)
+		}
+		usedExtensions[id] = true
+	}
+	for _, id := range slices.Sorted(slices.Values(topology.Extensions)) {
+		if !usedExtensions[id] {
+			warnf("extensions::"+id, "extension is defined but not enabled in service::extensions")
+		}
+	}
+
+	if cycle := findCycle(topology); len(cycle) > 0 {
+		errorf("service::pipelines", "pipelines form a cycle through connectors: %s", strings.Join(cycle, " -> "))
+	}
+
+	return issues
+}
+
+// connectorConversionIssues returns the pipelines a connector is used in without a pipeline of a signal it converts
+// to or from on the other side, the collector fails to build the pipelines. Connectors without curated conversions are
+// not checked.
+func connectorConversionIssues(id string, exporterPipelines, receiverPipelines []string) []string {
+	name, _, _ := strings.Cut(id, "/")
+	conversions, ok := GetConnectorConversions(name)
+	if !ok {
+		return nil
+	}
+	var issues []string
+	for _, exporterPipeline := range exporterPipelines {
+		supported := false
+		for _, receiverPipeline := range receiverPipelines {
+			supported = supported || conversions.Converts(pipelineSignal(exporterPipeline), pipelineSignal(receiverPipeline))
+		}
+		if !supported {
+			issues = append(issues, fmt.Sprintf("connector is used as an exporter in %s pipeline but not as a receiver in any pipeline of a signal it converts %s to, supported conversions: %s",
+				exporterPipeline, pipelineSignal(exporterPipeline), strings.Join(conversions.Conversions, ", ")))
+		}
+	}
+	for _, receiverPipeline := range receiverPipelines {
+		supported := false
+		for _, exporterPipeline := range exporterPipelines {
+			supported = supported || conversions.Converts(pipelineSignal(exporterPipeline), pipelineSignal(receiverPipeline))
+		}
+		if !supported {
+			issues = append(issues, fmt.Sprintf("connector is used as a receiver in %s pipeline but not as an exporter in any pipeline of a signal it converts to %s, supported conversions: %s",
+				receiverPipeline, pipelineSignal(receiverPipeline), strings.Join(conversions.Conversions, ", ")))
+		}
+	}
+	return issues
+}
+
+// findCycle returns the pipelines forming a cycle via connectors or nil
+func findCycle(topology Topology) []string {
+	// Edges from a pipeline exporting to a connector to all pipelines receiving from it
+	edges := make(map[string][]string)
+	for _, from := range sortedKeys(topology.Pipelines) {
+		for _, exporter := range topology.Pipelines[from].Exporters {
+			if !slices.Contains(topology.Connectors, exporter) {
+				continue
+			}
+			for _, to := range sortedKeys(topology.Pipelines) {
+				if slices.Contains(topology.Pipelines[to].Receivers, exporter) {
+					edges[from] = append(edges[from], to)
+				}
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+	var cycle []string
+
+	var visit func(pipeline string) bool
+	visit = func(pipeline string) bool {
+		state[pipeline] = visiting
+		stack = append(stack, pipeline)
+		for _, next := range edges[pipeline] {
+			if state[next] == visiting {
+				for i, p := range stack {
+					if p == next {
+						cycle = append(append([]string{}, stack[i:]...), next)
+					}
+				}
+				return true
+			}
+			if state[next] == unvisited && visit(next) {
+				return true
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[pipeline] = visited
+		return false
+	}
+
+	for _, pipeline := range sortedKeys(topology.Pipelines) {
+		if state[pipeline] == unvisited && visit(pipeline) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// pipelineSignal returns the signal of a pipeline ID e.g. traces for traces/backend
+func pipelineSignal(pipelineID string) string {
+	signal, _, _ := strings.Cut(pipelineID, "/")
+	return signal
+}
@@ -0,0 +1,93 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaManager_ValidateConfig(t *testing.T) {
+	sm := NewSchemaManager()
+
+	validation, err := sm.ValidateConfig("0.139.0", []byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+  otlp/unused:
+processors:
+  batch:
+    send_batch_size: many
+exporters:
+  debug:
+connectors:
+  forward:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces/in:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [forward]
+    traces/out:
+      receivers: [forward, jaeger]
+      exporters: [debug, otlp/backend]
+`))
+	require.NoError(t, err)
+	assert.False(t, validation.Valid())
+
+	require.Len(t, validation.Schema.Errors(), 1)
+	assert.Equal(t, "processors.batch.send_batch_size", validation.Schema.Errors()[0].Field())
+	assert.Equal(t, []ConfigIssue{
+		{Severity: IssueSeverityError, Path: "service::pipelines::traces/out::receivers", Message: `references receiver "jaeger" which is not defined`},
+		{Severity: IssueSeverityError, Path: "service::pipelines::traces/out::exporters", Message: `references exporter "otlp/backend" which is not defined`},
+		{Severity: IssueSeverityWarning, Path: "receivers::otlp/unused", Message: "receiver is defined but not used in any pipeline"},
+		{Severity: IssueSeverityError, Path: "service::extensions", Message: `references extension "health_check" which is not defined`},
+	}, validation.Issues)
+}
+
+func TestSchemaManager_ValidateConfig_Valid(t *testing.T) {
+	sm := NewSchemaManager()
+
+	validation, err := sm.ValidateConfig("0.139.0", []byte(`
+receivers:
+  otlp/in:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp/in]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+	assert.True(t, validation.Valid())
+	assert.Empty(t, validation.Issues)
+
+	_, err = sm.ValidateConfig("0.139.0", []byte("[otlp]"))
+	assert.ErrorContains(t, err, "the configuration must be a map")
+}
+
+func TestValidateTopology(t *testing.T) {
+	issues := ValidateTopology(Topology{
+		Receivers:  []string{"otlp"},
+		Exporters:  []string{"debug"},
+		Connectors: []string{"spanmetrics", "forward"},
+		Pipelines: map[string]TopologyPipeline{
+			"traces":        {Receivers: []string{"otlp"}, Exporters: []string{"spanmetrics"}},
+			"logs":          {Receivers: []string{"spanmetrics"}, Exporters: []string{"debug"}},
+			"metrics/a":     {Receivers: []string{"forward"}, Exporters: []string{"forward"}},
+			"unknown/extra": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+		},
+	})
+
+	assert.Equal(t, []ConfigIssue{
+		{Severity: IssueSeverityError, Path: "service::pipelines::unknown/extra", Message: `unknown signal "unknown", pipeline ID has to start with one of traces, metrics, logs, profiles`},
+		{Severity: IssueSeverityError, Path: "connectors::spanmetrics", Message: "connector is used as an exporter in traces pipeline but not as a receiver in any pipeline of a signal it converts traces to, supported conversions: traces_to_metrics"},
+		{Severity: IssueSeverityError, Path: "connectors::spanmetrics", Message: "connector is used as a receiver in logs pipeline but not as an exporter in any pipeline of a signal it converts to logs, supported conversions: traces_to_metrics"},
+		{Severity: IssueSeverityError, Path: "service::pipelines", Message: "pipelines form a cycle through connectors: metrics/a -> metrics/a"},
+	}, issues)
+}
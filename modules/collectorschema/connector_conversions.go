@@ -0,0 +1,93 @@
+package collectorschema
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed connector_conversions.yaml
+var embeddedConnectorConversions []byte
+
+// ConnectorConversions are the signal conversions of a connector e.g. traces_to_metrics of spanmetrics
+type ConnectorConversions struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	// Conversions are <from>_to_<to> pairs of the signal of the pipeline the connector exports from and the signal of
+	// the pipeline it receives in, as the stability keys of the connector metadata.yaml
+	Conversions []string `yaml:"conversions" json:"conversions"`
+}
+
+type connectorConversionDatabase struct {
+	Connectors []ConnectorConversions `yaml:"connectors"`
+}
+
+var connectorConversions = sync.OnceValues(func() ([]ConnectorConversions, error) {
+	var database connectorConversionDatabase
+	if err := yaml.Unmarshal(embeddedConnectorConversions, &database); err != nil {
+		return nil, fmt.Errorf("failed to parse connector conversions: %w", err)
+	}
+	return database.Connectors, nil
+})
+
+// SignalConversion returns the conversion key of a pair of signals e.g. traces_to_metrics
+func SignalConversion(from, to string) string {
+	return from + "_to_" + to
+}
+
+// Converts returns true if the connector converts the from signal to the to signal
+func (c ConnectorConversions) Converts(from, to string) bool {
+	return slices.Contains(c.Conversions, SignalConversion(from, to))
+}
+
+// GetConnectorConversions returns the signal conversions of a connector, the bool is false for connectors without
+// curated conversions
+func GetConnectorConversions(name string) (ConnectorConversions, bool) {
+	connectors, err := connectorConversions()
+	if err != nil {
+		return ConnectorConversions{}, false
+	}
+	for _, connector := range connectors {
+		if connector.Name == name {
+			return connector, true
+		}
+	}
+	return ConnectorConversions{}, false
+}
+
+// FindConnectorConversions returns the connectors of a version converting the from signal to the to signal, an empty
+// signal matches any signal and a to signal of "same" matches conversions keeping the signal e.g. forward
+func (sm *SchemaManager) FindConnectorConversions(from, to, version string) ([]ConnectorConversions, error) {
+	connectors, err := connectorConversions()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := sm.GetComponentManifest(version)
+	if err != nil {
+		return nil, err
+	}
+	available := manifest.componentsByType()[ComponentTypeConnector]
+
+	matches := []ConnectorConversions{}
+	for _, connector := range connectors {
+		if !slices.Contains(available, connector.Name) {
+			continue
+		}
+		var conversions []string
+		for _, conversion := range connector.Conversions {
+			conversionFrom, conversionTo, _ := strings.Cut(conversion, "_to_")
+			if (from == "" || from == conversionFrom) && (to == "" || to == conversionTo || to == "same" && conversionFrom == conversionTo) {
+				conversions = append(conversions, conversion)
+			}
+		}
+		if len(conversions) > 0 {
+			connector.Conversions = conversions
+			matches = append(matches, connector)
+		}
+	}
+	return matches, nil
+}
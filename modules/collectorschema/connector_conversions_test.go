@@ -0,0 +1,44 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConnectorConversions(t *testing.T) {
+	spanmetrics, ok := GetConnectorConversions("spanmetrics")
+	require.True(t, ok)
+	assert.True(t, spanmetrics.Converts("traces", "metrics"))
+	assert.False(t, spanmetrics.Converts("logs", "metrics"))
+
+	_, ok = GetConnectorConversions("unknown")
+	assert.False(t, ok)
+}
+
+func TestFindConnectorConversions(t *testing.T) {
+	sm := NewSchemaManager()
+	connectors, err := sm.FindConnectorConversions("logs", "metrics", "0.139.0")
+	require.NoError(t, err)
+	require.Len(t, connectors, 1)
+	assert.Equal(t, "count", connectors[0].Name)
+	assert.Equal(t, []string{"logs_to_metrics"}, connectors[0].Conversions)
+
+	connectors, err = sm.FindConnectorConversions("traces", "same", "0.139.0")
+	require.NoError(t, err)
+	var names []string
+	for _, connector := range connectors {
+		names = append(names, connector.Name)
+		assert.Equal(t, []string{"traces_to_traces"}, connector.Conversions)
+	}
+	assert.Equal(t, []string{"failover", "forward", "routing"}, names)
+
+	connectors, err = sm.FindConnectorConversions("", "", "0.139.0")
+	require.NoError(t, err)
+	assert.Len(t, connectors, 5)
+
+	connectors, err = sm.FindConnectorConversions("profiles", "metrics", "0.139.0")
+	require.NoError(t, err)
+	assert.Empty(t, connectors)
+}
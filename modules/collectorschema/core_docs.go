@@ -0,0 +1,177 @@
+package collectorschema
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/philippgille/chromem-go"
+)
+
+// The core documentation pages are written for this project and are not versioned, they describe the configuration
+// common to the supported versions and the schemas of a version are the reference of its fields
+//
+//go:embed core_docs/*.md
+var embeddedCoreDocs embed.FS
+
+// CoreDoc is a documentation page of the collector core e.g. the service telemetry settings, the pages are embedded
+// as core_docs/<name>.md
+type CoreDoc struct {
+	// Name of the page e.g. service
+	Name string `json:"name"`
+	// Title is the first heading of the page
+	Title string `json:"title,omitempty"`
+	// Path is the package of the opentelemetry-collector repository the page documents e.g. config/configtls
+	Path string `json:"path"`
+}
+
+// coreDocPaths are the packages of the opentelemetry-collector repository the core documentation pages document by
+// name
+var coreDocPaths = map[string]string{
+	"service":        "service",
+	"confmap":        "confmap",
+	"configtls":      "config/configtls",
+	"confighttp":     "config/confighttp",
+	"configgrpc":     "config/configgrpc",
+	"exporterhelper": "exporter/exporterhelper",
+}
+
+// CoreDocNames returns the names of the core documentation pages
+func CoreDocNames() []string {
+	return sortedKeys(coreDocPaths)
+}
+
+// CoreDocPath returns the package of the opentelemetry-collector repository a core documentation page documents
+func CoreDocPath(name string) string {
+	return coreDocPaths[name]
+}
+
+// ListCoreDocs returns the core documentation pages
+func ListCoreDocs() ([]CoreDoc, error) {
+	docs := make([]CoreDoc, 0, len(coreDocPaths))
+	for _, name := range CoreDocNames() {
+		content, err := fs.ReadFile(embeddedCoreDocs, coreDocFile(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read core documentation %s: %w", name, err)
+		}
+		docs = append(docs, CoreDoc{Name: name, Title: markdownTitle(string(content)), Path: coreDocPaths[name]})
+	}
+	return docs, nil
+}
+
+// GetCoreDoc returns the content of a core documentation page e.g. service
+func GetCoreDoc(name string) (string, error) {
+	if _, ok := coreDocPaths[name]; !ok {
+		return "", fmt.Errorf("unknown core documentation %q, it can be %s", name, strings.Join(CoreDocNames(), ", "))
+	}
+	data, err := fs.ReadFile(embeddedCoreDocs, coreDocFile(name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read core documentation %s: %w", name, err)
+	}
+	return string(data), nil
+}
+
+// coreDocuments returns the sections of the core documentation pages as documents of a specific version, a section
+// answers a narrower question than the page e.g. how to change the log level of the collector
+func (sm *SchemaManager) coreDocuments(version string) []chromem.Document {
+	var docs []chromem.Document
+	for _, name := range CoreDocNames() {
+		filePath := coreDocFile(name)
+		content, err := fs.ReadFile(embeddedCoreDocs, filePath)
+		if err != nil {
+			continue
+		}
+		for _, section := range markdownSections(string(content)) {
+			id := fmt.Sprintf("%s/core/%s", version, name)
+			if section.anchor != "" {
+				id += "#" + section.anchor
+			}
+			docs = append(docs, chromem.Document{
+				ID:      id,
+				Content: section.content,
+				Metadata: map[string]string{
+					"version":   version,
+					"component": "core_" + name,
+					"core_doc":  name,
+					"file_path": filePath,
+					"file_type": "core",
+					"title":     section.title,
+				},
+			})
+		}
+	}
+	return docs
+}
+
+// markdownSection is the text under a heading of a markdown page
+type markdownSection struct {
+	// anchor is the GitHub anchor of the heading, empty for the text before the first section
+	anchor string
+	// title is the page title followed by the headings of the section e.g. Service Telemetry Logs
+	title   string
+	content string
+}
+
+// markdownSections splits a markdown page at the ## and deeper headings, headings in code blocks are ignored
+func markdownSections(content string) []markdownSection {
+	var sections []markdownSection
+	var headings []string
+	current := markdownSection{}
+	var lines []string
+	inCode := false
+	flush := func() {
+		current.content = strings.TrimSpace(strings.Join(lines, "\n"))
+		if current.content != "" {
+			sections = append(sections, current)
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if inCode || level == 0 || !strings.HasPrefix(line[level:], " ") {
+			lines = append(lines, line)
+			continue
+		}
+		heading := strings.TrimSpace(line[level:])
+		if level == 1 {
+			headings = []string{heading}
+			current.title = heading
+			lines = append(lines, line)
+			continue
+		}
+		flush()
+		// The headings of the page title and the parent sections
+		if len(headings) > level-1 {
+			headings = headings[:level-1]
+		}
+		headings = append(headings, heading)
+		current = markdownSection{anchor: markdownAnchor(heading), title: strings.Join(headings, " ")}
+		lines = append(lines, line)
+	}
+	flush()
+	return sections
+}
+
+// markdownAnchor returns the GitHub anchor of a heading e.g. client-settings
+func markdownAnchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+func coreDocFile(name string) string {
+	return path.Join("core_docs", name+".md")
+}
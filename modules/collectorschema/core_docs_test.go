@@ -0,0 +1,60 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoreDocs(t *testing.T) {
+	docs, err := ListCoreDocs()
+	require.NoError(t, err)
+	require.Len(t, docs, len(CoreDocNames()))
+	assert.Equal(t, CoreDoc{Name: "configtls", Title: "TLS configuration settings", Path: "config/configtls"}, docs[2])
+
+	doc, err := GetCoreDoc("service")
+	require.NoError(t, err)
+	assert.Contains(t, doc, "service::telemetry::logs::level")
+
+	_, err = GetCoreDoc("pipelines")
+	require.EqualError(t, err, `unknown core documentation "pipelines", it can be configgrpc, confighttp, configtls, confmap, exporterhelper, service`)
+}
+
+func TestQueryDocumentation_CoreDocs(t *testing.T) {
+	sm := NewSchemaManager()
+	tests := []struct {
+		query   string
+		section string
+	}{
+		{query: "how do I change the collector log level", section: "0.139.0/core/service#logs"},
+		{query: "collector telemetry metrics level", section: "0.139.0/core/service#metrics"},
+		{query: "environment variable default value", section: "0.139.0/core/confmap#expansion"},
+		{query: "skip the verification of the server certificate", section: "0.139.0/core/configtls#client-settings"},
+		{query: "cors allowed origins", section: "0.139.0/core/confighttp#server-settings"},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			results, err := sm.QueryDocumentation(test.query, "0.139.0", 5)
+			require.NoError(t, err)
+			var ids []string
+			for _, result := range results {
+				ids = append(ids, result.ID)
+				if result.ID == test.section {
+					assert.Equal(t, "core", result.Metadata["file_type"])
+					assert.Equal(t, "core_docs/"+result.Metadata["core_doc"]+".md", result.FilePath)
+				}
+			}
+			assert.Contains(t, ids, test.section)
+		})
+	}
+}
+
+func TestMarkdownSections(t *testing.T) {
+	sections := markdownSections("# Service\n\nIntro\n\n## Telemetry\n\n### Logs\n\n```yaml\n# level\n```\n\n## Pipelines\n\nText\n")
+	require.Len(t, sections, 4)
+	assert.Equal(t, markdownSection{title: "Service", content: "# Service\n\nIntro"}, sections[0])
+	assert.Equal(t, markdownSection{anchor: "telemetry", title: "Service Telemetry", content: "## Telemetry"}, sections[1])
+	assert.Equal(t, markdownSection{anchor: "logs", title: "Service Telemetry Logs", content: "### Logs\n\n```yaml\n# level\n```"}, sections[2])
+	assert.Equal(t, markdownSection{anchor: "pipelines", title: "Service Pipelines", content: "## Pipelines\n\nText"}, sections[3])
+}
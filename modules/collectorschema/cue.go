@@ -0,0 +1,335 @@
+package collectorschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CUEPackage is the package of the exported CUE definitions
+const CUEPackage = "otelcol"
+
+// Formats of the exported component schemas
+const (
+	SchemaFormatJSON = "json"
+	SchemaFormatCUE  = "cue"
+)
+
+// SchemaFormats are the formats of the exported component schemas
+var SchemaFormats = []string{SchemaFormatJSON, SchemaFormatCUE}
+
+var (
+	cueIdentifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	// cueKeywords cannot be used as unquoted field labels
+	cueKeywords = []string{"package", "import", "for", "in", "if", "let", "true", "false", "null"}
+)
+
+// GetComponentCUE returns the schema of a component converted to a CUE file with a definition of the component
+// configuration e.g. #OtlpReceiver, for pipelines validating collector configurations with CUE e.g.
+// cue vet -d '#OtlpReceiver' otlp.cue config.yaml
+func (sm *SchemaManager) GetComponentCUE(componentType ComponentType, componentName string, version string) (string, error) {
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return "", err
+	}
+	comment := fmt.Sprintf("OpenTelemetry Collector %s %s %s configuration, converted from the JSON schema", componentName, componentType, version)
+	return ToCUE(schema.Schema, CUEDefinitionName(componentType, componentName), comment), nil
+}
+
+// CUEDefinitionName returns the name of the CUE definition of a component configuration e.g. #K8sClusterReceiver for
+// the k8s_cluster receiver
+func CUEDefinitionName(componentType ComponentType, componentName string) string {
+	var name strings.Builder
+	name.WriteString("#")
+	words := strings.FieldsFunc(componentName+"_"+string(componentType), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	for _, word := range words {
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return name.String()
+}
+
+// ToCUE converts a JSON schema to a CUE file of the CUEPackage with a definition of the schema. The fields are
+// optional unless required, the required fields use the required field constraint of CUE v0.6. The objects are open
+// unless additionalProperties is false, the schemas do not cover every setting. Scalar defaults become CUE defaults,
+// descriptions and deprecations become comments and the keywords without a CUE equivalent are ignored.
+func ToCUE(schema map[string]interface{}, definition string, comment string) string {
+	w := &cueWriter{imports: map[string]bool{}}
+	body := w.expr(schema, 0)
+
+	var buf strings.Builder
+	if comment != "" {
+		buf.WriteString("// " + comment + "\n")
+	}
+	buf.WriteString("package " + CUEPackage + "\n\n")
+	imports := sortedKeys(w.imports)
+	switch len(imports) {
+	case 0:
+	case 1:
+		buf.WriteString(fmt.Sprintf("import %q\n\n", imports[0]))
+	default:
+		buf.WriteString("import (\n")
+		for _, imported := range imports {
+			buf.WriteString(fmt.Sprintf("\t%q\n", imported))
+		}
+		buf.WriteString(")\n\n")
+	}
+	buf.WriteString(definition + ": " + body + "\n")
+	return buf.String()
+}
+
+// cueWriter converts schemas to CUE expressions and collects the packages the expressions use
+type cueWriter struct {
+	imports map[string]bool
+}
+
+// expr returns the CUE expression of a schema, the nested lines of a struct are indented by indent+1 tabs
+func (w *cueWriter) expr(schema map[string]interface{}, indent int) string {
+	defaultValue, hasDefault := schema["default"]
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		values := make([]string, 0, len(enum))
+		for _, value := range enum {
+			literal := cueLiteral(value)
+			if hasDefault && sameValue(value, defaultValue) {
+				literal = "*" + literal
+			}
+			values = append(values, literal)
+		}
+		return strings.Join(values, " | ")
+	}
+	if constValue, ok := schema["const"]; ok {
+		return cueLiteral(constValue)
+	}
+
+	var expr string
+	switch {
+	case schema["anyOf"] != nil || schema["oneOf"] != nil:
+		alternatives, _ := schema["anyOf"].([]interface{})
+		if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+			alternatives = oneOf
+		}
+		expr = w.combine(alternatives, " | ", indent)
+	case schema["allOf"] != nil:
+		parts, _ := schema["allOf"].([]interface{})
+		expr = w.combine(parts, " & ", indent)
+	default:
+		types := schemaTypes(schema)
+		exprs := make([]string, 0, len(types))
+		for _, schemaType := range types {
+			exprs = append(exprs, w.typeExpr(schemaType, schema, indent))
+		}
+		expr = strings.Join(exprs, " | ")
+	}
+
+	if hasDefault && isCUEScalar(defaultValue) && defaultMatches(defaultValue, schemaTypes(schema)) {
+		return "*" + cueLiteral(defaultValue) + " | " + expr
+	}
+	return expr
+}
+
+// combine returns the expressions of the sub-schemas of anyOf, oneOf or allOf joined by the operator
+func (w *cueWriter) combine(schemas []interface{}, operator string, indent int) string {
+	exprs := make([]string, 0, len(schemas))
+	for _, item := range schemas {
+		if itemSchema, ok := item.(map[string]interface{}); ok {
+			exprs = append(exprs, "("+w.expr(itemSchema, indent)+")")
+		}
+	}
+	if len(exprs) == 0 {
+		return "_"
+	}
+	return strings.Join(exprs, operator)
+}
+
+// typeExpr returns the CUE expression of a schema type with its constraints e.g. int & >=0
+func (w *cueWriter) typeExpr(schemaType string, schema map[string]interface{}, indent int) string {
+	var constraints []string
+	switch schemaType {
+	case "string":
+		constraints = append(constraints, "string")
+		if pattern, ok := schema["pattern"].(string); ok {
+			constraints = append(constraints, "=~"+cueLiteral(pattern))
+		}
+		if minLength, ok := schema["minLength"]; ok {
+			w.imports["strings"] = true
+			constraints = append(constraints, fmt.Sprintf("strings.MinRunes(%s)", cueLiteral(minLength)))
+		}
+		if maxLength, ok := schema["maxLength"]; ok {
+			w.imports["strings"] = true
+			constraints = append(constraints, fmt.Sprintf("strings.MaxRunes(%s)", cueLiteral(maxLength)))
+		}
+	case "integer", "number":
+		if schemaType == "integer" {
+			constraints = append(constraints, "int")
+		} else {
+			constraints = append(constraints, "number")
+		}
+		for _, bound := range []struct{ keyword, operator string }{
+			{"minimum", ">="}, {"exclusiveMinimum", ">"}, {"maximum", "<="}, {"exclusiveMaximum", "<"},
+		} {
+			if value, ok := schema[bound.keyword]; ok && isCUEScalar(value) {
+				constraints = append(constraints, bound.operator+cueLiteral(value))
+			}
+		}
+	case "boolean":
+		constraints = append(constraints, "bool")
+	case "null":
+		constraints = append(constraints, "null")
+	case "array":
+		items := "_"
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			items = w.expr(itemSchema, indent)
+		}
+		constraints = append(constraints, "[..."+items+"]")
+		if minItems, ok := schema["minItems"]; ok {
+			w.imports["list"] = true
+			constraints = append(constraints, fmt.Sprintf("list.MinItems(%s)", cueLiteral(minItems)))
+		}
+		if maxItems, ok := schema["maxItems"]; ok {
+			w.imports["list"] = true
+			constraints = append(constraints, fmt.Sprintf("list.MaxItems(%s)", cueLiteral(maxItems)))
+		}
+	case "object":
+		return w.structExpr(schema, indent)
+	default:
+		return "_"
+	}
+	return strings.Join(constraints, " & ")
+}
+
+// structExpr returns the CUE struct of an object schema with a field per property
+func (w *cueWriter) structExpr(schema map[string]interface{}, indent int) string {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := stringValues(schema["required"])
+	var lines []string
+	tabs := strings.Repeat("\t", indent+1)
+	for _, name := range sortedKeys(properties) {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, comment := range cueComments(property) {
+			lines = append(lines, tabs+"// "+comment)
+		}
+		marker := "?"
+		if slices.Contains(required, name) {
+			marker = "!"
+		}
+		lines = append(lines, tabs+cueLabel(name)+marker+": "+w.expr(property, indent+1))
+	}
+
+	switch additional := schema["additionalProperties"].(type) {
+	case bool:
+		if additional {
+			lines = append(lines, tabs+"...")
+		}
+	case map[string]interface{}:
+		lines = append(lines, tabs+"[string]: "+w.expr(additional, indent+1))
+	default:
+		lines = append(lines, tabs+"...")
+	}
+
+	if len(lines) == 0 {
+		return "{}"
+	}
+	if len(lines) == 1 && strings.TrimSpace(lines[0]) == "..." {
+		return "{...}"
+	}
+	return "{\n" + strings.Join(lines, "\n") + "\n" + strings.Repeat("\t", indent) + "}"
+}
+
+// cueComments returns the comment lines of a field: its description, the defaults without a CUE equivalent and the
+// deprecation
+func cueComments(schema map[string]interface{}) []string {
+	var comments []string
+	if description, ok := schema["description"].(string); ok && description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+			comments = append(comments, strings.TrimRight(line, " \t"))
+		}
+	}
+	if defaultValue, ok := schema["default"]; ok && (!isCUEScalar(defaultValue) || !defaultMatches(defaultValue, schemaTypes(schema))) {
+		if _, isEnum := schema["enum"]; !isEnum {
+			comments = append(comments, "Default: "+cueLiteral(defaultValue))
+		}
+	}
+	if isDeprecated(schema) {
+		comments = append(comments, "Deprecated.")
+	}
+	return comments
+}
+
+// schemaTypes returns the types of a schema, an object for a schema with properties and no type
+func schemaTypes(schema map[string]interface{}) []string {
+	switch schemaType := schema["type"].(type) {
+	case string:
+		return []string{schemaType}
+	case []interface{}:
+		return stringValues(schemaType)
+	}
+	if _, ok := schema["properties"]; ok {
+		return []string{"object"}
+	}
+	return []string{""}
+}
+
+// defaultMatches returns true if a scalar default has one of the types, a default of another type would widen the
+// CUE disjunction
+func defaultMatches(value interface{}, types []string) bool {
+	for _, schemaType := range types {
+		switch v := value.(type) {
+		case string:
+			if schemaType == "string" {
+				return true
+			}
+		case bool:
+			if schemaType == "boolean" {
+				return true
+			}
+		case int, int64, uint64:
+			if schemaType == "integer" || schemaType == "number" {
+				return true
+			}
+		case float64:
+			isInteger := v == float64(int64(v))
+			if schemaType == "number" || schemaType == "integer" && isInteger {
+				return true
+			}
+		case nil:
+			if schemaType == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isCUEScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int64, uint64, float64, nil:
+		return true
+	}
+	return false
+}
+
+// cueLiteral returns a value as a CUE literal, JSON strings, numbers, lists and structs are valid CUE
+func cueLiteral(value interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "_"
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// cueLabel returns a field name as a CUE label, the names that are not identifiers or are keywords are quoted
+func cueLabel(name string) string {
+	if cueIdentifierPattern.MatchString(name) && !slices.Contains(cueKeywords, name) {
+		return name
+	}
+	return cueLiteral(name)
+}
@@ -0,0 +1,116 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCUEDefinitionName(t *testing.T) {
+	assert.Equal(t, "#OtlpReceiver", CUEDefinitionName(ComponentTypeReceiver, "otlp"))
+	assert.Equal(t, "#K8sClusterReceiver", CUEDefinitionName(ComponentTypeReceiver, "k8s_cluster"))
+	assert.Equal(t, "#PrometheusremotewriteExporter", CUEDefinitionName(ComponentTypeExporter, "prometheusremotewrite"))
+}
+
+func TestToCUE(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"endpoint"},
+		"properties": map[string]interface{}{
+			"endpoint": map[string]interface{}{"type": "string", "description": "Endpoint of the server.", "minLength": 1},
+			"mode":     map[string]interface{}{"type": "string", "enum": []interface{}{"fast", "safe"}, "default": "safe"},
+			"retries":  map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 10, "default": 3},
+			"ratio":    map[string]interface{}{"type": []interface{}{"number", "null"}},
+			"legacy":   map[string]interface{}{"type": "boolean", "deprecated": true},
+			"ports":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}, "minItems": 1},
+			"1st":      map[string]interface{}{"type": "string"},
+			"if":       map[string]interface{}{"type": "string"},
+			"labels":   map[string]interface{}{"type": "object", "default": map[string]interface{}{"a": "b"}, "additionalProperties": map[string]interface{}{"type": "string"}},
+			"strict": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": map[string]interface{}{
+					"timeout": map[string]interface{}{"type": "string", "pattern": `^[0-9]+\.?s$`, "default": "5s"},
+				},
+			},
+			"auth": map[string]interface{}{"anyOf": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{"type": "object", "properties": map[string]interface{}{"token": map[string]interface{}{"type": "string"}}},
+			}},
+		},
+	}
+
+	assert.Equal(t, `// Test configuration
+package otelcol
+
+import (
+	"list"
+	"strings"
+)
+
+#Test: {
+	"1st"?: string
+	auth?: (string) | ({
+		token?: string
+		...
+	})
+	// Endpoint of the server.
+	endpoint!: string & strings.MinRunes(1)
+	"if"?: string
+	// Default: {"a":"b"}
+	labels?: {
+		[string]: string
+	}
+	// Deprecated.
+	legacy?: bool
+	mode?: "fast" | *"safe"
+	ports?: [...int] & list.MinItems(1)
+	ratio?: number | null
+	retries?: *3 | int & >=0 & <=10
+	strict?: {
+		timeout?: *"5s" | string & =~"^[0-9]+\\.?s$"
+	}
+	...
+}
+`, ToCUE(schema, "#Test", "Test configuration"))
+}
+
+func TestToCUE_DefaultOfAnotherType(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"timeout": map[string]interface{}{"type": "integer", "default": "5s"},
+		},
+		"additionalProperties": false,
+	}
+	assert.Equal(t, `package otelcol
+
+#Test: {
+	// Default: "5s"
+	timeout?: int
+}
+`, ToCUE(schema, "#Test", ""))
+}
+
+func TestGetComponentCUE(t *testing.T) {
+	sm := NewSchemaManager()
+	cue, err := sm.GetComponentCUE(ComponentTypeProcessor, "batch", "0.139.0")
+	require.NoError(t, err)
+	assert.Contains(t, cue, "package otelcol\n")
+	assert.Contains(t, cue, "#BatchProcessor: {\n")
+	assert.Contains(t, cue, "\tsend_batch_size?: *8192 | int\n")
+	assert.Contains(t, cue, "\ttimeout?: *\"200ms\" | string & =~\"^[0-9]+(ns|us|µs|ms|s|m|h)$\"\n")
+
+	cue, err = sm.GetComponentCUE(ComponentTypeReceiver, "otlp", "0.139.0")
+	require.NoError(t, err)
+	assert.Contains(t, cue, "\tprotocols?: {\n\t\tgrpc?: {\n")
+	assert.Contains(t, cue, "\t\t\t// Endpoint configures the address for this network connection.\n\t\t\tendpoint?: string\n")
+
+	cue, err = sm.GetComponentCUE(ComponentTypeExporter, "otlp", "0.139.0")
+	require.NoError(t, err)
+	assert.Contains(t, cue, "\theaders?: {\n\t\t[string]: string\n\t}\n")
+
+	_, err = sm.GetComponentCUE(ComponentTypeReceiver, "nonexistent", "0.139.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,239 @@
+package collectorschema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kinds of a deprecation event
+const (
+	DeprecationKindDeprecated = "deprecated"
+	DeprecationKindRemoved    = "removed"
+)
+
+// Sources of a deprecation event
+const (
+	DeprecationSourceChangelog = "changelog"
+	DeprecationSourceSchema    = "schema"
+)
+
+var (
+	// changelogCode matches the code spans of a changelog entry e.g. `brokers`
+	changelogCode = regexp.MustCompile("`([^`]+)`")
+	// changelogReplacement matches the replacement of a deprecated or removed setting e.g. in favour of `traces::topic`
+	changelogReplacement = regexp.MustCompile("(?i)(?:in favou?r of|replaced (?:by|with)|use|migrate to|superseded by)\\s+`([^`]+)`")
+	// changelogRemoval matches the breaking changes removing a setting or component
+	changelogRemoval = regexp.MustCompile(`(?i)\b(remov|drop|delet)`)
+)
+
+// DeprecationEvent is a deprecation or removal of a component or field in a release
+type DeprecationEvent struct {
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Source  string `json:"source"`
+	Entry   string `json:"entry,omitempty"`
+}
+
+// FieldDeprecation is the deprecation timeline of a component field, or of the component itself if the field is empty
+type FieldDeprecation struct {
+	Field        string             `json:"field,omitempty"`
+	DeprecatedIn string             `json:"deprecatedIn,omitempty"`
+	RemovedIn    string             `json:"removedIn,omitempty"`
+	Replacement  string             `json:"replacement,omitempty"`
+	Events       []DeprecationEvent `json:"events"`
+}
+
+// DeprecationTimeline is the deprecation timeline of a component across the embedded versions
+type DeprecationTimeline struct {
+	Component string             `json:"component"`
+	Versions  []string           `json:"versions"`
+	Fields    []FieldDeprecation `json:"fields"`
+}
+
+// changelogEntry is a bullet of a changelog section e.g. Deprecations
+type changelogEntry struct {
+	Section string
+	Text    string
+}
+
+// parseChangelog returns the entries of a changelog with their lower case section heading
+func parseChangelog(changelog string) []changelogEntry {
+	var entries []changelogEntry
+	section := ""
+	for _, line := range strings.Split(changelog, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			section = strings.ToLower(trimmed)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") {
+			entries = append(entries, changelogEntry{Section: section, Text: strings.TrimPrefix(trimmed, "- ")})
+		}
+	}
+	return entries
+}
+
+// GetDeprecationTimeline combines the changelog deprecations and breaking changes with the schema deprecation flags
+// of all embedded versions into the deprecation timeline of a component. A non-empty field restricts the timeline to
+// the field, nested fields are dotted e.g. traces.topic.
+func (sm *SchemaManager) GetDeprecationTimeline(componentType ComponentType, componentName string, field string) (*DeprecationTimeline, error) {
+	versions, err := sm.GetAllVersions()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(versions, func(i, j int) bool { return CompareVersions(versions[i], versions[j]) < 0 })
+	field = normalizeChangelogField(field)
+
+	timeline := &DeprecationTimeline{Component: fmt.Sprintf("%s/%s", componentType, componentName), Versions: versions, Fields: []FieldDeprecation{}}
+	fields := make(map[string]*FieldDeprecation)
+	record := func(fieldPath string, event DeprecationEvent, replacement string) {
+		deprecation, ok := fields[fieldPath]
+		if !ok {
+			deprecation = &FieldDeprecation{Field: fieldPath}
+			fields[fieldPath] = deprecation
+		}
+		deprecation.Events = append(deprecation.Events, event)
+		if event.Kind == DeprecationKindDeprecated && deprecation.DeprecatedIn == "" {
+			deprecation.DeprecatedIn = event.Version
+		}
+		if event.Kind == DeprecationKindRemoved && deprecation.RemovedIn == "" {
+			deprecation.RemovedIn = event.Version
+		}
+		if replacement != "" {
+			deprecation.Replacement = replacement
+		}
+	}
+
+	previousFields := map[string]bool{}
+	deprecatedFields := map[string]bool{}
+	for _, version := range versions {
+		if changelog, err := sm.GetChangelog(version); err == nil {
+			for _, entry := range parseChangelog(changelog) {
+				message, ok := componentChangelogMessage(entry.Text, componentType, componentName)
+				if !ok {
+					continue
+				}
+				kind := ""
+				switch {
+				case strings.Contains(entry.Section, "deprecation"):
+					kind = DeprecationKindDeprecated
+				case strings.Contains(entry.Section, "breaking") && changelogRemoval.MatchString(message):
+					kind = DeprecationKindRemoved
+				default:
+					continue
+				}
+				replacement := ""
+				if match := changelogReplacement.FindStringSubmatch(message); match != nil {
+					replacement = normalizeChangelogField(match[1])
+				}
+				entryField := ""
+				for _, code := range changelogCode.FindAllStringSubmatch(message, -1) {
+					if candidate := normalizeChangelogField(code[1]); candidate != replacement {
+						entryField = candidate
+						break
+					}
+				}
+				if field != "" && !sameField(field, entryField) {
+					continue
+				}
+				record(entryField, DeprecationEvent{Version: version, Kind: kind, Source: DeprecationSourceChangelog, Entry: entry.Text}, replacement)
+			}
+		}
+
+		schema, err := sm.GetComponentSchema(componentType, componentName, version)
+		if err != nil {
+			continue
+		}
+		currentFields := map[string]bool{}
+		walkFields(schema.Schema, "", func(fieldPath string, fieldSchema map[string]interface{}) {
+			currentFields[fieldPath] = true
+			if deprecated, _ := fieldSchema["deprecated"].(bool); deprecated && !deprecatedFields[fieldPath] && (field == "" || sameField(field, fieldPath)) {
+				deprecatedFields[fieldPath] = true
+				description, _ := fieldSchema["description"].(string)
+				record(fieldPath, DeprecationEvent{Version: version, Kind: DeprecationKindDeprecated, Source: DeprecationSourceSchema}, ReplacementFromDescription(description))
+			}
+		})
+		for fieldPath := range deprecatedFields {
+			if previousFields[fieldPath] && !currentFields[fieldPath] {
+				record(fieldPath, DeprecationEvent{Version: version, Kind: DeprecationKindRemoved, Source: DeprecationSourceSchema}, "")
+			}
+		}
+		previousFields = currentFields
+	}
+
+	for _, fieldPath := range sortedKeys(fields) {
+		timeline.Fields = append(timeline.Fields, *fields[fieldPath])
+	}
+	return timeline, nil
+}
+
+// componentChangelogMessage returns the message of a changelog entry of the component, entries start with the
+// component e.g. `exporter/kafka`: or `kafkaexporter`:
+func componentChangelogMessage(text string, componentType ComponentType, componentName string) (string, bool) {
+	if !strings.HasPrefix(text, "`") {
+		return "", false
+	}
+	components, message, ok := strings.Cut(strings.TrimPrefix(text, "`"), "`")
+	if !ok {
+		return "", false
+	}
+	for _, component := range strings.Split(components, ",") {
+		component = strings.TrimSpace(component)
+		if component == fmt.Sprintf("%s/%s", componentType, componentName) || component == componentName+string(componentType) {
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(message), ":")), true
+		}
+	}
+	return "", false
+}
+
+// normalizeChangelogField returns a field path with dots, changelogs separate nested fields with :: e.g. traces::topic
+func normalizeChangelogField(field string) string {
+	return strings.ReplaceAll(strings.TrimSpace(field), "::", ".")
+}
+
+// sameField returns true if the field paths are equal or one is the suffix of the other e.g. topic and traces.topic
+func sameField(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return a == b || strings.HasSuffix(a, "."+b) || strings.HasSuffix(b, "."+a)
+}
+
+// descriptionReplacement matches the replacement of a deprecated field in its description e.g. use protocols::grpc
+// instead, the replacement must be quoted, followed by instead or look like a field path
+var descriptionReplacement = regexp.MustCompile("(?i)(?:\\buse|in favou?r of|replaced (?:by|with)|superseded by|migrate to)\\s+(`[^`]+`|[A-Za-z0-9_.:/-]+)(\\s+instead)?")
+
+// ReplacementFromDescription returns the replacement field path of a deprecated field description e.g. traces.topic
+// for "Deprecated [v0.124.0] use traces::topic instead.", or an empty string
+func ReplacementFromDescription(description string) string {
+	for _, match := range descriptionReplacement.FindAllStringSubmatch(description, -1) {
+		replacement := strings.TrimRight(match[1], ".,;:")
+		quoted := strings.HasPrefix(replacement, "`")
+		if !quoted && match[2] == "" && !strings.ContainsAny(replacement, "_.:") {
+			continue
+		}
+		return normalizeChangelogField(strings.Trim(replacement, "`"))
+	}
+	return ""
+}
+
+// migrationSnippet returns a YAML snippet moving a field to its replacement, nested fields are dotted paths
+func migrationSnippet(field, replacement string) string {
+	return "# before\n" + nestedYAML(field, "<value>") + "# after\n" + nestedYAML(replacement, "<value>")
+}
+
+// nestedYAML returns the YAML of a dotted field path set to the value
+func nestedYAML(path, value string) string {
+	var builder strings.Builder
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		builder.WriteString(strings.Repeat("  ", i) + key + ":")
+		if i == len(keys)-1 {
+			builder.WriteString(" " + value)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,76 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChangelog(t *testing.T) {
+	entries := parseChangelog("## v0.139.0\n\n### 🚩 Deprecations 🚩\n\n- `exporter/kafka`: Deprecate `topic` (#1)\n\n### 🛑 Breaking changes 🛑\n\n- `receiver/jaeger`: Remove `thrift_binary` (#2)\n")
+	require.Len(t, entries, 2)
+	assert.Equal(t, "### 🚩 deprecations 🚩", entries[0].Section)
+	assert.Equal(t, "`exporter/kafka`: Deprecate `topic` (#1)", entries[0].Text)
+}
+
+func TestComponentChangelogMessage(t *testing.T) {
+	message, ok := componentChangelogMessage("`exporter/kafka`: Deprecate `topic` (#1)", ComponentTypeExporter, "kafka")
+	assert.True(t, ok)
+	assert.Equal(t, "Deprecate `topic` (#1)", message)
+	_, ok = componentChangelogMessage("`kafkaexporter, kafkareceiver`: Remove `brokers`", ComponentTypeReceiver, "kafka")
+	assert.True(t, ok)
+	_, ok = componentChangelogMessage("`exporter/kafkametrics`: Deprecate `topic`", ComponentTypeExporter, "kafka")
+	assert.False(t, ok)
+}
+
+func TestGetDeprecationTimeline(t *testing.T) {
+	sm := NewSchemaManager()
+	timeline, err := sm.GetDeprecationTimeline(ComponentTypeExporter, "kafka", "topic")
+	require.NoError(t, err)
+	assert.Equal(t, "exporter/kafka", timeline.Component)
+	require.NotEmpty(t, timeline.Fields)
+	topic := timeline.Fields[0]
+	assert.Equal(t, "topic", topic.Field)
+	assert.Equal(t, timeline.Versions[0], topic.DeprecatedIn)
+	assert.Equal(t, "traces.topic", topic.Replacement)
+	assert.Empty(t, topic.RemovedIn)
+
+	timeline, err = sm.GetDeprecationTimeline(ComponentTypeExporter, "kafka", "brokers")
+	require.NoError(t, err)
+	require.Len(t, timeline.Fields, 1)
+	assert.Equal(t, "brokers", timeline.Fields[0].Field)
+	assert.Equal(t, []DeprecationEvent{{Version: timeline.Versions[0], Kind: DeprecationKindDeprecated, Source: DeprecationSourceSchema}}, timeline.Fields[0].Events)
+
+	timeline, err = sm.GetDeprecationTimeline(ComponentTypeExporter, "kafka", "client_id")
+	require.NoError(t, err)
+	assert.Equal(t, []FieldDeprecation{}, timeline.Fields)
+}
+
+func TestReplacementFromDescription(t *testing.T) {
+	tests := map[string]string{
+		"Deprecated [v0.124.0] use traces::topic instead.":       "traces.topic",
+		"Deprecated, use protocol_version instead.":              "protocol_version",
+		"Deprecated: use `protocols::grpc`.":                     "protocols.grpc",
+		"Deprecated in favor of sending_queue.batch.":            "sending_queue.batch",
+		"Deprecated, replaced by `compression`":                  "compression",
+		"Deprecated, use the new setting which is more flexible": "",
+		"Deprecated, it has no effect.":                          "",
+	}
+	for description, expected := range tests {
+		assert.Equal(t, expected, ReplacementFromDescription(description), description)
+	}
+}
+
+func TestGetDeprecatedFields_Replacement(t *testing.T) {
+	sm := NewSchemaManager()
+	fields, err := sm.GetDeprecatedFields(ComponentTypeExporter, "kafka", "0.139.0")
+	require.NoError(t, err)
+	replacements := map[string]DeprecatedField{}
+	for _, field := range fields {
+		replacements[field.Name] = field
+	}
+	assert.Equal(t, "traces.topic", replacements["topic"].ReplacedBy)
+	assert.Equal(t, "# before\ntopic: <value>\n# after\ntraces:\n  topic: <value>\n", replacements["topic"].Migration)
+	assert.Equal(t, "protocol_version", replacements["brokers"].ReplacedBy)
+}
@@ -0,0 +1,121 @@
+package collectorschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// draft07 is the JSON Schema draft supported by YAML language servers (yaml-language-server, VSCode)
+const draft07 = "http://json-schema.org/draft-07/schema#"
+
+// componentSections maps the collector configuration sections to their component types
+var componentSections = map[string]ComponentType{
+	"receivers":  ComponentTypeReceiver,
+	"processors": ComponentTypeProcessor,
+	"exporters":  ComponentTypeExporter,
+	"extensions": ComponentTypeExtension,
+	"connectors": ComponentTypeConnector,
+}
+
+// GetEditorSchema returns the component schema converted to the JSON Schema layout expected by YAML language servers
+func (sm *SchemaManager) GetEditorSchema(componentType ComponentType, componentName string, version string) (map[string]interface{}, error) {
+	componentSchema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := nullableObjects(ToDraft07(componentSchema.Schema))
+	schema["$schema"] = draft07
+	schema["title"] = fmt.Sprintf("OpenTelemetry Collector %s %s %s", componentName, componentType, version)
+	return schema, nil
+}
+
+// GetEditorConfigSchema returns a JSON Schema for a full collector configuration of the version, it is the config
+// schema of GetConfigSchema
+func (sm *SchemaManager) GetEditorConfigSchema(version string) (map[string]interface{}, error) {
+	return sm.GetConfigSchema(version)
+}
+
+// ToDraft07 returns a copy of the JSON Schema with draft 2019-09/2020-12 keywords converted to draft-07
+func ToDraft07(schema map[string]interface{}) map[string]interface{} {
+	converted, _ := toDraft07(schema).(map[string]interface{})
+	return converted
+}
+
+func toDraft07(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			switch key {
+			case "$schema", "$id":
+				// Nested schemas must not redeclare the draft or identity
+				continue
+			case "$defs":
+				key = "definitions"
+			case "prefixItems":
+				key = "items"
+			case "dependentRequired", "dependentSchemas":
+				key = "dependencies"
+			case "$ref":
+				if ref, ok := item.(string); ok {
+					item = strings.Replace(ref, "#/$defs/", "#/definitions/", 1)
+				}
+			}
+			result[key] = toDraft07(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = toDraft07(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// nullableObjects allows null for object types, the collector accepts empty sections written as "grpc:" in YAML
+func nullableObjects(schema map[string]interface{}) map[string]interface{} {
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if v["type"] == "object" {
+				v["type"] = []interface{}{"object", "null"}
+			}
+			for _, item := range v {
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(schema)
+	return schema
+}
+
+func sortedSectionNames() []string {
+	names := make([]string, 0, len(componentSections))
+	for name := range componentSections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// regexpQuote escapes the regular expression meta characters of a component name
+func regexpQuote(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if strings.ContainsRune(`\.+*?()|[]{}^$`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package collectorschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestToDraft07(t *testing.T) {
+	schema := ToDraft07(map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs":   map[string]interface{}{"tls": map[string]interface{}{"type": "object"}},
+		"properties": map[string]interface{}{
+			"tls": map[string]interface{}{"$ref": "#/$defs/tls"},
+		},
+	})
+
+	assert.NotContains(t, schema, "$schema")
+	assert.Contains(t, schema, "definitions")
+	assert.Equal(t, "#/definitions/tls", schema["properties"].(map[string]interface{})["tls"].(map[string]interface{})["$ref"])
+}
+
+func TestSchemaManager_GetEditorSchema(t *testing.T) {
+	sm := NewSchemaManager()
+
+	schema, err := sm.GetEditorSchema(ComponentTypeReceiver, "otlp", "0.138.0")
+	require.NoError(t, err)
+	assert.Equal(t, draft07, schema["$schema"])
+	assert.Equal(t, []interface{}{"object", "null"}, schema["type"])
+}
+
+func TestSchemaManager_GetEditorConfigSchema(t *testing.T) {
+	sm := NewSchemaManager()
+
+	schema, err := sm.GetEditorConfigSchema("0.138.0")
+	require.NoError(t, err)
+	schemaJSON, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	validate := func(config string) *gojsonschema.Result {
+		result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schemaJSON), gojsonschema.NewStringLoader(config))
+		require.NoError(t, err)
+		return result
+	}
+
+	result := validate(`{"receivers": {"otlp/in": {"protocols": {"grpc": null}}}, "service": {"pipelines": {"traces": {"receivers": ["otlp/in"], "exporters": ["debug"]}}}}`)
+	assert.True(t, result.Valid(), result.Errors())
+
+	result = validate(`{"receivers": {"unknown": null}, "service": {}}`)
+	assert.False(t, result.Valid())
+
+	result = validate(`{"service": {"pipelines": {"spans": {"receivers": [], "exporters": []}}}}`)
+	assert.False(t, result.Valid())
+}
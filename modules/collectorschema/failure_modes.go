@@ -0,0 +1,191 @@
+package collectorschema
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed failure_modes/*.yaml
+var embeddedFailureModes embed.FS
+
+// FailureMode is a common failure of a component with its cause and configuration fix e.g. 429 responses of the
+// prometheusremotewrite exporter
+type FailureMode struct {
+	ID       string   `yaml:"id" json:"id"`
+	Title    string   `yaml:"title" json:"title"`
+	Symptoms []string `yaml:"symptoms" json:"symptoms"`
+	// Patterns are case-insensitive regular expressions matched against error messages
+	Patterns []string `yaml:"patterns" json:"patterns"`
+	Cause    string   `yaml:"cause" json:"cause"`
+	Fix      string   `yaml:"fix" json:"fix"`
+	// Config is the collector configuration YAML applying the fix
+	Config string `yaml:"config,omitempty" json:"config,omitempty"`
+	// Introduced and Fixed are the collector version range the failure mode applies to
+	Introduced string   `yaml:"introduced,omitempty" json:"introduced,omitempty"`
+	Fixed      string   `yaml:"fixed,omitempty" json:"fixed,omitempty"`
+	References []string `yaml:"references,omitempty" json:"references,omitempty"`
+
+	patterns []*regexp.Regexp
+}
+
+// ComponentFailureModes are the failure modes of a component e.g. exporter/otlp
+type ComponentFailureModes struct {
+	Component    string        `yaml:"component" json:"component"`
+	FailureModes []FailureMode `yaml:"failure_modes" json:"failureModes"`
+}
+
+// FailureModeMatch is a failure mode matching an error message
+type FailureModeMatch struct {
+	Component string `json:"component"`
+	FailureMode
+	// Matched are the patterns matching the error message, empty when the failure modes of a component are listed
+	// without an error message
+	Matched []string `json:"matched,omitempty"`
+}
+
+// Applies returns true if the collector version is in the version range of the failure mode
+func (f FailureMode) Applies(version string) bool {
+	if f.Introduced != "" && CompareVersions(version, f.Introduced) < 0 {
+		return false
+	}
+	return f.Fixed == "" || CompareVersions(version, f.Fixed) < 0
+}
+
+// ParseFailureModes parses the failure modes file of a component, the file name e.g. exporter_otlp.yaml must match the
+// component
+func ParseFailureModes(fileName string, data []byte) (*ComponentFailureModes, error) {
+	var component ComponentFailureModes
+	if err := yaml.Unmarshal(data, &component); err != nil {
+		return nil, fmt.Errorf("failed to parse failure modes %s: %w", fileName, err)
+	}
+	if expected := strings.Replace(strings.TrimSuffix(path.Base(fileName), ".yaml"), "_", "/", 1); component.Component != expected {
+		return nil, fmt.Errorf("failure modes %s must describe component %s, got %q", fileName, expected, component.Component)
+	}
+	ids := map[string]bool{}
+	for i := range component.FailureModes {
+		failureMode := &component.FailureModes[i]
+		if failureMode.ID == "" || failureMode.Title == "" || failureMode.Fix == "" {
+			return nil, fmt.Errorf("failure mode %d of %s must set id, title and fix", i, component.Component)
+		}
+		if ids[failureMode.ID] {
+			return nil, fmt.Errorf("failure mode %s of %s is duplicated", failureMode.ID, component.Component)
+		}
+		ids[failureMode.ID] = true
+		if len(failureMode.Patterns) == 0 {
+			return nil, fmt.Errorf("failure mode %s of %s must list patterns", failureMode.ID, component.Component)
+		}
+		for _, pattern := range failureMode.Patterns {
+			compiled, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return nil, fmt.Errorf("failure mode %s of %s has an invalid pattern: %w", failureMode.ID, component.Component, err)
+			}
+			failureMode.patterns = append(failureMode.patterns, compiled)
+		}
+		if failureMode.Config != "" {
+			var config map[string]interface{}
+			if err := yaml.Unmarshal([]byte(failureMode.Config), &config); err != nil {
+				return nil, fmt.Errorf("failure mode %s of %s has an invalid config: %w", failureMode.ID, component.Component, err)
+			}
+		}
+		if failureMode.Introduced != "" && failureMode.Fixed != "" && CompareVersions(failureMode.Introduced, failureMode.Fixed) >= 0 {
+			return nil, fmt.Errorf("failure mode %s of %s is fixed in %s before it is introduced in %s", failureMode.ID, component.Component, failureMode.Fixed, failureMode.Introduced)
+		}
+	}
+	return &component, nil
+}
+
+var failureModes = sync.OnceValues(func() ([]ComponentFailureModes, error) {
+	files, err := fs.Glob(embeddedFailureModes, "failure_modes/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	components := make([]ComponentFailureModes, 0, len(files))
+	for _, file := range files {
+		data, err := embeddedFailureModes.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		component, err := ParseFailureModes(file, data)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, *component)
+	}
+	return components, nil
+})
+
+// FailureModeComponents returns the components with curated failure modes e.g. exporter/otlp
+func FailureModeComponents() []string {
+	components, err := failureModes()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		names = append(names, component.Component)
+	}
+	return names
+}
+
+// GetFailureModes returns the failure modes of a component applying to the collector version
+func GetFailureModes(componentType ComponentType, componentName, version string) ([]FailureMode, error) {
+	components, err := failureModes()
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range components {
+		if component.Component != string(componentType)+"/"+componentName {
+			continue
+		}
+		var applying []FailureMode
+		for _, failureMode := range component.FailureModes {
+			if failureMode.Applies(version) {
+				applying = append(applying, failureMode)
+			}
+		}
+		return applying, nil
+	}
+	return nil, nil
+}
+
+// MatchFailureModes returns the failure modes applying to the collector version whose patterns match the error message,
+// of the component if it is set. The matches are sorted by the number of matching patterns.
+func MatchFailureModes(errorMessage string, componentType ComponentType, componentName, version string) ([]FailureModeMatch, error) {
+	components, err := failureModes()
+	if err != nil {
+		return nil, err
+	}
+	var matches []FailureModeMatch
+	for _, component := range components {
+		if componentName != "" && component.Component != string(componentType)+"/"+componentName {
+			continue
+		}
+		for _, failureMode := range component.FailureModes {
+			if !failureMode.Applies(version) {
+				continue
+			}
+			var matched []string
+			for i, pattern := range failureMode.patterns {
+				if pattern.MatchString(errorMessage) {
+					matched = append(matched, failureMode.Patterns[i])
+				}
+			}
+			if len(matched) > 0 {
+				matches = append(matches, FailureModeMatch{Component: component.Component, FailureMode: failureMode, Matched: matched})
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return len(matches[i].Matched) > len(matches[j].Matched)
+	})
+	return matches, nil
+}
@@ -0,0 +1,98 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureModes_Embedded(t *testing.T) {
+	components, err := failureModes()
+	require.NoError(t, err)
+	assert.Contains(t, FailureModeComponents(), "exporter/prometheusremotewrite")
+	assert.Contains(t, FailureModeComponents(), "processor/memory_limiter")
+	for _, component := range components {
+		assert.NotEmpty(t, component.FailureModes, component.Component)
+	}
+}
+
+func TestGetFailureModes(t *testing.T) {
+	failureModes, err := GetFailureModes(ComponentTypeReceiver, "otlp", "0.138.0")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost-default-endpoint", failureModes[0].ID)
+
+	// The localhost default endpoints were introduced in v0.104.0
+	failureModes, err = GetFailureModes(ComponentTypeReceiver, "otlp", "0.103.0")
+	require.NoError(t, err)
+	assert.Equal(t, "address-in-use", failureModes[0].ID)
+
+	failureModes, err = GetFailureModes(ComponentTypeExporter, "debug", "0.138.0")
+	require.NoError(t, err)
+	assert.Empty(t, failureModes)
+}
+
+func TestMatchFailureModes(t *testing.T) {
+	matches, err := MatchFailureModes("Exporting failed. Dropping data. remote write returned HTTP status 429 Too Many Requests", "", "", "0.138.0")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "exporter/prometheusremotewrite", matches[0].Component)
+	assert.Equal(t, "too-many-requests", matches[0].ID)
+	assert.Equal(t, []string{`\b429\b`, "too many requests"}, matches[0].Matched)
+
+	matches, err = MatchFailureModes("rpc error: code = ResourceExhausted desc = grpc: received message larger than max (5242880 vs. 4194304)", "", "", "0.138.0")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "exporter/otlp", matches[0].Component)
+	assert.Equal(t, "receiver/otlp", matches[1].Component)
+
+	matches, err = MatchFailureModes("grpc: received message larger than max", ComponentTypeReceiver, "otlp", "0.138.0")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "grpc-message-too-large", matches[0].ID)
+
+	matches, err = MatchFailureModes("everything is fine", "", "", "0.138.0")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestParseFailureModes_Invalid(t *testing.T) {
+	_, err := ParseFailureModes("exporter_otlp.yaml", []byte("component: exporter/otlphttp\n"))
+	assert.EqualError(t, err, `failure modes exporter_otlp.yaml must describe component exporter/otlp, got "exporter/otlphttp"`)
+
+	_, err = ParseFailureModes("exporter_otlp.yaml", []byte(`
+component: exporter/otlp
+failure_modes:
+  - id: invalid
+    title: Invalid
+    fix: Fix
+    patterns: ["("]
+`))
+	assert.ErrorContains(t, err, "failure mode invalid of exporter/otlp has an invalid pattern")
+
+	_, err = ParseFailureModes("exporter_otlp.yaml", []byte(`
+component: exporter/otlp
+failure_modes:
+  - id: duplicated
+    title: Duplicated
+    fix: Fix
+    patterns: [error]
+  - id: duplicated
+    title: Duplicated
+    fix: Fix
+    patterns: [error]
+`))
+	assert.EqualError(t, err, "failure mode duplicated of exporter/otlp is duplicated")
+
+	_, err = ParseFailureModes("exporter_otlp.yaml", []byte(`
+component: exporter/otlp
+failure_modes:
+  - id: range
+    title: Range
+    fix: Fix
+    patterns: [error]
+    introduced: 0.110.0
+    fixed: 0.100.0
+`))
+	assert.EqualError(t, err, "failure mode range of exporter/otlp is fixed in 0.100.0 before it is introduced in 0.110.0")
+}
@@ -0,0 +1,125 @@
+package collectorschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// descriptionsSuffix is the suffix of the translated field descriptions of a component generated with the schemas,
+// e.g. receiver_otlp.de.descriptions.json maps the dotted field paths to their German description
+const descriptionsSuffix = ".descriptions.json"
+
+// DescriptionLocales returns the locales of the translated field descriptions of the component e.g. de and pt-br
+func (c ManifestComponent) DescriptionLocales() []string {
+	prefix := fmt.Sprintf("%s_%s.", c.Type, c.Name)
+	var locales []string
+	for _, file := range c.Files {
+		rest, found := strings.CutPrefix(file, prefix)
+		if !found {
+			continue
+		}
+		if locale, found := strings.CutSuffix(rest, descriptionsSuffix); found && locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// GetFieldTranslations returns the translated descriptions of the component fields by their dotted path and the locale
+// of the translations, falling back from e.g. pt-br to pt. It returns no translations for English or if the schemas
+// have no translations of the locale.
+func (sm *SchemaManager) GetFieldTranslations(componentType ComponentType, componentName string, version string, locale string) (map[string]string, string, error) {
+	locale = normalizeLocale(locale)
+	if locale == "" || locale == "en" || strings.HasPrefix(locale, "en-") {
+		return nil, "", nil
+	}
+	schemaPath := fmt.Sprintf("schemas/%s", version)
+	for _, candidate := range localeCandidates(locale) {
+		filename := fmt.Sprintf("%s_%s.%s%s", componentType, componentName, candidate, descriptionsSuffix)
+		data, err := fs.ReadFile(sm.schemas, filepath.Join(schemaPath, filename))
+		if err != nil {
+			continue
+		}
+		var translations map[string]string
+		if err := json.Unmarshal(data, &translations); err != nil {
+			return nil, "", fmt.Errorf("failed to parse translated descriptions %s: %w", filename, err)
+		}
+		return translations, candidate, nil
+	}
+	return nil, "", nil
+}
+
+// GetComponentSchemaLocalized returns the schema of a component with the field descriptions in the locale, fields
+// without a translation keep their English description. The locale of the schema is empty if no translations exist.
+func (sm *SchemaManager) GetComponentSchemaLocalized(componentType ComponentType, componentName string, version string, locale string) (*ComponentSchema, error) {
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+	translations, translationsLocale, err := sm.GetFieldTranslations(componentType, componentName, version, locale)
+	if err != nil || len(translations) == 0 {
+		return schema, err
+	}
+	// The cached schema is shared, the translated descriptions are set on a copy
+	localized := *schema
+	localized.Locale = translationsLocale
+	localized.Schema = translateDescriptions(schema.Schema, "", translations)
+	return &localized, nil
+}
+
+// GetFieldDescriptionsLocalized returns the descriptions of the component fields by their dotted path in the locale,
+// fields without a translation keep their English description
+func (sm *SchemaManager) GetFieldDescriptionsLocalized(componentType ComponentType, componentName string, version string, locale string) (map[string]string, error) {
+	descriptions, err := sm.GetFieldDescriptions(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+	translations, _, err := sm.GetFieldTranslations(componentType, componentName, version, locale)
+	if err != nil {
+		return nil, err
+	}
+	for fieldPath, description := range translations {
+		if _, exists := descriptions[fieldPath]; exists && description != "" {
+			descriptions[fieldPath] = description
+		}
+	}
+	return descriptions, nil
+}
+
+// translateDescriptions returns a copy of the schema with the descriptions of the translated fields replaced, the
+// fields of array items share the path of the array like in walkFields
+func translateDescriptions(schema map[string]interface{}, currentPath string, translations map[string]string) map[string]interface{} {
+	translated := maps.Clone(schema)
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		translated["items"] = translateDescriptions(items, currentPath, translations)
+	}
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return translated
+	}
+	translatedProperties := make(map[string]interface{}, len(properties))
+	for fieldName, fieldSchema := range properties {
+		fieldPath := fieldName
+		if currentPath != "" {
+			fieldPath = currentPath + "." + fieldName
+		}
+		fieldSchemaMap, ok := fieldSchema.(map[string]interface{})
+		if !ok {
+			translatedProperties[fieldName] = fieldSchema
+			continue
+		}
+		translatedField := translateDescriptions(fieldSchemaMap, fieldPath, translations)
+		if description := translations[fieldPath]; description != "" {
+			translatedField["description"] = description
+		}
+		translatedProperties[fieldName] = translatedField
+	}
+	translated["properties"] = translatedProperties
+	return translated
+}
@@ -0,0 +1,80 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTranslatedSchemaManager() *SchemaManager {
+	return newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/receiver_otlp.yaml": {Data: []byte(`type: object
+properties:
+  endpoint:
+    type: string
+    description: The address to listen on
+  headers:
+    type: array
+    items:
+      type: object
+      properties:
+        key:
+          type: string
+          description: The header name
+  timeout:
+    type: string
+    description: The read timeout
+`)},
+		"schemas/1.0.0/receiver_otlp.pt.descriptions.json": {Data: []byte(`{"endpoint": "O endereço de escuta", "headers.key": "O nome do cabeçalho", "removed": "Campo removido"}`)},
+		"schemas/1.0.0/receiver_otlp.de.descriptions.json": {Data: []byte(`{"endpoint": "Die Adresse, auf der gelauscht wird"}`)},
+	})
+}
+
+func TestSchemaManager_GetComponentSchemaLocalized(t *testing.T) {
+	sm := newTranslatedSchemaManager()
+
+	// pt-BR falls back to pt, fields without a translation keep their English description
+	schema, err := sm.GetComponentSchemaLocalized(ComponentTypeReceiver, "otlp", "1.0.0", "pt_BR")
+	require.NoError(t, err)
+	assert.Equal(t, "pt", schema.Locale)
+	properties := schema.Schema["properties"].(map[string]interface{})
+	assert.Equal(t, "O endereço de escuta", properties["endpoint"].(map[string]interface{})["description"])
+	assert.Equal(t, "The read timeout", properties["timeout"].(map[string]interface{})["description"])
+	items := properties["headers"].(map[string]interface{})["items"].(map[string]interface{})
+	assert.Equal(t, "O nome do cabeçalho", items["properties"].(map[string]interface{})["key"].(map[string]interface{})["description"])
+
+	// The cached English schema is not changed
+	english, err := sm.GetComponentSchemaLocalized(ComponentTypeReceiver, "otlp", "1.0.0", "en")
+	require.NoError(t, err)
+	assert.Empty(t, english.Locale)
+	assert.Equal(t, "The address to listen on", english.Schema["properties"].(map[string]interface{})["endpoint"].(map[string]interface{})["description"])
+
+	// Locales without translations fall back to English
+	schema, err = sm.GetComponentSchemaLocalized(ComponentTypeReceiver, "otlp", "1.0.0", "ja")
+	require.NoError(t, err)
+	assert.Empty(t, schema.Locale)
+
+	_, err = sm.GetComponentSchemaLocalized(ComponentTypeReceiver, "nonexistent", "1.0.0", "de")
+	assert.Error(t, err)
+}
+
+func TestSchemaManager_GetFieldDescriptionsLocalized(t *testing.T) {
+	descriptions, err := newTranslatedSchemaManager().GetFieldDescriptionsLocalized(ComponentTypeReceiver, "otlp", "1.0.0", "de")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"endpoint":    "Die Adresse, auf der gelauscht wird",
+		"headers.key": "The header name",
+		"timeout":     "The read timeout",
+	}, descriptions)
+}
+
+func TestSchemaManager_GetSchemaBundleTranslations(t *testing.T) {
+	bundle, err := newTranslatedSchemaManager().GetSchemaBundle("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"de", "pt"}, bundle.Manifest.Locales)
+	require.Len(t, bundle.Components, 1)
+	assert.Equal(t, "Die Adresse, auf der gelauscht wird", bundle.Components[0].Descriptions["de"]["endpoint"])
+	assert.Len(t, bundle.Components[0].Descriptions["pt"], 3)
+}
@@ -0,0 +1,64 @@
+package collectorschema
+
+import (
+	"strings"
+	"testing"
+)
+
+// Run a target with e.g. go test -run '^$' -fuzz FuzzValidateConfigYAML -fuzztime 1m
+
+var fuzzJSONSeeds = []string{
+	`{"protocols": {"grpc": {"endpoint": "0.0.0.0:4317"}}}`,
+	`{"timeout": "5s", "send_batch_size": 100}`,
+	`{}`,
+	`[]`,
+	`null`,
+	`{"a": [1, 2, {"b": "c"}]}`,
+	`{"a": `,
+	"\x00\xff\xfe",
+	strings.Repeat("[", 200) + strings.Repeat("]", 200),
+}
+
+var fuzzYAMLSeeds = []string{
+	"receivers:\n  otlp:\n    protocols:\n      grpc:\n",
+	"service:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [debug]\n",
+	"a: &a [1, 2]\nb: [*a, *a]\n",
+	"? [a, b]\n: c\n",
+	"1: 2\n",
+	"a: !!binary aGVsbG8=\n",
+	"a: *undefined\n",
+	"receivers: [",
+	"\x00\xff\xfe",
+	strings.Repeat("- ", 200) + "a\n",
+	strings.Repeat("{", 200) + strings.Repeat("}", 200),
+}
+
+func FuzzValidateComponentJSON(f *testing.F) {
+	for _, seed := range fuzzJSONSeeds {
+		f.Add([]byte(seed))
+	}
+	sm := NewSchemaManager()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = sm.ValidateComponentJSON(ComponentTypeReceiver, "otlp", "0.139.0", data)
+	})
+}
+
+func FuzzValidateComponentYAML(f *testing.F) {
+	for _, seed := range fuzzYAMLSeeds {
+		f.Add([]byte(seed))
+	}
+	sm := NewSchemaManager()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = sm.ValidateComponentYAML(ComponentTypeProcessor, "batch", "0.139.0", data)
+	})
+}
+
+func FuzzValidateConfigYAML(f *testing.F) {
+	for _, seed := range fuzzYAMLSeeds {
+		f.Add([]byte(seed))
+	}
+	sm := NewSchemaManager()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = sm.ValidateConfigYAML("0.139.0", data)
+	})
+}
@@ -0,0 +1,230 @@
+package collectorschema
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/philippgille/chromem-go"
+)
+
+const (
+	// bm25K1 and bm25B are the BM25 term frequency saturation and document length normalization parameters
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// keywordFieldWeight is how many times a term of the title or the component name counts compared to the content
+	keywordFieldWeight = 3
+)
+
+// SearchWeights configures how the documentation search combines vector similarity and keyword matching
+type SearchWeights struct {
+	// Vector is the weight of the vector similarity
+	Vector float32
+	// Keyword is the weight of the BM25 keyword score over titles, component names and content normalized to [0, 1]
+	Keyword float32
+	// ComponentBoost is added to documents of a component named in the query e.g. kafka or kafkaexporter
+	ComponentBoost float32
+}
+
+// DefaultSearchWeights are the search weights of a new schema manager
+var DefaultSearchWeights = SearchWeights{Vector: 0.5, Keyword: 0.5, ComponentBoost: 0.3}
+
+// SearchExplanation explains the score of a search result
+type SearchExplanation struct {
+	VectorSimilarity float32  `json:"vectorSimilarity"`
+	KeywordScore     float32  `json:"keywordScore"`
+	ComponentBoost   float32  `json:"componentBoost,omitempty"`
+	MatchedTerms     []string `json:"matchedTerms,omitempty"`
+	Title            string   `json:"title,omitempty"`
+}
+
+// keywordDocument is the tokenized title, component name and content of an indexed document
+type keywordDocument struct {
+	title         string
+	fieldTerms    map[string]int
+	contentTerms  map[string]int
+	length        int
+	componentType string
+	componentName string
+}
+
+// SetSearchWeights configures the weighting of the documentation search
+func (sm *SchemaManager) SetSearchWeights(weights SearchWeights) {
+	sm.searchWeights = weights
+}
+
+// indexKeywords tokenizes a document for the keyword search
+func (index *ragSearchIndex) indexKeywords(doc chromem.Document) {
+	document := keywordDocument{
+		title:         doc.Metadata["title"],
+		fieldTerms:    make(map[string]int),
+		contentTerms:  make(map[string]int),
+		componentType: doc.Metadata["component_type"],
+		componentName: doc.Metadata["component_name"],
+	}
+	if document.title == "" {
+		document.title = markdownTitle(doc.Content)
+	}
+	for _, term := range tokenize(document.title + " " + document.componentName) {
+		document.fieldTerms[term]++
+	}
+	for _, term := range tokenize(doc.Content) {
+		document.contentTerms[term]++
+		document.length++
+	}
+	index.keywords[doc.ID] = document
+}
+
+// hybridQuery ranks the documents of the index matching the filter by the weighted vector similarity, BM25 keyword
+// score and component boost and returns the best results with the explanation of their score
+func (sm *SchemaManager) hybridQuery(index *ragSearchIndex, query string, maxResults int, where map[string]string) (searchResults []DocumentSearchResult, err error) {
+	defer func(start time.Time) {
+		sm.observer.RAGQuery(time.Since(start), err)
+	}(time.Now())
+	if maxResults <= 0 {
+		return nil, fmt.Errorf("maxResults must be positive")
+	}
+	if index.collection.Count() == 0 {
+		return nil, nil
+	}
+	// Every document matching the filter gets a vector similarity, the keyword score re-ranks them
+	results, err := index.collection.Query(context.Background(), query, index.collection.Count(), where, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// READMEs, changelog entries and schema fields differ in length by orders of magnitude, the length normalization
+	// and the keyword score normalization are computed per kind so that every kind can surface
+	queryTerms := uniqueTerms(tokenize(query))
+	keywordScores := make([]float64, len(results))
+	matchedTerms := make([][]string, len(results))
+	maxKeywordScore := make(map[string]float64)
+	averageLength := make(map[string]float64)
+	kindCount := make(map[string]int)
+	documentFrequency := make(map[string]int)
+	for _, result := range results {
+		document := index.keywords[result.ID]
+		averageLength[result.Metadata["file_type"]] += float64(document.length)
+		kindCount[result.Metadata["file_type"]]++
+		for _, term := range queryTerms {
+			if document.fieldTerms[term] > 0 || document.contentTerms[term] > 0 {
+				documentFrequency[term]++
+			}
+		}
+	}
+	for kind, count := range kindCount {
+		averageLength[kind] /= float64(count)
+	}
+	for i, result := range results {
+		document := index.keywords[result.ID]
+		kind := result.Metadata["file_type"]
+		for _, term := range queryTerms {
+			frequency := float64(keywordFieldWeight*document.fieldTerms[term] + document.contentTerms[term])
+			if frequency == 0 {
+				continue
+			}
+			matchedTerms[i] = append(matchedTerms[i], term)
+			idf := math.Log(1 + (float64(len(results))-float64(documentFrequency[term])+0.5)/(float64(documentFrequency[term])+0.5))
+			lengthNorm := 1.0
+			if averageLength[kind] > 0 {
+				lengthNorm = 1 - bm25B + bm25B*float64(document.length)/averageLength[kind]
+			}
+			keywordScores[i] += idf * frequency * (bm25K1 + 1) / (frequency + bm25K1*lengthNorm)
+		}
+		maxKeywordScore[kind] = math.Max(maxKeywordScore[kind], keywordScores[i])
+	}
+
+	weights := sm.searchWeights
+	searchResults = make([]DocumentSearchResult, len(results))
+	for i, result := range results {
+		document := index.keywords[result.ID]
+		// A negative cosine similarity is an unrelated document, it must not penalize keyword matches
+		similarity := max(result.Similarity, 0)
+		explanation := &SearchExplanation{
+			VectorSimilarity: similarity,
+			MatchedTerms:     matchedTerms[i],
+			Title:            document.title,
+		}
+		if maxKeywordScore[result.Metadata["file_type"]] > 0 {
+			explanation.KeywordScore = float32(keywordScores[i] / maxKeywordScore[result.Metadata["file_type"]])
+		}
+		if namesComponent(queryTerms, document.componentType, document.componentName) {
+			explanation.ComponentBoost = weights.ComponentBoost
+		}
+		searchResults[i] = DocumentSearchResult{
+			ID:          result.ID,
+			Content:     result.Content,
+			Metadata:    result.Metadata,
+			Similarity:  similarity,
+			Score:       weights.Vector*explanation.VectorSimilarity + weights.Keyword*explanation.KeywordScore + explanation.ComponentBoost,
+			Explanation: explanation,
+			Component:   result.Metadata["component"],
+			Version:     result.Metadata["version"],
+			FilePath:    result.Metadata["file_path"],
+		}
+	}
+	sort.SliceStable(searchResults, func(i, j int) bool { return searchResults[i].Score > searchResults[j].Score })
+	if len(searchResults) > maxResults {
+		searchResults = searchResults[:maxResults]
+	}
+	return searchResults, nil
+}
+
+// namesComponent returns true if the query terms name the component e.g. kafka, tail sampling or kafkaexporter
+func namesComponent(queryTerms []string, componentType, componentName string) bool {
+	if componentName == "" {
+		return false
+	}
+	query := " " + strings.Join(queryTerms, " ") + " "
+	name := strings.ReplaceAll(componentName, "_", " ")
+	return strings.Contains(query, " "+name+" ") ||
+		strings.Contains(query, " "+strings.ReplaceAll(componentName, "_", "")+componentType+" ")
+}
+
+// markdownTitle returns the first heading of a markdown document
+func markdownTitle(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "#") {
+			return strings.TrimSpace(strings.TrimLeft(line, "#"))
+		}
+	}
+	return ""
+}
+
+// searchStopWords are the query words ignored by the keyword search
+var searchStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "be": true, "can": true, "do": true, "does": true, "for": true,
+	"how": true, "i": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true, "the": true,
+	"to": true, "what": true, "which": true, "with": true,
+}
+
+// tokenize splits a text into lower case terms, underscores separate terms e.g. tail_sampling is tail and sampling
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := fields[:0]
+	for _, field := range fields {
+		if !searchStopWords[field] {
+			terms = append(terms, field)
+		}
+	}
+	return terms
+}
+
+// uniqueTerms returns the terms without duplicates in their original order
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	var unique []string
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
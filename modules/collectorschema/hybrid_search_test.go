@@ -0,0 +1,50 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"configure", "tail", "sampling", "processor"}, tokenize("How do I configure the tail_sampling processor?"))
+}
+
+func TestNamesComponent(t *testing.T) {
+	terms := tokenize("kafka exporter topic")
+	assert.True(t, namesComponent(terms, "exporter", "kafka"))
+	assert.False(t, namesComponent(terms, "exporter", "kafkametrics"))
+	assert.True(t, namesComponent(tokenize("tail sampling policies"), "processor", "tail_sampling"))
+	assert.True(t, namesComponent(tokenize("kafkaexporter topic"), "exporter", "kafka"))
+	assert.False(t, namesComponent(terms, "exporter", ""))
+}
+
+func TestMarkdownTitle(t *testing.T) {
+	assert.Equal(t, "Kafka Exporter", markdownTitle("<!-- status -->\n# Kafka Exporter\n\ntext"))
+	assert.Equal(t, "", markdownTitle("no heading"))
+}
+
+func TestQueryDocumentation_Hybrid(t *testing.T) {
+	sm := NewSchemaManager()
+	results, err := sm.QueryDocumentation("kafka exporter", "0.139.0", 3)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "exporter_kafka", results[0].Component)
+	require.NotNil(t, results[0].Explanation)
+	assert.Equal(t, DefaultSearchWeights.ComponentBoost, results[0].Explanation.ComponentBoost)
+	assert.Equal(t, []string{"kafka", "exporter"}, results[0].Explanation.MatchedTerms)
+	assert.Equal(t, float32(1), results[0].Explanation.KeywordScore)
+	for i := 1; i < len(results); i++ {
+		assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+
+	// Vector similarity only
+	sm = NewSchemaManager()
+	sm.SetSearchWeights(SearchWeights{Vector: 1})
+	results, err = sm.QueryDocumentation("kafka exporter", "0.139.0", 3)
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.Equal(t, result.Similarity, result.Score)
+	}
+}
@@ -0,0 +1,353 @@
+package collectorschema
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The limits of InputLimits reported by InputTooLargeError
+const (
+	LimitSize           = "size"
+	LimitDepth          = "depth"
+	LimitMappingEntries = "mappingEntries"
+	LimitAliasExpansion = "aliasExpansion"
+	LimitParseTimeout   = "parseTimeout"
+)
+
+// InputLimits protect the parsing and the validation of untrusted configurations from exhausting the memory or the
+// CPU e.g. with YAML alias bombs, a zero value disables a limit
+type InputLimits struct {
+	// MaxSize is the maximum size in bytes of an input
+	MaxSize int
+	// MaxDepth is the maximum nesting depth of maps and lists
+	MaxDepth int
+	// MaxMappingEntries is the maximum number of keys of a map. The schema validation time grows quadratically with
+	// the keys of a map, 90k components in a section take about a minute to validate.
+	MaxMappingEntries int
+	// MaxAliasExpansion is the maximum number of nodes the YAML aliases of an input expand to
+	MaxAliasExpansion int
+	// ParseTimeout is the maximum duration of parsing a YAML input
+	ParseTimeout time.Duration
+}
+
+const (
+	// MaxInputSize is the default maximum size in bytes of a validated configuration
+	MaxInputSize = 1 << 20
+	// MaxNestingDepth is the default maximum nesting depth of maps and lists in a validated configuration
+	MaxNestingDepth = 100
+	// MaxMappingEntries is the default maximum number of keys of a map in a validated configuration
+	MaxMappingEntries = 1000
+)
+
+// DefaultInputLimits are the input limits of a schema manager, they are far above the size of real configurations
+var DefaultInputLimits = InputLimits{
+	MaxSize:           MaxInputSize,
+	MaxDepth:          MaxNestingDepth,
+	MaxMappingEntries: MaxMappingEntries,
+	MaxAliasExpansion: 10000,
+	ParseTimeout:      5 * time.Second,
+}
+
+// parseSlots bounds the concurrent parses, a parse over its timeout keeps its slot until it finishes so the parses
+// abandoned by their callers cannot pile up
+var parseSlots = make(chan struct{}, runtime.GOMAXPROCS(0))
+
+// parsedInputsSize is the number of documents checked by CheckYAML kept for ParseYAML
+const parsedInputsSize = 16
+
+// parsedInputs keeps the last documents checked by CheckYAML, a tool argument checked before the handler runs is not
+// parsed again by the validation of the handler
+var parsedInputs = &parsedDocuments{nodes: make(map[parsedKey]*yaml.Node)}
+
+// InputTooLargeError is returned for an input exceeding one of the input limits
+type InputTooLargeError struct {
+	// Limit is the exceeded limit e.g. size
+	Limit string `json:"limit"`
+	// Maximum is the value of the limit, in milliseconds for the parse timeout
+	Maximum int64 `json:"maximum"`
+	// Size is the size of an input exceeding the size limit
+	Size int64 `json:"size,omitempty"`
+	// Line is the line of the YAML map or list exceeding the limit
+	Line int `json:"line,omitempty"`
+	// Offset is the byte offset of the JSON object or array exceeding the limit
+	Offset int `json:"offset,omitempty"`
+}
+
+func (e *InputTooLargeError) Error() string {
+	var message string
+	switch e.Limit {
+	case LimitSize:
+		message = fmt.Sprintf("%d bytes exceed the maximum size of %d bytes", e.Size, e.Maximum)
+	case LimitDepth:
+		message = fmt.Sprintf("the nesting exceeds the maximum depth of %d", e.Maximum)
+	case LimitMappingEntries:
+		message = fmt.Sprintf("a map exceeds the maximum of %d keys", e.Maximum)
+	case LimitAliasExpansion:
+		message = fmt.Sprintf("the aliases expand to more than the maximum of %d nodes", e.Maximum)
+	case LimitParseTimeout:
+		message = fmt.Sprintf("parsing exceeds the timeout of %s", time.Duration(e.Maximum)*time.Millisecond)
+	default:
+		message = fmt.Sprintf("the input exceeds the %s limit of %d", e.Limit, e.Maximum)
+	}
+	switch {
+	case e.Line > 0:
+		message += fmt.Sprintf(" at line %d", e.Line)
+	case e.Offset > 0:
+		message += fmt.Sprintf(" at offset %d", e.Offset)
+	}
+	return "input too large: " + message
+}
+
+// SetInputLimits sets the limits of the configurations validated by the schema manager
+func (sm *SchemaManager) SetInputLimits(limits InputLimits) {
+	sm.inputLimits = limits
+}
+
+// ParseYAML parses a YAML input rejecting inputs over the limits with an InputTooLargeError
+func (l InputLimits) ParseYAML(data []byte) (interface{}, error) {
+	if err := l.checkSize(data); err != nil {
+		return nil, err
+	}
+	return withParseTimeout(l.ParseTimeout, func() (interface{}, error) {
+		node, found := parsedInputs.get(l, data)
+		if !found {
+			var err error
+			node, err = l.parseNode(data)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if node.Kind == 0 {
+			// An empty document
+			return nil, nil
+		}
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML data: %w", err)
+		}
+		return value, nil
+	})
+}
+
+// CheckYAML returns an InputTooLargeError if a YAML or JSON input exceeds the limits. Malformed inputs are left to the
+// parser reporting the syntax error. The checked document is kept for a following ParseYAML of the same input.
+func (l InputLimits) CheckYAML(data []byte) error {
+	if err := l.checkSize(data); err != nil {
+		return err
+	}
+	_, err := withParseTimeout(l.ParseTimeout, func() (interface{}, error) {
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, nil
+		}
+		if err := l.checkYAMLNode(&node); err != nil {
+			return nil, err
+		}
+		parsedInputs.put(l, data, &node)
+		return nil, nil
+	})
+	return err
+}
+
+// parseNode parses a YAML input and checks the parsed document against the limits
+func (l InputLimits) parseNode(data []byte) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML data: %w", err)
+	}
+	if node.Kind == 0 {
+		return &node, nil
+	}
+	if err := l.checkYAMLNode(&node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// CheckJSON returns an InputTooLargeError if a JSON input exceeds the limits. Malformed JSON is left to the parser
+// reporting the syntax error.
+func (l InputLimits) CheckJSON(data []byte) error {
+	if err := l.checkSize(data); err != nil {
+		return err
+	}
+	// The number of keys of each open object, -1 for arrays
+	var open []int
+	inString, escaped := false, false
+	for offset, b := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+			continue
+		}
+		switch b {
+		case '"':
+			inString = true
+		case '{', '[':
+			if l.MaxDepth > 0 && len(open) >= l.MaxDepth {
+				return &InputTooLargeError{Limit: LimitDepth, Maximum: int64(l.MaxDepth), Offset: offset}
+			}
+			keys := 0
+			if b == '[' {
+				keys = -1
+			}
+			open = append(open, keys)
+		case '}', ']':
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		case ':':
+			// Every key of an object is followed by a colon
+			if len(open) > 0 && open[len(open)-1] >= 0 {
+				open[len(open)-1]++
+				if l.MaxMappingEntries > 0 && open[len(open)-1] > l.MaxMappingEntries {
+					return &InputTooLargeError{Limit: LimitMappingEntries, Maximum: int64(l.MaxMappingEntries), Offset: offset}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (l InputLimits) checkSize(data []byte) error {
+	if l.MaxSize > 0 && len(data) > l.MaxSize {
+		return &InputTooLargeError{Limit: LimitSize, Maximum: int64(l.MaxSize), Size: int64(len(data))}
+	}
+	return nil
+}
+
+// checkYAMLNode checks the depth, the map sizes and the alias expansion of a parsed YAML document, aliases are
+// checked where their anchor is defined
+func (l InputLimits) checkYAMLNode(root *yaml.Node) error {
+	expanded := 0
+	sizes := make(map[*yaml.Node]int)
+	var check func(node *yaml.Node, depth int) error
+	check = func(node *yaml.Node, depth int) error {
+		switch node.Kind {
+		case yaml.MappingNode, yaml.SequenceNode:
+			depth++
+			if l.MaxDepth > 0 && depth > l.MaxDepth {
+				return &InputTooLargeError{Limit: LimitDepth, Maximum: int64(l.MaxDepth), Line: node.Line}
+			}
+			if node.Kind == yaml.MappingNode && l.MaxMappingEntries > 0 && len(node.Content)/2 > l.MaxMappingEntries {
+				return &InputTooLargeError{Limit: LimitMappingEntries, Maximum: int64(l.MaxMappingEntries), Line: node.Line}
+			}
+		case yaml.AliasNode:
+			expanded += yamlNodeSize(node.Alias, sizes)
+			if l.MaxAliasExpansion > 0 && expanded > l.MaxAliasExpansion {
+				return &InputTooLargeError{Limit: LimitAliasExpansion, Maximum: int64(l.MaxAliasExpansion), Line: node.Line}
+			}
+			return nil
+		}
+		for _, child := range node.Content {
+			if err := check(child, depth); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check(root, 0)
+}
+
+// yamlNodeSize returns the number of nodes a node expands to with its aliases, the sizes are memoized so nested
+// aliases are counted without expanding them
+func yamlNodeSize(node *yaml.Node, sizes map[*yaml.Node]int) int {
+	if node == nil {
+		return 0
+	}
+	if size, ok := sizes[node]; ok {
+		return size
+	}
+	// An anchor containing itself counts once
+	sizes[node] = 1
+	size := 1
+	if node.Kind == yaml.AliasNode {
+		size = yamlNodeSize(node.Alias, sizes)
+	}
+	for _, child := range node.Content {
+		// Saturate instead of overflowing on alias bombs
+		size = min(size+yamlNodeSize(child, sizes), 1<<40)
+	}
+	sizes[node] = size
+	return size
+}
+
+// withParseTimeout returns the result of parse or an InputTooLargeError if it does not finish within the timeout.
+// The parsing goroutine is not interrupted, the other limits bound its duration and the parse slots the number of
+// parses running after their timeout. Waiting for a parse slot counts towards the timeout.
+func withParseTimeout(timeout time.Duration, parse func() (interface{}, error)) (interface{}, error) {
+	if timeout <= 0 {
+		return parse()
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case parseSlots <- struct{}{}:
+	case <-timer.C:
+		return nil, &InputTooLargeError{Limit: LimitParseTimeout, Maximum: timeout.Milliseconds()}
+	}
+
+	type parsed struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan parsed, 1)
+	go func() {
+		defer func() { <-parseSlots }()
+		value, err := parse()
+		done <- parsed{value: value, err: err}
+	}()
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-timer.C:
+		return nil, &InputTooLargeError{Limit: LimitParseTimeout, Maximum: timeout.Milliseconds()}
+	}
+}
+
+// parsedKey identifies a document checked against the limits
+type parsedKey struct {
+	limits InputLimits
+	hash   [sha256.Size]byte
+}
+
+// parsedDocuments keeps the last parsed documents by their limits and content, the oldest is dropped first
+type parsedDocuments struct {
+	mutex sync.Mutex
+	nodes map[parsedKey]*yaml.Node
+	order []parsedKey
+}
+
+// get returns the parsed document of the input, the documents are only read by the decoding
+func (p *parsedDocuments) get(limits InputLimits, data []byte) (*yaml.Node, bool) {
+	key := parsedKey{limits: limits, hash: sha256.Sum256(data)}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	node, found := p.nodes[key]
+	return node, found
+}
+
+func (p *parsedDocuments) put(limits InputLimits, data []byte, node *yaml.Node) {
+	key := parsedKey{limits: limits, hash: sha256.Sum256(data)}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, found := p.nodes[key]; found {
+		return
+	}
+	p.nodes[key] = node
+	p.order = append(p.order, key)
+	for len(p.order) > parsedInputsSize {
+		delete(p.nodes, p.order[0])
+		p.order = p.order[1:]
+	}
+}
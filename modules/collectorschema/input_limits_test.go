@@ -0,0 +1,158 @@
+package collectorschema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aliasBomb = "a: &a [x,x,x,x,x,x,x,x,x]\nb: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]\nc: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]\nd: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]\ne: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]\nf: [*e,*e,*e,*e,*e,*e,*e,*e,*e]\n"
+
+func TestInputLimits_YAML(t *testing.T) {
+	sm := NewSchemaManager()
+	limits := DefaultInputLimits
+
+	var components strings.Builder
+	components.WriteString("receivers:\n")
+	for i := 0; i <= limits.MaxMappingEntries; i++ {
+		fmt.Fprintf(&components, "  otlp/%d: {}\n", i)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		err   InputTooLargeError
+	}{
+		{name: "size", input: "receivers:\n  otlp:\n    x: " + strings.Repeat("a", limits.MaxSize) + "\n", err: InputTooLargeError{Limit: LimitSize, Maximum: 1 << 20, Size: 1<<20 + 27}},
+		{name: "flow depth", input: "receivers: " + strings.Repeat("[", limits.MaxDepth) + strings.Repeat("]", limits.MaxDepth), err: InputTooLargeError{Limit: LimitDepth, Maximum: 100, Line: 1}},
+		{name: "block depth", input: strings.Repeat("- ", limits.MaxDepth+1) + "a\n", err: InputTooLargeError{Limit: LimitDepth, Maximum: 100, Line: 1}},
+		{name: "map size", input: components.String(), err: InputTooLargeError{Limit: LimitMappingEntries, Maximum: 1000, Line: 2}},
+		{name: "aliases", input: aliasBomb, err: InputTooLargeError{Limit: LimitAliasExpansion, Maximum: 10000, Line: 5}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := sm.ValidateConfigYAML("0.139.0", []byte(test.input))
+			var tooLarge *InputTooLargeError
+			require.True(t, errors.As(err, &tooLarge), "expected an input too large error: %v", err)
+			assert.Equal(t, test.err, *tooLarge)
+			assert.True(t, strings.HasPrefix(err.Error(), "input too large: "))
+
+			_, err = sm.ValidateComponentYAML(ComponentTypeProcessor, "batch", "0.139.0", []byte(test.input))
+			require.True(t, errors.As(err, &tooLarge), "expected an input too large error: %v", err)
+			assert.Equal(t, test.err, *tooLarge)
+		})
+	}
+
+	// The limits allow the nesting depth and the map size
+	result, err := sm.ValidateConfigYAML("0.139.0", []byte("receivers:\n  otlp:\n    x: "+strings.Repeat("[", limits.MaxDepth-3)+strings.Repeat("]", limits.MaxDepth-3)+"\n"))
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	_, err = sm.ValidateComponentYAML(ComponentTypeProcessor, "batch", "0.139.0", []byte(""))
+	require.NoError(t, err)
+	_, err = sm.ValidateComponentYAML(ComponentTypeProcessor, "batch", "0.139.0", []byte("a: &a {timeout: 1s}\nb: *a\n"))
+	require.NoError(t, err)
+}
+
+func TestInputLimits_JSON(t *testing.T) {
+	sm := NewSchemaManager()
+	limits := DefaultInputLimits
+
+	keys := make([]string, 0, limits.MaxMappingEntries+1)
+	for i := 0; i <= limits.MaxMappingEntries; i++ {
+		keys = append(keys, fmt.Sprintf(`"key%d": 1`, i))
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{name: "size", input: `{"x": "` + strings.Repeat("a", limits.MaxSize) + `"}`, err: "input too large: 1048585 bytes exceed the maximum size of 1048576 bytes"},
+		{name: "depth", input: strings.Repeat(`{"a":`, limits.MaxDepth+1) + "1" + strings.Repeat("}", limits.MaxDepth+1), err: "input too large: the nesting exceeds the maximum depth of 100 at offset 500"},
+		{name: "map size", input: "{" + strings.Join(keys, ", ") + "}", err: "input too large: a map exceeds the maximum of 1000 keys at offset 12900"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := sm.ValidateComponentJSON(ComponentTypeReceiver, "otlp", "0.139.0", []byte(test.input))
+			require.EqualError(t, err, test.err)
+			_, err = sm.CheckKeySpelling(ComponentTypeReceiver, "otlp", "0.139.0", []byte(test.input))
+			require.EqualError(t, err, test.err)
+		})
+	}
+
+	// Brackets and colons in strings are not structure
+	result, err := sm.ValidateComponentJSON(ComponentTypeReceiver, "otlp", "0.139.0", []byte(`{"protocols": {"grpc": {"endpoint": "`+strings.Repeat(`[{\":`, limits.MaxMappingEntries+1)+`"}}}`))
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestInputLimits_Configured(t *testing.T) {
+	sm := NewSchemaManager()
+	sm.SetInputLimits(InputLimits{MaxSize: 64, MaxDepth: 2})
+
+	_, err := sm.ValidateConfigYAML("0.139.0", []byte("receivers:\n  otlp:\n    protocols: {}\n"))
+	require.EqualError(t, err, "input too large: the nesting exceeds the maximum depth of 2 at line 3")
+	_, err = sm.ValidateComponentJSON(ComponentTypeReceiver, "otlp", "0.139.0", []byte(`{"protocols": {"grpc": {"endpoint": "0.0.0.0:4317"}}}`))
+	require.EqualError(t, err, "input too large: the nesting exceeds the maximum depth of 2 at offset 23")
+
+	// Disabled limits accept the alias bomb, the parser limits the aliasing
+	_, err = sm.ValidateConfigYAML("0.139.0", []byte(aliasBomb))
+	require.Error(t, err)
+	sm.SetInputLimits(InputLimits{})
+	_, err = sm.ValidateConfigYAML("0.139.0", []byte(aliasBomb))
+	require.ErrorContains(t, err, "excessive aliasing")
+}
+
+func TestInputLimits_ParseTimeout(t *testing.T) {
+	_, err := withParseTimeout(time.Millisecond, func() (interface{}, error) {
+		time.Sleep(time.Second)
+		return nil, nil
+	})
+	require.EqualError(t, err, "input too large: parsing exceeds the timeout of 1ms")
+
+	value, err := withParseTimeout(time.Second, func() (interface{}, error) {
+		return "parsed", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "parsed", value)
+
+	// The parses abandoned after their timeout hold their slot, a parse waits for a free slot within its timeout
+	for i := 0; i < cap(parseSlots); i++ {
+		parseSlots <- struct{}{}
+	}
+	_, err = withParseTimeout(10*time.Millisecond, func() (interface{}, error) {
+		return "parsed", nil
+	})
+	require.EqualError(t, err, "input too large: parsing exceeds the timeout of 10ms")
+	for i := 0; i < cap(parseSlots); i++ {
+		<-parseSlots
+	}
+}
+
+func TestInputLimits_CheckYAML(t *testing.T) {
+	limits := DefaultInputLimits
+	require.NoError(t, limits.CheckYAML([]byte("receivers: [")))
+	require.NoError(t, limits.CheckYAML([]byte(`{"a": [1, 2]}`)))
+	require.NoError(t, limits.CheckYAML([]byte(`type == "port" && port == 6379`)))
+
+	// The checked document is parsed once, ParseYAML decodes it
+	input := []byte("receivers:\n  otlp/checked: {}\n")
+	require.NoError(t, limits.CheckYAML(input))
+	_, found := parsedInputs.get(limits, input)
+	assert.True(t, found)
+	_, found = parsedInputs.get(InputLimits{}, input)
+	assert.False(t, found)
+	value, err := limits.ParseYAML(input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"receivers": map[string]interface{}{"otlp/checked": map[string]interface{}{}}}, value)
+
+	err = limits.CheckYAML([]byte(aliasBomb))
+	var tooLarge *InputTooLargeError
+	require.True(t, errors.As(err, &tooLarge))
+	assert.Equal(t, LimitAliasExpansion, tooLarge.Limit)
+}
@@ -0,0 +1,133 @@
+package collectorschema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LicenseApache2 is the license of the collector modules and most of their dependencies
+const LicenseApache2 = "Apache-2.0"
+
+// ModuleLicense is the license of a Go module as an SPDX identifier e.g. MIT, unknown if it was not detected
+type ModuleLicense struct {
+	Module  string `yaml:"module" json:"module"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+	License string `yaml:"license" json:"license"`
+}
+
+// ComponentLicense is the license of the module of a component and the licenses of its dependencies other than
+// Apache-2.0
+type ComponentLicense struct {
+	Type          ComponentType   `json:"type"`
+	Name          string          `json:"name"`
+	Module        string          `json:"module,omitempty"`
+	ModuleVersion string          `json:"moduleVersion,omitempty"`
+	License       string          `json:"license,omitempty"`
+	Dependencies  []ModuleLicense `json:"dependencies,omitempty"`
+}
+
+// LicenseReport lists the licenses of a set of components of a version
+type LicenseReport struct {
+	Version    string             `json:"version"`
+	Components []ComponentLicense `json:"components"`
+	// Licenses are the modules of the components and of their dependencies by license
+	Licenses map[string][]string `json:"licenses"`
+	// Unknown are the components and modules without license metadata, either not part of the version or generated
+	// without it
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// GetComponentLicense returns the licenses of a component captured when its schema was generated
+func (sm *SchemaManager) GetComponentLicense(componentType ComponentType, componentName string, version string) (*ComponentLicense, error) {
+	manifest, err := sm.GetComponentManifest(version)
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range manifest.Components {
+		if component.Type != componentType || component.Name != componentName {
+			continue
+		}
+		license := &ComponentLicense{
+			Type:          component.Type,
+			Name:          component.Name,
+			Module:        component.Module,
+			ModuleVersion: component.ModuleVersion,
+			License:       component.License,
+			Dependencies:  component.DependencyLicenses,
+		}
+		if license.Module == "" {
+			if module, err := sm.GetComponentModule(componentType, componentName, version); err == nil {
+				license.Module, license.ModuleVersion = module.Module, module.ModuleVersion
+			}
+		}
+		return license, nil
+	}
+	return nil, sm.componentNotFound(componentType, componentName, version)
+}
+
+// GetLicenseReport returns the licenses of the components of a version, components unknown to the version e.g.
+// components of a custom distribution are reported as unknown
+func (sm *SchemaManager) GetLicenseReport(version string, components map[ComponentType][]string) (*LicenseReport, error) {
+	report := &LicenseReport{Version: version, Components: []ComponentLicense{}, Licenses: make(map[string][]string)}
+	modules := make(map[string]map[string]bool)
+	addModule := func(license, module string) {
+		if modules[license] == nil {
+			modules[license] = make(map[string]bool)
+		}
+		modules[license][module] = true
+	}
+
+	for _, componentType := range sortedKeys(components) {
+		names := append([]string(nil), components[componentType]...)
+		sort.Strings(names)
+		for _, name := range names {
+			license, err := sm.GetComponentLicense(componentType, name, version)
+			var notFound *ComponentNotFoundError
+			if errors.As(err, &notFound) {
+				report.Unknown = append(report.Unknown, fmt.Sprintf("%s %s", componentType, name))
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			report.Components = append(report.Components, *license)
+			if license.License == "" {
+				report.Unknown = append(report.Unknown, fmt.Sprintf("%s %s", componentType, name))
+				continue
+			}
+			addModule(license.License, strings.TrimSpace(license.Module+" "+license.ModuleVersion))
+			for _, dependency := range license.Dependencies {
+				addModule(dependency.License, strings.TrimSpace(dependency.Module+" "+dependency.Version))
+			}
+		}
+	}
+	for license, licenseModules := range modules {
+		report.Licenses[license] = sortedKeys(licenseModules)
+	}
+	return report, nil
+}
+
+// Notice returns the report as a NOTICE file listing the modules by license, Apache-2.0 first
+func (r *LicenseReport) Notice() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("OpenTelemetry Collector %s components\n\n", r.Version))
+	sb.WriteString("This distribution includes the following Go modules under the listed licenses.\n")
+
+	licenses := sortedKeys(r.Licenses)
+	sort.SliceStable(licenses, func(i, j int) bool { return licenses[i] == LicenseApache2 && licenses[j] != LicenseApache2 })
+	for _, license := range licenses {
+		sb.WriteString(fmt.Sprintf("\n%s:\n", license))
+		for _, module := range r.Licenses[license] {
+			sb.WriteString(fmt.Sprintf("  %s\n", module))
+		}
+	}
+	if len(r.Unknown) > 0 {
+		sb.WriteString("\nComponents and modules without license information, review them manually:\n")
+		for _, component := range r.Unknown {
+			sb.WriteString(fmt.Sprintf("  %s\n", component))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package collectorschema
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLicenseReport(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/components.yaml": {Data: []byte(`components:
+  - type: receiver
+    name: kafka
+    files: [receiver_kafka.yaml]
+    module: github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver
+    module_version: v1.0.0
+    license: Apache-2.0
+    dependency_licenses:
+      - module: github.com/IBM/sarama
+        version: v1.46.0
+        license: MIT
+      - module: github.com/hashicorp/go-uuid
+        version: v1.0.3
+        license: MPL-2.0
+  - type: exporter
+    name: kafka
+    files: [exporter_kafka.yaml]
+    module: github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter
+    module_version: v1.0.0
+    license: Apache-2.0
+    dependency_licenses:
+      - module: github.com/IBM/sarama
+        version: v1.46.0
+        license: MIT
+  - type: exporter
+    name: debug
+    files: [exporter_debug.yaml]
+`)},
+		"schemas/1.0.0/receiver_kafka.yaml": {Data: []byte("type: object\n")},
+		"schemas/1.0.0/exporter_kafka.yaml": {Data: []byte("type: object\n")},
+		"schemas/1.0.0/exporter_debug.yaml": {Data: []byte("type: object\n")},
+	})
+
+	report, err := sm.GetLicenseReport("1.0.0", map[ComponentType][]string{
+		ComponentTypeReceiver: {"kafka", "custom"},
+		ComponentTypeExporter: {"kafka", "debug"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, report.Components, 3)
+	assert.Equal(t, map[string][]string{
+		"Apache-2.0": {
+			"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter v1.0.0",
+			"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver v1.0.0",
+		},
+		"MIT":     {"github.com/IBM/sarama v1.46.0"},
+		"MPL-2.0": {"github.com/hashicorp/go-uuid v1.0.3"},
+	}, report.Licenses)
+	assert.Equal(t, []string{"exporter debug", "receiver custom"}, report.Unknown)
+
+	notice := report.Notice()
+	assert.Contains(t, notice, "Apache-2.0:\n  github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter v1.0.0\n")
+	assert.Less(t, strings.Index(notice, "Apache-2.0:"), strings.Index(notice, "MIT:"))
+	assert.Contains(t, notice, "review them manually:\n  exporter debug\n  receiver custom\n")
+}
@@ -0,0 +1,70 @@
+package collectorschema
+
+import (
+	"time"
+)
+
+const (
+	// DefaultNotFoundCacheTTL is how long a lookup of a missing component is answered from the not found cache
+	DefaultNotFoundCacheTTL = 5 * time.Minute
+	// maxNotFoundCacheEntries bounds the not found cache, agents guessing names would otherwise grow it without limit
+	maxNotFoundCacheEntries = 1024
+)
+
+// notFoundEntry is a cached error of a component missing in a version
+type notFoundEntry struct {
+	err     *ComponentNotFoundError
+	expires time.Time
+}
+
+// SetNotFoundCacheTTL sets how long the errors of the missing components are cached, 0 disables the cache
+func (sm *SchemaManager) SetNotFoundCacheTTL(ttl time.Duration) {
+	sm.notFoundMutex.Lock()
+	defer sm.notFoundMutex.Unlock()
+	sm.notFoundTTL = ttl
+	clear(sm.notFound)
+}
+
+// cachedNotFound returns the cached error of a component missing in a version, the error is shared by the lookups
+// and must not be modified
+func (sm *SchemaManager) cachedNotFound(cacheKey string) (*ComponentNotFoundError, bool) {
+	sm.notFoundMutex.Lock()
+	defer sm.notFoundMutex.Unlock()
+	if sm.notFoundTTL <= 0 {
+		return nil, false
+	}
+	entry, ok := sm.notFound[cacheKey]
+	if !ok {
+		sm.observer.CacheAccess(CacheNotFound, false)
+		return nil, false
+	}
+	if sm.now().After(entry.expires) {
+		delete(sm.notFound, cacheKey)
+		sm.observer.CacheAccess(CacheNotFound, false)
+		return nil, false
+	}
+	sm.observer.CacheAccess(CacheNotFound, true)
+	return entry.err, true
+}
+
+// cacheNotFound caches the error of a component missing in a version. When the cache is full the expired entries are
+// dropped, the error is not cached if none expired.
+func (sm *SchemaManager) cacheNotFound(cacheKey string, err *ComponentNotFoundError) {
+	sm.notFoundMutex.Lock()
+	defer sm.notFoundMutex.Unlock()
+	if sm.notFoundTTL <= 0 {
+		return
+	}
+	now := sm.now()
+	if len(sm.notFound) >= maxNotFoundCacheEntries {
+		for key, entry := range sm.notFound {
+			if now.After(entry.expires) {
+				delete(sm.notFound, key)
+			}
+		}
+		if len(sm.notFound) >= maxNotFoundCacheEntries {
+			return
+		}
+	}
+	sm.notFound[cacheKey] = notFoundEntry{err: err, expires: now.Add(sm.notFoundTTL)}
+}
@@ -0,0 +1,80 @@
+package collectorschema
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotFoundCache(t *testing.T) {
+	observer := &recordingObserver{cacheAccesses: make(map[string][]bool)}
+	sm := NewSchemaManager()
+	sm.SetObserver(observer)
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	sm.now = func() time.Time { return now }
+
+	_, err := sm.GetComponentSchema(ComponentTypeReceiver, "otlpp", "0.139.0")
+	var notFound *ComponentNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, []string{"otlp"}, notFound.Suggestions)
+	assert.Equal(t, "schema not found for component receiver otlpp, did you mean otlp?", err.Error())
+
+	// The repeated lookup is served from the cache with the same error
+	_, cachedErr := sm.GetComponentSchema(ComponentTypeReceiver, "otlpp", "0.139.0")
+	assert.Same(t, notFound, cachedErr)
+	assert.Len(t, observer.schemaLoads, 1)
+	assert.Equal(t, []bool{false, true}, observer.cacheAccesses[CacheNotFound])
+
+	// The entry expires after the TTL
+	now = now.Add(DefaultNotFoundCacheTTL + time.Second)
+	_, err = sm.GetComponentSchema(ComponentTypeReceiver, "otlpp", "0.139.0")
+	assert.Error(t, err)
+	assert.Len(t, observer.schemaLoads, 2)
+
+	// Other errors and found components are not cached
+	_, err = sm.GetComponentSchema(ComponentTypeReceiver, "otlp", "0.139.0")
+	require.NoError(t, err)
+	assert.NotContains(t, sm.notFound, "receiver_otlp_0.139.0")
+
+	// A TTL of 0 disables the cache
+	sm.SetNotFoundCacheTTL(0)
+	assert.Empty(t, sm.notFound)
+	_, err = sm.GetComponentSchema(ComponentTypeReceiver, "otlpp", "0.139.0")
+	assert.Error(t, err)
+	assert.Empty(t, sm.notFound)
+	assert.Len(t, observer.schemaLoads, 4)
+}
+
+func TestNotFoundCache_Full(t *testing.T) {
+	sm := NewSchemaManager()
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	sm.now = func() time.Time { return now }
+	for i := range maxNotFoundCacheEntries {
+		sm.cacheNotFound(fmt.Sprintf("receiver_missing%d_0.139.0", i), &ComponentNotFoundError{})
+	}
+
+	// A full cache drops the expired entries only
+	sm.cacheNotFound("receiver_other_0.139.0", &ComponentNotFoundError{})
+	assert.NotContains(t, sm.notFound, "receiver_other_0.139.0")
+	now = now.Add(DefaultNotFoundCacheTTL + time.Second)
+	sm.cacheNotFound("receiver_other_0.139.0", &ComponentNotFoundError{})
+	assert.Len(t, sm.notFound, 1)
+}
+
+func TestSimilarComponentNames(t *testing.T) {
+	sm := NewSchemaManager()
+	assert.Equal(t, []string{"kafka"}, sm.similarComponentNames(ComponentTypeExporter, "Kafka"))
+	assert.Equal(t, []string{"otlp"}, sm.similarComponentNames(ComponentTypeReceiver, "otlpreceiver"))
+	assert.Equal(t, []string{"prometheus"}, sm.similarComponentNames(ComponentTypeExporter, "prometheus_remote_write"))
+	assert.Empty(t, sm.similarComponentNames(ComponentTypeExporter, "xyz"))
+
+	// A component available in other versions lists the versions instead of the suggestions
+	_, err := sm.GetComponentSchema(ComponentTypeConnector, "failover", "0.135.0")
+	var notFound *ComponentNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Empty(t, notFound.Suggestions)
+}
@@ -0,0 +1,36 @@
+package collectorschema
+
+import "time"
+
+const (
+	// CacheSchema is the cache of the parsed component schemas
+	CacheSchema = "schema"
+	// CacheTranslation is the cache of the translated READMEs
+	CacheTranslation = "translation"
+	// CacheConfigSchema is the cache of the full collector config schemas
+	CacheConfigSchema = "config_schema"
+	// CacheNotFound is the cache of the errors of the components missing in a version
+	CacheNotFound = "not_found"
+)
+
+// Observer is notified about the schema manager operations e.g. to expose them as metrics.
+// The methods are called synchronously and must not block.
+type Observer interface {
+	// CacheAccess is called on every lookup of a cache e.g. schema
+	CacheAccess(cache string, hit bool)
+	// SchemaLoaded is called when a component schema is read from the schema files, err is set if it failed
+	SchemaLoaded(componentType ComponentType, version string, err error)
+	// RAGQuery is called after every documentation search
+	RAGQuery(duration time.Duration, err error)
+}
+
+type noopObserver struct{}
+
+func (noopObserver) CacheAccess(string, bool)                  {}
+func (noopObserver) SchemaLoaded(ComponentType, string, error) {}
+func (noopObserver) RAGQuery(time.Duration, error)             {}
+
+// SetObserver configures the observer of the schema manager operations
+func (sm *SchemaManager) SetObserver(observer Observer) {
+	sm.observer = observer
+}
@@ -0,0 +1,48 @@
+package collectorschema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	cacheAccesses map[string][]bool
+	schemaLoads   []error
+	ragQueries    int
+}
+
+func (o *recordingObserver) CacheAccess(cache string, hit bool) {
+	o.cacheAccesses[cache] = append(o.cacheAccesses[cache], hit)
+}
+
+func (o *recordingObserver) SchemaLoaded(_ ComponentType, _ string, err error) {
+	o.schemaLoads = append(o.schemaLoads, err)
+}
+
+func (o *recordingObserver) RAGQuery(duration time.Duration, err error) {
+	o.ragQueries++
+}
+
+func TestSchemaManager_Observer(t *testing.T) {
+	observer := &recordingObserver{cacheAccesses: make(map[string][]bool)}
+	sm := NewSchemaManager()
+	sm.SetObserver(observer)
+
+	_, err := sm.GetComponentSchema(ComponentTypeReceiver, "otlp", "0.139.0")
+	require.NoError(t, err)
+	_, err = sm.GetComponentSchema(ComponentTypeReceiver, "otlp", "0.139.0")
+	require.NoError(t, err)
+	_, err = sm.GetComponentSchema(ComponentTypeReceiver, "nonexistent", "0.139.0")
+	require.Error(t, err)
+	assert.Equal(t, []bool{false, true, false}, observer.cacheAccesses[CacheSchema])
+	require.Len(t, observer.schemaLoads, 2)
+	assert.NoError(t, observer.schemaLoads[0])
+	assert.Error(t, observer.schemaLoads[1])
+
+	_, err = sm.QueryDocumentation("kafka exporter", "0.139.0", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, observer.ragQueries)
+}
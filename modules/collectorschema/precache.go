@@ -0,0 +1,103 @@
+package collectorschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LatestVersion is the version of a precache entry resolved to the latest collector version
+const LatestVersion = "latest"
+
+// PrecacheEntry is a component whose schema is loaded at startup e.g. receiver/otlp@0.139.0
+type PrecacheEntry struct {
+	Type    ComponentType
+	Name    string
+	Version string
+}
+
+func (e PrecacheEntry) String() string {
+	return fmt.Sprintf("%s/%s@%s", e.Type, e.Name, e.Version)
+}
+
+// ParsePrecacheEntries parses a comma separated list of components e.g. "receiver/otlp@0.139.0,processor/batch@latest",
+// the version defaults to latest
+func ParsePrecacheEntries(value string) ([]PrecacheEntry, error) {
+	var entries []PrecacheEntry
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		component, version, _ := strings.Cut(item, "@")
+		kind, name, ok := strings.Cut(component, "/")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid precache entry %q, expected kind/name@version e.g. receiver/otlp@0.139.0", item)
+		}
+		componentType, err := ParseComponentType(kind)
+		if err != nil {
+			return nil, fmt.Errorf("invalid precache entry %q: %w", item, err)
+		}
+		if version == "" {
+			version = LatestVersion
+		}
+		entries = append(entries, PrecacheEntry{Type: componentType, Name: name, Version: version})
+	}
+	return entries, nil
+}
+
+// ResolvePrecacheEntries resolves the latest versions of the entries and checks that the versions exist, so invalid
+// entries are reported at startup instead of by the warm-up
+func (sm *SchemaManager) ResolvePrecacheEntries(entries []PrecacheEntry) ([]PrecacheEntry, error) {
+	versions, err := sm.GetAllVersions()
+	if err != nil {
+		return nil, err
+	}
+	latestVersion, err := sm.GetLatestVersion()
+	if err != nil {
+		return nil, err
+	}
+	resolved := make([]PrecacheEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Version == LatestVersion {
+			entry.Version = latestVersion
+		}
+		if !slices.Contains(versions, entry.Version) {
+			return nil, fmt.Errorf("invalid precache entry %s: version %s is not supported", entry, entry.Version)
+		}
+		resolved = append(resolved, entry)
+	}
+	return resolved, nil
+}
+
+// Precache loads the schemas and the component manifests of the versions of the resolved entries, so the first tool
+// calls are served from the caches
+func (sm *SchemaManager) Precache(entries []PrecacheEntry) error {
+	for _, entry := range entries {
+		if _, err := sm.GetComponentManifest(entry.Version); err != nil {
+			return fmt.Errorf("failed to precache %s: %w", entry, err)
+		}
+		if _, err := sm.GetComponentSchema(entry.Type, entry.Name, entry.Version); err != nil {
+			return fmt.Errorf("failed to precache %s: %w", entry, err)
+		}
+	}
+	return nil
+}
+
+// ProgressFunc reports the progress of a long-running operation as done of total steps with a message describing the
+// completed step
+type ProgressFunc func(done, total int, message string)
+
+// PrewarmRAG builds the documentation search index, otherwise it is built by the first search
+func (sm *SchemaManager) PrewarmRAG() error {
+	_, err := sm.readyRAGSearchIndex(nil)
+	return err
+}
+
+// BuildRAGDatabase builds the documentation search index like PrewarmRAG and reports each indexed version to the
+// progress function. It returns without reporting progress if the index is already built, and waits without reporting
+// progress if another caller is building it.
+func (sm *SchemaManager) BuildRAGDatabase(progress ProgressFunc) error {
+	_, err := sm.readyRAGSearchIndex(progress)
+	return err
+}
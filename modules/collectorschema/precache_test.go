@@ -0,0 +1,62 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrecacheEntries(t *testing.T) {
+	entries, err := ParsePrecacheEntries("receiver/otlp@0.139.0, processor/batch@latest,exporter/debug")
+	require.NoError(t, err)
+	assert.Equal(t, []PrecacheEntry{
+		{Type: ComponentTypeReceiver, Name: "otlp", Version: "0.139.0"},
+		{Type: ComponentTypeProcessor, Name: "batch", Version: LatestVersion},
+		{Type: ComponentTypeExporter, Name: "debug", Version: LatestVersion},
+	}, entries)
+
+	_, err = ParsePrecacheEntries("otlp@0.139.0")
+	assert.ErrorContains(t, err, `invalid precache entry "otlp@0.139.0", expected kind/name@version`)
+	_, err = ParsePrecacheEntries("sink/otlp")
+	assert.ErrorContains(t, err, `invalid precache entry "sink/otlp"`)
+}
+
+func TestPrecache(t *testing.T) {
+	sm := NewSchemaManager()
+	latestVersion, err := sm.GetLatestVersion()
+	require.NoError(t, err)
+
+	entries, err := sm.ResolvePrecacheEntries([]PrecacheEntry{{Type: ComponentTypeProcessor, Name: "batch", Version: LatestVersion}})
+	require.NoError(t, err)
+	assert.Equal(t, []PrecacheEntry{{Type: ComponentTypeProcessor, Name: "batch", Version: latestVersion}}, entries)
+
+	require.NoError(t, sm.Precache(entries))
+	assert.Contains(t, sm.cache, "processor_batch_"+latestVersion)
+
+	_, err = sm.ResolvePrecacheEntries([]PrecacheEntry{{Type: ComponentTypeProcessor, Name: "batch", Version: "0.1.0"}})
+	assert.ErrorContains(t, err, "version 0.1.0 is not supported")
+
+	err = sm.Precache([]PrecacheEntry{{Type: ComponentTypeProcessor, Name: "unknown", Version: latestVersion}})
+	assert.ErrorContains(t, err, "failed to precache processor/unknown@"+latestVersion)
+}
+
+func TestBuildRAGDatabase(t *testing.T) {
+	sm := NewSchemaManager()
+	versions, err := sm.GetAllVersions()
+	require.NoError(t, err)
+
+	var done []int
+	require.NoError(t, sm.BuildRAGDatabase(func(progress, total int, message string) {
+		assert.Equal(t, len(versions), total)
+		assert.Contains(t, message, "documents of version")
+		done = append(done, progress)
+	}))
+	assert.Len(t, done, len(versions))
+	assert.Equal(t, len(versions), done[len(done)-1])
+
+	// The built index is not indexed again
+	require.NoError(t, sm.BuildRAGDatabase(func(int, int, string) {
+		t.Error("progress reported for a built index")
+	}))
+}
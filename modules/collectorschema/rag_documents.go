@@ -0,0 +1,143 @@
+package collectorschema
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/philippgille/chromem-go"
+)
+
+// ragDocuments returns the documents of a specific version indexed in the RAG database: the markdown files, the core
+// documentation pages, the changelog entries and the schema fields
+func (sm *SchemaManager) ragDocuments(version string) ([]chromem.Document, error) {
+	docs, err := sm.markdownDocuments(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index markdown files for version %s: %w", version, err)
+	}
+	docs = append(docs, sm.coreDocuments(version)...)
+	docs = append(docs, sm.changelogDocuments(version)...)
+	fieldDocs, err := sm.schemaFieldDocuments(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index schema fields for version %s: %w", version, err)
+	}
+	return append(docs, fieldDocs...), nil
+}
+
+// changelogDocuments returns every entry of the core and contrib changelogs of a specific version as a document, entries
+// of a component e.g. `exporter/kafka`: ... carry the component metadata
+func (sm *SchemaManager) changelogDocuments(version string) []chromem.Document {
+	var docs []chromem.Document
+	// Not every version is released with a changelog
+	for _, file := range sm.changelogFiles(version) {
+		for _, entry := range parseChangelog(file.Content) {
+			section := changelogSectionName(entry.Section)
+			metadata := map[string]string{
+				"version":   version,
+				"component": "changelog",
+				"file_path": file.Path,
+				"file_type": "changelog",
+				"source":    string(file.Source),
+				"section":   section,
+				"title":     section,
+			}
+			if componentType, componentName, ok := changelogComponent(entry.Text); ok {
+				metadata["component_type"] = componentType
+				metadata["component_name"] = componentName
+				metadata["component"] = fmt.Sprintf("%s_%s", componentType, componentName)
+				metadata["title"] = fmt.Sprintf("%s %s %s", componentType, componentName, section)
+			}
+
+			docs = append(docs, chromem.Document{
+				ID:       fmt.Sprintf("%s/changelog/%d", version, len(docs)),
+				Content:  fmt.Sprintf("%s: %s", section, entry.Text),
+				Metadata: metadata,
+			})
+		}
+	}
+	return docs
+}
+
+// schemaFieldDocuments returns the fields of all component schemas of a specific version as small documents with the
+// field path metadata e.g. limit_mib of the memory_limiter processor
+func (sm *SchemaManager) schemaFieldDocuments(version string) ([]chromem.Document, error) {
+	components, err := sm.listEmbeddedComponents(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []chromem.Document
+
+	for componentType, componentNames := range components {
+		for _, componentName := range componentNames {
+			schema, err := sm.loadSchemaFromFile(componentType, componentName, version)
+			if err != nil {
+				fmt.Printf("Warning: failed to load schema %s %s for RAG indexing: %v\n", componentType, componentName, err)
+				continue
+			}
+
+			component := fmt.Sprintf("%s_%s", componentType, componentName)
+			walkFields(schema.Schema, "", func(fieldPath string, fieldSchema map[string]interface{}) {
+				docs = append(docs, chromem.Document{
+					ID:      fmt.Sprintf("%s/%s/%s", version, component, fieldPath),
+					Content: fieldDocument(componentType, componentName, fieldPath, fieldSchema),
+					Metadata: map[string]string{
+						"version":        version,
+						"component":      component,
+						"component_type": string(componentType),
+						"component_name": componentName,
+						"field_path":     fieldPath,
+						"file_path":      filepath.Join(fmt.Sprintf("schemas/%s", version), component+".yaml"),
+						"file_type":      "schema_field",
+						"title":          fieldPath,
+					},
+				})
+			})
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+	return docs, nil
+}
+
+// fieldDocument returns the searchable text of a schema field e.g.
+// memory_limiter processor field limit_mib (integer, default 0): Maximum amount of memory in MiB
+func fieldDocument(componentType ComponentType, componentName string, fieldPath string, fieldSchema map[string]interface{}) string {
+	details := []string{fieldType(fieldSchema)}
+	if defaultValue, ok := fieldSchema["default"]; ok {
+		details = append(details, fmt.Sprintf("default %v", defaultValue))
+	}
+	if deprecated, _ := fieldSchema["deprecated"].(bool); deprecated {
+		details = append(details, "deprecated")
+	}
+
+	content := fmt.Sprintf("%s %s field %s (%s)", componentName, componentType, fieldPath, strings.Join(details, ", "))
+	if description, ok := fieldSchema["description"].(string); ok && strings.TrimSpace(description) != "" {
+		content += ": " + strings.TrimSpace(description)
+	}
+	return content
+}
+
+// changelogSectionName returns the changelog heading without the markdown and emojis e.g. breaking changes
+func changelogSectionName(section string) string {
+	return strings.TrimFunc(strings.TrimLeft(section, "#"), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// changelogComponent returns the component of a changelog entry in the type/name format e.g. `exporter/kafka`: ...
+func changelogComponent(text string) (string, string, bool) {
+	if !strings.HasPrefix(text, "`") {
+		return "", "", false
+	}
+	component, _, ok := strings.Cut(strings.TrimPrefix(text, "`"), "`")
+	if !ok {
+		return "", "", false
+	}
+	componentType, componentName, ok := strings.Cut(component, "/")
+	if !ok || !ComponentType(componentType).Valid() || componentName == "" {
+		return "", "", false
+	}
+	return componentType, componentName, true
+}
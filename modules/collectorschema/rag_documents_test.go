@@ -0,0 +1,59 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelogComponent(t *testing.T) {
+	componentType, componentName, ok := changelogComponent("`exporter/kafka`: Deprecate `topic` (#456)")
+	require.True(t, ok)
+	assert.Equal(t, "exporter", componentType)
+	assert.Equal(t, "kafka", componentName)
+
+	_, _, ok = changelogComponent("`pkg/ottl`: new function")
+	assert.False(t, ok)
+	_, _, ok = changelogComponent("Update Go to 1.25")
+	assert.False(t, ok)
+}
+
+func TestChangelogSectionName(t *testing.T) {
+	assert.Equal(t, "breaking changes", changelogSectionName("### 🛑 breaking changes 🛑"))
+	assert.Equal(t, "v0.138.0", changelogSectionName("## v0.138.0"))
+}
+
+func TestFieldDocument(t *testing.T) {
+	fieldSchema := map[string]interface{}{"type": "integer", "default": 0, "description": "Maximum amount of memory in MiB"}
+	assert.Equal(t, "memory_limiter processor field limit_mib (integer, default 0): Maximum amount of memory in MiB",
+		fieldDocument(ComponentTypeProcessor, "memory_limiter", "limit_mib", fieldSchema))
+	assert.Equal(t, "kafka exporter field topic (string, deprecated)",
+		fieldDocument(ComponentTypeExporter, "kafka", "topic", map[string]interface{}{"type": "string", "deprecated": true}))
+}
+
+func TestQueryDocumentation_SchemaFields(t *testing.T) {
+	sm := NewSchemaManager()
+	results, err := sm.QueryDocumentation("setting to limit memory usage", "0.139.0", 5)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "schema_field", results[0].Metadata["file_type"])
+	assert.Equal(t, "processor_memory_limiter", results[0].Component)
+	assert.NotEmpty(t, results[0].Metadata["field_path"])
+
+	fileTypes := make(map[string]bool)
+	for _, result := range results {
+		fileTypes[result.Metadata["file_type"]] = true
+	}
+	assert.True(t, fileTypes["markdown"], "the README should surface next to the fields")
+}
+
+func TestQueryDocumentation_Changelog(t *testing.T) {
+	sm := NewSchemaManager()
+	results, err := sm.QueryDocumentationWithFilters("deprecate topic", 3, "exporter", "kafka", "0.139.0")
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "changelog", results[0].Metadata["file_type"])
+	assert.Equal(t, "deprecations", results[0].Metadata["section"])
+	assert.Contains(t, results[0].Content, "`exporter/kafka`")
+}
@@ -0,0 +1,243 @@
+package collectorschema
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/philippgille/chromem-go"
+)
+
+// RAGIndexFile is the file name of the precomputed RAG index in the index directory
+const RAGIndexFile = "rag-index.json.gz"
+
+const (
+	// EmbeddingProviderSimple is the hash based embedding without external dependencies
+	EmbeddingProviderSimple = "simple"
+	// EmbeddingProviderOpenAI is the OpenAI or an OpenAI compatible embeddings API e.g. Ollama
+	EmbeddingProviderOpenAI = "openai"
+)
+
+// EmbeddingConfig selects the embedding of the documents and queries
+type EmbeddingConfig struct {
+	// Provider is simple or openai
+	Provider string `json:"provider"`
+	// Model is the embedding model of the openai provider e.g. text-embedding-3-small
+	Model string `json:"model,omitempty"`
+	// BaseURL of an OpenAI compatible API, defaults to the OpenAI API
+	BaseURL string `json:"baseUrl,omitempty"`
+	// APIKey is never persisted in the index
+	APIKey string `json:"-"`
+}
+
+// NewEmbeddingFunc returns the embedding function of the provider
+func NewEmbeddingFunc(config EmbeddingConfig) (chromem.EmbeddingFunc, error) {
+	switch config.Provider {
+	case "", EmbeddingProviderSimple:
+		return createSimpleEmbeddingFunc(), nil
+	case EmbeddingProviderOpenAI:
+		model := config.Model
+		if model == "" {
+			model = string(chromem.EmbeddingModelOpenAI3Small)
+		}
+		if config.BaseURL != "" {
+			return chromem.NewEmbeddingFuncOpenAICompat(config.BaseURL, config.APIKey, model, nil), nil
+		}
+		if config.APIKey == "" {
+			return nil, fmt.Errorf("the openai embedding provider requires an API key")
+		}
+		return chromem.NewEmbeddingFuncOpenAI(config.APIKey, chromem.EmbeddingModelOpenAI(model)), nil
+	default:
+		return nil, fmt.Errorf("unsupported embedding provider %q, it can be %s or %s", config.Provider, EmbeddingProviderSimple, EmbeddingProviderOpenAI)
+	}
+}
+
+// EmbeddingsCacheNamespace is the namespace of the embeddings in the content cache
+const EmbeddingsCacheNamespace = "embeddings"
+
+// ContentCache caches content computed by external services, it is shared with the other features of the server.
+// Get returns the content of a key of a namespace and Put stores it for the TTL, or without expiration if it is 0.
+type ContentCache interface {
+	Get(namespace, key string) ([]byte, bool)
+	Put(namespace, key string, content []byte, ttl time.Duration) (string, error)
+}
+
+// SetContentCache configures the cache of the embeddings of the openai provider, the documents and queries already
+// embedded by the API are not sent again. It has to be set before BuildRAGIndex or LoadRAGIndex.
+func (sm *SchemaManager) SetContentCache(cache ContentCache) {
+	sm.contentCache = cache
+}
+
+// cachedEmbeddingFunc returns the embedding function of the config, the embeddings of the openai provider are cached
+// by model and text hash in the content cache if one is configured
+func (sm *SchemaManager) cachedEmbeddingFunc(config EmbeddingConfig) (chromem.EmbeddingFunc, error) {
+	embeddingFunc, err := NewEmbeddingFunc(config)
+	if err != nil || sm.contentCache == nil || config.Provider != EmbeddingProviderOpenAI {
+		return embeddingFunc, err
+	}
+	prefix := fmt.Sprintf("%s/%s/%s/", config.Provider, config.BaseURL, config.Model)
+	return func(ctx context.Context, text string) ([]float32, error) {
+		sum := sha256.Sum256([]byte(text))
+		key := prefix + hex.EncodeToString(sum[:])
+		if content, found := sm.contentCache.Get(EmbeddingsCacheNamespace, key); found && len(content)%4 == 0 {
+			embedding := make([]float32, len(content)/4)
+			for i := range embedding {
+				embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(content[i*4:]))
+			}
+			return embedding, nil
+		}
+		embedding, err := embeddingFunc(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		content := make([]byte, 0, len(embedding)*4)
+		for _, value := range embedding {
+			content = binary.LittleEndian.AppendUint32(content, math.Float32bits(value))
+		}
+		// An embedding that cannot be cached is still returned
+		_, _ = sm.contentCache.Put(EmbeddingsCacheNamespace, key, content, 0)
+		return embedding, nil
+	}, nil
+}
+
+// RAGIndexInfo describes a precomputed RAG index
+type RAGIndexInfo struct {
+	Embedding  EmbeddingConfig `json:"embedding"`
+	Dimensions int             `json:"dimensions"`
+	Versions   []string        `json:"versions"`
+	Documents  int             `json:"documents"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// ragIndex is the persisted RAG index, the documents carry their embeddings
+type ragIndex struct {
+	Info      RAGIndexInfo       `json:"info"`
+	Documents []ragIndexDocument `json:"documents"`
+}
+
+type ragIndexDocument struct {
+	ID        string            `json:"id"`
+	Content   string            `json:"content"`
+	Metadata  map[string]string `json:"metadata"`
+	Embedding []float32         `json:"embedding"`
+}
+
+// chromemDocuments returns the index documents with their embeddings
+func (index *ragIndex) chromemDocuments() []chromem.Document {
+	docs := make([]chromem.Document, 0, len(index.Documents))
+	for _, doc := range index.Documents {
+		docs = append(docs, chromem.Document{ID: doc.ID, Content: doc.Content, Metadata: doc.Metadata, Embedding: doc.Embedding})
+	}
+	return docs
+}
+
+// BuildRAGIndex embeds the documents of the versions with the embedding of the config and writes the index to the
+// directory, all versions are indexed if versions is empty. The server loads the index with LoadRAGIndex instead of
+// embedding the documents at runtime. Each embedded version is reported to the progress function if it is set.
+func (sm *SchemaManager) BuildRAGIndex(ctx context.Context, dir string, versions []string, config EmbeddingConfig, concurrency int, progress ProgressFunc) (*RAGIndexInfo, error) {
+	embeddingFunc, err := sm.cachedEmbeddingFunc(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		if versions, err = sm.GetAllVersions(); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return CompareVersions(versions[i], versions[j]) < 0 })
+
+	// The collection embeds the documents of a version concurrently
+	collection, err := chromem.NewDB().CreateCollection("otel-docs", nil, embeddingFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RAG collection: %w", err)
+	}
+	var docs []chromem.Document
+	for i, version := range versions {
+		versionDocs, err := sm.ragDocuments(version)
+		if err != nil {
+			return nil, err
+		}
+		if len(versionDocs) > 0 {
+			if err := collection.AddDocuments(ctx, versionDocs, max(concurrency, 1)); err != nil {
+				return nil, fmt.Errorf("failed to embed documents: %w", err)
+			}
+		}
+		docs = append(docs, versionDocs...)
+		if progress != nil {
+			progress(i+1, len(versions), fmt.Sprintf("embedded %d documents of version %s", len(versionDocs), version))
+		}
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no documents to index for versions %v", versions)
+	}
+
+	index := &ragIndex{Info: RAGIndexInfo{Embedding: config, Versions: versions, Documents: len(docs), CreatedAt: time.Now().UTC()}}
+	for _, doc := range docs {
+		embedded, err := collection.GetByID(ctx, doc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get embedded document %s: %w", doc.ID, err)
+		}
+		index.Info.Dimensions = len(embedded.Embedding)
+		index.Documents = append(index.Documents, ragIndexDocument{ID: doc.ID, Content: doc.Content, Metadata: doc.Metadata, Embedding: embedded.Embedding})
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create index directory: %w", err)
+	}
+	file, err := os.Create(filepath.Join(dir, RAGIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index file: %w", err)
+	}
+	defer file.Close()
+	writer := gzip.NewWriter(file)
+	if err := json.NewEncoder(writer).Encode(index); err != nil {
+		return nil, fmt.Errorf("failed to write index: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write index: %w", err)
+	}
+	return &index.Info, file.Close()
+}
+
+// LoadRAGIndex loads a precomputed index from the directory, the documents are not embedded at runtime.
+// Queries are embedded with the embedding of the index, the API key of the openai provider is taken from apiKey.
+// It has to be called before the first query.
+func (sm *SchemaManager) LoadRAGIndex(dir string, apiKey string) (*RAGIndexInfo, error) {
+	file, err := os.Open(filepath.Join(dir, RAGIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open RAG index: %w", err)
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RAG index: %w", err)
+	}
+	var index ragIndex
+	if err := json.NewDecoder(reader).Decode(&index); err != nil {
+		return nil, fmt.Errorf("failed to parse RAG index: %w", err)
+	}
+	for _, doc := range index.Documents {
+		if len(doc.Embedding) != index.Info.Dimensions {
+			return nil, fmt.Errorf("document %s of the RAG index has %d dimensions, expected %d", doc.ID, len(doc.Embedding), index.Info.Dimensions)
+		}
+	}
+
+	config := index.Info.Embedding
+	config.APIKey = apiKey
+	embeddingFunc, err := sm.cachedEmbeddingFunc(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the query embedding of the RAG index: %w", err)
+	}
+	sm.SetEmbeddingFunc(embeddingFunc)
+	sm.ragIndex = &index
+	return &index.Info, nil
+}
@@ -0,0 +1,114 @@
+package collectorschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRAGIndex(t *testing.T) {
+	dir := t.TempDir()
+	var progress []string
+	info, err := NewSchemaManager().BuildRAGIndex(context.Background(), dir, []string{"0.139.0"}, EmbeddingConfig{Provider: EmbeddingProviderSimple}, 4, func(done, total int, message string) {
+		progress = append(progress, fmt.Sprintf("%d/%d", done, total))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1/1"}, progress)
+	assert.Equal(t, []string{"0.139.0"}, info.Versions)
+	assert.Equal(t, 384, info.Dimensions)
+	assert.Positive(t, info.Documents)
+
+	sm := NewSchemaManager()
+	loaded, err := sm.LoadRAGIndex(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, info.Documents, loaded.Documents)
+	results, err := sm.QueryDocumentation("kafka exporter", "0.139.0", 3)
+	require.NoError(t, err)
+
+	expected, err := NewSchemaManager().QueryDocumentation("kafka exporter", "0.139.0", 3)
+	require.NoError(t, err)
+	require.Len(t, results, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].ID, results[i].ID)
+		assert.InDelta(t, expected[i].Score, results[i].Score, 1e-6)
+	}
+
+	// Only the indexed versions are searchable
+	results, err = sm.QueryDocumentation("kafka exporter", "0.138.0", 3)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestNewEmbeddingFunc(t *testing.T) {
+	_, err := NewEmbeddingFunc(EmbeddingConfig{Provider: EmbeddingProviderOpenAI})
+	assert.ErrorContains(t, err, "requires an API key")
+	_, err = NewEmbeddingFunc(EmbeddingConfig{Provider: EmbeddingProviderOpenAI, BaseURL: "http://localhost:11434/v1", Model: "nomic-embed-text"})
+	assert.NoError(t, err)
+	_, err = NewEmbeddingFunc(EmbeddingConfig{Provider: "cohere"})
+	assert.ErrorContains(t, err, "unsupported embedding provider")
+}
+
+func TestLoadRAGIndex_Missing(t *testing.T) {
+	_, err := NewSchemaManager().LoadRAGIndex(t.TempDir(), "")
+	assert.ErrorContains(t, err, "failed to open RAG index")
+}
+
+// mapContentCache is a content cache in a map
+type mapContentCache map[string][]byte
+
+func (c mapContentCache) Get(namespace, key string) ([]byte, bool) {
+	content, found := c[namespace+"/"+key]
+	return content, found
+}
+
+func (c mapContentCache) Put(namespace, key string, content []byte, _ time.Duration) (string, error) {
+	c[namespace+"/"+key] = content
+	return "", nil
+}
+
+func TestCachedEmbeddingFunc(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{{"embedding": []float32{0.6, 0.8}}}})
+	}))
+	defer server.Close()
+
+	sm := newSchemaManager(fstest.MapFS{})
+	cache := mapContentCache{}
+	sm.SetContentCache(cache)
+	config := EmbeddingConfig{Provider: EmbeddingProviderOpenAI, BaseURL: server.URL, Model: "nomic-embed-text"}
+	embeddingFunc, err := sm.cachedEmbeddingFunc(config)
+	require.NoError(t, err)
+
+	for range 2 {
+		embedding, err := embeddingFunc(context.Background(), "kafka exporter")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{0.6, 0.8}, embedding)
+	}
+	assert.Equal(t, 1, requests)
+	assert.Len(t, cache, 1)
+
+	// Another model does not share the cached embeddings
+	config.Model = "mxbai-embed-large"
+	embeddingFunc, err = sm.cachedEmbeddingFunc(config)
+	require.NoError(t, err)
+	_, err = embeddingFunc(context.Background(), "kafka exporter")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	// The simple embedding is computed locally and not cached
+	embeddingFunc, err = sm.cachedEmbeddingFunc(EmbeddingConfig{Provider: EmbeddingProviderSimple})
+	require.NoError(t, err)
+	_, err = embeddingFunc(context.Background(), "kafka exporter")
+	require.NoError(t, err)
+	assert.Len(t, cache, 2)
+}
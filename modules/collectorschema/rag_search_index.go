@@ -0,0 +1,249 @@
+package collectorschema
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sort"
+
+	"github.com/philippgille/chromem-go"
+)
+
+// ragStatus is the state of the documentation search index of a schema manager. The first search, PrewarmRAG or
+// BuildRAGDatabase builds it: empty -> building -> ready, a failed build returns to empty and the next search builds it
+// again. ReloadRAG builds the next index while the searches read the ready one: ready -> reloading -> ready.
+type ragStatus int
+
+const (
+	ragEmpty ragStatus = iota
+	ragBuilding
+	ragReady
+	ragReloading
+)
+
+// ragSearchIndex is a built documentation search index, it is not modified once it is published to the searches, a
+// reload publishes a new one
+type ragSearchIndex struct {
+	collection *chromem.Collection
+	keywords   map[string]keywordDocument
+	// versionDocuments are the IDs of the documents of each version
+	versionDocuments map[string][]string
+}
+
+func newRAGSearchIndex(embeddingFunc chromem.EmbeddingFunc) (*ragSearchIndex, error) {
+	metadata := map[string]string{
+		"description": "OpenTelemetry Collector Component Documentation",
+	}
+	collection, err := chromem.NewDB().CreateCollection("otel-docs", metadata, embeddingFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RAG collection: %w", err)
+	}
+	return &ragSearchIndex{
+		collection:       collection,
+		keywords:         make(map[string]keywordDocument),
+		versionDocuments: make(map[string][]string),
+	}, nil
+}
+
+// add adds the documents to the collection and the keyword index, documents without an embedding are embedded
+// concurrently
+func (index *ragSearchIndex) add(docs []chromem.Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	if err := index.collection.AddDocuments(context.Background(), docs, runtime.NumCPU()); err != nil {
+		return fmt.Errorf("failed to add documents to RAG database: %w", err)
+	}
+	for _, doc := range docs {
+		index.indexKeywords(doc)
+		index.versionDocuments[doc.Metadata["version"]] = append(index.versionDocuments[doc.Metadata["version"]], doc.ID)
+	}
+	return nil
+}
+
+// documents returns the documents of a version with their embeddings
+func (index *ragSearchIndex) documents(version string) ([]chromem.Document, error) {
+	docs := make([]chromem.Document, 0, len(index.versionDocuments[version]))
+	for _, id := range index.versionDocuments[version] {
+		doc, err := index.collection.GetByID(context.Background(), id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy document %s: %w", id, err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// readyRAGSearchIndex returns the published search index, the first caller builds it and reports each indexed version
+// to the progress function, the other callers wait for the build. A search during a reload reads the index being
+// replaced.
+func (sm *SchemaManager) readyRAGSearchIndex(progress ProgressFunc) (index *ragSearchIndex, err error) {
+	sm.ragMutex.Lock()
+	switch sm.ragStatus {
+	case ragReady, ragReloading:
+		published := sm.ragSearch
+		sm.ragMutex.Unlock()
+		return published, nil
+	case ragBuilding:
+		done := sm.ragDone
+		sm.ragMutex.Unlock()
+		<-done
+		sm.ragMutex.Lock()
+		defer sm.ragMutex.Unlock()
+		if sm.ragSearch == nil {
+			return nil, sm.ragErr
+		}
+		return sm.ragSearch, nil
+	}
+	sm.ragStatus = ragBuilding
+	sm.ragDone = make(chan struct{})
+	sm.ragMutex.Unlock()
+
+	// The state leaves building even if the build panics, the waiting searches get its error
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("failed to build RAG database: %v", recovered)
+		}
+		sm.ragMutex.Lock()
+		defer sm.ragMutex.Unlock()
+		if err != nil {
+			sm.ragStatus, sm.ragErr = ragEmpty, err
+		} else {
+			sm.ragStatus, sm.ragSearch, sm.ragErr = ragReady, index, nil
+		}
+		close(sm.ragDone)
+	}()
+	index, err = sm.buildRAGSearchIndex(progress)
+	return index, err
+}
+
+// buildRAGSearchIndex builds the search index from the precomputed index if one is loaded, otherwise it indexes the
+// documents of all versions and reports each indexed version to the progress function if it is set
+func (sm *SchemaManager) buildRAGSearchIndex(progress ProgressFunc) (*ragSearchIndex, error) {
+	index, err := newRAGSearchIndex(sm.embeddingFunc)
+	if err != nil {
+		return nil, err
+	}
+	// The precomputed index carries the embeddings, the documents are not embedded again
+	if sm.ragIndex != nil {
+		return index, index.add(sm.ragIndex.chromemDocuments())
+	}
+
+	versions, err := sm.GetAllVersions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions for RAG indexing: %w", err)
+	}
+	// Index all markdown files, core documentation pages, changelog entries and schema field descriptions across all versions
+	for i, version := range versions {
+		docs, err := sm.ragDocuments(version)
+		if err != nil {
+			return nil, err
+		}
+		if err := index.add(docs); err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(i+1, len(versions), fmt.Sprintf("indexed %d documents of version %s", len(docs), version))
+		}
+	}
+	return index, nil
+}
+
+// ReloadRAG indexes the documents of the versions again, all served versions if versions is empty, and reports each
+// indexed version to the progress function if it is set. The documents of the other versions are kept with their
+// embeddings and the documents of versions that are no longer served are removed. The searches read the current index
+// until the reloaded one replaces it, a failed reload keeps the current index. An index that is not built yet is
+// built, concurrent reloads run one after the other.
+func (sm *SchemaManager) ReloadRAG(versions []string, progress ProgressFunc) error {
+	served, err := sm.GetAllVersions()
+	if err != nil {
+		return fmt.Errorf("failed to get versions for RAG indexing: %w", err)
+	}
+	for _, version := range versions {
+		if !slices.Contains(served, version) {
+			return fmt.Errorf("version %s is not supported", version)
+		}
+	}
+	if len(versions) == 0 {
+		versions = served
+	}
+
+	for {
+		sm.ragMutex.Lock()
+		switch sm.ragStatus {
+		case ragEmpty:
+			sm.ragMutex.Unlock()
+			_, err := sm.readyRAGSearchIndex(progress)
+			return err
+		case ragBuilding, ragReloading:
+			done := sm.ragDone
+			sm.ragMutex.Unlock()
+			<-done
+			continue
+		}
+		current := sm.ragSearch
+		sm.ragStatus = ragReloading
+		sm.ragDone = make(chan struct{})
+		sm.ragMutex.Unlock()
+		return sm.reload(current, served, versions, progress)
+	}
+}
+
+// reload publishes the reloaded search index, the state leaves reloading even if the reload panics and a failed reload
+// keeps the current index published
+func (sm *SchemaManager) reload(current *ragSearchIndex, served, versions []string, progress ProgressFunc) (err error) {
+	var index *ragSearchIndex
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("failed to reload RAG database: %v", recovered)
+		}
+		sm.ragMutex.Lock()
+		defer sm.ragMutex.Unlock()
+		if err == nil {
+			sm.ragSearch = index
+		}
+		sm.ragStatus = ragReady
+		close(sm.ragDone)
+	}()
+	index, err = sm.reloadRAGSearchIndex(current, served, versions, progress)
+	return err
+}
+
+// reloadRAGSearchIndex returns a new search index with the documents of the current index of the served versions that
+// are not reindexed and the documents of the reindexed versions
+func (sm *SchemaManager) reloadRAGSearchIndex(current *ragSearchIndex, served, versions []string, progress ProgressFunc) (*ragSearchIndex, error) {
+	index, err := newRAGSearchIndex(sm.embeddingFunc)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]string, 0, len(current.versionDocuments))
+	for version := range current.versionDocuments {
+		if slices.Contains(served, version) && !slices.Contains(versions, version) {
+			kept = append(kept, version)
+		}
+	}
+	sort.Strings(kept)
+	for _, version := range kept {
+		docs, err := current.documents(version)
+		if err != nil {
+			return nil, err
+		}
+		if err := index.add(docs); err != nil {
+			return nil, err
+		}
+	}
+	for i, version := range versions {
+		docs, err := sm.ragDocuments(version)
+		if err != nil {
+			return nil, err
+		}
+		if err := index.add(docs); err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(i+1, len(versions), fmt.Sprintf("indexed %d documents of version %s", len(docs), version))
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,133 @@
+package collectorschema
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadRAG(t *testing.T) {
+	sm := NewSchemaManager()
+	versions, err := sm.GetAllVersions()
+	require.NoError(t, err)
+	latestVersion, err := sm.GetLatestVersion()
+	require.NoError(t, err)
+
+	// A reload of an index that is not built yet builds it
+	var built []int
+	require.NoError(t, sm.ReloadRAG([]string{latestVersion}, func(done, total int, message string) {
+		assert.Equal(t, len(versions), total)
+		built = append(built, done)
+	}))
+	assert.Len(t, built, len(versions))
+	before, err := sm.QueryDocumentation("kafka exporter", latestVersion, 3)
+	require.NoError(t, err)
+	documents := sm.ragSearch.collection.Count()
+
+	// Only the reloaded version is indexed again, the other versions are kept
+	var reloaded []string
+	require.NoError(t, sm.ReloadRAG([]string{latestVersion}, func(done, total int, message string) {
+		assert.Equal(t, 1, total)
+		reloaded = append(reloaded, message)
+	}))
+	assert.Len(t, reloaded, 1)
+	assert.Contains(t, reloaded[0], "documents of version "+latestVersion)
+	assert.Equal(t, documents, sm.ragSearch.collection.Count())
+	assert.ElementsMatch(t, versions, sortedKeys(sm.ragSearch.versionDocuments))
+	after, err := sm.QueryDocumentation("kafka exporter", latestVersion, 3)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	assert.ErrorContains(t, sm.ReloadRAG([]string{"0.1.0"}, nil), "version 0.1.0 is not supported")
+	assert.Equal(t, ragReady, sm.ragStatus)
+}
+
+func TestRAGSearchIndex_FailedBuild(t *testing.T) {
+	sm := NewSchemaManager()
+	embed := createSimpleEmbeddingFunc()
+	var fail atomic.Bool
+	fail.Store(true)
+	sm.SetEmbeddingFunc(func(ctx context.Context, text string) ([]float32, error) {
+		if fail.Load() {
+			return nil, errors.New("embedding unavailable")
+		}
+		return embed(ctx, text)
+	})
+
+	// The searches waiting for the failed build get its error, the next search builds the index again
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sm.QueryDocumentation("kafka exporter", "0.139.0", 3)
+			assert.ErrorContains(t, err, "embedding unavailable")
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, ragEmpty, sm.ragStatus)
+
+	fail.Store(false)
+	results, err := sm.QueryDocumentation("kafka exporter", "0.139.0", 3)
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, ragReady, sm.ragStatus)
+}
+
+// TestRAGSearchIndex_SearchesDuringReload searches while the index is built and reloaded, run it with -race to detect
+// unsynchronized access to the index
+func TestRAGSearchIndex_SearchesDuringReload(t *testing.T) {
+	sm := NewSchemaManager()
+	latestVersion, err := sm.GetLatestVersion()
+	require.NoError(t, err)
+
+	stop := make(chan struct{})
+	var searches atomic.Int64
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				var results []DocumentSearchResult
+				var err error
+				if i%2 == 0 {
+					results, err = sm.QueryDocumentation("kafka exporter", latestVersion, 3)
+				} else {
+					results, err = sm.QueryDocumentationWithFilters("batch size", 3, "processor", "batch", latestVersion)
+				}
+				if !assert.NoError(t, err) || !assert.NotEmpty(t, results) {
+					return
+				}
+				searches.Add(1)
+			}
+		}()
+	}
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				assert.NoError(t, sm.ReloadRAG([]string{latestVersion}, nil))
+			} else {
+				assert.NoError(t, sm.ReloadRAG(nil, nil))
+			}
+		}()
+	}
+	assert.NoError(t, sm.BuildRAGDatabase(nil))
+	assert.NoError(t, sm.ReloadRAG(nil, nil))
+	close(stop)
+	wg.Wait()
+	assert.Positive(t, searches.Load())
+	assert.Equal(t, ragReady, sm.ragStatus)
+}
@@ -0,0 +1,87 @@
+package collectorschema
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ReadmeAssetsDir is the directory of the README images in the schemas directory of a version, the images of a
+// component are in assets/<type>_<name> at the path referenced by its README e.g. images/arch.png
+const ReadmeAssetsDir = "assets"
+
+// readmeAssetTypes are the MIME types of the README images by extension
+var readmeAssetTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".svg":  "image/svg+xml",
+	".webp": "image/webp",
+}
+
+// ReadmeAsset is an image referenced by the README of a component e.g. an architecture diagram
+type ReadmeAsset struct {
+	// Path is the path of the image as referenced by the README e.g. images/arch.png
+	Path     string `json:"path"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+}
+
+// GetReadmeAssets returns the images referenced by the README of a component in a version sorted by path, empty if
+// the README references none
+func (sm *SchemaManager) GetReadmeAssets(componentType ComponentType, componentName string, version string) ([]ReadmeAsset, error) {
+	if !slices.Contains(sm.GetComponentVersions(componentType, componentName), version) {
+		return nil, sm.componentNotFound(componentType, componentName, version)
+	}
+	dir := readmeAssetsPath(componentType, componentName, version)
+	var assets []ReadmeAsset
+	err := fs.WalkDir(sm.schemas, dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		mimeType, ok := readmeAssetTypes[strings.ToLower(path.Ext(filePath))]
+		if entry.IsDir() || !ok {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		assets = append(assets, ReadmeAsset{Path: strings.TrimPrefix(filePath, dir+"/"), MimeType: mimeType, Size: info.Size()})
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list README images of %s %s v%s: %w", componentType, componentName, version, err)
+	}
+	return assets, nil
+}
+
+// GetReadmeAsset returns an image referenced by the README of a component in a version, the path is relative to the
+// README e.g. ./images/arch.png
+func (sm *SchemaManager) GetReadmeAsset(componentType ComponentType, componentName string, version string, assetPath string) (*ReadmeAsset, []byte, error) {
+	cleaned := path.Clean(strings.TrimPrefix(assetPath, "/"))
+	if !fs.ValidPath(cleaned) || cleaned == "." {
+		return nil, nil, fmt.Errorf("invalid README image path %q", assetPath)
+	}
+	mimeType, ok := readmeAssetTypes[strings.ToLower(path.Ext(cleaned))]
+	if !ok {
+		return nil, nil, fmt.Errorf("README image %q is not an image, supported extensions are %s", assetPath, strings.Join(sortedKeys(readmeAssetTypes), ", "))
+	}
+	if !slices.Contains(sm.GetComponentVersions(componentType, componentName), version) {
+		return nil, nil, sm.componentNotFound(componentType, componentName, version)
+	}
+	data, err := fs.ReadFile(sm.schemas, path.Join(readmeAssetsPath(componentType, componentName, version), cleaned))
+	if err != nil {
+		return nil, nil, fmt.Errorf("README image %s of %s %s not found in version %s", cleaned, componentType, componentName, version)
+	}
+	return &ReadmeAsset{Path: cleaned, MimeType: mimeType, Size: int64(len(data))}, data, nil
+}
+
+// readmeAssetsPath returns the directory of the README images of a component in the schemas file system
+func readmeAssetsPath(componentType ComponentType, componentName string, version string) string {
+	return path.Join("schemas", version, ReadmeAssetsDir, fmt.Sprintf("%s_%s", componentType, componentName))
+}
@@ -0,0 +1,69 @@
+package collectorschema
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReadmeAssets(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/receiver_foo.yaml":                   {Data: []byte("type: object\n")},
+		"schemas/1.0.0/receiver_foo.md":                     {Data: []byte("![Architecture](images/arch.png)\n")},
+		"schemas/1.0.0/receiver_bar.yaml":                   {Data: []byte("type: object\n")},
+		"schemas/1.0.0/assets/receiver_foo/images/arch.png": {Data: []byte("png")},
+		"schemas/1.0.0/assets/receiver_foo/flow.SVG":        {Data: []byte("<svg/>")},
+		"schemas/1.0.0/assets/receiver_foo/notes.txt":       {Data: []byte("notes")},
+	})
+
+	assets, err := sm.GetReadmeAssets(ComponentTypeReceiver, "foo", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []ReadmeAsset{
+		{Path: "flow.SVG", MimeType: "image/svg+xml", Size: 6},
+		{Path: "images/arch.png", MimeType: "image/png", Size: 3},
+	}, assets)
+
+	// The asset directory is not a component
+	components, err := sm.ListAvailableComponents("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar", "foo"}, components[ComponentTypeReceiver])
+
+	assets, err = sm.GetReadmeAssets(ComponentTypeReceiver, "bar", "1.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, assets)
+
+	_, err = sm.GetReadmeAssets(ComponentTypeReceiver, "missing", "1.0.0")
+	var notFound *ComponentNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+}
+
+func TestGetReadmeAsset(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/receiver_foo.yaml":                   {Data: []byte("type: object\n")},
+		"schemas/1.0.0/assets/receiver_foo/images/arch.png": {Data: []byte("png")},
+		"schemas/1.0.0/assets/receiver_foo/notes.txt":       {Data: []byte("notes")},
+		"schemas/1.0.0/assets/receiver_bar/secret.png":      {Data: []byte("bar")},
+	})
+
+	asset, data, err := sm.GetReadmeAsset(ComponentTypeReceiver, "foo", "1.0.0", "./images/arch.png")
+	require.NoError(t, err)
+	assert.Equal(t, &ReadmeAsset{Path: "images/arch.png", MimeType: "image/png", Size: 3}, asset)
+	assert.Equal(t, []byte("png"), data)
+
+	_, _, err = sm.GetReadmeAsset(ComponentTypeReceiver, "foo", "1.0.0", "images/missing.png")
+	assert.EqualError(t, err, "README image images/missing.png of receiver foo not found in version 1.0.0")
+
+	_, _, err = sm.GetReadmeAsset(ComponentTypeReceiver, "foo", "1.0.0", "notes.txt")
+	assert.ErrorContains(t, err, "is not an image")
+
+	// Paths outside of the asset directory of the component are rejected
+	_, _, err = sm.GetReadmeAsset(ComponentTypeReceiver, "foo", "1.0.0", "../receiver_bar/secret.png")
+	assert.EqualError(t, err, `invalid README image path "../receiver_bar/secret.png"`)
+
+	_, _, err = sm.GetReadmeAsset(ComponentTypeReceiver, "foo", "2.0.0", "images/arch.png")
+	var notFound *ComponentNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+}
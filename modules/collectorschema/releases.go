@@ -0,0 +1,82 @@
+package collectorschema
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed releases.yaml
+var embeddedReleases []byte
+
+// ReleasePolicy describes the collector release cadence and support policy
+type ReleasePolicy struct {
+	Support string `yaml:"support" json:"support"`
+	// CurrentDays is the number of days after the latest release a previous release is still reasonable to run
+	CurrentDays int `yaml:"current_days" json:"currentDays"`
+	// OutdatedDays is the number of days after the latest release a previous release is considered far behind
+	OutdatedDays int       `yaml:"outdated_days" json:"outdatedDays"`
+	Releases     []Release `yaml:"releases" json:"releases,omitempty"`
+}
+
+// Release is a collector release and its release date
+type Release struct {
+	Version string `yaml:"version" json:"version"`
+	Date    string `yaml:"date" json:"date"`
+}
+
+// GetReleasePolicy returns the embedded collector release policy
+func (sm *SchemaManager) GetReleasePolicy() (*ReleasePolicy, error) {
+	var policy ReleasePolicy
+	if err := yaml.Unmarshal(embeddedReleases, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse release policy: %w", err)
+	}
+	if policy.Support == "" {
+		return nil, fmt.Errorf("release policy support must be set")
+	}
+	if policy.CurrentDays <= 0 || policy.OutdatedDays < policy.CurrentDays {
+		return nil, fmt.Errorf("release policy current_days must be positive and not above outdated_days")
+	}
+	return &policy, nil
+}
+
+// ReleaseDate returns the release date of the version if it is known
+func (p *ReleasePolicy) ReleaseDate(version string) (time.Time, bool) {
+	for _, release := range p.Releases {
+		if CompareVersions(release.Version, version) == 0 {
+			date, err := time.Parse(time.DateOnly, release.Date)
+			return date, err == nil
+		}
+	}
+	return time.Time{}, false
+}
+
+// OldestRelease returns the oldest release with a known release date
+func (p *ReleasePolicy) OldestRelease() (Release, bool) {
+	var oldest Release
+	for _, release := range p.Releases {
+		if oldest.Version == "" || CompareVersions(release.Version, oldest.Version) < 0 {
+			oldest = release
+		}
+	}
+	return oldest, oldest.Version != ""
+}
+
+// GetBreakingChanges returns the breaking changes listed in the changelog of the version
+func (sm *SchemaManager) GetBreakingChanges(version string) ([]string, error) {
+	changelog, err := sm.GetChangelog(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	for _, entry := range parseChangelog(changelog) {
+		if strings.Contains(entry.Section, "breaking changes") {
+			changes = append(changes, entry.Text)
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,49 @@
+package collectorschema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaManager_GetReleasePolicy(t *testing.T) {
+	sm := NewSchemaManager()
+
+	policy, err := sm.GetReleasePolicy()
+	require.NoError(t, err)
+	assert.Contains(t, policy.Support, "only the latest release receives fixes")
+	assert.Positive(t, policy.CurrentDays)
+	assert.GreaterOrEqual(t, policy.OutdatedDays, policy.CurrentDays)
+
+	// Every served version has a release date
+	versions, err := sm.GetAllVersions()
+	require.NoError(t, err)
+	for _, version := range versions {
+		_, ok := policy.ReleaseDate(version)
+		assert.True(t, ok, "release date of %s", version)
+	}
+	date, ok := policy.ReleaseDate("v0.138.0")
+	require.True(t, ok)
+	assert.Equal(t, "2025-10-20", date.Format(time.DateOnly))
+	_, ok = policy.ReleaseDate("0.100.0")
+	assert.False(t, ok)
+
+	oldest, ok := policy.OldestRelease()
+	require.True(t, ok)
+	assert.Equal(t, "0.135.0", oldest.Version)
+}
+
+func TestSchemaManager_GetBreakingChanges(t *testing.T) {
+	sm := NewSchemaManager()
+
+	changes, err := sm.GetBreakingChanges("0.138.0")
+	require.NoError(t, err)
+	for _, change := range changes {
+		assert.NotEmpty(t, change)
+	}
+
+	_, err = sm.GetBreakingChanges("0.0.1")
+	require.Error(t, err)
+}
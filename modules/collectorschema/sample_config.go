@@ -0,0 +1,234 @@
+package collectorschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Sample fill levels selecting the fields of a generated sample configuration
+const (
+	// SampleFillMinimal are the required fields and the endpoints
+	SampleFillMinimal = "minimal"
+	// SampleFillTypical are the fields of the component and of its nested settings, without deeper nested settings
+	SampleFillTypical = "typical"
+	// SampleFillFull are all fields
+	SampleFillFull = "full"
+)
+
+// SampleFills are the supported sample fill levels
+var SampleFills = []string{SampleFillMinimal, SampleFillTypical, SampleFillFull}
+
+// typicalSampleDepth is the depth of the fields of a typical sample, 3 are e.g. the fields of protocols::grpc of the
+// otlp receiver or of the tls settings of an exporter queue
+const typicalSampleDepth = 3
+
+// durationPattern is the pattern of the duration fields of the generated schemas
+const durationPattern = `^[0-9]+(ns|us|µs|ms|s|m|h)$`
+
+// fullSampleFields are the fields referencing other components, e.g. the storage extension of a sending queue, which are
+// only part of a full sample as the referenced components have to be configured as well
+var fullSampleFields = []string{"storage", "auth", "authenticator"}
+
+// sampleStrings are the sample values of string fields without a default, enum or example by field name
+var sampleStrings = map[string]string{
+	"endpoint":       "localhost:4317",
+	"compression":    "gzip",
+	"listen_address": "localhost:8888",
+	"path":           "/var/lib/otelcol",
+	"directory":      "/var/lib/otelcol",
+	"storage":        "file_storage",
+	"authenticator":  "basicauth",
+	"error_mode":     "ignore",
+	"transport":      "tcp",
+	"context":        "span",
+	"action":         "insert",
+	"key":            "deployment.environment",
+	"value":          "production",
+}
+
+// GenerateSampleConfig returns a sample configuration of a component generated from its schema, the values are the
+// defaults, the first enum values or examples of the fields and otherwise values matching the field type, pattern and
+// name. The fill is one of SampleFills. Deprecated fields are skipped.
+func (sm *SchemaManager) GenerateSampleConfig(componentType ComponentType, componentName string, version string, fill string) (map[string]interface{}, error) {
+	if !slices.Contains(SampleFills, fill) {
+		return nil, fmt.Errorf("unsupported fill %q, it can be %s", fill, strings.Join(SampleFills, ", "))
+	}
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := sampleObject(componentName, schema.Schema, fill, 1)
+
+	// The sample has to be accepted by the schema it was generated from
+	sampleJSON, err := json.Marshal(sample)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the sample config: %w", err)
+	}
+	result, err := sm.ValidateComponentJSON(componentType, componentName, version, sampleJSON)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Valid() {
+		descriptions := make([]string, 0, len(result.Errors()))
+		for _, resultErr := range result.Errors() {
+			descriptions = append(descriptions, resultErr.String())
+		}
+		return nil, fmt.Errorf("the sample config of %s %s is not valid for version %s: %s", componentType, componentName, version, strings.Join(descriptions, "; "))
+	}
+	return sample, nil
+}
+
+// sampleObject returns the sample of the properties of an object schema selected by the fill, the parent is the name
+// of the object e.g. http for the endpoint of the otlp receiver
+func sampleObject(parent string, schema map[string]interface{}, fill string, depth int) map[string]interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := stringValues(schema["required"])
+	sample := map[string]interface{}{}
+	for _, name := range sortedKeys(properties) {
+		fieldSchema, ok := properties[name].(map[string]interface{})
+		if !ok || isDeprecated(fieldSchema) {
+			continue
+		}
+		if fill != SampleFillFull && slices.Contains(fullSampleFields, name) {
+			continue
+		}
+		isRequired := slices.Contains(required, name)
+		// Nested settings of a minimal sample are kept only if they have required fields or endpoints
+		isObject := schemaType(fieldSchema) == "object" && fieldSchema["properties"] != nil
+		switch fill {
+		case SampleFillMinimal:
+			if !isRequired && name != "endpoint" && !isObject {
+				continue
+			}
+		case SampleFillTypical:
+			if depth > typicalSampleDepth && !isRequired && name != "endpoint" {
+				continue
+			}
+		}
+		value := sampleValue(name, fieldSchema, fill, depth+1)
+		if name == "endpoint" && parent == "http" && value == sampleStrings["endpoint"] {
+			value = "localhost:4318"
+		}
+		if value != nil {
+			sample[name] = value
+		}
+	}
+	return sample
+}
+
+// sampleValue returns the sample value of a field, nil if the field is not part of the sample
+func sampleValue(name string, fieldSchema map[string]interface{}, fill string, depth int) interface{} {
+	if value, ok := fieldSchema["default"]; ok && value != nil {
+		return value
+	}
+	if value, ok := fieldSchema["const"]; ok {
+		return value
+	}
+	if values, ok := fieldSchema["enum"].([]interface{}); ok && len(values) > 0 {
+		return values[0]
+	}
+	if values, ok := fieldSchema["examples"].([]interface{}); ok && len(values) > 0 {
+		return values[0]
+	}
+
+	switch schemaType(fieldSchema) {
+	case "object":
+		if _, ok := fieldSchema["properties"].(map[string]interface{}); ok {
+			sample := sampleObject(name, fieldSchema, fill, depth)
+			if len(sample) == 0 && fill != SampleFillFull {
+				return nil
+			}
+			return sample
+		}
+		if additional, ok := fieldSchema["additionalProperties"].(map[string]interface{}); ok && fill != SampleFillMinimal {
+			return map[string]interface{}{"key": sampleValue("value", additional, fill, depth+1)}
+		}
+		return map[string]interface{}{}
+	case "array":
+		items, ok := fieldSchema["items"].(map[string]interface{})
+		if !ok {
+			return []interface{}{}
+		}
+		item := sampleValue(strings.TrimSuffix(name, "s"), items, fill, depth)
+		if item == nil {
+			return []interface{}{}
+		}
+		return []interface{}{item}
+	case "string":
+		return sampleString(name, fieldSchema)
+	case "integer":
+		if minimum, ok := fieldSchema["minimum"].(float64); ok {
+			return int(minimum)
+		}
+		return 1
+	case "number":
+		if minimum, ok := fieldSchema["minimum"].(float64); ok {
+			return minimum
+		}
+		return 1.0
+	case "boolean":
+		return false
+	}
+	return nil
+}
+
+// sampleString returns the sample value of a string field matching its pattern or format and named after the field
+func sampleString(name string, fieldSchema map[string]interface{}) string {
+	pattern, _ := fieldSchema["pattern"].(string)
+	format, _ := fieldSchema["format"].(string)
+	switch {
+	case pattern == durationPattern || format == "duration":
+		return "5s"
+	case format == "uri":
+		return "http://localhost:4318"
+	}
+	if value, ok := sampleStrings[name]; ok {
+		return value
+	}
+	switch {
+	case strings.HasSuffix(name, "_file"):
+		return "/etc/otelcol/" + strings.TrimSuffix(name, "_file")
+	case strings.HasSuffix(name, "_interval"), strings.HasSuffix(name, "_timeout"), strings.HasSuffix(name, "_time"), name == "timeout":
+		return "5s"
+	case strings.HasSuffix(name, "endpoint"):
+		return sampleStrings["endpoint"]
+	}
+	return "example"
+}
+
+// schemaType returns the type of a schema, the first type other than null of a list of types
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, value := range t {
+			if s, ok := value.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+func isDeprecated(schema map[string]interface{}) bool {
+	deprecated, _ := schema["deprecated"].(bool)
+	return deprecated
+}
+
+func stringValues(value interface{}) []string {
+	values, _ := value.([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
@@ -0,0 +1,63 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSampleConfig(t *testing.T) {
+	sm := NewSchemaManager()
+
+	minimal, err := sm.GenerateSampleConfig(ComponentTypeExporter, "otlp", "0.139.0", SampleFillMinimal)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"endpoint": "localhost:4317"}, minimal)
+
+	typical, err := sm.GenerateSampleConfig(ComponentTypeExporter, "otlp", "0.139.0", SampleFillTypical)
+	require.NoError(t, err)
+	assert.Contains(t, typical, "endpoint")
+	assert.Contains(t, typical, "tls")
+	assert.Greater(t, len(typical), len(minimal))
+	assert.NotContains(t, typical["sending_queue"], "storage")
+
+	full, err := sm.GenerateSampleConfig(ComponentTypeExporter, "otlp", "0.139.0", SampleFillFull)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, len(full), len(typical))
+
+	receiver, err := sm.GenerateSampleConfig(ComponentTypeReceiver, "otlp", "0.139.0", SampleFillMinimal)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{"endpoint": "localhost:4317"},
+			"http": map[string]interface{}{"endpoint": "localhost:4318"},
+		},
+	}, receiver)
+
+	_, err = sm.GenerateSampleConfig(ComponentTypeExporter, "otlp", "0.139.0", "everything")
+	assert.ErrorContains(t, err, "unsupported fill")
+
+	_, err = sm.GenerateSampleConfig(ComponentTypeExporter, "unknown", "0.139.0", SampleFillMinimal)
+	assert.Error(t, err)
+}
+
+func TestGenerateSampleConfig_AllComponents(t *testing.T) {
+	sm := NewSchemaManager()
+	manifest, err := sm.GetComponentManifest("0.139.0")
+	require.NoError(t, err)
+
+	generated := 0
+	for componentType, names := range manifest.componentsByType() {
+		for _, name := range names {
+			if _, err := sm.GetComponentSchema(componentType, name, "0.139.0"); err != nil {
+				continue
+			}
+			for _, fill := range SampleFills {
+				_, err := sm.GenerateSampleConfig(componentType, name, "0.139.0", fill)
+				assert.NoError(t, err, "%s %s %s", componentType, name, fill)
+			}
+			generated++
+		}
+	}
+	assert.NotZero(t, generated)
+}
@@ -0,0 +1,115 @@
+package collectorschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SchemaBundleFormat is the format of the schema bundle layout, it is incremented only by incompatible changes e.g. a
+// renamed or removed key, new keys are added within a format
+const SchemaBundleFormat = 1
+
+// SchemaBundle are all schemas, READMEs and defaults of a version with the full configuration schema in a single
+// document for offline tooling
+type SchemaBundle struct {
+	Manifest SchemaBundleManifest `json:"manifest"`
+	// ConfigSchema is the JSON Schema of a full collector configuration
+	ConfigSchema map[string]interface{} `json:"configSchema"`
+	// Components are sorted by type in the order of the pipeline stages and by name
+	Components []BundledComponent `json:"components"`
+}
+
+// SchemaBundleManifest describes the content of a schema bundle
+type SchemaBundleManifest struct {
+	Format  int    `json:"format"`
+	Version string `json:"version"`
+	// Components are the number of components by type
+	Components map[ComponentType]int `json:"components"`
+	// Readmes are the number of components with a README
+	Readmes int `json:"readmes"`
+	// Locales are the locales of the translated field descriptions of the components
+	Locales []string `json:"locales,omitempty"`
+}
+
+// BundledComponent is a component of a schema bundle with its manifest entry, schema, README and field defaults
+type BundledComponent struct {
+	ManifestComponent
+	Schema map[string]interface{} `json:"schema,omitempty"`
+	Readme string                 `json:"readme,omitempty"`
+	// Defaults are the default values of the fields by their dotted path e.g. timeout
+	Defaults map[string]interface{} `json:"defaults,omitempty"`
+	// Descriptions are the translated field descriptions by locale and dotted path, the schema has the English
+	// descriptions
+	Descriptions map[string]map[string]string `json:"descriptions,omitempty"`
+}
+
+// GetSchemaBundle returns the schema bundle of a version
+func (sm *SchemaManager) GetSchemaBundle(version string) (*SchemaBundle, error) {
+	manifest, err := sm.GetComponentManifest(version)
+	if err != nil {
+		return nil, err
+	}
+	configSchema, err := sm.GetConfigSchema(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config schema for version %s: %w", version, err)
+	}
+
+	bundle := &SchemaBundle{
+		Manifest: SchemaBundleManifest{
+			Format:     SchemaBundleFormat,
+			Version:    version,
+			Components: map[ComponentType]int{},
+		},
+		ConfigSchema: configSchema,
+		Components:   make([]BundledComponent, 0, len(manifest.Components)),
+	}
+	for _, component := range manifest.Components {
+		bundled := BundledComponent{ManifestComponent: component}
+		if component.SchemaFile() != "" {
+			schema, err := sm.GetComponentSchema(component.Type, component.Name, version)
+			if err != nil {
+				return nil, err
+			}
+			bundled.Schema = schema.Schema
+			defaults, err := sm.GetFieldDefaults(component.Type, component.Name, version)
+			if err != nil {
+				return nil, err
+			}
+			if len(defaults) > 0 {
+				bundled.Defaults = defaults
+			}
+			for _, locale := range component.DescriptionLocales() {
+				translations, _, err := sm.GetFieldTranslations(component.Type, component.Name, version, locale)
+				if err != nil {
+					return nil, err
+				}
+				if bundled.Descriptions == nil {
+					bundled.Descriptions = make(map[string]map[string]string)
+				}
+				bundled.Descriptions[locale] = translations
+				if !slices.Contains(bundle.Manifest.Locales, locale) {
+					bundle.Manifest.Locales = append(bundle.Manifest.Locales, locale)
+				}
+			}
+		}
+		if component.ReadmeFile() != "" {
+			readme, err := sm.GetComponentReadme(component.Type, component.Name, version)
+			if err != nil {
+				return nil, err
+			}
+			bundled.Readme = readme
+			bundle.Manifest.Readmes++
+		}
+		bundle.Manifest.Components[component.Type]++
+		bundle.Components = append(bundle.Components, bundled)
+	}
+	slices.SortStableFunc(bundle.Components, func(a, b BundledComponent) int {
+		if order := slices.Index(componentTypes, a.Type) - slices.Index(componentTypes, b.Type); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.Sort(bundle.Manifest.Locales)
+	return bundle, nil
+}
@@ -0,0 +1,39 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaManager_GetSchemaBundle(t *testing.T) {
+	sm := newSchemaManager(fstest.MapFS{
+		"schemas/1.0.0/receiver_otlp.yaml":   {Data: []byte("type: object\nproperties:\n  endpoint:\n    type: string\n    default: localhost:4317\n")},
+		"schemas/1.0.0/receiver_otlp.md":     {Data: []byte("# OTLP Receiver\n")},
+		"schemas/1.0.0/exporter_debug.yaml":  {Data: []byte("type: object\n")},
+		"schemas/1.0.0/processor_batch.yaml": {Data: []byte("type: object\nproperties:\n  timeout:\n    type: string\n")},
+	})
+
+	bundle, err := sm.GetSchemaBundle("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, SchemaBundleManifest{
+		Format:     SchemaBundleFormat,
+		Version:    "1.0.0",
+		Components: map[ComponentType]int{ComponentTypeReceiver: 1, ComponentTypeProcessor: 1, ComponentTypeExporter: 1},
+		Readmes:    1,
+	}, bundle.Manifest)
+	assert.Contains(t, bundle.ConfigSchema["definitions"], "receiver_otlp")
+
+	// The components are in the order of the pipeline stages
+	require.Len(t, bundle.Components, 3)
+	assert.Equal(t, []string{"otlp", "batch", "debug"}, []string{bundle.Components[0].Name, bundle.Components[1].Name, bundle.Components[2].Name})
+	assert.Equal(t, "# OTLP Receiver\n", bundle.Components[0].Readme)
+	assert.Equal(t, map[string]interface{}{"endpoint": "localhost:4317"}, bundle.Components[0].Defaults)
+	assert.Equal(t, "object", bundle.Components[1].Schema["type"])
+	assert.Empty(t, bundle.Components[1].Defaults)
+
+	_, err = sm.GetSchemaBundle("2.0.0")
+	assert.Error(t, err)
+}
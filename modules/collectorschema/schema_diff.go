@@ -0,0 +1,238 @@
+package collectorschema
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of the semantic changes of a field between two schemas of a component
+const (
+	FieldChangeAdded       = "added"
+	FieldChangeRemoved     = "removed"
+	FieldChangeType        = "type"
+	FieldChangeDefault     = "default"
+	FieldChangeDeprecated  = "deprecated"
+	FieldChangeConstraint  = "constraint"
+	FieldChangeDescription = "description"
+)
+
+// Statuses of the files of two schema directories
+const (
+	SchemaFileAdded   = "added"
+	SchemaFileRemoved = "removed"
+	SchemaFileChanged = "changed"
+)
+
+// constraintKeywords are the keywords restricting the values of a field
+var constraintKeywords = []string{"pattern", "format", "enum", "const", "minimum", "maximum", "additionalProperties"}
+
+// FieldChange is a semantic change of a field between two schemas, the field is empty for the root of the schema
+type FieldChange struct {
+	Field string      `json:"field"`
+	Kind  string      `json:"kind"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+func (c FieldChange) String() string {
+	switch c.Kind {
+	case FieldChangeAdded, FieldChangeRemoved:
+		return fmt.Sprintf("%s: %s", c.Field, c.Kind)
+	case FieldChangeDescription:
+		return fmt.Sprintf("%s: description changed", c.Field)
+	}
+	return fmt.Sprintf("%s: %s %v -> %v", c.Field, c.Kind, c.Old, c.New)
+}
+
+// SchemaFileDiff are the changes of a file between two schema directories, the changes of the files other than the
+// schemas e.g. READMEs are not listed
+type SchemaFileDiff struct {
+	File    string        `json:"file"`
+	Status  string        `json:"status"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// DiffSchemas returns the semantic changes between two schemas sorted by field. The order of the keys, the formatting
+// and the representation of equal values e.g. 1 and 1.0 are ignored, so a regenerated schema only differs by the
+// changes of the component configuration.
+func DiffSchemas(oldSchema, newSchema map[string]interface{}) []FieldChange {
+	oldFields := schemaFields(oldSchema)
+	newFields := schemaFields(newSchema)
+
+	var changes []FieldChange
+	for _, field := range sortedKeys(oldFields) {
+		newField, ok := newFields[field]
+		if !ok {
+			changes = append(changes, FieldChange{Field: field, Kind: FieldChangeRemoved})
+			continue
+		}
+		changes = append(changes, diffField(field, oldFields[field], newField)...)
+	}
+	for _, field := range sortedKeys(newFields) {
+		if _, ok := oldFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, Kind: FieldChangeAdded})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// DiffSchemaDirs compares the files of two version directories of schemas e.g. the committed schemas and the
+// regenerated ones, the schemas are compared semantically and the other files by content. Files without changes are
+// not listed. The configuration schema is composed from the component schemas and not compared.
+func DiffSchemaDirs(oldDir, newDir fs.FS) ([]SchemaFileDiff, error) {
+	oldFiles, err := schemaDirFiles(oldDir)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := schemaDirFiles(newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []SchemaFileDiff
+	for _, file := range sortedKeys(oldFiles) {
+		newData, ok := newFiles[file]
+		if !ok {
+			diffs = append(diffs, SchemaFileDiff{File: file, Status: SchemaFileRemoved})
+			continue
+		}
+		oldData := oldFiles[file]
+		if path.Ext(file) != ".yaml" {
+			if !bytes.Equal(oldData, newData) {
+				diffs = append(diffs, SchemaFileDiff{File: file, Status: SchemaFileChanged})
+			}
+			continue
+		}
+		var oldSchema, newSchema map[string]interface{}
+		if err := yaml.Unmarshal(oldData, &oldSchema); err != nil {
+			return nil, fmt.Errorf("failed to parse schema %s: %w", file, err)
+		}
+		if err := yaml.Unmarshal(newData, &newSchema); err != nil {
+			return nil, fmt.Errorf("failed to parse schema %s: %w", file, err)
+		}
+		if changes := DiffSchemas(oldSchema, newSchema); len(changes) > 0 {
+			diffs = append(diffs, SchemaFileDiff{File: file, Status: SchemaFileChanged, Changes: changes})
+		}
+	}
+	for _, file := range sortedKeys(newFiles) {
+		if _, ok := oldFiles[file]; !ok {
+			diffs = append(diffs, SchemaFileDiff{File: file, Status: SchemaFileAdded})
+		}
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].File < diffs[j].File
+	})
+	return diffs, nil
+}
+
+// schemaFields returns the schemas of the fields of a schema by path e.g. tls.insecure, the root is the empty path
+func schemaFields(schema map[string]interface{}) map[string]map[string]interface{} {
+	fields := map[string]map[string]interface{}{"": schema}
+	walkFields(schema, "", func(fieldPath string, fieldSchema map[string]interface{}) {
+		fields[fieldPath] = fieldSchema
+	})
+	return fields
+}
+
+// diffField returns the changes of the keywords of a field, the nested fields are compared separately
+func diffField(field string, oldField, newField map[string]interface{}) []FieldChange {
+	var changes []FieldChange
+	if oldType, newType := schemaType(oldField), schemaType(newField); oldType != newType {
+		changes = append(changes, FieldChange{Field: field, Kind: FieldChangeType, Old: oldType, New: newType})
+	}
+	if !sameValue(oldField["default"], newField["default"]) {
+		changes = append(changes, FieldChange{Field: field, Kind: FieldChangeDefault, Old: oldField["default"], New: newField["default"]})
+	}
+	if isDeprecated(oldField) != isDeprecated(newField) {
+		changes = append(changes, FieldChange{Field: field, Kind: FieldChangeDeprecated, Old: isDeprecated(oldField), New: isDeprecated(newField)})
+	}
+	for _, keyword := range constraintKeywords {
+		oldValue, newValue := oldField[keyword], newField[keyword]
+		// The schemas of the map values are compared by type, their fields are not walked
+		if oldMap, ok := oldValue.(map[string]interface{}); ok {
+			oldValue = schemaType(oldMap)
+		}
+		if newMap, ok := newValue.(map[string]interface{}); ok {
+			newValue = schemaType(newMap)
+		}
+		if !sameValue(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Kind: FieldChangeConstraint, Old: constraintValue(keyword, oldValue), New: constraintValue(keyword, newValue)})
+		}
+	}
+	if oldField["description"] != newField["description"] {
+		changes = append(changes, FieldChange{Field: field, Kind: FieldChangeDescription, Old: oldField["description"], New: newField["description"]})
+	}
+	return changes
+}
+
+// constraintValue returns a constraint prefixed with its keyword e.g. pattern=^[0-9]+s$, nil if it is not set
+func constraintValue(keyword string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return fmt.Sprintf("%s=%v", keyword, value)
+}
+
+// sameValue compares two schema values ignoring the numeric type e.g. 1 and 1.0 and the int types of YAML and JSON
+func sameValue(a, b interface{}) bool {
+	return reflect.DeepEqual(normalizeValue(a), normalizeValue(b))
+}
+
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeValue(item)
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeValue(item)
+		}
+		return normalized
+	}
+	return value
+}
+
+// schemaDirFiles returns the content of the files of a schema directory by slash separated path
+func schemaDirFiles(dir fs.FS) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := fs.WalkDir(dir, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || filePath == ConfigSchemaFile {
+			return nil
+		}
+		data, err := fs.ReadFile(dir, filePath)
+		if err != nil {
+			return err
+		}
+		files[filePath] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema directory: %w", err)
+	}
+	return files, nil
+}
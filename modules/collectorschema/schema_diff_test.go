@@ -0,0 +1,78 @@
+package collectorschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	oldSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"endpoint": map[string]interface{}{"type": "string"},
+			"timeout":  map[string]interface{}{"type": "string", "default": "5s"},
+			"tls": map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+				"insecure": map[string]interface{}{"type": "boolean", "default": false},
+				"ca_file":  map[string]interface{}{"type": "string"},
+			}},
+			"queue_size": map[string]interface{}{"type": "integer", "default": 1000},
+		},
+	}
+	newSchema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"endpoint": map[string]interface{}{"type": "string", "pattern": "^.+:[0-9]+$"},
+			"timeout":  map[string]interface{}{"type": "string", "default": "10s", "deprecated": true},
+			"tls": map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+				"insecure": map[string]interface{}{"type": "boolean", "default": false},
+				"ca_pem":   map[string]interface{}{"type": "string"},
+			}},
+			"queue_size": map[string]interface{}{"type": "integer", "default": 1000.0},
+		},
+		"type": "object",
+	}
+
+	changes := DiffSchemas(oldSchema, newSchema)
+	assert.Equal(t, []FieldChange{
+		{Field: "endpoint", Kind: FieldChangeConstraint, New: "pattern=^.+:[0-9]+$"},
+		{Field: "timeout", Kind: FieldChangeDefault, Old: "5s", New: "10s"},
+		{Field: "timeout", Kind: FieldChangeDeprecated, Old: false, New: true},
+		{Field: "tls.ca_file", Kind: FieldChangeRemoved},
+		{Field: "tls.ca_pem", Kind: FieldChangeAdded},
+	}, changes)
+	assert.Equal(t, "timeout: default 5s -> 10s", changes[1].String())
+
+	assert.Empty(t, DiffSchemas(oldSchema, oldSchema))
+}
+
+func TestDiffSchemaDirs(t *testing.T) {
+	oldDir := fstest.MapFS{
+		"receiver_otlp.yaml":  {Data: []byte("type: object\nproperties:\n  endpoint:\n    type: string\n")},
+		"receiver_otlp.md":    {Data: []byte("# OTLP receiver\n")},
+		"exporter_debug.yaml": {Data: []byte("type: object\n")},
+		"core/retry.yaml":     {Data: []byte("type: object\n")},
+		ConfigSchemaFile:      {Data: []byte("{}")},
+	}
+	newDir := fstest.MapFS{
+		// The key order and the indentation differ without a semantic change
+		"receiver_otlp.yaml":  {Data: []byte("properties:\n    endpoint:\n        type: string\ntype: object\n")},
+		"receiver_otlp.md":    {Data: []byte("# OTLP receiver\n\nUpdated.\n")},
+		"exporter_debug.yaml": {Data: []byte("type: object\nproperties:\n  verbosity:\n    type: string\n")},
+		"exporter_file.yaml":  {Data: []byte("type: object\n")},
+	}
+
+	diffs, err := DiffSchemaDirs(oldDir, newDir)
+	require.NoError(t, err)
+	assert.Equal(t, []SchemaFileDiff{
+		{File: "core/retry.yaml", Status: SchemaFileRemoved},
+		{File: "exporter_debug.yaml", Status: SchemaFileChanged, Changes: []FieldChange{{Field: "verbosity", Kind: FieldChangeAdded}}},
+		{File: "exporter_file.yaml", Status: SchemaFileAdded},
+		{File: "receiver_otlp.md", Status: SchemaFileChanged},
+	}, diffs)
+
+	diffs, err = DiffSchemaDirs(oldDir, oldDir)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
@@ -0,0 +1,88 @@
+package collectorschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SchemaStats are the field counts and the size of the schema of a component in a version
+type SchemaStats struct {
+	Version string `json:"version"`
+	// Fields are all fields including the nested ones e.g. tls.insecure
+	Fields         int `json:"fields"`
+	TopLevelFields int `json:"topLevelFields"`
+	Required       int `json:"required"`
+	Deprecated     int `json:"deprecated"`
+	// MaxDepth is the nesting depth of the deepest field, 1 for the top-level fields
+	MaxDepth int `json:"maxDepth"`
+	// SchemaBytes is the size of the schema as JSON
+	SchemaBytes int `json:"schemaBytes"`
+	// SummaryBytes is the size of the component summary as JSON
+	SummaryBytes int `json:"summaryBytes"`
+}
+
+// ComponentSchemaStats are the schema stats of a component in the versions including it
+type ComponentSchemaStats struct {
+	Component string        `json:"component"`
+	Versions  []SchemaStats `json:"versions"`
+}
+
+// GetSchemaStats returns the field counts and the size of the schema of a component in a version, so clients decide
+// whether to fetch the full schema or the summary
+func (sm *SchemaManager) GetSchemaStats(componentType ComponentType, componentName string, version string) (*SchemaStats, error) {
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+	schemaJSON, err := json.Marshal(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema of %s %s v%s: %w", componentType, componentName, version, err)
+	}
+	summary, err := sm.GetComponentSummary(componentType, componentName, version)
+	if err != nil {
+		return nil, err
+	}
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal summary of %s %s v%s: %w", componentType, componentName, version, err)
+	}
+
+	stats := &SchemaStats{
+		Version:      version,
+		Required:     len(stringValues(schema.Schema["required"])),
+		SchemaBytes:  len(schemaJSON),
+		SummaryBytes: len(summaryJSON),
+	}
+	walkFields(schema.Schema, "", func(fieldPath string, fieldSchema map[string]interface{}) {
+		stats.Fields++
+		depth := strings.Count(fieldPath, ".") + 1
+		if depth == 1 {
+			stats.TopLevelFields++
+		}
+		stats.MaxDepth = max(stats.MaxDepth, depth)
+		stats.Required += len(stringValues(fieldSchema["required"]))
+		if isDeprecated(fieldSchema) {
+			stats.Deprecated++
+		}
+	})
+	return stats, nil
+}
+
+// GetComponentSchemaStats returns the schema stats of a component in every version including it in ascending order,
+// to track the growth of the schema across versions
+func (sm *SchemaManager) GetComponentSchemaStats(componentType ComponentType, componentName string) (*ComponentSchemaStats, error) {
+	versions := sm.GetComponentVersions(componentType, componentName)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("component %s %s is not available in any version", componentType, componentName)
+	}
+	componentStats := &ComponentSchemaStats{Component: fmt.Sprintf("%s/%s", componentType, componentName)}
+	for _, version := range versions {
+		stats, err := sm.GetSchemaStats(componentType, componentName, version)
+		if err != nil {
+			return nil, err
+		}
+		componentStats.Versions = append(componentStats.Versions, *stats)
+	}
+	return componentStats, nil
+}
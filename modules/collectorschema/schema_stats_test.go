@@ -0,0 +1,59 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSchemaStats(t *testing.T) {
+	sm := NewSchemaManager()
+	sm.cache["receiver_stats_1.0.0"] = &ComponentSchema{Name: "stats", Type: ComponentTypeReceiver, Version: "1.0.0", Schema: map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"endpoint"},
+		"properties": map[string]interface{}{
+			"endpoint": map[string]interface{}{"type": "string"},
+			"legacy":   map[string]interface{}{"type": "string", "deprecated": true},
+			"tls": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"ca_file"},
+				"properties": map[string]interface{}{
+					"ca_file":  map[string]interface{}{"type": "string"},
+					"insecure": map[string]interface{}{"type": "boolean"},
+				},
+			},
+		},
+	}}
+
+	stats, err := sm.GetSchemaStats(ComponentTypeReceiver, "stats", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", stats.Version)
+	assert.Equal(t, 5, stats.Fields)
+	assert.Equal(t, 3, stats.TopLevelFields)
+	assert.Equal(t, 2, stats.Required)
+	assert.Equal(t, 1, stats.Deprecated)
+	assert.Equal(t, 2, stats.MaxDepth)
+	assert.Positive(t, stats.SchemaBytes)
+	assert.Positive(t, stats.SummaryBytes)
+
+	_, err = sm.GetSchemaStats(ComponentTypeReceiver, "nonexistent", "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestGetComponentSchemaStats(t *testing.T) {
+	sm := NewSchemaManager()
+	componentStats, err := sm.GetComponentSchemaStats(ComponentTypeConnector, "failover")
+	require.NoError(t, err)
+	assert.Equal(t, "connector/failover", componentStats.Component)
+	require.Len(t, componentStats.Versions, 4)
+	assert.Equal(t, "0.136.0", componentStats.Versions[0].Version)
+	assert.Equal(t, "0.139.0", componentStats.Versions[3].Version)
+	for _, stats := range componentStats.Versions {
+		assert.Positive(t, stats.Fields)
+		assert.Positive(t, stats.SchemaBytes)
+	}
+
+	_, err = sm.GetComponentSchemaStats(ComponentTypeReceiver, "nonexistent")
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+#!/usr/bin/env bash
+# Reads a collector builder (OCB) manifest of a custom distribution.
+#   distro_manifest.sh manifest <manifest.yaml>  prints the manifest generating the sources into ./build next to the schema generator
+#   distro_manifest.sh version <manifest.yaml>   prints dist.version of the manifest
+set -euo pipefail
+
+if [ $# -ne 2 ]; then
+  echo "usage: $0 manifest|version <manifest.yaml>" >&2
+  exit 1
+fi
+command=$1
+manifest=$2
+
+case "${command}" in
+  manifest)
+    awk '
+      /^dist:/ { print; print "  output_path: ./build"; in_dist = 1; next }
+      /^[^[:space:]#]/ { in_dist = 0 }
+      in_dist && /^[[:space:]]+output_path:/ { next }
+      { print }
+    ' "${manifest}"
+    ;;
+  version)
+    version=$(awk '
+      /^dist:/ { in_dist = 1; next }
+      /^[^[:space:]#]/ { in_dist = 0 }
+      in_dist && /^[[:space:]]+version:/ { gsub(/["'\'']/, "", $2); print $2; exit }
+    ' "${manifest}")
+    if [ -z "${version}" ]; then
+      echo "dist.version is missing in ${manifest}" >&2
+      exit 1
+    fi
+    echo "${version#v}"
+    ;;
+  *)
+    echo "unknown command ${command}, use manifest or version" >&2
+    exit 1
+    ;;
+esac
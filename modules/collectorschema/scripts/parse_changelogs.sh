@@ -0,0 +1,38 @@
+#!/usr/bin/env bash
+# Extracts the changelog of every version in schemas/ from the upstream CHANGELOGs downloaded by make changelogs.
+#   schemas/<version>/changelog-core.md     the release of open-telemetry/opentelemetry-collector e.g. ## v1.45.0/v0.139.0
+#   schemas/<version>/changelog-contrib.md  the release of open-telemetry/opentelemetry-collector-contrib e.g. ## v0.139.0
+set -euo pipefail
+
+core=tmp/opentelemetry-collector-CHANGELOG.md
+contrib=tmp/opentelemetry-collector-CHANGELOG-contrib.md
+
+# extract <changelog> <version> prints the release section of the version up to the next release
+extract() {
+  awk -v version="v$2" '
+    /^## / {
+      if (in_release) { exit }
+      n = split($2, versions, "/")
+      for (i = 1; i <= n; i++) { if (versions[i] == version) { in_release = 1 } }
+    }
+    in_release { print }
+  ' "$1"
+}
+
+for dir in schemas/*/; do
+  version=$(basename "${dir}")
+  for source in core contrib; do
+    changelog=${core}
+    if [ "${source}" = contrib ]; then
+      changelog=${contrib}
+    fi
+    section=$(extract "${changelog}" "${version}")
+    if [ -z "${section}" ]; then
+      echo "no ${source} changelog for ${version}" >&2
+      continue
+    fi
+    printf '%s\n' "${section}" > "${dir}changelog-${source}.md"
+  done
+  # The single changelog of versions generated before core and contrib were stored separately
+  rm -f "${dir}changelog.md"
+done
@@ -0,0 +1,209 @@
+package collectorschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Reasons of a key suggestion
+const (
+	// SuggestionReasonCase is a key differing from a schema property only by case e.g. Endpoint
+	SuggestionReasonCase = "case"
+	// SuggestionReasonSeparator is a key differing from a schema property by separators and case e.g. sendBatchSize
+	// or send-batch-size
+	SuggestionReasonSeparator = "separator"
+	// SuggestionReasonTypo is a key within a small edit distance of a schema property e.g. endpiont
+	SuggestionReasonTypo = "typo"
+)
+
+// KeySuggestion is a configuration key that is not a schema property but resembles one
+type KeySuggestion struct {
+	Path       string `json:"path"`
+	Key        string `json:"key"`
+	Suggestion string `json:"suggestion"`
+	Reason     string `json:"reason"`
+}
+
+func (s KeySuggestion) String() string {
+	return fmt.Sprintf("%s: unknown key %q, did you mean %q?", s.Path, s.Key, s.Suggestion)
+}
+
+// CheckKeySpelling returns the keys of a component configuration JSON that are not schema properties but differ from
+// one only by case, separators or a small edit distance. Permissive schema objects accept these keys, the collector
+// rejects them at startup.
+func (sm *SchemaManager) CheckKeySpelling(componentType ComponentType, componentName string, version string, jsonData []byte) ([]KeySuggestion, error) {
+	componentSchema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema for %s %s v%s: %w", componentType, componentName, version, err)
+	}
+	if err := sm.inputLimits.CheckJSON(jsonData); err != nil {
+		return nil, err
+	}
+	var config interface{}
+	if err := json.Unmarshal(jsonData, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config JSON: %w", err)
+	}
+	return SuggestKeys(componentSchema.Schema, config), nil
+}
+
+// SuggestKeys walks a configuration along its schema and returns the unknown keys resembling a schema property.
+// Unknown keys without a similar property are not reported, the schemas do not cover every setting.
+func SuggestKeys(schema map[string]interface{}, config interface{}) []KeySuggestion {
+	var suggestions []KeySuggestion
+	suggestKeys(schema, config, "", &suggestions)
+	return suggestions
+}
+
+func suggestKeys(schema map[string]interface{}, config interface{}, path string, suggestions *[]KeySuggestion) {
+	switch value := config.(type) {
+	case []interface{}:
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for i, item := range value {
+			suggestKeys(items, item, fmt.Sprintf("%s[%d]", path, i), suggestions)
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		valueSchema, isMap := schema["additionalProperties"].(map[string]interface{})
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			if property, ok := properties[key].(map[string]interface{}); ok {
+				suggestKeys(property, value[key], keyPath, suggestions)
+				continue
+			}
+			if isMap {
+				suggestKeys(valueSchema, value[key], keyPath, suggestions)
+				continue
+			}
+			if suggestion, reason := similarKey(key, properties); suggestion != "" {
+				*suggestions = append(*suggestions, KeySuggestion{Path: keyPath, Key: key, Suggestion: suggestion, Reason: reason})
+			}
+		}
+	}
+}
+
+// UnknownKeys walks a configuration along its schema and returns the paths of the keys that are not schema
+// properties. Objects without properties or accepting additional properties are not checked.
+func UnknownKeys(schema map[string]interface{}, config interface{}) []string {
+	var unknown []string
+	unknownKeys(schema, config, "", &unknown)
+	return unknown
+}
+
+func unknownKeys(schema map[string]interface{}, config interface{}, path string, unknown *[]string) {
+	switch value := config.(type) {
+	case []interface{}:
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for i, item := range value {
+			unknownKeys(items, item, fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		valueSchema, isMap := schema["additionalProperties"].(map[string]interface{})
+		additional, _ := schema["additionalProperties"].(bool)
+		for _, key := range sortedKeys(value) {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			if property, ok := properties[key].(map[string]interface{}); ok {
+				unknownKeys(property, value[key], keyPath, unknown)
+				continue
+			}
+			if isMap {
+				unknownKeys(valueSchema, value[key], keyPath, unknown)
+				continue
+			}
+			if len(properties) > 0 && !additional {
+				*unknown = append(*unknown, keyPath)
+			}
+		}
+	}
+}
+
+// similarKey returns the schema property most similar to an unknown key and the reason, or an empty string
+func similarKey(key string, properties map[string]interface{}) (string, string) {
+	normalizedKey := normalizeKey(key)
+	best, bestDistance := "", maxKeyDistance(normalizedKey)+1
+	for _, property := range sortedKeys(properties) {
+		if strings.EqualFold(key, property) {
+			return property, SuggestionReasonCase
+		}
+		normalizedProperty := normalizeKey(property)
+		if normalizedKey == normalizedProperty {
+			return property, SuggestionReasonSeparator
+		}
+		if distance := editDistance(normalizedKey, normalizedProperty); distance < bestDistance {
+			best, bestDistance = property, distance
+		}
+	}
+	if best == "" {
+		return "", ""
+	}
+	return best, SuggestionReasonTypo
+}
+
+// normalizeKey lowercases a key and removes its separators e.g. send_batch_size and sendBatchSize are sendbatchsize
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+// maxKeyDistance is the edit distance up to which a key is a typo of a property, short keys allow a single edit
+func maxKeyDistance(key string) int {
+	if len(key) <= 5 {
+		return 1
+	}
+	return 2
+}
+
+// editDistance returns the optimal string alignment distance of two strings, the Levenshtein distance counting a
+// transposition of adjacent characters e.g. tsl and tls as a single edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,87 @@
+package collectorschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestKeys(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"send_batch_size": map[string]interface{}{"type": "integer"},
+			"timeout":         map[string]interface{}{"type": "string"},
+			"tls": map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+				"insecure": map[string]interface{}{"type": "boolean"},
+			}},
+			"headers": map[string]interface{}{"type": "object", "additionalProperties": true},
+			"policies": map[string]interface{}{"type": "array", "items": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+			}},
+		},
+	}
+	config := map[string]interface{}{
+		"sendBatchSize": 100,
+		"Timeout":       "1s",
+		"tsl":           map[string]interface{}{},
+		"tls":           map[string]interface{}{"insecrue": true},
+		"headers":       map[string]interface{}{"X-Tenant": "a"},
+		"policies":      []interface{}{map[string]interface{}{"nmae": "errors"}},
+		"unrelated":     true,
+	}
+
+	assert.Equal(t, []KeySuggestion{
+		{Path: "Timeout", Key: "Timeout", Suggestion: "timeout", Reason: SuggestionReasonCase},
+		{Path: "policies[0].nmae", Key: "nmae", Suggestion: "name", Reason: SuggestionReasonTypo},
+		{Path: "sendBatchSize", Key: "sendBatchSize", Suggestion: "send_batch_size", Reason: SuggestionReasonSeparator},
+		{Path: "tls.insecrue", Key: "insecrue", Suggestion: "insecure", Reason: SuggestionReasonTypo},
+		{Path: "tsl", Key: "tsl", Suggestion: "tls", Reason: SuggestionReasonTypo},
+	}, SuggestKeys(schema, config))
+}
+
+func TestUnknownKeys(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"timeout": map[string]interface{}{"type": "string"},
+			"tls": map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+				"insecure": map[string]interface{}{"type": "boolean"},
+			}},
+			"headers":  map[string]interface{}{"type": "object", "additionalProperties": true},
+			"settings": map[string]interface{}{"type": "object"},
+			"policies": map[string]interface{}{"type": "array", "items": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+			}},
+		},
+	}
+	config := map[string]interface{}{
+		"timeout":   "1s",
+		"tls":       map[string]interface{}{"insecure": true, "ca": "ca.pem"},
+		"headers":   map[string]interface{}{"X-Tenant": "a"},
+		"settings":  map[string]interface{}{"anything": 1},
+		"policies":  []interface{}{map[string]interface{}{"name": "errors", "type": "status_code"}},
+		"unrelated": true,
+	}
+
+	assert.Equal(t, []string{"policies[0].type", "tls.ca", "unrelated"}, UnknownKeys(schema, config))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("tls", "tls"))
+	assert.Equal(t, 1, editDistance("tsl", "tls"))
+	assert.Equal(t, 1, editDistance("endpont", "endpoint"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+}
+
+func TestCheckKeySpelling(t *testing.T) {
+	sm := NewSchemaManager()
+	suggestions, err := sm.CheckKeySpelling(ComponentTypeProcessor, "batch", "0.139.0", []byte(`{"sendBatchSize": 100, "timeout": "1s"}`))
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "send_batch_size", suggestions[0].Suggestion)
+	assert.Equal(t, `sendBatchSize: unknown key "sendBatchSize", did you mean "send_batch_size"?`, suggestions[0].String())
+}
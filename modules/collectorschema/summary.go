@@ -0,0 +1,182 @@
+package collectorschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// summaryMaxFields is the number of top-level fields listed in a component summary
+	summaryMaxFields = 10
+	// summaryMaxDefaults is the number of default values listed in a component summary
+	summaryMaxDefaults = 20
+	// summaryMaxDescription is the length in characters of the descriptions in a component summary
+	summaryMaxDescription = 300
+)
+
+// FieldSummary is a top-level field of a component summary
+type FieldSummary struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+}
+
+// ComponentSummary is a concise description of a component configuration, a fraction of the size of its schema
+type ComponentSummary struct {
+	Name        string                 `json:"name"`
+	Type        ComponentType          `json:"type"`
+	Version     string                 `json:"version,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	FieldCount  int                    `json:"fieldCount"`
+	Fields      []FieldSummary         `json:"fields"`
+	Required    []string               `json:"required,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+}
+
+// GetComponentSummary returns the summary of a component summarized from its schema and README
+func (sm *SchemaManager) GetComponentSummary(componentType ComponentType, componentName string, version string) (*ComponentSummary, error) {
+	schema, err := sm.GetComponentSchema(componentType, componentName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema for %s %s v%s: %w", componentType, componentName, version, err)
+	}
+	readme, _ := sm.GetComponentReadme(componentType, componentName, version)
+	summary := SummarizeSchema(schema.Schema, readme)
+	summary.Name, summary.Type, summary.Version = componentName, componentType, version
+	return &summary, nil
+}
+
+// SummarizeSchema summarizes a component schema: the first README paragraph, the top-level fields ranked by
+// required, described and defaulted fields, the required fields and the default values of the shallowest fields
+func SummarizeSchema(schema map[string]interface{}, readme string) ComponentSummary {
+	summary := ComponentSummary{
+		Description: truncateSentence(readmeParagraph(readme), summaryMaxDescription),
+		Required:    stringList(schema["required"]),
+		Defaults:    make(map[string]interface{}),
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	summary.FieldCount = len(properties)
+	required := make(map[string]bool, len(summary.Required))
+	for _, name := range summary.Required {
+		required[name] = true
+	}
+	for name, property := range properties {
+		fieldSchema, _ := property.(map[string]interface{})
+		description, _ := fieldSchema["description"].(string)
+		summary.Fields = append(summary.Fields, FieldSummary{
+			Name:        name,
+			Type:        fieldType(fieldSchema),
+			Description: truncateSentence(description, summaryMaxDescription),
+			Default:     fieldSchema["default"],
+		})
+	}
+	rank := func(field FieldSummary) int {
+		score := 0
+		if required[field.Name] {
+			score += 4
+		}
+		if field.Description != "" {
+			score += 2
+		}
+		if field.Default != nil {
+			score++
+		}
+		return score
+	}
+	sort.Slice(summary.Fields, func(i, j int) bool {
+		if rank(summary.Fields[i]) != rank(summary.Fields[j]) {
+			return rank(summary.Fields[i]) > rank(summary.Fields[j])
+		}
+		return summary.Fields[i].Name < summary.Fields[j].Name
+	})
+	if len(summary.Fields) > summaryMaxFields {
+		summary.Fields = summary.Fields[:summaryMaxFields]
+	}
+
+	var paths []string
+	defaults := make(map[string]interface{})
+	walkFields(schema, "", func(fieldPath string, fieldSchema map[string]interface{}) {
+		if defaultValue, ok := fieldSchema["default"]; ok {
+			paths = append(paths, fieldPath)
+			defaults[fieldPath] = defaultValue
+		}
+	})
+	sort.Slice(paths, func(i, j int) bool {
+		depthI, depthJ := strings.Count(paths[i], "."), strings.Count(paths[j], ".")
+		if depthI != depthJ {
+			return depthI < depthJ
+		}
+		return paths[i] < paths[j]
+	})
+	for i := 0; i < len(paths) && i < summaryMaxDefaults; i++ {
+		summary.Defaults[paths[i]] = defaults[paths[i]]
+	}
+	return summary
+}
+
+// fieldType returns the JSON schema type of a field e.g. string or array of string
+func fieldType(fieldSchema map[string]interface{}) string {
+	fieldType, _ := fieldSchema["type"].(string)
+	if fieldType == "" {
+		return "object"
+	}
+	if fieldType == "array" {
+		if items, ok := fieldSchema["items"].(map[string]interface{}); ok {
+			if itemType, ok := items["type"].(string); ok {
+				return "array of " + itemType
+			}
+		}
+	}
+	return fieldType
+}
+
+// readmeParagraph returns the first text paragraph of a README skipping headings, tables, badges and code blocks
+func readmeParagraph(readme string) string {
+	var paragraph []string
+	inCode := false
+	for _, line := range strings.Split(readme, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "|") ||
+			strings.HasPrefix(line, "<") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "!") {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	return strings.Join(paragraph, " ")
+}
+
+// truncateSentence shortens a text to its sentences fitting the length, or to the length if the first sentence
+// does not fit
+func truncateSentence(text string, length int) string {
+	if len(text) <= length {
+		return text
+	}
+	if end := strings.LastIndex(text[:length], ". "); end > 0 {
+		return text[:end+1]
+	}
+	return strings.TrimSpace(text[:length]) + "..."
+}
+
+// stringList returns the strings of a YAML or JSON list
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var result []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
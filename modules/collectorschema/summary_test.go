@@ -0,0 +1,56 @@
+package collectorschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeSchema(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"endpoint"},
+		"properties": map[string]interface{}{
+			"endpoint": map[string]interface{}{"type": "string"},
+			"timeout":  map[string]interface{}{"type": "string", "description": "Timeout of the requests. It applies to every retry.", "default": "5s"},
+			"headers":  map[string]interface{}{"type": "object"},
+			"keys":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "default": []interface{}{}},
+			"tls": map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+				"insecure": map[string]interface{}{"type": "boolean", "default": false},
+			}},
+		},
+	}
+	readme := "# Example exporter\n\n| Status |\n| --- |\n\nThe example exporter sends data\nto an example backend.\n\n## Configuration\n"
+
+	summary := SummarizeSchema(schema, readme)
+	assert.Equal(t, "The example exporter sends data to an example backend.", summary.Description)
+	assert.Equal(t, 5, summary.FieldCount)
+	assert.Equal(t, []string{"endpoint"}, summary.Required)
+	names := make([]string, 0, len(summary.Fields))
+	for _, field := range summary.Fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"endpoint", "timeout", "keys", "headers", "tls"}, names)
+	assert.Equal(t, "array of string", summary.Fields[2].Type)
+	assert.Equal(t, map[string]interface{}{"timeout": "5s", "keys": []interface{}{}, "tls.insecure": false}, summary.Defaults)
+}
+
+func TestTruncateSentence(t *testing.T) {
+	assert.Equal(t, "short", truncateSentence("short", 10))
+	assert.Equal(t, "First. Second.", truncateSentence("First. Second. Third sentence.", 20))
+	assert.Equal(t, strings.Repeat("a", 10)+"...", truncateSentence(strings.Repeat("a", 30), 10))
+}
+
+func TestGetComponentSummary(t *testing.T) {
+	sm := NewSchemaManager()
+	summary, err := sm.GetComponentSummary(ComponentTypeProcessor, "batch", "0.139.0")
+	require.NoError(t, err)
+	assert.Equal(t, "batch", summary.Name)
+	assert.NotEmpty(t, summary.Fields)
+	assert.LessOrEqual(t, len(summary.Fields), summaryMaxFields)
+
+	_, err = sm.GetComponentSummary(ComponentTypeProcessor, "missing", "0.139.0")
+	assert.Error(t, err)
+}
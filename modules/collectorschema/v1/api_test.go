@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update records the additions to the API: go test ./v1 -run TestAPICompatibility -update
+var update = flag.Bool("update", false, "record the exported API in testdata/api.txt")
+
+const apiFile = "testdata/api.txt"
+
+// TestAPICompatibility fails when a declaration of the recorded API is removed or changed, additions are recorded
+// with -update
+func TestAPICompatibility(t *testing.T) {
+	current := exportedAPI(t)
+	if *update {
+		require.NoError(t, os.WriteFile(apiFile, []byte(strings.Join(current, "\n")+"\n"), 0o644))
+	}
+	data, err := os.ReadFile(apiFile)
+	require.NoError(t, err)
+	recorded := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	declared := make(map[string]bool, len(current))
+	for _, declaration := range current {
+		declared[declaration] = true
+	}
+	for _, declaration := range recorded {
+		assert.True(t, declared[declaration], "the v1 API must not remove or change %q, deprecate it and add the replacement instead", declaration)
+	}
+	assert.Equal(t, recorded, current, "the v1 API has new declarations, record them with -update")
+}
+
+// exportedAPI returns a line per exported declaration of the package: the types, struct fields with their JSON tags,
+// interface methods, functions, methods and constants
+func exportedAPI(t *testing.T) []string {
+	t.Helper()
+	fileSet := token.NewFileSet()
+	fileNames, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	var files []*ast.File
+	for _, fileName := range fileNames {
+		if strings.HasSuffix(fileName, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fileSet, fileName, nil, 0)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+
+	node := func(n ast.Node) string {
+		var buf bytes.Buffer
+		require.NoError(t, printer.Fprint(&buf, fileSet, n))
+		return strings.Join(strings.Fields(buf.String()), " ")
+	}
+	var api []string
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if !decl.Name.IsExported() {
+					continue
+				}
+				receiver := ""
+				if decl.Recv != nil {
+					receiver = "(" + node(decl.Recv.List[0].Type) + ") "
+					if !exportedReceiver(decl.Recv.List[0].Type) {
+						continue
+					}
+				}
+				api = append(api, fmt.Sprintf("func %s%s%s", receiver, decl.Name.Name, strings.TrimPrefix(node(decl.Type), "func")))
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if spec.Name.IsExported() {
+							api = append(api, typeAPI(spec, node)...)
+						}
+					case *ast.ValueSpec:
+						for i, name := range spec.Names {
+							if name.IsExported() {
+								api = append(api, fmt.Sprintf("%s %s %s = %s", decl.Tok, name.Name, node(spec.Type), node(spec.Values[i])))
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(api)
+	return api
+}
+
+func typeAPI(spec *ast.TypeSpec, node func(ast.Node) string) []string {
+	switch typ := spec.Type.(type) {
+	case *ast.StructType:
+		api := []string{fmt.Sprintf("type %s struct", spec.Name.Name)}
+		for _, field := range typ.Fields.List {
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					continue
+				}
+				declaration := fmt.Sprintf("field %s.%s %s", spec.Name.Name, name.Name, node(field.Type))
+				if field.Tag != nil {
+					declaration += " " + field.Tag.Value
+				}
+				api = append(api, declaration)
+			}
+		}
+		return api
+	case *ast.InterfaceType:
+		api := []string{fmt.Sprintf("type %s interface", spec.Name.Name)}
+		for _, method := range typ.Methods.List {
+			api = append(api, fmt.Sprintf("method %s.%s%s", spec.Name.Name, method.Names[0].Name, strings.TrimPrefix(node(method.Type), "func")))
+		}
+		return api
+	default:
+		return []string{fmt.Sprintf("type %s %s", spec.Name.Name, node(spec.Type))}
+	}
+}
+
+func exportedReceiver(receiver ast.Expr) bool {
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		receiver = star.X
+	}
+	ident, ok := receiver.(*ast.Ident)
+	return ok && ident.IsExported()
+}
@@ -0,0 +1,22 @@
+// Package v1 is the stable API of the collector schemas for Go programs outside of this repository, e.g. other MCP
+// servers and CI tools.
+//
+// The root collectorschema package serves the MCP server of this repository and changes with it: its result structs
+// gain, rename and remove fields and its methods change their signatures when the server needs it. This package
+// freezes a subset of it. The SchemaManager interface and the result structs are defined here and converted from the
+// root package, so a refactor of the root package is absorbed by the conversion instead of breaking the consumers.
+//
+// Compatibility guarantees of v1:
+//
+//   - Exported types, functions, methods, constants, struct fields and JSON names are not removed, renamed or changed.
+//   - New methods may be added to SchemaManager and new fields to the result structs. Implementations of SchemaManager
+//     outside of this package e.g. test doubles should embed a SchemaManager to keep compiling.
+//   - Error strings are not part of the API, match errors with errors.As and the error types of this package.
+//
+// Deprecation policy: an API to be replaced is marked with a "Deprecated:" comment naming its replacement and is kept
+// in v1. It is only removed in a new major package, e.g. v2, which is added next to v1 and served in parallel for at
+// least two minor releases of the module.
+//
+// The exported API is recorded in testdata/api.txt, TestAPICompatibility fails when a recorded declaration is removed
+// or changed.
+package v1
@@ -0,0 +1,307 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ComponentType is the type of a collector component
+type ComponentType string
+
+const (
+	ComponentTypeReceiver  ComponentType = "receiver"
+	ComponentTypeProcessor ComponentType = "processor"
+	ComponentTypeExporter  ComponentType = "exporter"
+	ComponentTypeExtension ComponentType = "extension"
+	ComponentTypeConnector ComponentType = "connector"
+)
+
+// SchemaManager serves the schemas, READMEs and changelogs of the collector components by collector version e.g.
+// 0.139.0
+type SchemaManager interface {
+	// GetAllVersions returns the collector versions with schemas
+	GetAllVersions() ([]string, error)
+	// GetLatestVersion returns the latest collector version with schemas
+	GetLatestVersion() (string, error)
+	// ListAvailableComponents returns the component names of a version by component type
+	ListAvailableComponents(version string) (map[ComponentType][]string, error)
+	// GetComponentSchema returns the JSON schema of a component configuration
+	GetComponentSchema(componentType ComponentType, componentName string, version string) (*ComponentSchema, error)
+	// GetComponentSummary returns the description, top fields, required fields and defaults of a component
+	GetComponentSummary(componentType ComponentType, componentName string, version string) (*ComponentSummary, error)
+	// GetComponentReadme returns the README of a component
+	GetComponentReadme(componentType ComponentType, componentName string, version string) (string, error)
+	// GetDeprecatedFields returns the deprecated fields of a component
+	GetDeprecatedFields(componentType ComponentType, componentName string, version string) ([]DeprecatedField, error)
+	// GetFieldDefaults returns the default values of the component fields by their dotted path e.g. timeout
+	GetFieldDefaults(componentType ComponentType, componentName string, version string) (map[string]interface{}, error)
+	// ValidateComponentYAML validates a component configuration YAML against its schema
+	ValidateComponentYAML(componentType ComponentType, componentName string, version string, yamlData []byte) (*ValidationResult, error)
+	// ValidateConfigYAML validates a full collector configuration YAML against the config schema of the version
+	ValidateConfigYAML(version string, yamlData []byte) (*ValidationResult, error)
+	// GetChangelog returns the merged core and contrib changelog of a version
+	GetChangelog(version string) (string, error)
+	// QueryDocumentation searches the documentation of a version, the search index is built by the first search
+	QueryDocumentation(query string, version string, maxResults int) ([]SearchResult, error)
+}
+
+// ComponentSchema is the JSON schema of a component configuration
+type ComponentSchema struct {
+	Name    string                 `json:"name"`
+	Type    ComponentType          `json:"type"`
+	Version string                 `json:"version"`
+	Schema  map[string]interface{} `json:"schema"`
+}
+
+// ComponentSummary is a concise description of a component configuration
+type ComponentSummary struct {
+	Name        string                 `json:"name"`
+	Type        ComponentType          `json:"type"`
+	Version     string                 `json:"version"`
+	Description string                 `json:"description,omitempty"`
+	FieldCount  int                    `json:"fieldCount"`
+	Fields      []FieldSummary         `json:"fields"`
+	Required    []string               `json:"required,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+}
+
+// FieldSummary is a field of a component summary
+type FieldSummary struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+}
+
+// DeprecatedField is a deprecated field of a component
+type DeprecatedField struct {
+	// Name is the dotted path of the field e.g. protocols.grpc.endpoint
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	// ReplacedBy is the dotted path of the replacement field, empty if it is unknown
+	ReplacedBy string `json:"replacedBy,omitempty"`
+	// Migration is a YAML snippet moving the field to its replacement, empty if the replacement is unknown
+	Migration string `json:"migration,omitempty"`
+}
+
+// ValidationResult is the result of a schema validation
+type ValidationResult struct {
+	Valid  bool              `json:"valid"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+// ValidationError is a violation of the schema
+type ValidationError struct {
+	// Field is the dotted path of the invalid value, (root) for the document
+	Field string `json:"field"`
+	// Type is the violated JSON schema rule e.g. required, invalid_type or additional_property_not_allowed
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// SearchResult is a documentation search result
+type SearchResult struct {
+	ID       string            `json:"id"`
+	Content  string            `json:"content"`
+	Score    float32           `json:"score"`
+	Version  string            `json:"version,omitempty"`
+	FilePath string            `json:"filePath,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ComponentNotFoundError is returned for a component without a schema in the version
+type ComponentNotFoundError struct {
+	ComponentType ComponentType
+	ComponentName string
+	Version       string
+	// Versions are the versions including the component in ascending order
+	Versions []string
+	// Suggestions are the names of the components of the type closest to the name, set if no version includes it
+	Suggestions []string
+	err         error
+}
+
+func (e *ComponentNotFoundError) Error() string {
+	return e.err.Error()
+}
+
+// New returns a SchemaManager serving the schemas embedded in the module
+func New() SchemaManager {
+	return Wrap(collectorschema.NewSchemaManager())
+}
+
+// NewFromDir returns a SchemaManager serving the schemas of a directory with a directory per version, e.g. the schemas
+// of a custom distribution
+func NewFromDir(dir string) (SchemaManager, error) {
+	sm, err := collectorschema.NewSchemaManagerFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(sm), nil
+}
+
+// Wrap returns the SchemaManager of a configured collectorschema.SchemaManager e.g. with an embedding function or a
+// content cache
+func Wrap(sm *collectorschema.SchemaManager) SchemaManager {
+	return &schemaManager{sm: sm}
+}
+
+// schemaManager converts the results of the collectorschema package to the frozen types
+type schemaManager struct {
+	sm *collectorschema.SchemaManager
+}
+
+var _ SchemaManager = (*schemaManager)(nil)
+
+func (m *schemaManager) GetAllVersions() ([]string, error) {
+	return m.sm.GetAllVersions()
+}
+
+func (m *schemaManager) GetLatestVersion() (string, error) {
+	return m.sm.GetLatestVersion()
+}
+
+func (m *schemaManager) ListAvailableComponents(version string) (map[ComponentType][]string, error) {
+	components, err := m.sm.ListAvailableComponents(version)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	converted := make(map[ComponentType][]string, len(components))
+	for componentType, names := range components {
+		converted[ComponentType(componentType)] = names
+	}
+	return converted, nil
+}
+
+func (m *schemaManager) GetComponentSchema(componentType ComponentType, componentName string, version string) (*ComponentSchema, error) {
+	schema, err := m.sm.GetComponentSchema(collectorschema.ComponentType(componentType), componentName, version)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return &ComponentSchema{
+		Name:    schema.Name,
+		Type:    ComponentType(schema.Type),
+		Version: version,
+		Schema:  schema.Schema,
+	}, nil
+}
+
+func (m *schemaManager) GetComponentSummary(componentType ComponentType, componentName string, version string) (*ComponentSummary, error) {
+	summary, err := m.sm.GetComponentSummary(collectorschema.ComponentType(componentType), componentName, version)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	fields := make([]FieldSummary, 0, len(summary.Fields))
+	for _, field := range summary.Fields {
+		fields = append(fields, FieldSummary{Name: field.Name, Type: field.Type, Description: field.Description, Default: field.Default})
+	}
+	return &ComponentSummary{
+		Name:        summary.Name,
+		Type:        ComponentType(summary.Type),
+		Version:     version,
+		Description: summary.Description,
+		FieldCount:  summary.FieldCount,
+		Fields:      fields,
+		Required:    summary.Required,
+		Defaults:    summary.Defaults,
+	}, nil
+}
+
+func (m *schemaManager) GetComponentReadme(componentType ComponentType, componentName string, version string) (string, error) {
+	readme, err := m.sm.GetComponentReadme(collectorschema.ComponentType(componentType), componentName, version)
+	return readme, convertError(err)
+}
+
+func (m *schemaManager) GetDeprecatedFields(componentType ComponentType, componentName string, version string) ([]DeprecatedField, error) {
+	deprecated, err := m.sm.GetDeprecatedFields(collectorschema.ComponentType(componentType), componentName, version)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	fields := make([]DeprecatedField, 0, len(deprecated))
+	for _, field := range deprecated {
+		fields = append(fields, DeprecatedField{
+			Name:        field.Name,
+			Description: field.Description,
+			Type:        field.Type,
+			ReplacedBy:  field.ReplacedBy,
+			Migration:   field.Migration,
+		})
+	}
+	return fields, nil
+}
+
+func (m *schemaManager) GetFieldDefaults(componentType ComponentType, componentName string, version string) (map[string]interface{}, error) {
+	defaults, err := m.sm.GetFieldDefaults(collectorschema.ComponentType(componentType), componentName, version)
+	return defaults, convertError(err)
+}
+
+func (m *schemaManager) ValidateComponentYAML(componentType ComponentType, componentName string, version string, yamlData []byte) (*ValidationResult, error) {
+	result, err := m.sm.ValidateComponentYAML(collectorschema.ComponentType(componentType), componentName, version, yamlData)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return convertValidationResult(result), nil
+}
+
+func (m *schemaManager) ValidateConfigYAML(version string, yamlData []byte) (*ValidationResult, error) {
+	result, err := m.sm.ValidateConfigYAML(version, yamlData)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return convertValidationResult(result), nil
+}
+
+func (m *schemaManager) GetChangelog(version string) (string, error) {
+	return m.sm.GetChangelog(version)
+}
+
+func (m *schemaManager) QueryDocumentation(query string, version string, maxResults int) ([]SearchResult, error) {
+	documents, err := m.sm.QueryDocumentation(query, version, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, len(documents))
+	for _, document := range documents {
+		results = append(results, SearchResult{
+			ID:       document.ID,
+			Content:  document.Content,
+			Score:    document.Score,
+			Version:  document.Version,
+			FilePath: document.FilePath,
+			Metadata: document.Metadata,
+		})
+	}
+	return results, nil
+}
+
+// convertValidationResult converts a JSON schema validation result, the validator is not part of the API
+func convertValidationResult(result *gojsonschema.Result) *ValidationResult {
+	converted := &ValidationResult{Valid: result.Valid()}
+	for _, resultError := range result.Errors() {
+		converted.Errors = append(converted.Errors, ValidationError{
+			Field:       resultError.Field(),
+			Type:        resultError.Type(),
+			Description: resultError.Description(),
+		})
+	}
+	return converted
+}
+
+// convertError converts the errors with a type in the API, the other errors are returned as they are
+func convertError(err error) error {
+	var notFound *collectorschema.ComponentNotFoundError
+	if errors.As(err, &notFound) {
+		return &ComponentNotFoundError{
+			ComponentType: ComponentType(notFound.ComponentType),
+			ComponentName: notFound.ComponentName,
+			Version:       notFound.Version,
+			Versions:      notFound.Versions,
+			Suggestions:   notFound.Suggestions,
+			err:           err,
+		}
+	}
+	return err
+}
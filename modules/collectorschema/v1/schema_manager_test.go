@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaManager(t *testing.T) {
+	sm := New()
+
+	versions, err := sm.GetAllVersions()
+	require.NoError(t, err)
+	latestVersion, err := sm.GetLatestVersion()
+	require.NoError(t, err)
+	assert.Contains(t, versions, latestVersion)
+
+	components, err := sm.ListAvailableComponents(latestVersion)
+	require.NoError(t, err)
+	assert.Contains(t, components[ComponentTypeReceiver], "otlp")
+
+	schema, err := sm.GetComponentSchema(ComponentTypeProcessor, "batch", latestVersion)
+	require.NoError(t, err)
+	assert.Equal(t, ComponentSchema{Name: "batch", Type: ComponentTypeProcessor, Version: latestVersion, Schema: schema.Schema}, *schema)
+	assert.Contains(t, schema.Schema["properties"], "timeout")
+
+	summary, err := sm.GetComponentSummary(ComponentTypeProcessor, "batch", latestVersion)
+	require.NoError(t, err)
+	assert.Equal(t, latestVersion, summary.Version)
+	assert.NotEmpty(t, summary.Fields)
+
+	readme, err := sm.GetComponentReadme(ComponentTypeProcessor, "batch", latestVersion)
+	require.NoError(t, err)
+	assert.NotEmpty(t, readme)
+
+	defaults, err := sm.GetFieldDefaults(ComponentTypeProcessor, "batch", latestVersion)
+	require.NoError(t, err)
+	assert.Equal(t, "200ms", defaults["timeout"])
+
+	changelog, err := sm.GetChangelog(latestVersion)
+	require.NoError(t, err)
+	assert.NotEmpty(t, changelog)
+}
+
+func TestSchemaManager_Validate(t *testing.T) {
+	sm := New()
+
+	result, err := sm.ValidateComponentYAML(ComponentTypeProcessor, "batch", "0.139.0", []byte("timeout: 1s\nsend_batch_size: 100\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &ValidationResult{Valid: true}, result)
+
+	result, err = sm.ValidateComponentYAML(ComponentTypeProcessor, "batch", "0.139.0", []byte("send_batch_size: many\n"))
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "send_batch_size", result.Errors[0].Field)
+	assert.Equal(t, "invalid_type", result.Errors[0].Type)
+
+	result, err = sm.ValidateConfigYAML("0.139.0", []byte(`
+receivers:
+  otlp:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`))
+	require.NoError(t, err)
+	assert.True(t, result.Valid, result.Errors)
+}
+
+func TestSchemaManager_ComponentNotFound(t *testing.T) {
+	sm := New()
+
+	_, err := sm.GetComponentSchema(ComponentTypeReceiver, "otlpp", "0.139.0")
+	var notFound *ComponentNotFoundError
+	require.True(t, errors.As(err, &notFound), err)
+	assert.Equal(t, ComponentTypeReceiver, notFound.ComponentType)
+	assert.Equal(t, "otlpp", notFound.ComponentName)
+	assert.Contains(t, notFound.Suggestions, "otlp")
+	assert.Contains(t, err.Error(), "did you mean")
+
+	_, err = sm.GetDeprecatedFields(ComponentTypeReceiver, "otlpp", "0.139.0")
+	assert.True(t, errors.As(err, &notFound), err)
+}
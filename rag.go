@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+var ragCmd = &cobra.Command{
+	Use:   "rag",
+	Short: "Manage the documentation search index",
+}
+
+var ragBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Precompute the documentation embeddings into an index loaded by the server with --rag-index",
+	Long: `Embed the component READMEs, changelog entries and schema fields offline and write a persistent index.
+The server started with --rag-index loads the embeddings instead of indexing the documents at startup,
+only the search queries are embedded at runtime. The embeddings of the openai provider are cached in --cache-dir.`,
+	Example: `  opentelemetry-mcp-server rag build --provider openai --versions 0.138.0,0.139.0 --out ./rag-index`,
+	RunE:    runRAGBuild,
+}
+
+func init() {
+	ragBuildCmd.Flags().StringSlice("versions", nil, "Comma separated collector versions to index, defaults to all versions")
+	ragBuildCmd.Flags().String("provider", collectorschema.EmbeddingProviderSimple, "Embedding provider: simple or openai")
+	ragBuildCmd.Flags().String("model", "", "Embedding model of the openai provider, defaults to text-embedding-3-small")
+	ragBuildCmd.Flags().String("base-url", "", "Base URL of an OpenAI compatible embeddings API e.g. http://localhost:11434/v1 for Ollama")
+	ragBuildCmd.Flags().String("api-key", "", "API key of the openai provider, defaults to the OPENAI_API_KEY environment variable")
+	ragBuildCmd.Flags().Int("concurrency", 8, "Number of documents embedded concurrently")
+	ragBuildCmd.Flags().String("out", "", "Output directory of the index")
+	_ = ragBuildCmd.MarkFlagRequired("out")
+	ragCmd.AddCommand(ragBuildCmd)
+	rootCmd.AddCommand(ragCmd)
+}
+
+func runRAGBuild(cmd *cobra.Command, _ []string) error {
+	versions, _ := cmd.Flags().GetStringSlice("versions")
+	provider, _ := cmd.Flags().GetString("provider")
+	model, _ := cmd.Flags().GetString("model")
+	baseURL, _ := cmd.Flags().GetString("base-url")
+	apiKey, _ := cmd.Flags().GetString("api-key")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	out, _ := cmd.Flags().GetString("out")
+	if apiKey == "" {
+		apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+
+	config := collectorschema.EmbeddingConfig{Provider: provider, Model: model, BaseURL: baseURL, APIKey: apiKey}
+	schemaManager, err := newSchemaManager(cmd)
+	if err != nil {
+		return err
+	}
+	// A rebuild with --cache-dir embeds only the documents changed since the previous build
+	contentCache, err := newCache(cmd)
+	if err != nil {
+		return err
+	}
+	schemaManager.SetContentCache(contentCache)
+	info, err := schemaManager.BuildRAGIndex(cmd.Context(), out, versions, config, concurrency, func(done, total int, message string) {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "[%d/%d] %s\n", done, total, message)
+	})
+	// The embeddings cached before a failure are kept for the next build
+	if closeErr := contentCache.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "indexed %d documents of versions %s with %d dimensions %s embeddings into %s\n",
+		info.Documents, strings.Join(info.Versions, ", "), info.Dimensions, info.Embedding.Provider, out)
+	return err
+}
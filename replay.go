@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/recording"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/mcpserver"
+)
+
+// The rules of the replay findings
+const (
+	ruleChangedResult = "changed-result"
+	ruleUnknownTool   = "unknown-tool"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <recording>",
+	Short: "Re-execute the tool calls recorded by a server started with --record and compare the results",
+	Long: `Re-execute the tool calls of a recording written by a server started with --record against the current build
+and report the results differing from the recorded ones. The calls are replayed in the recorded order in their
+recorded sessions, so the version pins, snapshots and validation profiles of a session apply like in the recording.
+The artifact IDs and the timestamps of the results are ignored.
+
+A recording attached to a report of unexpected agent behavior reproduces the tool results the agent saw, and a set of
+recordings regression tests a schema bundle update against real-world interactions. The tools enabled by server flags
+e.g. --enable-github are not served by the replay, their calls are reported as unknown-tool warnings.`,
+	Example: `  opentelemetry-mcp-server --record session.jsonl
+  opentelemetry-mcp-server replay session.jsonl --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplay,
+}
+
+func init() {
+	addReportFlags(replayCmd, string(report.SeverityError))
+	rootCmd.AddCommand(replayCmd)
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	if err := validateReportFlags(cmd); err != nil {
+		return err
+	}
+	calls, err := recording.Load(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	schemaManager, err := newSchemaManager(cmd)
+	if err != nil {
+		return err
+	}
+	// The session state of the recorded sessions is created again by the replayed calls, the tools enabled by flags
+	// are not served
+	opts := mcpserver.DefaultOptions()
+	opts.SchemaManager = schemaManager
+	opts.CacheDir, _ = cmd.Flags().GetString("cache-dir")
+	opts.CacheSize, _ = cmd.Flags().GetInt64("cache-size")
+	s, err := mcpserver.New(opts)
+	if err != nil {
+		return err
+	}
+
+	findings, err := replayCalls(cmd.Context(), s.MCPServer, calls)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return writeReport(cmd, report.New("replay", path, findings))
+}
+
+// replayCalls re-executes the recorded calls in their sessions and returns the differences of the results as findings
+func replayCalls(ctx context.Context, s *server.MCPServer, calls []recording.Call) ([]report.Finding, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	sessions := make(map[string]*replaySession)
+	var findings []report.Finding
+	for _, call := range calls {
+		tool := s.GetTool(call.Tool)
+		if tool == nil {
+			findings = append(findings, report.Finding{
+				Severity: report.SeverityWarning,
+				Rule:     ruleUnknownTool,
+				Group:    call.Tool,
+				Message:  "the tool is not served by the replay, it was removed or is enabled by a server flag",
+				Line:     call.Line,
+			})
+			continue
+		}
+		session, ok := sessions[call.Session]
+		if !ok {
+			session = &replaySession{id: call.Session, notifications: make(chan mcp.JSONRPCNotification, 100)}
+			sessions[call.Session] = session
+		}
+		request := mcp.CallToolRequest{}
+		request.Params.Name = call.Tool
+		request.Params.Arguments = call.Arguments
+		result, err := tool.Handler(s.WithContext(ctx, session), request)
+		replayed, err := recording.NewCall(call.Session, call.Tool, call.Arguments, result, err)
+		if err != nil {
+			return nil, err
+		}
+		differences, err := recording.Compare(call, replayed)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", call.Line, err)
+		}
+		for _, difference := range differences {
+			findings = append(findings, report.Finding{
+				Severity: report.SeverityError,
+				Rule:     ruleChangedResult,
+				Group:    call.Tool,
+				Path:     difference.Path,
+				Message:  fmt.Sprintf("recorded %s, replayed %s", difference.Recorded, difference.Replayed),
+				Line:     call.Line,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// replaySession is the client session of the replayed calls of a recorded session, the session state of the tools
+// e.g. the pinned version is kept by session ID
+type replaySession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *replaySession) Initialize()       {}
+func (s *replaySession) Initialized() bool { return true }
+func (s *replaySession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+func (s *replaySession) SessionID() string { return s.id }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+)
+
+// addReportFlags adds the output format, color and exit code policy flags of the commands reporting findings
+func addReportFlags(cmd *cobra.Command, failOn string) {
+	cmd.Flags().String("format", report.FormatText, "Output format: "+strings.Join(report.Formats, ", ")+", SARIF is read by code review tools e.g. GitHub code scanning")
+	cmd.Flags().String("color", report.ColorAuto, "Color the text output: auto (terminals unless NO_COLOR is set), always or never")
+	cmd.Flags().String("fail-on", failOn, "Exit with an error on findings of the severity or more severe: "+strings.Join(report.FailOnValues, ", "))
+}
+
+// writeReport writes the report in the format of the flags and returns an error if the exit code policy fails it
+func writeReport(cmd *cobra.Command, r report.Report) error {
+	format, _ := cmd.Flags().GetString("format")
+	colorMode, _ := cmd.Flags().GetString("color")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	color, err := report.UseColor(colorMode, cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	if err := r.Write(cmd.OutOrStdout(), format, color); err != nil {
+		return err
+	}
+	// A failed policy is not a usage error
+	cmd.SilenceUsage = true
+	return r.Failed(failOn)
+}
+
+// validateReportFlags returns an error for an unsupported format or exit code policy before the command runs
+func validateReportFlags(cmd *cobra.Command) error {
+	format, _ := cmd.Flags().GetString("format")
+	if err := report.ValidateFormat(format); err != nil {
+		return err
+	}
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	return report.ValidateFailOn(failOn)
+}
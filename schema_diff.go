@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+var schemaDiffCmd = &cobra.Command{
+	Use:   "schema-diff",
+	Short: "Report the semantic changes between two schema directories of a version",
+	Long: `Compare the committed schemas of a version with regenerated ones and report the added and removed fields and the
+changed types, defaults, deprecations and constraints. The key order and the formatting of the schemas are ignored, so
+schema bundle updates are reviewed by their semantic changes. Removed files and fields and changed types and constraints
+are warnings, the other changes are notes:
+  make schema-diff OCB_VERSION=0.139.0`,
+	RunE: runSchemaDiff,
+}
+
+func init() {
+	schemaDiffCmd.Flags().String("from", "", "Directory of the committed schemas of a version e.g. modules/collectorschema/schemas/0.139.0")
+	schemaDiffCmd.Flags().String("to", "", "Directory of the regenerated schemas of the version")
+	schemaDiffCmd.Flags().Bool("json", false, "Print the changes of the files with the old and new values as JSON")
+	schemaDiffCmd.Flags().Bool("fail-on-change", false, "Exit with an error if the schemas differ e.g. to check in CI that the committed schemas are up to date, same as --fail-on note")
+	addReportFlags(schemaDiffCmd, report.FailNever)
+	_ = schemaDiffCmd.MarkFlagRequired("from")
+	_ = schemaDiffCmd.MarkFlagRequired("to")
+	rootCmd.AddCommand(schemaDiffCmd)
+}
+
+func runSchemaDiff(cmd *cobra.Command, _ []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if failOnChange, _ := cmd.Flags().GetBool("fail-on-change"); failOnChange {
+		_ = cmd.Flags().Set("fail-on", string(report.SeverityNote))
+	}
+	if err := validateReportFlags(cmd); err != nil {
+		return err
+	}
+
+	for _, dir := range []string{from, to} {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return fmt.Errorf("schema directory %s does not exist", dir)
+		}
+	}
+	diffs, err := collectorschema.DiffSchemaDirs(os.DirFS(from), os.DirFS(to))
+	if err != nil {
+		return err
+	}
+
+	diffReport := report.New("schema-diff", to, schemaDiffFindings(to, diffs))
+	if !asJSON {
+		return writeReport(cmd, diffReport)
+	}
+	if diffs == nil {
+		diffs = []collectorschema.SchemaFileDiff{}
+	}
+	diffJSON, err := json.MarshalIndent(diffs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the schema changes: %w", err)
+	}
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(diffJSON)); err != nil {
+		return err
+	}
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	cmd.SilenceUsage = true
+	return diffReport.Failed(failOn)
+}
+
+// breakingChanges are the changes that can reject configurations valid for the old schema, they are warnings and the
+// other changes are notes. Removed covers the removed files as well.
+var breakingChanges = map[string]bool{
+	collectorschema.FieldChangeRemoved:    true,
+	collectorschema.FieldChangeType:       true,
+	collectorschema.FieldChangeConstraint: true,
+}
+
+// schemaDiffFindings returns a finding for each added or removed file and each field change of the directory, grouped
+// by file
+func schemaDiffFindings(dir string, diffs []collectorschema.SchemaFileDiff) []report.Finding {
+	severity := func(change string) report.Severity {
+		if breakingChanges[change] {
+			return report.SeverityWarning
+		}
+		return report.SeverityNote
+	}
+	var findings []report.Finding
+	for _, diff := range diffs {
+		if diff.Status != collectorschema.SchemaFileChanged {
+			findings = append(findings, report.Finding{Severity: severity(diff.Status), Rule: "file-" + diff.Status, Group: diff.File, File: filepath.Join(dir, diff.File), Message: "file " + diff.Status})
+		}
+		for _, change := range diff.Changes {
+			findings = append(findings, report.Finding{
+				Severity: severity(change.Kind),
+				Rule:     "field-" + change.Kind,
+				Group:    diff.File,
+				File:     filepath.Join(dir, diff.File),
+				Path:     change.Field,
+				Message:  strings.TrimPrefix(change.String(), change.Field+": "),
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/searchlog"
+)
+
+var searchReportCmd = &cobra.Command{
+	Use:   "search-report",
+	Short: "Report the failed and low-scoring documentation search queries and the result feedback",
+	Long: `Report the documentation search queries recorded by a server started with --search-log.
+Failed, empty and low-scoring queries and down-voted results show where the chunking, embedding or search weights need tuning.`,
+	RunE: runSearchReport,
+}
+
+func init() {
+	searchReportCmd.Flags().String("search-log", "", "JSON lines file written by the server with --search-log")
+	searchReportCmd.Flags().Float32("min-score", 0.3, "Queries whose best result scored below are reported as low-scoring")
+	searchReportCmd.Flags().Bool("json", false, "Print the report as JSON")
+	_ = searchReportCmd.MarkFlagRequired("search-log")
+	rootCmd.AddCommand(searchReportCmd)
+}
+
+func runSearchReport(cmd *cobra.Command, _ []string) error {
+	path, _ := cmd.Flags().GetString("search-log")
+	minScore, _ := cmd.Flags().GetFloat32("min-score")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	store, err := searchlog.NewStore(path)
+	if err != nil {
+		return err
+	}
+	report := store.Report(minScore)
+	if asJSON {
+		reportJSON, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(reportJSON))
+		return err
+	}
+	return printSearchReport(cmd.OutOrStdout(), report, minScore)
+}
+
+func printSearchReport(out io.Writer, report searchlog.Report, minScore float32) error {
+	fmt.Fprintf(out, "%d queries\n", report.Queries)
+	fmt.Fprintf(out, "\nfailed queries (%d):\n", len(report.Failed))
+	for _, stats := range report.Failed {
+		fmt.Fprintf(out, "  %q x%d: %s\n", stats.Query, stats.Count, stats.Error)
+	}
+	fmt.Fprintf(out, "\nqueries without results (%d):\n", len(report.Empty))
+	for _, stats := range report.Empty {
+		fmt.Fprintf(out, "  %q x%d\n", stats.Query, stats.Count)
+	}
+	fmt.Fprintf(out, "\nqueries scoring below %.2f (%d):\n", minScore, len(report.LowScoring))
+	for _, stats := range report.LowScoring {
+		fmt.Fprintf(out, "  %q x%d: top score %.2f\n", stats.Query, stats.Count, stats.TopScore)
+	}
+	fmt.Fprintf(out, "\nresult feedback (%d):\n", len(report.Feedback))
+	for _, feedback := range report.Feedback {
+		fmt.Fprintf(out, "  %s: %d up, %d down, queries %q\n", feedback.ResultID, feedback.Up, feedback.Down, feedback.Queries)
+	}
+	return nil
+}
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/tools"
+	"github.com/pavolloffay/opentelemetry-mcp-server/mcpserver"
+	"github.com/pavolloffay/opentelemetry-mcp-server/modules/collectorschema"
+)
+
+// update rewrites the golden files with the current output: go test . -run TestServer -update
+var update = flag.Bool("update", false, "update the golden files of the server integration tests")
+
+const integrationTestdata = "testdata/integration"
+
+// toolExamples is the embedded file of the worked examples served by the capabilities tool, it is written from the
+// cases marked as examples
+const toolExamples = "internal/tools/examples.yaml"
+
+const toolExamplesHeader = `# Worked examples of the tools for few-shot prompting, served by the capabilities tool. The outputs are the truncated
+# text results of the cases marked as examples in testdata/integration/cases.yaml, regenerate them with:
+#   go test . -run TestServer -update
+`
+
+// integrationCase is a tool call of testdata/integration/cases.yaml, its output is compared with
+// testdata/integration/<tool>.golden. The text result of an example case is a worked example of the tool.
+type integrationCase struct {
+	Tool      string         `yaml:"tool"`
+	Arguments map[string]any `yaml:"arguments"`
+	Example   bool           `yaml:"example"`
+}
+
+// nullValue matches the null values of the results, a worked example has to show a useful result
+var nullValue = regexp.MustCompile(`\bnull\b`)
+
+// volatileOutput matches the parts of the tool results that differ between runs
+var volatileOutput = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`artifact://[0-9a-f-]+`), "artifact://<id>"},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`), "<time>"},
+}
+
+func newTestServer(t *testing.T) *server.MCPServer {
+	t.Helper()
+	opts := mcpserver.DefaultOptions()
+	opts.ArtifactTTL = time.Minute
+	opts.PolicyPacks = []string{"testdata/integration/policy-pack.yaml"}
+	s, err := mcpserver.New(opts)
+	require.NoError(t, err)
+	return s.MCPServer
+}
+
+func newStdioClient(t *testing.T, s *server.MCPServer) *client.Client {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	stdioServer := server.NewStdioServer(s)
+	stdioServer.SetErrorLogger(log.New(io.Discard, "", 0))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = stdioServer.Listen(ctx, serverReader, serverWriter)
+	}()
+
+	c := client.NewClient(transport.NewIO(clientReader, clientWriter, io.NopCloser(strings.NewReader(""))))
+	require.NoError(t, c.Start(ctx))
+	t.Cleanup(func() {
+		_ = c.Close()
+		cancel()
+		_ = serverReader.Close()
+		_ = serverWriter.Close()
+		<-done
+	})
+	initialize(t, c)
+	return c
+}
+
+func newHTTPClient(t *testing.T, s *server.MCPServer) *client.Client {
+	t.Helper()
+	httpServer := httptest.NewServer(server.NewStreamableHTTPServer(s))
+	c, err := client.NewStreamableHttpClient(httpServer.URL)
+	require.NoError(t, err)
+	require.NoError(t, c.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = c.Close()
+		httpServer.Close()
+	})
+	initialize(t, c)
+	return c
+}
+
+func initialize(t *testing.T, c *client.Client) {
+	t.Helper()
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{Name: "integration-test", Version: "1.0.0"}
+	result, err := c.Initialize(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "otel-mcp-server", result.ServerInfo.Name)
+}
+
+func TestServer(t *testing.T) {
+	transports := map[string]func(*testing.T, *server.MCPServer) *client.Client{
+		"stdio": newStdioClient,
+		"http":  newHTTPClient,
+	}
+	for _, name := range []string{"stdio", "http"} {
+		t.Run(name, func(t *testing.T) {
+			c := transports[name](t, newTestServer(t))
+			listed, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+			require.NoError(t, err)
+
+			t.Run("list", func(t *testing.T) {
+				testToolList(t, listed.Tools)
+			})
+			t.Run("required", func(t *testing.T) {
+				testRequiredArguments(t, c, listed.Tools)
+			})
+			t.Run("call", func(t *testing.T) {
+				testToolCalls(t, c, listed.Tools)
+			})
+		})
+	}
+}
+
+// TestServer_Progress checks the long-running tools stream progress notifications while the call runs
+func TestServer_Progress(t *testing.T) {
+	t.Run("stdio", func(t *testing.T) {
+		c := newStdioClient(t, newTestServer(t))
+		messages := collectProgress(t, c)
+
+		request := callToolRequest("opentelemetry-collector-config-versions-validation", map[string]any{
+			"config":   "receivers:\n  otlp:\n    protocols:\n      grpc: {}\nexporters:\n  debug: {}\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [debug]\n",
+			"versions": []string{"0.138.0", "0.139.0"},
+		})
+		request.Params.Meta = &mcp.Meta{ProgressToken: "progress"}
+		result, err := c.CallTool(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+			assert.Equal(collect, []string{"1/2 0.138.0: valid", "2/2 0.139.0: valid"}, messages())
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		// The streamable HTTP server of mcp-go drops the notifications queued when the result is written, indexing the
+		// documentation of a version takes long enough for the notifications of all but the last version to be streamed
+		c := newHTTPClient(t, newTestServer(t))
+		messages := collectProgress(t, c)
+		versions, err := collectorschema.NewSchemaManager().GetAllVersions()
+		require.NoError(t, err)
+
+		request := callToolRequest("opentelemetry-collector-rag", map[string]any{"query": "kafka exporter", "version": "0.139.0"})
+		request.Params.Meta = &mcp.Meta{ProgressToken: "progress"}
+		result, err := c.CallTool(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+			received := messages()
+			if assert.GreaterOrEqual(collect, len(received), len(versions)-1) {
+				assert.Contains(collect, received[0], fmt.Sprintf("1/%d indexed", len(versions)))
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+// TestServer_VersionPinning checks the calls without a version argument use the version pinned by their session and
+// the results report the version they were produced for
+func TestServer_VersionPinning(t *testing.T) {
+	s := newTestServer(t)
+	pinned, other := newHTTPClient(t, s), newHTTPClient(t, s)
+	latestVersion, err := collectorschema.NewSchemaManager().GetLatestVersion()
+	require.NoError(t, err)
+	readme := func(c *client.Client, arguments map[string]any) (string, string) {
+		result, err := c.CallTool(context.Background(), callToolRequest("opentelemetry-collector-readme", arguments))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		require.NotNil(t, result.Meta)
+		return result.Meta.AdditionalFields[tools.VersionMetaKey].(string), result.Meta.AdditionalFields[tools.VersionSourceMetaKey].(string)
+	}
+
+	result, err := pinned.CallTool(context.Background(), callToolRequest("opentelemetry-collector-version-pin", map[string]any{"version": "0.138.0"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	arguments := map[string]any{"kind": "processor", "name": "batch"}
+	version, source := readme(pinned, arguments)
+	assert.Equal(t, []string{"0.138.0", "pin"}, []string{version, source})
+	version, source = readme(pinned, map[string]any{"kind": "processor", "name": "batch", "version": "0.139.0"})
+	assert.Equal(t, []string{"0.139.0", "argument"}, []string{version, source})
+	// The pin belongs to the session
+	version, source = readme(other, arguments)
+	assert.Equal(t, []string{latestVersion, "default"}, []string{version, source})
+
+	result, err = pinned.CallTool(context.Background(), callToolRequest("opentelemetry-collector-version-pin", map[string]any{"clear": true}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	version, source = readme(pinned, arguments)
+	assert.Equal(t, []string{latestVersion, "default"}, []string{version, source})
+}
+
+// collectProgress records the progress notifications of the progress token as done/total message, the returned
+// function returns the recorded notifications
+func collectProgress(t *testing.T, c *client.Client) func() []string {
+	var mutex sync.Mutex
+	var messages []string
+	c.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != "notifications/progress" {
+			return
+		}
+		fields := notification.Params.AdditionalFields
+		assert.Equal(t, "progress", fields["progressToken"])
+		mutex.Lock()
+		defer mutex.Unlock()
+		messages = append(messages, fmt.Sprintf("%v/%v %v", fields["progress"], fields["total"], fields["message"]))
+	})
+	return func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return slices.Clone(messages)
+	}
+}
+
+// testToolList compares the names and the input and output schemas of the tools with the golden file
+func testToolList(t *testing.T, listed []mcp.Tool) {
+	schemas := make(map[string]any, len(listed))
+	for _, tool := range listed {
+		schemas[tool.Name] = map[string]any{
+			"inputSchema":  tool.InputSchema,
+			"outputSchema": tool.OutputSchema,
+		}
+	}
+	got, err := json.MarshalIndent(schemas, "", "  ")
+	require.NoError(t, err)
+	assertGolden(t, filepath.Join(integrationTestdata, "tools.golden.json"), string(got)+"\n")
+}
+
+// testRequiredArguments calls each tool without arguments, tools with required arguments have to return an error
+// result instead of failing the call
+func testRequiredArguments(t *testing.T, c *client.Client, listed []mcp.Tool) {
+	for _, tool := range listed {
+		if len(tool.InputSchema.Required) == 0 {
+			continue
+		}
+		t.Run(tool.Name, func(t *testing.T) {
+			result, err := c.CallTool(context.Background(), callToolRequest(tool.Name, map[string]any{}))
+			require.NoError(t, err)
+			assert.True(t, result.IsError, "tool %s has required arguments %v but accepted a call without arguments", tool.Name, tool.InputSchema.Required)
+		})
+	}
+}
+
+// testToolCalls runs the cases and compares the results with the golden files, each tool needs a case
+func testToolCalls(t *testing.T, c *client.Client, listed []mcp.Tool) {
+	casesYAML, err := os.ReadFile(filepath.Join(integrationTestdata, "cases.yaml"))
+	require.NoError(t, err)
+	var cases []integrationCase
+	require.NoError(t, yaml.Unmarshal(casesYAML, &cases))
+
+	tested := make(map[string]bool, len(cases))
+	for _, testCase := range cases {
+		tested[testCase.Tool] = true
+	}
+	var untested []string
+	for _, tool := range listed {
+		if !tested[tool.Name] {
+			untested = append(untested, tool.Name)
+		}
+	}
+	sort.Strings(untested)
+	assert.Empty(t, untested, "add a case of each tool to %s/cases.yaml", integrationTestdata)
+
+	listedTools := make(map[string]mcp.Tool, len(listed))
+	for _, tool := range listed {
+		listedTools[tool.Name] = tool
+	}
+	calls := make(map[string]int)
+	examples := make(map[string][]tools.ToolExample)
+	for _, testCase := range cases {
+		calls[testCase.Tool]++
+		golden := testCase.Tool
+		if calls[testCase.Tool] > 1 {
+			golden = testCase.Tool + "-" + strconv.Itoa(calls[testCase.Tool])
+		}
+		t.Run(golden, func(t *testing.T) {
+			result, err := c.CallTool(context.Background(), callToolRequest(testCase.Tool, testCase.Arguments))
+			require.NoError(t, err)
+			assertOutputSchema(t, listedTools[testCase.Tool], result)
+			assertGolden(t, filepath.Join(integrationTestdata, golden+".golden"), formatResult(t, result))
+			if testCase.Example {
+				examples[testCase.Tool] = append(examples[testCase.Tool], tools.ToolExample{Arguments: testCase.Arguments, Output: exampleOutput(t, result)})
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(toolExamplesHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	require.NoError(t, encoder.Encode(examples))
+	require.NoError(t, encoder.Close())
+	assertGolden(t, toolExamples, buf.String())
+}
+
+// exampleOutput returns the truncated text result of an example case, the example has to succeed without null values
+func exampleOutput(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	output := strings.Join(texts, "\n")
+	for _, volatile := range volatileOutput {
+		output = volatile.pattern.ReplaceAllString(output, volatile.replacement)
+	}
+	output = tools.TruncateExampleOutput(output)
+	assert.False(t, result.IsError, "the example returns an error: %s", output)
+	assert.NotRegexp(t, nullValue, output, "the example returns null values")
+	return output
+}
+
+// assertOutputSchema validates the structured content of a successful result against the output schema of the tool,
+// as clients validating the results do
+func assertOutputSchema(t *testing.T, tool mcp.Tool, result *mcp.CallToolResult) {
+	t.Helper()
+	if result.IsError || result.StructuredContent == nil || tool.OutputSchema.Type == "" {
+		return
+	}
+	schemaJSON, err := json.Marshal(tool.OutputSchema)
+	require.NoError(t, err)
+	contentJSON, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	validation, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schemaJSON), gojsonschema.NewBytesLoader(contentJSON))
+	require.NoError(t, err)
+	for _, validationError := range validation.Errors() {
+		t.Errorf("the structured content of %s does not match its output schema: %s", tool.Name, validationError)
+	}
+}
+
+func callToolRequest(name string, arguments map[string]any) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	return request
+}
+
+// formatResult returns the result as it is compared with the golden file: the error flag, the text content and the
+// structured content
+func formatResult(t *testing.T, result *mcp.CallToolResult) string {
+	var sb strings.Builder
+	if result.IsError {
+		sb.WriteString("error: true\n")
+	}
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			sb.WriteString("--- text\n")
+			sb.WriteString(c.Text)
+			sb.WriteString("\n")
+		case mcp.ResourceLink:
+			sb.WriteString("--- resource link\n")
+			sb.WriteString(c.URI)
+			sb.WriteString("\n")
+		}
+	}
+	if result.StructuredContent != nil {
+		structured, err := json.MarshalIndent(result.StructuredContent, "", "  ")
+		require.NoError(t, err)
+		sb.WriteString("--- structured\n")
+		sb.Write(structured)
+		sb.WriteString("\n")
+	}
+	output := sb.String()
+	for _, volatile := range volatileOutput {
+		output = volatile.pattern.ReplaceAllString(output, volatile.replacement)
+	}
+	return output
+}
+
+func assertGolden(t *testing.T, path string, got string) {
+	t.Helper()
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test . -run TestServer -update to create the golden file")
+	assert.Equal(t, string(want), got, "the output differs from %s, run go test . -run TestServer -update if the change is expected", path)
+}
+
+func TestValidateFlags(t *testing.T) {
+	newCommand := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("no-filesystem", false, "")
+		names := append([]string{}, filesystemFlags...)
+		for name, required := range requiredFlags {
+			names = append(names, name, required)
+		}
+		for _, name := range names {
+			if cmd.Flags().Lookup(name) == nil {
+				cmd.Flags().String(name, "", "")
+			}
+		}
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	assert.NoError(t, validateFlags(newCommand("--no-filesystem")))
+	assert.NoError(t, validateFlags(newCommand("--schemas-dir", "schemas", "--search-log", "search.jsonl")))
+	assert.EqualError(t, validateFlags(newCommand("--no-filesystem", "--snapshot-dir", "snapshots", "--schemas-dir", "schemas")),
+		"--no-filesystem conflicts with --schemas-dir, --snapshot-dir, the flags read or write the local disk")
+	assert.EqualError(t, validateFlags(newCommand("--github-token", "token")), "--github-token requires --enable-github")
+	assert.NoError(t, validateFlags(newCommand("--github-token", "token", "--enable-github", "true")))
+}
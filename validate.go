@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/configcheck"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/report"
+	"github.com/pavolloffay/opentelemetry-mcp-server/internal/validation"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a collector configuration against the configuration schema and the pipeline topology rules",
+	Long: `Validate a collector configuration file against the configuration schema of a version, the pipeline topology and
+the component naming rules and the OTTL statements. The findings are grouped by configuration section and reported as
+colored text, JSON or SARIF for code review tools, --fail-on decides the exit code.`,
+	Example: `  opentelemetry-mcp-server validate --config collector.yaml --version 0.139.0
+  opentelemetry-mcp-server validate --config collector.yaml --format sarif > validate.sarif`,
+	RunE: runValidate,
+}
+
+func init() {
+	validateCmd.Flags().String("config", "", "Collector configuration YAML file")
+	validateCmd.Flags().String("version", "", "OpenTelemetry Collector version e.g. 0.138.0, defaults to the latest version")
+	validateCmd.Flags().String("profile", validation.ProfileCI, "Validation profile: "+strings.Join(validation.Names(), ", ")+", the editor and agent profiles accept ${env:VAR} placeholders")
+	addReportFlags(validateCmd, string(report.SeverityError))
+	_ = validateCmd.MarkFlagRequired("config")
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, _ []string) error {
+	path, _ := cmd.Flags().GetString("config")
+	profileName, _ := cmd.Flags().GetString("profile")
+	if err := validateReportFlags(cmd); err != nil {
+		return err
+	}
+	profile, err := validation.Get(profileName)
+	if err != nil {
+		return err
+	}
+	configYAML, err := loadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	schemaManager, err := newSchemaManager(cmd)
+	if err != nil {
+		return err
+	}
+	version, _ := cmd.Flags().GetString("version")
+	if version == "" {
+		if version, err = schemaManager.GetLatestVersion(); err != nil {
+			return err
+		}
+	}
+	findings, err := configcheck.Validate(schemaManager, configYAML, version, profile)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return writeReport(cmd, report.New("validate", path, findings))
+}
+
+// loadConfigFile reads the collector configuration file
+func loadConfigFile(path string) ([]byte, error) {
+	configYAML, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the configuration: %w", err)
+	}
+	return configYAML, nil
+}